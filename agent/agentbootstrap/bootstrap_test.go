@@ -234,6 +234,7 @@ LXC_BRIDGE="ignored"`[1:])
 		"audit-log-capture-args":    true,
 		"audit-log-max-size":        "200M",
 		"audit-log-max-backups":     5,
+		"audit-log-db-size":         "1M",
 	})
 
 	// Check that controller model configuration has been added, and
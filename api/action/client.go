@@ -36,11 +36,26 @@ func (c *Client) Actions(arg params.Entities) (params.ActionResults, error) {
 }
 
 // Operations fetches the called functions (actions) for specified apps/units.
-func (c *Client) Operations(arg params.OperationQueryArgs) (params.ActionResults, error) {
-	results := params.ActionResults{}
-	if v := c.BestAPIVersion(); v < 5 {
+// Actions run as part of an operation are reported with the operation.
+func (c *Client) Operations(arg params.OperationQueryArgs) (params.OperationResults, error) {
+	results := params.OperationResults{}
+	v := c.BestAPIVersion()
+	if v < 5 {
 		return results, errors.Errorf("Operations not supported by this version (%d) of Juju", v)
 	}
+	if v < 6 {
+		if len(arg.Operations) > 0 {
+			return results, errors.Errorf("filtering by operation not supported by this version (%d) of Juju", v)
+		}
+		// Older controllers report only the actions.
+		actions := params.ActionResults{}
+		err := c.facade.FacadeCall("Operations", arg, &actions)
+		if params.ErrCode(err) == params.CodeNotFound {
+			err = nil
+		}
+		results.Actions = actions.Results
+		return results, err
+	}
 	err := c.facade.FacadeCall("Operations", arg, &results)
 	if params.ErrCode(err) == params.CodeNotFound {
 		err = nil
@@ -62,10 +77,34 @@ func (c *Client) FindActionTagsByPrefix(arg params.FindTags) (params.FindTagsRes
 // Action.
 func (c *Client) Enqueue(arg params.Actions) (params.ActionResults, error) {
 	results := params.ActionResults{}
+	if v := c.BestAPIVersion(); v < 6 {
+		for _, a := range arg.Actions {
+			if a.QueueTimeout != 0 || a.ExecutionTimeout != 0 {
+				return results, errors.Errorf("action timeouts not supported by this version (%d) of Juju", v)
+			}
+		}
+	}
 	err := c.facade.FacadeCall("Enqueue", arg, &results)
 	return results, err
 }
 
+// EnqueueOperation queues up an action to be run on the given units a
+// batch at a time, returning the id of the operation tracking it along
+// with the actions queued for the first batch of units.
+func (c *Client) EnqueueOperation(arg params.EnqueueOperationArgs) (params.EnqueueOperationResult, error) {
+	result := params.EnqueueOperationResult{}
+	if v := c.BestAPIVersion(); v < 6 {
+		return result, errors.Errorf("EnqueueOperation not supported by this version (%d) of Juju", v)
+	}
+	if err := c.facade.FacadeCall("EnqueueOperation", arg, &result); err != nil {
+		return result, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return result, result.Error
+	}
+	return result, nil
+}
+
 // FindActionsByNames takes a list of action names and returns actions for
 // every name.
 func (c *Client) FindActionsByNames(arg params.FindActionsByNames) (params.ActionsByNames, error) {
@@ -163,3 +202,32 @@ func (c *Client) WatchActionProgress(actionId string) (watcher.StringsWatcher, e
 	w := apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result)
 	return w, nil
 }
+
+// WatchActionOutput streams the messages logged by the action with the
+// given id, and the lines it writes to stdout and stderr, as they happen.
+// Messages already logged are sent first; the returned channel is closed
+// once the action has finished or the connection is lost.
+func (c *Client) WatchActionOutput(actionId string) (<-chan params.ActionMessage, error) {
+	if v := c.BestAPIVersion(); v < 6 {
+		return nil, errors.NotSupportedf("WatchActionOutput by this version (%d) of Juju", v)
+	}
+	connection, err := c.facade.RawAPICaller().ConnectStream("/actions/"+actionId+"/output", nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	messages := make(chan params.ActionMessage)
+	go func() {
+		defer close(messages)
+		defer connection.Close()
+
+		for {
+			var msg params.ActionMessage
+			if err := connection.ReadJSON(&msg); err != nil {
+				return
+			}
+			messages <- msg
+		}
+	}()
+	return messages, nil
+}
@@ -5,12 +5,16 @@ package action_test
 
 import (
 	"errors"
+	"io"
+	"net/url"
+	"time"
 
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/api/action"
+	"github.com/juju/juju/api/base"
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/apiserver/params"
 )
@@ -213,6 +217,137 @@ func (s *actionSuite) TestWatchActionProgressNotSupported(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "WatchActionProgress not supported by this version \\(4\\) of Juju")
 }
 
+func (s *actionSuite) TestEnqueueWithTimeouts(c *gc.C) {
+	args := params.Actions{
+		Actions: []params.Action{{
+			Receiver:         "unit-mysql-0",
+			Name:             "backup",
+			ExecutionTimeout: time.Minute,
+		}},
+	}
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				called = true
+				c.Assert(request, gc.Equals, "Enqueue")
+				c.Assert(a, jc.DeepEquals, args)
+				return nil
+			},
+		),
+		BestVersion: 6,
+	}
+	client := action.NewClient(apiCaller)
+	_, err := client.Enqueue(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *actionSuite) TestEnqueueWithTimeoutsNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := action.NewClient(apiCaller)
+	_, err := client.Enqueue(params.Actions{
+		Actions: []params.Action{{
+			Receiver:     "unit-mysql-0",
+			Name:         "backup",
+			QueueTimeout: time.Minute,
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, "action timeouts not supported by this version \\(5\\) of Juju")
+}
+
+func (s *actionSuite) TestEnqueueOperation(c *gc.C) {
+	args := params.EnqueueOperationArgs{
+		Receivers:     []string{"application-mysql"},
+		Name:          "restart",
+		BatchSize:     2,
+		LeaderFirst:   true,
+		StopOnFailure: true,
+	}
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Assert(request, gc.Equals, "EnqueueOperation")
+				c.Assert(a, jc.DeepEquals, args)
+				c.Assert(result, gc.FitsTypeOf, &params.EnqueueOperationResult{})
+				*(result.(*params.EnqueueOperationResult)) = params.EnqueueOperationResult{
+					Operation: "1",
+					Actions: []params.ActionResult{{
+						Action: &params.Action{Tag: "action-1", Receiver: "unit-mysql-0"},
+					}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	}
+	client := action.NewClient(apiCaller)
+	result, err := client.EnqueueOperation(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Operation, gc.Equals, "1")
+	c.Assert(result.Actions, gc.HasLen, 1)
+}
+
+func (s *actionSuite) TestEnqueueOperationError(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				*(result.(*params.EnqueueOperationResult)) = params.EnqueueOperationResult{
+					Error: &params.Error{Message: "boom"},
+				}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	}
+	client := action.NewClient(apiCaller)
+	_, err := client.EnqueueOperation(params.EnqueueOperationArgs{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *actionSuite) TestEnqueueOperationNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := action.NewClient(apiCaller)
+	_, err := client.EnqueueOperation(params.EnqueueOperationArgs{})
+	c.Assert(err, gc.ErrorMatches, "EnqueueOperation not supported by this version \\(5\\) of Juju")
+}
+
 func (s *actionSuite) TestOperations(c *gc.C) {
 	var args params.OperationQueryArgs
 	apiCaller := basetesting.BestVersionCaller{
@@ -238,13 +373,70 @@ func (s *actionSuite) TestOperations(c *gc.C) {
 	client := action.NewClient(apiCaller)
 	result, err := client.Operations(args)
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(result, jc.DeepEquals, params.ActionResults{
-		Results: []params.ActionResult{{
+	c.Assert(result, jc.DeepEquals, params.OperationResults{
+		Actions: []params.ActionResult{{
 			Error: &params.Error{Message: "FAIL"},
 		}},
 	})
 }
 
+func (s *actionSuite) TestOperationsV6(c *gc.C) {
+	args := params.OperationQueryArgs{Operations: []string{"1"}}
+	expected := params.OperationResults{
+		Results: []params.OperationResult{{
+			Operation:     "1",
+			Action:        "restart",
+			Status:        "running",
+			BatchSize:     1,
+			QueuedUnits:   []string{"mysql/0"},
+			UnqueuedUnits: []string{"mysql/1"},
+			Actions: []params.ActionResult{{
+				Action:    &params.Action{Tag: "action-1", Receiver: "unit-mysql-0"},
+				Operation: "1",
+			}},
+		}},
+	}
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Assert(request, gc.Equals, "Operations")
+				c.Assert(a, jc.DeepEquals, args)
+				c.Assert(result, gc.FitsTypeOf, &params.OperationResults{})
+				*(result.(*params.OperationResults)) = expected
+				return nil
+			},
+		),
+		BestVersion: 6,
+	}
+	client := action.NewClient(apiCaller)
+	result, err := client.Operations(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, expected)
+}
+
+func (s *actionSuite) TestOperationsFilterNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := action.NewClient(apiCaller)
+	_, err := client.Operations(params.OperationQueryArgs{Operations: []string{"1"}})
+	c.Assert(err, gc.ErrorMatches, "filtering by operation not supported by this version \\(5\\) of Juju")
+}
+
 func (s *actionSuite) TestOperationsNotSupported(c *gc.C) {
 	apiCaller := basetesting.BestVersionCaller{
 		APICallerFunc: basetesting.APICallerFunc(
@@ -262,3 +454,65 @@ func (s *actionSuite) TestOperationsNotSupported(c *gc.C) {
 	_, err := client.Operations(params.OperationQueryArgs{})
 	c.Assert(err, gc.ErrorMatches, "Operations not supported by this version \\(4\\) of Juju")
 }
+
+func (s *actionSuite) TestWatchActionOutput(c *gc.C) {
+	now := time.Now().UTC()
+	stream := &fakeStream{messages: []params.ActionMessage{
+		{Message: "starting", Timestamp: now},
+		{Message: "hello", Stream: "stdout", Timestamp: now},
+	}}
+	apiCaller := streamCaller{
+		BestVersionCaller: basetesting.BestVersionCaller{BestVersion: 6},
+		c:                 c,
+		stream:            stream,
+	}
+	client := action.NewClient(apiCaller)
+	messages, err := client.WatchActionOutput("1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	var obtained []params.ActionMessage
+	for msg := range messages {
+		obtained = append(obtained, msg)
+	}
+	c.Assert(obtained, jc.DeepEquals, stream.messages)
+	c.Assert(stream.closed, jc.IsTrue)
+}
+
+func (s *actionSuite) TestWatchActionOutputNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{BestVersion: 5}
+	client := action.NewClient(apiCaller)
+	_, err := client.WatchActionOutput("1")
+	c.Assert(err, gc.ErrorMatches, `WatchActionOutput by this version \(5\) of Juju not supported`)
+}
+
+type streamCaller struct {
+	basetesting.BestVersionCaller
+	c      *gc.C
+	stream base.Stream
+}
+
+func (s streamCaller) ConnectStream(path string, attrs url.Values) (base.Stream, error) {
+	s.c.Check(path, gc.Equals, "/actions/1/output")
+	return s.stream, nil
+}
+
+type fakeStream struct {
+	base.Stream
+	messages []params.ActionMessage
+	next     int
+	closed   bool
+}
+
+func (s *fakeStream) ReadJSON(v interface{}) error {
+	if s.next == len(s.messages) {
+		return io.EOF
+	}
+	*(v.(*params.ActionMessage)) = s.messages[s.next]
+	s.next++
+	return nil
+}
+
+func (s *fakeStream) Close() error {
+	s.closed = true
+	return nil
+}
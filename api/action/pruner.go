@@ -6,6 +6,8 @@ package action
 import (
 	"time"
 
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/apiserver/params"
@@ -33,3 +35,12 @@ func (s *Facade) Prune(maxHistoryTime time.Duration, maxHistoryMB int) error {
 	}
 	return s.facade.FacadeCall("Prune", p, nil)
 }
+
+// ExpireActions cancels or fails actions that have exceeded their
+// queue or execution timeouts.
+func (s *Facade) ExpireActions() error {
+	if s.facade.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("expiring actions")
+	}
+	return s.facade.FacadeCall("ExpireActions", nil, nil)
+}
@@ -777,6 +777,21 @@ func (c *Client) Expose(application string) error {
 	return c.facade.FacadeCall("Expose", args, nil)
 }
 
+// ExposeEndpoints exposes the ports opened for the given endpoints of the
+// application to the spaces and CIDRs specified for each endpoint, merging
+// the settings with any already in place. The empty endpoint name applies
+// the settings to all of the application's endpoints.
+func (c *Client) ExposeEndpoints(application string, exposedEndpoints map[string]params.ExposedEndpoint) error {
+	if c.BestAPIVersion() < 12 {
+		return errors.NotSupportedf("exposing application endpoints to spaces or CIDRs by this controller")
+	}
+	args := params.ApplicationExpose{
+		ApplicationName:  application,
+		ExposedEndpoints: exposedEndpoints,
+	}
+	return c.facade.FacadeCall("Expose", args, nil)
+}
+
 // Unexpose changes the juju-managed firewall to unexpose any ports that
 // were also explicitly marked by units as open.
 func (c *Client) Unexpose(application string) error {
@@ -784,6 +799,19 @@ func (c *Client) Unexpose(application string) error {
 	return c.facade.FacadeCall("Unexpose", args, nil)
 }
 
+// UnexposeEndpoints removes the expose settings of the given endpoints of
+// the application. The application is unexposed once no settings remain.
+func (c *Client) UnexposeEndpoints(application string, endpoints []string) error {
+	if c.BestAPIVersion() < 12 {
+		return errors.NotSupportedf("unexposing application endpoints by this controller")
+	}
+	args := params.ApplicationUnexpose{
+		ApplicationName:  application,
+		ExposedEndpoints: endpoints,
+	}
+	return c.facade.FacadeCall("Unexpose", args, nil)
+}
+
 // Get returns the configuration for the named application.
 func (c *Client) Get(branchName, application string) (*params.ApplicationGetResults, error) {
 	var results params.ApplicationGetResults
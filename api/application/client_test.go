@@ -1559,3 +1559,62 @@ func (s *applicationSuite) TestApplicationsInfoResultMismatch(c *gc.C) {
 	c.Check(called, jc.IsTrue)
 	c.Assert(err, gc.ErrorMatches, "expected 2 results, got 3")
 }
+
+func (s *applicationSuite) TestExposeEndpoints(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "Expose")
+				c.Assert(a, jc.DeepEquals, params.ApplicationExpose{
+					ApplicationName: "foo",
+					ExposedEndpoints: map[string]params.ExposedEndpoint{
+						"db": {ExposeToSpaces: []string{"dmz"}, ExposeToCIDRs: []string{"10.0.0.0/24"}},
+					},
+				})
+				return nil
+			},
+		),
+		BestVersion: 12,
+	})
+
+	err := client.ExposeEndpoints("foo", map[string]params.ExposedEndpoint{
+		"db": {ExposeToSpaces: []string{"dmz"}, ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *applicationSuite) TestExposeEndpointsNotSupported(c *gc.C) {
+	var called bool
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				return nil
+			},
+		),
+		BestVersion: 11,
+	})
+
+	err := client.ExposeEndpoints("foo", map[string]params.ExposedEndpoint{"db": {}})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *applicationSuite) TestUnexposeEndpoints(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "Unexpose")
+				c.Assert(a, jc.DeepEquals, params.ApplicationUnexpose{
+					ApplicationName:  "foo",
+					ExposedEndpoints: []string{"db"},
+				})
+				return nil
+			},
+		),
+		BestVersion: 12,
+	})
+
+	err := client.UnexposeEndpoints("foo", []string{"db"})
+	c.Assert(err, jc.ErrorIsNil)
+}
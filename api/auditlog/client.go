@@ -0,0 +1,38 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package auditlog provides a client for querying the audit log kept
+// by the controller.
+package auditlog
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the AuditLog API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the AuditLog API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "AuditLog")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Query returns the requests recorded in the controller's audit log
+// that match the arguments, oldest first.
+func (c *Client) Query(args params.AuditLogQueryArgs) ([]params.AuditLogEntry, error) {
+	if c.BestAPIVersion() < 1 {
+		return nil, errors.NotSupportedf("querying the audit log on this controller")
+	}
+	var result params.AuditLogEntries
+	if err := c.facade.FacadeCall("Query", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Entries, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/auditlog"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditLogSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&auditLogSuite{})
+
+func (s *auditLogSuite) TestQuery(c *gc.C) {
+	args := params.AuditLogQueryArgs{
+		User:       "bob",
+		ErrorsOnly: true,
+		Limit:      20,
+	}
+	entries := []params.AuditLogEntry{{
+		ConversationID: "aaaa",
+		Who:            "bob",
+		RequestID:      2,
+		Facade:         "Application",
+		Method:         "DestroyApplication",
+	}}
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Check(objType, gc.Equals, "AuditLog")
+			c.Check(version, gc.Equals, 1)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Query")
+			c.Check(a, jc.DeepEquals, args)
+			*(response.(*params.AuditLogEntries)) = params.AuditLogEntries{Entries: entries}
+			return nil
+		},
+		BestVersion: 1,
+	}
+	client := auditlog.NewClient(apiCaller)
+	result, err := client.Query(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, entries)
+}
+
+func (s *auditLogSuite) TestQueryError(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			return errors.New("boom")
+		},
+		BestVersion: 1,
+	}
+	client := auditlog.NewClient(apiCaller)
+	_, err := client.Query(params.AuditLogQueryArgs{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *auditLogSuite) TestQueryNotSupported(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	client := auditlog.NewClient(apiCaller)
+	_, err := client.Query(params.AuditLogQueryArgs{})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -12,12 +12,13 @@ import (
 // Create sends a request to create a backup of juju's state.  It
 // returns the metadata associated with the resulting backup and a
 // filename for download.
-func (c *Client) Create(notes string, keepCopy, noDownload bool) (*params.BackupsMetadataResult, error) {
+func (c *Client) Create(notes string, keepCopy, noDownload, incremental bool) (*params.BackupsMetadataResult, error) {
 	var result params.BackupsMetadataResult
 	args := params.BackupsCreateArgs{
-		Notes:      notes,
-		KeepCopy:   keepCopy,
-		NoDownload: noDownload,
+		Notes:       notes,
+		KeepCopy:    keepCopy,
+		NoDownload:  noDownload,
+		Incremental: incremental,
 	}
 
 	if err := c.facade.FacadeCall("Create", args, &result); err != nil {
@@ -29,6 +29,7 @@ func (s *createSuite) TestCreate(c *gc.C) {
 			c.Check(p.Notes, gc.Equals, "important")
 			c.Check(p.KeepCopy, jc.IsFalse)
 			c.Check(p.NoDownload, jc.IsFalse)
+			c.Check(p.Incremental, jc.IsFalse)
 
 			if result, ok := resp.(*params.BackupsMetadataResult); ok {
 				*result = apiserverbackups.CreateResult(s.Meta, "test-filename")
@@ -41,7 +42,7 @@ func (s *createSuite) TestCreate(c *gc.C) {
 	)
 	defer cleanup()
 
-	result, err := s.client.Create("important", false, false)
+	result, err := s.client.Create("important", false, false, false)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Log(result)
 	meta := backupstesting.UpdateNotes(s.Meta, "important")
@@ -0,0 +1,70 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// PreviewRestore returns a summary of the controller that restoring
+// the identified backup would produce, and how that differs from the
+// controller as it is now, without restoring anything. If until is not
+// zero, an incremental backup is previewed as restored to that time.
+func (c *Client) PreviewRestore(backupId string, until time.Time) (*params.RestorePreviewResult, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("previewing restores on this controller")
+	}
+	args := params.RestoreArgs{
+		BackupId: backupId,
+	}
+	if !until.IsZero() {
+		args.Until = &until
+	}
+	var result params.RestorePreviewResult
+	if err := c.facade.FacadeCall("PreviewRestore", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &result, nil
+}
+
+// PreviewRestoreReader previews restoring the contents of backupFile.
+// The backup is uploaded to the controller first, unless the controller
+// already holds it; an upload made for the preview is removed again
+// once the preview is done.
+func (c *Client) PreviewRestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResult, until time.Time) (_ *params.RestorePreviewResult, err error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("previewing restores on this controller")
+	}
+	backupId, err := c.storedBackupID(meta)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if backupId == "" {
+		if backupId, err = c.Upload(r, *meta); err != nil {
+			return nil, errors.Annotatef(err, "cannot upload backup file")
+		}
+		defer func() {
+			if removeErr := c.removeBackup(backupId); removeErr != nil {
+				logger.Errorf("could not remove backup %q uploaded for restore preview: %v", backupId, removeErr)
+			}
+		}()
+	}
+	return c.PreviewRestore(backupId, until)
+}
+
+func (c *Client) removeBackup(backupId string) error {
+	results, err := c.Remove(backupId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if results[0].Error != nil {
+		return results[0].Error
+	}
+	return nil
+}
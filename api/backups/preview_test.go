@@ -0,0 +1,85 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/backups"
+	"github.com/juju/juju/api/base/mocks"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type previewSuite struct {
+	facade       *mocks.MockFacadeCaller
+	clientFacade *mocks.MockClientFacade
+	client       *backups.Client
+}
+
+var _ = gc.Suite(&previewSuite{})
+
+func (s *previewSuite) setup(c *gc.C, version int) *gomock.Controller {
+	ctrl := gomock.NewController(c)
+	s.facade = mocks.NewMockFacadeCaller(ctrl)
+	s.clientFacade = mocks.NewMockClientFacade(ctrl)
+	s.clientFacade.EXPECT().BestAPIVersion().Return(version).AnyTimes()
+	s.client = backups.MakeClient(s.clientFacade, s.facade, nil)
+	return ctrl
+}
+
+func (s *previewSuite) TestPreviewRestore(c *gc.C) {
+	defer s.setup(c, 3).Finish()
+
+	until := time.Date(2020, time.January, 1, 12, 0, 0, 0, time.UTC)
+	expected := params.RestorePreviewResult{
+		Models: []params.RestorePreviewModel{{
+			UUID:  "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			Name:  "controller",
+			Owner: "admin",
+		}},
+	}
+	args := params.RestoreArgs{BackupId: "some-id", Until: &until}
+	s.facade.EXPECT().FacadeCall("PreviewRestore", args, gomock.Any()).SetArg(2, expected)
+
+	result, err := s.client.PreviewRestore("some-id", until)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(*result, jc.DeepEquals, expected)
+}
+
+func (s *previewSuite) TestPreviewRestoreNotSupported(c *gc.C) {
+	defer s.setup(c, 2).Finish()
+
+	_, err := s.client.PreviewRestore("some-id", time.Time{})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *previewSuite) TestPreviewRestoreReaderStored(c *gc.C) {
+	defer s.setup(c, 3).Finish()
+
+	// Upload is not called, since the controller already holds the backup.
+	stored := params.BackupsListResult{
+		List: []params.BackupsMetadataResult{{ID: "some-id", Checksum: "checksum"}},
+	}
+	gomock.InOrder(
+		s.facade.EXPECT().FacadeCall("List", params.BackupsListArgs{}, gomock.Any()).SetArg(2, stored),
+		s.facade.EXPECT().FacadeCall("PreviewRestore", params.RestoreArgs{BackupId: "some-id"}, gomock.Any()),
+	)
+
+	meta := &params.BackupsMetadataResult{Checksum: "checksum"}
+	_, err := s.client.PreviewRestoreReader(nil, meta, time.Time{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *previewSuite) TestRestoreUntilNotSupported(c *gc.C) {
+	defer s.setup(c, 2).Finish()
+
+	// The restore is refused before the controller is prepared for it.
+	err := s.client.Restore("some-id", time.Unix(3600, 0), nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -67,44 +67,72 @@ func prepareRestore(newClient ClientConnection) error {
 	return errors.Annotatef(err, "could not start restore process: %v", remoteError)
 }
 
-// RestoreReader restores the contents of backupFile as backup.
-func (c *Client) RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResult, newClient ClientConnection) error {
+// RestoreReader restores the contents of backupFile as backup. If until
+// is not zero, an incremental backup is only restored up to that time.
+func (c *Client) RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResult, until time.Time, newClient ClientConnection) error {
+	if err := c.checkUntil(until); err != nil {
+		return errors.Trace(err)
+	}
 	if err := prepareRestore(newClient); err != nil {
 		return errors.Trace(err)
 	}
 	logger.Debugf("Server is now in 'about to restore' mode, proceeding to upload the backup file")
 
-	results, err := c.List()
+	// Do not upload if backup already exists on controller.
+	backupId, err := c.storedBackupID(meta)
 	if err != nil {
 		return errors.Trace(err)
 	}
-
-	// Do not upload if backup already exists on controller.
-	list := results.List
-	for _, b := range list {
-		if b.Checksum == meta.Checksum {
-			return c.restore(b.ID, newClient)
-		}
+	if backupId != "" {
+		return c.restore(backupId, until, newClient)
 	}
 
 	// Upload.
-	backupId, err := c.Upload(r, *meta)
+	backupId, err = c.Upload(r, *meta)
 	if err != nil {
 		finishErr := finishRestore(newClient)
 		logger.Errorf("could not clean up after failed backup upload: %v", finishErr)
 		return errors.Annotatef(err, "cannot upload backup file")
 	}
 
-	return c.restore(backupId, newClient)
+	return c.restore(backupId, until, newClient)
+}
+
+// checkUntil returns an error if the controller cannot restore to the
+// supplied point in time.
+func (c *Client) checkUntil(until time.Time) error {
+	if !until.IsZero() && c.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("restoring to a point in time on this controller")
+	}
+	return nil
+}
+
+// storedBackupID returns the ID of the backup stored on the controller
+// with the same checksum as the supplied backup, or "" if there is none.
+func (c *Client) storedBackupID(meta *params.BackupsMetadataResult) (string, error) {
+	results, err := c.List()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	for _, b := range results.List {
+		if b.Checksum == meta.Checksum {
+			return b.ID, nil
+		}
+	}
+	return "", nil
 }
 
 // Restore performs restore using a backup id corresponding to a backup stored in the server.
-func (c *Client) Restore(backupId string, newClient ClientConnection) error {
+// If until is not zero, an incremental backup is only restored up to that time.
+func (c *Client) Restore(backupId string, until time.Time, newClient ClientConnection) error {
+	if err := c.checkUntil(until); err != nil {
+		return errors.Trace(err)
+	}
 	if err := prepareRestore(newClient); err != nil {
 		return errors.Trace(err)
 	}
 	logger.Debugf("Server in 'about to restore' mode")
-	return c.restore(backupId, newClient)
+	return c.restore(backupId, until, newClient)
 }
 
 func restoreAttempt(client *Client, restoreArgs params.RestoreArgs) (error, error) {
@@ -117,16 +145,20 @@ func restoreAttempt(client *Client, restoreArgs params.RestoreArgs) (error, erro
 // restore is responsible for triggering the whole restore process in a remote
 // machine. The backup information for the process should already be in the
 // server and loaded in the backup storage under the backupId id.
-// It takes backupId as the identifier for the remote backup file and a
-// client connection factory newClient (newClient should no longer be
+// It takes backupId as the identifier for the remote backup file, the
+// point in time to restore to, if any, and a client connection factory
+// newClient (newClient should no longer be
 // necessary when lp:1399722 is sorted out).
-func (c *Client) restore(backupId string, newClient ClientConnection) error {
+func (c *Client) restore(backupId string, until time.Time, newClient ClientConnection) error {
 	var err, remoteError error
 
 	// Restore
 	restoreArgs := params.RestoreArgs{
 		BackupId: backupId,
 	}
+	if !until.IsZero() {
+		restoreArgs.Until = &until
+	}
 
 	cleanExit := false
 	for a := restoreStrategy.Start(); a.Next(); {
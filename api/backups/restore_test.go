@@ -4,6 +4,8 @@
 package backups_test
 
 import (
+	"time"
+
 	"github.com/golang/mock/gomock"
 	gc "gopkg.in/check.v1"
 
@@ -49,5 +51,5 @@ func (s *restoreSuite) TestRestoreFromFileBackupExistsOnController(c *gc.C) {
 		return backups.MakeClient(mockBackupClientFacade, mockBackupFacadeCaller, nil), nil
 	}
 	mockBackupsClient, _ := connFunc()
-	mockBackupsClient.RestoreReader(nil, &testBackupResults, connFunc)
+	mockBackupsClient.RestoreReader(nil, &testBackupResults, time.Time{}, connFunc)
 }
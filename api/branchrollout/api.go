@@ -0,0 +1,120 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchrollout
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/model"
+)
+
+// Rollout describes a branch rollout in progress.
+type Rollout struct {
+	// BranchName is the name of the branch being rolled out.
+	BranchName string
+
+	// Policy describes how the branch is rolled out.
+	Policy model.RolloutPolicy
+
+	// StartedBy is the user who started the rollout.
+	StartedBy string
+
+	// LastBatch is the time at which the last batch of units was set
+	// to track the branch. It is zero before the first batch.
+	LastBatch time.Time
+
+	// UnitsPending is the names of the units of the branch's
+	// applications that are not yet tracking it.
+	UnitsPending []string
+
+	// UnitsInError is the names of the units tracking the branch that
+	// have a workload status of "error". It is only populated if the
+	// rollout halts on error.
+	UnitsInError []string
+}
+
+// API makes calls to the BranchRollout facade.
+type API struct {
+	caller base.FacadeCaller
+}
+
+// NewAPI returns a new API using the supplied caller.
+func NewAPI(caller base.APICaller) *API {
+	return &API{
+		caller: base.NewFacadeCaller(caller, "BranchRollout"),
+	}
+}
+
+// Rollouts returns the branch rollouts in progress in the model.
+func (api *API) Rollouts() ([]Rollout, error) {
+	var result params.BranchRolloutResults
+	if err := api.caller.FacadeCall("Rollouts", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+
+	rollouts := make([]Rollout, len(result.Rollouts))
+	for i, r := range result.Rollouts {
+		rollouts[i] = Rollout{
+			BranchName: r.BranchName,
+			Policy: model.RolloutPolicy{
+				BatchSize:     r.Rollout.BatchSize,
+				BatchInterval: r.Rollout.BatchInterval,
+				HaltOnError:   r.Rollout.HaltOnError,
+			},
+			StartedBy:    r.Rollout.StartedBy,
+			UnitsPending: r.UnitsPending,
+			UnitsInError: r.UnitsInError,
+		}
+		if r.LastBatch > 0 {
+			rollouts[i].LastBatch = time.Unix(r.LastBatch, 0)
+		}
+	}
+	return rollouts, nil
+}
+
+// AssignRolloutBatch sets the input units to track the
+// branch with the input name, as a batch of its rollout.
+func (api *API) AssignRolloutBatch(branchName string, unitNames []string) error {
+	args := params.BranchRolloutBatches{
+		Batches: []params.BranchRolloutBatch{{BranchName: branchName, Units: unitNames}},
+	}
+	var results params.ErrorResults
+	if err := api.caller.FacadeCall("AssignRolloutBatches", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(results.OneError())
+}
+
+// HaltRollout stops the rollout of the branch
+// with the input name, for the input reason.
+func (api *API) HaltRollout(branchName, reason string) error {
+	args := params.BranchRolloutHalts{
+		Halts: []params.BranchRolloutHalt{{BranchName: branchName, Reason: reason}},
+	}
+	var results params.ErrorResults
+	if err := api.caller.FacadeCall("HaltRollouts", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(results.OneError())
+}
+
+// CompleteRollout commits the branch with the input name
+// on behalf of the user who started its rollout.
+func (api *API) CompleteRollout(branchName string) error {
+	args := params.BranchArgs{
+		Args: []params.BranchArg{{BranchName: branchName}},
+	}
+	var results params.ErrorResults
+	if err := api.caller.FacadeCall("CompleteRollouts", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(results.OneError())
+}
@@ -0,0 +1,122 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchrollout_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/branchrollout"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/model"
+)
+
+type APISuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&APISuite{})
+
+func (s *APISuite) TestRollouts(c *gc.C) {
+	caller := apiCaller(c, func(request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "Rollouts")
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.BranchRolloutResults)) = params.BranchRolloutResults{
+			Rollouts: []params.BranchRollout{{
+				BranchName: "canary",
+				Rollout: params.GenerationRollout{
+					BatchSize:     2,
+					BatchInterval: time.Minute,
+					HaltOnError:   true,
+					StartedBy:     "bob",
+				},
+				LastBatch:    300,
+				UnitsPending: []string{"redis/1"},
+				UnitsInError: []string{"redis/0"},
+			}},
+		}
+		return nil
+	})
+
+	rollouts, err := branchrollout.NewAPI(caller).Rollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(rollouts, jc.DeepEquals, []branchrollout.Rollout{{
+		BranchName:   "canary",
+		Policy:       model.RolloutPolicy{BatchSize: 2, BatchInterval: time.Minute, HaltOnError: true},
+		StartedBy:    "bob",
+		LastBatch:    time.Unix(300, 0),
+		UnitsPending: []string{"redis/1"},
+		UnitsInError: []string{"redis/0"},
+	}})
+}
+
+func (s *APISuite) TestRolloutsError(c *gc.C) {
+	caller := apiCaller(c, func(_ string, _, result interface{}) error {
+		*(result.(*params.BranchRolloutResults)) = params.BranchRolloutResults{
+			Error: &params.Error{Message: "boom"},
+		}
+		return nil
+	})
+
+	_, err := branchrollout.NewAPI(caller).Rollouts()
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *APISuite) TestAssignRolloutBatch(c *gc.C) {
+	caller := apiCaller(c, func(request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "AssignRolloutBatches")
+		c.Check(arg, jc.DeepEquals, params.BranchRolloutBatches{
+			Batches: []params.BranchRolloutBatch{{BranchName: "canary", Units: []string{"redis/1"}}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{Results: []params.ErrorResult{{}}}
+		return nil
+	})
+
+	err := branchrollout.NewAPI(caller).AssignRolloutBatch("canary", []string{"redis/1"})
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *APISuite) TestHaltRollout(c *gc.C) {
+	caller := apiCaller(c, func(request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "HaltRollouts")
+		c.Check(arg, jc.DeepEquals, params.BranchRolloutHalts{
+			Halts: []params.BranchRolloutHalt{{BranchName: "canary", Reason: "redis/0 in error"}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{Results: []params.ErrorResult{{
+			Error: &params.Error{Message: "nope"},
+		}}}
+		return nil
+	})
+
+	err := branchrollout.NewAPI(caller).HaltRollout("canary", "redis/0 in error")
+	c.Check(err, gc.ErrorMatches, "nope")
+}
+
+func (s *APISuite) TestCompleteRollout(c *gc.C) {
+	caller := apiCaller(c, func(request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "CompleteRollouts")
+		c.Check(arg, jc.DeepEquals, params.BranchArgs{
+			Args: []params.BranchArg{{BranchName: "canary"}},
+		})
+		return errors.New("snorble flip")
+	})
+
+	err := branchrollout.NewAPI(caller).CompleteRollout("canary")
+	c.Check(err, gc.ErrorMatches, "snorble flip")
+}
+
+func apiCaller(c *gc.C, check func(request string, arg, result interface{}) error) base.APICaller {
+	return apitesting.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(facade, gc.Equals, "BranchRollout")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		return check(request, arg, result)
+	})
+}
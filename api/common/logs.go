@@ -6,6 +6,7 @@ package common
 import (
 	"fmt"
 	"net/url"
+	"sort"
 	"time"
 
 	"github.com/juju/errors"
@@ -34,6 +35,16 @@ type DebugLogParams struct {
 	// ExcludeModule lists logging modules to exclude from the resposne. If a
 	// module is specified, all the submodules are also excluded.
 	ExcludeModule []string
+	// IncludeFields holds structured log fields that the log messages
+	// must have, mapped to the value each field must hold.
+	IncludeFields map[string]string
+	// IncludeMessage lists regular expressions matched against the log
+	// messages. If any are set, only messages matching one of them are
+	// sent.
+	IncludeMessage []string
+	// ExcludeMessage lists regular expressions matched against the log
+	// messages. Messages matching any of them are not sent.
+	ExcludeMessage []string
 	// Limit defines the maximum number of lines to return. Once this many
 	// have been sent, the socket is closed.  If zero, all filtered lines are
 	// sent down the connection until the client closes the connection.
@@ -53,14 +64,23 @@ type DebugLogParams struct {
 	// StartTime should be a time in the past - only records with a
 	// log time on or after StartTime will be returned.
 	StartTime time.Time
+	// EndTime, if set, means only records with a log time before
+	// EndTime will be returned. The server stops sending records once
+	// EndTime has passed.
+	EndTime time.Time
 }
 
 func (args DebugLogParams) URLQuery() url.Values {
 	attrs := url.Values{
-		"includeEntity": args.IncludeEntity,
-		"includeModule": args.IncludeModule,
-		"excludeEntity": args.ExcludeEntity,
-		"excludeModule": args.ExcludeModule,
+		"includeEntity":  args.IncludeEntity,
+		"includeModule":  args.IncludeModule,
+		"excludeEntity":  args.ExcludeEntity,
+		"excludeModule":  args.ExcludeModule,
+		"includeMessage": args.IncludeMessage,
+		"excludeMessage": args.ExcludeMessage,
+	}
+	for _, key := range sortedKeys(args.IncludeFields) {
+		attrs.Add("includeField", key+"="+args.IncludeFields[key])
 	}
 	if args.Replay {
 		attrs.Set("replay", fmt.Sprint(args.Replay))
@@ -80,17 +100,31 @@ func (args DebugLogParams) URLQuery() url.Values {
 	if !args.StartTime.IsZero() {
 		attrs.Set("startTime", args.StartTime.Format(time.RFC3339Nano))
 	}
+	if !args.EndTime.IsZero() {
+		attrs.Set("endTime", args.EndTime.Format(time.RFC3339Nano))
+	}
 	return attrs
 }
 
 // LogMessage is a structured logging entry.
 type LogMessage struct {
+	ModelUUID string
 	Entity    string
 	Timestamp time.Time
 	Severity  string
 	Module    string
 	Location  string
 	Message   string
+	Fields    map[string]string
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 // StreamDebugLog requests the specified debug log records from the
@@ -122,12 +156,14 @@ func StreamDebugLog(source base.StreamConnector, args DebugLogParams) (<-chan Lo
 				return
 			}
 			messages <- LogMessage{
+				ModelUUID: msg.ModelUUID,
 				Entity:    msg.Entity,
 				Timestamp: msg.Timestamp,
 				Severity:  msg.Severity,
 				Module:    msg.Module,
 				Location:  msg.Location,
 				Message:   msg.Message,
+				Fields:    msg.Fields,
 			}
 		}
 	}()
@@ -11,18 +11,20 @@ package api
 // New facades should start at 1.
 // Facades that existed before versioning start at 0.
 var facadeVersions = map[string]int{
-	"Action":                       5,
-	"ActionPruner":                 1,
+	"Action":                       6,
+	"ActionPruner":                 2,
 	"Agent":                        2,
 	"AgentTools":                   1,
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  11,
+	"Application":                  12,
 	"ApplicationOffers":            2,
 	"ApplicationScaler":            1,
-	"Backups":                      2,
+	"AuditLog":                     1,
+	"Backups":                      3,
 	"Block":                        2,
+	"BranchRollout":                1,
 	"Bundle":                       4,
 	"CAASAgent":                    1,
 	"CAASFirewaller":               1,
@@ -46,8 +48,8 @@ var facadeVersions = map[string]int{
 	"ExternalControllerUpdater":    1,
 	"FanConfigurer":                1,
 	"FilesystemAttachmentsWatcher": 2,
-	"Firewaller":                   5,
-	"FirewallRules":                1,
+	"Firewaller":                   7,
+	"FirewallRules":                2,
 	"HighAvailability":             2,
 	"HostKeyReporter":              1,
 	"ImageManager":                 2,
@@ -75,7 +77,7 @@ var facadeVersions = map[string]int{
 	"MigrationStatusWatcher":       1,
 	"MigrationTarget":              1,
 	"ModelConfig":                  2,
-	"ModelGeneration":              4,
+	"ModelGeneration":              6,
 	"ModelManager":                 8,
 	"ModelUpgrader":                1,
 	"NotifyWatcher":                1,
@@ -98,13 +100,13 @@ var facadeVersions = map[string]int{
 	"Spaces":                       5,
 	"SSHClient":                    2,
 	"StatusHistory":                2,
-	"Storage":                      6,
-	"StorageProvisioner":           4,
+	"Storage":                      7,
+	"StorageProvisioner":           5,
 	"StringsWatcher":               1,
 	"Subnets":                      3,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       14,
+	"Uniter":                       15,
 	"Upgrader":                     1,
 	"UpgradeSeries":                1,
 	"UpgradeSteps":                 1,
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/watcher"
 )
 
@@ -64,3 +65,40 @@ func (s *Application) IsExposed() (bool, error) {
 	}
 	return result.Result, nil
 }
+
+// ExposeInfo returns whether this application is exposed and, if so, the
+// expose settings of its endpoints keyed by endpoint name. The CIDRs of each
+// endpoint include those of the subnets in the spaces it is exposed to.
+// Controllers that do not support expose settings report an exposed
+// application as exposing all of its endpoints to 0.0.0.0/0.
+func (s *Application) ExposeInfo() (bool, map[string]params.ExposedEndpoint, error) {
+	if s.st.BestAPIVersion() < 6 {
+		exposed, err := s.IsExposed()
+		if err != nil || !exposed {
+			return false, nil, err
+		}
+		return true, map[string]params.ExposedEndpoint{
+			"": {ExposeToCIDRs: []string{network.AllNetworksIPv4CIDR}},
+		}, nil
+	}
+
+	var results params.ExposeInfoResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	err := s.st.facade.FacadeCall("GetExposeInfo", args, &results)
+	if err != nil {
+		return false, nil, err
+	}
+	if len(results.Results) != 1 {
+		return false, nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		if params.IsCodeNotFound(result.Error) {
+			return false, nil, errors.NewNotFound(result.Error, "")
+		}
+		return false, nil, result.Error
+	}
+	return result.Exposed, result.ExposedEndpoints, nil
+}
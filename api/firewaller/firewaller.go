@@ -256,3 +256,55 @@ func (c *Client) FirewallRules(knownServices ...string) ([]params.FirewallRule,
 	}
 	return results.Rules, nil
 }
+
+// FirewallServiceRules returns the firewall rules for the services
+// defined by an operator, along with the port range of each service.
+// Controllers that do not support such services have no rules for them.
+func (c *Client) FirewallServiceRules() ([]params.FirewallServiceRule, error) {
+	if c.BestAPIVersion() < 7 {
+		return nil, nil
+	}
+	var results params.FirewallServiceRulesResults
+	err := c.facade.FacadeCall("FirewallServiceRules", nil, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Rules, nil
+}
+
+// WatchFirewallRules returns a NotifyWatcher that notifies of changes
+// to the firewall rules and the services defined by an operator.
+// Controllers that do not support such services return a NotSupported
+// error.
+func (c *Client) WatchFirewallRules() (watcher.NotifyWatcher, error) {
+	if c.BestAPIVersion() < 7 {
+		return nil, errors.NotSupportedf("watching firewall rules")
+	}
+	var result params.NotifyWatchResult
+	err := c.facade.FacadeCall("WatchFirewallRules", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), result), nil
+}
+
+// WatchSubnetsAndSpaces returns a NotifyWatcher that notifies of changes
+// to the model's subnets and spaces. Controllers that do not support
+// watching them return a NotSupported error.
+func (c *Client) WatchSubnetsAndSpaces() (watcher.NotifyWatcher, error) {
+	if c.BestAPIVersion() < 7 {
+		return nil, errors.NotSupportedf("watching subnets and spaces")
+	}
+	var result params.NotifyWatchResult
+	err := c.facade.FacadeCall("WatchSubnetsAndSpaces", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), result), nil
+}
@@ -4,6 +4,7 @@
 package firewaller_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
@@ -248,3 +249,103 @@ func (s *firewallerSuite) TestFirewallRules(c *gc.C) {
 	c.Assert(result, gc.HasLen, 1)
 	c.Check(callCount, gc.Equals, 1)
 }
+
+func (s *firewallerSuite) TestFirewallServiceRules(c *gc.C) {
+	var callCount int
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Firewaller")
+			c.Check(version, gc.Equals, 7)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "FirewallServiceRules")
+			c.Assert(arg, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.FirewallServiceRulesResults{})
+			*(result.(*params.FirewallServiceRulesResults)) = params.FirewallServiceRulesResults{
+				Rules: []params.FirewallServiceRule{{
+					Service: params.FirewallService{
+						Name: "node-exporter", Protocol: "tcp", FromPort: 9100, ToPort: 9100,
+					},
+					WhitelistCIDRS: []string{"10.0.0.0/8"},
+				}},
+			}
+			callCount++
+			return nil
+		},
+		BestVersion: 7,
+	}
+	client, err := firewaller.NewClient(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := client.FirewallServiceRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.HasLen, 1)
+	c.Check(result[0].Service.Name, gc.Equals, params.KnownServiceValue("node-exporter"))
+	c.Check(callCount, gc.Equals, 1)
+}
+
+func (s *firewallerSuite) TestWatchFirewallRulesError(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Firewaller")
+			c.Check(request, gc.Equals, "WatchFirewallRules")
+			c.Assert(arg, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResult{})
+			*(result.(*params.NotifyWatchResult)) = params.NotifyWatchResult{
+				Error: &params.Error{Message: "boom"},
+			}
+			return nil
+		},
+		BestVersion: 7,
+	}
+	client, err := firewaller.NewClient(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = client.WatchFirewallRules()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *firewallerSuite) TestWatchFirewallRulesNotSupported(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Errorf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 6,
+	}
+	client, err := firewaller.NewClient(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = client.WatchFirewallRules()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *firewallerSuite) TestWatchSubnetsAndSpacesError(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Firewaller")
+			c.Check(request, gc.Equals, "WatchSubnetsAndSpaces")
+			c.Assert(arg, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResult{})
+			*(result.(*params.NotifyWatchResult)) = params.NotifyWatchResult{
+				Error: &params.Error{Message: "boom"},
+			}
+			return nil
+		},
+		BestVersion: 7,
+	}
+	client, err := firewaller.NewClient(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = client.WatchSubnetsAndSpaces()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *firewallerSuite) TestWatchSubnetsAndSpacesNotSupported(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Errorf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 6,
+	}
+	client, err := firewaller.NewClient(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = client.WatchSubnetsAndSpaces()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
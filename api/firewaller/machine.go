@@ -109,9 +109,21 @@ func (m *Machine) ActiveSubnets() ([]names.SubnetTag, error) {
 	return tags, nil
 }
 
-// OpenedPorts returns a map of network.PortRange to unit tag for all opened
-// port ranges on the machine for the subnet matching given subnetTag.
-func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[network.PortRange]names.UnitTag, error) {
+// OpenedPortRange identifies the unit that opened a port range and the
+// endpoint the range was opened for.
+type OpenedPortRange struct {
+	// Unit is the tag of the unit that opened the range.
+	Unit names.UnitTag
+
+	// Endpoint is the application endpoint the range was opened for,
+	// or empty if it was opened for all of the unit's endpoints.
+	Endpoint string
+}
+
+// OpenedPorts returns a map of network.PortRange to the unit tag and
+// endpoint for all opened port ranges on the machine for the subnet
+// matching given subnetTag.
+func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[network.PortRange]OpenedPortRange, error) {
 	var results params.MachinePortsResults
 	var subnetTagAsString string
 	if subnetTag.Id() != "" {
@@ -134,13 +146,16 @@ func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[network.PortRange]
 		return nil, result.Error
 	}
 	// Convert string tags to names.UnitTag before returning.
-	endResult := make(map[network.PortRange]names.UnitTag)
+	endResult := make(map[network.PortRange]OpenedPortRange)
 	for _, ports := range result.Ports {
 		unitTag, err := names.ParseUnitTag(ports.UnitTag)
 		if err != nil {
 			return nil, err
 		}
-		endResult[ports.PortRange.NetworkPortRange()] = unitTag
+		endResult[ports.PortRange.NetworkPortRange()] = OpenedPortRange{
+			Unit:     unitTag,
+			Endpoint: ports.Endpoint,
+		}
 	}
 	return endResult, nil
 }
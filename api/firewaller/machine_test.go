@@ -130,8 +130,18 @@ func (s *machineSuite) TestOpenedPorts(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	ports, err = s.apiMachine.OpenedPorts(names.SubnetTag{})
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(ports, jc.DeepEquals, map[network.PortRange]names.UnitTag{
-		{FromPort: 1234, ToPort: 1234, Protocol: "tcp"}: unitTag,
+	c.Assert(ports, jc.DeepEquals, map[network.PortRange]firewaller.OpenedPortRange{
+		{FromPort: 1234, ToPort: 1234, Protocol: "tcp"}: {Unit: unitTag},
+	})
+
+	// Open a port for an endpoint.
+	err = s.units[0].OpenPortsForEndpoint("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	ports, err = s.apiMachine.OpenedPorts(names.SubnetTag{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, jc.DeepEquals, map[network.PortRange]firewaller.OpenedPortRange{
+		{FromPort: 80, ToPort: 80, Protocol: "tcp"}:     {Unit: unitTag, Endpoint: "url"},
+		{FromPort: 1234, ToPort: 1234, Protocol: "tcp"}: {Unit: unitTag},
 	})
 }
 
@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/network"
 )
 
 // Client allows access to the firewall rules API end point.
@@ -24,10 +25,14 @@ func NewClient(st base.APICallCloser) *Client {
 }
 
 // SetFirewallRule creates or updates a firewall rule.
+// Controllers supporting services defined by an operator
+// validate the service themselves.
 func (c *Client) SetFirewallRule(service string, whiteListCidrs []string) error {
 	serviceValue := params.KnownServiceValue(service)
-	if err := serviceValue.Validate(); err != nil {
-		return errors.Trace(err)
+	if c.BestAPIVersion() < 2 {
+		if err := serviceValue.Validate(); err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	args := params.FirewallRuleArgs{
@@ -52,3 +57,53 @@ func (c *Client) ListFirewallRules() ([]params.FirewallRule, error) {
 	}
 	return results.Rules, nil
 }
+
+// SetFirewallService creates or updates the definition of a service,
+// to which firewall rules may then be applied.
+func (c *Client) SetFirewallService(name string, portRange network.PortRange) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("defining firewall services on this version of Juju")
+	}
+	args := params.FirewallServiceArgs{
+		Args: []params.FirewallService{{
+			Name:     params.KnownServiceValue(name),
+			Protocol: portRange.Protocol,
+			FromPort: portRange.FromPort,
+			ToPort:   portRange.ToPort,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetFirewallServices", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// RemoveFirewallService removes the definition of a service,
+// along with any firewall rule for it.
+func (c *Client) RemoveFirewallService(name string) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("removing firewall services on this version of Juju")
+	}
+	args := params.KnownServiceArgs{
+		KnownServices: []params.KnownServiceValue{params.KnownServiceValue(name)},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RemoveFirewallServices", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// ListFirewallServices returns the definitions of all the
+// services defined by an operator.
+func (c *Client) ListFirewallServices() ([]params.FirewallService, error) {
+	if c.BestAPIVersion() < 2 {
+		return nil, nil
+	}
+	var results params.ListFirewallServicesResults
+	if err := c.facade.FacadeCall("ListFirewallServices", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Services, nil
+}
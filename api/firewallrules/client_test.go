@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/api/firewallrules"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/testing"
 )
 
@@ -141,3 +142,139 @@ func (s *FirewallRulesSuite) TestListError(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.ErrorMatches, "fail")
 	c.Assert(called, jc.IsTrue)
 }
+
+func (s *FirewallRulesSuite) TestSetFirewallRuleForService(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(request, gc.Equals, "SetFirewallRules")
+			c.Check(a, jc.DeepEquals, params.FirewallRuleArgs{
+				Args: []params.FirewallRule{{
+					KnownService:   "node-exporter",
+					WhitelistCIDRS: []string{"10.0.0.0/8"},
+				}},
+			})
+			called = true
+			if results, ok := result.(*params.ErrorResults); ok {
+				results.Results = []params.ErrorResult{{}}
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+
+	client := firewallrules.NewClient(apiCaller)
+	err := client.SetFirewallRule("node-exporter", []string{"10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *FirewallRulesSuite) TestSetFirewallService(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "FirewallRules")
+			c.Check(request, gc.Equals, "SetFirewallServices")
+			c.Check(a, jc.DeepEquals, params.FirewallServiceArgs{
+				Args: []params.FirewallService{{
+					Name:     "node-exporter",
+					Protocol: "tcp",
+					FromPort: 9100,
+					ToPort:   9100,
+				}},
+			})
+			if results, ok := result.(*params.ErrorResults); ok {
+				results.Results = []params.ErrorResult{{
+					Error: common.ServerError(errors.New("fail"))}}
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+
+	client := firewallrules.NewClient(apiCaller)
+	err := client.SetFirewallService("node-exporter", network.PortRange{Protocol: "tcp", FromPort: 9100, ToPort: 9100})
+	c.Assert(err, gc.ErrorMatches, "fail")
+}
+
+func (s *FirewallRulesSuite) TestSetFirewallServiceNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Fail()
+			return nil
+		},
+		BestVersion: 1,
+	}
+
+	client := firewallrules.NewClient(apiCaller)
+	err := client.SetFirewallService("node-exporter", network.PortRange{Protocol: "tcp", FromPort: 9100, ToPort: 9100})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *FirewallRulesSuite) TestRemoveFirewallService(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(request, gc.Equals, "RemoveFirewallServices")
+			c.Check(a, jc.DeepEquals, params.KnownServiceArgs{
+				KnownServices: []params.KnownServiceValue{"node-exporter"},
+			})
+			if results, ok := result.(*params.ErrorResults); ok {
+				results.Results = []params.ErrorResult{{}}
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+
+	client := firewallrules.NewClient(apiCaller)
+	err := client.RemoveFirewallService("node-exporter")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *FirewallRulesSuite) TestListFirewallServices(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(request, gc.Equals, "ListFirewallServices")
+			c.Assert(a, gc.IsNil)
+			if results, ok := result.(*params.ListFirewallServicesResults); ok {
+				results.Services = []params.FirewallService{{
+					Name:     "node-exporter",
+					Protocol: "tcp",
+					FromPort: 9100,
+					ToPort:   9100,
+				}}
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+
+	client := firewallrules.NewClient(apiCaller)
+	results, err := client.ListFirewallServices()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.FirewallService{{
+		Name:     "node-exporter",
+		Protocol: "tcp",
+		FromPort: 9100,
+		ToPort:   9100,
+	}})
+}
@@ -34,10 +34,13 @@ type LogStream struct {
 	mu             sync.Mutex
 	stream         jsonReadCloser
 	controllerUUID string
+	audit          bool
 }
 
 // Open opens a websocket to the API's /logstream endpoint and returns
-// a stream of log records from that connection.
+// a stream of log records from that connection. If cfg.Audit is set,
+// the records are taken from the controller's audit log, and each is
+// given an audit origin naming the user who made the request.
 func Open(conn base.StreamConnector, cfg params.LogStreamConfig, controllerUUID string) (*LogStream, error) {
 	wsStream, err := stream.Open(conn, "/logstream", &cfg)
 	if err != nil {
@@ -46,6 +49,7 @@ func Open(conn base.StreamConnector, cfg params.LogStreamConfig, controllerUUID
 	ls := &LogStream{
 		stream:         wsStream,
 		controllerUUID: controllerUUID,
+		audit:          cfg.Audit,
 	}
 	return ls, nil
 }
@@ -71,7 +75,7 @@ func (ls *LogStream) Next() ([]logfwd.Record, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	records, err := recordsFromAPI(apiRecords, ls.controllerUUID)
+	records, err := recordsFromAPI(apiRecords, ls.controllerUUID, ls.audit)
 	if err != nil {
 		// This should only happen if the data got corrupted over the
 		// network. Any other cause should be addressed by fixing the
@@ -118,10 +122,10 @@ func (ls *LogStream) Close() error {
 }
 
 // See the counterpart in apiserver/logstream.go.
-func recordsFromAPI(apiRecords params.LogStreamRecords, controllerUUID string) ([]logfwd.Record, error) {
+func recordsFromAPI(apiRecords params.LogStreamRecords, controllerUUID string, audit bool) ([]logfwd.Record, error) {
 	result := make([]logfwd.Record, len(apiRecords.Records))
 	for i, apiRec := range apiRecords.Records {
-		rec, err := recordFromAPI(apiRec, controllerUUID)
+		rec, err := recordFromAPI(apiRec, controllerUUID, audit)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -130,14 +134,15 @@ func recordsFromAPI(apiRecords params.LogStreamRecords, controllerUUID string) (
 	return result, nil
 }
 
-func recordFromAPI(apiRec params.LogStreamRecord, controllerUUID string) (logfwd.Record, error) {
+func recordFromAPI(apiRec params.LogStreamRecord, controllerUUID string, audit bool) (logfwd.Record, error) {
 	rec := logfwd.Record{
 		ID:        apiRec.ID,
 		Timestamp: apiRec.Timestamp,
 		Message:   apiRec.Message,
+		Fields:    apiRec.Fields,
 	}
 
-	origin, err := originFromAPI(apiRec, controllerUUID)
+	origin, err := originFromAPI(apiRec, controllerUUID, audit)
 	if err != nil {
 		return rec, errors.Trace(err)
 	}
@@ -162,7 +167,7 @@ func recordFromAPI(apiRec params.LogStreamRecord, controllerUUID string) (logfwd
 	return rec, nil
 }
 
-func originFromAPI(apiRec params.LogStreamRecord, controllerUUID string) (logfwd.Origin, error) {
+func originFromAPI(apiRec params.LogStreamRecord, controllerUUID string, audit bool) (logfwd.Origin, error) {
 	var origin logfwd.Origin
 
 	tag, err := names.ParseTag(apiRec.Entity)
@@ -175,6 +180,14 @@ func originFromAPI(apiRec params.LogStreamRecord, controllerUUID string) (logfwd
 		return origin, errors.Annotatef(err, "invalid version %q", apiRec.Version)
 	}
 
+	if audit {
+		userTag, ok := tag.(names.UserTag)
+		if !ok {
+			return origin, errors.Errorf("invalid audit record entity %q", apiRec.Entity)
+		}
+		return logfwd.OriginForAudit(userTag, controllerUUID, apiRec.ModelUUID, ver), nil
+	}
+
 	switch tag := tag.(type) {
 	case names.MachineTag:
 		origin = logfwd.OriginForMachineAgent(tag, controllerUUID, apiRec.ModelUUID, ver)
@@ -141,6 +141,65 @@ func (s *LogReaderSuite) TestNextOneRecord(c *gc.C) {
 	}
 }
 
+func (s *LogReaderSuite) TestNextAuditRecord(c *gc.C) {
+	ts := time.Now()
+	apiRecords := params.LogStreamRecords{
+		Records: []params.LogStreamRecord{{
+			ID:        ts.UnixNano(),
+			ModelUUID: "deadbeef-2f18-4fd2-967d-db9663db7bea",
+			Entity:    "user-bob",
+			Version:   version.Current.String(),
+			Timestamp: ts,
+			Module:    "juju.apiserver.auditlog",
+			Level:     loggo.INFO.String(),
+			Message:   `{"request":{"facade":"Client"}}`,
+		}},
+	}
+	cUUID := "feebdaed-2f18-4fd2-967d-db9663db7bea"
+	stub := &testing.Stub{}
+	conn := &mockConnector{stub: stub}
+	jsonReader := mockStream{stub: stub}
+	logsCh := make(chan params.LogStreamRecords, 1)
+	logsCh <- apiRecords
+	jsonReader.ReturnReadJSON = logsCh
+	conn.ReturnConnectStream = jsonReader
+	cfg := params.LogStreamConfig{
+		Sink:  "spam-audit",
+		Audit: true,
+	}
+	stream, err := logstream.Open(conn, cfg, cUUID)
+	c.Assert(err, gc.IsNil)
+	stub.CheckCall(c, 0, "ConnectStream", `/logstream`, url.Values{
+		"sink":  []string{"spam-audit"},
+		"audit": []string{"true"},
+	})
+
+	records, err := stream.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, gc.HasLen, 1)
+	c.Check(records[0], jc.DeepEquals, logfwd.Record{
+		ID: ts.UnixNano(),
+		Origin: logfwd.Origin{
+			ControllerUUID: cUUID,
+			ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+			Type:           logfwd.OriginTypeAudit,
+			Name:           "bob",
+			Software: logfwd.Software{
+				PrivateEnterpriseNumber: 28978,
+				Name:                    "juju-audit-log",
+				Version:                 version.Current,
+			},
+		},
+		Timestamp: ts,
+		Level:     loggo.INFO,
+		Location: logfwd.SourceLocation{
+			Module: "juju.apiserver.auditlog",
+			Line:   -1,
+		},
+		Message: `{"request":{"facade":"Client"}}`,
+	})
+}
+
 func (s *LogReaderSuite) TestNextError(c *gc.C) {
 	cUUID := "feebdaed-2f18-4fd2-967d-db9663db7bea"
 	stub := &testing.Stub{}
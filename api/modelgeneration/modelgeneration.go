@@ -11,6 +11,7 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/model"
 )
 
@@ -69,6 +70,30 @@ func (c *Client) CommitBranch(branchName string) (int, error) {
 	return result.Result, nil
 }
 
+// RolloutBranch starts a progressive rollout of the branch with the input
+// name. Units are set to track the branch in batches according to the input
+// policy, and the branch is committed once all units are tracking it.
+func (c *Client) RolloutBranch(branchName string, policy model.RolloutPolicy) error {
+	if c.facade.BestAPIVersion() < 6 {
+		return errors.NotSupportedf("rolling out branches by this controller")
+	}
+	arg := params.BranchRolloutArg{
+		BranchName:    branchName,
+		BatchSize:     policy.BatchSize,
+		BatchInterval: policy.BatchInterval,
+		HaltOnError:   policy.HaltOnError,
+	}
+	var result params.ErrorResult
+	err := c.facade.FacadeCall("RolloutBranch", arg, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return errors.Trace(result.Error)
+	}
+	return nil
+}
+
 // ListCommits returns the details of all committed model branches.
 func (c *Client) ListCommits() (model.GenerationCommits, error) {
 	var result params.BranchResults
@@ -130,6 +155,50 @@ func (c *Client) TrackBranch(branchName string, entities []string, numUnits int)
 	return nil
 }
 
+// SetConstraints stages the replacement of the input application's
+// constraints under the input branch.
+func (c *Client) SetConstraints(branchName, appName string, cons constraints.Value) error {
+	return c.stageApplicationChanges(params.BranchApplicationChanges{
+		BranchName:      branchName,
+		ApplicationName: appName,
+		Constraints:     &cons,
+	})
+}
+
+// ScaleApplication stages a change to the input application's
+// desired scale under the input branch.
+func (c *Client) ScaleApplication(branchName, appName string, scale int) error {
+	return c.stageApplicationChanges(params.BranchApplicationChanges{
+		BranchName:      branchName,
+		ApplicationName: appName,
+		Scale:           &scale,
+	})
+}
+
+// MergeBindings stages the merge of the input endpoint bindings with
+// those of the input application under the input branch.
+func (c *Client) MergeBindings(branchName, appName string, bindings map[string]string, force bool) error {
+	return c.stageApplicationChanges(params.BranchApplicationChanges{
+		BranchName:      branchName,
+		ApplicationName: appName,
+		Bindings:        bindings,
+		Force:           force,
+	})
+}
+
+func (c *Client) stageApplicationChanges(arg params.BranchApplicationChanges) error {
+	if c.facade.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("staging application changes in a branch by this controller")
+	}
+	var result params.ErrorResults
+	args := params.BranchApplicationChangesArgs{Args: []params.BranchApplicationChanges{arg}}
+	err := c.facade.FacadeCall("StageApplicationChanges", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(result.OneError())
+}
+
 // HasActiveBranch returns true if the model has an
 // "in-flight" branch with the input name.
 func (c *Client) HasActiveBranch(branchName string) (bool, error) {
@@ -184,6 +253,11 @@ func generationInfoFromResult(
 				ApplicationName: a.ApplicationName,
 				UnitProgress:    a.UnitProgress,
 				ConfigChanges:   a.ConfigChanges,
+				CharmURL:        a.CharmURL,
+				Resources:       a.Resources,
+				Constraints:     constraintsString(a.Constraints),
+				Scale:           a.Scale,
+				Bindings:        a.Bindings,
 			}
 			if detailed {
 				bApp.UnitDetail = &model.GenerationUnits{
@@ -197,11 +271,29 @@ func generationInfoFromResult(
 			Created:      formatTime(time.Unix(res.Created, 0)),
 			CreatedBy:    res.CreatedBy,
 			Applications: appDeltas,
+			Rollout:      generationRolloutFromResult(res.Rollout, formatTime),
 		}
 	}
 	return summaries
 }
 
+func generationRolloutFromResult(
+	rollout *params.GenerationRollout, formatTime func(time.Time) string,
+) *model.GenerationRollout {
+	if rollout == nil {
+		return nil
+	}
+	return &model.GenerationRollout{
+		BatchSize:     rollout.BatchSize,
+		BatchInterval: rollout.BatchInterval.String(),
+		HaltOnError:   rollout.HaltOnError,
+		Started:       formatTime(time.Unix(rollout.Started, 0)),
+		StartedBy:     rollout.StartedBy,
+		Batches:       rollout.Batches,
+		Halted:        rollout.Halted,
+	}
+}
+
 func generationCommitsFromResults(results params.BranchResults) model.GenerationCommits {
 	commits := make(model.GenerationCommits, len(results.Generations))
 	for i, gen := range results.Generations {
@@ -222,6 +314,11 @@ func generationCommitFromResult(result params.GenerationResult) model.Generation
 		app := model.GenerationApplication{
 			ApplicationName: a.ApplicationName,
 			ConfigChanges:   a.ConfigChanges,
+			CharmURL:        a.CharmURL,
+			Resources:       a.Resources,
+			Constraints:     constraintsString(a.Constraints),
+			Scale:           a.Scale,
+			Bindings:        a.Bindings,
 			UnitDetail:      &model.GenerationUnits{UnitsTracking: a.UnitsTracking},
 		}
 		appChanges[i] = app
@@ -237,3 +334,13 @@ func generationCommitFromResult(result params.GenerationResult) model.Generation
 	}
 	return modelCommit
 }
+
+// constraintsString returns the string form of the input
+// constraints, or nil if none are supplied.
+func constraintsString(cons *constraints.Value) *string {
+	if cons == nil {
+		return nil
+	}
+	str := cons.String()
+	return &str
+}
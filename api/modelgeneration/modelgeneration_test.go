@@ -7,12 +7,14 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/api/base/mocks"
 	"github.com/juju/juju/api/modelgeneration"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/model"
 )
 
@@ -111,6 +113,33 @@ func (s *modelGenerationSuite) TestCommitBranch(c *gc.C) {
 	c.Check(newGenID, gc.Equals, 2)
 }
 
+func (s *modelGenerationSuite) TestRolloutBranch(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	resultSource := params.ErrorResult{}
+	arg := params.BranchRolloutArg{
+		BranchName:    s.branchName,
+		BatchSize:     2,
+		BatchInterval: time.Minute,
+		HaltOnError:   true,
+	}
+	s.fCaller.EXPECT().BestAPIVersion().Return(6)
+	s.fCaller.EXPECT().FacadeCall("RolloutBranch", arg, gomock.Any()).SetArg(2, resultSource).Return(nil)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	err := api.RolloutBranch(s.branchName, model.RolloutPolicy{BatchSize: 2, BatchInterval: time.Minute, HaltOnError: true})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *modelGenerationSuite) TestRolloutBranchNotSupported(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+	s.fCaller.EXPECT().BestAPIVersion().Return(5)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	err := api.RolloutBranch(s.branchName, model.RolloutPolicy{BatchSize: 1})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *modelGenerationSuite) TestHasActiveBranch(c *gc.C) {
 	defer s.setUpMocks(c).Finish()
 
@@ -124,9 +153,59 @@ func (s *modelGenerationSuite) TestHasActiveBranch(c *gc.C) {
 	c.Check(has, jc.IsTrue)
 }
 
+func (s *modelGenerationSuite) TestSetConstraints(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	cons := constraints.MustParse("mem=4G")
+	resultSource := params.ErrorResults{Results: []params.ErrorResult{{}}}
+	arg := params.BranchApplicationChangesArgs{Args: []params.BranchApplicationChanges{{
+		BranchName:      s.branchName,
+		ApplicationName: "redis",
+		Constraints:     &cons,
+	}}}
+	s.fCaller.EXPECT().BestAPIVersion().Return(5)
+	s.fCaller.EXPECT().FacadeCall("StageApplicationChanges", arg, gomock.Any()).SetArg(2, resultSource).Return(nil)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	err := api.SetConstraints(s.branchName, "redis", cons)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *modelGenerationSuite) TestScaleApplicationError(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+
+	scale := 3
+	resultSource := params.ErrorResults{Results: []params.ErrorResult{
+		{Error: &params.Error{Message: "boom"}},
+	}}
+	arg := params.BranchApplicationChangesArgs{Args: []params.BranchApplicationChanges{{
+		BranchName:      s.branchName,
+		ApplicationName: "redis",
+		Scale:           &scale,
+	}}}
+	s.fCaller.EXPECT().BestAPIVersion().Return(5)
+	s.fCaller.EXPECT().FacadeCall("StageApplicationChanges", arg, gomock.Any()).SetArg(2, resultSource).Return(nil)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	err := api.ScaleApplication(s.branchName, "redis", scale)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *modelGenerationSuite) TestMergeBindingsNotSupported(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+	s.fCaller.EXPECT().BestAPIVersion().Return(4)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	err := api.MergeBindings(s.branchName, "redis", map[string]string{"db": "alpha"}, false)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *modelGenerationSuite) TestBranchInfo(c *gc.C) {
 	defer s.setUpMocks(c).Finish()
 
+	mem := uint64(4096)
+	consStr := "mem=4096M"
+
 	resultSource := params.BranchResults{Generations: []params.Generation{{
 		BranchName: "new-branch",
 		Created:    time.Time{}.Unix(),
@@ -138,8 +217,17 @@ func (s *modelGenerationSuite) TestBranchInfo(c *gc.C) {
 				UnitsTracking:   []string{"redis/0"},
 				UnitsPending:    []string{"redis/1"},
 				ConfigChanges:   map[string]interface{}{"databases": 8},
+				Constraints:     &constraints.Value{Mem: &mem},
+				Bindings:        map[string]string{"db": "alpha"},
 			},
 		},
+		Rollout: &params.GenerationRollout{
+			BatchSize:     2,
+			BatchInterval: time.Minute,
+			Started:       time.Time{}.Unix(),
+			StartedBy:     "test-user",
+			Batches:       1,
+		},
 	}}}
 	arg := params.BranchInfoArgs{
 		BranchNames: []string{s.branchName},
@@ -168,7 +256,16 @@ func (s *modelGenerationSuite) TestBranchInfo(c *gc.C) {
 					UnitsPending:  []string{"redis/1"},
 				},
 				ConfigChanges: map[string]interface{}{"databases": 8},
+				Constraints:   &consStr,
+				Bindings:      map[string]string{"db": "alpha"},
 			}},
+			Rollout: &model.GenerationRollout{
+				BatchSize:     2,
+				BatchInterval: "1m0s",
+				Started:       "0001-01-01 00:00:00",
+				StartedBy:     "test-user",
+				Batches:       1,
+			},
 		},
 	})
 }
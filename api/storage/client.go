@@ -355,3 +355,113 @@ func (c *Client) Import(
 	}
 	return names.ParseStorageTag(results.Results[0].Result.StorageTag)
 }
+
+// Resize requests that the volumes backing the specified storage
+// instances be expanded to the given size in MiB.
+func (c *Client) Resize(storageIds []string, size uint64) ([]params.ErrorResult, error) {
+	if c.BestAPIVersion() < 7 {
+		return nil, errors.New("this juju controller does not support resizing storage")
+	}
+	args := params.StorageResizeParams{
+		Storage: make([]params.StorageResize, len(storageIds)),
+	}
+	for i, id := range storageIds {
+		if !names.IsValidStorage(id) {
+			return nil, errors.NotValidf("storage ID %q", id)
+		}
+		args.Storage[i] = params.StorageResize{
+			StorageTag: names.NewStorageTag(id).String(),
+			Size:       size,
+		}
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("ResizeStorage", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(storageIds) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(storageIds), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
+// CreateSnapshot requests a snapshot, with the specified name, of the
+// volume backing the specified storage instance.
+func (c *Client) CreateSnapshot(storageId, snapshotName string) error {
+	if c.BestAPIVersion() < 7 {
+		return errors.New("this juju controller does not support storage snapshots")
+	}
+	if !names.IsValidStorage(storageId) {
+		return errors.NotValidf("storage ID %q", storageId)
+	}
+	args := params.StorageSnapshotCreateParams{
+		Snapshots: []params.StorageSnapshotCreate{{
+			StorageTag: names.NewStorageTag(storageId).String(),
+			Name:       snapshotName,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("CreateStorageSnapshots", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// ListSnapshots returns the details of the storage snapshots in the model.
+func (c *Client) ListSnapshots() ([]params.StorageSnapshotDetails, error) {
+	if c.BestAPIVersion() < 7 {
+		return nil, errors.New("this juju controller does not support storage snapshots")
+	}
+	var results params.StorageSnapshotDetailsResults
+	if err := c.facade.FacadeCall("ListStorageSnapshots", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results, nil
+}
+
+// RemoveSnapshots destroys the storage snapshots with the specified names.
+func (c *Client) RemoveSnapshots(snapshotNames []string) ([]params.ErrorResult, error) {
+	if c.BestAPIVersion() < 7 {
+		return nil, errors.New("this juju controller does not support storage snapshots")
+	}
+	args := params.VolumeSnapshotNames{Names: snapshotNames}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RemoveStorageSnapshots", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(snapshotNames) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(snapshotNames), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
+// RestoreSnapshot requests that the named storage snapshot be restored
+// to a new storage instance with the given storage name, returning the
+// tag of the new storage instance.
+func (c *Client) RestoreSnapshot(snapshotName, storageName string) (names.StorageTag, error) {
+	if c.BestAPIVersion() < 7 {
+		return names.StorageTag{}, errors.New("this juju controller does not support storage snapshots")
+	}
+	args := params.StorageSnapshotRestoreParams{
+		Restores: []params.StorageSnapshotRestore{{
+			Name:        snapshotName,
+			StorageName: storageName,
+		}},
+	}
+	var results params.ImportStorageResults
+	if err := c.facade.FacadeCall("RestoreStorageSnapshots", args, &results); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return names.StorageTag{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return names.StorageTag{}, err
+	}
+	return names.ParseStorageTag(results.Results[0].Result.StorageTag)
+}
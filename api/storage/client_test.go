@@ -1077,3 +1077,195 @@ func (s *storageMockSuite) TestUpdatePoolFacadeCallError(c *gc.C) {
 	err := storageClient.UpdatePool("", "", nil)
 	c.Assert(errors.Cause(err), gc.ErrorMatches, msg)
 }
+
+func (s *storageMockSuite) TestResize(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "ResizeStorage")
+				c.Check(a, jc.DeepEquals, params.StorageResizeParams{
+					Storage: []params.StorageResize{
+						{StorageTag: "storage-foo-0", Size: 2048},
+						{StorageTag: "storage-bar-1", Size: 2048},
+					},
+				})
+				c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{
+					{},
+					{Error: &params.Error{Message: "baz"}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := storage.NewClient(apiCaller)
+	results, err := client.Resize([]string{"foo/0", "bar/1"}, 2048)
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, gc.IsNil)
+	c.Assert(results[1].Error, jc.DeepEquals, &params.Error{Message: "baz"})
+}
+
+func (s *storageMockSuite) TestResizeV6(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{BestVersion: 6}
+	client := storage.NewClient(apiCaller)
+	_, err := client.Resize([]string{"foo/0"}, 2048)
+	c.Check(err, gc.ErrorMatches, "this juju controller does not support resizing storage")
+}
+
+func (s *storageMockSuite) TestCreateSnapshot(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "CreateStorageSnapshots")
+				c.Check(a, jc.DeepEquals, params.StorageSnapshotCreateParams{
+					Snapshots: []params.StorageSnapshotCreate{
+						{StorageTag: "storage-pgdata-0", Name: "nightly"},
+					},
+				})
+				c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{
+					{Error: &params.Error{Message: "baz"}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.CreateSnapshot("pgdata/0", "nightly")
+	c.Check(err, gc.ErrorMatches, "baz")
+}
+
+func (s *storageMockSuite) TestCreateSnapshotInvalidStorageId(c *gc.C) {
+	client := storage.NewClient(basetesting.BestVersionCaller{BestVersion: 7})
+	err := client.CreateSnapshot("pgdata", "nightly")
+	c.Check(err, gc.ErrorMatches, `storage ID "pgdata" not valid`)
+}
+
+func (s *storageMockSuite) TestListSnapshots(c *gc.C) {
+	details := []params.StorageSnapshotDetails{{
+		Name:       "nightly",
+		VolumeTag:  "volume-0",
+		Pool:       "kubernetes",
+		Life:       "alive",
+		SnapshotId: "nightly",
+		Size:       1024,
+		Ready:      true,
+	}}
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "ListStorageSnapshots")
+				c.Check(a, gc.IsNil)
+				c.Assert(result, gc.FitsTypeOf, &params.StorageSnapshotDetailsResults{})
+				result.(*params.StorageSnapshotDetailsResults).Results = details
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := storage.NewClient(apiCaller)
+	results, err := client.ListSnapshots()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(results, jc.DeepEquals, details)
+}
+
+func (s *storageMockSuite) TestRemoveSnapshots(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "RemoveStorageSnapshots")
+				c.Check(a, jc.DeepEquals, params.VolumeSnapshotNames{
+					Names: []string{"nightly", "weekly"},
+				})
+				c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{
+					{},
+					{Error: &params.Error{Message: "baz"}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := storage.NewClient(apiCaller)
+	results, err := client.RemoveSnapshots([]string{"nightly", "weekly"})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, gc.IsNil)
+	c.Assert(results[1].Error, jc.DeepEquals, &params.Error{Message: "baz"})
+}
+
+func (s *storageMockSuite) TestRestoreSnapshot(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "RestoreStorageSnapshots")
+				c.Check(a, jc.DeepEquals, params.StorageSnapshotRestoreParams{
+					Restores: []params.StorageSnapshotRestore{
+						{Name: "nightly", StorageName: "pgdata"},
+					},
+				})
+				c.Assert(result, gc.FitsTypeOf, &params.ImportStorageResults{})
+				results := result.(*params.ImportStorageResults)
+				results.Results = []params.ImportStorageResult{{
+					Result: &params.ImportStorageDetails{StorageTag: "storage-pgdata-1"},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := storage.NewClient(apiCaller)
+	tag, err := client.RestoreSnapshot("nightly", "pgdata")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(tag, gc.Equals, names.NewStorageTag("pgdata/1"))
+}
+
+func (s *storageMockSuite) TestSnapshotsV6(c *gc.C) {
+	client := storage.NewClient(basetesting.BestVersionCaller{BestVersion: 6})
+	const expect = "this juju controller does not support storage snapshots"
+	err := client.CreateSnapshot("pgdata/0", "nightly")
+	c.Check(err, gc.ErrorMatches, expect)
+	_, err = client.ListSnapshots()
+	c.Check(err, gc.ErrorMatches, expect)
+	_, err = client.RemoveSnapshots([]string{"nightly"})
+	c.Check(err, gc.ErrorMatches, expect)
+	_, err = client.RestoreSnapshot("nightly", "pgdata")
+	c.Check(err, gc.ErrorMatches, expect)
+}
@@ -88,6 +88,18 @@ func (st *State) WatchVolumes(scope names.Tag) (watcher.StringsWatcher, error) {
 	return st.watchStorageEntities("WatchVolumes", scope)
 }
 
+// WatchVolumeResizes watches for changes to volumes scoped to the
+// entity with the specified tag, including requests to expand them.
+func (st *State) WatchVolumeResizes(scope names.Tag) (watcher.StringsWatcher, error) {
+	return st.watchStorageEntities("WatchVolumeResizes", scope)
+}
+
+// WatchVolumeSnapshots watches for changes to the volume snapshots
+// in the model, including requests to restore them.
+func (st *State) WatchVolumeSnapshots(scope names.Tag) (watcher.StringsWatcher, error) {
+	return st.watchStorageEntities("WatchVolumeSnapshots", scope)
+}
+
 // WatchVolumes watches for lifecycle changes to volumes scoped to the
 // entity with the specified tag.
 func (st *State) WatchFilesystems(scope names.Tag) (watcher.StringsWatcher, error) {
@@ -301,6 +313,61 @@ func (st *State) RemoveVolumeParams(tags []names.VolumeTag) ([]params.RemoveVolu
 	return results.Results, nil
 }
 
+// VolumeResizeParams returns the parameters for expanding the volumes
+// with the specified tags.
+func (st *State) VolumeResizeParams(tags []names.VolumeTag) ([]params.VolumeResizeParamsResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.VolumeResizeParamsResults
+	err := st.facade.FacadeCall("VolumeResizeParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// CancelVolumeResizes cancels the requests to expand the volumes
+// with the specified tags.
+func (st *State) CancelVolumeResizes(tags []names.VolumeTag) ([]params.ErrorResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall("CancelVolumeResizes", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// VolumeSnapshotParams returns the parameters for taking, restoring
+// or destroying the volume snapshots with the specified names.
+func (st *State) VolumeSnapshotParams(snapshotNames []string) ([]params.VolumeSnapshotParamsResult, error) {
+	args := params.VolumeSnapshotNames{Names: snapshotNames}
+	var results params.VolumeSnapshotParamsResults
+	err := st.facade.FacadeCall("VolumeSnapshotParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(snapshotNames) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(snapshotNames), len(results.Results))
+	}
+	return results.Results, nil
+}
+
 // FilesystemParams returns the parameters for creating the filesystems
 // with the specified tags.
 func (st *State) FilesystemParams(tags []names.FilesystemTag) ([]params.FilesystemParamsResult, error) {
@@ -385,6 +452,51 @@ func (st *State) SetVolumeInfo(volumes []params.Volume) ([]params.ErrorResult, e
 	return results.Results, nil
 }
 
+// SetVolumeSnapshotInfo records the details of volume snapshots
+// taken by the storage provider.
+func (st *State) SetVolumeSnapshotInfo(snapshots []params.VolumeSnapshot) ([]params.ErrorResult, error) {
+	args := params.VolumeSnapshots{Snapshots: snapshots}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall("SetVolumeSnapshotInfo", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(snapshots) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(snapshots), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// SetVolumeSnapshotRestored records the volumes restored from
+// volume snapshots.
+func (st *State) SetVolumeSnapshotRestored(restores []params.VolumeSnapshotRestore) ([]params.ErrorResult, error) {
+	args := params.VolumeSnapshotRestores{Restores: restores}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall("SetVolumeSnapshotRestored", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(restores) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(restores), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// RemoveVolumeSnapshots removes the destroyed volume snapshots with
+// the specified names from state.
+func (st *State) RemoveVolumeSnapshots(snapshotNames []string) ([]params.ErrorResult, error) {
+	args := params.VolumeSnapshotNames{Names: snapshotNames}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall("RemoveVolumeSnapshots", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(snapshotNames) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(snapshotNames), len(results.Results))
+	}
+	return results.Results, nil
+}
+
 // SetFilesystemInfo records the details of newly provisioned filesystems.
 func (st *State) SetFilesystemInfo(filesystems []params.Filesystem) ([]params.ErrorResult, error) {
 	args := params.Filesystems{Filesystems: filesystems}
@@ -3,10 +3,13 @@
 
 package uniter
 
+import "time"
+
 // Action represents a single instance of an Action call, by name and params.
 type Action struct {
-	name   string
-	params map[string]interface{}
+	name             string
+	params           map[string]interface{}
+	executionTimeout time.Duration
 }
 
 // NewAction makes a new Action with specified name and params map.
@@ -23,3 +26,9 @@ func (a *Action) Name() string {
 func (a *Action) Params() map[string]interface{} {
 	return a.params
 }
+
+// ExecutionTimeout retrieves how long the Action may run for,
+// or zero if it may run indefinitely.
+func (a *Action) ExecutionTimeout() time.Duration {
+	return a.executionTimeout
+}
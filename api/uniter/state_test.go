@@ -56,7 +56,7 @@ func (s *stateSuite) TestAllMachinePorts(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	// Open some ports on both units.
-	err = s.wordpressUnit.OpenPorts("tcp", 100, 200)
+	err = s.wordpressUnit.OpenPortsForEndpoint("url", "tcp", 100, 200)
 	c.Assert(err, jc.ErrorIsNil)
 	err = s.wordpressUnit.OpenPorts("udp", 10, 20)
 	c.Assert(err, jc.ErrorIsNil)
@@ -67,10 +67,23 @@ func (s *stateSuite) TestAllMachinePorts(c *gc.C) {
 
 	portsMap, err := s.uniter.AllMachinePorts(s.wordpressMachine.Tag().(names.MachineTag))
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(portsMap, jc.DeepEquals, map[network.PortRange]params.RelationUnit{
-		{100, 200, "tcp"}: {Unit: s.wordpressUnit.Tag().String()},
-		{10, 20, "udp"}:   {Unit: s.wordpressUnit.Tag().String()},
-		{201, 250, "tcp"}: {Unit: wordpressUnit1.Tag().String()},
-		{1, 8, "udp"}:     {Unit: wordpressUnit1.Tag().String()},
+	c.Assert(portsMap, jc.DeepEquals, map[network.PortRange]params.MachinePortRange{
+		{100, 200, "tcp"}: {
+			UnitTag:   s.wordpressUnit.Tag().String(),
+			PortRange: params.PortRange{100, 200, "tcp"},
+			Endpoint:  "url",
+		},
+		{10, 20, "udp"}: {
+			UnitTag:   s.wordpressUnit.Tag().String(),
+			PortRange: params.PortRange{10, 20, "udp"},
+		},
+		{201, 250, "tcp"}: {
+			UnitTag:   wordpressUnit1.Tag().String(),
+			PortRange: params.PortRange{201, 250, "tcp"},
+		},
+		{1, 8, "udp"}: {
+			UnitTag:   wordpressUnit1.Tag().String(),
+			PortRange: params.PortRange{1, 8, "udp"},
+		},
 	})
 }
@@ -459,6 +459,14 @@ func (u *Unit) AvailabilityZone() (string, error) {
 // OpenPorts sets the policy of the port range with protocol to be
 // opened.
 func (u *Unit) OpenPorts(protocol string, fromPort, toPort int) error {
+	return u.OpenPortsForEndpoint("", protocol, fromPort, toPort)
+}
+
+// OpenPortsForEndpoint sets the policy of the port range with protocol
+// to be opened for the given endpoint. The range is only accessible from
+// the sources the endpoint is exposed to; the empty endpoint opens it for
+// all of the unit's endpoints.
+func (u *Unit) OpenPortsForEndpoint(endpoint, protocol string, fromPort, toPort int) error {
 	var result params.ErrorResults
 	args := params.EntitiesPortRanges{
 		Entities: []params.EntityPortRange{{
@@ -466,6 +474,7 @@ func (u *Unit) OpenPorts(protocol string, fromPort, toPort int) error {
 			Protocol: protocol,
 			FromPort: fromPort,
 			ToPort:   toPort,
+			Endpoint: endpoint,
 		}},
 	}
 	err := u.st.facade.FacadeCall("OpenPorts", args, &result)
@@ -654,6 +663,24 @@ func (u *Unit) LogActionMessage(tag names.ActionTag, message string) error {
 	return result.OneError()
 }
 
+// LogActionOutput records lines written by the running action
+// to the given output stream.
+func (u *Unit) LogActionOutput(tag names.ActionTag, stream string, lines []string) error {
+	if u.st.facade.BestAPIVersion() < 15 {
+		return errors.NotImplementedf("LogActionOutput() (need V15+)")
+	}
+
+	var result params.ErrorResults
+	args := params.ActionOutputParams{
+		Output: []params.ActionOutput{{Tag: tag.String(), Stream: stream, Lines: lines}},
+	}
+	err := u.st.facade.FacadeCall("LogActionsOutput", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 // UpgradeSeriesStatus returns the upgrade series status of a unit from remote state
 func (u *Unit) UpgradeSeriesStatus() (model.UpgradeSeriesStatus, error) {
 	res, err := u.st.UpgradeSeriesUnitStatus()
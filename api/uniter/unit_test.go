@@ -165,6 +165,23 @@ func (s *unitSuite) TestLogActionMessage(c *gc.C) {
 	c.Assert(messages[0].Timestamp(), gc.NotNil)
 }
 
+func (s *unitSuite) TestLogActionOutput(c *gc.C) {
+	anAction, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = anAction.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.apiUnit.LogActionOutput(anAction.ActionTag(), "stderr", []string{"hello", "world"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	anAction, err = s.Model.Action(anAction.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := anAction.Messages()
+	c.Assert(messages, gc.HasLen, 2)
+	c.Assert(messages[0].Message(), gc.Equals, "hello")
+	c.Assert(messages[0].Stream(), gc.Equals, "stderr")
+	c.Assert(messages[1].Message(), gc.Equals, "world")
+}
+
 func (s *unitSuite) TestEnsureDead(c *gc.C) {
 	c.Assert(s.wordpressUnit.Life(), gc.Equals, state.Alive)
 
@@ -481,6 +498,17 @@ func (s *unitSuite) TestOpenClosePortRanges(c *gc.C) {
 	c.Assert(ports, gc.HasLen, 0)
 }
 
+func (s *unitSuite) TestOpenPortsForEndpoint(c *gc.C) {
+	err := s.apiUnit.OpenPortsForEndpoint("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	endpoints, err := s.wordpressUnit.OpenedPortEndpoints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(endpoints, jc.DeepEquals, map[corenetwork.PortRange]string{
+		{Protocol: "tcp", FromPort: 80, ToPort: 80}: "url",
+	})
+}
+
 func (s *unitSuite) TestGetSetCharmURL(c *gc.C) {
 	// No charm URL set yet.
 	curl, ok := s.wordpressUnit.CharmURL()
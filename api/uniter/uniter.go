@@ -224,8 +224,9 @@ func (st *State) Action(tag names.ActionTag) (*Action, error) {
 		return nil, err
 	}
 	return &Action{
-		name:   result.Action.Name,
-		params: result.Action.Parameters,
+		name:             result.Action.Name,
+		params:           result.Action.Parameters,
+		executionTimeout: result.Action.ExecutionTimeout,
 	}, nil
 }
 
@@ -332,9 +333,9 @@ func (st *State) Model() (*model.Model, error) {
 }
 
 // AllMachinePorts returns all port ranges currently open on the given
-// machine, mapped to the tags of the unit that opened them and the
-// relation that applies.
-func (st *State) AllMachinePorts(machineTag names.MachineTag) (map[network.PortRange]params.RelationUnit, error) {
+// machine, mapped to the tags of the unit that opened them, the
+// relation that applies and the endpoint they were opened for.
+func (st *State) AllMachinePorts(machineTag names.MachineTag) (map[network.PortRange]params.MachinePortRange, error) {
 	if st.BestAPIVersion() < 1 {
 		// AllMachinePorts() was introduced in UniterAPIV1.
 		return nil, errors.NotImplementedf("AllMachinePorts() (need V1+)")
@@ -354,13 +355,9 @@ func (st *State) AllMachinePorts(machineTag names.MachineTag) (map[network.PortR
 	if result.Error != nil {
 		return nil, result.Error
 	}
-	portsMap := make(map[network.PortRange]params.RelationUnit)
+	portsMap := make(map[network.PortRange]params.MachinePortRange)
 	for _, ports := range result.Ports {
-		portRange := ports.PortRange.NetworkPortRange()
-		portsMap[portRange] = params.RelationUnit{
-			Unit:     ports.UnitTag,
-			Relation: ports.RelationTag,
-		}
+		portsMap[ports.PortRange.NetworkPortRange()] = ports
 	}
 	return portsMap, nil
 }
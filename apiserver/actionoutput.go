@@ -0,0 +1,159 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/httpcontext"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/websocket"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// actionOutputHandler takes requests to watch the messages logged by an
+// action, and the output it writes to stdout and stderr, as they happen.
+type actionOutputHandler struct {
+	ctxt          httpContext
+	authenticator httpcontext.Authenticator
+	authorizer    httpcontext.Authorizer
+}
+
+// ServeHTTP will serve up connections as a websocket streaming the
+// messages of the action with the id given in the URL. Messages already
+// logged are sent first, then new ones as they are logged; the socket is
+// closed once the action has finished.
+//
+// As with debug-log, authentication and authorization are done after the
+// http request has been upgraded to a websocket, so that any auth failure
+// is returned in the initial error sent over the websocket.
+func (h *actionOutputHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	handler := func(conn *websocket.Conn) {
+		socket := &actionOutputSocketImpl{debugLogSocketImpl{conn}}
+		defer conn.Close()
+
+		authInfo, err := h.authenticator.Authenticate(req)
+		if err != nil {
+			socket.sendError(errors.Annotate(err, "authentication failed"))
+			return
+		}
+		if err := h.authorizer.Authorize(authInfo); err != nil {
+			socket.sendError(errors.Annotate(err, "authorization failed"))
+			return
+		}
+
+		st, err := h.ctxt.stateForRequestUnauthenticated(req)
+		if err != nil {
+			socket.sendError(err)
+			return
+		}
+		defer st.Release()
+
+		// Notice when the client goes away, so that we don't
+		// wait for an action which may never run.
+		closed := make(chan struct{})
+		go func() {
+			defer close(closed)
+			for {
+				if _, _, err := conn.NextReader(); err != nil {
+					return
+				}
+			}
+		}()
+
+		id := req.URL.Query().Get(":id")
+		if err := streamActionOutput(st.State, id, socket, h.ctxt.stop(), closed); err != nil {
+			if isBrokenPipe(err) {
+				logger.Tracef("action output handler stopped (client disconnected)")
+			} else {
+				logger.Errorf("action output handler error: %v", err)
+			}
+		}
+	}
+	websocket.Serve(w, req, handler)
+}
+
+// actionOutputSocket describes the functionality required to send
+// the messages of an action to the client.
+type actionOutputSocket interface {
+	// sendOk sends a nil error response, indicating there were no errors.
+	sendOk()
+
+	// sendError sends a JSON-encoded error response.
+	sendError(err error)
+
+	// sendMessage sends message JSON encoded.
+	sendMessage(message *params.ActionMessage) error
+}
+
+// actionOutputSocketImpl implements the actionOutputSocket interface.
+type actionOutputSocketImpl struct {
+	debugLogSocketImpl
+}
+
+func (s *actionOutputSocketImpl) sendMessage(message *params.ActionMessage) error {
+	return s.conn.WriteJSON(message)
+}
+
+func streamActionOutput(
+	st *state.State,
+	id string,
+	socket actionOutputSocket,
+	stop, closed <-chan struct{},
+) error {
+	if !names.IsValidAction(id) {
+		socket.sendError(errors.NotValidf("action id %q", id))
+		return nil
+	}
+	m, err := st.Model()
+	if err != nil {
+		socket.sendError(err)
+		return errors.Trace(err)
+	}
+	if _, err := m.Action(id); err != nil {
+		socket.sendError(err)
+		return nil
+	}
+
+	w := st.WatchAction(id)
+	defer w.Stop()
+	socket.sendOk()
+
+	var sent int
+	for {
+		select {
+		case <-stop:
+			return nil
+		case <-closed:
+			return nil
+		case _, ok := <-w.Changes():
+			if !ok {
+				return watcher.EnsureErr(w)
+			}
+		}
+		action, err := m.Action(id)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		messages := action.Messages()
+		for _, msg := range messages[sent:] {
+			if err := socket.sendMessage(&params.ActionMessage{
+				Timestamp: msg.Timestamp(),
+				Message:   msg.Message(),
+				Stream:    msg.Stream(),
+			}); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		sent = len(messages)
+		switch action.Status() {
+		case state.ActionCompleted, state.ActionFailed, state.ActionCancelled:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"net/http"
+
+	"github.com/gorilla/websocket"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/websocket/websockettest"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type actionOutputSuite struct {
+	apiserverBaseSuite
+}
+
+var _ = gc.Suite(&actionOutputSuite{})
+
+func (s *actionOutputSuite) TestNoAuth(c *gc.C) {
+	conn, _, err := s.dialWebsocketInternal(c, "1", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+
+	websockettest.AssertJSONError(c, conn, "authentication failed: no credentials provided")
+	websockettest.AssertWebsocketClosed(c, conn)
+}
+
+func (s *actionOutputSuite) TestUnitLoginsRejected(c *gc.C) {
+	u, password := s.Factory.MakeUnitReturningPassword(c, nil)
+	header := utils.BasicAuthHeader(u.Tag().String(), password)
+
+	conn, _, err := s.dialWebsocketInternal(c, "1", header)
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+
+	websockettest.AssertJSONError(c, conn, "authorization failed: tag kind unit not valid")
+	websockettest.AssertWebsocketClosed(c, conn)
+}
+
+func (s *actionOutputSuite) TestActionNotFound(c *gc.C) {
+	conn := s.dialWebsocket(c, "42")
+	defer conn.Close()
+
+	websockettest.AssertJSONError(c, conn, `action "42" not found`)
+	websockettest.AssertWebsocketClosed(c, conn)
+}
+
+func (s *actionOutputSuite) TestStreamsMessagesUntilFinished(c *gc.C) {
+	ch := s.Factory.MakeCharm(c, &factory.CharmParams{Name: "dummy"})
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{Charm: ch})
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: app, SetCharmURL: true})
+	action, err := unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	action, err = action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = action.Log("starting")
+	c.Assert(err, jc.ErrorIsNil)
+
+	conn := s.dialWebsocket(c, action.Id())
+	defer conn.Close()
+	websockettest.AssertJSONInitialErrorNil(c, conn)
+	s.assertMessage(c, conn, params.ActionMessage{Message: "starting"})
+
+	err = action.LogOutput("stdout", []string{"hello"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertMessage(c, conn, params.ActionMessage{Message: "hello", Stream: "stdout"})
+
+	_, err = action.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	websockettest.AssertWebsocketClosed(c, conn)
+}
+
+func (s *actionOutputSuite) assertMessage(c *gc.C, conn *websocket.Conn, expected params.ActionMessage) {
+	var msg params.ActionMessage
+	err := conn.ReadJSON(&msg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(msg.Timestamp.IsZero(), jc.IsFalse)
+	msg.Timestamp = expected.Timestamp
+	c.Assert(msg, jc.DeepEquals, expected)
+}
+
+func (s *actionOutputSuite) dialWebsocket(c *gc.C, id string) *websocket.Conn {
+	header := utils.BasicAuthHeader(s.Owner.String(), ownerPassword)
+	conn, _, err := s.dialWebsocketInternal(c, id, header)
+	c.Assert(err, jc.ErrorIsNil)
+	return conn
+}
+
+func (s *actionOutputSuite) dialWebsocketInternal(
+	c *gc.C, id string, header http.Header,
+) (*websocket.Conn, *http.Response, error) {
+	url := s.URL("/model/"+s.State.ModelUUID()+"/actions/"+id+"/output", nil)
+	url.Scheme = "wss"
+	return dialWebsocketFromURL(c, url.String(), header)
+}
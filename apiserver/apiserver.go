@@ -572,6 +572,11 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 		httpCtxt, srv.authenticator,
 		tagKindAuthorizer{names.MachineTagKind, names.ControllerAgentTagKind, names.UserTagKind, names.ApplicationTagKind})
 	pubsubHandler := newPubSubHandler(httpCtxt, srv.shared.centralHub)
+	actionOutputHandler := &actionOutputHandler{
+		ctxt:          httpCtxt,
+		authenticator: srv.authenticator,
+		authorizer:    tagKindAuthorizer{names.UserTagKind},
+	}
 	logSinkHandler := logsink.NewHTTPHandler(
 		newAgentLogWriteCloserFunc(httpCtxt, srv.logSinkWriter, &srv.dbloggers),
 		httpCtxt.stop(),
@@ -712,6 +717,13 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 		// The authentication is handled within the debugLogHandler in order
 		// for discharge required errors to be handled correctly.
 		unauthenticated: true,
+	}, {
+		pattern: modelRoutePrefix + "/actions/:id/output",
+		handler: actionOutputHandler,
+		tracked: true,
+		// The authentication is handled within the actionOutputHandler
+		// in order for discharge required errors to be handled correctly.
+		unauthenticated: true,
 	}, {
 		pattern:    modelRoutePrefix + "/logsink",
 		handler:    logSinkHandler,
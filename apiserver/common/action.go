@@ -126,6 +126,10 @@ func FinishActions(args params.ActionExecutionResults, actionFn func(string) (st
 
 		_, err = action.Finish(actionResults)
 		if err != nil {
+			// The action may have been expired while it was running.
+			if current, getErr := actionFn(arg.ActionTag); getErr == nil && isFinishedAction(current) {
+				err = ErrActionNotAvailable
+			}
 			results.Results[i].Error = ServerError(err)
 			continue
 		}
@@ -134,6 +138,15 @@ func FinishActions(args params.ActionExecutionResults, actionFn func(string) (st
 	return results
 }
 
+// isFinishedAction reports whether the action has reached a final status.
+func isFinishedAction(action state.Action) bool {
+	switch action.Status() {
+	case state.ActionCompleted, state.ActionCancelled, state.ActionFailed:
+		return true
+	}
+	return false
+}
+
 // Actions returns the Actions by Tags passed in and ensures that the receiver asking for
 // them is the same one that has the action.
 // It's a helper function currently used by the uniter and by machineactions.
@@ -154,8 +167,9 @@ func Actions(args params.Entities, actionFn func(string) (state.Action, error))
 			continue
 		}
 		results.Results[i].Action = &params.Action{
-			Name:       action.Name(),
-			Parameters: action.Parameters(),
+			Name:             action.Name(),
+			Parameters:       action.Parameters(),
+			ExecutionTimeout: action.Timeouts().Execution,
 		}
 	}
 
@@ -239,12 +253,15 @@ func MakeActionResult(actionReceiverTag names.Tag, action state.Action, compat b
 	if !compat {
 		convertActionOutput(output)
 	}
+	timeouts := action.Timeouts()
 	result := params.ActionResult{
 		Action: &params.Action{
-			Receiver:   actionReceiverTag.String(),
-			Tag:        action.ActionTag().String(),
-			Name:       action.Name(),
-			Parameters: action.Parameters(),
+			Receiver:         actionReceiverTag.String(),
+			Tag:              action.ActionTag().String(),
+			Name:             action.Name(),
+			Parameters:       action.Parameters(),
+			QueueTimeout:     timeouts.Queue,
+			ExecutionTimeout: timeouts.Execution,
 		},
 		Status:    string(action.Status()),
 		Message:   message,
@@ -252,11 +269,13 @@ func MakeActionResult(actionReceiverTag names.Tag, action state.Action, compat b
 		Enqueued:  action.Enqueued(),
 		Started:   action.Started(),
 		Completed: action.Completed(),
+		Operation: action.Operation(),
 	}
 	for _, m := range action.Messages() {
 		result.Log = append(result.Log, params.ActionMessage{
 			Timestamp: m.Timestamp(),
 			Message:   m.Message(),
+			Stream:    m.Stream(),
 		})
 	}
 
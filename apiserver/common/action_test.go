@@ -166,6 +166,7 @@ func (s *actionsSuite) TestFinishActions(c *gc.C) {
 			{ActionTag: "notfound"},
 			{ActionTag: "convertFail", Status: "failStatus"},
 			{ActionTag: "finishFail", Status: string(state.ActionCancelled)},
+			{ActionTag: "expired", Status: string(state.ActionCompleted)},
 		},
 	}
 	expectErr := errors.New("explosivo")
@@ -173,6 +174,7 @@ func (s *actionsSuite) TestFinishActions(c *gc.C) {
 		"success":     fakeAction{},
 		"convertFail": fakeAction{},
 		"finishFail":  fakeAction{finishErr: expectErr},
+		"expired":     fakeAction{finishErr: expectErr, status: state.ActionFailed},
 	})
 	results := common.FinishActions(args, actionFn)
 	c.Assert(results, jc.DeepEquals, params.ErrorResults{
@@ -181,6 +183,7 @@ func (s *actionsSuite) TestFinishActions(c *gc.C) {
 			{common.ServerError(actionNotFoundErr)},
 			{common.ServerError(errors.New("unrecognized action status 'failStatus'"))},
 			{common.ServerError(expectErr)},
+			{common.ServerError(common.ErrActionNotAvailable)},
 		},
 	})
 }
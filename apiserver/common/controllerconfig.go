@@ -8,6 +8,7 @@ import (
 	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/state"
 )
@@ -37,6 +38,9 @@ func (s *ControllerConfigAPI) ControllerConfig() (params.ControllerConfigResult,
 	if err != nil {
 		return result, err
 	}
+	for key := range controller.SecretAttributes {
+		delete(config, key)
+	}
 	result.Config = params.ControllerConfig(config)
 	return result, nil
 }
@@ -42,10 +42,12 @@ func (f *fakeControllerAccessor) ControllerConfig() (controller.Config, error) {
 		return nil, f.controllerConfigError
 	}
 	return map[string]interface{}{
-		controller.ControllerUUIDKey: testing.ControllerTag.Id(),
-		controller.CACertKey:         testing.CACert,
-		controller.APIPort:           4321,
-		controller.StatePort:         1234,
+		controller.ControllerUUIDKey:     testing.ControllerTag.Id(),
+		controller.CACertKey:             testing.CACert,
+		controller.APIPort:               4321,
+		controller.StatePort:             1234,
+		controller.BackupTargetAccessKey: "AKID",
+		controller.BackupTargetSecretKey: "secret",
 	}, nil
 }
 
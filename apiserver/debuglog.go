@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"regexp"
 	"strconv"
 	"syscall"
 	"time"
@@ -19,6 +20,7 @@ import (
 	"github.com/juju/juju/apiserver/httpcontext"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/apiserver/websocket"
+	"github.com/juju/juju/core/logfields"
 	"github.com/juju/juju/state"
 )
 
@@ -77,6 +79,11 @@ func newDebugLogHandler(
 //   excludeEntity -> []string - lists entity tags to exclude from the response
 //      - as with include, it may finish with a '*'
 //   excludeModule -> []string - lists logging modules to exclude from the response
+//   includeField -> []string - lists key=value fields that each line must have
+//   includeMessage -> []string - lists regular expressions, one of which
+//      each line's message must match
+//   excludeMessage -> []string - lists regular expressions that each line's
+//      message must not match
 //   limit -> uint - show *at most* this many lines
 //   backlog -> uint
 //      - go back this many lines from the end before starting to filter
@@ -85,6 +92,9 @@ func newDebugLogHandler(
 //   replay -> string - one of [true, false], if true, start the file from the start
 //   noTail -> string - one of [true, false], if true, existing logs are sent back,
 //      - but the command does not wait for new ones.
+//   startTime -> string - only send lines logged at or after this RFC3339 time
+//   endTime -> string - only send lines logged before this RFC3339 time; the
+//      request ends once this time has passed
 func (h *debugLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	handler := func(conn *websocket.Conn) {
 		socket := &debugLogSocketImpl{conn}
@@ -180,16 +190,20 @@ func (s *debugLogSocketImpl) sendLogRecord(record *params.LogMessage) error {
 
 // debugLogParams contains the parsed debuglog API request parameters.
 type debugLogParams struct {
-	startTime     time.Time
-	maxLines      uint
-	fromTheStart  bool
-	noTail        bool
-	backlog       uint
-	filterLevel   loggo.Level
-	includeEntity []string
-	excludeEntity []string
-	includeModule []string
-	excludeModule []string
+	startTime      time.Time
+	endTime        time.Time
+	maxLines       uint
+	fromTheStart   bool
+	noTail         bool
+	backlog        uint
+	filterLevel    loggo.Level
+	includeEntity  []string
+	excludeEntity  []string
+	includeModule  []string
+	excludeModule  []string
+	includeFields  map[string]string
+	includeMessage []string
+	excludeMessage []string
 }
 
 func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
@@ -245,10 +259,45 @@ func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
 		params.startTime = startTime
 	}
 
+	if value := queryMap.Get("endTime"); value != "" {
+		endTime, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return params, errors.Errorf("end time %q is not a valid time in RFC3339 format", value)
+		}
+		if endTime.Before(params.startTime) {
+			return params, errors.Errorf("end time %q is before the start time", value)
+		}
+		params.endTime = endTime
+	}
+
 	params.includeEntity = queryMap["includeEntity"]
 	params.excludeEntity = queryMap["excludeEntity"]
 	params.includeModule = queryMap["includeModule"]
 	params.excludeModule = queryMap["excludeModule"]
 
+	for _, value := range queryMap["includeField"] {
+		key, fieldValue, err := logfields.ParseKeyValue(value)
+		if err != nil {
+			return params, errors.Trace(err)
+		}
+		if _, ok := params.includeFields[key]; ok {
+			return params, errors.Errorf("field %q specified more than once", key)
+		}
+		if params.includeFields == nil {
+			params.includeFields = make(map[string]string)
+		}
+		params.includeFields[key] = fieldValue
+	}
+
+	for _, name := range []string{"includeMessage", "excludeMessage"} {
+		for _, value := range queryMap[name] {
+			if _, err := regexp.Compile(value); err != nil {
+				return params, errors.Errorf("%s value %q is not a valid regular expression", name, value)
+			}
+		}
+	}
+	params.includeMessage = queryMap["includeMessage"]
+	params.excludeMessage = queryMap["excludeMessage"]
+
 	return params, nil
 }
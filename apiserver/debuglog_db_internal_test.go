@@ -14,6 +14,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/logfields"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -36,16 +37,21 @@ func (s *debugLogDBIntSuite) SetUpTest(c *gc.C) {
 
 func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 	t1 := time.Date(2016, 11, 30, 10, 51, 0, 0, time.UTC)
+	t2 := time.Date(2016, 11, 30, 11, 51, 0, 0, time.UTC)
 	reqParams := debugLogParams{
-		fromTheStart:  false,
-		noTail:        true,
-		backlog:       11,
-		startTime:     t1,
-		filterLevel:   loggo.INFO,
-		includeEntity: []string{"foo"},
-		includeModule: []string{"bar"},
-		excludeEntity: []string{"baz"},
-		excludeModule: []string{"qux"},
+		fromTheStart:   false,
+		noTail:         true,
+		backlog:        11,
+		startTime:      t1,
+		endTime:        t2,
+		filterLevel:    loggo.INFO,
+		includeEntity:  []string{"foo"},
+		includeModule:  []string{"bar"},
+		excludeEntity:  []string{"baz"},
+		excludeModule:  []string{"qux"},
+		includeFields:  map[string]string{"unit": "mysql/0"},
+		includeMessage: []string{"fail"},
+		excludeMessage: []string{"^ignore"},
 	}
 
 	called := false
@@ -55,6 +61,7 @@ func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 		// Start time will be used once the client is extended to send
 		// time range arguments.
 		c.Assert(params.StartTime, gc.Equals, t1)
+		c.Assert(params.EndTime, gc.Equals, t2)
 		c.Assert(params.NoTail, jc.IsTrue)
 		c.Assert(params.MinLevel, gc.Equals, loggo.INFO)
 		c.Assert(params.InitialLines, gc.Equals, 11)
@@ -62,6 +69,9 @@ func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 		c.Assert(params.IncludeModule, jc.DeepEquals, []string{"bar"})
 		c.Assert(params.ExcludeEntity, jc.DeepEquals, []string{"baz"})
 		c.Assert(params.ExcludeModule, jc.DeepEquals, []string{"qux"})
+		c.Assert(params.IncludeFields, jc.DeepEquals, map[string]string{"unit": "mysql/0"})
+		c.Assert(params.IncludeMessage, jc.DeepEquals, []string{"fail"})
+		c.Assert(params.ExcludeMessage, jc.DeepEquals, []string{"^ignore"})
 
 		return newFakeLogTailer(), nil
 	})
@@ -114,6 +124,7 @@ func (s *debugLogDBIntSuite) TestFullRequest(c *gc.C) {
 		Location: "go.go:22",
 		Level:    loggo.ERROR,
 		Message:  "whoops",
+		Fields:   map[string]string{"unit": "foo/2", "hook": "install"},
 	}
 	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params state.LogTailerParams) (state.LogTailer, error) {
 		return tailer, nil
@@ -125,7 +136,7 @@ func (s *debugLogDBIntSuite) TestFullRequest(c *gc.C) {
 	s.assertOutput(c, []string{
 		"ok", // sendOk() call needs to happen first.
 		"machine-99: 2015-06-19 15:34:37 INFO some.where code.go:42 stuff happened\n",
-		"unit-foo-2: 2015-06-19 15:36:40 ERROR else.where go.go:22 whoops\n",
+		"unit-foo-2: 2015-06-19 15:36:40 ERROR else.where go.go:22 whoops [fields: hook=install unit=foo/2]\n",
 	})
 
 	// Check the request stops when requested.
@@ -307,7 +318,7 @@ func (s *fakeDebugLogSocket) sendLogRecord(r *params.LogMessage) error {
 		r.Severity,
 		r.Module,
 		r.Location,
-		r.Message)
+		logfields.Format(r.Message, r.Fields))
 	return nil
 }
 
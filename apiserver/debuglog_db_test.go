@@ -35,6 +35,33 @@ func (s *debugLogDBSuite) TestBadParams(c *gc.C) {
 	websockettest.AssertWebsocketClosed(c, conn)
 }
 
+func (s *debugLogDBSuite) TestBadFieldParam(c *gc.C) {
+	conn := s.dialWebsocket(c, url.Values{"includeField": {"unit"}})
+	defer conn.Close()
+
+	websockettest.AssertJSONError(c, conn, `log field "unit" \(expected key=value\) not valid`)
+	websockettest.AssertWebsocketClosed(c, conn)
+}
+
+func (s *debugLogDBSuite) TestBadMessagePattern(c *gc.C) {
+	conn := s.dialWebsocket(c, url.Values{"excludeMessage": {"fail(ed"}})
+	defer conn.Close()
+
+	websockettest.AssertJSONError(c, conn, `excludeMessage value "fail\(ed" is not a valid regular expression`)
+	websockettest.AssertWebsocketClosed(c, conn)
+}
+
+func (s *debugLogDBSuite) TestEndTimeBeforeStartTime(c *gc.C) {
+	conn := s.dialWebsocket(c, url.Values{
+		"startTime": {"2020-01-02T00:00:00Z"},
+		"endTime":   {"2020-01-01T00:00:00Z"},
+	})
+	defer conn.Close()
+
+	websockettest.AssertJSONError(c, conn, `end time "2020-01-01T00:00:00Z" is before the start time`)
+	websockettest.AssertWebsocketClosed(c, conn)
+}
+
 func (s *debugLogDBSuite) TestWithHTTP(c *gc.C) {
 	uri := s.logURL("http", nil).String()
 	apitesting.SendHTTPRequest(c, apitesting.HTTPRequestParams{
@@ -23,6 +23,11 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.storageprovisioner")
 
+// StorageProvisionerAPIv5 provides the StorageProvisioner API v5 facade.
+type StorageProvisionerAPIv5 struct {
+	*StorageProvisionerAPIv4
+}
+
 // StorageProvisionerAPIv4 provides the StorageProvisioner API v4 facade.
 type StorageProvisionerAPIv4 struct {
 	*StorageProvisionerAPIv3
@@ -48,6 +53,11 @@ type StorageProvisionerAPIv3 struct {
 	getAttachmentAuthFunc    func() (func(names.Tag, names.Tag) bool, error)
 }
 
+// NewStorageProvisionerAPIv5 creates a new server-side StorageProvisioner v5 facade.
+func NewStorageProvisionerAPIv5(v4 *StorageProvisionerAPIv4) *StorageProvisionerAPIv5 {
+	return &StorageProvisionerAPIv5{v4}
+}
+
 // NewStorageProvisionerAPIv4 creates a new server-side StorageProvisioner v4 facade.
 func NewStorageProvisionerAPIv4(v3 *StorageProvisionerAPIv3) *StorageProvisionerAPIv4 {
 	return &StorageProvisionerAPIv4{v3}
@@ -320,6 +330,20 @@ func (s *StorageProvisionerAPIv3) WatchVolumes(args params.Entities) (params.Str
 	return s.watchStorageEntities(args, s.sb.WatchModelVolumes, s.sb.WatchMachineVolumes, nil)
 }
 
+// WatchVolumeResizes watches for changes to volumes scoped to the
+// entity with the tag passed to NewState, including requests to
+// expand them. Only model-scoped volumes may be expanded.
+func (s *StorageProvisionerAPIv5) WatchVolumeResizes(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.sb.WatchModelVolumeResizes, nil, nil)
+}
+
+// WatchVolumeSnapshots watches for changes to the volume snapshots
+// in the model, including requests to restore them. Only model-scoped
+// volumes may be snapshotted.
+func (s *StorageProvisionerAPIv5) WatchVolumeSnapshots(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.sb.WatchModelVolumeSnapshots, nil, nil)
+}
+
 // WatchFilesystems watches for changes to filesystems scoped
 // to the entity with the tag passed to NewState.
 func (s *StorageProvisionerAPIv3) WatchFilesystems(args params.Entities) (params.StringsWatchResults, error) {
@@ -351,12 +375,17 @@ func (s *StorageProvisionerAPIv3) watchStorageEntities(
 		var w state.StringsWatcher
 		switch tag := tag.(type) {
 		case names.MachineTag:
-			w = watchMachineStorage(tag)
+			if watchMachineStorage != nil {
+				w = watchMachineStorage(tag)
+			}
 		case names.ModelTag:
 			w = watchEnvironStorage()
 		case names.ApplicationTag:
-			w = watchApplicationStorage(tag)
-		default:
+			if watchApplicationStorage != nil {
+				w = watchApplicationStorage(tag)
+			}
+		}
+		if w == nil {
 			return "", nil, common.ServerError(errors.NotSupportedf("watching storage for %v", tag))
 		}
 
@@ -873,6 +902,269 @@ func (s *StorageProvisionerAPIv4) RemoveVolumeParams(args params.Entities) (para
 	return results, nil
 }
 
+// VolumeResizeParams returns the parameters for expanding the volumes
+// with the specified tags. A not found error is returned for each volume
+// that has no pending expansion.
+func (s *StorageProvisionerAPIv5) VolumeResizeParams(args params.Entities) (params.VolumeResizeParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.VolumeResizeParamsResults{}, err
+	}
+	results := params.VolumeResizeParamsResults{
+		Results: make([]params.VolumeResizeParamsResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) (params.VolumeResizeParams, error) {
+		tag, err := names.ParseVolumeTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return params.VolumeResizeParams{}, common.ErrPerm
+		}
+		volume, err := s.sb.Volume(tag)
+		if errors.IsNotFound(err) {
+			return params.VolumeResizeParams{}, common.ErrPerm
+		} else if err != nil {
+			return params.VolumeResizeParams{}, err
+		}
+		size, ok := volume.RequestedSize()
+		if !ok {
+			return params.VolumeResizeParams{}, errors.NotFoundf(
+				"resize request for %s", names.ReadableString(tag),
+			)
+		}
+		volumeInfo, err := volume.Info()
+		if err != nil {
+			return params.VolumeResizeParams{}, err
+		}
+		provider, _, err := storagecommon.StoragePoolConfig(
+			volumeInfo.Pool, s.poolManager, s.registry,
+		)
+		if err != nil {
+			return params.VolumeResizeParams{}, err
+		}
+		return params.VolumeResizeParams{
+			VolumeTag: tag.String(),
+			VolumeId:  volumeInfo.VolumeId,
+			Provider:  string(provider),
+			Size:      size,
+		}, nil
+	}
+	for i, arg := range args.Entities {
+		var result params.VolumeResizeParamsResult
+		resizeParams, err := one(arg)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = resizeParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+// CancelVolumeResizes cancels the requests to expand the volumes with
+// the specified tags. The storage provisioner cancels requests that the
+// storage provider cannot satisfy.
+func (s *StorageProvisionerAPIv5) CancelVolumeResizes(args params.Entities) (params.ErrorResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) error {
+		tag, err := names.ParseVolumeTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return common.ErrPerm
+		}
+		err = s.sb.CancelVolumeResize(tag)
+		if errors.IsNotFound(err) {
+			return common.ErrPerm
+		}
+		return err
+	}
+	for i, arg := range args.Entities {
+		err := one(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// volumeSnapshot returns the volume snapshot with the specified name,
+// if the snapshot's volume may be accessed.
+func (s *StorageProvisionerAPIv5) volumeSnapshot(name string, canAccess common.AuthFunc) (state.VolumeSnapshot, error) {
+	snapshot, err := s.sb.VolumeSnapshot(name)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess(snapshot.Volume()) {
+		return nil, common.ErrPerm
+	}
+	return snapshot, nil
+}
+
+// VolumeSnapshotParams returns the parameters for taking, restoring
+// or destroying the volume snapshots with the specified names.
+func (s *StorageProvisionerAPIv5) VolumeSnapshotParams(args params.VolumeSnapshotNames) (params.VolumeSnapshotParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.VolumeSnapshotParamsResults{}, err
+	}
+	modelCfg, err := s.st.ModelConfig()
+	if err != nil {
+		return params.VolumeSnapshotParamsResults{}, err
+	}
+	controllerCfg, err := s.st.ControllerConfig()
+	if err != nil {
+		return params.VolumeSnapshotParamsResults{}, err
+	}
+	results := params.VolumeSnapshotParamsResults{
+		Results: make([]params.VolumeSnapshotParamsResult, len(args.Names)),
+	}
+	one := func(name string) (params.VolumeSnapshotParams, error) {
+		snapshot, err := s.volumeSnapshot(name, canAccess)
+		if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		provider, cfg, err := storagecommon.StoragePoolConfig(
+			snapshot.Pool(), s.poolManager, s.registry,
+		)
+		if err != nil {
+			return params.VolumeSnapshotParams{}, err
+		}
+		snapshotTags, err := storagecommon.StorageTags(
+			nil, modelCfg.UUID(), controllerCfg.ControllerUUID(), modelCfg,
+		)
+		if err != nil {
+			return params.VolumeSnapshotParams{}, errors.Annotate(err, "computing storage tags")
+		}
+		result := params.VolumeSnapshotParams{
+			Name:       snapshot.Name(),
+			Life:       life.Value(snapshot.Life().String()),
+			VolumeTag:  snapshot.Volume().String(),
+			Provider:   string(provider),
+			Attributes: cfg.Attrs(),
+			Tags:       snapshotTags,
+		}
+		// The volume may have been removed since the snapshot
+		// was taken, in which case there is no volume ID.
+		volume, err := s.sb.Volume(snapshot.Volume())
+		if err == nil {
+			if volumeInfo, err := volume.Info(); err == nil {
+				result.VolumeId = volumeInfo.VolumeId
+			}
+		} else if !errors.IsNotFound(err) {
+			return params.VolumeSnapshotParams{}, err
+		}
+		if info, err := snapshot.Info(); err == nil {
+			result.Info = &params.VolumeSnapshotInfo{
+				SnapshotId: info.SnapshotId,
+				Size:       info.Size,
+				Ready:      info.Ready,
+			}
+		} else if !errors.IsNotProvisioned(err) {
+			return params.VolumeSnapshotParams{}, err
+		}
+		if storageTag, ok := snapshot.PendingRestore(); ok {
+			result.RestoreStorageTag = storageTag.String()
+		}
+		return result, nil
+	}
+	for i, name := range args.Names {
+		var result params.VolumeSnapshotParamsResult
+		snapshotParams, err := one(name)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = snapshotParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+// SetVolumeSnapshotInfo records the details of volume snapshots
+// taken by the storage provider.
+func (s *StorageProvisionerAPIv5) SetVolumeSnapshotInfo(args params.VolumeSnapshots) (params.ErrorResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Snapshots)),
+	}
+	one := func(arg params.VolumeSnapshot) error {
+		if _, err := s.volumeSnapshot(arg.Name, canAccess); err != nil {
+			return err
+		}
+		return s.sb.SetVolumeSnapshotInfo(arg.Name, state.VolumeSnapshotInfo{
+			SnapshotId: arg.Info.SnapshotId,
+			Size:       arg.Info.Size,
+			Ready:      arg.Info.Ready,
+		})
+	}
+	for i, arg := range args.Snapshots {
+		err := one(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// SetVolumeSnapshotRestored records the volumes restored from volume
+// snapshots, adding the storage instances they back.
+func (s *StorageProvisionerAPIv5) SetVolumeSnapshotRestored(args params.VolumeSnapshotRestores) (params.ErrorResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Restores)),
+	}
+	one := func(arg params.VolumeSnapshotRestore) error {
+		if _, err := s.volumeSnapshot(arg.Name, canAccess); err != nil {
+			return err
+		}
+		return s.sb.SetVolumeSnapshotRestored(arg.Name, state.VolumeInfo{
+			HardwareId: arg.Info.HardwareId,
+			WWN:        arg.Info.WWN,
+			Size:       arg.Info.Size,
+			VolumeId:   arg.Info.VolumeId,
+			Persistent: arg.Info.Persistent,
+		})
+	}
+	for i, arg := range args.Restores {
+		err := one(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// RemoveVolumeSnapshots removes the dying volume snapshots with the
+// specified names from state, once the storage provider has destroyed
+// them.
+func (s *StorageProvisionerAPIv5) RemoveVolumeSnapshots(args params.VolumeSnapshotNames) (params.ErrorResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Names)),
+	}
+	one := func(name string) error {
+		_, err := s.volumeSnapshot(name, canAccess)
+		if errors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		return s.sb.RemoveVolumeSnapshot(name)
+	}
+	for i, name := range args.Names {
+		err := one(name)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
 // FilesystemParams returns the parameters for creating the filesystems
 // with the specified tags.
 func (s *StorageProvisionerAPIv3) FilesystemParams(args params.Entities) (params.FilesystemParamsResults, error) {
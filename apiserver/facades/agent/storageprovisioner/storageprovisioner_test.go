@@ -55,7 +55,7 @@ type provisionerSuite struct {
 
 	resources      *common.Resources
 	authorizer     *apiservertesting.FakeAuthorizer
-	api            *storageprovisioner.StorageProvisionerAPIv4
+	api            *storageprovisioner.StorageProvisionerAPIv5
 	storageBackend storageprovisioner.StorageBackend
 }
 
@@ -86,7 +86,7 @@ func (s *iaasProvisionerSuite) SetUpTest(c *gc.C) {
 	s.storageBackend = storageBackend
 	v3, err := storageprovisioner.NewStorageProvisionerAPIv3(backend, storageBackend, s.resources, s.authorizer, registry, pm)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = storageprovisioner.NewStorageProvisionerAPIv4(v3)
+	s.api = storageprovisioner.NewStorageProvisionerAPIv5(storageprovisioner.NewStorageProvisionerAPIv4(v3))
 }
 
 func (s *caasProvisionerSuite) SetUpTest(c *gc.C) {
@@ -118,7 +118,7 @@ func (s *caasProvisionerSuite) SetUpTest(c *gc.C) {
 	s.storageBackend = storageBackend
 	v3, err := storageprovisioner.NewStorageProvisionerAPIv3(backend, storageBackend, s.resources, s.authorizer, registry, pm)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = storageprovisioner.NewStorageProvisionerAPIv4(v3)
+	s.api = storageprovisioner.NewStorageProvisionerAPIv5(storageprovisioner.NewStorageProvisionerAPIv4(v3))
 }
 
 func (s *provisionerSuite) TestNewStorageProvisionerAPINonMachine(c *gc.C) {
@@ -629,6 +629,169 @@ func (s *iaasProvisionerSuite) TestRemoveVolumeParams(c *gc.C) {
 	})
 }
 
+func (s *iaasProvisionerSuite) TestVolumeResizeParams(c *gc.C) {
+	s.setupVolumes(c)
+	sb, err := state.NewStorageBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	err = sb.ResizeVolume(names.NewVolumeTag("2"), 8192)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.VolumeResizeParams(params.Entities{
+		Entities: []params.Entity{
+			{"volume-2"},
+			{"volume-0-0"},
+			{"volume-42"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeResizeParamsResults{
+		Results: []params.VolumeResizeParamsResult{{
+			Result: params.VolumeResizeParams{
+				VolumeTag: "volume-2",
+				VolumeId:  "def",
+				Provider:  "modelscoped",
+				Size:      8192,
+			},
+		}, {
+			Error: &params.Error{Message: `resize request for volume 0/0 not found`, Code: "not found"},
+		}, {
+			Error: &params.Error{Message: "permission denied", Code: "unauthorized access"},
+		}},
+	})
+}
+
+func (s *iaasProvisionerSuite) TestCancelVolumeResizes(c *gc.C) {
+	s.setupVolumes(c)
+	sb, err := state.NewStorageBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	err = sb.ResizeVolume(names.NewVolumeTag("2"), 8192)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.CancelVolumeResizes(params.Entities{
+		Entities: []params.Entity{
+			{"volume-2"},
+			{"volume-0-0"},
+			{"volume-42"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+	volume, err := sb.Volume(names.NewVolumeTag("2"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := volume.RequestedSize()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *iaasProvisionerSuite) TestVolumeSnapshotParams(c *gc.C) {
+	s.setupVolumes(c)
+	sb, err := state.NewStorageBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	err = sb.CreateVolumeSnapshot(names.NewVolumeTag("2"), "nightly")
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.VolumeSnapshotParams(params.VolumeSnapshotNames{
+		Names: []string{"nightly", "missing"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeSnapshotParamsResults{
+		Results: []params.VolumeSnapshotParamsResult{{
+			Result: params.VolumeSnapshotParams{
+				Name:      "nightly",
+				Life:      life.Alive,
+				VolumeTag: "volume-2",
+				VolumeId:  "def",
+				Provider:  "modelscoped",
+				Tags: map[string]string{
+					tags.JujuController: testing.ControllerTag.Id(),
+					tags.JujuModel:      testing.ModelTag.Id(),
+				},
+			},
+		}, {
+			Error: &params.Error{Message: `volume snapshot "missing" not found`, Code: "not found"},
+		}},
+	})
+}
+
+func (s *iaasProvisionerSuite) TestVolumeSnapshotLifecycle(c *gc.C) {
+	sb, err := state.NewStorageBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	storageTag, err := sb.AddExistingFilesystem(
+		state.FilesystemInfo{Pool: "modelscoped-block", Size: 1024},
+		&state.VolumeInfo{Pool: "modelscoped-block", Size: 1024, VolumeId: "vol-0"},
+		"pgdata",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	volume, err := sb.StorageInstanceVolume(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = sb.CreateVolumeSnapshot(volume.VolumeTag(), "nightly")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Record the snapshot taken by the provider.
+	errResults, err := s.api.SetVolumeSnapshotInfo(params.VolumeSnapshots{
+		Snapshots: []params.VolumeSnapshot{{
+			Name: "nightly",
+			Info: params.VolumeSnapshotInfo{SnapshotId: "nightly", Size: 1024, Ready: true},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errResults, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+
+	// Restore it, and record the restored volume.
+	restoredTag, err := sb.RestoreVolumeSnapshot("nightly", "pgdata")
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := s.api.VolumeSnapshotParams(params.VolumeSnapshotNames{Names: []string{"nightly"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Result.Info, jc.DeepEquals, &params.VolumeSnapshotInfo{
+		SnapshotId: "nightly", Size: 1024, Ready: true,
+	})
+	c.Assert(results.Results[0].Result.RestoreStorageTag, gc.Equals, restoredTag.String())
+
+	errResults, err = s.api.SetVolumeSnapshotRestored(params.VolumeSnapshotRestores{
+		Restores: []params.VolumeSnapshotRestore{{
+			Name: "nightly",
+			Info: params.VolumeInfo{VolumeId: "vol-1", Size: 1024},
+		}, {
+			Name: "nightly",
+			Info: params.VolumeInfo{VolumeId: "vol-1", Size: 1024},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errResults, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `cannot complete restore of volume snapshot "nightly": no restore pending`}},
+		},
+	})
+	restoredVolume, err := sb.StorageInstanceVolume(restoredTag)
+	c.Assert(err, jc.ErrorIsNil)
+	restoredInfo, err := restoredVolume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(restoredInfo.VolumeId, gc.Equals, "vol-1")
+
+	// Dying snapshots are removed once destroyed by the provider.
+	err = sb.DestroyVolumeSnapshot("nightly")
+	c.Assert(err, jc.ErrorIsNil)
+	errResults, err = s.api.RemoveVolumeSnapshots(params.VolumeSnapshotNames{
+		Names: []string{"nightly", "nightly"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errResults, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}, {}},
+	})
+	_, err = sb.VolumeSnapshot("nightly")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *iaasProvisionerSuite) TestFilesystemParams(c *gc.C) {
 	s.setupFilesystems(c)
 	results, err := s.api.FilesystemParams(params.Entities{
@@ -1056,6 +1219,73 @@ func (s *iaasProvisionerSuite) TestWatchVolumes(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *iaasProvisionerSuite) TestWatchVolumeResizes(c *gc.C) {
+	s.setupVolumes(c)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{s.Model.ModelTag().String()},
+		{"machine-0"},
+		{"machine-42"}},
+	}
+	result, err := s.api.WatchVolumeResizes(args)
+	c.Assert(err, jc.ErrorIsNil)
+	sort.Strings(result.Results[0].Changes)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{
+			{StringsWatcherId: "1", Changes: []string{"1", "2", "3", "4"}},
+			{Error: &params.Error{Message: "watching storage for machine-0 not supported", Code: "not supported"}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	w := s.resources.Get("1")
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w.(state.StringsWatcher))
+	wc.AssertNoChange()
+
+	sb, err := state.NewStorageBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	err = sb.ResizeVolume(names.NewVolumeTag("2"), 8192)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("2")
+	wc.AssertNoChange()
+}
+
+func (s *iaasProvisionerSuite) TestWatchVolumeSnapshots(c *gc.C) {
+	s.setupVolumes(c)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{s.Model.ModelTag().String()},
+		{"machine-0"},
+		{"machine-42"}},
+	}
+	result, err := s.api.WatchVolumeSnapshots(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{
+			{StringsWatcherId: "1"},
+			{Error: &params.Error{Message: "watching storage for machine-0 not supported", Code: "not supported"}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	w := s.resources.Get("1")
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w.(state.StringsWatcher))
+	wc.AssertNoChange()
+
+	sb, err := state.NewStorageBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	err = sb.CreateVolumeSnapshot(names.NewVolumeTag("2"), "nightly")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("nightly")
+	wc.AssertNoChange()
+}
+
 func (s *iaasProvisionerSuite) TestWatchVolumeAttachments(c *gc.C) {
 	// Only IAAS models support block storage right now.
 	s.setupVolumes(c)
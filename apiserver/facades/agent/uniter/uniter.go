@@ -36,8 +36,8 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.uniter")
 
-// UniterAPI implements the latest version (v14) of the Uniter API,
-// which adds GetPodSpec.
+// UniterAPI implements the latest version (v15) of the Uniter API,
+// which adds LogActionsOutput.
 type UniterAPI struct {
 	*common.LifeGetter
 	*StatusAPI
@@ -74,10 +74,16 @@ type UniterAPI struct {
 	cloudSpec       cloudspec.CloudSpecAPI
 }
 
+// UniterAPIV14 implements version (v14) of the Uniter API,
+// which adds GetPodSpec.
+type UniterAPIV14 struct {
+	UniterAPI
+}
+
 // UniterAPIV13 implements version (v13) of the Uniter API,
 // which adds UpdateNetworkInfo.
 type UniterAPIV13 struct {
-	UniterAPI
+	UniterAPIV14
 }
 
 // UniterAPIV12 implements version (v12) of the Uniter API,
@@ -228,14 +234,25 @@ func NewUniterAPI(context facade.Context) (*UniterAPI, error) {
 	}, nil
 }
 
+// NewUniterAPIV14 creates an instance of the V14 uniter API.
+func NewUniterAPIV14(context facade.Context) (*UniterAPIV14, error) {
+	uniterAPI, err := NewUniterAPI(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV14{
+		UniterAPI: *uniterAPI,
+	}, nil
+}
+
 // NewUniterAPIV13 creates an instance of the V13 uniter API.
 func NewUniterAPIV13(context facade.Context) (*UniterAPIV13, error) {
-	uniterAPI, err := NewUniterAPI(context)
+	uniterAPI, err := NewUniterAPIV14(context)
 	if err != nil {
 		return nil, err
 	}
 	return &UniterAPIV13{
-		UniterAPI: *uniterAPI,
+		UniterAPIV14: *uniterAPI,
 	}, nil
 }
 
@@ -423,6 +440,7 @@ func (u *UniterAPI) getOneMachinePorts(canAccess common.AuthFunc, machineTag str
 		// AllPortRanges gives a map, but apis require a stable order
 		// for results, so sort the port ranges.
 		portRangesToUnits := ports.AllPortRanges()
+		portRangesToEndpoints := ports.AllPortRangeEndpoints()
 		portRanges := make([]corenetwork.PortRange, 0, len(portRangesToUnits))
 		for portRange := range portRangesToUnits {
 			portRanges = append(portRanges, portRange)
@@ -433,6 +451,7 @@ func (u *UniterAPI) getOneMachinePorts(canAccess common.AuthFunc, machineTag str
 			resultPorts = append(resultPorts, params.MachinePortRange{
 				UnitTag:   names.NewUnitTag(unitName).String(),
 				PortRange: params.FromNetworkPortRange(portRange),
+				Endpoint:  portRangesToEndpoints[portRange],
 			})
 		}
 	}
@@ -779,7 +798,37 @@ func (u *UniterAPI) charmModifiedVersion(tagStr string, canAccess func(names.Tag
 	default:
 		return -1, errors.BadRequestf("type %T does not have a CharmModifiedVersion", entity)
 	}
-	return application.CharmModifiedVersion(), nil
+	branchVersion, err := u.branchCharmModifiedVersion(application)
+	if err != nil {
+		return -1, err
+	}
+	return application.CharmModifiedVersion() + branchVersion, nil
+}
+
+// branchCharmModifiedVersion returns the number of times that an upgrade
+// has been staged for the input application in the branch tracked by the
+// authenticated unit, so that the unit upgrades when only the resources
+// staged in the branch change. When the branch is committed, the
+// application's charm modified version is set to include it, so that
+// the version the unit sees does not go down.
+// Zero is returned if the authenticated entity is not a unit of the
+// application, or if the application has not been upgraded in the branch
+// that the unit is tracking.
+func (u *UniterAPI) branchCharmModifiedVersion(app *state.Application) (int, error) {
+	unitTag, ok := u.auth.GetAuthTag().(names.UnitTag)
+	if !ok {
+		return 0, nil
+	}
+	appName, err := names.UnitApplication(unitTag.Id())
+	if err != nil || appName != app.Name() {
+		return 0, errors.Trace(err)
+	}
+	unit, err := u.getUnit(unitTag)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	ver, err := unit.BranchCharmModifiedVersion()
+	return ver, errors.Trace(err)
 }
 
 // CharmURL returns the charm URL for all given units or applications.
@@ -807,6 +856,13 @@ func (u *UniterAPI) CharmURL(args params.Entities) (params.StringBoolResults, er
 					CharmURL() (*charm.URL, bool)
 				})
 				curl, ok := charmURLer.CharmURL()
+				if app, isApp := unitOrApplication.(*state.Application); isApp {
+					var branchURL *charm.URL
+					branchURL, err = u.branchCharmURL(app)
+					if branchURL != nil {
+						curl = branchURL
+					}
+				}
 				if curl != nil {
 					result.Results[i].Result = curl.String()
 					result.Results[i].Ok = ok
@@ -818,6 +874,28 @@ func (u *UniterAPI) CharmURL(args params.Entities) (params.StringBoolResults, er
 	return result, nil
 }
 
+// branchCharmURL returns the URL of the charm that the input application
+// has been upgraded to in the branch tracked by the authenticated unit.
+// Nil is returned if the authenticated entity is not a unit of the
+// application, or if the application has not been upgraded in the branch
+// that the unit is tracking.
+func (u *UniterAPI) branchCharmURL(app *state.Application) (*charm.URL, error) {
+	unitTag, ok := u.auth.GetAuthTag().(names.UnitTag)
+	if !ok {
+		return nil, nil
+	}
+	appName, err := names.UnitApplication(unitTag.Id())
+	if err != nil || appName != app.Name() {
+		return nil, errors.Trace(err)
+	}
+	unit, err := u.getUnit(unitTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	curl, err := unit.BranchCharmURL()
+	return curl, errors.Trace(err)
+}
+
 // SetCharmURL sets the charm URL for each given unit. An error will
 // be returned if a unit is dead, or the charm URL is not known.
 func (u *UniterAPI) SetCharmURL(args params.EntitiesCharmURL) (params.ErrorResults, error) {
@@ -948,7 +1026,8 @@ func (u *UniterAPI) SetWorkloadVersion(args params.EntityWorkloadVersions) (para
 }
 
 // OpenPorts sets the policy of the port range with protocol to be
-// opened, for all given units.
+// opened, for all given units. Ranges opened for an endpoint are only
+// accessible from the sources that endpoint is exposed to.
 func (u *UniterAPI) OpenPorts(args params.EntitiesPortRanges) (params.ErrorResults, error) {
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.Entities)),
@@ -968,7 +1047,7 @@ func (u *UniterAPI) OpenPorts(args params.EntitiesPortRanges) (params.ErrorResul
 			var unit *state.Unit
 			unit, err = u.getUnit(tag)
 			if err == nil {
-				err = unit.OpenPorts(entity.Protocol, entity.FromPort, entity.ToPort)
+				err = unit.OpenPortsForEndpoint(entity.Endpoint, entity.Protocol, entity.FromPort, entity.ToPort)
 			}
 		}
 		result.Results[i].Error = common.ServerError(err)
@@ -1228,6 +1307,43 @@ func (u *UniterAPI) LogActionsMessages(args params.ActionMessageParams) (params.
 	return result, nil
 }
 
+// Mask the LogActionsOutput method from the v14 API. The API reflection
+// code in rpc/rpcreflect/type.go:newMethod skips 2-argument methods, so
+// this removes the method as far as the RPC machinery is concerned.
+
+// LogActionsOutput isn't on the v14 API.
+func (u *UniterAPIV14) LogActionsOutput(_, _ struct{}) {}
+
+// LogActionsOutput records lines written to stdout or stderr by the
+// specified running actions.
+func (u *UniterAPI) LogActionsOutput(args params.ActionOutputParams) (params.ErrorResults, error) {
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	m, err := u.st.Model()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	actionFn := common.AuthAndActionFromTagFn(canAccess, m.ActionByTag)
+
+	oneActionOutput := func(output params.ActionOutput) error {
+		action, err := actionFn(output.Tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return action.LogOutput(output.Stream, output.Lines)
+	}
+
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Output)),
+	}
+	for i, output := range args.Output {
+		result.Results[i].Error = common.ServerError(oneActionOutput(output))
+	}
+	return result, nil
+}
+
 // RelationById returns information about all given relations,
 // specified by their ids, including their key and the local
 // endpoint.
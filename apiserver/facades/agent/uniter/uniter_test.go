@@ -987,6 +987,30 @@ func (s *uniterSuite) TestCharmModifiedVersion(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestCharmModifiedVersionTrackingBranch(c *gc.C) {
+	newCharm := s.Factory.MakeCharm(c, &factory.CharmParams{
+		Name: "wordpress",
+		URL:  "cs:quantal/wordpress-4",
+	})
+	c.Assert(s.Model.AddBranch("new-branch", "test-user"), jc.ErrorIsNil)
+	branch, err := s.Model.Branch("new-branch")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(branch.AssignUnit("wordpress/0"), jc.ErrorIsNil)
+	err = s.wordpress.SetCharm(state.SetCharmConfig{Charm: newCharm, BranchName: "new-branch"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "application-wordpress"},
+	}}
+	result, err := s.uniter.CharmModifiedVersion(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.IntResults{
+		Results: []params.IntResult{
+			{Result: s.wordpress.CharmModifiedVersion() + 1},
+		},
+	})
+}
+
 func (s *uniterSuite) TestOpenPorts(c *gc.C) {
 	openedPorts, err := s.wordpressUnit.OpenedPorts()
 	c.Assert(err, jc.ErrorIsNil)
@@ -1015,6 +1039,24 @@ func (s *uniterSuite) TestOpenPorts(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestOpenPortsForEndpoint(c *gc.C) {
+	args := params.EntitiesPortRanges{Entities: []params.EntityPortRange{
+		{Tag: "unit-wordpress-0", Protocol: "tcp", FromPort: 80, ToPort: 80, Endpoint: "url"},
+		{Tag: "unit-wordpress-0", Protocol: "tcp", FromPort: 443, ToPort: 443, Endpoint: "bogus"},
+	}}
+	result, err := s.uniter.OpenPorts(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `.*endpoint "bogus" not valid`)
+
+	endpoints, err := s.wordpressUnit.OpenedPortEndpoints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(endpoints, jc.DeepEquals, map[network.PortRange]string{
+		{Protocol: "tcp", FromPort: 80, ToPort: 80}: "url",
+	})
+}
+
 func (s *uniterSuite) TestClosePorts(c *gc.C) {
 	// Open port udp:4321 in advance on wordpressUnit.
 	err := s.wordpressUnit.OpenPorts("udp", 4321, 5000)
@@ -1156,6 +1198,40 @@ func (s *uniterSuite) TestLogActionMessage(c *gc.C) {
 	c.Assert(messages[0].Timestamp(), gc.NotNil)
 }
 
+func (s *uniterSuite) TestLogActionOutput(c *gc.C) {
+	anAction, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = anAction.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	wrongAction, err := s.mysqlUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.ActionOutputParams{Output: []params.ActionOutput{
+		{Tag: anAction.Tag().String(), Stream: "stdout", Lines: []string{"hello", "world"}},
+		{Tag: anAction.Tag().String(), Stream: "stdin", Lines: []string{"hello"}},
+		{Tag: wrongAction.Tag().String(), Stream: "stdout", Lines: []string{"world"}},
+		{Tag: "foo-42", Stream: "stdout", Lines: []string{"mars"}},
+	}}
+	result, err := s.uniter.LogActionsOutput(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `action output stream "stdin" not valid`}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: &params.Error{Message: `"foo-42" is not a valid tag`}},
+		},
+	})
+	anAction, err = s.Model.Action(anAction.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := anAction.Messages()
+	c.Assert(messages, gc.HasLen, 2)
+	c.Assert(messages[0].Message(), gc.Equals, "hello")
+	c.Assert(messages[0].Stream(), gc.Equals, "stdout")
+	c.Assert(messages[1].Message(), gc.Equals, "world")
+}
+
 func (s *uniterSuite) TestWatchActionNotifications(c *gc.C) {
 	err := s.wordpressUnit.SetCharmURL(s.wpCharm.URL())
 	c.Assert(err, jc.ErrorIsNil)
@@ -3217,7 +3293,7 @@ func (s *uniterSuite) TestAllMachinePorts(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	// Open some ports on both units.
-	err = s.wordpressUnit.OpenPorts("tcp", 100, 200)
+	err = s.wordpressUnit.OpenPortsForEndpoint("url", "tcp", 100, 200)
 	c.Assert(err, jc.ErrorIsNil)
 	err = s.wordpressUnit.OpenPorts("udp", 10, 20)
 	c.Assert(err, jc.ErrorIsNil)
@@ -3235,7 +3311,7 @@ func (s *uniterSuite) TestAllMachinePorts(c *gc.C) {
 		{Tag: "application-wordpress"},
 	}}
 	expectPorts := []params.MachinePortRange{
-		{UnitTag: "unit-wordpress-0", PortRange: params.PortRange{100, 200, "tcp"}},
+		{UnitTag: "unit-wordpress-0", PortRange: params.PortRange{100, 200, "tcp"}, Endpoint: "url"},
 		{UnitTag: "unit-mysql-1", PortRange: params.PortRange{201, 250, "tcp"}},
 		{UnitTag: "unit-mysql-1", PortRange: params.PortRange{1, 8, "udp"}},
 		{UnitTag: "unit-wordpress-0", PortRange: params.PortRange{10, 20, "udp"}},
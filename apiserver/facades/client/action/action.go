@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/juju/errors"
+	"github.com/juju/naturalsort"
 	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v3"
 
@@ -44,6 +45,11 @@ type APIv4 struct {
 
 // APIv5 provides the Action API facade for version 5.
 type APIv5 struct {
+	*APIv6
+}
+
+// APIv6 provides the Action API facade for version 6.
+type APIv6 struct {
 	*ActionAPI
 }
 
@@ -76,13 +82,22 @@ func NewActionAPIV4(ctx facade.Context) (*APIv4, error) {
 
 // NewActionAPIV5 returns an initialized ActionAPI for version 4.
 func NewActionAPIV5(ctx facade.Context) (*APIv5, error) {
-	api, err := newActionAPI(ctx.State(), ctx.Resources(), ctx.Auth())
+	api, err := NewActionAPIV6(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv5{api}, nil
 }
 
+// NewActionAPIV6 returns an initialized ActionAPI for version 6.
+func NewActionAPIV6(ctx facade.Context) (*APIv6, error) {
+	api, err := newActionAPI(ctx.State(), ctx.Resources(), ctx.Auth())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv6{api}, nil
+}
+
 func newActionAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ActionAPI, error) {
 	if !authorizer.AuthClient() {
 		return nil, common.ErrPerm
@@ -289,7 +304,10 @@ func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
-		enqueued, err := receiver.AddAction(action.Name, action.Parameters)
+		enqueued, err := receiver.AddActionWithTimeouts(action.Name, action.Parameters, state.ActionTimeouts{
+			Queue:     action.QueueTimeout,
+			Execution: action.ExecutionTimeout,
+		})
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
 			continue
@@ -300,6 +318,145 @@ func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 	return response, nil
 }
 
+// EnqueueOperation queues up an action to be run on each of the given
+// units in turn, a batch of units at a time, tracked as a single
+// operation. It returns the id of the operation along with the actions
+// queued for the first batch of units.
+func (a *ActionAPI) EnqueueOperation(arg params.EnqueueOperationArgs) (params.EnqueueOperationResult, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.EnqueueOperationResult{}, errors.Trace(err)
+	}
+
+	var result params.EnqueueOperationResult
+	units, err := a.operationUnits(arg.Receivers, arg.LeaderFirst)
+	if err != nil {
+		result.Error = common.ServerError(err)
+		return result, nil
+	}
+	op, err := a.model.EnqueueOperation(state.OperationArgs{
+		ActionName: arg.Name,
+		Parameters: arg.Parameters,
+		Timeouts: state.ActionTimeouts{
+			Queue:     arg.QueueTimeout,
+			Execution: arg.ExecutionTimeout,
+		},
+		Units:         units,
+		BatchSize:     arg.BatchSize,
+		StopOnFailure: arg.StopOnFailure,
+	})
+	if err != nil {
+		result.Error = common.ServerError(err)
+		return result, nil
+	}
+	result.Operation = op.Id()
+	if op.Status() == state.ActionFailed {
+		// The action could not be queued on the first batch of units.
+		result.Error = common.ServerError(errors.New(op.Message()))
+		return result, nil
+	}
+
+	actions, err := op.Actions()
+	if err != nil {
+		result.Error = common.ServerError(err)
+		return result, nil
+	}
+	for _, action := range actions {
+		receiverTag := names.NewUnitTag(action.Receiver())
+		result.Actions = append(result.Actions, common.MakeActionResult(receiverTag, action, false))
+	}
+	return result, nil
+}
+
+// operationUnits returns the names of the units an operation runs on,
+// given its receivers: unit tags, application tags standing for all the
+// application's units, or "<application>/leader". If leaderFirst is
+// true, the leader of each application is moved ahead of all the
+// application's other units.
+func (a *ActionAPI) operationUnits(receivers []string, leaderFirst bool) ([]string, error) {
+	var leaders map[string]string
+	if leaderFirst || hasLeaderReceiver(receivers) {
+		var err error
+		leaders, err = a.state.ApplicationLeaders()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	var unitNames []string
+	for _, receiver := range receivers {
+		if strings.HasSuffix(receiver, "/leader") {
+			appName := strings.TrimSuffix(receiver, "/leader")
+			leader, ok := leaders[appName]
+			if !ok {
+				return nil, errors.Errorf("could not determine leader for %q", appName)
+			}
+			unitNames = append(unitNames, leader)
+			continue
+		}
+		tag, err := names.ParseTag(receiver)
+		if err != nil {
+			return nil, errors.NotValidf("receiver %q", receiver)
+		}
+		switch tag := tag.(type) {
+		case names.UnitTag:
+			unitNames = append(unitNames, tag.Id())
+		case names.ApplicationTag:
+			app, err := a.state.Application(tag.Id())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			units, err := app.AllUnits()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			appUnitNames := make([]string, len(units))
+			for i, unit := range units {
+				appUnitNames[i] = unit.Name()
+			}
+			naturalsort.Sort(appUnitNames)
+			unitNames = append(unitNames, appUnitNames...)
+		default:
+			return nil, errors.NotValidf("receiver %q", receiver)
+		}
+	}
+	if !leaderFirst {
+		return unitNames, nil
+	}
+
+	isLeader := make(map[string]bool)
+	for _, leader := range leaders {
+		isLeader[leader] = true
+	}
+	ordered := make([]string, 0, len(unitNames))
+	for _, unitName := range unitNames {
+		if isLeader[unitName] {
+			ordered = append(ordered, unitName)
+		}
+	}
+	for _, unitName := range unitNames {
+		if !isLeader[unitName] {
+			ordered = append(ordered, unitName)
+		}
+	}
+	return ordered, nil
+}
+
+// Mask out new methods from the old API versions. The API reflection
+// code in rpc/rpcreflect/type.go:newMethod skips 2-argument methods,
+// so this removes the method as far as the RPC machinery is concerned.
+
+// EnqueueOperation isn't on the v5 API.
+func (*APIv5) EnqueueOperation(_, _ struct{}) {}
+
+func hasLeaderReceiver(receivers []string) bool {
+	for _, receiver := range receivers {
+		if strings.HasSuffix(receiver, "/leader") {
+			return true
+		}
+	}
+	return false
+}
+
 // ListAll takes a list of Entities representing ActionReceivers and
 // returns all of the Actions that have been enqueued or run by each of
 // those Entities.
@@ -323,7 +480,67 @@ func (a *ActionAPI) listAll(arg params.Entities, compat bool) (params.ActionsByR
 }
 
 // Operations fetches the called functions (actions) for specified apps/units.
-func (a *ActionAPI) Operations(arg params.OperationQueryArgs) (params.ActionResults, error) {
+// Actions run as part of an operation are reported with the operation,
+// along with its status and the units it has and has yet to queue the
+// action for. Operations named in the query are always reported, even
+// if none of their actions match it.
+func (a *ActionAPI) Operations(arg params.OperationQueryArgs) (params.OperationResults, error) {
+	actions, err := a.operationActions(arg)
+	if err != nil {
+		return params.OperationResults{}, errors.Trace(err)
+	}
+
+	var result params.OperationResults
+	operationIds := set.NewStrings(arg.Operations...)
+	byOperation := make(map[string][]params.ActionResult)
+	for _, ar := range actions.Results {
+		if ar.Operation == "" {
+			result.Actions = append(result.Actions, ar)
+			continue
+		}
+		operationIds.Add(ar.Operation)
+		byOperation[ar.Operation] = append(byOperation[ar.Operation], ar)
+	}
+	ids := operationIds.Values()
+	naturalsort.Sort(ids)
+	for _, id := range ids {
+		op, err := a.model.Operation(id)
+		if err != nil {
+			result.Results = append(result.Results, params.OperationResult{
+				Operation: id,
+				Error:     common.ServerError(err),
+			})
+			continue
+		}
+		result.Results = append(result.Results, makeOperationResult(op, byOperation[id]))
+	}
+	return result, nil
+}
+
+// Operations fetches the called functions (actions) for specified
+// apps/units. The v5 API reports only the actions.
+func (a *APIv5) Operations(arg params.OperationQueryArgs) (params.ActionResults, error) {
+	return a.operationActions(arg)
+}
+
+func makeOperationResult(op *state.Operation, actions []params.ActionResult) params.OperationResult {
+	units := op.Units()
+	return params.OperationResult{
+		Operation:     op.Id(),
+		Action:        op.ActionName(),
+		Status:        string(op.Status()),
+		Message:       op.Message(),
+		Enqueued:      op.Enqueued(),
+		Completed:     op.Completed(),
+		BatchSize:     op.BatchSize(),
+		QueuedUnits:   units[:op.Queued()],
+		UnqueuedUnits: units[op.Queued():],
+		Actions:       actions,
+	}
+}
+
+// operationActions returns the actions matching the query.
+func (a *ActionAPI) operationActions(arg params.OperationQueryArgs) (params.ActionResults, error) {
 	if err := a.checkCanRead(); err != nil {
 		return params.ActionResults{}, errors.Trace(err)
 	}
@@ -400,9 +617,13 @@ func (a *ActionAPI) Operations(arg params.OperationQueryArgs) (params.ActionResu
 			return params.ActionResults{}, errors.Trace(actions.Error)
 		}
 		for _, ar := range actions.Actions {
-			if nameMatches(ar.Action.Name, arg.FunctionNames) {
-				result.Results = append(result.Results, ar)
+			if !nameMatches(ar.Action.Name, arg.FunctionNames) {
+				continue
+			}
+			if !nameMatches(ar.Operation, arg.Operations) {
+				continue
 			}
+			result.Results = append(result.Results, ar)
 		}
 	}
 	return result, nil
@@ -274,6 +274,134 @@ func (s *actionSuite) TestEnqueue(c *gc.C) {
 	c.Assert(actions, gc.HasLen, 0)
 }
 
+func (s *actionSuite) TestEnqueueWithTimeouts(c *gc.C) {
+	arg := params.Actions{
+		Actions: []params.Action{{
+			Receiver:         s.wordpressUnit.Tag().String(),
+			Name:             "fakeaction",
+			QueueTimeout:     time.Minute,
+			ExecutionTimeout: time.Hour,
+		}, {
+			Receiver:         s.wordpressUnit.Tag().String(),
+			Name:             "fakeaction",
+			ExecutionTimeout: -time.Hour,
+		}},
+	}
+	res, err := s.action.Enqueue(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 2)
+
+	c.Assert(res.Results[0].Error, gc.IsNil)
+	c.Assert(res.Results[0].Action.QueueTimeout, gc.Equals, time.Minute)
+	c.Assert(res.Results[0].Action.ExecutionTimeout, gc.Equals, time.Hour)
+	c.Assert(res.Results[1].Error, gc.ErrorMatches, "negative execution timeout -1h0m0s not valid")
+
+	actions, err := s.wordpressUnit.Actions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 1)
+	c.Assert(actions[0].Timeouts(), gc.Equals, state.ActionTimeouts{
+		Queue:     time.Minute,
+		Execution: time.Hour,
+	})
+}
+
+func (s *actionSuite) TestEnqueueOperation(c *gc.C) {
+	wordpressUnit1 := s.Factory.MakeUnit(c, &factory.UnitParams{
+		Application: s.wordpress,
+		Machine:     s.machine1,
+	})
+	// Ensure the second wordpress unit is the leader.
+	claimer, err := s.LeaseManager.Claimer("application-leadership", s.State.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+	err = claimer.Claim("wordpress", wordpressUnit1.Name(), time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	res, err := s.action.EnqueueOperation(params.EnqueueOperationArgs{
+		Receivers:   []string{s.mysqlUnit.Tag().String(), s.wordpress.Tag().String()},
+		Name:        "fakeaction",
+		BatchSize:   2,
+		LeaderFirst: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Error, gc.IsNil)
+	c.Assert(res.Operation, gc.Equals, "1")
+	c.Assert(res.Actions, gc.HasLen, 2)
+	c.Assert(res.Actions[0].Action.Receiver, gc.Equals, "unit-wordpress-1")
+	c.Assert(res.Actions[0].Operation, gc.Equals, "1")
+	c.Assert(res.Actions[1].Action.Receiver, gc.Equals, "unit-mysql-0")
+
+	op, err := s.Model.Operation(res.Operation)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.Units(), jc.DeepEquals, []string{"wordpress/1", "mysql/0", "wordpress/0"})
+
+	// Only the actions queued by the operation are reported when
+	// filtering by operation.
+	_, err = s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := s.action.Operations(params.OperationQueryArgs{
+		Operations: []string{res.Operation},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Actions, gc.HasLen, 0)
+	c.Assert(results.Results, gc.HasLen, 1)
+	result := results.Results[0]
+	c.Check(result.Operation, gc.Equals, res.Operation)
+	c.Check(result.Action, gc.Equals, "fakeaction")
+	c.Check(result.Status, gc.Equals, "running")
+	c.Check(result.BatchSize, gc.Equals, 2)
+	c.Check(result.QueuedUnits, jc.DeepEquals, []string{"wordpress/1", "mysql/0"})
+	c.Check(result.UnqueuedUnits, jc.DeepEquals, []string{"wordpress/0"})
+	c.Assert(result.Actions, gc.HasLen, 2)
+	for _, ar := range result.Actions {
+		c.Check(ar.Operation, gc.Equals, res.Operation)
+	}
+}
+
+func (s *actionSuite) TestOperationsUnknownOperation(c *gc.C) {
+	results, err := s.action.Operations(params.OperationQueryArgs{
+		Operations: []string{"42"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].Operation, gc.Equals, "42")
+	c.Check(results.Results[0].Error, gc.ErrorMatches, `operation "42" not found`)
+}
+
+func (s *actionSuite) TestOperationsV5(c *gc.C) {
+	s.setupOperations(c)
+	api := &action.APIv5{APIv6: &action.APIv6{ActionAPI: s.action}}
+	actions, err := api.Operations(params.OperationQueryArgs{
+		Status: []string{"running"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions.Results, gc.HasLen, 1)
+	c.Assert(actions.Results[0].Action.Tag, gc.Equals, "action-1")
+}
+
+func (s *actionSuite) TestEnqueueOperationErrors(c *gc.C) {
+	for i, test := range []struct {
+		args   params.EnqueueOperationArgs
+		expect string
+	}{{
+		args:   params.EnqueueOperationArgs{Receivers: []string{"wordpress/leader"}, Name: "fakeaction", BatchSize: 1},
+		expect: `could not determine leader for "wordpress"`,
+	}, {
+		args:   params.EnqueueOperationArgs{Receivers: []string{s.machine0.Tag().String()}, Name: "fakeaction", BatchSize: 1},
+		expect: `receiver "machine-0" not valid`,
+	}, {
+		args:   params.EnqueueOperationArgs{Receivers: []string{s.wordpressUnit.Tag().String()}, Name: "fakeaction"},
+		expect: "non-positive batch size 0 not valid",
+	}, {
+		args:   params.EnqueueOperationArgs{Receivers: []string{s.wordpressUnit.Tag().String()}, Name: "nope", BatchSize: 1},
+		expect: `cannot run action on wordpress/0: action "nope" not defined on unit "wordpress/0"`,
+	}} {
+		c.Logf("test %d", i)
+		res, err := s.action.EnqueueOperation(test.args)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(res.Error, gc.ErrorMatches, test.expect)
+	}
+}
+
 type testCaseAction struct {
 	Name       string
 	Parameters map[string]interface{}
@@ -898,8 +1026,8 @@ func (s *actionSuite) TestOperationsStatusFilter(c *gc.C) {
 		Status: []string{"running"},
 	})
 	c.Assert(err, gc.Equals, nil)
-	c.Assert(actions.Results, gc.HasLen, 1)
-	result := actions.Results[0]
+	c.Assert(actions.Actions, gc.HasLen, 1)
+	result := actions.Actions[0]
 	c.Assert(result.Action, gc.NotNil)
 	if result.Enqueued.IsZero() {
 		c.Fatal("enqueued time not set")
@@ -919,8 +1047,8 @@ func (s *actionSuite) TestOperationsNameFilter(c *gc.C) {
 		FunctionNames: []string{"anotherfakeaction"},
 	})
 	c.Assert(err, gc.Equals, nil)
-	c.Assert(actions.Results, gc.HasLen, 1)
-	result := actions.Results[0]
+	c.Assert(actions.Actions, gc.HasLen, 1)
+	result := actions.Actions[0]
 	c.Assert(result.Action, gc.NotNil)
 	if result.Enqueued.IsZero() {
 		c.Fatal("enqueued time not set")
@@ -937,9 +1065,9 @@ func (s *actionSuite) TestOperationsAppFilter(c *gc.C) {
 		Applications: []string{"wordpress"},
 	})
 	c.Assert(err, gc.Equals, nil)
-	c.Assert(actions.Results, gc.HasLen, 2)
-	result0 := actions.Results[0]
-	result1 := actions.Results[1]
+	c.Assert(actions.Actions, gc.HasLen, 2)
+	result0 := actions.Actions[0]
+	result1 := actions.Actions[1]
 
 	c.Assert(result0.Action, gc.NotNil)
 	if result0.Enqueued.IsZero() {
@@ -970,8 +1098,8 @@ func (s *actionSuite) TestOperationsUnitFilter(c *gc.C) {
 		Status: []string{"pending"},
 	})
 	c.Assert(err, gc.Equals, nil)
-	c.Assert(actions.Results, gc.HasLen, 1)
-	result := actions.Results[0]
+	c.Assert(actions.Actions, gc.HasLen, 1)
+	result := actions.Actions[0]
 
 	c.Assert(result.Action, gc.NotNil)
 	if result.Enqueued.IsZero() {
@@ -991,10 +1119,10 @@ func (s *actionSuite) TestOperationsAppAndUnitFilter(c *gc.C) {
 		Status:       []string{"pending"},
 	})
 	c.Assert(err, gc.Equals, nil)
-	c.Assert(actions.Results, gc.HasLen, 2)
-	mysqlAction := actions.Results[0]
-	wordpressAction := actions.Results[1]
-	c.Log(pretty.Sprint(actions.Results))
+	c.Assert(actions.Actions, gc.HasLen, 2)
+	mysqlAction := actions.Actions[0]
+	wordpressAction := actions.Actions[1]
+	c.Log(pretty.Sprint(actions.Actions))
 
 	c.Assert(mysqlAction.Action, gc.NotNil)
 	if mysqlAction.Enqueued.IsZero() {
@@ -88,6 +88,12 @@ type APIv10 struct {
 // The Get call also returns the current endpoint bindings while the SetCharm
 // call access a map of operator-defined bindings.
 type APIv11 struct {
+	*APIv12
+}
+
+// APIv12 provides the Application API facade for version 12.
+// The Expose and Unexpose calls accept per-endpoint expose settings.
+type APIv12 struct {
 	*APIBase
 }
 
@@ -186,13 +192,21 @@ func NewFacadeV10(ctx facade.Context) (*APIv10, error) {
 }
 
 func NewFacadeV11(ctx facade.Context) (*APIv11, error) {
-	api, err := newFacadeBase(ctx)
+	api, err := NewFacadeV12(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv11{api}, nil
 }
 
+func NewFacadeV12(ctx facade.Context) (*APIv12, error) {
+	api, err := newFacadeBase(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv12{api}, nil
+}
+
 type caasBrokerInterface interface {
 	ValidateStorageClass(config map[string]interface{}) error
 	Version() (*version.Number, error)
@@ -804,6 +818,7 @@ type setCharmParams struct {
 	StorageConstraints    map[string]params.StorageConstraints
 	EndpointBindings      map[string]string
 	Force                 forceParams
+	BranchName            string
 }
 
 type forceParams struct {
@@ -984,6 +999,7 @@ func (api *APIBase) SetCharm(args params.ApplicationSetCharm) error {
 				ForceUnits:  args.ForceUnits,
 				Force:       args.Force,
 			},
+			BranchName: args.Generation,
 		},
 		args.CharmURL,
 	)
@@ -1082,6 +1098,7 @@ func (api *APIBase) applicationSetCharm(
 		ResourceIDs:        params.ResourceIDs,
 		StorageConstraints: stateStorageConstraints,
 		EndpointBindings:   params.EndpointBindings,
+		BranchName:         params.BranchName,
 	}
 	return params.Application.SetCharm(cfg)
 }
@@ -1147,10 +1164,21 @@ func applicationSetCharmConfigYAML(
 
 // GetCharmURL returns the charm URL the given application is
 // running at present.
+// If a branch is indicated and the application has been upgraded
+// in it, the URL of the charm staged in the branch is returned.
 func (api *APIBase) GetCharmURL(args params.ApplicationGet) (params.StringResult, error) {
 	if err := api.checkCanWrite(); err != nil {
 		return params.StringResult{}, errors.Trace(err)
 	}
+	if args.BranchName != "" && args.BranchName != model.GenerationMaster {
+		gen, err := api.backend.Branch(args.BranchName)
+		if err != nil {
+			return params.StringResult{}, errors.Trace(err)
+		}
+		if curl, ok := gen.CharmURLs()[args.ApplicationName]; ok {
+			return params.StringResult{Result: curl}, nil
+		}
+	}
 	oneApplication, err := api.backend.Application(args.ApplicationName)
 	if err != nil {
 		return params.StringResult{}, errors.Trace(err)
@@ -1234,7 +1262,9 @@ func (api *APIBase) CharmRelations(p params.ApplicationCharmRelations) (params.A
 }
 
 // Expose changes the juju-managed firewall to expose any ports that
-// were also explicitly marked by units as open.
+// were also explicitly marked by units as open. If expose settings are
+// supplied for the application's endpoints, the ports are only exposed
+// to the spaces and CIDRs in those settings.
 func (api *APIBase) Expose(args params.ApplicationExpose) error {
 	if err := api.checkCanWrite(); err != nil {
 		return errors.Trace(err)
@@ -1247,6 +1277,9 @@ func (api *APIBase) Expose(args params.ApplicationExpose) error {
 		return errors.Trace(err)
 	}
 	if api.modelType == state.ModelTypeCAAS {
+		if len(args.ExposedEndpoints) > 0 {
+			return errors.NotSupportedf("exposing the endpoints of a k8s application")
+		}
 		appConfig, err := app.ApplicationConfig()
 		if err != nil {
 			return errors.Trace(err)
@@ -1257,11 +1290,44 @@ func (api *APIBase) Expose(args params.ApplicationExpose) error {
 					"juju config %s %s=<value>", caas.JujuExternalHostNameKey, args.ApplicationName, caas.JujuExternalHostNameKey)
 		}
 	}
-	return app.SetExposed()
+	if len(args.ExposedEndpoints) == 0 {
+		return app.SetExposed()
+	}
+	exposedEndpoints, err := api.exposedEndpointsFromParams(args.ExposedEndpoints)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return app.MergeExposeSettings(exposedEndpoints)
+}
+
+// exposedEndpointsFromParams converts the input expose settings
+// to their state representation, resolving space names to IDs.
+func (api *APIBase) exposedEndpointsFromParams(in map[string]params.ExposedEndpoint) (map[string]state.ExposedEndpoint, error) {
+	spaceInfos, err := api.backend.AllSpaceInfos()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make(map[string]state.ExposedEndpoint, len(in))
+	for endpoint, exposed := range in {
+		var spaceIDs []string
+		for _, spaceName := range exposed.ExposeToSpaces {
+			space := spaceInfos.GetByName(spaceName)
+			if space == nil {
+				return nil, errors.NotFoundf("space %q", spaceName)
+			}
+			spaceIDs = append(spaceIDs, space.ID)
+		}
+		out[endpoint] = state.ExposedEndpoint{
+			ExposeToSpaceIDs: spaceIDs,
+			ExposeToCIDRs:    exposed.ExposeToCIDRs,
+		}
+	}
+	return out, nil
 }
 
 // Unexpose changes the juju-managed firewall to unexpose any ports that
-// were also explicitly marked by units as open.
+// were also explicitly marked by units as open. If endpoints are supplied,
+// only their expose settings are removed.
 func (api *APIBase) Unexpose(args params.ApplicationUnexpose) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
@@ -1273,6 +1339,9 @@ func (api *APIBase) Unexpose(args params.ApplicationUnexpose) error {
 	if err != nil {
 		return err
 	}
+	if len(args.ExposedEndpoints) > 0 {
+		return app.UnsetExposeSettings(args.ExposedEndpoints)
+	}
 	return app.ClearExposed()
 }
 
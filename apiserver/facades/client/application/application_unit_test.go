@@ -55,7 +55,7 @@ type ApplicationSuite struct {
 	env          environs.Environ
 	blockChecker mockBlockChecker
 	authorizer   apiservertesting.FakeAuthorizer
-	api          *application.APIv11
+	api          *application.APIv12
 	deployParams map[string]application.DeployApplicationParams
 }
 
@@ -85,7 +85,7 @@ func (s *ApplicationSuite) setAPIUser(c *gc.C, user names.UserTag) {
 		s.caasBroker,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = &application.APIv11{api}
+	s.api = &application.APIv12{api}
 }
 
 func (s *ApplicationSuite) SetUpTest(c *gc.C) {
@@ -348,6 +348,50 @@ postgresql:
 	})
 }
 
+func (s *ApplicationSuite) TestSetCharmBranch(c *gc.C) {
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+		Generation:      "new-branch",
+		ResourceIDs:     map[string]string{"data": "pending-id"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.backend.applications["postgresql"]
+	app.CheckCall(c, 2, "SetCharm", state.SetCharmConfig{
+		Charm:       &state.Charm{},
+		ResourceIDs: map[string]string{"data": "pending-id"},
+		BranchName:  "new-branch",
+	})
+}
+
+func (s *ApplicationSuite) TestGetCharmURLBranch(c *gc.C) {
+	s.backend.generation = &mockGeneration{
+		charmURLs: map[string]string{"postgresql": "cs:postgresql-42"},
+	}
+	result, err := s.api.GetCharmURL(params.ApplicationGet{
+		ApplicationName: "postgresql",
+		BranchName:      "new-branch",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Result, gc.Equals, "cs:postgresql-42")
+	s.backend.generation.CheckCallNames(c, "CharmURLs")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestGetCharmURLBranchWithoutUpgrade(c *gc.C) {
+	app := s.backend.applications["postgresql"]
+	app.curl = charm.MustParseURL("cs:postgresql-1")
+
+	result, err := s.api.GetCharmURL(params.ApplicationGet{
+		ApplicationName: "postgresql",
+		BranchName:      "new-branch",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Result, gc.Equals, "cs:postgresql-1")
+	s.backend.generation.CheckCallNames(c, "CharmURLs")
+	s.backend.CheckCallNames(c, "Application")
+}
+
 func (s *ApplicationSuite) TestLXDProfileSetCharmWithNewerAgentVersion(c *gc.C) {
 	err := s.api.SetCharm(params.ApplicationSetCharm{
 		ApplicationName: "postgresql",
@@ -1707,6 +1751,52 @@ func (s *ApplicationSuite) TestCAASExposeWithHostname(c *gc.C) {
 	app.CheckCallNames(c, "ApplicationConfig", "SetExposed")
 }
 
+func (s *ApplicationSuite) TestCAASExposeEndpointsNotSupported(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	err := s.api.Expose(params.ApplicationExpose{
+		ApplicationName:  "postgresql",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{"db": {}},
+	})
+	c.Assert(err, gc.ErrorMatches, "exposing the endpoints of a k8s application not supported")
+}
+
+func (s *ApplicationSuite) TestExposeEndpoints(c *gc.C) {
+	s.backend.spaceInfos = network.SpaceInfos{{ID: "42", Name: "dmz"}}
+	err := s.api.Expose(params.ApplicationExpose{
+		ApplicationName: "postgresql",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"db":    {ExposeToSpaces: []string{"dmz"}},
+			"admin": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.backend.applications["postgresql"]
+	app.CheckCall(c, 0, "MergeExposeSettings", map[string]state.ExposedEndpoint{
+		"db":    {ExposeToSpaceIDs: []string{"42"}},
+		"admin": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+}
+
+func (s *ApplicationSuite) TestExposeEndpointsUnknownSpace(c *gc.C) {
+	err := s.api.Expose(params.ApplicationExpose{
+		ApplicationName: "postgresql",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"db": {ExposeToSpaces: []string{"dmz"}},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `space "dmz" not found`)
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestUnexposeEndpoints(c *gc.C) {
+	err := s.api.Unexpose(params.ApplicationUnexpose{
+		ApplicationName:  "postgresql",
+		ExposedEndpoints: []string{"db"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.applications["postgresql"].CheckCall(c, 0, "UnsetExposeSettings", []string{"db"})
+}
+
 func (s *ApplicationSuite) TestApplicationsInfoOne(c *gc.C) {
 	entities := []params.Entity{{Tag: "application-postgresql"}}
 	result, err := s.api.ApplicationsInfo(params.Entities{entities})
@@ -78,6 +78,7 @@ type Application interface {
 	DestroyOperation() *state.DestroyApplicationOperation
 	EndpointBindings() (Bindings, error)
 	Endpoints() ([]state.Endpoint, error)
+	ExposedEndpoints() map[string]state.ExposedEndpoint
 	IsExposed() bool
 	IsPrincipal() bool
 	IsRemote() bool
@@ -87,6 +88,8 @@ type Application interface {
 	SetExposed() error
 	SetMetricCredentials([]byte) error
 	SetMinUnits(int) error
+	MergeExposeSettings(map[string]state.ExposedEndpoint) error
+	UnsetExposeSettings([]string) error
 	UpdateApplicationSeries(string, bool) error
 	UpdateCharmConfig(string, charm.Settings) error
 	UpdateApplicationConfig(application.ConfigAttributes, []string, environschema.Fields, schema.Defaults) error
@@ -180,6 +183,7 @@ type Resources interface {
 
 type Generation interface {
 	AssignApplication(string) error
+	CharmURLs() map[string]string
 }
 
 type stateShim struct {
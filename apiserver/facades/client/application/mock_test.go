@@ -103,6 +103,8 @@ type mockApplication struct {
 	exposed     bool
 	remote      bool
 	agentTools  *tools.Tools
+
+	exposedEndpoints map[string]state.ExposedEndpoint
 }
 
 func (m *mockApplication) Name() string {
@@ -243,6 +245,21 @@ func (a *mockApplication) IsExposed() bool {
 	return a.exposed
 }
 
+func (a *mockApplication) ExposedEndpoints() map[string]state.ExposedEndpoint {
+	a.MethodCall(a, "ExposedEndpoints")
+	return a.exposedEndpoints
+}
+
+func (a *mockApplication) MergeExposeSettings(exposedEndpoints map[string]state.ExposedEndpoint) error {
+	a.MethodCall(a, "MergeExposeSettings", exposedEndpoints)
+	return a.NextErr()
+}
+
+func (a *mockApplication) UnsetExposeSettings(endpoints []string) error {
+	a.MethodCall(a, "UnsetExposeSettings", endpoints)
+	return a.NextErr()
+}
+
 func (a *mockApplication) IsRemote() bool {
 	a.MethodCall(a, "IsRemote")
 	return a.remote
@@ -362,6 +379,7 @@ type mockBackend struct {
 	controllers                map[string]crossmodel.ControllerInfo
 	machines                   map[string]*mockMachine
 	generation                 *mockGeneration
+	spaceInfos                 network.SpaceInfos
 }
 
 type mockFilesystemAccess struct {
@@ -435,7 +453,7 @@ func (m *mockBackend) Machine(id string) (application.Machine, error) {
 }
 
 func (m *mockBackend) AllSpaceInfos() (network.SpaceInfos, error) {
-	return nil, nil
+	return m.spaceInfos, nil
 }
 
 func (m *mockBackend) Space(_ string) (*state.Space, error) {
@@ -969,6 +987,7 @@ func (m *mockCaasBroker) Version() (*version.Number, error) {
 
 type mockGeneration struct {
 	jtesting.Stub
+	charmURLs map[string]string
 }
 
 func (g *mockGeneration) AssignApplication(appName string) error {
@@ -976,6 +995,11 @@ func (g *mockGeneration) AssignApplication(appName string) error {
 	return g.NextErr()
 }
 
+func (g *mockGeneration) CharmURLs() map[string]string {
+	g.MethodCall(g, "CharmURLs")
+	return g.charmURLs
+}
+
 type mockRepo struct {
 	charmrepo.Interface
 	*jtesting.CallMocker
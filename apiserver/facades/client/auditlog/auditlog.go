@@ -0,0 +1,123 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package auditlog provides the API for querying the audit log kept
+// by the controller.
+package auditlog
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend exposes the state functionality needed by the AuditLog
+// facade.
+type Backend interface {
+	ControllerTag() names.ControllerTag
+	QueryAuditLog(filter state.AuditLogFilter) ([]state.AuditLogEntry, error)
+}
+
+type stateShim struct {
+	*state.State
+}
+
+// QueryAuditLog is part of the Backend interface.
+func (s stateShim) QueryAuditLog(filter state.AuditLogFilter) ([]state.AuditLogEntry, error) {
+	return state.QueryAuditLog(s.State, filter)
+}
+
+// API serves the AuditLog facade.
+type API struct {
+	backend Backend
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(stateShim{ctx.State()}, ctx.Auth())
+}
+
+// NewAPI returns a new AuditLog facade. Only controller superusers
+// may read the audit log.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	isControllerAdmin, err := authorizer.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	if !isControllerAdmin {
+		return nil, common.ErrPerm
+	}
+	return &API{backend: backend}, nil
+}
+
+// Query returns the requests in the audit log that match the
+// arguments, oldest first.
+func (api *API) Query(args params.AuditLogQueryArgs) (params.AuditLogEntries, error) {
+	if args.Limit < 0 {
+		return params.AuditLogEntries{}, errors.NotValidf("negative limit")
+	}
+	filter := state.AuditLogFilter{
+		User:       args.User,
+		Model:      args.Model,
+		Facade:     args.Facade,
+		Method:     args.Method,
+		ErrorsOnly: args.ErrorsOnly,
+		Limit:      args.Limit,
+	}
+	if args.From != nil {
+		filter.From = *args.From
+	}
+	if args.To != nil {
+		filter.To = *args.To
+	}
+	entries, err := api.backend.QueryAuditLog(filter)
+	if err != nil {
+		return params.AuditLogEntries{}, errors.Trace(err)
+	}
+
+	result := params.AuditLogEntries{
+		Entries: make([]params.AuditLogEntry, len(entries)),
+	}
+	for i, entry := range entries {
+		when, err := time.Parse(time.RFC3339, entry.Request.When)
+		if err != nil {
+			return params.AuditLogEntries{}, errors.Annotatef(err, "parsing time of request %d", entry.Request.RequestID)
+		}
+		result.Entries[i] = params.AuditLogEntry{
+			ConversationID: entry.Conversation.ConversationID,
+			ConnectionID:   entry.Conversation.ConnectionID,
+			Who:            entry.Conversation.Who,
+			What:           entry.Conversation.What,
+			ModelName:      entry.Conversation.ModelName,
+			ModelUUID:      entry.Conversation.ModelUUID,
+			RequestID:      entry.Request.RequestID,
+			When:           when,
+			Facade:         entry.Request.Facade,
+			Method:         entry.Request.Method,
+			Version:        entry.Request.Version,
+			Args:           entry.Request.Args,
+		}
+		if entry.Errors == nil {
+			continue
+		}
+		for _, e := range entry.Errors.Errors {
+			if e != nil {
+				result.Entries[i].Errors = append(result.Entries[i].Errors, params.AuditLogError{
+					Message: e.Message,
+					Code:    e.Code,
+				})
+			}
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/auditlog"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coreauditlog "github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditLogSuite struct {
+	testing.IsolationSuite
+
+	backend    *fakeBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&auditLogSuite{})
+
+func (s *auditLogSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &fakeBackend{}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("superuser-bob"),
+	}
+}
+
+func (s *auditLogSuite) TestNewAPINotSuperuser(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("admin-bob")
+	_, err := auditlog.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *auditLogSuite) TestNewAPIAgent(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := auditlog.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *auditLogSuite) TestQuery(c *gc.C) {
+	s.backend.entries = []state.AuditLogEntry{{
+		Conversation: coreauditlog.Conversation{
+			Who:            "bob",
+			What:           "juju remove-application mysql",
+			When:           "2020-01-01T10:00:00Z",
+			ModelName:      "bob/prod",
+			ModelUUID:      "uuid-1",
+			ConversationID: "aaaa",
+			ConnectionID:   "A1",
+		},
+		Request: coreauditlog.Request{
+			ConversationID: "aaaa",
+			ConnectionID:   "A1",
+			RequestID:      2,
+			When:           "2020-01-01T10:00:02Z",
+			Facade:         "Application",
+			Method:         "DestroyApplication",
+			Version:        11,
+			Args:           `{"applications":["mysql"]}`,
+		},
+		Errors: &coreauditlog.ResponseErrors{
+			Errors: []*coreauditlog.Error{nil, {Message: "boom", Code: "not found"}},
+		},
+	}}
+	api, err := auditlog.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	from := time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC)
+	result, err := api.Query(params.AuditLogQueryArgs{
+		User:       "bob",
+		Model:      "bob/prod",
+		Facade:     "Application",
+		Method:     "DestroyApplication",
+		From:       &from,
+		ErrorsOnly: true,
+		Limit:      10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCall(c, 0, "QueryAuditLog", state.AuditLogFilter{
+		User:       "bob",
+		Model:      "bob/prod",
+		Facade:     "Application",
+		Method:     "DestroyApplication",
+		From:       from,
+		ErrorsOnly: true,
+		Limit:      10,
+	})
+	c.Assert(result, jc.DeepEquals, params.AuditLogEntries{
+		Entries: []params.AuditLogEntry{{
+			ConversationID: "aaaa",
+			ConnectionID:   "A1",
+			Who:            "bob",
+			What:           "juju remove-application mysql",
+			ModelName:      "bob/prod",
+			ModelUUID:      "uuid-1",
+			RequestID:      2,
+			When:           time.Date(2020, 1, 1, 10, 0, 2, 0, time.UTC),
+			Facade:         "Application",
+			Method:         "DestroyApplication",
+			Version:        11,
+			Args:           `{"applications":["mysql"]}`,
+			Errors: []params.AuditLogError{{
+				Message: "boom",
+				Code:    "not found",
+			}},
+		}},
+	})
+}
+
+func (s *auditLogSuite) TestQueryError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	api, err := auditlog.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.Query(params.AuditLogQueryArgs{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *auditLogSuite) TestQueryNegativeLimit(c *gc.C) {
+	api, err := auditlog.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.Query(params.AuditLogQueryArgs{Limit: -1})
+	c.Assert(err, gc.ErrorMatches, "negative limit not valid")
+	s.backend.CheckNoCalls(c)
+}
+
+type fakeBackend struct {
+	testing.Stub
+	entries []state.AuditLogEntry
+}
+
+func (b *fakeBackend) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (b *fakeBackend) QueryAuditLog(filter state.AuditLogFilter) ([]state.AuditLogEntry, error) {
+	b.MethodCall(b, "QueryAuditLog", filter)
+	return b.entries, b.NextErr()
+}
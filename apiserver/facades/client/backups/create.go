@@ -30,7 +30,24 @@ func (a *API) Create(args params.BackupsCreateArgs) (params.BackupsMetadataResul
 	return result, nil
 }
 
+// Create is the API method that requests juju to create a new backup
+// of its state.  It returns the metadata for that backup.
+//
+// NOTE: incremental backups are not supported before facade version 3.
 func (a *APIv2) Create(args params.BackupsCreateArgs) (params.BackupsMetadataResult, error) {
+	args.Incremental = false
+	return a.create(args)
+}
+
+// Create is the API method that requests juju to create a new backup
+// of its state.  It returns the metadata for that backup. An incremental
+// backup follows on from the most recent backup kept on the controller
+// that records its oplog position.
+func (a *APIv3) Create(args params.BackupsCreateArgs) (params.BackupsMetadataResult, error) {
+	return a.create(args)
+}
+
+func (a *API) create(args params.BackupsCreateArgs) (params.BackupsMetadataResult, error) {
 	backupsMethods, closer := newBackups(a.backend)
 	defer closer.Close()
 
@@ -70,6 +87,13 @@ func (a *APIv2) Create(args params.BackupsCreateArgs) (params.BackupsMetadataRes
 		return result, errors.Trace(err)
 	}
 	meta.Notes = args.Notes
+	if args.Incremental {
+		parent, err := latestOplogBackup(backupsMethods)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		meta.Parent = parent.ID()
+	}
 
 	fileName, err := backupsMethods.Create(meta, a.paths, dbInfo, args.KeepCopy, args.NoDownload)
 	if err != nil {
@@ -79,3 +103,26 @@ func (a *APIv2) Create(args params.BackupsCreateArgs) (params.BackupsMetadataRes
 	result = CreateResult(meta, fileName)
 	return result, nil
 }
+
+// latestOplogBackup returns the most recently started backup kept on
+// the controller that records its oplog position, which an incremental
+// backup can follow on from.
+func latestOplogBackup(backupsMethods backups.Backups) (*backups.Metadata, error) {
+	metas, err := backupsMethods.List()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var latest *backups.Metadata
+	for _, meta := range metas {
+		if meta.OplogEnd == 0 {
+			continue
+		}
+		if latest == nil || meta.Started.After(latest.Started) {
+			latest = meta
+		}
+	}
+	if latest == nil {
+		return nil, errors.New("no backup kept on the controller records an oplog position; create a full backup first")
+	}
+	return latest, nil
+}
@@ -4,12 +4,16 @@
 package backups_test
 
 import (
+	"fmt"
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/mgo.v2"
 
 	"github.com/juju/juju/apiserver/facades/client/backups"
 	"github.com/juju/juju/apiserver/params"
+	backupstesting "github.com/juju/juju/state/backups/testing"
 )
 
 func (s *backupsSuite) TestCreateOkay(c *gc.C) {
@@ -54,3 +58,66 @@ func (s *backupsSuite) TestCreateError(c *gc.C) {
 	c.Logf("%v", err)
 	c.Check(err, gc.ErrorMatches, "failed!")
 }
+
+func (s *backupsSuite) newAPIv3(c *gc.C) *backups.APIv3 {
+	api, err := backups.NewAPIv3(&stateShim{State: s.State, Model: s.Model}, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *backupsSuite) TestCreateIncremental(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	fake := s.setBackups(c, nil, "")
+	started := time.Date(2020, time.January, 1, 12, 0, 0, 0, time.UTC)
+	for i, oplogEnd := range []int64{10, 20, 0} {
+		meta := backupstesting.NewMetadataStarted()
+		meta.SetID(fmt.Sprintf("backup-%d", i))
+		meta.Started = started.Add(time.Duration(i) * time.Hour)
+		meta.OplogEnd = oplogEnd
+		fake.MetaList = append(fake.MetaList, meta)
+	}
+
+	args := params.BackupsCreateArgs{
+		KeepCopy:    true,
+		Incremental: true,
+	}
+	result, err := s.newAPIv3(c).Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(fake.Calls, jc.DeepEquals, []string{"List", "Create"})
+	c.Check(fake.MetaArg.Parent, gc.Equals, "backup-1")
+	c.Check(result.Parent, gc.Equals, "backup-1")
+}
+
+func (s *backupsSuite) TestCreateIncrementalWithoutParent(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	s.setBackups(c, nil, "")
+
+	args := params.BackupsCreateArgs{
+		KeepCopy:    true,
+		Incremental: true,
+	}
+	_, err := s.newAPIv3(c).Create(args)
+	c.Check(err, gc.ErrorMatches, "no backup kept on the controller records an oplog position; create a full backup first")
+}
+
+func (s *backupsSuite) TestCreateIncrementalV2(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	fake := s.setBackups(c, nil, "")
+
+	args := params.BackupsCreateArgs{
+		KeepCopy:    true,
+		Incremental: true,
+	}
+	result, err := s.api.Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(fake.Calls, jc.DeepEquals, []string{"Create"})
+	c.Check(result.Parent, gc.Equals, "")
+}
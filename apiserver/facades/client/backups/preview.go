@@ -0,0 +1,69 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/backups"
+)
+
+// PreviewRestore restores the identified backup into a scratch
+// database, and returns a summary of the result along with how it
+// differs from the controller as it is now. The controller itself is
+// not changed.
+func (a *APIv3) PreviewRestore(p params.RestoreArgs) (params.RestorePreviewResult, error) {
+	var result params.RestorePreviewResult
+
+	backupsMethods, closer := newBackups(a.backend)
+	defer closer.Close()
+
+	var until time.Time
+	if p.Until != nil {
+		until = *p.Until
+	}
+	restored, err := backupsMethods.Preview(p.BackupId, until)
+	if err != nil {
+		return result, errors.Annotatef(err, "cannot preview restore of backup %q", p.BackupId)
+	}
+
+	session := a.backend.MongoSession().Copy()
+	defer session.Close()
+	current, err := backups.SummarizeDB(session)
+	if err != nil {
+		return result, errors.Annotate(err, "cannot summarise controller")
+	}
+
+	for _, model := range restored.Models {
+		result.Models = append(result.Models, params.RestorePreviewModel{
+			UUID:         model.UUID,
+			Name:         model.Name,
+			Owner:        model.Owner,
+			AgentVersion: model.AgentVersion,
+			Machines:     model.Machines,
+			Applications: model.Applications,
+		})
+	}
+
+	diff := backups.DiffSummaries(current, restored)
+	result.Diff = params.RestorePreviewDiff{
+		AddedModels:   diff.AddedModels,
+		RemovedModels: diff.RemovedModels,
+	}
+	for _, model := range diff.ChangedModels {
+		result.Diff.ChangedModels = append(result.Diff.ChangedModels, params.RestorePreviewModelDiff{
+			Model:                model.Model,
+			CurrentAgentVersion:  model.CurrentAgentVersion,
+			RestoredAgentVersion: model.RestoredAgentVersion,
+			AddedMachines:        model.AddedMachines,
+			RemovedMachines:      model.RemovedMachines,
+			AddedApplications:    model.AddedApplications,
+			RemovedApplications:  model.RemovedApplications,
+		})
+	}
+	return result, nil
+}
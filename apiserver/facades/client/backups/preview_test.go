@@ -0,0 +1,62 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/backups"
+)
+
+func (s *backupsSuite) TestPreviewRestore(c *gc.C) {
+	current, err := backups.SummarizeDB(s.State.MongoSession())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(current.Models, gc.HasLen, 1)
+
+	restored := *current
+	restored.Models = append(restored.Models, backups.ModelSummary{
+		UUID:         "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Name:         "staging",
+		Owner:        "bob",
+		AgentVersion: "2.7.6",
+		Machines:     []string{"0", "1"},
+		Applications: []string{"mysql"},
+	})
+	fake := s.setBackups(c, nil, "")
+	fake.Summary = &restored
+
+	until := time.Date(2020, time.January, 1, 12, 0, 0, 0, time.UTC)
+	result, err := s.newAPIv3(c).PreviewRestore(params.RestoreArgs{
+		BackupId: "some-id",
+		Until:    &until,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(fake.Calls, jc.DeepEquals, []string{"Preview"})
+	c.Check(fake.IDArg, gc.Equals, "some-id")
+	c.Check(fake.UntilArg, gc.Equals, until)
+	c.Assert(result.Models, gc.HasLen, 2)
+	c.Check(result.Models[0].UUID, gc.Equals, s.Model.UUID())
+	c.Check(result.Models[1], jc.DeepEquals, params.RestorePreviewModel{
+		UUID:         "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Name:         "staging",
+		Owner:        "bob",
+		AgentVersion: "2.7.6",
+		Machines:     []string{"0", "1"},
+		Applications: []string{"mysql"},
+	})
+	c.Check(result.Diff, jc.DeepEquals, params.RestorePreviewDiff{
+		AddedModels: []string{"bob/staging"},
+	})
+}
+
+func (s *backupsSuite) TestPreviewRestoreError(c *gc.C) {
+	s.setBackups(c, nil, "failed!")
+	_, err := s.newAPIv3(c).PreviewRestore(params.RestoreArgs{BackupId: "some-id"})
+	c.Check(err, gc.ErrorMatches, `cannot preview restore of backup "some-id": failed!`)
+}
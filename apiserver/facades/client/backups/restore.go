@@ -67,6 +67,9 @@ func (a *API) Restore(p params.RestoreArgs) error {
 		NewInstTag:     machine.Tag(),
 		NewInstSeries:  machine.Series(),
 	}
+	if p.Until != nil {
+		restoreArgs.Until = *p.Until
+	}
 
 	session := a.backend.MongoSession().Copy()
 	defer session.Close()
@@ -486,6 +486,9 @@ type applicationStatusInfo struct {
 
 	// lxdProfiles: lxd profile name -> lxd profile
 	lxdProfiles map[string]*charm.LXDProfile
+
+	// exposedEndpoints: application name -> endpoint -> expose settings
+	exposedEndpoints map[string]map[string]params.ExposedEndpoint
 }
 
 type statusContext struct {
@@ -727,6 +730,11 @@ func fetchAllApplicationsAndUnits(
 		}
 	}
 
+	exposedEndpoints, err := fetchExposedEndpoints(st, applications)
+	if err != nil {
+		return applicationStatusInfo{}, err
+	}
+
 	for baseURL := range latestCharms {
 		ch, err := st.LatestPlaceholderCharm(&baseURL)
 		if errors.IsNotFound(err) {
@@ -744,9 +752,56 @@ func fetchAllApplicationsAndUnits(
 		latestCharms:     latestCharms,
 		endpointBindings: allBindingsByApp,
 		lxdProfiles:      lxdProfiles,
+		exposedEndpoints: exposedEndpoints,
 	}, nil
 }
 
+// fetchExposedEndpoints returns a map from application name to the expose
+// settings of its endpoints, with space IDs resolved to names. Applications
+// that are not exposed, or are exposed to 0.0.0.0/0 without any particular
+// settings, are omitted.
+func fetchExposedEndpoints(
+	st Backend,
+	applications []*state.Application,
+) (map[string]map[string]params.ExposedEndpoint, error) {
+	result := make(map[string]map[string]params.ExposedEndpoint)
+	var spaceInfos network.SpaceInfos
+	for _, app := range applications {
+		exposedEndpoints := app.ExposedEndpoints()
+		if len(exposedEndpoints) == 0 {
+			continue
+		}
+		if wildcard, ok := exposedEndpoints[""]; ok && len(exposedEndpoints) == 1 &&
+			len(wildcard.ExposeToSpaceIDs) == 0 && len(wildcard.ExposeToCIDRs) == 1 &&
+			wildcard.AllowTrafficFromAnyNetwork() {
+			continue
+		}
+		if spaceInfos == nil {
+			var err error
+			if spaceInfos, err = st.AllSpaceInfos(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		appEndpoints := make(map[string]params.ExposedEndpoint, len(exposedEndpoints))
+		for endpoint, exposed := range exposedEndpoints {
+			var spaceNames []string
+			for _, spaceID := range exposed.ExposeToSpaceIDs {
+				spaceName := spaceID
+				if space := spaceInfos.GetByID(spaceID); space != nil {
+					spaceName = string(space.Name)
+				}
+				spaceNames = append(spaceNames, spaceName)
+			}
+			appEndpoints[endpoint] = params.ExposedEndpoint{
+				ExposeToSpaces: spaceNames,
+				ExposeToCIDRs:  exposed.ExposeToCIDRs,
+			}
+		}
+		result[app.Name()] = appEndpoints
+	}
+	return result, nil
+}
+
 // fetchConsumerRemoteApplications returns a map from application name to remote application.
 func fetchConsumerRemoteApplications(st Backend) (map[string]*state.RemoteApplication, error) {
 	appMap := make(map[string]*state.RemoteApplication)
@@ -1214,6 +1269,7 @@ func (context *statusContext) processApplication(application *state.Application)
 		processedStatus.Scale = application.GetScale()
 	}
 	processedStatus.EndpointBindings = context.allAppsUnitsCharmBindings.endpointBindings[application.Name()]
+	processedStatus.ExposedEndpoints = context.allAppsUnitsCharmBindings.exposedEndpoints[application.Name()]
 	return processedStatus
 }
 
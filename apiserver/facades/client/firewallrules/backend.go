@@ -7,6 +7,7 @@ import (
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v3"
 
+	"github.com/juju/juju/core/firewall"
 	"github.com/juju/juju/state"
 )
 
@@ -17,6 +18,9 @@ type Backend interface {
 	ModelTag() names.ModelTag
 	SaveFirewallRule(state.FirewallRule) error
 	ListFirewallRules() ([]*state.FirewallRule, error)
+	SaveFirewallService(firewall.Service) error
+	RemoveFirewallService(firewall.WellKnownServiceType) error
+	ListFirewallServices() ([]firewall.Service, error)
 }
 
 // BlockChecker defines the block-checking functionality required by
@@ -57,3 +61,18 @@ func (s stateShim) ListFirewallRules() ([]*state.FirewallRule, error) {
 	api := state.NewFirewallRules(s.State)
 	return api.AllRules()
 }
+
+func (s stateShim) SaveFirewallService(service firewall.Service) error {
+	api := state.NewFirewallRules(s.State)
+	return api.SaveService(service)
+}
+
+func (s stateShim) RemoveFirewallService(name firewall.WellKnownServiceType) error {
+	api := state.NewFirewallRules(s.State)
+	return api.RemoveService(name)
+}
+
+func (s stateShim) ListFirewallServices() ([]firewall.Service, error) {
+	api := state.NewFirewallRules(s.State)
+	return api.AllServices()
+}
@@ -12,19 +12,35 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/firewall"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.firewallrules")
 
-// API provides the firewallrules facade APIs for v1.
+// API provides the firewallrules facade APIs for v2.
 type API struct {
 	backend    Backend
 	authorizer facade.Authorizer
 	check      BlockChecker
 }
 
+// APIv1 provides the firewallrules facade APIs for v1.
+type APIv1 struct {
+	*API
+}
+
+// NewFacadeV1 provides the signature required for facade registration
+// of the v1 facade.
+func NewFacadeV1(ctx facade.Context) (*APIv1, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv1{api}, nil
+}
+
 // NewFacade provides the signature required for facade registration.
 func NewFacade(ctx facade.Context) (*API, error) {
 	backend, err := NewStateBackend(ctx.State())
@@ -114,3 +130,87 @@ func (api *API) ListFirewallRules() (params.ListFirewallRulesResults, error) {
 	}
 	return listResults, nil
 }
+
+// SetFirewallServices creates or updates the definitions of the
+// specified services, to which firewall rules may then be applied.
+func (api *API) SetFirewallServices(args params.FirewallServiceArgs) (params.ErrorResults, error) {
+	var errResults params.ErrorResults
+	if err := api.checkAdmin(); err != nil {
+		return errResults, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errResults, errors.Trace(err)
+	}
+
+	results := make([]params.ErrorResult, len(args.Args))
+	for i, arg := range args.Args {
+		logger.Debugf("saving firewall service %+v", arg)
+		err := api.backend.SaveFirewallService(firewall.Service{
+			Name: firewall.WellKnownServiceType(arg.Name),
+			PortRange: network.PortRange{
+				Protocol: arg.Protocol,
+				FromPort: arg.FromPort,
+				ToPort:   arg.ToPort,
+			},
+		})
+		results[i].Error = common.ServerError(err)
+	}
+	errResults.Results = results
+	return errResults, nil
+}
+
+// RemoveFirewallServices removes the definitions of the specified
+// services, along with any firewall rules for them.
+func (api *API) RemoveFirewallServices(args params.KnownServiceArgs) (params.ErrorResults, error) {
+	var errResults params.ErrorResults
+	if err := api.checkAdmin(); err != nil {
+		return errResults, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errResults, errors.Trace(err)
+	}
+
+	results := make([]params.ErrorResult, len(args.KnownServices))
+	for i, name := range args.KnownServices {
+		err := api.backend.RemoveFirewallService(firewall.WellKnownServiceType(name))
+		results[i].Error = common.ServerError(err)
+	}
+	errResults.Results = results
+	return errResults, nil
+}
+
+// ListFirewallServices returns the definitions of all the services
+// defined by an operator.
+func (api *API) ListFirewallServices() (params.ListFirewallServicesResults, error) {
+	var listResults params.ListFirewallServicesResults
+	if err := api.checkCanRead(); err != nil {
+		return listResults, errors.Trace(err)
+	}
+	services, err := api.backend.ListFirewallServices()
+	if err != nil {
+		return listResults, errors.Trace(err)
+	}
+	listResults.Services = make([]params.FirewallService, len(services))
+	for i, s := range services {
+		listResults.Services[i] = params.FirewallService{
+			Name:     params.KnownServiceValue(s.Name),
+			Protocol: s.PortRange.Protocol,
+			FromPort: s.PortRange.FromPort,
+			ToPort:   s.PortRange.ToPort,
+		}
+	}
+	return listResults, nil
+}
+
+// Mask out new methods from the old API versions. The API reflection
+// code in rpc/rpcreflect/type.go:newMethod skips 2-argument methods,
+// so this removes the method as far as the RPC machinery is concerned.
+//
+// SetFirewallServices did not exist prior to v2.
+func (*APIv1) SetFirewallServices(_, _ struct{}) {}
+
+// RemoveFirewallServices did not exist prior to v2.
+func (*APIv1) RemoveFirewallServices(_, _ struct{}) {}
+
+// ListFirewallServices did not exist prior to v2.
+func (*APIv1) ListFirewallServices(_, _ struct{}) {}
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/core/firewall"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -50,6 +51,7 @@ func (s *FirewallRulesSuite) SetUpTest(c *gc.C) {
 	s.backend = mockBackend{
 		modelUUID: coretesting.ModelTag.Id(),
 		rules:     make(map[string]state.FirewallRule),
+		services:  make(map[string]firewall.Service),
 	}
 	s.blockChecker = mockBlockChecker{}
 	api, err := firewallrules.NewAPI(
@@ -118,3 +120,77 @@ func (s *FirewallRulesSuite) TestListFirewallRulesPermission(c *gc.C) {
 	_, err := s.api.ListFirewallRules()
 	c.Assert(err, gc.ErrorMatches, ".*permission denied.*")
 }
+
+func (s *FirewallRulesSuite) TestSetFirewallServices(c *gc.C) {
+	// The first error is for the ModelTag call made by the permission check.
+	s.backend.SetErrors(nil, nil, errors.NotValidf(`redefining built-in service "ssh"`))
+	result, err := s.api.SetFirewallServices(params.FirewallServiceArgs{
+		Args: []params.FirewallService{{
+			Name:     "node-exporter",
+			Protocol: "tcp",
+			FromPort: 9100,
+			ToPort:   9100,
+		}, {
+			Name:     "ssh",
+			Protocol: "tcp",
+			FromPort: 22,
+			ToPort:   22,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `redefining built-in service "ssh" not valid`)
+	c.Assert(s.backend.services, jc.DeepEquals, map[string]firewall.Service{
+		"node-exporter": {
+			Name:      "node-exporter",
+			PortRange: network.PortRange{Protocol: "tcp", FromPort: 9100, ToPort: 9100},
+		},
+	})
+}
+
+func (s *FirewallRulesSuite) TestSetFirewallServicesPermission(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("mary"))
+	_, err := s.api.SetFirewallServices(params.FirewallServiceArgs{
+		Args: []params.FirewallService{{Name: "node-exporter", Protocol: "tcp", FromPort: 9100, ToPort: 9100}},
+	})
+	c.Assert(err, gc.ErrorMatches, ".*permission denied.*")
+	c.Assert(s.backend.services, gc.HasLen, 0)
+}
+
+func (s *FirewallRulesSuite) TestRemoveFirewallServices(c *gc.C) {
+	s.backend.services["node-exporter"] = firewall.Service{Name: "node-exporter"}
+	result, err := s.api.RemoveFirewallServices(params.KnownServiceArgs{
+		KnownServices: []params.KnownServiceValue{"node-exporter"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{[]params.ErrorResult{{Error: nil}}})
+	c.Assert(s.backend.services, gc.HasLen, 0)
+}
+
+func (s *FirewallRulesSuite) TestRemoveFirewallServicesBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.RemoveFirewallServices(params.KnownServiceArgs{
+		KnownServices: []params.KnownServiceValue{"node-exporter"},
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	s.backend.CheckCallNames(c, "ModelTag")
+}
+
+func (s *FirewallRulesSuite) TestListFirewallServices(c *gc.C) {
+	s.backend.services["node-exporter"] = firewall.Service{
+		Name:      "node-exporter",
+		PortRange: network.PortRange{Protocol: "tcp", FromPort: 9100, ToPort: 9100},
+	}
+	result, err := s.api.ListFirewallServices()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ListFirewallServicesResults{
+		Services: []params.FirewallService{{
+			Name:     "node-exporter",
+			Protocol: "tcp",
+			FromPort: 9100,
+			ToPort:   9100,
+		}},
+	})
+}
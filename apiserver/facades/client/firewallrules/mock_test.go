@@ -18,6 +18,7 @@ type mockBackend struct {
 
 	modelUUID string
 	rules     map[string]state.FirewallRule
+	services  map[string]firewall.Service
 }
 
 func (m *mockBackend) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
@@ -46,6 +47,34 @@ func (m *mockBackend) ListFirewallRules() ([]*state.FirewallRule, error) {
 	return frls, nil
 }
 
+func (m *mockBackend) SaveFirewallService(service firewall.Service) error {
+	m.MethodCall(m, "SaveFirewallService", service)
+	if err := m.NextErr(); err != nil {
+		return err
+	}
+	m.services[string(service.Name)] = service
+	return nil
+}
+
+func (m *mockBackend) RemoveFirewallService(name firewall.WellKnownServiceType) error {
+	m.MethodCall(m, "RemoveFirewallService", name)
+	if err := m.NextErr(); err != nil {
+		return err
+	}
+	delete(m.services, string(name))
+	return nil
+}
+
+func (m *mockBackend) ListFirewallServices() ([]firewall.Service, error) {
+	m.MethodCall(m, "ListFirewallServices")
+	m.PopNoErr()
+	var services []firewall.Service
+	for _, service := range m.services {
+		services = append(services, service)
+	}
+	return services, nil
+}
+
 type mockBlockChecker struct {
 	jtesting.Stub
 }
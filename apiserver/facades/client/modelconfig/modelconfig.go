@@ -116,6 +116,11 @@ func (c *ModelConfigAPI) ModelGet() (params.ModelConfigResults, error) {
 		return result, errors.Trace(err)
 	}
 
+	schema, err := config.Schema(nil)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
 	result.Config = make(map[string]params.ConfigValue)
 	for attr, val := range values {
 		// Authorized keys are able to be listed using
@@ -124,6 +129,11 @@ func (c *ModelConfigAPI) ModelGet() (params.ModelConfigResults, error) {
 		if attr == config.AuthorizedKeysKey {
 			continue
 		}
+		// Secret attributes, such as passwords, may be
+		// set but are never displayed.
+		if field, ok := schema[attr]; ok && field.Secret {
+			continue
+		}
 		result.Config[attr] = params.ConfigValue{
 			Value:  val.Value,
 			Source: val.Source,
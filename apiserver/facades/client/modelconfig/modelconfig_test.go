@@ -37,10 +37,11 @@ func (s *modelconfigSuite) SetUpTest(c *gc.C) {
 	}
 	s.backend = &mockBackend{
 		cfg: config.ConfigValues{
-			"type":            {"dummy", "model"},
-			"agent-version":   {"1.2.3.4", "model"},
-			"ftp-proxy":       {"http://proxy", "model"},
-			"authorized-keys": {testing.FakeAuthKeys, "model"},
+			"type":                     {"dummy", "model"},
+			"agent-version":            {"1.2.3.4", "model"},
+			"ftp-proxy":                {"http://proxy", "model"},
+			"authorized-keys":          {testing.FakeAuthKeys, "model"},
+			"logforward-http-password": {"secret", "model"},
 		},
 	}
 	var err error
@@ -8,7 +8,11 @@ import (
 	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/settings"
+	"github.com/juju/juju/state"
 )
 
 //go:generate mockgen -package mocks -destination mocks/package_mock.go github.com/juju/juju/apiserver/facades/client/modelgeneration State,Model,Generation,Application,ModelCache
@@ -18,6 +22,7 @@ type State interface {
 	ControllerTag() names.ControllerTag
 	Model() (Model, error)
 	Application(string) (Application, error)
+	AllSpaceInfos() (network.SpaceInfos, error)
 }
 
 // Model describes model state used by the model generation API.
@@ -49,6 +54,16 @@ type Generation interface {
 	Commit(string) (int, error)
 	Abort(string) error
 	Config() map[string]settings.ItemChanges
+	CharmURLs() map[string]string
+	Resources() map[string]map[string]string
+	Constraints() map[string]constraints.Value
+	Scales() map[string]int
+	Bindings() map[string]map[string]string
+	SetConstraints(string, constraints.Value) error
+	SetScale(string, int) error
+	MergeBindings(string, map[string]string, bool) error
+	Rollout() (state.BranchRollout, bool)
+	StartRollout(model.RolloutPolicy, string) error
 	GenerationId() int
 }
 
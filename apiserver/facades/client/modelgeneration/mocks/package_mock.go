@@ -10,7 +10,11 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	modelgeneration "github.com/juju/juju/apiserver/facades/client/modelgeneration"
 	cache "github.com/juju/juju/core/cache"
+	constraints "github.com/juju/juju/core/constraints"
+	model "github.com/juju/juju/core/model"
+	network "github.com/juju/juju/core/network"
 	settings "github.com/juju/juju/core/settings"
+	state "github.com/juju/juju/state"
 	charm_v6 "gopkg.in/juju/charm.v6"
 	names_v3 "gopkg.in/juju/names.v3"
 )
@@ -38,6 +42,21 @@ func (m *MockState) EXPECT() *MockStateMockRecorder {
 	return m.recorder
 }
 
+// AllSpaceInfos mocks base method
+func (m *MockState) AllSpaceInfos() (network.SpaceInfos, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllSpaceInfos")
+	ret0, _ := ret[0].(network.SpaceInfos)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllSpaceInfos indicates an expected call of AllSpaceInfos
+func (mr *MockStateMockRecorder) AllSpaceInfos() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllSpaceInfos", reflect.TypeOf((*MockState)(nil).AllSpaceInfos))
+}
+
 // Application mocks base method
 func (m *MockState) Application(arg0 string) (modelgeneration.Application, error) {
 	m.ctrl.T.Helper()
@@ -286,6 +305,20 @@ func (mr *MockGenerationMockRecorder) AssignedUnits() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignedUnits", reflect.TypeOf((*MockGeneration)(nil).AssignedUnits))
 }
 
+// Bindings mocks base method
+func (m *MockGeneration) Bindings() map[string]map[string]string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Bindings")
+	ret0, _ := ret[0].(map[string]map[string]string)
+	return ret0
+}
+
+// Bindings indicates an expected call of Bindings
+func (mr *MockGenerationMockRecorder) Bindings() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bindings", reflect.TypeOf((*MockGeneration)(nil).Bindings))
+}
+
 // BranchName mocks base method
 func (m *MockGeneration) BranchName() string {
 	m.ctrl.T.Helper()
@@ -300,6 +333,20 @@ func (mr *MockGenerationMockRecorder) BranchName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BranchName", reflect.TypeOf((*MockGeneration)(nil).BranchName))
 }
 
+// CharmURLs mocks base method
+func (m *MockGeneration) CharmURLs() map[string]string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CharmURLs")
+	ret0, _ := ret[0].(map[string]string)
+	return ret0
+}
+
+// CharmURLs indicates an expected call of CharmURLs
+func (mr *MockGenerationMockRecorder) CharmURLs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CharmURLs", reflect.TypeOf((*MockGeneration)(nil).CharmURLs))
+}
+
 // Commit mocks base method
 func (m *MockGeneration) Commit(arg0 string) (int, error) {
 	m.ctrl.T.Helper()
@@ -357,6 +404,20 @@ func (mr *MockGenerationMockRecorder) Config() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Config", reflect.TypeOf((*MockGeneration)(nil).Config))
 }
 
+// Constraints mocks base method
+func (m *MockGeneration) Constraints() map[string]constraints.Value {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Constraints")
+	ret0, _ := ret[0].(map[string]constraints.Value)
+	return ret0
+}
+
+// Constraints indicates an expected call of Constraints
+func (mr *MockGenerationMockRecorder) Constraints() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Constraints", reflect.TypeOf((*MockGeneration)(nil).Constraints))
+}
+
 // Created mocks base method
 func (m *MockGeneration) Created() int64 {
 	m.ctrl.T.Helper()
@@ -399,6 +460,105 @@ func (mr *MockGenerationMockRecorder) GenerationId() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerationId", reflect.TypeOf((*MockGeneration)(nil).GenerationId))
 }
 
+// MergeBindings mocks base method
+func (m *MockGeneration) MergeBindings(arg0 string, arg1 map[string]string, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeBindings", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeBindings indicates an expected call of MergeBindings
+func (mr *MockGenerationMockRecorder) MergeBindings(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeBindings", reflect.TypeOf((*MockGeneration)(nil).MergeBindings), arg0, arg1, arg2)
+}
+
+// Resources mocks base method
+func (m *MockGeneration) Resources() map[string]map[string]string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resources")
+	ret0, _ := ret[0].(map[string]map[string]string)
+	return ret0
+}
+
+// Resources indicates an expected call of Resources
+func (mr *MockGenerationMockRecorder) Resources() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resources", reflect.TypeOf((*MockGeneration)(nil).Resources))
+}
+
+// Rollout mocks base method
+func (m *MockGeneration) Rollout() (state.BranchRollout, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rollout")
+	ret0, _ := ret[0].(state.BranchRollout)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Rollout indicates an expected call of Rollout
+func (mr *MockGenerationMockRecorder) Rollout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollout", reflect.TypeOf((*MockGeneration)(nil).Rollout))
+}
+
+// Scales mocks base method
+func (m *MockGeneration) Scales() map[string]int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Scales")
+	ret0, _ := ret[0].(map[string]int)
+	return ret0
+}
+
+// Scales indicates an expected call of Scales
+func (mr *MockGenerationMockRecorder) Scales() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scales", reflect.TypeOf((*MockGeneration)(nil).Scales))
+}
+
+// SetConstraints mocks base method
+func (m *MockGeneration) SetConstraints(arg0 string, arg1 constraints.Value) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetConstraints", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetConstraints indicates an expected call of SetConstraints
+func (mr *MockGenerationMockRecorder) SetConstraints(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetConstraints", reflect.TypeOf((*MockGeneration)(nil).SetConstraints), arg0, arg1)
+}
+
+// SetScale mocks base method
+func (m *MockGeneration) SetScale(arg0 string, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetScale", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetScale indicates an expected call of SetScale
+func (mr *MockGenerationMockRecorder) SetScale(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetScale", reflect.TypeOf((*MockGeneration)(nil).SetScale), arg0, arg1)
+}
+
+// StartRollout mocks base method
+func (m *MockGeneration) StartRollout(arg0 model.RolloutPolicy, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartRollout", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartRollout indicates an expected call of StartRollout
+func (mr *MockGenerationMockRecorder) StartRollout(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartRollout", reflect.TypeOf((*MockGeneration)(nil).StartRollout), arg0, arg1)
+}
+
 // MockApplication is a mock of Application interface
 type MockApplication struct {
 	ctrl     *gomock.Controller
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/permission"
 )
 
@@ -31,10 +32,18 @@ type API struct {
 	modelCache        ModelCache
 }
 
-type APIV3 struct {
+type APIV5 struct {
 	*API
 }
 
+type APIV4 struct {
+	*APIV5
+}
+
+type APIV3 struct {
+	*APIV4
+}
+
 type APIV2 struct {
 	*APIV3
 }
@@ -43,8 +52,8 @@ type APIV1 struct {
 	*APIV2
 }
 
-// NewModelGenerationFacadeV4 provides the signature required for facade registration.
-func NewModelGenerationFacadeV4(ctx facade.Context) (*API, error) {
+// NewModelGenerationFacadeV6 provides the signature required for facade registration.
+func NewModelGenerationFacadeV6(ctx facade.Context) (*API, error) {
 	authorizer := ctx.Auth()
 	st := &stateShim{State: ctx.State()}
 	m, err := st.Model()
@@ -58,6 +67,24 @@ func NewModelGenerationFacadeV4(ctx facade.Context) (*API, error) {
 	return NewModelGenerationAPI(st, authorizer, m, &modelCacheShim{Model: mc})
 }
 
+// NewModelGenerationFacadeV5 provides the signature required for facade registration.
+func NewModelGenerationFacadeV5(ctx facade.Context) (*APIV5, error) {
+	v6, err := NewModelGenerationFacadeV6(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &APIV5{v6}, nil
+}
+
+// NewModelGenerationFacadeV4 provides the signature required for facade registration.
+func NewModelGenerationFacadeV4(ctx facade.Context) (*APIV4, error) {
+	v5, err := NewModelGenerationFacadeV5(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &APIV4{v5}, nil
+}
+
 // NewModelGenerationFacadeV3 provides the signature required for facade registration.
 func NewModelGenerationFacadeV3(ctx facade.Context) (*APIV3, error) {
 	v4, err := NewModelGenerationFacadeV4(ctx)
@@ -220,9 +247,42 @@ func (api *API) CommitBranch(arg params.BranchArg) (params.IntResult, error) {
 	return result, nil
 }
 
+// RolloutBranch starts a progressive rollout of the input branch.
+// Units are set to track the branch in batches, according to the input
+// policy, and the branch is committed when all units are tracking it.
+func (api *API) RolloutBranch(arg params.BranchRolloutArg) (params.ErrorResult, error) {
+	result := params.ErrorResult{}
+
+	isModelAdmin, err := api.hasAdminAccess()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if !isModelAdmin && !api.isControllerAdmin {
+		return result, common.ErrPerm
+	}
+
+	branch, err := api.model.Branch(arg.BranchName)
+	if err != nil {
+		result.Error = common.ServerError(err)
+		return result, nil
+	}
+
+	policy := model.RolloutPolicy{
+		BatchSize:     arg.BatchSize,
+		BatchInterval: arg.BatchInterval,
+		HaltOnError:   arg.HaltOnError,
+	}
+	result.Error = common.ServerError(branch.StartRollout(policy, api.apiUser.Name()))
+	return result, nil
+}
+
+// RolloutBranch is not available on the V5 API.
+func (*APIV5) RolloutBranch(_, _ struct{}) {}
+
 // AbortBranch aborts the input branch, marking it complete.  However no
 // changes are made applicable to the whole model.  No units may be assigned
-// to the branch when aborting.
+// to the branch when aborting; a rollout of the branch in progress is
+// stopped.
 func (api *API) AbortBranch(arg params.BranchArg) (params.ErrorResult, error) {
 	result := params.ErrorResult{}
 
@@ -246,6 +306,61 @@ func (api *API) AbortBranch(arg params.BranchArg) (params.ErrorResult, error) {
 	return result, nil
 }
 
+// StageApplicationChanges records the input application constraints,
+// scales and endpoint bindings under their branches.
+// The changes are applied to the applications when the branches are
+// committed.
+func (api *API) StageApplicationChanges(args params.BranchApplicationChangesArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{}
+
+	isModelAdmin, err := api.hasAdminAccess()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if !isModelAdmin && !api.isControllerAdmin {
+		return result, common.ErrPerm
+	}
+
+	result.Results = make([]params.ErrorResult, len(args.Args))
+	for i, arg := range args.Args {
+		result.Results[i].Error = common.ServerError(api.stageApplicationChanges(arg))
+	}
+	return result, nil
+}
+
+// StageApplicationChanges is not available on the V4 API.
+func (*APIV4) StageApplicationChanges(_, _ struct{}) {}
+
+func (api *API) stageApplicationChanges(arg params.BranchApplicationChanges) error {
+	if err := model.ValidateBranchName(arg.BranchName); err != nil {
+		return errors.Trace(err)
+	}
+	if arg.Constraints == nil && arg.Scale == nil && len(arg.Bindings) == 0 {
+		return errors.NotValidf("no changes for application %q", arg.ApplicationName)
+	}
+
+	branch, err := api.model.Branch(arg.BranchName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if arg.Constraints != nil {
+		if err := branch.SetConstraints(arg.ApplicationName, *arg.Constraints); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if arg.Scale != nil {
+		if err := branch.SetScale(arg.ApplicationName, *arg.Scale); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if len(arg.Bindings) > 0 {
+		if err := branch.MergeBindings(arg.ApplicationName, arg.Bindings, arg.Force); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
 // BranchInfo will return details of branch identified by the input argument,
 // including units on the branch and the configuration disjoint with the
 // master generation.
@@ -357,6 +472,20 @@ func (api *API) ListCommits() (params.BranchResults, error) {
 
 func (api *API) oneBranchInfo(branch Generation, detailed bool) (params.Generation, error) {
 	deltas := branch.Config()
+	charmURLs := branch.CharmURLs()
+	resources := branch.Resources()
+	cons := branch.Constraints()
+	scales := branch.Scales()
+	bindings := branch.Bindings()
+
+	// Bindings are staged with space IDs; they are reported by name.
+	var spaceInfos network.SpaceInfos
+	if len(bindings) > 0 {
+		var err error
+		if spaceInfos, err = api.st.AllSpaceInfos(); err != nil {
+			return params.Generation{}, errors.Trace(err)
+		}
+	}
 
 	var apps []params.GenerationApplication
 	for appName, tracking := range branch.AssignedUnits() {
@@ -380,10 +509,19 @@ func (api *API) oneBranchInfo(branch Generation, detailed bool) (params.Generati
 			return params.Generation{}, errors.Trace(err)
 		}
 		branchApp.ConfigChanges = deltas[appName].EffectiveChanges(defaults)
-
-		// TODO (manadart 2019-04-12): Charm URL.
-
-		// TODO (manadart 2019-04-12): Resources.
+		branchApp.CharmURL = charmURLs[appName]
+		branchApp.Resources = resources[appName]
+		if appCons, ok := cons[appName]; ok {
+			branchApp.Constraints = &appCons
+		}
+		if scale, ok := scales[appName]; ok {
+			branchApp.Scale = &scale
+		}
+		if appBindings, ok := bindings[appName]; ok {
+			if branchApp.Bindings, err = bindingsWithSpaceNames(appBindings, spaceInfos); err != nil {
+				return params.Generation{}, errors.Trace(err)
+			}
+		}
 
 		// Only include unit names if detailed info was requested.
 		if detailed {
@@ -395,12 +533,38 @@ func (api *API) oneBranchInfo(branch Generation, detailed bool) (params.Generati
 		apps = append(apps, branchApp)
 	}
 
-	return params.Generation{
+	result := params.Generation{
 		BranchName:   branch.BranchName(),
 		Created:      branch.Created(),
 		CreatedBy:    branch.CreatedBy(),
 		Applications: apps,
-	}, nil
+	}
+	if rollout, ok := branch.Rollout(); ok {
+		result.Rollout = &params.GenerationRollout{
+			BatchSize:     rollout.BatchSize,
+			BatchInterval: rollout.BatchInterval,
+			HaltOnError:   rollout.HaltOnError,
+			Started:       rollout.Started.Unix(),
+			StartedBy:     rollout.StartedBy,
+			Batches:       rollout.Batches,
+			Halted:        rollout.Halted,
+		}
+	}
+	return result, nil
+}
+
+// bindingsWithSpaceNames returns the input bindings
+// with space IDs replaced by space names.
+func bindingsWithSpaceNames(bindings map[string]string, spaceInfos network.SpaceInfos) (map[string]string, error) {
+	named := make(map[string]string, len(bindings))
+	for endpoint, spaceID := range bindings {
+		info := spaceInfos.GetByID(spaceID)
+		if info == nil {
+			return nil, errors.NotFoundf("space with ID %q", spaceID)
+		}
+		named[endpoint] = string(info.Name)
+	}
+	return named, nil
 }
 
 func (api *API) getGenerationCommit(branch Generation) (params.Generation, error) {
@@ -4,6 +4,8 @@
 package modelgeneration_test
 
 import (
+	"time"
+
 	"github.com/golang/mock/gomock"
 	"github.com/juju/errors"
 	"github.com/juju/juju/core/cache"
@@ -15,8 +17,11 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/modelgeneration"
 	"github.com/juju/juju/apiserver/facades/client/modelgeneration/mocks"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/settings"
+	"github.com/juju/juju/state"
 )
 
 type modelGenerationSuite struct {
@@ -146,6 +151,23 @@ func (s *modelGenerationSuite) TestAbortBranchSuccess(c *gc.C) {
 	c.Assert(result, gc.DeepEquals, params.ErrorResult{Error: nil})
 }
 
+func (s *modelGenerationSuite) TestRolloutBranch(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+	s.expectBranch()
+
+	policy := model.RolloutPolicy{BatchSize: 2, BatchInterval: time.Minute, HaltOnError: true}
+	s.mockGen.EXPECT().StartRollout(policy, s.apiUser).Return(nil)
+
+	result, err := s.api.RolloutBranch(params.BranchRolloutArg{
+		BranchName:    s.newBranchName,
+		BatchSize:     2,
+		BatchInterval: time.Minute,
+		HaltOnError:   true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResult{Error: nil})
+}
+
 func (s *modelGenerationSuite) TestHasActiveBranchTrue(c *gc.C) {
 	defer s.setupModelGenerationAPI(c).Finish()
 	s.expectHasActiveBranch(nil)
@@ -166,6 +188,46 @@ func (s *modelGenerationSuite) TestHasActiveBranchFalse(c *gc.C) {
 	c.Check(result.Result, jc.IsFalse)
 }
 
+func (s *modelGenerationSuite) TestStageApplicationChanges(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+	s.expectBranch()
+
+	cons := constraints.MustParse("mem=4G")
+	scale := 3
+	bindings := map[string]string{"db": "alpha"}
+	s.mockGen.EXPECT().SetConstraints("redis", cons).Return(nil)
+	s.mockGen.EXPECT().SetScale("redis", scale).Return(nil)
+	s.mockGen.EXPECT().MergeBindings("redis", bindings, true).Return(nil)
+
+	result, err := s.api.StageApplicationChanges(params.BranchApplicationChangesArgs{
+		Args: []params.BranchApplicationChanges{{
+			BranchName:      s.newBranchName,
+			ApplicationName: "redis",
+			Constraints:     &cons,
+			Scale:           &scale,
+			Bindings:        bindings,
+			Force:           true,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Results, gc.DeepEquals, []params.ErrorResult{{}})
+}
+
+func (s *modelGenerationSuite) TestStageApplicationChangesErrors(c *gc.C) {
+	defer s.setupModelGenerationAPI(c).Finish()
+
+	result, err := s.api.StageApplicationChanges(params.BranchApplicationChangesArgs{
+		Args: []params.BranchApplicationChanges{
+			{BranchName: model.GenerationMaster, ApplicationName: "redis"},
+			{BranchName: s.newBranchName, ApplicationName: "redis"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Check(result.Results[0].Error, gc.ErrorMatches, `branch name "master" not valid`)
+	c.Check(result.Results[1].Error, gc.ErrorMatches, `no changes for application "redis" not valid`)
+}
+
 func (s *modelGenerationSuite) TestBranchInfoDetailed(c *gc.C) {
 	s.testBranchInfo(c, nil, true)
 }
@@ -179,12 +241,19 @@ func (s *modelGenerationSuite) testBranchInfo(c *gc.C, branchNames []string, det
 	defer ctrl.Finish()
 
 	units := []string{"redis/0", "redis/1", "redis/2"}
+	mem := uint64(4096)
 
 	s.expectConfig()
+	s.expectCharmURLs()
+	s.expectResources()
+	s.expectConstraints()
+	s.expectScales()
+	s.expectBindings()
 	s.expectBranchName()
 	s.expectAssignedUnits(units[:2])
 	s.expectCreated()
 	s.expectCreatedBy()
+	s.expectRollout()
 
 	// Flex the code path based on whether we are getting all branches
 	// or a sub-set.
@@ -209,6 +278,13 @@ func (s *modelGenerationSuite) testBranchInfo(c *gc.C, branchNames []string, det
 	c.Assert(gen.Created, gc.Equals, int64(666))
 	c.Assert(gen.CreatedBy, gc.Equals, s.apiUser)
 	c.Assert(gen.Applications, gc.HasLen, 1)
+	c.Check(gen.Rollout, gc.DeepEquals, &params.GenerationRollout{
+		BatchSize:     2,
+		BatchInterval: time.Minute,
+		Started:       777,
+		StartedBy:     s.apiUser,
+		Batches:       1,
+	})
 
 	genApp := gen.Applications[0]
 	c.Check(genApp.ApplicationName, gc.Equals, "redis")
@@ -218,6 +294,11 @@ func (s *modelGenerationSuite) testBranchInfo(c *gc.C, branchNames []string, det
 		"databases": 16,
 		"port":      8000,
 	})
+	c.Check(genApp.CharmURL, gc.Equals, "cs:redis-2")
+	c.Check(genApp.Resources, gc.DeepEquals, map[string]string{"data": "pending-id"})
+	c.Check(genApp.Constraints, gc.DeepEquals, &constraints.Value{Mem: &mem})
+	c.Check(genApp.Scale, gc.IsNil)
+	c.Check(genApp.Bindings, gc.DeepEquals, map[string]string{"": "alpha", "db": "db-space"})
 
 	// Unit lists are only populated when detailed is true.
 	if detailed {
@@ -319,6 +400,40 @@ func (s *modelGenerationSuite) expectConfig() {
 	}})
 }
 
+func (s *modelGenerationSuite) expectCharmURLs() {
+	s.mockGen.EXPECT().CharmURLs().Return(map[string]string{"redis": "cs:redis-2"})
+}
+
+func (s *modelGenerationSuite) expectResources() {
+	s.mockGen.EXPECT().Resources().Return(map[string]map[string]string{"redis": {"data": "pending-id"}})
+}
+
+func (s *modelGenerationSuite) expectConstraints() {
+	s.mockGen.EXPECT().Constraints().Return(map[string]constraints.Value{"redis": constraints.MustParse("mem=4G")})
+}
+
+func (s *modelGenerationSuite) expectScales() {
+	s.mockGen.EXPECT().Scales().Return(map[string]int{})
+}
+
+func (s *modelGenerationSuite) expectBindings() {
+	s.mockGen.EXPECT().Bindings().Return(map[string]map[string]string{"redis": {"": "0", "db": "1"}})
+	s.mockState.EXPECT().AllSpaceInfos().Return(network.SpaceInfos{
+		{ID: "0", Name: "alpha"},
+		{ID: "1", Name: "db-space"},
+	}, nil)
+}
+
+func (s *modelGenerationSuite) expectRollout() {
+	s.mockGen.EXPECT().Rollout().Return(state.BranchRollout{
+		RolloutPolicy: model.RolloutPolicy{BatchSize: 2, BatchInterval: time.Minute},
+		Started:       time.Unix(777, 0),
+		StartedBy:     s.apiUser,
+		LastBatch:     time.Unix(888, 0),
+		Batches:       1,
+	}, true)
+}
+
 func (s *modelGenerationSuite) setupMockApp(ctrl *gomock.Controller, units []string) {
 	mockApp := mocks.NewMockApplication(ctrl)
 	mockApp.EXPECT().DefaultCharmConfig().Return(map[string]interface{}{
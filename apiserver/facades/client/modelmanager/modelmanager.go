@@ -1412,8 +1412,17 @@ func (m *ModelManagerAPI) modelDefaults(cloud string) params.ModelDefaultsResult
 		result.Error = common.ServerError(err)
 		return result
 	}
+	schema, err := config.Schema(nil)
+	if err != nil {
+		result.Error = common.ServerError(err)
+		return result
+	}
 	result.Config = make(map[string]params.ModelDefaults)
 	for attr, val := range values {
+		// Secret attributes may be set but are never displayed.
+		if field, ok := schema[attr]; ok && field.Secret {
+			continue
+		}
 		settings := params.ModelDefaults{
 			Controller: val.Controller,
 			Default:    val.Default,
@@ -507,6 +507,9 @@ func (s *modelManagerSuite) TestCreateCAASModelNamespaceClash(c *gc.C) {
 }
 
 func (s *modelManagerSuite) TestModelDefaults(c *gc.C) {
+	s.ctlrSt.cfgDefaults["logforward-http-password"] = config.AttributeDefaultValues{
+		Controller: "secret",
+	}
 	results, err := s.api.ModelDefaultsForClouds(params.Entities{
 		Entities: []params.Entity{{Tag: names.NewCloudTag("dummy").String()}},
 	})
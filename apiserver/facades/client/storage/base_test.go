@@ -44,6 +44,7 @@ type baseStorageSuite struct {
 	filesystemTag        names.FilesystemTag
 	filesystem           *mockFilesystem
 	filesystemAttachment *mockFilesystemAttachment
+	volumeSnapshots      []state.VolumeSnapshot
 	stub                 testing.Stub
 
 	registry    jujustorage.StaticProviderRegistry
@@ -75,7 +76,9 @@ func (s *baseStorageSuite) SetUpTest(c *gc.C) {
 	s.apiv3 = &storage.StorageAPIv3{
 		StorageAPIv4: storage.StorageAPIv4{
 			StorageAPIv5: storage.StorageAPIv5{
-				StorageAPI: *newAPI,
+				StorageAPIv6: storage.StorageAPIv6{
+					StorageAPI: *newAPI,
+				},
 			},
 		},
 	}
@@ -112,6 +115,11 @@ const (
 	destroyStorageInstanceCall              = "destroyStorageInstance"
 	releaseStorageInstanceCall              = "releaseStorageInstance"
 	addExistingFilesystemCall               = "addExistingFilesystem"
+	resizeVolumeCall                        = "resizeVolume"
+	createVolumeSnapshotCall                = "createVolumeSnapshot"
+	allVolumeSnapshotsCall                  = "allVolumeSnapshots"
+	destroyVolumeSnapshotCall               = "destroyVolumeSnapshot"
+	restoreVolumeSnapshotCall               = "restoreVolumeSnapshot"
 )
 
 func (s *baseStorageSuite) constructState() *mockState {
@@ -320,6 +328,26 @@ func (s *baseStorageSuite) constructStorageAccessor() *mockStorageAccessor {
 			s.stub.AddCall(addExistingFilesystemCall, f, v, storageName)
 			return s.storageTag, s.stub.NextErr()
 		},
+		resizeVolume: func(tag names.VolumeTag, size uint64) error {
+			s.stub.AddCall(resizeVolumeCall, tag, size)
+			return s.stub.NextErr()
+		},
+		createVolumeSnapshot: func(tag names.VolumeTag, name string) error {
+			s.stub.AddCall(createVolumeSnapshotCall, tag, name)
+			return s.stub.NextErr()
+		},
+		allVolumeSnapshots: func() ([]state.VolumeSnapshot, error) {
+			s.stub.AddCall(allVolumeSnapshotsCall)
+			return s.volumeSnapshots, s.stub.NextErr()
+		},
+		destroyVolumeSnapshot: func(name string) error {
+			s.stub.AddCall(destroyVolumeSnapshotCall, name)
+			return s.stub.NextErr()
+		},
+		restoreVolumeSnapshot: func(name, storageName string) (names.StorageTag, error) {
+			s.stub.AddCall(restoreVolumeSnapshotCall, name, storageName)
+			return names.NewStorageTag(storageName + "/1"), s.stub.NextErr()
+		},
 	}
 }
 
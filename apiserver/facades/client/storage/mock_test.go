@@ -71,6 +71,11 @@ type mockStorageAccessor struct {
 	attachStorage                       func(names.StorageTag, names.UnitTag) error
 	detachStorage                       func(names.StorageTag, names.UnitTag, bool) error
 	addExistingFilesystem               func(state.FilesystemInfo, *state.VolumeInfo, string) (names.StorageTag, error)
+	resizeVolume                        func(names.VolumeTag, uint64) error
+	createVolumeSnapshot                func(names.VolumeTag, string) error
+	allVolumeSnapshots                  func() ([]state.VolumeSnapshot, error)
+	destroyVolumeSnapshot               func(string) error
+	restoreVolumeSnapshot               func(string, string) (names.StorageTag, error)
 }
 
 func (st *mockStorageAccessor) VolumeAccess() storage.StorageVolume {
@@ -189,6 +194,66 @@ func (st *mockStorageAccessor) AddExistingFilesystem(f state.FilesystemInfo, v *
 	return st.addExistingFilesystem(f, v, s)
 }
 
+func (st *mockStorageAccessor) ResizeVolume(tag names.VolumeTag, size uint64) error {
+	return st.resizeVolume(tag, size)
+}
+
+func (st *mockStorageAccessor) CreateVolumeSnapshot(tag names.VolumeTag, name string) error {
+	return st.createVolumeSnapshot(tag, name)
+}
+
+func (st *mockStorageAccessor) AllVolumeSnapshots() ([]state.VolumeSnapshot, error) {
+	return st.allVolumeSnapshots()
+}
+
+func (st *mockStorageAccessor) DestroyVolumeSnapshot(name string) error {
+	return st.destroyVolumeSnapshot(name)
+}
+
+func (st *mockStorageAccessor) RestoreVolumeSnapshot(name, storageName string) (names.StorageTag, error) {
+	return st.restoreVolumeSnapshot(name, storageName)
+}
+
+type mockVolumeSnapshot struct {
+	state.VolumeSnapshot
+	name    string
+	volume  names.VolumeTag
+	pool    string
+	life    state.Life
+	info    *state.VolumeSnapshotInfo
+	restore *names.StorageTag
+}
+
+func (m *mockVolumeSnapshot) Name() string {
+	return m.name
+}
+
+func (m *mockVolumeSnapshot) Volume() names.VolumeTag {
+	return m.volume
+}
+
+func (m *mockVolumeSnapshot) Pool() string {
+	return m.pool
+}
+
+func (m *mockVolumeSnapshot) Life() state.Life {
+	return m.life
+}
+
+func (m *mockVolumeSnapshot) Info() (state.VolumeSnapshotInfo, error) {
+	if m.info != nil {
+		return *m.info, nil
+	}
+	return state.VolumeSnapshotInfo{}, errors.NotProvisionedf("volume snapshot %q", m.name)
+}
+
+func (m *mockVolumeSnapshot) PendingRestore() (names.StorageTag, bool) {
+	if m.restore != nil {
+		return *m.restore, true
+	}
+	return names.StorageTag{}, false
+}
+
 type mockVolume struct {
 	state.Volume
 	tag     names.VolumeTag
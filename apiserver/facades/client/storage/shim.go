@@ -82,6 +82,23 @@ type storageVolume interface {
 
 	// AddExistingFilesystem imports an existing filesystem into the model.
 	AddExistingFilesystem(f state.FilesystemInfo, v *state.VolumeInfo, storageName string) (names.StorageTag, error)
+
+	// ResizeVolume requests that the volume be expanded to the given size.
+	ResizeVolume(tag names.VolumeTag, size uint64) error
+
+	// CreateVolumeSnapshot requests a snapshot of the volume with the
+	// given name.
+	CreateVolumeSnapshot(tag names.VolumeTag, name string) error
+
+	// AllVolumeSnapshots returns all volume snapshots in the model.
+	AllVolumeSnapshots() ([]state.VolumeSnapshot, error)
+
+	// DestroyVolumeSnapshot destroys the named volume snapshot.
+	DestroyVolumeSnapshot(name string) error
+
+	// RestoreVolumeSnapshot requests that the named volume snapshot be
+	// restored to a new storage instance.
+	RestoreVolumeSnapshot(name, storageName string) (names.StorageTag, error)
 }
 
 type storageFile interface {
@@ -30,7 +30,7 @@ import (
 	"github.com/juju/juju/storage/poolmanager"
 )
 
-// StorageAPI implements the latest version (v6) of the Storage API.
+// StorageAPI implements the latest version (v7) of the Storage API.
 type StorageAPI struct {
 	backend       backend
 	storageAccess storageAccess
@@ -41,9 +41,14 @@ type StorageAPI struct {
 	modelType     state.ModelType
 }
 
+// StorageAPIv6 implements the storage v6 API.
+type StorageAPIv6 struct {
+	StorageAPI
+}
+
 // APIv5 implements the storage v5 API.
 type StorageAPIv5 struct {
-	StorageAPI
+	StorageAPIv6
 }
 
 // APIv4 implements the storage v4 API adding AddToUnit, Import and Remove (replacing Destroy)
@@ -104,14 +109,25 @@ func newStorageAPI(
 	}
 }
 
+// NewStorageAPIV6 returns a new storage v6 API facade.
+func NewStorageAPIV6(context facade.Context) (*StorageAPIv6, error) {
+	storageAPI, err := NewStorageAPI(context)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageAPIv6{
+		StorageAPI: *storageAPI,
+	}, nil
+}
+
 // NewStorageAPIV5 returns a new storage v5 API facade.
 func NewStorageAPIV5(context facade.Context) (*StorageAPIv5, error) {
-	storageAPI, err := NewStorageAPI(context)
+	storageAPI, err := NewStorageAPIV6(context)
 	if err != nil {
 		return nil, err
 	}
 	return &StorageAPIv5{
-		StorageAPI: *storageAPI,
+		StorageAPIv6: *storageAPI,
 	}, nil
 }
 
@@ -1087,24 +1103,32 @@ func (a *StorageAPI) importStorage(arg params.ImportStorageParams) (*params.Impo
 		return nil, errors.NotValidf("pool name %q", arg.Pool)
 	}
 
-	cfg, err := a.poolManager.Get(arg.Pool)
+	cfg, err := a.poolConfig(arg.Pool)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	provider, err := a.registry.StorageProvider(cfg.Provider())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return a.importFilesystem(arg, provider, cfg)
+}
+
+// poolConfig returns the configuration of the named storage pool,
+// or of the storage provider with that name if there is no such pool.
+func (a *StorageAPI) poolConfig(pool string) (*storage.Config, error) {
+	cfg, err := a.poolManager.Get(pool)
 	if errors.IsNotFound(err) {
 		cfg, err = storage.NewConfig(
-			arg.Pool,
-			storage.ProviderType(arg.Pool),
+			pool,
+			storage.ProviderType(pool),
 			map[string]interface{}{},
 		)
-		if err != nil {
-			return nil, errors.Trace(err)
-		}
-	} else if err != nil {
-		return nil, errors.Trace(err)
 	}
-	provider, err := a.registry.StorageProvider(cfg.Provider())
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return a.importFilesystem(arg, provider, cfg)
+	return cfg, nil
 }
 
 func (a *StorageAPI) importFilesystem(
@@ -1175,6 +1199,230 @@ func (a *StorageAPI) importFilesystem(
 	}, nil
 }
 
+// ResizeStorage requests that the volumes backing the specified storage
+// instances be expanded to the given sizes. The expansion is performed
+// by the storage provisioner responsible for each volume.
+// A "CHANGE" block can block this operation.
+func (a *StorageAPI) ResizeStorage(args params.StorageResizeParams) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.ErrorResult, len(args.Storage))
+	for i, arg := range args.Storage {
+		if err := a.resizeStorage(arg); err != nil {
+			results[i].Error = common.ServerError(err)
+		}
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (a *StorageAPI) resizeStorage(arg params.StorageResize) error {
+	storageTag, err := names.ParseStorageTag(arg.StorageTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	volume, err := a.storageInstanceVolume(storageTag)
+	if errors.Cause(err) == state.ErrNoBackingVolume {
+		return errors.NotSupportedf(
+			"expanding %s with no backing volume", names.ReadableString(storageTag),
+		)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	volumeSource, providerType, err := a.volumeSource(volume)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := volumeSource.(storage.VolumeResizer); !ok {
+		return errors.NotSupportedf(
+			"expanding volume with storage provider %q", providerType,
+		)
+	}
+	return a.storageAccess.VolumeAccess().ResizeVolume(volume.VolumeTag(), arg.Size)
+}
+
+// volumeSource returns the volume source for the provisioned volume,
+// and the type of its storage provider.
+func (a *StorageAPI) volumeSource(volume state.Volume) (storage.VolumeSource, storage.ProviderType, error) {
+	info, err := volume.Info()
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	cfg, err := a.poolConfig(info.Pool)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	provider, err := a.registry.StorageProvider(cfg.Provider())
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	volumeSource, err := provider.VolumeSource(cfg)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	return volumeSource, cfg.Provider(), nil
+}
+
+// CreateStorageSnapshots requests snapshots of the volumes backing the
+// specified storage instances. The snapshots are taken by the storage
+// provisioner responsible for each volume.
+// A "CHANGE" block can block this operation.
+func (a *StorageAPI) CreateStorageSnapshots(args params.StorageSnapshotCreateParams) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.ErrorResult, len(args.Snapshots))
+	for i, arg := range args.Snapshots {
+		if err := a.createStorageSnapshot(arg); err != nil {
+			results[i].Error = common.ServerError(err)
+		}
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (a *StorageAPI) createStorageSnapshot(arg params.StorageSnapshotCreate) error {
+	storageTag, err := names.ParseStorageTag(arg.StorageTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	volume, err := a.storageInstanceVolume(storageTag)
+	if errors.Cause(err) == state.ErrNoBackingVolume {
+		return errors.NotSupportedf(
+			"taking snapshot of %s with no backing volume", names.ReadableString(storageTag),
+		)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	volumeSource, providerType, err := a.volumeSource(volume)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := volumeSource.(storage.VolumeSnapshotter); !ok {
+		return errors.NotSupportedf(
+			"taking snapshot with storage provider %q", providerType,
+		)
+	}
+	return a.storageAccess.VolumeAccess().CreateVolumeSnapshot(volume.VolumeTag(), arg.Name)
+}
+
+// ListStorageSnapshots returns the details of the storage snapshots
+// in the model.
+func (a *StorageAPI) ListStorageSnapshots() (params.StorageSnapshotDetailsResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.StorageSnapshotDetailsResults{}, errors.Trace(err)
+	}
+	snapshots, err := a.storageAccess.VolumeAccess().AllVolumeSnapshots()
+	if err != nil {
+		return params.StorageSnapshotDetailsResults{}, errors.Trace(err)
+	}
+	results := make([]params.StorageSnapshotDetails, len(snapshots))
+	for i, snapshot := range snapshots {
+		details := params.StorageSnapshotDetails{
+			Name:      snapshot.Name(),
+			VolumeTag: snapshot.Volume().String(),
+			Pool:      snapshot.Pool(),
+			Life:      life.Value(snapshot.Life().String()),
+		}
+		if info, err := snapshot.Info(); err == nil {
+			details.SnapshotId = info.SnapshotId
+			details.Size = info.Size
+			details.Ready = info.Ready
+		} else if !errors.IsNotProvisioned(err) {
+			return params.StorageSnapshotDetailsResults{}, errors.Trace(err)
+		}
+		if storageTag, ok := snapshot.PendingRestore(); ok {
+			details.RestoringTo = storageTag.String()
+		}
+		results[i] = details
+	}
+	return params.StorageSnapshotDetailsResults{Results: results}, nil
+}
+
+// RemoveStorageSnapshots destroys the storage snapshots with the
+// specified names. Any pending restores of the snapshots are cancelled.
+// A "REMOVE" block can block this operation.
+func (a *StorageAPI) RemoveStorageSnapshots(args params.VolumeSnapshotNames) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.ErrorResult, len(args.Names))
+	for i, name := range args.Names {
+		err := a.storageAccess.VolumeAccess().DestroyVolumeSnapshot(name)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+		}
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+// RestoreStorageSnapshots requests that the specified storage snapshots
+// be restored to new storage instances, which may then be attached to
+// units. The restores are performed by the storage provisioner
+// responsible for each snapshot's volume.
+// A "CHANGE" block can block this operation.
+func (a *StorageAPI) RestoreStorageSnapshots(args params.StorageSnapshotRestoreParams) (params.ImportStorageResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ImportStorageResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ImportStorageResults{}, errors.Trace(err)
+	}
+
+	results := make([]params.ImportStorageResult, len(args.Restores))
+	for i, arg := range args.Restores {
+		storageTag, err := a.storageAccess.VolumeAccess().RestoreVolumeSnapshot(arg.Name, arg.StorageName)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Result = &params.ImportStorageDetails{
+			StorageTag: storageTag.String(),
+		}
+	}
+	return params.ImportStorageResults{Results: results}, nil
+}
+
+// storageInstanceVolume returns the volume assigned to the storage
+// instance with the specified tag, or backing its filesystem.
+func (a *StorageAPI) storageInstanceVolume(tag names.StorageTag) (state.Volume, error) {
+	storageInstance, err := a.storageAccess.StorageInstance(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if storageInstance.Kind() == state.StorageKindBlock {
+		return a.storageAccess.VolumeAccess().StorageInstanceVolume(tag)
+	}
+	filesystem, err := a.storageAccess.FilesystemAccess().StorageInstanceFilesystem(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumeTag, err := filesystem.Volume()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return a.storageAccess.VolumeAccess().Volume(volumeTag)
+}
+
 // RemovePool deletes the named pool
 func (a *StorageAPI) RemovePool(p params.StoragePoolDeleteArgs) (params.ErrorResults, error) {
 	results := params.ErrorResults{
@@ -1213,6 +1461,13 @@ func (a *StorageAPI) UpdatePool(p params.StoragePoolArgs) (params.ErrorResults,
 // code in rpc/rpcreflect/type.go:newMethod skips 2-argument methods,
 // so this removes the method as far as the RPC machinery is concerned.
 
+// Added in v7 api version
+func (*StorageAPIv6) ResizeStorage(_, _ struct{})           {}
+func (*StorageAPIv6) CreateStorageSnapshots(_, _ struct{})  {}
+func (*StorageAPIv6) ListStorageSnapshots(_, _ struct{})    {}
+func (*StorageAPIv6) RemoveStorageSnapshots(_, _ struct{})  {}
+func (*StorageAPIv6) RestoreStorageSnapshots(_, _ struct{}) {}
+
 // Added in v6 api version
 func (*StorageAPIv5) DetachStorage(_, _ struct{}) {}
 
@@ -16,6 +16,7 @@ import (
 	facadestorage "github.com/juju/juju/apiserver/facades/client/storage"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/permission"
@@ -371,7 +372,9 @@ func (s *storageSuite) TestDestroyV3(c *gc.C) {
 
 func (s *storageSuite) TestDetachV5(c *gc.C) {
 	apiv5 := &facadestorage.StorageAPIv5{
-		StorageAPI: *s.api,
+		StorageAPIv6: facadestorage.StorageAPIv6{
+			StorageAPI: *s.api,
+		},
 	}
 	results, err := apiv5.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
 		{StorageTag: "storage-data-0", UnitTag: "unit-mysql-0"},
@@ -442,7 +445,9 @@ func (s *storageSuite) TestDetachV6(c *gc.C) {
 
 func (s *storageSuite) TestDetachSpecifiedNotFound(c *gc.C) {
 	apiv5 := &facadestorage.StorageAPIv5{
-		StorageAPI: *s.api,
+		StorageAPIv6: facadestorage.StorageAPIv6{
+			StorageAPI: *s.api,
+		},
 	}
 	results, err := apiv5.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
 		{StorageTag: "storage-data-0", UnitTag: "unit-foo-42"},
@@ -512,7 +517,9 @@ func (s *storageSuite) TestDetachAttachmentNotFoundConcurrentv5(c *gc.C) {
 		)
 	}
 	apiv5 := &facadestorage.StorageAPIv5{
-		StorageAPI: *s.api,
+		StorageAPIv6: facadestorage.StorageAPIv6{
+			StorageAPI: *s.api,
+		},
 	}
 	results, err := apiv5.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
 		{StorageTag: "storage-data-0"},
@@ -568,7 +575,9 @@ func (s *storageSuite) TestDetachAttachmentNotFoundConcurrentV6(c *gc.C) {
 
 func (s *storageSuite) TestDetachNoAttachmentsStorageNotFoundv5(c *gc.C) {
 	apiv5 := &facadestorage.StorageAPIv5{
-		StorageAPI: *s.api,
+		StorageAPIv6: facadestorage.StorageAPIv6{
+			StorageAPI: *s.api,
+		},
 	}
 	results, err := apiv5.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
 		{StorageTag: "storage-foo-42"},
@@ -877,6 +886,242 @@ func (s *storageSuite) TestListStorageAsNonAdminOnNotOwnedModel(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.Equals, common.ErrPerm)
 }
 
+func (s *storageSuite) TestResizeStorage(c *gc.C) {
+	s.filesystem.volume = &s.volumeTag
+	s.volume.info = &state.VolumeInfo{Pool: "radiance", Size: 1024}
+	s.registry.Providers["radiance"] = &dummy.StorageProvider{
+		StorageScope: storage.ScopeEnviron,
+		IsDynamic:    true,
+		VolumeSourceFunc: func(*storage.Config) (storage.VolumeSource, error) {
+			return volumeResizer{&dummy.VolumeSource{}}, nil
+		},
+	}
+
+	results, err := s.api.ResizeStorage(params.StorageResizeParams{[]params.StorageResize{{
+		StorageTag: "storage-data-0",
+		Size:       2048,
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{{}})
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{getBlockForTypeCall, []interface{}{state.ChangeBlock}},
+		{storageInstanceCall, []interface{}{s.storageTag}},
+		{storageInstanceFilesystemCall, nil},
+		{volumeCall, nil},
+		{resizeVolumeCall, []interface{}{s.volumeTag, uint64(2048)}},
+	})
+}
+
+func (s *storageSuite) TestResizeStorageNotSupported(c *gc.C) {
+	s.filesystem.volume = &s.volumeTag
+	s.volume.info = &state.VolumeInfo{Pool: "radiance", Size: 1024}
+	s.registry.Providers["radiance"] = &dummy.StorageProvider{
+		StorageScope: storage.ScopeEnviron,
+		IsDynamic:    true,
+		VolumeSourceFunc: func(*storage.Config) (storage.VolumeSource, error) {
+			return &dummy.VolumeSource{}, nil
+		},
+	}
+
+	results, err := s.api.ResizeStorage(params.StorageResizeParams{[]params.StorageResize{{
+		StorageTag: "storage-data-0",
+		Size:       2048,
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{{
+		Error: &params.Error{
+			Message: `expanding volume with storage provider "radiance" not supported`,
+			Code:    "not supported",
+		},
+	}})
+	s.stub.CheckCallNames(c, getBlockForTypeCall, storageInstanceCall, storageInstanceFilesystemCall, volumeCall)
+}
+
+func (s *storageSuite) TestResizeStorageNoBackingVolume(c *gc.C) {
+	results, err := s.api.ResizeStorage(params.StorageResizeParams{[]params.StorageResize{{
+		StorageTag: "storage-data-0",
+		Size:       2048,
+	}, {
+		StorageTag: "storage-foo-0",
+		Size:       2048,
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{{
+		Error: &params.Error{
+			Message: `expanding storage data/0 with no backing volume not supported`,
+			Code:    "not supported",
+		},
+	}, {
+		Error: &params.Error{
+			Message: `storage foo/0 not found`,
+			Code:    "not found",
+		},
+	}})
+}
+
+func (s *storageSuite) TestResizeStorageBlocked(c *gc.C) {
+	s.addBlock(c, state.ChangeBlock, "TestResizeStorageBlocked")
+	_, err := s.api.ResizeStorage(params.StorageResizeParams{[]params.StorageResize{{
+		StorageTag: "storage-data-0",
+		Size:       2048,
+	}}})
+	s.assertBlocked(c, err, "TestResizeStorageBlocked")
+}
+
+func (s *storageSuite) TestCreateStorageSnapshots(c *gc.C) {
+	s.filesystem.volume = &s.volumeTag
+	s.volume.info = &state.VolumeInfo{Pool: "radiance", Size: 1024}
+	s.registry.Providers["radiance"] = &dummy.StorageProvider{
+		StorageScope: storage.ScopeEnviron,
+		IsDynamic:    true,
+		VolumeSourceFunc: func(*storage.Config) (storage.VolumeSource, error) {
+			return volumeSnapshotter{&dummy.VolumeSource{}}, nil
+		},
+	}
+
+	results, err := s.api.CreateStorageSnapshots(params.StorageSnapshotCreateParams{[]params.StorageSnapshotCreate{{
+		StorageTag: "storage-data-0",
+		Name:       "nightly",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{{}})
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{getBlockForTypeCall, []interface{}{state.ChangeBlock}},
+		{storageInstanceCall, []interface{}{s.storageTag}},
+		{storageInstanceFilesystemCall, nil},
+		{volumeCall, nil},
+		{createVolumeSnapshotCall, []interface{}{s.volumeTag, "nightly"}},
+	})
+}
+
+func (s *storageSuite) TestCreateStorageSnapshotsNotSupported(c *gc.C) {
+	s.filesystem.volume = &s.volumeTag
+	s.volume.info = &state.VolumeInfo{Pool: "radiance", Size: 1024}
+	s.registry.Providers["radiance"] = &dummy.StorageProvider{
+		StorageScope: storage.ScopeEnviron,
+		IsDynamic:    true,
+		VolumeSourceFunc: func(*storage.Config) (storage.VolumeSource, error) {
+			return &dummy.VolumeSource{}, nil
+		},
+	}
+
+	results, err := s.api.CreateStorageSnapshots(params.StorageSnapshotCreateParams{[]params.StorageSnapshotCreate{{
+		StorageTag: "storage-data-0",
+		Name:       "nightly",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{{
+		Error: &params.Error{
+			Message: `taking snapshot with storage provider "radiance" not supported`,
+			Code:    "not supported",
+		},
+	}})
+	s.stub.CheckCallNames(c, getBlockForTypeCall, storageInstanceCall, storageInstanceFilesystemCall, volumeCall)
+}
+
+func (s *storageSuite) TestCreateStorageSnapshotsNoBackingVolume(c *gc.C) {
+	results, err := s.api.CreateStorageSnapshots(params.StorageSnapshotCreateParams{[]params.StorageSnapshotCreate{{
+		StorageTag: "storage-data-0",
+		Name:       "nightly",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{{
+		Error: &params.Error{
+			Message: `taking snapshot of storage data/0 with no backing volume not supported`,
+			Code:    "not supported",
+		},
+	}})
+}
+
+func (s *storageSuite) TestCreateStorageSnapshotsBlocked(c *gc.C) {
+	s.addBlock(c, state.ChangeBlock, "TestCreateStorageSnapshotsBlocked")
+	_, err := s.api.CreateStorageSnapshots(params.StorageSnapshotCreateParams{[]params.StorageSnapshotCreate{{
+		StorageTag: "storage-data-0",
+		Name:       "nightly",
+	}}})
+	s.assertBlocked(c, err, "TestCreateStorageSnapshotsBlocked")
+}
+
+func (s *storageSuite) TestListStorageSnapshots(c *gc.C) {
+	restoreTag := names.NewStorageTag("data/1")
+	s.volumeSnapshots = []state.VolumeSnapshot{
+		&mockVolumeSnapshot{
+			name:   "hourly",
+			volume: s.volumeTag,
+			pool:   "radiance",
+			life:   state.Alive,
+		},
+		&mockVolumeSnapshot{
+			name:    "nightly",
+			volume:  s.volumeTag,
+			pool:    "radiance",
+			life:    state.Alive,
+			info:    &state.VolumeSnapshotInfo{SnapshotId: "nightly", Size: 1024, Ready: true},
+			restore: &restoreTag,
+		},
+	}
+
+	results, err := s.api.ListStorageSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StorageSnapshotDetailsResults{
+		Results: []params.StorageSnapshotDetails{{
+			Name:      "hourly",
+			VolumeTag: s.volumeTag.String(),
+			Pool:      "radiance",
+			Life:      life.Alive,
+		}, {
+			Name:        "nightly",
+			VolumeTag:   s.volumeTag.String(),
+			Pool:        "radiance",
+			Life:        life.Alive,
+			SnapshotId:  "nightly",
+			Size:        1024,
+			Ready:       true,
+			RestoringTo: "storage-data-1",
+		}},
+	})
+	s.stub.CheckCallNames(c, allVolumeSnapshotsCall)
+}
+
+func (s *storageSuite) TestRemoveStorageSnapshots(c *gc.C) {
+	s.stub.SetErrors(nil, errors.NotFoundf(`volume snapshot "weekly"`))
+	results, err := s.api.RemoveStorageSnapshots(params.VolumeSnapshotNames{
+		Names: []string{"nightly", "weekly"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: `volume snapshot "weekly" not found`, Code: "not found"}},
+	})
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{getBlockForTypeCall, []interface{}{state.RemoveBlock}},
+		{destroyVolumeSnapshotCall, []interface{}{"nightly"}},
+		{destroyVolumeSnapshotCall, []interface{}{"weekly"}},
+	})
+}
+
+func (s *storageSuite) TestRestoreStorageSnapshots(c *gc.C) {
+	results, err := s.api.RestoreStorageSnapshots(params.StorageSnapshotRestoreParams{
+		Restores: []params.StorageSnapshotRestore{{Name: "nightly", StorageName: "data"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ImportStorageResult{{
+		Result: &params.ImportStorageDetails{StorageTag: "storage-data-1"},
+	}})
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{getBlockForTypeCall, []interface{}{state.ChangeBlock}},
+		{restoreVolumeSnapshotCall, []interface{}{"nightly", "data"}},
+	})
+}
+
+func (s *storageSuite) TestRestoreStorageSnapshotsBlocked(c *gc.C) {
+	s.addBlock(c, state.ChangeBlock, "TestRestoreStorageSnapshotsBlocked")
+	_, err := s.api.RestoreStorageSnapshots(params.StorageSnapshotRestoreParams{
+		Restores: []params.StorageSnapshotRestore{{Name: "nightly", StorageName: "data"}},
+	})
+	s.assertBlocked(c, err, "TestRestoreStorageSnapshotsBlocked")
+}
+
 type filesystemImporter struct {
 	*dummy.FilesystemSource
 }
@@ -903,3 +1148,35 @@ func (v volumeImporter) ImportVolume(ctx context.ProviderCallContext, providerId
 		HardwareId: "hw",
 	}, v.NextErr()
 }
+
+type volumeResizer struct {
+	*dummy.VolumeSource
+}
+
+// ResizeVolumes is part of the storage.VolumeResizer interface.
+func (v volumeResizer) ResizeVolumes(ctx context.ProviderCallContext, args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	v.MethodCall(v, "ResizeVolumes", ctx, args)
+	return make([]storage.ResizeVolumesResult, len(args)), v.NextErr()
+}
+
+type volumeSnapshotter struct {
+	*dummy.VolumeSource
+}
+
+// SnapshotVolumes is part of the storage.VolumeSnapshotter interface.
+func (v volumeSnapshotter) SnapshotVolumes(ctx context.ProviderCallContext, args []storage.VolumeSnapshotParams) ([]storage.SnapshotVolumesResult, error) {
+	v.MethodCall(v, "SnapshotVolumes", ctx, args)
+	return make([]storage.SnapshotVolumesResult, len(args)), v.NextErr()
+}
+
+// RestoreVolumes is part of the storage.VolumeSnapshotter interface.
+func (v volumeSnapshotter) RestoreVolumes(ctx context.ProviderCallContext, args []storage.VolumeRestoreParams) ([]storage.RestoreVolumesResult, error) {
+	v.MethodCall(v, "RestoreVolumes", ctx, args)
+	return make([]storage.RestoreVolumesResult, len(args)), v.NextErr()
+}
+
+// DestroySnapshots is part of the storage.VolumeSnapshotter interface.
+func (v volumeSnapshotter) DestroySnapshots(ctx context.ProviderCallContext, snapshotIds []string) ([]error, error) {
+	v.MethodCall(v, "DestroySnapshots", ctx, snapshotIds)
+	return make([]error, len(snapshotIds)), v.NextErr()
+}
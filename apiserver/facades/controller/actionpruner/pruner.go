@@ -17,6 +17,20 @@ type API struct {
 	authorizer facade.Authorizer
 }
 
+// APIv1 provides the ActionPruner API facade for version 1.
+type APIv1 struct {
+	*API
+}
+
+// NewAPIv1 returns an ActionPruner API facade for version 1.
+func NewAPIv1(st *state.State, r facade.Resources, auth facade.Authorizer) (*APIv1, error) {
+	api, err := NewAPI(st, r, auth)
+	if err != nil {
+		return nil, err
+	}
+	return &APIv1{api}, nil
+}
+
 func NewAPI(st *state.State, r facade.Resources, auth facade.Authorizer) (*API, error) {
 	m, err := st.Model()
 	if err != nil {
@@ -37,3 +51,20 @@ func (api *API) Prune(p params.ActionPruneArgs) error {
 
 	return state.PruneActions(api.st, p.MaxHistoryTime, p.MaxHistoryMB)
 }
+
+// ExpireActions cancels pending actions and fails running actions
+// that have exceeded their queue or execution timeouts.
+func (api *API) ExpireActions() error {
+	if !api.authorizer.AuthController() {
+		return common.ErrPerm
+	}
+
+	return state.ExpireActions(api.st)
+}
+
+// Mask out new methods from the old API versions. The API reflection
+// code in rpc/rpcreflect/type.go:newMethod skips 2-argument methods,
+// so this removes the method as far as the RPC machinery is concerned.
+
+// ExpireActions isn't on the v1 API.
+func (*APIv1) ExpireActions(_, _ struct{}) {}
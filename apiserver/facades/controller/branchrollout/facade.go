@@ -0,0 +1,197 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchrollout
+
+import (
+	"sort"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+// Backend exposes functionality required by Facade.
+type Backend interface {
+
+	// Branches returns the in-flight branches of the model.
+	Branches() ([]Branch, error)
+
+	// Branch returns the in-flight branch with the input name.
+	Branch(name string) (Branch, error)
+
+	// ApplicationUnitNames returns the names of
+	// the units of the application with the input name.
+	ApplicationUnitNames(appName string) ([]string, error)
+
+	// UnitWorkloadStatus returns the workload status
+	// of the unit with the input name.
+	UnitWorkloadStatus(unitName string) (status.Status, error)
+}
+
+// Branch describes the methods of a branch used by Facade.
+type Branch interface {
+	BranchName() string
+	AssignedUnits() map[string][]string
+	Rollout() (state.BranchRollout, bool)
+	AssignRolloutBatch([]string) error
+	HaltRollout(string) error
+	Commit(string) (int, error)
+}
+
+// Facade allows model-manager clients to drive the
+// progressive rollout of branches to their units.
+type Facade struct {
+	backend Backend
+}
+
+// NewFacade creates a new authorized Facade.
+func NewFacade(backend Backend, auth facade.Authorizer) (*Facade, error) {
+	if !auth.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{backend: backend}, nil
+}
+
+// Rollouts returns the rollouts of in-flight branches that are in progress,
+// with the units yet to be rolled out to.
+// Rollouts that were halted are not included.
+func (facade *Facade) Rollouts() (params.BranchRolloutResults, error) {
+	branches, err := facade.backend.Branches()
+	if err != nil {
+		return params.BranchRolloutResults{Error: common.ServerError(err)}, nil
+	}
+
+	var result params.BranchRolloutResults
+	for _, branch := range branches {
+		rollout, ok := branch.Rollout()
+		if !ok || rollout.Halted != "" {
+			continue
+		}
+		oneRollout, err := facade.oneRollout(branch, rollout)
+		if err != nil {
+			return params.BranchRolloutResults{Error: common.ServerError(err)}, nil
+		}
+		result.Rollouts = append(result.Rollouts, oneRollout)
+	}
+	return result, nil
+}
+
+// oneRollout returns the details of the input branch rollout.
+// Units in error are only determined if the rollout halts on error.
+func (facade *Facade) oneRollout(branch Branch, rollout state.BranchRollout) (params.BranchRollout, error) {
+	result := params.BranchRollout{
+		BranchName: branch.BranchName(),
+		Rollout: params.GenerationRollout{
+			BatchSize:     rollout.BatchSize,
+			BatchInterval: rollout.BatchInterval,
+			HaltOnError:   rollout.HaltOnError,
+			Started:       rollout.Started.Unix(),
+			StartedBy:     rollout.StartedBy,
+			Batches:       rollout.Batches,
+		},
+	}
+	if !rollout.LastBatch.IsZero() {
+		result.LastBatch = rollout.LastBatch.Unix()
+	}
+
+	// Iterate over the applications in a stable order, so that units
+	// are rolled out to one application at a time.
+	assigned := branch.AssignedUnits()
+	appNames := make([]string, 0, len(assigned))
+	for appName := range assigned {
+		appNames = append(appNames, appName)
+	}
+	sort.Strings(appNames)
+
+	for _, appName := range appNames {
+		allUnits, err := facade.backend.ApplicationUnitNames(appName)
+		if err != nil {
+			return params.BranchRollout{}, errors.Trace(err)
+		}
+		tracking := set.NewStrings(assigned[appName]...)
+		result.UnitsPending = append(result.UnitsPending, set.NewStrings(allUnits...).Difference(tracking).SortedValues()...)
+
+		if !rollout.HaltOnError {
+			continue
+		}
+		for _, unitName := range tracking.SortedValues() {
+			workloadStatus, err := facade.backend.UnitWorkloadStatus(unitName)
+			if errors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return params.BranchRollout{}, errors.Trace(err)
+			}
+			if workloadStatus == status.Error {
+				result.UnitsInError = append(result.UnitsInError, unitName)
+			}
+		}
+	}
+	return result, nil
+}
+
+// AssignRolloutBatches sets the input batches of units
+// to track the branches being rolled out.
+func (facade *Facade) AssignRolloutBatches(args params.BranchRolloutBatches) params.ErrorResults {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Batches)),
+	}
+	for i, batch := range args.Batches {
+		branch, err := facade.backend.Branch(batch.BranchName)
+		if err == nil {
+			err = branch.AssignRolloutBatch(batch.Units)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result
+}
+
+// HaltRollouts stops the input branch rollouts,
+// recording the reasons for stopping them.
+func (facade *Facade) HaltRollouts(args params.BranchRolloutHalts) params.ErrorResults {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Halts)),
+	}
+	for i, halt := range args.Halts {
+		branch, err := facade.backend.Branch(halt.BranchName)
+		if err == nil {
+			err = branch.HaltRollout(halt.Reason)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result
+}
+
+// CompleteRollouts commits the input branches on behalf of
+// the users who started their rollouts.
+func (facade *Facade) CompleteRollouts(args params.BranchArgs) params.ErrorResults {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		result.Results[i].Error = common.ServerError(facade.completeOne(arg.BranchName))
+	}
+	return result
+}
+
+func (facade *Facade) completeOne(branchName string) error {
+	branch, err := facade.backend.Branch(branchName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	rollout, ok := branch.Rollout()
+	if !ok {
+		return errors.NotFoundf("rollout of branch %q", branchName)
+	}
+	if rollout.Halted != "" {
+		return errors.Errorf("rollout of branch %q was halted: %s", branchName, rollout.Halted)
+	}
+	_, err = branch.Commit(rollout.StartedBy)
+	return errors.Trace(err)
+}
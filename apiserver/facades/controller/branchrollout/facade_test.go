@@ -0,0 +1,224 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchrollout_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/facades/controller/branchrollout"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+type FacadeSuite struct {
+	testing.IsolationSuite
+
+	backend *mockBackend
+	branch  *mockBranch
+	facade  *branchrollout.Facade
+}
+
+var _ = gc.Suite(&FacadeSuite{})
+
+func (s *FacadeSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.branch = &mockBranch{
+		name: "canary",
+		assigned: map[string][]string{
+			"redis": {"redis/0"},
+			"mysql": {},
+		},
+		rollout: state.BranchRollout{
+			RolloutPolicy: model.RolloutPolicy{BatchSize: 2, BatchInterval: time.Minute, HaltOnError: true},
+			Started:       time.Unix(100, 0),
+			StartedBy:     "bob",
+			LastBatch:     time.Unix(200, 0),
+			Batches:       1,
+		},
+		hasRollout: true,
+	}
+	s.backend = &mockBackend{
+		branch: s.branch,
+		units: map[string][]string{
+			"redis": {"redis/0", "redis/1", "redis/2"},
+			"mysql": {"mysql/0"},
+		},
+		statuses: map[string]status.Status{"redis/0": status.Error},
+	}
+
+	var err error
+	s.facade, err = branchrollout.NewFacade(s.backend, auth(true))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *FacadeSuite) TestNotModelManager(c *gc.C) {
+	facade, err := branchrollout.NewFacade(s.backend, auth(false))
+	c.Check(err, gc.Equals, common.ErrPerm)
+	c.Check(facade, gc.IsNil)
+}
+
+func (s *FacadeSuite) TestRollouts(c *gc.C) {
+	result, err := s.facade.Rollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Check(result.Rollouts, jc.DeepEquals, []params.BranchRollout{{
+		BranchName: "canary",
+		Rollout: params.GenerationRollout{
+			BatchSize:     2,
+			BatchInterval: time.Minute,
+			HaltOnError:   true,
+			Started:       100,
+			StartedBy:     "bob",
+			Batches:       1,
+		},
+		LastBatch:    200,
+		UnitsPending: []string{"mysql/0", "redis/1", "redis/2"},
+		UnitsInError: []string{"redis/0"},
+	}})
+}
+
+func (s *FacadeSuite) TestRolloutsExcludesHalted(c *gc.C) {
+	s.branch.rollout.Halted = "aborted by bob"
+
+	result, err := s.facade.Rollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Check(result.Rollouts, gc.HasLen, 0)
+}
+
+func (s *FacadeSuite) TestAssignRolloutBatches(c *gc.C) {
+	result := s.facade.AssignRolloutBatches(params.BranchRolloutBatches{
+		Batches: []params.BranchRolloutBatch{
+			{BranchName: "canary", Units: []string{"mysql/0", "redis/1"}},
+			{BranchName: "missing", Units: []string{"redis/2"}},
+		},
+	})
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Check(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Check(s.branch.batches, jc.DeepEquals, [][]string{{"mysql/0", "redis/1"}})
+}
+
+func (s *FacadeSuite) TestHaltRollouts(c *gc.C) {
+	result := s.facade.HaltRollouts(params.BranchRolloutHalts{
+		Halts: []params.BranchRolloutHalt{{BranchName: "canary", Reason: "redis/0 in error"}},
+	})
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Check(result.Results[0].Error, gc.IsNil)
+	c.Check(s.branch.rollout.Halted, gc.Equals, "redis/0 in error")
+}
+
+func (s *FacadeSuite) TestCompleteRollouts(c *gc.C) {
+	result := s.facade.CompleteRollouts(params.BranchArgs{
+		Args: []params.BranchArg{{BranchName: "canary"}},
+	})
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Check(result.Results[0].Error, gc.IsNil)
+	c.Check(s.branch.committedBy, gc.Equals, "bob")
+}
+
+func (s *FacadeSuite) TestCompleteRolloutsHalted(c *gc.C) {
+	s.branch.rollout.Halted = "aborted by bob"
+
+	result := s.facade.CompleteRollouts(params.BranchArgs{
+		Args: []params.BranchArg{{BranchName: "canary"}},
+	})
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Check(result.Results[0].Error, gc.ErrorMatches, `rollout of branch "canary" was halted: aborted by bob`)
+	c.Check(s.branch.committedBy, gc.Equals, "")
+}
+
+// mockAuth implements facade.Authorizer for the tests' convenience.
+type mockAuth struct {
+	facade.Authorizer
+	modelManager bool
+}
+
+func (mock mockAuth) AuthController() bool {
+	return mock.modelManager
+}
+
+// auth is a convenience constructor for a mockAuth.
+func auth(modelManager bool) facade.Authorizer {
+	return mockAuth{modelManager: modelManager}
+}
+
+// mockBackend implements branchrollout.Backend
+// with a single in-flight branch.
+type mockBackend struct {
+	branch   *mockBranch
+	units    map[string][]string
+	statuses map[string]status.Status
+}
+
+func (m *mockBackend) Branches() ([]branchrollout.Branch, error) {
+	return []branchrollout.Branch{m.branch}, nil
+}
+
+func (m *mockBackend) Branch(name string) (branchrollout.Branch, error) {
+	if name != m.branch.name {
+		return nil, errors.NotFoundf("branch %q", name)
+	}
+	return m.branch, nil
+}
+
+func (m *mockBackend) ApplicationUnitNames(appName string) ([]string, error) {
+	return m.units[appName], nil
+}
+
+func (m *mockBackend) UnitWorkloadStatus(unitName string) (status.Status, error) {
+	if s, ok := m.statuses[unitName]; ok {
+		return s, nil
+	}
+	return status.Active, nil
+}
+
+// mockBranch implements branchrollout.Branch,
+// recording the changes made to it.
+type mockBranch struct {
+	name       string
+	assigned   map[string][]string
+	rollout    state.BranchRollout
+	hasRollout bool
+
+	batches     [][]string
+	committedBy string
+}
+
+func (m *mockBranch) BranchName() string {
+	return m.name
+}
+
+func (m *mockBranch) AssignedUnits() map[string][]string {
+	return m.assigned
+}
+
+func (m *mockBranch) Rollout() (state.BranchRollout, bool) {
+	return m.rollout, m.hasRollout
+}
+
+func (m *mockBranch) AssignRolloutBatch(units []string) error {
+	m.batches = append(m.batches, units)
+	return nil
+}
+
+func (m *mockBranch) HaltRollout(reason string) error {
+	m.rollout.Halted = reason
+	return nil
+}
+
+func (m *mockBranch) Commit(userName string) (int, error) {
+	m.committedBy = userName
+	return 1, nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchrollout
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb.
+
+// NewAPI provides the required signature for facade registration.
+func NewAPI(ctx facade.Context) (*Facade, error) {
+	return NewFacade(backendShim{ctx.State()}, ctx.Auth())
+}
+
+// backendShim wraps a *State to implement Backend.
+type backendShim struct {
+	st *state.State
+}
+
+// Branches is part of the Backend interface.
+func (shim backendShim) Branches() ([]Branch, error) {
+	branches, err := shim.st.Branches()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Branch, len(branches))
+	for i, b := range branches {
+		result[i] = b
+	}
+	return result, nil
+}
+
+// Branch is part of the Backend interface.
+func (shim backendShim) Branch(name string) (Branch, error) {
+	branch, err := shim.st.Branch(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return branch, nil
+}
+
+// ApplicationUnitNames is part of the Backend interface.
+func (shim backendShim) ApplicationUnitNames(appName string) ([]string, error) {
+	app, err := shim.st.Application(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	names, err := app.UnitNames()
+	return names, errors.Trace(err)
+}
+
+// UnitWorkloadStatus is part of the Backend interface.
+func (shim backendShim) UnitWorkloadStatus(unitName string) (status.Status, error) {
+	unit, err := shim.st.Unit(unitName)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	info, err := unit.Status()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return info.Status, nil
+}
@@ -4,6 +4,7 @@
 package firewaller
 
 import (
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v3"
@@ -52,6 +53,16 @@ type FirewallerAPIV5 struct {
 	*FirewallerAPIV4
 }
 
+// FirewallerAPIV6 provides access to the Firewaller v6 API facade.
+type FirewallerAPIV6 struct {
+	*FirewallerAPIV5
+}
+
+// FirewallerAPIV7 provides access to the Firewaller v7 API facade.
+type FirewallerAPIV7 struct {
+	*FirewallerAPIV6
+}
+
 // NewStateFirewallerAPIV3 creates a new server-side FirewallerAPIV3 facade.
 func NewStateFirewallerAPIV3(context facade.Context) (*FirewallerAPIV3, error) {
 	st := context.State()
@@ -95,6 +106,28 @@ func NewStateFirewallerAPIV5(context facade.Context) (*FirewallerAPIV5, error) {
 	}, nil
 }
 
+// NewStateFirewallerAPIV6 creates a new server-side FirewallerAPIV6 facade.
+func NewStateFirewallerAPIV6(context facade.Context) (*FirewallerAPIV6, error) {
+	facadev5, err := NewStateFirewallerAPIV5(context)
+	if err != nil {
+		return nil, err
+	}
+	return &FirewallerAPIV6{
+		FirewallerAPIV5: facadev5,
+	}, nil
+}
+
+// NewStateFirewallerAPIV7 creates a new server-side FirewallerAPIV7 facade.
+func NewStateFirewallerAPIV7(context facade.Context) (*FirewallerAPIV7, error) {
+	facadev6, err := NewStateFirewallerAPIV6(context)
+	if err != nil {
+		return nil, err
+	}
+	return &FirewallerAPIV7{
+		FirewallerAPIV6: facadev6,
+	}, nil
+}
+
 // NewFirewallerAPI creates a new server-side FirewallerAPIV3 facade.
 func NewFirewallerAPI(
 	st State,
@@ -249,6 +282,7 @@ func (f *FirewallerAPIV3) GetMachinePorts(args params.MachinePortsParams) (param
 		}
 		if ports != nil {
 			portRangeMap := ports.AllPortRanges()
+			endpointMap := ports.AllPortRangeEndpoints()
 			var portRanges []network.PortRange
 			for portRange := range portRangeMap {
 				portRanges = append(portRanges, portRange)
@@ -261,6 +295,7 @@ func (f *FirewallerAPIV3) GetMachinePorts(args params.MachinePortsParams) (param
 					params.MachinePortRange{
 						UnitTag:   unitTag,
 						PortRange: params.FromNetworkPortRange(portRange),
+						Endpoint:  endpointMap[portRange],
 					})
 			}
 		}
@@ -530,3 +565,125 @@ func (f *FirewallerAPIV5) AreManuallyProvisioned(args params.Entities) (params.B
 	}
 	return result, nil
 }
+
+// GetExposeInfo returns the exposed flag and the expose settings of the
+// endpoints of each given application. The CIDRs returned for each endpoint
+// include those of the subnets in the spaces the endpoint is exposed to.
+func (f *FirewallerAPIV6) GetExposeInfo(args params.Entities) (params.ExposeInfoResults, error) {
+	result := params.ExposeInfoResults{
+		Results: make([]params.ExposeInfoResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessApplication()
+	if err != nil {
+		return params.ExposeInfoResults{}, err
+	}
+	var spaceInfos network.SpaceInfos
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		application, err := f.getApplication(canAccess, tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if !application.IsExposed() {
+			continue
+		}
+		if spaceInfos == nil {
+			if spaceInfos, err = f.st.AllSpaceInfos(); err != nil {
+				return params.ExposeInfoResults{}, errors.Trace(err)
+			}
+		}
+		exposedEndpoints, err := exposedEndpointsToParams(application.ExposedEndpoints(), spaceInfos)
+		result.Results[i] = params.ExposeInfoResult{
+			Exposed:          true,
+			ExposedEndpoints: exposedEndpoints,
+			Error:            common.ServerError(err),
+		}
+	}
+	return result, nil
+}
+
+// FirewallServiceRules returns the firewall rules for the services
+// defined by an operator, along with the port range of each service.
+// Services without a rule are omitted.
+func (f *FirewallerAPIV7) FirewallServiceRules() (params.FirewallServiceRulesResults, error) {
+	var result params.FirewallServiceRulesResults
+	services, err := f.st.FirewallServices()
+	if err != nil {
+		return result, common.ServerError(err)
+	}
+	for _, service := range services {
+		rule, err := f.st.FirewallRule(service.Name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return result, common.ServerError(err)
+		}
+		result.Rules = append(result.Rules, params.FirewallServiceRule{
+			Service: params.FirewallService{
+				Name:     params.KnownServiceValue(service.Name),
+				Protocol: service.PortRange.Protocol,
+				FromPort: service.PortRange.FromPort,
+				ToPort:   service.PortRange.ToPort,
+			},
+			WhitelistCIDRS: rule.WhitelistCIDRs(),
+		})
+	}
+	return result, nil
+}
+
+// WatchFirewallRules returns a NotifyWatcher which triggers whenever
+// a firewall rule or an operator defined service changes, so that the
+// ports already opened can be adjusted.
+func (f *FirewallerAPIV7) WatchFirewallRules() (params.NotifyWatchResult, error) {
+	watch := f.st.WatchFirewallRules()
+	// Consume the initial event.
+	if _, ok := <-watch.Changes(); ok {
+		return params.NotifyWatchResult{NotifyWatcherId: f.resources.Register(watch)}, nil
+	}
+	return params.NotifyWatchResult{}, common.ServerError(watcher.EnsureErr(watch))
+}
+
+// WatchSubnetsAndSpaces returns a NotifyWatcher which triggers whenever
+// a subnet or space changes, so that the CIDRs of the spaces that
+// applications are exposed to can be refreshed.
+func (f *FirewallerAPIV7) WatchSubnetsAndSpaces() (params.NotifyWatchResult, error) {
+	watch := f.st.WatchSubnetsAndSpaces()
+	// Consume the initial event.
+	if _, ok := <-watch.Changes(); ok {
+		return params.NotifyWatchResult{NotifyWatcherId: f.resources.Register(watch)}, nil
+	}
+	return params.NotifyWatchResult{}, common.ServerError(watcher.EnsureErr(watch))
+}
+
+// exposedEndpointsToParams converts the input expose settings to their
+// params representation, adding the CIDRs of the subnets in each space
+// to those that the endpoint is exposed to.
+func exposedEndpointsToParams(
+	exposedEndpoints map[string]state.ExposedEndpoint, spaceInfos network.SpaceInfos,
+) (map[string]params.ExposedEndpoint, error) {
+	result := make(map[string]params.ExposedEndpoint, len(exposedEndpoints))
+	for endpoint, exposed := range exposedEndpoints {
+		cidrs := set.NewStrings(exposed.ExposeToCIDRs...)
+		var spaceNames []string
+		for _, spaceID := range exposed.ExposeToSpaceIDs {
+			space := spaceInfos.GetByID(spaceID)
+			if space == nil {
+				return nil, errors.NotFoundf("space with ID %q", spaceID)
+			}
+			spaceNames = append(spaceNames, string(space.Name))
+			for _, subnet := range space.Subnets {
+				cidrs.Add(subnet.CIDR)
+			}
+		}
+		result[endpoint] = params.ExposedEndpoint{
+			ExposeToSpaces: spaceNames,
+			ExposeToCIDRs:  cidrs.SortedValues(),
+		}
+	}
+	return result, nil
+}
@@ -106,7 +106,7 @@ func (s *firewallerSuite) openPorts(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	err = s.units[0].OpenPort("tcp", 4321)
 	c.Assert(err, jc.ErrorIsNil)
-	err = s.units[2].OpenPorts("udp", 1111, 2222)
+	err = s.units[2].OpenPortsForEndpoint("url", "udp", 1111, 2222)
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -187,7 +187,7 @@ func (s *firewallerSuite) TestGetMachinePorts(c *gc.C) {
 	expectPortsMachine2 := []params.MachinePortRange{
 		{UnitTag: unit2Tag, PortRange: params.PortRange{
 			FromPort: 1111, ToPort: 2222, Protocol: "udp",
-		}},
+		}, Endpoint: "url"},
 	}
 	result, err := s.firewaller.GetMachinePorts(args)
 	c.Assert(err, jc.ErrorIsNil)
@@ -278,3 +278,47 @@ func (s *firewallerSuite) TestAreManuallyProvisioned(c *gc.C) {
 		},
 	})
 }
+
+func (s *firewallerSuite) TestGetExposeInfo(c *gc.C) {
+	_, err := s.State.AddSpace("dmz", "", []string{s.subnet.ID()}, false)
+	c.Assert(err, jc.ErrorIsNil)
+	space, err := s.State.SpaceByName("dmz")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"url": {ExposeToSpaceIDs: []string{space.Id()}, ExposeToCIDRs: []string{"192.168.0.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	other := s.AddTestingApplication(c, "other", s.charm)
+
+	apiv6 := &firewaller.FirewallerAPIV6{
+		&firewaller.FirewallerAPIV5{
+			&firewaller.FirewallerAPIV4{
+				FirewallerAPIV3:     s.firewaller,
+				ControllerConfigAPI: common.NewControllerConfig(newMockState(coretesting.ModelTag.Id())),
+			}},
+	}
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: s.application.Tag().String()},
+		{Tag: other.Tag().String()},
+		{Tag: "application-bar"},
+		{Tag: s.units[0].Tag().String()},
+	}}
+	result, err := apiv6.GetExposeInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ExposeInfoResults{
+		Results: []params.ExposeInfoResult{
+			{
+				Exposed: true,
+				ExposedEndpoints: map[string]params.ExposedEndpoint{
+					"url": {
+						ExposeToSpaces: []string{"dmz"},
+						ExposeToCIDRs:  []string{"10.20.30.0/24", "192.168.0.0/24"},
+					},
+				},
+			},
+			{},
+			{Error: apiservertesting.NotFoundError(`application "bar"`)},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
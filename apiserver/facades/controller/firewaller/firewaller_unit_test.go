@@ -16,6 +16,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/firewall"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
@@ -133,3 +134,64 @@ func (s *RemoteFirewallerSuite) TestFirewallRules(c *gc.C) {
 	c.Assert(result.Rules[0].KnownService, gc.Equals, params.KnownServiceValue("juju-application-offer"))
 	c.Assert(result.Rules[0].WhitelistCIDRS, jc.SameContents, []string{"192.168.0.0/16"})
 }
+
+func (s *RemoteFirewallerSuite) TestFirewallServiceRules(c *gc.C) {
+	s.st.services = []firewall.Service{{
+		Name:      "jmx",
+		PortRange: network.PortRange{Protocol: "tcp", FromPort: 7199, ToPort: 7200},
+	}, {
+		Name:      "node-exporter",
+		PortRange: network.PortRange{Protocol: "tcp", FromPort: 9100, ToPort: 9100},
+	}}
+	rule := state.NewFirewallRule("node-exporter", []string{"10.0.0.0/8"})
+	s.st.firewallRules["node-exporter"] = &rule
+
+	api := &firewaller.FirewallerAPIV7{
+		FirewallerAPIV6: &firewaller.FirewallerAPIV6{
+			FirewallerAPIV5: &firewaller.FirewallerAPIV5{FirewallerAPIV4: s.api},
+		},
+	}
+	result, err := api.FirewallServiceRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.FirewallServiceRulesResults{
+		Rules: []params.FirewallServiceRule{{
+			Service: params.FirewallService{
+				Name:     "node-exporter",
+				Protocol: "tcp",
+				FromPort: 9100,
+				ToPort:   9100,
+			},
+			WhitelistCIDRS: []string{"10.0.0.0/8"},
+		}},
+	})
+}
+
+func (s *RemoteFirewallerSuite) TestWatchFirewallRules(c *gc.C) {
+	api := &firewaller.FirewallerAPIV7{
+		FirewallerAPIV6: &firewaller.FirewallerAPIV6{
+			FirewallerAPIV5: &firewaller.FirewallerAPIV5{FirewallerAPIV4: s.api},
+		},
+	}
+	result, err := api.WatchFirewallRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	s.st.CheckCallNames(c, "WatchFirewallRules")
+
+	resource := s.resources.Get(result.NotifyWatcherId)
+	c.Assert(resource, gc.Equals, s.st.rulesWatcher)
+}
+
+func (s *RemoteFirewallerSuite) TestWatchSubnetsAndSpaces(c *gc.C) {
+	api := &firewaller.FirewallerAPIV7{
+		FirewallerAPIV6: &firewaller.FirewallerAPIV6{
+			FirewallerAPIV5: &firewaller.FirewallerAPIV5{FirewallerAPIV4: s.api},
+		},
+	}
+	result, err := api.WatchSubnetsAndSpaces()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	s.st.CheckCallNames(c, "WatchSubnetsAndSpaces")
+
+	resource := s.resources.Get(result.NotifyWatcherId)
+	c.Assert(resource, gc.Equals, s.st.subnetsWatcher)
+}
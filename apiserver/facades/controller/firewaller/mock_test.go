@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/crossmodel"
 	corefirewall "github.com/juju/juju/core/firewall"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
@@ -39,8 +40,11 @@ type mockState struct {
 	relations      map[string]*mockRelation
 	controllerInfo map[string]*mockControllerInfo
 	firewallRules  map[corefirewall.WellKnownServiceType]*state.FirewallRule
+	services       []corefirewall.Service
 	subnetsWatcher *mockStringsWatcher
 	modelWatcher   *mockNotifyWatcher
+	rulesWatcher   *mockNotifyWatcher
+	subnetsWatcher *mockNotifyWatcher
 	configAttrs    map[string]interface{}
 }
 
@@ -54,6 +58,8 @@ func newMockState(modelUUID string) *mockState {
 		firewallRules:  make(map[corefirewall.WellKnownServiceType]*state.FirewallRule),
 		subnetsWatcher: newMockStringsWatcher(),
 		modelWatcher:   newMockNotifyWatcher(),
+		rulesWatcher:   newMockNotifyWatcher(),
+		subnetsWatcher: newMockNotifyWatcher(),
 		configAttrs:    coretesting.FakeConfig(),
 	}
 }
@@ -119,6 +125,20 @@ func (st *mockState) FirewallRule(service corefirewall.WellKnownServiceType) (*s
 	return r, nil
 }
 
+func (st *mockState) FirewallServices() ([]corefirewall.Service, error) {
+	return st.services, nil
+}
+
+func (st *mockState) WatchFirewallRules() state.NotifyWatcher {
+	st.MethodCall(st, "WatchFirewallRules")
+	return st.rulesWatcher
+}
+
+func (st *mockState) WatchSubnetsAndSpaces() state.NotifyWatcher {
+	st.MethodCall(st, "WatchSubnetsAndSpaces")
+	return st.subnetsWatcher
+}
+
 func (st *mockState) SubnetByCIDR(cidr string) (firewaller.Subnet, error) {
 	return nil, errors.NotImplementedf("SubnetByCIDR")
 }
@@ -127,6 +147,10 @@ func (st *mockState) Subnet(id string) (firewaller.Subnet, error) {
 	return nil, errors.NotImplementedf("Subnet")
 }
 
+func (st *mockState) AllSpaceInfos() (network.SpaceInfos, error) {
+	return nil, errors.NotImplementedf("AllSpaceInfos")
+}
+
 type mockWatcher struct {
 	testing.Stub
 	tomb.Tomb
@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/juju/apiserver/common/firewall"
 	corefirewall "github.com/juju/juju/core/firewall"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/state"
 )
 
@@ -27,9 +28,17 @@ type State interface {
 
 	FirewallRule(service corefirewall.WellKnownServiceType) (*state.FirewallRule, error)
 
+	FirewallServices() ([]corefirewall.Service, error)
+
+	WatchFirewallRules() state.NotifyWatcher
+
+	WatchSubnetsAndSpaces() state.NotifyWatcher
+
 	Subnet(id string) (Subnet, error)
 
 	SubnetByCIDR(cidr string) (Subnet, error)
+
+	AllSpaceInfos() (network.SpaceInfos, error)
 }
 
 // TODO(wallyworld) - for tests, remove when remaining firewaller tests become unit tests.
@@ -64,6 +73,19 @@ func (st stateShim) FirewallRule(service corefirewall.WellKnownServiceType) (*st
 	return api.Rule(service)
 }
 
+func (st stateShim) FirewallServices() ([]corefirewall.Service, error) {
+	api := state.NewFirewallRules(st.st)
+	return api.AllServices()
+}
+
+func (st stateShim) WatchFirewallRules() state.NotifyWatcher {
+	return st.st.WatchFirewallRules()
+}
+
+func (st stateShim) WatchSubnetsAndSpaces() state.NotifyWatcher {
+	return st.st.WatchSubnetsAndSpaces()
+}
+
 type Subnet interface {
 	ID() string
 	CIDR() string
@@ -76,3 +98,7 @@ func (st stateShim) Subnet(id string) (Subnet, error) {
 func (st stateShim) SubnetByCIDR(cidr string) (Subnet, error) {
 	return st.st.SubnetByCIDR(cidr)
 }
+
+func (st stateShim) AllSpaceInfos() (network.SpaceInfos, error) {
+	return st.st.AllSpaceInfos()
+}
@@ -8,6 +8,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	firewall "github.com/juju/juju/core/firewall"
 	migration "github.com/juju/juju/migration"
 	resource "github.com/juju/juju/resource"
 	state "github.com/juju/juju/state"
@@ -53,6 +54,21 @@ func (mr *MockPrecheckBackendMockRecorder) AgentVersion() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AgentVersion", reflect.TypeOf((*MockPrecheckBackend)(nil).AgentVersion))
 }
 
+// AllActions mocks base method
+func (m *MockPrecheckBackend) AllActions() ([]migration.PrecheckAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllActions")
+	ret0, _ := ret[0].([]migration.PrecheckAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllActions indicates an expected call of AllActions
+func (mr *MockPrecheckBackendMockRecorder) AllActions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllActions", reflect.TypeOf((*MockPrecheckBackend)(nil).AllActions))
+}
+
 // AllApplications mocks base method
 func (m *MockPrecheckBackend) AllApplications() ([]migration.PrecheckApplication, error) {
 	m.ctrl.T.Helper()
@@ -68,6 +84,21 @@ func (mr *MockPrecheckBackendMockRecorder) AllApplications() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllApplications", reflect.TypeOf((*MockPrecheckBackend)(nil).AllApplications))
 }
 
+// AllFirewallServices mocks base method
+func (m *MockPrecheckBackend) AllFirewallServices() ([]firewall.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllFirewallServices")
+	ret0, _ := ret[0].([]firewall.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllFirewallServices indicates an expected call of AllFirewallServices
+func (mr *MockPrecheckBackendMockRecorder) AllFirewallServices() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllFirewallServices", reflect.TypeOf((*MockPrecheckBackend)(nil).AllFirewallServices))
+}
+
 // AllMachines mocks base method
 func (m *MockPrecheckBackend) AllMachines() ([]migration.PrecheckMachine, error) {
 	m.ctrl.T.Helper()
@@ -98,6 +129,21 @@ func (mr *MockPrecheckBackendMockRecorder) AllModelUUIDs() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllModelUUIDs", reflect.TypeOf((*MockPrecheckBackend)(nil).AllModelUUIDs))
 }
 
+// AllOperations mocks base method
+func (m *MockPrecheckBackend) AllOperations() ([]migration.PrecheckOperation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllOperations")
+	ret0, _ := ret[0].([]migration.PrecheckOperation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllOperations indicates an expected call of AllOperations
+func (mr *MockPrecheckBackendMockRecorder) AllOperations() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllOperations", reflect.TypeOf((*MockPrecheckBackend)(nil).AllOperations))
+}
+
 // AllRelations mocks base method
 func (m *MockPrecheckBackend) AllRelations() ([]migration.PrecheckRelation, error) {
 	m.ctrl.T.Helper()
@@ -113,6 +159,36 @@ func (mr *MockPrecheckBackendMockRecorder) AllRelations() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllRelations", reflect.TypeOf((*MockPrecheckBackend)(nil).AllRelations))
 }
 
+// AllVolumeSnapshots mocks base method
+func (m *MockPrecheckBackend) AllVolumeSnapshots() ([]migration.PrecheckVolumeSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllVolumeSnapshots")
+	ret0, _ := ret[0].([]migration.PrecheckVolumeSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllVolumeSnapshots indicates an expected call of AllVolumeSnapshots
+func (mr *MockPrecheckBackendMockRecorder) AllVolumeSnapshots() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllVolumeSnapshots", reflect.TypeOf((*MockPrecheckBackend)(nil).AllVolumeSnapshots))
+}
+
+// AllVolumes mocks base method
+func (m *MockPrecheckBackend) AllVolumes() ([]migration.PrecheckVolume, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllVolumes")
+	ret0, _ := ret[0].([]migration.PrecheckVolume)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllVolumes indicates an expected call of AllVolumes
+func (mr *MockPrecheckBackendMockRecorder) AllVolumes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllVolumes", reflect.TypeOf((*MockPrecheckBackend)(nil).AllVolumes))
+}
+
 // CloudCredential mocks base method
 func (m *MockPrecheckBackend) CloudCredential(arg0 names_v3.CloudCredentialTag) (state.Credential, error) {
 	m.ctrl.T.Helper()
@@ -17,6 +17,7 @@ import (
 
 	"github.com/juju/juju/apiserver/logsink"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/logfields"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/logdb"
 )
@@ -173,6 +174,7 @@ func (s *agentLoggingStrategy) WriteLog(m params.LogRecord) error {
 		Location: m.Location,
 		Level:    level,
 		Message:  m.Message,
+		Fields:   m.Fields,
 	}}), "logging to DB failed")
 
 	m.Entity = s.entity
@@ -198,7 +200,7 @@ func logToFile(writer io.Writer, prefix string, m params.LogRecord) error {
 		m.Level,
 		m.Module,
 		m.Location,
-		m.Message,
+		logfields.Format(m.Message, m.Fields),
 	}, " ") + "\n"))
 	return err
 }
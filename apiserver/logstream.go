@@ -21,6 +21,7 @@ import (
 type logStreamSource interface {
 	getStart(sink string) (time.Time, error)
 	newTailer(state.LogTailerParams) (state.LogTailer, error)
+	newAuditTailer(state.LogTailerParams) (state.LogTailer, error)
 }
 
 type messageWriter interface {
@@ -52,6 +53,8 @@ func newLogStreamEndpointHandler(ctxt httpContext) *logStreamEndpointHandler {
 // Args for the HTTP request are as follows:
 //   all -> string - one of [true, false], if true, include records from all models
 //   sink -> string - the name of the the log forwarding target
+//   audit -> string - one of [true, false], if true, stream the controller's
+//                     audit log rather than the agents' logs
 func (h *logStreamEndpointHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	logger.Infof("log stream request handler starting")
 	handler := func(conn *websocket.Conn) {
@@ -123,6 +126,18 @@ func (h *logStreamEndpointHandler) newTailer(source logStreamSource, cfg params.
 		}
 	}
 
+	if cfg.Audit {
+		// Every audit record since the last one sent is streamed, so
+		// the lookback record limit doesn't apply.
+		tailer, err := source.newAuditTailer(state.LogTailerParams{
+			StartTime: start,
+		})
+		if err != nil {
+			return nil, errors.Annotate(err, "tailing audit log")
+		}
+		return tailer, nil
+	}
+
 	tailerArgs := state.LogTailerParams{
 		StartTime:    start,
 		InitialLines: cfg.MaxLookbackRecords,
@@ -181,6 +196,14 @@ func (st logStreamState) newTailer(args state.LogTailerParams) (state.LogTailer,
 	return tailer, nil
 }
 
+func (st logStreamState) newAuditTailer(args state.LogTailerParams) (state.LogTailer, error) {
+	tailer, err := state.NewAuditLogTailer(st, args)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return tailer, nil
+}
+
 type logStreamRequestHandler struct {
 	conn       messageWriter
 	req        *http.Request
@@ -237,6 +260,7 @@ func (h *logStreamRequestHandler) apiFromRecords(records []*state.LogRecord) par
 			Location:  rec.Location,
 			Level:     rec.Level.String(),
 			Message:   rec.Message,
+			Fields:    rec.Fields,
 		}
 		result.Records[i] = apiRec
 	}
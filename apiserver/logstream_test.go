@@ -59,6 +59,32 @@ func (s *LogStreamIntSuite) TestParamConversion(c *gc.C) {
 	})
 }
 
+func (s *LogStreamIntSuite) TestParamAudit(c *gc.C) {
+	cfg := params.LogStreamConfig{
+		Sink:               "spam-audit",
+		MaxLookbackRecords: 100,
+		Audit:              true,
+	}
+	req := s.newReq(c, cfg)
+
+	stub := &testing.Stub{}
+	source := &stubSource{stub: stub}
+	source.ReturnGetStart = 10
+	handler := logStreamEndpointHandler{
+		stopCh:    nil,
+		newSource: source.newSource,
+	}
+
+	_, err := handler.newLogStreamRequestHandler(nil, req, clock.WallClock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	stub.CheckCallNames(c, "newSource", "getStart", "newAuditTailer")
+	stub.CheckCall(c, 1, "getStart", "spam-audit")
+	stub.CheckCall(c, 2, "newAuditTailer", state.LogTailerParams{
+		StartTime: time.Unix(10, 0),
+	})
+}
+
 type mockClock struct {
 	clock.Clock
 	now time.Time
@@ -285,6 +311,15 @@ func (s *stubSource) newTailer(args state.LogTailerParams) (state.LogTailer, err
 	return s.ReturnNewTailer, nil
 }
 
+func (s *stubSource) newAuditTailer(args state.LogTailerParams) (state.LogTailer, error) {
+	s.stub.AddCall("newAuditTailer", args)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s.ReturnNewTailer, nil
+}
+
 type stubLogTailer struct {
 	state.LogTailer
 	stub *testing.Stub
@@ -86,6 +86,7 @@ func (s *migrationLoggingStrategy) WriteLog(m params.LogRecord) error {
 		Location: m.Location,
 		Level:    level,
 		Message:  m.Message,
+		Fields:   m.Fields,
 	}})
 	if err == nil {
 		err = s.tracker.Track(m.Time)
@@ -38,6 +38,14 @@ type Action struct {
 	Receiver   string                 `json:"receiver"`
 	Name       string                 `json:"name"`
 	Parameters map[string]interface{} `json:"parameters,omitempty"`
+
+	// QueueTimeout, if non-zero, is how long the action may remain
+	// pending before it is cancelled.
+	QueueTimeout time.Duration `json:"queue-timeout,omitempty"`
+
+	// ExecutionTimeout, if non-zero, is how long the action may run
+	// for before it is failed.
+	ExecutionTimeout time.Duration `json:"execution-timeout,omitempty"`
 }
 
 // ActionResults is a slice of ActionResult for bulk requests.
@@ -45,10 +53,12 @@ type ActionResults struct {
 	Results []ActionResult `json:"results,omitempty"`
 }
 
-// ActionMessage represents a logged message on an action.
+// ActionMessage represents a logged message on an action. Lines of
+// output written by the action have the stream they were written to.
 type ActionMessage struct {
 	Timestamp time.Time `json:"timestamp"`
 	Message   string    `json:"message"`
+	Stream    string    `json:"stream,omitempty"`
 }
 
 // ActionResult describes an Action that will be or has been completed.
@@ -61,6 +71,7 @@ type ActionResult struct {
 	Message   string                 `json:"message,omitempty"`
 	Log       []ActionMessage        `json:"log,omitempty"`
 	Output    map[string]interface{} `json:"output,omitempty"`
+	Operation string                 `json:"operation,omitempty"`
 	Error     *Error                 `json:"error,omitempty"`
 }
 
@@ -114,6 +125,71 @@ type OperationQueryArgs struct {
 	Units         []string `json:"units,omitempty"`
 	FunctionNames []string `json:"functions,omitempty"`
 	Status        []string `json:"status,omitempty"`
+	Operations    []string `json:"operations,omitempty"`
+}
+
+// EnqueueOperationArgs holds the details of an action to be run on a
+// set of units a batch at a time, as a single operation.
+type EnqueueOperationArgs struct {
+	// Receivers holds the units to run the action on, as unit or
+	// application tags or "<application>/leader". The action is run
+	// on the units in the order given, with the units of an
+	// application ordered by unit number.
+	Receivers  []string               `json:"receivers"`
+	Name       string                 `json:"name"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+
+	// BatchSize is the number of units to run the action on at a time.
+	BatchSize int `json:"batch-size"`
+
+	// LeaderFirst, if true, runs the action on the leader of each
+	// application before any of the application's other units.
+	LeaderFirst bool `json:"leader-first,omitempty"`
+
+	// StopOnFailure, if true, stops running the action on further
+	// batches once it has failed on any unit.
+	StopOnFailure bool `json:"stop-on-failure,omitempty"`
+
+	QueueTimeout     time.Duration `json:"queue-timeout,omitempty"`
+	ExecutionTimeout time.Duration `json:"execution-timeout,omitempty"`
+}
+
+// EnqueueOperationResult holds the id of an enqueued operation and the
+// actions queued for its first batch of units.
+type EnqueueOperationResult struct {
+	Operation string         `json:"operation,omitempty"`
+	Actions   []ActionResult `json:"actions,omitempty"`
+	Error     *Error         `json:"error,omitempty"`
+}
+
+// OperationResults holds the results of an operations query: the
+// operations matching the query, and the matching actions that were not
+// run as part of any operation.
+type OperationResults struct {
+	Results []OperationResult `json:"results,omitempty"`
+	Actions []ActionResult    `json:"actions,omitempty"`
+}
+
+// OperationResult describes an action being, or having been, rolled out
+// across a set of units a batch at a time.
+type OperationResult struct {
+	Operation string    `json:"operation"`
+	Action    string    `json:"action,omitempty"`
+	Status    string    `json:"status,omitempty"`
+	Message   string    `json:"message,omitempty"`
+	Enqueued  time.Time `json:"enqueued,omitempty"`
+	Completed time.Time `json:"completed,omitempty"`
+	BatchSize int       `json:"batch-size,omitempty"`
+
+	// QueuedUnits holds the units the action has been queued for, and
+	// UnqueuedUnits those it is yet to be queued for, in the order in
+	// which the action is run on them.
+	QueuedUnits   []string `json:"queued-units,omitempty"`
+	UnqueuedUnits []string `json:"unqueued-units,omitempty"`
+
+	// Actions holds the operation's actions that match the query.
+	Actions []ActionResult `json:"actions,omitempty"`
+	Error   *Error         `json:"error,omitempty"`
 }
 
 // ActionExecutionResults holds a slice of ActionExecutionResult for a
@@ -164,3 +240,17 @@ type ActionPruneArgs struct {
 type ActionMessageParams struct {
 	Messages []EntityString `json:"messages"`
 }
+
+// ActionOutputParams holds the arguments for
+// logging the output of some running actions.
+type ActionOutputParams struct {
+	Output []ActionOutput `json:"output"`
+}
+
+// ActionOutput holds lines written by a running action
+// to one of its output streams.
+type ActionOutput struct {
+	Tag    string   `json:"tag"`
+	Stream string   `json:"stream"`
+	Lines  []string `json:"lines"`
+}
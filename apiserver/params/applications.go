@@ -154,6 +154,20 @@ type ApplicationSetCharm struct {
 // ApplicationExpose holds the parameters for making the application Expose call.
 type ApplicationExpose struct {
 	ApplicationName string `json:"application"`
+
+	// ExposedEndpoints is a map of endpoint names to the sources that
+	// should be able to access the ports opened for them. The empty
+	// endpoint name applies to all endpoints. This field is only
+	// understood by Application facade version 12 and greater.
+	ExposedEndpoints map[string]ExposedEndpoint `json:"exposed-endpoints,omitempty"`
+}
+
+// ExposedEndpoint describes the spaces and/or CIDRs that should be able
+// to access the ports opened for an application endpoint. If neither is
+// specified, the ports are accessible from 0.0.0.0/0.
+type ExposedEndpoint struct {
+	ExposeToSpaces []string `json:"expose-to-spaces,omitempty"`
+	ExposeToCIDRs  []string `json:"expose-to-cidrs,omitempty"`
 }
 
 // ApplicationSet holds the parameters for an application Set
@@ -246,6 +260,11 @@ type ApplicationCharmRelationsResults struct {
 // ApplicationUnexpose holds parameters for the application Unexpose call.
 type ApplicationUnexpose struct {
 	ApplicationName string `json:"application"`
+
+	// ExposedEndpoints, if set, is the list of endpoints whose expose
+	// settings are removed; otherwise the application is unexposed. This
+	// field is only understood by Application facade version 12 and greater.
+	ExposedEndpoints []string `json:"exposed-endpoints"`
 }
 
 // ApplicationMetricCredential holds parameters for the SetApplicationCredentials call.
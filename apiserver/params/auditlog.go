@@ -0,0 +1,66 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// AuditLogQueryArgs holds the filters used to select records from the
+// controller's audit log. Empty filters match every record.
+type AuditLogQueryArgs struct {
+	// User is the name of the user who made the requests.
+	User string `json:"user,omitempty"`
+
+	// Model is the qualified name or UUID of the model the requests
+	// were made against.
+	Model string `json:"model,omitempty"`
+
+	// Facade and Method identify the API method called.
+	Facade string `json:"facade,omitempty"`
+	Method string `json:"method,omitempty"`
+
+	// From and To bound the times of the requests.
+	From *time.Time `json:"from,omitempty"`
+	To   *time.Time `json:"to,omitempty"`
+
+	// ErrorsOnly restricts the results to requests that failed.
+	ErrorsOnly bool `json:"errors-only,omitempty"`
+
+	// Limit is the maximum number of records to return; the most
+	// recent are returned.
+	Limit int `json:"limit,omitempty"`
+}
+
+// AuditLogEntries holds the results of an audit log query, oldest
+// first.
+type AuditLogEntries struct {
+	Entries []AuditLogEntry `json:"entries"`
+}
+
+// AuditLogEntry describes an API request recorded in the audit log,
+// along with the conversation it was made in and the errors in its
+// response.
+type AuditLogEntry struct {
+	ConversationID string    `json:"conversation-id"`
+	ConnectionID   string    `json:"connection-id"`
+	Who            string    `json:"who"`
+	What           string    `json:"what"`
+	ModelName      string    `json:"model-name"`
+	ModelUUID      string    `json:"model-uuid"`
+	RequestID      uint64    `json:"request-id"`
+	When           time.Time `json:"when"`
+	Facade         string    `json:"facade"`
+	Method         string    `json:"method"`
+	Version        int       `json:"version"`
+	Args           string    `json:"args,omitempty"`
+
+	// Errors holds the errors in the response. For bulk calls, the
+	// results that succeeded are left out.
+	Errors []AuditLogError `json:"errors,omitempty"`
+}
+
+// AuditLogError holds an error returned in response to an API request.
+type AuditLogError struct {
+	Message string `json:"message"`
+	Code    string `json:"code,omitempty"`
+}
@@ -14,6 +14,10 @@ type BackupsCreateArgs struct {
 	Notes      string `json:"notes"`
 	KeepCopy   bool   `json:"keep-copy"`
 	NoDownload bool   `json:"no-download"`
+
+	// Incremental requests a backup holding only the database changes
+	// made since the most recent backup kept on the controller.
+	Incremental bool `json:"incremental,omitempty"`
 }
 
 // BackupsInfoArgs holds the args for the API Info method.
@@ -73,10 +77,58 @@ type BackupsMetadataResult struct {
 	CACert       string `json:"ca-cert"`
 	CAPrivateKey string `json:"ca-private-key"`
 	Filename     string `json:"filename"`
+
+	// Parent is the ID of the backup that an incremental
+	// backup follows on from.
+	Parent string `json:"parent,omitempty"`
 }
 
 // RestoreArgs Holds the backup file or id
 type RestoreArgs struct {
 	// BackupId holds the id of the backup in server if any
 	BackupId string `json:"backup-id"`
+
+	// Until, if set, is the point in time to restore an
+	// incremental backup to.
+	Until *time.Time `json:"until,omitempty"`
+}
+
+// RestorePreviewResult holds a summary of the controller that
+// restoring a backup would produce, and how that differs from the
+// controller as it is now.
+type RestorePreviewResult struct {
+	Models []RestorePreviewModel `json:"models"`
+	Diff   RestorePreviewDiff    `json:"diff"`
+}
+
+// RestorePreviewModel summarises a model held in a backup.
+type RestorePreviewModel struct {
+	UUID         string   `json:"uuid"`
+	Name         string   `json:"name"`
+	Owner        string   `json:"owner"`
+	AgentVersion string   `json:"agent-version"`
+	Machines     []string `json:"machines,omitempty"`
+	Applications []string `json:"applications,omitempty"`
+}
+
+// RestorePreviewDiff describes how restoring a backup would change
+// the controller. Added entities are held by the backup but not by
+// the controller; removed entities are held by the controller but
+// not by the backup.
+type RestorePreviewDiff struct {
+	AddedModels   []string                  `json:"added-models,omitempty"`
+	RemovedModels []string                  `json:"removed-models,omitempty"`
+	ChangedModels []RestorePreviewModelDiff `json:"changed-models,omitempty"`
+}
+
+// RestorePreviewModelDiff describes how restoring a backup would
+// change a model. The agent versions are only set if they differ.
+type RestorePreviewModelDiff struct {
+	Model                string   `json:"model"`
+	CurrentAgentVersion  string   `json:"current-agent-version,omitempty"`
+	RestoredAgentVersion string   `json:"restored-agent-version,omitempty"`
+	AddedMachines        []string `json:"added-machines,omitempty"`
+	RemovedMachines      []string `json:"removed-machines,omitempty"`
+	AddedApplications    []string `json:"added-applications,omitempty"`
+	RemovedApplications  []string `json:"removed-applications,omitempty"`
 }
@@ -27,6 +27,51 @@ type FirewallRule struct {
 	WhitelistCIDRS []string `json:"whitelist-cidrs,omitempty"`
 }
 
+// FirewallServiceArgs holds the parameters for defining
+// firewall services.
+type FirewallServiceArgs struct {
+	// Args holds the service definitions to save.
+	Args []FirewallService `json:"args"`
+}
+
+// ListFirewallServicesResults holds the results of listing
+// firewall services.
+type ListFirewallServicesResults struct {
+	Services []FirewallService `json:"services"`
+}
+
+// FirewallService is a service, defined by an operator,
+// for which firewall rules may be set up.
+type FirewallService struct {
+	// Name is the name used to refer to the service in firewall rules.
+	Name KnownServiceValue `json:"name"`
+
+	// Protocol is the protocol on which the service accepts connections.
+	Protocol string `json:"protocol"`
+
+	// FromPort is the start of the service's port range.
+	FromPort int `json:"from-port"`
+
+	// ToPort is the end of the service's port range.
+	ToPort int `json:"to-port"`
+}
+
+// FirewallServiceRulesResults holds the firewall rules for
+// the services defined by an operator.
+type FirewallServiceRulesResults struct {
+	Rules []FirewallServiceRule `json:"rules"`
+}
+
+// FirewallServiceRule is a rule for ingress to a service
+// defined by an operator.
+type FirewallServiceRule struct {
+	// Service is the service to which the rule applies.
+	Service FirewallService `json:"service"`
+
+	// WhitelistCIDRS is the list of subnets allowed access.
+	WhitelistCIDRS []string `json:"whitelist-cidrs,omitempty"`
+}
+
 // KnownServiceArgs holds the parameters for retrieving firewall rules.
 type KnownServiceArgs struct {
 	// KnownServices are the well known services for a firewall rule.
@@ -38,7 +83,8 @@ type KnownServiceArgs struct {
 type KnownServiceValue string
 
 const (
-	// The supported services for firewall rules.
+	// The services defined by Juju for firewall rules.
+	// Rules may also be set up for services defined by an operator.
 	// If a new service is added here, remember to update the
 	// set-firewall-rule command help text.
 
@@ -52,7 +98,8 @@ const (
 	JujuApplicationOfferRule KnownServiceValue = "juju-application-offer"
 )
 
-// Validate returns an error if the service value is not valid.
+// Validate returns an error if the service value is not one
+// of the services defined by Juju.
 func (v KnownServiceValue) Validate() error {
 	switch v {
 	case SSHRule, JujuControllerRule, JujuApplicationOfferRule:
@@ -60,3 +107,17 @@ func (v KnownServiceValue) Validate() error {
 	}
 	return errors.NotValidf("known service %q", v)
 }
+
+// ExposeInfoResults holds the expose details of a set of applications.
+type ExposeInfoResults struct {
+	Results []ExposeInfoResult `json:"results"`
+}
+
+// ExposeInfoResult holds whether an application is exposed and, if so,
+// the expose settings of its endpoints. The CIDRs of each endpoint include
+// those of the subnets in the spaces the endpoint is exposed to.
+type ExposeInfoResult struct {
+	Exposed          bool                       `json:"exposed,omitempty"`
+	ExposedEndpoints map[string]ExposedEndpoint `json:"exposed-endpoints,omitempty"`
+	Error            *Error                     `json:"error,omitempty"`
+}
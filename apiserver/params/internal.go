@@ -841,6 +841,12 @@ type LogMessage struct {
 	Module    string    `json:"mod"`
 	Location  string    `json:"loc"`
 	Message   string    `json:"msg"`
+
+	// Fields holds any structured fields attached to the message.
+	Fields map[string]string `json:"fields,omitempty"`
+
+	// ModelUUID identifies the model the message was logged in.
+	ModelUUID string `json:"model,omitempty"`
 }
 
 // ResourceUploadResult is used to return some details about an
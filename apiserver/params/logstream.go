@@ -24,6 +24,9 @@ type LogStreamRecord struct {
 	Location  string    `json:"lo"`
 	Level     string    `json:"lv"`
 	Message   string    `json:"msg"`
+
+	// Fields holds any structured fields attached to the message.
+	Fields map[string]string `json:"fields,omitempty"`
 }
 
 // LogStreamConfig holds all the information necessary to open a
@@ -47,4 +50,10 @@ type LogStreamConfig struct {
 
 	// MaxLookbackRecords is the maximum number of log records to stream from the past.
 	MaxLookbackRecords int `schema:"maxlookbackrecords" url:"maxlookbackrecords,omitempty"`
+
+	// Audit indicates that the records in the controller's audit log
+	// should be streamed, rather than the agents' log records. The
+	// position in the audit log is tracked separately, so Sink should
+	// differ from the one used for the agents' logs.
+	Audit bool `schema:"audit" url:"audit,omitempty"`
 }
@@ -423,16 +423,18 @@ type ItemChange struct {
 // BranchInfo holds data about a model generation (branch)
 // that is tracked by multiwatcherStore.
 type BranchInfo struct {
-	ModelUUID     string                  `json:"model-uuid"`
-	Id            string                  `json:"id"`
-	Name          string                  `json:"name"`
-	AssignedUnits map[string][]string     `json:"assigned-units"`
-	Config        map[string][]ItemChange `json:"charm-config"`
-	Created       int64                   `json:"created"`
-	CreatedBy     string                  `json:"created-by"`
-	Completed     int64                   `json:"completed"`
-	CompletedBy   string                  `json:"completed-by"`
-	GenerationId  int                     `json:"generation-id"`
+	ModelUUID     string                       `json:"model-uuid"`
+	Id            string                       `json:"id"`
+	Name          string                       `json:"name"`
+	AssignedUnits map[string][]string          `json:"assigned-units"`
+	Config        map[string][]ItemChange      `json:"charm-config"`
+	CharmURLs     map[string]string            `json:"charm-urls,omitempty"`
+	Resources     map[string]map[string]string `json:"resources,omitempty"`
+	Created       int64                        `json:"created"`
+	CreatedBy     string                       `json:"created-by"`
+	Completed     int64                        `json:"completed"`
+	CompletedBy   string                       `json:"completed-by"`
+	GenerationId  int                          `json:"generation-id"`
 }
 
 // EntityId returns a unique identifier for a generation.
@@ -933,6 +933,9 @@ type LogRecord struct {
 	Level    string    `json:"v"`
 	Message  string    `json:"x"`
 	Entity   string    `json:"e,omitempty"`
+
+	// Fields holds any structured fields attached to the message.
+	Fields map[string]string `json:"f,omitempty"`
 }
 
 // PubSubMessage is used to propagate pubsub messages from one api server to the
@@ -1223,6 +1226,141 @@ type BranchTrackArg struct {
 	NumUnits   int      `json:"num-units,omitempty"`
 }
 
+// BranchApplicationChanges holds application changes to be staged under
+// an in-flight branch and applied when the branch is committed.
+type BranchApplicationChanges struct {
+	// BranchName identifies the branch under which to stage the changes.
+	BranchName string `json:"branch"`
+
+	// ApplicationName is the name of the application to change.
+	ApplicationName string `json:"application"`
+
+	// Constraints, if set, replaces the application's constraints.
+	Constraints *constraints.Value `json:"constraints,omitempty"`
+
+	// Scale, if set, is the desired scale of the application.
+	// It only applies to CAAS models.
+	Scale *int `json:"scale,omitempty"`
+
+	// Bindings maps endpoint names to the spaces that they are bound to.
+	// The bindings are merged with the application's existing bindings.
+	Bindings map[string]string `json:"bindings,omitempty"`
+
+	// Force indicates that bindings are staged without checking that
+	// the application's machines have addresses in the bound spaces.
+	Force bool `json:"force,omitempty"`
+}
+
+// BranchApplicationChangesArgs holds the arguments to the
+// StageApplicationChanges API call.
+type BranchApplicationChangesArgs struct {
+	Args []BranchApplicationChanges `json:"args"`
+}
+
+// BranchRolloutArg holds the arguments to the RolloutBranch API call,
+// describing how the changes made under a branch are rolled out to its
+// applications' units before the branch is committed.
+type BranchRolloutArg struct {
+	// BranchName is the name of the branch to roll out.
+	BranchName string `json:"branch"`
+
+	// BatchSize is the maximum number of units
+	// set to track the branch in each batch.
+	BatchSize int `json:"batch-size"`
+
+	// BatchInterval is the time to wait between batches.
+	BatchInterval time.Duration `json:"batch-interval"`
+
+	// HaltOnError indicates that the rollout stops if any unit
+	// tracking the branch has a workload status of "error".
+	HaltOnError bool `json:"halt-on-error"`
+}
+
+// GenerationRollout represents the progress of a branch rollout.
+type GenerationRollout struct {
+	// BatchSize is the maximum number of units
+	// set to track the branch in each batch.
+	BatchSize int `json:"batch-size"`
+
+	// BatchInterval is the time waited between batches.
+	BatchInterval time.Duration `json:"batch-interval"`
+
+	// HaltOnError indicates that the rollout stops if any unit
+	// tracking the branch has a workload status of "error".
+	HaltOnError bool `json:"halt-on-error"`
+
+	// Started is the Unix timestamp at which the rollout started.
+	Started int64 `json:"started"`
+
+	// StartedBy is the user who started the rollout.
+	StartedBy string `json:"started-by"`
+
+	// Batches is the number of batches rolled out so far.
+	Batches int `json:"batches"`
+
+	// Halted, if set, is the reason that the rollout was stopped.
+	Halted string `json:"halted,omitempty"`
+}
+
+// BranchRollout describes a branch rollout in progress,
+// as required by the worker that drives it.
+type BranchRollout struct {
+	// BranchName is the name of the branch being rolled out.
+	BranchName string `json:"branch"`
+
+	// Rollout is the policy and progress of the rollout.
+	Rollout GenerationRollout `json:"rollout"`
+
+	// LastBatch is the Unix timestamp at which the last batch of units was
+	// set to track the branch. It is zero before the first batch.
+	LastBatch int64 `json:"last-batch,omitempty"`
+
+	// UnitsPending is the names of the units of the branch's
+	// applications that are not yet tracking it.
+	UnitsPending []string `json:"units-pending"`
+
+	// UnitsInError is the names of the units tracking the branch
+	// that have a workload status of "error".
+	UnitsInError []string `json:"units-in-error"`
+}
+
+// BranchRolloutResults holds the branch rollouts in progress.
+type BranchRolloutResults struct {
+	Rollouts []BranchRollout `json:"rollouts"`
+	Error    *Error          `json:"error,omitempty"`
+}
+
+// BranchRolloutBatch identifies units to be set
+// to track a branch that is being rolled out.
+type BranchRolloutBatch struct {
+	BranchName string   `json:"branch"`
+	Units      []string `json:"units"`
+}
+
+// BranchRolloutBatches holds the arguments to
+// the AssignRolloutBatches API call.
+type BranchRolloutBatches struct {
+	Batches []BranchRolloutBatch `json:"batches"`
+}
+
+// BranchRolloutHalt identifies a branch rollout
+// to be stopped, and the reason for stopping it.
+type BranchRolloutHalt struct {
+	BranchName string `json:"branch"`
+	Reason     string `json:"reason"`
+}
+
+// BranchRolloutHalts holds the arguments to
+// the HaltRollouts API call.
+type BranchRolloutHalts struct {
+	Halts []BranchRolloutHalt `json:"halts"`
+}
+
+// BranchArgs holds a collection of branch names.
+type BranchArgs struct {
+	Args []BranchArg `json:"args"`
+}
+
 // GenerationApplication represents changes to an application
 // made under a branch.
 type GenerationApplication struct {
@@ -1243,6 +1381,24 @@ type GenerationApplication struct {
 	// Config changes are the effective new configuration values resulting from
 	// changes made under this branch.
 	ConfigChanges map[string]interface{} `json:"config"`
+
+	// CharmURL is the URL of the charm that the application has been
+	// upgraded to under this branch.
+	CharmURL string `json:"charm-url,omitempty"`
+
+	// Resources maps resource names to the IDs of the pending resources
+	// staged under this branch.
+	Resources map[string]string `json:"resources,omitempty"`
+
+	// Constraints is the application constraints staged under this branch.
+	Constraints *constraints.Value `json:"constraints,omitempty"`
+
+	// Scale is the desired application scale staged under this branch.
+	Scale *int `json:"scale,omitempty"`
+
+	// Bindings maps endpoint names to the names of the spaces that they
+	// are bound to under this branch.
+	Bindings map[string]string `json:"bindings,omitempty"`
 }
 
 // Generation represents a model generation's details including config changes.
@@ -1268,6 +1424,10 @@ type Generation struct {
 	// Applications holds the collection of application changes
 	// made under this generation.
 	Applications []GenerationApplication `json:"applications"`
+
+	// Rollout, if set, is the progress of the rollout
+	// that will commit the generation.
+	Rollout *GenerationRollout `json:"rollout,omitempty"`
 }
 
 // BranchResults transports a collection of generation details.
@@ -275,6 +275,107 @@ type RemoveVolumeParams struct {
 	Destroy bool `json:"destroy,omitempty"`
 }
 
+// VolumeResizeParams holds the parameters for expanding a storage volume.
+type VolumeResizeParams struct {
+	// VolumeTag is the tag of the volume to expand.
+	VolumeTag string `json:"volume-tag"`
+
+	// VolumeId is the storage provider's unique ID for the volume.
+	VolumeId string `json:"volume-id"`
+
+	// Provider is the storage provider that manages the volume.
+	Provider string `json:"provider"`
+
+	// Size is the size in MiB the volume is to be expanded to.
+	Size uint64 `json:"size"`
+}
+
+// VolumeSnapshotNames holds the names of a collection of volume snapshots.
+type VolumeSnapshotNames struct {
+	Names []string `json:"names"`
+}
+
+// VolumeSnapshotInfo describes a volume snapshot taken by a
+// storage provider.
+type VolumeSnapshotInfo struct {
+	// SnapshotId is the storage provider's unique ID for the snapshot.
+	SnapshotId string `json:"snapshot-id"`
+
+	// Size is the size in MiB of the volume the snapshot was taken of.
+	Size uint64 `json:"size"`
+
+	// Ready reports whether the snapshot can be restored from.
+	Ready bool `json:"ready"`
+}
+
+// VolumeSnapshot records the provider's view of a volume snapshot.
+type VolumeSnapshot struct {
+	Name string             `json:"name"`
+	Info VolumeSnapshotInfo `json:"info"`
+}
+
+// VolumeSnapshots holds the provider's view of multiple volume snapshots.
+type VolumeSnapshots struct {
+	Snapshots []VolumeSnapshot `json:"snapshots"`
+}
+
+// VolumeSnapshotRestore records the volume restored from a snapshot.
+type VolumeSnapshotRestore struct {
+	Name string     `json:"name"`
+	Info VolumeInfo `json:"info"`
+}
+
+// VolumeSnapshotRestores records the volumes restored from
+// multiple snapshots.
+type VolumeSnapshotRestores struct {
+	Restores []VolumeSnapshotRestore `json:"restores"`
+}
+
+// VolumeSnapshotParams holds the parameters for taking, restoring
+// or destroying a volume snapshot.
+type VolumeSnapshotParams struct {
+	// Name is the name of the snapshot, unique within the model.
+	Name string `json:"name"`
+
+	// Life is the snapshot's life; dying snapshots are to be destroyed.
+	Life life.Value `json:"life"`
+
+	// VolumeTag is the tag of the volume the snapshot is taken of.
+	VolumeTag string `json:"volume-tag"`
+
+	// VolumeId is the storage provider's unique ID for the volume.
+	VolumeId string `json:"volume-id"`
+
+	// Provider is the storage provider that manages the volume.
+	Provider string `json:"provider"`
+
+	// Attributes holds the storage pool's configuration.
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+
+	// Tags holds the resource tags to apply to the snapshot
+	// and to restored volumes.
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// Info is the provider's view of the snapshot, if it has been taken.
+	Info *VolumeSnapshotInfo `json:"info,omitempty"`
+
+	// RestoreStorageTag is the tag of the storage instance that the
+	// snapshot is to be restored to, if a restore is pending.
+	RestoreStorageTag string `json:"restore-storage-tag,omitempty"`
+}
+
+// VolumeSnapshotParamsResult holds parameters for a volume snapshot.
+type VolumeSnapshotParamsResult struct {
+	Result VolumeSnapshotParams `json:"result"`
+	Error  *Error               `json:"error,omitempty"`
+}
+
+// VolumeSnapshotParamsResults holds parameters for multiple
+// volume snapshots.
+type VolumeSnapshotParamsResults struct {
+	Results []VolumeSnapshotParamsResult `json:"results,omitempty"`
+}
+
 // VolumeAttachmentParams holds the parameters for creating a volume
 // attachment.
 type VolumeAttachmentParams struct {
@@ -356,6 +457,17 @@ type RemoveVolumeParamsResults struct {
 	Results []RemoveVolumeParamsResult `json:"results,omitempty"`
 }
 
+// VolumeResizeParamsResult holds parameters for expanding a volume.
+type VolumeResizeParamsResult struct {
+	Result VolumeResizeParams `json:"result"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// VolumeResizeParamsResults holds parameters for expanding multiple volumes.
+type VolumeResizeParamsResults struct {
+	Results []VolumeResizeParamsResult `json:"results,omitempty"`
+}
+
 // VolumeAttachmentParamsResults holds provisioning parameters for a volume
 // attachment.
 type VolumeAttachmentParamsResult struct {
@@ -951,3 +1063,87 @@ type AddStorageDetails struct {
 	// of the added storage instances.
 	StorageTags []string `json:"storage-tags"`
 }
+
+// StorageResizeParams contains the parameters for expanding a collection
+// of storage instances.
+type StorageResizeParams struct {
+	Storage []StorageResize `json:"storage"`
+}
+
+// StorageResize contains the parameters for expanding a storage instance.
+type StorageResize struct {
+	// StorageTag is the tag of the storage instance to expand.
+	StorageTag string `json:"storage-tag"`
+
+	// Size is the size in MiB the storage is to be expanded to.
+	Size uint64 `json:"size"`
+}
+
+// StorageSnapshotCreateParams contains the parameters for taking
+// snapshots of a collection of storage instances.
+type StorageSnapshotCreateParams struct {
+	Snapshots []StorageSnapshotCreate `json:"snapshots"`
+}
+
+// StorageSnapshotCreate contains the parameters for taking a snapshot
+// of a storage instance.
+type StorageSnapshotCreate struct {
+	// StorageTag is the tag of the storage instance to take a
+	// snapshot of.
+	StorageTag string `json:"storage-tag"`
+
+	// Name is the name of the snapshot, unique within the model.
+	Name string `json:"name"`
+}
+
+// StorageSnapshotRestoreParams contains the parameters for restoring
+// a collection of storage snapshots.
+type StorageSnapshotRestoreParams struct {
+	Restores []StorageSnapshotRestore `json:"restores"`
+}
+
+// StorageSnapshotRestore contains the parameters for restoring a
+// storage snapshot to a new storage instance.
+type StorageSnapshotRestore struct {
+	// Name is the name of the snapshot to restore.
+	Name string `json:"name"`
+
+	// StorageName is the name of the storage as declared by the charm
+	// that the restored storage will be attached to, e.g. "pgdata".
+	StorageName string `json:"storage-name"`
+}
+
+// StorageSnapshotDetails holds information about a storage snapshot.
+type StorageSnapshotDetails struct {
+	// Name is the name of the snapshot, unique within the model.
+	Name string `json:"name"`
+
+	// VolumeTag is the tag of the volume the snapshot was taken of.
+	VolumeTag string `json:"volume-tag"`
+
+	// Pool is the storage pool of the volume the snapshot was taken of.
+	Pool string `json:"pool"`
+
+	// Life is the snapshot's life.
+	Life life.Value `json:"life"`
+
+	// SnapshotId is the storage provider's unique ID for the snapshot,
+	// if it has been taken.
+	SnapshotId string `json:"snapshot-id,omitempty"`
+
+	// Size is the size in MiB of the volume the snapshot was taken of,
+	// if it has been taken.
+	Size uint64 `json:"size,omitempty"`
+
+	// Ready reports whether the snapshot can be restored from.
+	Ready bool `json:"ready"`
+
+	// RestoringTo is the tag of the storage instance the snapshot is
+	// being restored to, if a restore is pending.
+	RestoringTo string `json:"restoring-to,omitempty"`
+}
+
+// StorageSnapshotDetailsResults holds the details of storage snapshots.
+type StorageSnapshotDetailsResults struct {
+	Results []StorageSnapshotDetails `json:"results"`
+}
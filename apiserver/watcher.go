@@ -439,6 +439,8 @@ func (aw *SrvAllWatcher) translateBranch(info multiwatcher.EntityInfo) params.En
 		Name:          orig.Name,
 		AssignedUnits: orig.AssignedUnits,
 		Config:        aw.translateBranchConfig(orig.Config),
+		CharmURLs:     orig.CharmURLs,
+		Resources:     orig.Resources,
 		Created:       orig.Created,
 		CreatedBy:     orig.CreatedBy,
 		Completed:     orig.Completed,
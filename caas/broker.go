@@ -5,6 +5,7 @@ package caas
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/version"
@@ -72,12 +73,12 @@ type NewContainerBrokerFunc func(args environs.OpenParams) (Broker, error)
 type StatusCallbackFunc func(appName string, settableStatus status.Status, info string, data map[string]interface{}) error
 
 // DeploymentType defines a deployment type.
-type DeploymentType string
+type DeploymentType = specs.DeploymentType
 
 const (
-	DeploymentStateless DeploymentType = "stateless"
-	DeploymentStateful  DeploymentType = "stateful"
-	DeploymentDaemon    DeploymentType = "daemon"
+	DeploymentStateless = specs.DeploymentStateless
+	DeploymentStateful  = specs.DeploymentStateful
+	DeploymentDaemon    = specs.DeploymentDaemon
 )
 
 // ServiceType defines a service type.
@@ -180,6 +181,15 @@ type Broker interface {
 	// are changes to the deployment of the specified application.
 	WatchService(appName string) (watcher.NotifyWatcher, error)
 
+	// WatchEvents returns a watcher which notifies when the
+	// substrate reports warning events in the model. A single
+	// watcher serves every application in the model.
+	WatchEvents() (watcher.NotifyWatcher, error)
+
+	// Events returns the warning events reported by the substrate
+	// for the workload of the specified application and its units.
+	Events(appName string) ([]Event, error)
+
 	// Operator returns an Operator with current status and life details.
 	Operator(string) (*Operator, error)
 
@@ -324,6 +334,25 @@ type Unit struct {
 	FilesystemInfo []FilesystemInfo
 }
 
+// Event represents a warning reported by the substrate about the
+// workload of an application, eg an image which can't be pulled.
+type Event struct {
+	// UnitId is the provider id of the unit the event is about,
+	// or empty if it is about the application's workload as a whole.
+	UnitId string
+
+	// Object identifies the substrate resource the event is about.
+	Object string
+
+	Reason  string
+	Message string
+
+	// Count is the number of times the event has occurred,
+	// and LastSeen the most recent.
+	Count    int32
+	LastSeen time.Time
+}
+
 // Operator represents information about the status of an "operator pod".
 type Operator struct {
 	Id     string
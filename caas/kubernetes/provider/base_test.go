@@ -48,6 +48,7 @@ type BaseSuite struct {
 	mockSecrets                *mocks.MockSecretInterface
 	mockDeployments            *mocks.MockDeploymentInterface
 	mockStatefulSets           *mocks.MockStatefulSetInterface
+	mockDaemonSets             *mocks.MockDaemonSetInterface
 	mockPods                   *mocks.MockPodInterface
 	mockServices               *mocks.MockServiceInterface
 	mockConfigMaps             *mocks.MockConfigMapInterface
@@ -59,6 +60,10 @@ type BaseSuite struct {
 	mockNodes                  *mocks.MockNodeInterface
 	mockEvents                 *mocks.MockEventInterface
 
+	mockNetworkPolicies          *mocks.MockNetworkPolicyInterface
+	mockPodDisruptionBudgets     *mocks.MockPodDisruptionBudgetInterface
+	mockHorizontalPodAutoscalers *mocks.MockHorizontalPodAutoscalerInterface
+
 	mockApiextensionsV1          *mocks.MockApiextensionsV1beta1Interface
 	mockApiextensionsClient      *mocks.MockApiExtensionsClientInterface
 	mockCustomResourceDefinition *mocks.MockCustomResourceDefinitionInterface
@@ -195,13 +200,30 @@ func (s *BaseSuite) setupK8sRestClient(c *gc.C, ctrl *gomock.Controller, namespa
 	s.mockExtensions = mocks.NewMockExtensionsV1beta1Interface(ctrl)
 	s.mockStatefulSets = mocks.NewMockStatefulSetInterface(ctrl)
 	s.mockDeployments = mocks.NewMockDeploymentInterface(ctrl)
+	s.mockDaemonSets = mocks.NewMockDaemonSetInterface(ctrl)
 	s.mockIngressInterface = mocks.NewMockIngressInterface(ctrl)
 	s.k8sClient.EXPECT().ExtensionsV1beta1().AnyTimes().Return(s.mockExtensions)
 	s.k8sClient.EXPECT().AppsV1().AnyTimes().Return(s.mockApps)
 	s.mockApps.EXPECT().StatefulSets(namespace).AnyTimes().Return(s.mockStatefulSets)
 	s.mockApps.EXPECT().Deployments(namespace).AnyTimes().Return(s.mockDeployments)
+	s.mockApps.EXPECT().DaemonSets(namespace).AnyTimes().Return(s.mockDaemonSets)
 	s.mockExtensions.EXPECT().Ingresses(namespace).AnyTimes().Return(s.mockIngressInterface)
 
+	mockNetworkingV1 := mocks.NewMockNetworkingV1Interface(ctrl)
+	s.mockNetworkPolicies = mocks.NewMockNetworkPolicyInterface(ctrl)
+	s.k8sClient.EXPECT().NetworkingV1().AnyTimes().Return(mockNetworkingV1)
+	mockNetworkingV1.EXPECT().NetworkPolicies(namespace).AnyTimes().Return(s.mockNetworkPolicies)
+
+	mockPolicyV1beta1 := mocks.NewMockPolicyV1beta1Interface(ctrl)
+	s.mockPodDisruptionBudgets = mocks.NewMockPodDisruptionBudgetInterface(ctrl)
+	s.k8sClient.EXPECT().PolicyV1beta1().AnyTimes().Return(mockPolicyV1beta1)
+	mockPolicyV1beta1.EXPECT().PodDisruptionBudgets(namespace).AnyTimes().Return(s.mockPodDisruptionBudgets)
+
+	mockAutoscalingV1 := mocks.NewMockAutoscalingV1Interface(ctrl)
+	s.mockHorizontalPodAutoscalers = mocks.NewMockHorizontalPodAutoscalerInterface(ctrl)
+	s.k8sClient.EXPECT().AutoscalingV1().AnyTimes().Return(mockAutoscalingV1)
+	mockAutoscalingV1.EXPECT().HorizontalPodAutoscalers(namespace).AnyTimes().Return(s.mockHorizontalPodAutoscalers)
+
 	s.mockStorage = mocks.NewMockStorageV1Interface(ctrl)
 	s.mockStorageClass = mocks.NewMockStorageClassInterface(ctrl)
 	s.k8sClient.EXPECT().StorageV1().AnyTimes().Return(s.mockStorage)
@@ -113,6 +113,7 @@ func (s *K8sBrokerSuite) assertCustomerResourceDefinitions(c *gc.C, crds map[str
 		OperatorImagePath: "operator/image-path",
 		ResourceTags:      map[string]string{"juju-controller-uuid": testing.ControllerTag.Id()},
 	}
+	s.expectNoPolicyResources("app-name")
 	err = s.broker.EnsureService("app-name", func(_ string, _ status.Status, e string, _ map[string]interface{}) error {
 		c.Logf("EnsureService error -> %q", e)
 		return nil
@@ -423,6 +424,7 @@ func (s *K8sBrokerSuite) assertCustomerResources(c *gc.C, crs map[string][]unstr
 	}...)
 	gomock.InOrder(assertCalls...)
 
+	s.expectNoPolicyResources("app-name")
 	errChan := make(chan error)
 	go func() {
 		params := &caas.ServiceParams{
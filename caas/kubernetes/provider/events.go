@@ -4,12 +4,16 @@
 package provider
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/juju/errors"
 
 	core "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/watcher"
 )
 
@@ -42,6 +46,9 @@ const (
 	BackOffPullImage        = "BackOff"
 )
 
+// warningEventsSelector selects the events which report a problem.
+var warningEventsSelector = fields.OneTermEqualSelector("type", core.EventTypeWarning).String()
+
 func (k *kubernetesClient) getEvents(objName string, objKind string) ([]core.Event, error) {
 	selector := fields.AndSelectors(
 		fields.OneTermEqualSelector("involvedObject.name", objName),
@@ -73,3 +80,72 @@ func (k *kubernetesClient) watchEvents(objName string, objKind string) (watcher.
 	}
 	return k.newWatcher(w, objName, k.clock)
 }
+
+// WatchEvents returns a watcher which notifies when warning
+// events are reported in the model's namespace.
+func (k *kubernetesClient) WatchEvents() (watcher.NotifyWatcher, error) {
+	events := k.client().CoreV1().Events(k.namespace)
+	w, err := events.Watch(v1.ListOptions{
+		FieldSelector: warningEventsSelector,
+		Watch:         true,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return k.newWatcher(w, k.namespace, k.clock)
+}
+
+// Events returns the warning events reported for the pods of the
+// specified application, and for the workload which manages them.
+func (k *kubernetesClient) Events(appName string) ([]caas.Event, error) {
+	deploymentName := k.deploymentName(appName)
+	pods, err := k.client().CoreV1().Pods(k.namespace).List(v1.ListOptions{
+		LabelSelector: applicationSelector(appName),
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unitIds := make(map[string]string)
+	for _, p := range pods.Items {
+		unitIds[p.Name] = providerID(&p)
+	}
+
+	eventList, err := k.client().CoreV1().Events(k.namespace).List(v1.ListOptions{
+		IncludeUninitialized: true,
+		FieldSelector:        warningEventsSelector,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []caas.Event
+	for _, evt := range eventList.Items {
+		obj := evt.InvolvedObject
+		var unitId string
+		switch obj.Kind {
+		case "Pod":
+			var ok bool
+			if unitId, ok = unitIds[obj.Name]; !ok {
+				continue
+			}
+		case "StatefulSet", "Deployment", "DaemonSet":
+			if obj.Name != deploymentName {
+				continue
+			}
+		default:
+			continue
+		}
+		lastSeen := evt.LastTimestamp.Time
+		if lastSeen.IsZero() {
+			lastSeen = evt.EventTime.Time
+		}
+		result = append(result, caas.Event{
+			UnitId:   unitId,
+			Object:   fmt.Sprintf("%s/%s", strings.ToLower(obj.Kind), obj.Name),
+			Reason:   evt.Reason,
+			Message:  evt.Message,
+			Count:    evt.Count,
+			LastSeen: lastSeen,
+		})
+	}
+	return result, nil
+}
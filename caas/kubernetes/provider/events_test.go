@@ -0,0 +1,114 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/juju/clock/testclock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/testing"
+)
+
+func (s *K8sBrokerSuite) TestEvents(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	lastSeen := time.Date(2020, 4, 1, 10, 0, 0, 0, time.UTC)
+	pod := core.Pod{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "app-name-0",
+			OwnerReferences: []v1.OwnerReference{
+				{Kind: "StatefulSet"},
+			},
+		},
+	}
+	events := []core.Event{{
+		InvolvedObject: core.ObjectReference{Kind: "Pod", Name: "app-name-0"},
+		Type:           core.EventTypeWarning,
+		Reason:         "Failed",
+		Message:        `Failed to pull image "app-image"`,
+		Count:          3,
+		LastTimestamp:  v1.NewTime(lastSeen),
+	}, {
+		InvolvedObject: core.ObjectReference{Kind: "StatefulSet", Name: "app-name"},
+		Type:           core.EventTypeWarning,
+		Reason:         "FailedCreate",
+		Message:        "exceeded quota",
+		Count:          1,
+		LastTimestamp:  v1.NewTime(lastSeen),
+	}, {
+		InvolvedObject: core.ObjectReference{Kind: "Pod", Name: "other-app-0"},
+		Type:           core.EventTypeWarning,
+		Reason:         "FailedScheduling",
+		Message:        "0/1 nodes are available",
+		Count:          1,
+	}}
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-app==app-name"}).
+			Return(&core.PodList{Items: []core.Pod{pod}}, nil),
+		s.mockEvents.EXPECT().List(v1.ListOptions{
+			IncludeUninitialized: true,
+			FieldSelector:        "type=Warning",
+		}).Return(&core.EventList{Items: events}, nil),
+	)
+
+	result, err := s.broker.Events("app-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, []caas.Event{{
+		UnitId:   "app-name-0",
+		Object:   "pod/app-name-0",
+		Reason:   "Failed",
+		Message:  `Failed to pull image "app-image"`,
+		Count:    3,
+		LastSeen: lastSeen,
+	}, {
+		Object:   "statefulset/app-name",
+		Reason:   "FailedCreate",
+		Message:  "exceeded quota",
+		Count:    1,
+		LastSeen: lastSeen,
+	}})
+}
+
+func (s *K8sBrokerSuite) TestWatchEvents(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	eventsWatcher := watch.NewRaceFreeFake()
+	gomock.InOrder(
+		s.mockEvents.EXPECT().Watch(v1.ListOptions{
+			FieldSelector: "type=Warning",
+			Watch:         true,
+		}).Return(eventsWatcher, nil),
+	)
+
+	w, err := s.broker.WatchEvents()
+	c.Assert(err, jc.ErrorIsNil)
+
+	evt := &core.Event{ObjectMeta: v1.ObjectMeta{Name: "app-name-0.1"}}
+	go func(w *watch.RaceFreeFakeWatcher, clk *testclock.Clock) {
+		if !w.IsStopped() {
+			clk.WaitAdvance(time.Second, testing.ShortWait, 1)
+			w.Add(evt)
+		}
+	}(eventsWatcher, s.clock)
+
+	select {
+	case _, ok := <-w.Changes():
+		c.Assert(ok, jc.IsTrue)
+	case <-time.After(testing.LongWait):
+		c.Fatal("timed out waiting for event")
+	}
+}
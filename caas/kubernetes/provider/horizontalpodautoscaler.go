@@ -0,0 +1,156 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	k8sspecs "github.com/juju/juju/caas/kubernetes/provider/specs"
+	k8sannotations "github.com/juju/juju/core/annotations"
+)
+
+func (k *kubernetesClient) getHorizontalPodAutoscalerLabels(appName string) map[string]string {
+	return map[string]string{
+		labelApplication: appName,
+	}
+}
+
+// ensureHorizontalPodAutoscalers creates or updates the horizontal pod
+// autoscalers in the spec, and deletes any others previously created for
+// the application. Autoscalers without a scale target scale the given
+// workload, the application's deployment or stateful set.
+func (k *kubernetesClient) ensureHorizontalPodAutoscalers(
+	appName string, workload autoscalingv1.CrossVersionObjectReference,
+	annotations k8sannotations.Annotation, hpaSpecs []k8sspecs.K8sHorizontalPodAutoscalerSpec,
+) (cleanUps []func(), err error) {
+	wanted := set.NewStrings()
+	for _, v := range hpaSpecs {
+		spec := v.Spec
+		if spec.ScaleTargetRef.Name == "" {
+			spec.ScaleTargetRef = workload
+		}
+		hpa := &autoscalingv1.HorizontalPodAutoscaler{
+			ObjectMeta: v1.ObjectMeta{
+				Name:        v.Name,
+				Labels:      k8slabels.Merge(v.Labels, k.getHorizontalPodAutoscalerLabels(appName)),
+				Annotations: k8sannotations.New(v.Annotations).Merge(annotations),
+			},
+			Spec: spec,
+		}
+		cleanUp, err := k.ensureHorizontalPodAutoscaler(appName, hpa)
+		cleanUps = append(cleanUps, cleanUp)
+		if err != nil {
+			return cleanUps, errors.Trace(err)
+		}
+		wanted.Add(v.Name)
+	}
+
+	existing, err := k.listHorizontalPodAutoscalers(k.getHorizontalPodAutoscalerLabels(appName))
+	if errors.IsNotFound(err) {
+		return cleanUps, nil
+	}
+	if err != nil {
+		return cleanUps, errors.Trace(err)
+	}
+	for _, hpa := range existing {
+		if wanted.Contains(hpa.GetName()) {
+			continue
+		}
+		logger.Debugf("deleting horizontal pod autoscaler %q no longer in the spec for %q", hpa.GetName(), appName)
+		if err := k.deleteHorizontalPodAutoscaler(hpa.GetName(), hpa.GetUID()); err != nil {
+			return cleanUps, errors.Trace(err)
+		}
+	}
+	return cleanUps, nil
+}
+
+func (k *kubernetesClient) ensureHorizontalPodAutoscaler(appName string, spec *autoscalingv1.HorizontalPodAutoscaler) (func(), error) {
+	cleanUp := func() {}
+	out, err := k.createHorizontalPodAutoscaler(spec)
+	if err == nil {
+		cleanUp = func() { _ = k.deleteHorizontalPodAutoscaler(out.GetName(), out.GetUID()) }
+		return cleanUp, nil
+	}
+	if !errors.IsAlreadyExists(err) {
+		return cleanUp, errors.Trace(err)
+	}
+	existing, err := k.getHorizontalPodAutoscaler(spec.GetName())
+	if err != nil {
+		return cleanUp, errors.Trace(err)
+	}
+	if len(existing.GetLabels()) == 0 || !k8slabels.AreLabelsInWhiteList(k.getHorizontalPodAutoscalerLabels(appName), existing.GetLabels()) {
+		return cleanUp, errors.NewAlreadyExists(nil, fmt.Sprintf("existing horizontal pod autoscaler %q found which does not belong to %q", spec.GetName(), appName))
+	}
+	_, err = k.updateHorizontalPodAutoscaler(spec)
+	return cleanUp, errors.Trace(err)
+}
+
+func (k *kubernetesClient) createHorizontalPodAutoscaler(hpa *autoscalingv1.HorizontalPodAutoscaler) (*autoscalingv1.HorizontalPodAutoscaler, error) {
+	purifyResource(hpa)
+	out, err := k.client().AutoscalingV1().HorizontalPodAutoscalers(k.namespace).Create(hpa)
+	if k8serrors.IsAlreadyExists(err) {
+		return nil, errors.AlreadyExistsf("horizontal pod autoscaler %q", hpa.GetName())
+	}
+	return out, errors.Trace(err)
+}
+
+func (k *kubernetesClient) getHorizontalPodAutoscaler(name string) (*autoscalingv1.HorizontalPodAutoscaler, error) {
+	out, err := k.client().AutoscalingV1().HorizontalPodAutoscalers(k.namespace).Get(name, v1.GetOptions{IncludeUninitialized: true})
+	if k8serrors.IsNotFound(err) {
+		return nil, errors.NotFoundf("horizontal pod autoscaler %q", name)
+	}
+	return out, errors.Trace(err)
+}
+
+func (k *kubernetesClient) updateHorizontalPodAutoscaler(hpa *autoscalingv1.HorizontalPodAutoscaler) (*autoscalingv1.HorizontalPodAutoscaler, error) {
+	out, err := k.client().AutoscalingV1().HorizontalPodAutoscalers(k.namespace).Update(hpa)
+	if k8serrors.IsNotFound(err) {
+		return nil, errors.NotFoundf("horizontal pod autoscaler %q", hpa.GetName())
+	}
+	return out, errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteHorizontalPodAutoscaler(name string, uid k8stypes.UID) error {
+	err := k.client().AutoscalingV1().HorizontalPodAutoscalers(k.namespace).Delete(name, newPreconditionDeleteOptions(uid))
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) listHorizontalPodAutoscalers(labels map[string]string) ([]autoscalingv1.HorizontalPodAutoscaler, error) {
+	listOps := v1.ListOptions{
+		LabelSelector:        labelsToSelector(labels),
+		IncludeUninitialized: true,
+	}
+	hpaList, err := k.client().AutoscalingV1().HorizontalPodAutoscalers(k.namespace).List(listOps)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(hpaList.Items) == 0 {
+		return nil, errors.NotFoundf("horizontal pod autoscaler with labels %v", labels)
+	}
+	return hpaList.Items, nil
+}
+
+func (k *kubernetesClient) deleteHorizontalPodAutoscalers(appName string) error {
+	err := k.client().AutoscalingV1().HorizontalPodAutoscalers(k.namespace).DeleteCollection(&v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	}, v1.ListOptions{
+		LabelSelector:        labelsToSelector(k.getHorizontalPodAutoscalerLabels(appName)),
+		IncludeUninitialized: true,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
@@ -0,0 +1,176 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	apps "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	core "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider"
+	k8sspecs "github.com/juju/juju/caas/kubernetes/provider/specs"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/testing"
+)
+
+func (s *K8sBrokerSuite) TestEnsureServiceHorizontalPodAutoscalersCreate(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	minReplicas := int32(2)
+	targetCPU := int32(80)
+	k8sResources := &k8sspecs.KubernetesResources{
+		HorizontalPodAutoscalers: []k8sspecs.K8sHorizontalPodAutoscalerSpec{{
+			Name: "app-name-hpa",
+			Spec: autoscalingv1.HorizontalPodAutoscalerSpec{
+				MinReplicas:                    &minReplicas,
+				MaxReplicas:                    10,
+				TargetCPUUtilizationPercentage: &targetCPU,
+			},
+		}},
+	}
+	hpa := &autoscalingv1.HorizontalPodAutoscaler{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "app-name-hpa",
+			Labels: map[string]string{"juju-app": "app-name"},
+			Annotations: map[string]string{
+				"juju.io/controller": testing.ControllerTag.Id(),
+			},
+		},
+		Spec: autoscalingv1.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv1.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "StatefulSet",
+				Name:       "app-name",
+			},
+			MinReplicas:                    &minReplicas,
+			MaxReplicas:                    10,
+			TargetCPUUtilizationPercentage: &targetCPU,
+		},
+	}
+	// The autoscaler is only created once the stateful set it scales exists.
+	s.assertKubernetesResources(c, k8sResources,
+		s.mockHorizontalPodAutoscalers.EXPECT().Create(hpa).Return(hpa, nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().List(v1.ListOptions{LabelSelector: "juju-app==app-name", IncludeUninitialized: true}).
+			Return(&autoscalingv1.HorizontalPodAutoscalerList{Items: []autoscalingv1.HorizontalPodAutoscaler{*hpa}}, nil),
+	)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceHorizontalPodAutoscalersKeepReplicas(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	k8sResources := &k8sspecs.KubernetesResources{
+		HorizontalPodAutoscalers: []k8sspecs.K8sHorizontalPodAutoscalerSpec{{
+			Name: "app-name-hpa",
+			Spec: autoscalingv1.HorizontalPodAutoscalerSpec{
+				MaxReplicas: 10,
+			},
+		}},
+	}
+	basicPodSpec := getBasicPodspec()
+	basicPodSpec.ProviderPod = &k8sspecs.K8sPodSpec{
+		KubernetesResources: k8sResources,
+	}
+	workloadSpec, err := provider.PrepareWorkloadSpec("app-name", "app-name", basicPodSpec, "operator/image-path")
+	c.Assert(err, jc.ErrorIsNil)
+	podSpec := provider.PodSpec(workloadSpec)
+
+	// The autoscaler has scaled the deployment to 5 pods,
+	// which is kept even though the application has 2 units.
+	scaled := int32(5)
+	deploymentArg := &apps.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "app-name",
+			Labels: map[string]string{"juju-app": "app-name"},
+			Annotations: map[string]string{
+				"juju.io/controller": testing.ControllerTag.Id(),
+			}},
+		Spec: apps.DeploymentSpec{
+			Replicas: &scaled,
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-app": "app-name"},
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: "app-name-",
+					Labels:       map[string]string{"juju-app": "app-name"},
+					Annotations: map[string]string{
+						"apparmor.security.beta.kubernetes.io/pod": "runtime/default",
+						"seccomp.security.beta.kubernetes.io/pod":  "docker/default",
+						"juju.io/controller":                       testing.ControllerTag.Id(),
+					},
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+	hpa := &autoscalingv1.HorizontalPodAutoscaler{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "app-name-hpa",
+			Labels: map[string]string{"juju-app": "app-name"},
+			Annotations: map[string]string{
+				"juju.io/controller": testing.ControllerTag.Id(),
+			},
+		},
+		Spec: autoscalingv1.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv1.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       "app-name",
+			},
+			MaxReplicas: 10,
+		},
+	}
+	serviceArg := *basicServiceArg
+	serviceArg.Spec.Type = core.ServiceTypeClusterIP
+
+	ociImageSecret := s.getOCIImageSecret(c, nil)
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().Create(ociImageSecret).
+			Return(ociImageSecret, nil),
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(&serviceArg).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Create(&serviceArg).
+			Return(nil, nil),
+		s.mockDeployments.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).
+			Return(&apps.Deployment{Spec: apps.DeploymentSpec{Replicas: &scaled}}, nil),
+		s.mockDeployments.EXPECT().Update(deploymentArg).
+			Return(deploymentArg, nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().Create(hpa).Return(hpa, nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().List(v1.ListOptions{LabelSelector: "juju-app==app-name", IncludeUninitialized: true}).
+			Return(&autoscalingv1.HorizontalPodAutoscalerList{Items: []autoscalingv1.HorizontalPodAutoscaler{*hpa}}, nil),
+	)
+	listOptions := v1.ListOptions{LabelSelector: "juju-app==app-name", IncludeUninitialized: true}
+	s.mockNetworkPolicies.EXPECT().List(listOptions).Return(&networkingv1.NetworkPolicyList{}, nil)
+	s.mockPodDisruptionBudgets.EXPECT().List(listOptions).Return(&policyv1beta1.PodDisruptionBudgetList{}, nil)
+
+	params := &caas.ServiceParams{
+		PodSpec:           basicPodSpec,
+		OperatorImagePath: "operator/image-path",
+		ResourceTags:      map[string]string{"juju-controller-uuid": testing.ControllerTag.Id()},
+	}
+	err = s.broker.EnsureService("app-name", func(_ string, _ status.Status, e string, _ map[string]interface{}) error {
+		c.Logf("EnsureService error -> %q", e)
+		return nil
+	}, params, 2, application.ConfigAttributes{
+		"kubernetes-service-loadbalancer-ip": "10.0.0.1",
+		"kubernetes-service-externalname":    "ext-name",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -16,12 +16,14 @@ import (
 	"time"
 
 	jujuclock "github.com/juju/clock"
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/arch"
 	"github.com/juju/version"
 	"gopkg.in/juju/names.v3"
 	apps "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	core "k8s.io/api/core/v1"
 	"k8s.io/api/extensions/v1beta1"
 	k8sstorage "k8s.io/api/storage/v1"
@@ -130,10 +132,13 @@ type kubernetesClient struct {
 // To regenerate the mocks for the kubernetes Client used by this broker,
 // run "go generate" from the package directory.
 //go:generate mockgen -package mocks -destination mocks/k8sclient_mock.go k8s.io/client-go/kubernetes Interface
-//go:generate mockgen -package mocks -destination mocks/appv1_mock.go k8s.io/client-go/kubernetes/typed/apps/v1 AppsV1Interface,DeploymentInterface,StatefulSetInterface
+//go:generate mockgen -package mocks -destination mocks/appv1_mock.go k8s.io/client-go/kubernetes/typed/apps/v1 AppsV1Interface,DaemonSetInterface,DeploymentInterface,StatefulSetInterface
 //go:generate mockgen -package mocks -destination mocks/corev1_mock.go k8s.io/client-go/kubernetes/typed/core/v1 EventInterface,CoreV1Interface,NamespaceInterface,PodInterface,ServiceInterface,ConfigMapInterface,PersistentVolumeInterface,PersistentVolumeClaimInterface,SecretInterface,NodeInterface
 //go:generate mockgen -package mocks -destination mocks/extenstionsv1_mock.go k8s.io/client-go/kubernetes/typed/extensions/v1beta1 ExtensionsV1beta1Interface,IngressInterface
 //go:generate mockgen -package mocks -destination mocks/storagev1_mock.go k8s.io/client-go/kubernetes/typed/storage/v1 StorageV1Interface,StorageClassInterface
+//go:generate mockgen -package mocks -destination mocks/networkingv1_mock.go k8s.io/client-go/kubernetes/typed/networking/v1 NetworkingV1Interface,NetworkPolicyInterface
+//go:generate mockgen -package mocks -destination mocks/policyv1beta1_mock.go k8s.io/client-go/kubernetes/typed/policy/v1beta1 PolicyV1beta1Interface,PodDisruptionBudgetInterface
+//go:generate mockgen -package mocks -destination mocks/autoscalingv1_mock.go k8s.io/client-go/kubernetes/typed/autoscaling/v1 AutoscalingV1Interface,HorizontalPodAutoscalerInterface
 //go:generate mockgen -package mocks -destination mocks/rbacv1_mock.go k8s.io/client-go/kubernetes/typed/rbac/v1 RbacV1Interface,ClusterRoleBindingInterface,ClusterRoleInterface,RoleInterface,RoleBindingInterface
 //go:generate mockgen -package mocks -destination mocks/apiextensions_mock.go k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1beta1 ApiextensionsV1beta1Interface,CustomResourceDefinitionInterface
 //go:generate mockgen -package mocks -destination mocks/apiextensionsclientset_mock.go -mock_names=Interface=MockApiExtensionsClientInterface k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset Interface
@@ -757,6 +762,28 @@ func (k *kubernetesClient) GetService(appName string, includeClusterIP bool) (*c
 			Status:  ssStatus,
 			Message: message,
 		}
+		return &result, nil
+	}
+
+	daemonSets := k.client().AppsV1().DaemonSets(k.namespace)
+	ds, err := daemonSets.Get(deploymentName, v1.GetOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	if err == nil {
+		// A daemon set runs a pod on each node it is scheduled to.
+		scale := int(ds.Status.DesiredNumberScheduled)
+		result.Scale = &scale
+		gen := ds.GetGeneration()
+		result.Generation = &gen
+		message, dsStatus, err := k.getDaemonSetStatus(ds)
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting status for %s", ds.Name)
+		}
+		result.Status = status.StatusInfo{
+			Status:  dsStatus,
+			Message: message,
+		}
 	}
 	return &result, nil
 }
@@ -778,6 +805,9 @@ func (k *kubernetesClient) DeleteService(appName string) (err error) {
 	if err := k.deleteDeployment(deploymentName); err != nil {
 		return errors.Trace(err)
 	}
+	if err := k.deleteDaemonSet(deploymentName); err != nil {
+		return errors.Trace(err)
+	}
 	if err := k.deleteSecrets(appName); err != nil {
 		return errors.Trace(err)
 	}
@@ -798,6 +828,15 @@ func (k *kubernetesClient) DeleteService(appName string) (err error) {
 	if err := k.deleteIngressResources(appName); err != nil {
 		return errors.Trace(err)
 	}
+	if err := k.deleteNetworkPolicies(appName); err != nil {
+		return errors.Trace(err)
+	}
+	if err := k.deletePodDisruptionBudgets(appName); err != nil {
+		return errors.Trace(err)
+	}
+	if err := k.deleteHorizontalPodAutoscalers(appName); err != nil {
+		return errors.Trace(err)
+	}
 	return nil
 }
 
@@ -1032,11 +1071,24 @@ func (k *kubernetesClient) EnsureService(
 		cleanups = append(cleanups, func() { k.deleteSecret(imageSecretName, "") })
 	}
 
-	// Add a deployment controller or stateful set configured to create the specified number of units/pods.
+	// Add a deployment controller, stateful set or daemon set configured to create the specified number of units/pods.
+	// A deployment type in the pod spec takes precedence over the one the application was deployed with.
 	// Defensively check to see if a stateful set is already used.
+	deploymentType := params.Deployment.DeploymentType
+	specDeploymentType := workloadSpec.Service != nil && workloadSpec.Service.DeploymentType != ""
+	if specDeploymentType {
+		deploymentType = workloadSpec.Service.DeploymentType
+		if charmType := params.Deployment.DeploymentType; charmType != "" && charmType != deploymentType {
+			return errors.NotValidf("%s deployment for charm with %s deployment", deploymentType, charmType)
+		}
+		if deploymentType != caas.DeploymentStateful && len(params.Filesystems) > 0 {
+			return errors.NotValidf("%s deployment with storage", deploymentType)
+		}
+	}
 	var useStatefulSet bool
-	if params.Deployment.DeploymentType != "" {
-		useStatefulSet = params.Deployment.DeploymentType == caas.DeploymentStateful
+	useDaemonSet := deploymentType == caas.DeploymentDaemon
+	if deploymentType != "" {
+		useStatefulSet = deploymentType == caas.DeploymentStateful
 	} else {
 		useStatefulSet = len(params.Filesystems) > 0
 	}
@@ -1045,7 +1097,17 @@ func (k *kubernetesClient) EnsureService(
 	if err != nil && !k8serrors.IsNotFound(err) {
 		return errors.Trace(err)
 	}
-	if !useStatefulSet {
+	if err != nil {
+		existingStatefulSet = nil
+	}
+	// The workload can't be changed to another kind once it exists,
+	// as the pods of the old one would be left running.
+	if specDeploymentType {
+		if err := k.checkDeploymentType(deploymentName, deploymentType, existingStatefulSet != nil); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if !useStatefulSet && !useDaemonSet {
 		useStatefulSet = err == nil
 		if useStatefulSet {
 			logger.Debugf("no updated filesystems but already using stateful set for %v", appName)
@@ -1100,7 +1162,44 @@ func (k *kubernetesClient) EnsureService(
 		}
 	}
 
+	workload := autoscalingv1.CrossVersionObjectReference{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       deploymentName,
+	}
+	if useStatefulSet {
+		workload.Kind = "StatefulSet"
+	} else if useDaemonSet {
+		workload.Kind = "DaemonSet"
+	}
+	if len(workloadSpec.HorizontalPodAutoscalers) > 0 && useDaemonSet {
+		return errors.NotSupportedf("horizontal pod autoscalers for daemon set %q", deploymentName)
+	}
+
 	numPods := int32(numUnits)
+	// A horizontal pod autoscaler owns the replica count of the workload
+	// it scales, so the count it has chosen is kept rather than being
+	// reset to the number of units.
+	if autoscalesWorkload(workload, workloadSpec.HorizontalPodAutoscalers) {
+		var replicas *int32
+		if useStatefulSet {
+			if existingStatefulSet != nil {
+				replicas = existingStatefulSet.Spec.Replicas
+			}
+		} else {
+			existing, err := k.client().AppsV1().Deployments(k.namespace).Get(deploymentName, v1.GetOptions{IncludeUninitialized: true})
+			if err != nil && !k8serrors.IsNotFound(err) {
+				return errors.Trace(err)
+			}
+			if err == nil {
+				replicas = existing.Spec.Replicas
+			}
+		}
+		if replicas != nil {
+			logger.Debugf("keeping %d replicas of %q chosen by its autoscaler", *replicas, deploymentName)
+			numPods = *replicas
+		}
+	}
 	if useStatefulSet {
 		if err := k.configureHeadlessService(appName, deploymentName, annotations.Copy()); err != nil {
 			return errors.Annotate(err, "creating or updating headless service")
@@ -1110,15 +1209,90 @@ func (k *kubernetesClient) EnsureService(
 			return errors.Annotate(err, "creating or updating StatefulSet")
 		}
 		cleanups = append(cleanups, func() { k.deleteDeployment(appName) })
+	} else if useDaemonSet {
+		if err := k.configureDaemonSet(appName, deploymentName, annotations.Copy(), workloadSpec, params.PodSpec.Containers); err != nil {
+			return errors.Annotate(err, "creating or updating DaemonSet")
+		}
+		cleanups = append(cleanups, func() { k.deleteDaemonSet(deploymentName) })
 	} else {
 		if err := k.configureDeployment(appName, deploymentName, annotations.Copy(), workloadSpec, params.PodSpec.Containers, &numPods); err != nil {
 			return errors.Annotate(err, "creating or updating DeploymentController")
 		}
 		cleanups = append(cleanups, func() { k.deleteDeployment(appName) })
 	}
+
+	// Network policies, pod disruption budgets and horizontal pod
+	// autoscalers are ensured even when the spec has none, so that
+	// those removed from the spec are deleted.
+
+	// ensure network policies.
+	npCleanUps, err := k.ensureNetworkPolicies(appName, annotations, workloadSpec.NetworkPolicies)
+	cleanups = append(cleanups, npCleanUps...)
+	if err != nil {
+		return errors.Annotate(err, "creating or updating network policies")
+	}
+	logger.Debugf("created/updated network policies for %q.", appName)
+
+	// ensure pod disruption budgets.
+	pdbCleanUps, err := k.ensurePodDisruptionBudgets(appName, annotations, workloadSpec.PodDisruptionBudgets)
+	cleanups = append(cleanups, pdbCleanUps...)
+	if err != nil {
+		return errors.Annotate(err, "creating or updating pod disruption budgets")
+	}
+	logger.Debugf("created/updated pod disruption budgets for %q.", appName)
+
+	// ensure horizontal pod autoscalers, once their default target exists.
+	hpaCleanUps, err := k.ensureHorizontalPodAutoscalers(appName, workload, annotations, workloadSpec.HorizontalPodAutoscalers)
+	cleanups = append(cleanups, hpaCleanUps...)
+	if err != nil {
+		return errors.Annotate(err, "creating or updating horizontal pod autoscalers")
+	}
+	logger.Debugf("created/updated horizontal pod autoscalers for %q.", appName)
 	return nil
 }
 
+// checkDeploymentType returns an error if a workload of a kind other than
+// the input deployment type already exists for the application.
+func (k *kubernetesClient) checkDeploymentType(deploymentName string, deploymentType caas.DeploymentType, hasStatefulSet bool) error {
+	existingType := caas.DeploymentType("")
+	if hasStatefulSet {
+		existingType = caas.DeploymentStateful
+	}
+	if existingType == "" {
+		_, err := k.client().AppsV1().Deployments(k.namespace).Get(deploymentName, v1.GetOptions{IncludeUninitialized: true})
+		if err == nil {
+			existingType = caas.DeploymentStateless
+		} else if !k8serrors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+	}
+	if existingType == "" {
+		_, err := k.client().AppsV1().DaemonSets(k.namespace).Get(deploymentName, v1.GetOptions{IncludeUninitialized: true})
+		if err == nil {
+			existingType = caas.DeploymentDaemon
+		} else if !k8serrors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+	}
+	if existingType != "" && existingType != deploymentType {
+		return errors.NotSupportedf("changing %s deployment %q to %s", existingType, deploymentName, deploymentType)
+	}
+	return nil
+}
+
+// autoscalesWorkload returns true if any of the horizontal pod autoscalers
+// scales the input workload. Autoscalers without a scale target scale the
+// application's workload.
+func autoscalesWorkload(workload autoscalingv1.CrossVersionObjectReference, hpaSpecs []k8sspecs.K8sHorizontalPodAutoscalerSpec) bool {
+	for _, v := range hpaSpecs {
+		target := v.Spec.ScaleTargetRef
+		if target.Name == "" || (target.Name == workload.Name && target.Kind == workload.Kind) {
+			return true
+		}
+	}
+	return false
+}
+
 func randomPrefix() (string, error) {
 	var randPrefixBytes [4]byte
 	if _, err := io.ReadFull(rand.Reader, randPrefixBytes[0:4]); err != nil {
@@ -1188,7 +1362,9 @@ func (k *kubernetesClient) deleteAllPods(appName, deploymentName string) error {
 	deployments := k.client().AppsV1().Deployments(k.namespace)
 	deployment, err := deployments.Get(deploymentName, v1.GetOptions{IncludeUninitialized: true})
 	if k8serrors.IsNotFound(err) {
-		return nil
+		// A daemon set can't be scaled, so remove it instead;
+		// it is created again when units are added.
+		return errors.Trace(k.deleteDaemonSet(deploymentName))
 	}
 	if err != nil {
 		return errors.Trace(err)
@@ -1495,6 +1671,65 @@ func (k *kubernetesClient) deleteDeployment(name string) error {
 	return errors.Trace(err)
 }
 
+func (k *kubernetesClient) configureDaemonSet(
+	appName, deploymentName string,
+	annotations k8sannotations.Annotation,
+	workloadSpec *workloadSpec,
+	containers []specs.ContainerSpec,
+) error {
+	logger.Debugf("creating/updating daemon set for %s", appName)
+
+	// Add the specified file to the pod spec.
+	cfgName := func(fileSetName string) string {
+		return applicationConfigMapName(deploymentName, fileSetName)
+	}
+	podSpec := workloadSpec.Pod
+	if err := k.configurePodFiles(appName, annotations, &podSpec, containers, cfgName); err != nil {
+		return errors.Trace(err)
+	}
+
+	daemonSet := &apps.DaemonSet{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        deploymentName,
+			Labels:      map[string]string{labelApplication: appName},
+			Annotations: annotations.ToMap()},
+		Spec: apps.DaemonSetSpec{
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{labelApplication: appName},
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: deploymentName + "-",
+					Labels:       map[string]string{labelApplication: appName},
+					Annotations:  podAnnotations(annotations.Copy()).ToMap(),
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+	return k.ensureDaemonSet(daemonSet)
+}
+
+func (k *kubernetesClient) ensureDaemonSet(spec *apps.DaemonSet) error {
+	daemonSets := k.client().AppsV1().DaemonSets(k.namespace)
+	_, err := daemonSets.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = daemonSets.Create(spec)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteDaemonSet(name string) error {
+	daemonSets := k.client().AppsV1().DaemonSets(k.namespace)
+	err := daemonSets.Delete(name, &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
 func getPodManagementPolicy(svc *specs.ServiceSpec) (out apps.PodManagementPolicyType) {
 	// default to "Parallel".
 	out = apps.ParallelPodManagement
@@ -1512,6 +1747,19 @@ func getPodManagementPolicy(svc *specs.ServiceSpec) (out apps.PodManagementPolic
 	return out
 }
 
+func getStatefulSetUpdateStrategy(svc *specs.ServiceSpec) (out apps.StatefulSetUpdateStrategy) {
+	if svc == nil || svc.UpdateStrategy == nil {
+		return out
+	}
+	// Pods are replaced in reverse ordinal order, down to the partition.
+	return apps.StatefulSetUpdateStrategy{
+		Type: apps.RollingUpdateStatefulSetStrategyType,
+		RollingUpdate: &apps.RollingUpdateStatefulSetStrategy{
+			Partition: svc.UpdateStrategy.Partition,
+		},
+	}
+}
+
 func (k *kubernetesClient) configureStatefulSet(
 	appName, deploymentName, randPrefix string, annotations k8sannotations.Annotation, workloadSpec *workloadSpec,
 	containers []specs.ContainerSpec, replicas *int32, filesystems []storage.KubernetesFilesystemParams,
@@ -1542,6 +1790,7 @@ func (k *kubernetesClient) configureStatefulSet(
 				},
 			},
 			PodManagementPolicy: getPodManagementPolicy(workloadSpec.Service),
+			UpdateStrategy:      getStatefulSetUpdateStrategy(workloadSpec.Service),
 			ServiceName:         headlessServiceName(deploymentName),
 		},
 	}
@@ -1580,6 +1829,7 @@ func (k *kubernetesClient) ensureStatefulSet(spec *apps.StatefulSet, existingPod
 	}
 	// TODO(caas) - allow extra storage to be added
 	existing.Spec.Replicas = spec.Spec.Replicas
+	existing.Spec.UpdateStrategy = spec.Spec.UpdateStrategy
 	existing.Spec.Template.Spec.Containers = existingPodSpec.Containers
 	existing.Spec.Template.Spec.ServiceAccountName = existingPodSpec.ServiceAccountName
 	existing.Spec.Template.Spec.AutomountServiceAccountToken = existingPodSpec.AutomountServiceAccountToken
@@ -1982,9 +2232,9 @@ func (k *kubernetesClient) WatchContainerStart(appName string, containerName str
 // WatchService returns a watcher which notifies when there
 // are changes to the deployment of the specified application.
 func (k *kubernetesClient) WatchService(appName string) (watcher.NotifyWatcher, error) {
-	// Application may be a statefulset or deployment. It may not have
-	// been set up when the watcher is started so we don't know which it
-	// is ahead of time. So use a multi-watcher to cover both cases.
+	// Application may be a statefulset, deployment or daemonset. It may not
+	// have been set up when the watcher is started so we don't know which it
+	// is ahead of time. So use a multi-watcher to cover all cases.
 	statefulsets := k.client().AppsV1().StatefulSets(k.namespace)
 	sswatcher, err := statefulsets.Watch(v1.ListOptions{
 		LabelSelector: applicationSelector(appName),
@@ -2011,7 +2261,20 @@ func (k *kubernetesClient) WatchService(appName string) (watcher.NotifyWatcher,
 		return nil, errors.Trace(err)
 	}
 
-	return watcher.NewMultiNotifyWatcher(w1, w2), nil
+	daemonSets := k.client().AppsV1().DaemonSets(k.namespace)
+	dswatcher, err := daemonSets.Watch(v1.ListOptions{
+		LabelSelector: applicationSelector(appName),
+		Watch:         true,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	w3, err := k.newWatcher(dswatcher, appName, k.clock)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return watcher.NewMultiNotifyWatcher(w1, w2, w3), nil
 }
 
 // legacyJujuPVNameRegexp matches how Juju labels persistent volumes.
@@ -2248,6 +2511,17 @@ func (k *kubernetesClient) getPODStatus(pod core.Pod, now time.Time) (string, st
 			}
 		}
 	}
+	if !terminated {
+		// A container which can't be started won't recover by
+		// itself, so report it rather than leaving the unit waiting.
+		if reason, message := blockedContainerReason(pod); reason != "" {
+			jujuStatus = status.Blocked
+			statusMessage = reason
+			if message != "" {
+				statusMessage += ": " + message
+			}
+		}
+	}
 
 	if statusMessage == "" {
 		// If there are any events for this pod we can use the
@@ -2265,6 +2539,32 @@ func (k *kubernetesClient) getPODStatus(pod core.Pod, now time.Time) (string, st
 	return statusMessage, jujuStatus, since, nil
 }
 
+// blockedContainerReasons are the reasons a container may be waiting
+// which require a change to the workload, or its image, to resolve.
+var blockedContainerReasons = set.NewStrings(
+	"ErrImagePull",
+	"ImagePullBackOff",
+	"InvalidImageName",
+	"CrashLoopBackOff",
+	"CreateContainerConfigError",
+	"CreateContainerError",
+)
+
+// blockedContainerReason returns the reason and message of the
+// first container in the pod which is blocked from running.
+func blockedContainerReason(pod core.Pod) (string, string) {
+	var statuses []core.ContainerStatus
+	statuses = append(statuses, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+	for _, cs := range statuses {
+		waiting := cs.State.Waiting
+		if waiting != nil && blockedContainerReasons.Contains(waiting.Reason) {
+			return waiting.Reason, waiting.Message
+		}
+	}
+	return "", ""
+}
+
 func (k *kubernetesClient) getStatefulSetStatus(ss *apps.StatefulSet) (string, status.Status, error) {
 	terminated := ss.DeletionTimestamp != nil
 	jujuStatus := status.Waiting
@@ -2289,6 +2589,18 @@ func (k *kubernetesClient) getDeploymentStatus(deployment *apps.Deployment) (str
 	return k.getStatusFromEvents(deployment.Name, "Deployment", jujuStatus)
 }
 
+func (k *kubernetesClient) getDaemonSetStatus(ds *apps.DaemonSet) (string, status.Status, error) {
+	terminated := ds.DeletionTimestamp != nil
+	jujuStatus := status.Waiting
+	if terminated {
+		jujuStatus = status.Terminated
+	}
+	if ds.Status.NumberReady == ds.Status.DesiredNumberScheduled {
+		jujuStatus = status.Active
+	}
+	return k.getStatusFromEvents(ds.Name, "DaemonSet", jujuStatus)
+}
+
 func (k *kubernetesClient) getStatusFromEvents(name, kind string, jujuStatus status.Status) (string, status.Status, error) {
 	events, err := k.getEvents(name, kind)
 	if err != nil {
@@ -2298,7 +2610,7 @@ func (k *kubernetesClient) getStatusFromEvents(name, kind string, jujuStatus sta
 	// Take the most recent event.
 	if count := len(events); count > 0 {
 		evt := events[count-1]
-		if jujuStatus == "" {
+		if jujuStatus != status.Active && jujuStatus != status.Terminated {
 			if evt.Type == core.EventTypeWarning && evt.Reason == "FailedCreate" {
 				jujuStatus = status.Blocked
 				statusMessage = evt.Message
@@ -2380,6 +2692,9 @@ type workloadSpec struct {
 	CustomResourceDefinitions map[string]apiextensionsv1beta1.CustomResourceDefinitionSpec
 	CustomResources           map[string][]unstructured.Unstructured
 	IngressResources          []k8sspecs.K8sIngressSpec
+	NetworkPolicies           []k8sspecs.K8sNetworkPolicySpec
+	PodDisruptionBudgets      []k8sspecs.K8sPodDisruptionBudgetSpec
+	HorizontalPodAutoscalers  []k8sspecs.K8sHorizontalPodAutoscalerSpec
 }
 
 func processContainers(deploymentName string, podSpec *specs.PodSpec, spec *core.PodSpec) error {
@@ -2452,6 +2767,9 @@ func prepareWorkloadSpec(appName, deploymentName string, podSpec *specs.PodSpec,
 			spec.CustomResourceDefinitions = k8sResources.CustomResourceDefinitions
 			spec.CustomResources = k8sResources.CustomResources
 			spec.IngressResources = k8sResources.IngressResources
+			spec.NetworkPolicies = k8sResources.NetworkPolicies
+			spec.PodDisruptionBudgets = k8sResources.PodDisruptionBudgets
+			spec.HorizontalPodAutoscalers = k8sResources.HorizontalPodAutoscalers
 			if k8sResources.Pod != nil {
 				spec.Pod.ActiveDeadlineSeconds = k8sResources.Pod.ActiveDeadlineSeconds
 				spec.Pod.TerminationGracePeriodSeconds = k8sResources.Pod.TerminationGracePeriodSeconds
@@ -2501,6 +2819,19 @@ func populateContainerDetails(deploymentName string, pod *core.PodSpec, podConta
 			pc.ImagePullPolicy = core.PullPolicy(c.ImagePullPolicy)
 		}
 
+		pc.EnvFrom = k8sspecs.ToK8sEnvFromSources(c.EnvFrom)
+		if c.Probes != nil {
+			pc.LivenessProbe = k8sspecs.ToK8sProbe(c.Probes.Liveness)
+			pc.ReadinessProbe = k8sspecs.ToK8sProbe(c.Probes.Readiness)
+		}
+		if c.Resources != nil {
+			resources, err := k8sspecs.ToK8sResourceRequirements(c.Resources)
+			if err != nil {
+				return errors.Annotatef(err, "container %q", c.Name)
+			}
+			pc.Resources = resources
+		}
+
 		pc.SecurityContext = defaultSecurityContext()
 		if c.ProviderContainer == nil {
 			continue
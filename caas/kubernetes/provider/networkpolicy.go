@@ -0,0 +1,155 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	k8sspecs "github.com/juju/juju/caas/kubernetes/provider/specs"
+	k8sannotations "github.com/juju/juju/core/annotations"
+)
+
+func (k *kubernetesClient) getNetworkPolicyLabels(appName string) map[string]string {
+	return map[string]string{
+		labelApplication: appName,
+	}
+}
+
+// ensureNetworkPolicies creates or updates the network policies in the
+// spec, and deletes any others previously created for the application.
+func (k *kubernetesClient) ensureNetworkPolicies(
+	appName string, annotations k8sannotations.Annotation, npSpecs []k8sspecs.K8sNetworkPolicySpec,
+) (cleanUps []func(), err error) {
+	wanted := set.NewStrings()
+	for _, v := range npSpecs {
+		spec := v.Spec
+		if len(spec.PodSelector.MatchLabels) == 0 && len(spec.PodSelector.MatchExpressions) == 0 {
+			spec.PodSelector = v1.LabelSelector{
+				MatchLabels: map[string]string{labelApplication: appName},
+			}
+		}
+		np := &networkingv1.NetworkPolicy{
+			ObjectMeta: v1.ObjectMeta{
+				Name:        v.Name,
+				Labels:      k8slabels.Merge(v.Labels, k.getNetworkPolicyLabels(appName)),
+				Annotations: k8sannotations.New(v.Annotations).Merge(annotations),
+			},
+			Spec: spec,
+		}
+		cleanUp, err := k.ensureNetworkPolicy(appName, np)
+		cleanUps = append(cleanUps, cleanUp)
+		if err != nil {
+			return cleanUps, errors.Trace(err)
+		}
+		wanted.Add(v.Name)
+	}
+
+	existing, err := k.listNetworkPolicies(k.getNetworkPolicyLabels(appName))
+	if errors.IsNotFound(err) {
+		return cleanUps, nil
+	}
+	if err != nil {
+		return cleanUps, errors.Trace(err)
+	}
+	for _, np := range existing {
+		if wanted.Contains(np.GetName()) {
+			continue
+		}
+		logger.Debugf("deleting network policy %q no longer in the spec for %q", np.GetName(), appName)
+		if err := k.deleteNetworkPolicy(np.GetName(), np.GetUID()); err != nil {
+			return cleanUps, errors.Trace(err)
+		}
+	}
+	return cleanUps, nil
+}
+
+func (k *kubernetesClient) ensureNetworkPolicy(appName string, spec *networkingv1.NetworkPolicy) (func(), error) {
+	cleanUp := func() {}
+	out, err := k.createNetworkPolicy(spec)
+	if err == nil {
+		cleanUp = func() { _ = k.deleteNetworkPolicy(out.GetName(), out.GetUID()) }
+		return cleanUp, nil
+	}
+	if !errors.IsAlreadyExists(err) {
+		return cleanUp, errors.Trace(err)
+	}
+	existing, err := k.getNetworkPolicy(spec.GetName())
+	if err != nil {
+		return cleanUp, errors.Trace(err)
+	}
+	if len(existing.GetLabels()) == 0 || !k8slabels.AreLabelsInWhiteList(k.getNetworkPolicyLabels(appName), existing.GetLabels()) {
+		return cleanUp, errors.NewAlreadyExists(nil, fmt.Sprintf("existing network policy %q found which does not belong to %q", spec.GetName(), appName))
+	}
+	_, err = k.updateNetworkPolicy(spec)
+	return cleanUp, errors.Trace(err)
+}
+
+func (k *kubernetesClient) createNetworkPolicy(np *networkingv1.NetworkPolicy) (*networkingv1.NetworkPolicy, error) {
+	purifyResource(np)
+	out, err := k.client().NetworkingV1().NetworkPolicies(k.namespace).Create(np)
+	if k8serrors.IsAlreadyExists(err) {
+		return nil, errors.AlreadyExistsf("network policy %q", np.GetName())
+	}
+	return out, errors.Trace(err)
+}
+
+func (k *kubernetesClient) getNetworkPolicy(name string) (*networkingv1.NetworkPolicy, error) {
+	out, err := k.client().NetworkingV1().NetworkPolicies(k.namespace).Get(name, v1.GetOptions{IncludeUninitialized: true})
+	if k8serrors.IsNotFound(err) {
+		return nil, errors.NotFoundf("network policy %q", name)
+	}
+	return out, errors.Trace(err)
+}
+
+func (k *kubernetesClient) updateNetworkPolicy(np *networkingv1.NetworkPolicy) (*networkingv1.NetworkPolicy, error) {
+	out, err := k.client().NetworkingV1().NetworkPolicies(k.namespace).Update(np)
+	if k8serrors.IsNotFound(err) {
+		return nil, errors.NotFoundf("network policy %q", np.GetName())
+	}
+	return out, errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteNetworkPolicy(name string, uid k8stypes.UID) error {
+	err := k.client().NetworkingV1().NetworkPolicies(k.namespace).Delete(name, newPreconditionDeleteOptions(uid))
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) listNetworkPolicies(labels map[string]string) ([]networkingv1.NetworkPolicy, error) {
+	listOps := v1.ListOptions{
+		LabelSelector:        labelsToSelector(labels),
+		IncludeUninitialized: true,
+	}
+	npList, err := k.client().NetworkingV1().NetworkPolicies(k.namespace).List(listOps)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(npList.Items) == 0 {
+		return nil, errors.NotFoundf("network policy with labels %v", labels)
+	}
+	return npList.Items, nil
+}
+
+func (k *kubernetesClient) deleteNetworkPolicies(appName string) error {
+	err := k.client().NetworkingV1().NetworkPolicies(k.namespace).DeleteCollection(&v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	}, v1.ListOptions{
+		LabelSelector:        labelsToSelector(k.getNetworkPolicyLabels(appName)),
+		IncludeUninitialized: true,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
@@ -0,0 +1,269 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	apps "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	core "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider"
+	k8sspecs "github.com/juju/juju/caas/kubernetes/provider/specs"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/testing"
+)
+
+// assertKubernetesResources ensures a stateful application whose spec has
+// the given kubernetes resources. The assertCalls are expected in order
+// after the application's workload is created. Network policies, pod
+// disruption budgets and horizontal pod autoscalers are expected to be
+// listed, and none found, for each kind that the spec has none of.
+func (s *K8sBrokerSuite) assertKubernetesResources(
+	c *gc.C, k8sResources *k8sspecs.KubernetesResources, assertCalls ...*gomock.Call,
+) {
+	listOptions := v1.ListOptions{LabelSelector: "juju-app==app-name", IncludeUninitialized: true}
+	if len(k8sResources.NetworkPolicies) == 0 {
+		s.mockNetworkPolicies.EXPECT().List(listOptions).Return(&networkingv1.NetworkPolicyList{}, nil)
+	}
+	if len(k8sResources.PodDisruptionBudgets) == 0 {
+		s.mockPodDisruptionBudgets.EXPECT().List(listOptions).Return(&policyv1beta1.PodDisruptionBudgetList{}, nil)
+	}
+	if len(k8sResources.HorizontalPodAutoscalers) == 0 {
+		s.mockHorizontalPodAutoscalers.EXPECT().List(listOptions).Return(&autoscalingv1.HorizontalPodAutoscalerList{}, nil)
+	}
+	s.ensureKubernetesResources(c, k8sResources, assertCalls...)
+}
+
+// ensureKubernetesResources ensures a stateful application whose spec has
+// the given kubernetes resources. The assertCalls are expected in order
+// after the application's workload is created.
+func (s *K8sBrokerSuite) ensureKubernetesResources(
+	c *gc.C, k8sResources *k8sspecs.KubernetesResources, assertCalls ...*gomock.Call,
+) {
+	basicPodSpec := getBasicPodspec()
+	basicPodSpec.ProviderPod = &k8sspecs.K8sPodSpec{
+		KubernetesResources: k8sResources,
+	}
+	workloadSpec, err := provider.PrepareWorkloadSpec("app-name", "app-name", basicPodSpec, "operator/image-path")
+	c.Assert(err, jc.ErrorIsNil)
+	podSpec := provider.PodSpec(workloadSpec)
+
+	numUnits := int32(2)
+	statefulSetArg := &apps.StatefulSet{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "app-name",
+			Annotations: map[string]string{
+				"juju-app-uuid":      "appuuid",
+				"juju.io/controller": testing.ControllerTag.Id(),
+			},
+		},
+		Spec: apps.StatefulSetSpec{
+			Replicas: &numUnits,
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-app": "app-name"},
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					Labels: map[string]string{"juju-app": "app-name"},
+					Annotations: map[string]string{
+						"apparmor.security.beta.kubernetes.io/pod": "runtime/default",
+						"seccomp.security.beta.kubernetes.io/pod":  "docker/default",
+						"juju.io/controller":                       testing.ControllerTag.Id(),
+					},
+				},
+				Spec: podSpec,
+			},
+			PodManagementPolicy: apps.ParallelPodManagement,
+			ServiceName:         "app-name-endpoints",
+		},
+	}
+
+	serviceArg := *basicServiceArg
+	serviceArg.Spec.Type = core.ServiceTypeClusterIP
+
+	ociImageSecret := s.getOCIImageSecret(c, nil)
+	workloadCalls := []*gomock.Call{
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().Create(ociImageSecret).
+			Return(ociImageSecret, nil),
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(&serviceArg).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Create(&serviceArg).
+			Return(nil, nil),
+		s.mockServices.EXPECT().Get("app-name-endpoints", v1.GetOptions{IncludeUninitialized: true}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(basicHeadlessServiceArg).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Create(basicHeadlessServiceArg).
+			Return(nil, nil),
+		s.mockStatefulSets.EXPECT().Update(statefulSetArg).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Create(statefulSetArg).
+			Return(nil, nil),
+	}
+	gomock.InOrder(append(workloadCalls, assertCalls...)...)
+
+	params := &caas.ServiceParams{
+		PodSpec: basicPodSpec,
+		Deployment: caas.DeploymentParams{
+			DeploymentType: caas.DeploymentStateful,
+		},
+		OperatorImagePath: "operator/image-path",
+		ResourceTags:      map[string]string{"juju-controller-uuid": testing.ControllerTag.Id()},
+	}
+	err = s.broker.EnsureService("app-name", func(_ string, _ status.Status, e string, _ map[string]interface{}) error {
+		c.Logf("EnsureService error -> %q", e)
+		return nil
+	}, params, 2, application.ConfigAttributes{
+		"kubernetes-service-loadbalancer-ip": "10.0.0.1",
+		"kubernetes-service-externalname":    "ext-name",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+// expectNoPolicyResources expects the application's network policies,
+// pod disruption budgets and horizontal pod autoscalers to be listed,
+// and none to be found.
+func (s *K8sBrokerSuite) expectNoPolicyResources(appName string) {
+	listOptions := v1.ListOptions{LabelSelector: "juju-app==" + appName, IncludeUninitialized: true}
+	s.mockNetworkPolicies.EXPECT().List(listOptions).Return(&networkingv1.NetworkPolicyList{}, nil)
+	s.mockPodDisruptionBudgets.EXPECT().List(listOptions).Return(&policyv1beta1.PodDisruptionBudgetList{}, nil)
+	s.mockHorizontalPodAutoscalers.EXPECT().List(listOptions).Return(&autoscalingv1.HorizontalPodAutoscalerList{}, nil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceNetworkPoliciesCreate(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	ingressRule := networkingv1.NetworkPolicyIngressRule{
+		From: []networkingv1.NetworkPolicyPeer{{
+			PodSelector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-app": "frontend"},
+			},
+		}},
+	}
+	k8sResources := &k8sspecs.KubernetesResources{
+		NetworkPolicies: []k8sspecs.K8sNetworkPolicySpec{{
+			Name:   "allow-frontend",
+			Labels: map[string]string{"foo": "bar"},
+			Spec: networkingv1.NetworkPolicySpec{
+				Ingress:     []networkingv1.NetworkPolicyIngressRule{ingressRule},
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			},
+		}},
+	}
+	np := &networkingv1.NetworkPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "allow-frontend",
+			Labels: map[string]string{
+				"foo":      "bar",
+				"juju-app": "app-name",
+			},
+			Annotations: map[string]string{
+				"juju.io/controller": testing.ControllerTag.Id(),
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-app": "app-name"},
+			},
+			Ingress:     []networkingv1.NetworkPolicyIngressRule{ingressRule},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		},
+	}
+	stale := networkingv1.NetworkPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "deny-all",
+			UID:    "deny-all-uid",
+			Labels: map[string]string{"juju-app": "app-name"},
+		},
+	}
+	s.assertKubernetesResources(c, k8sResources,
+		s.mockNetworkPolicies.EXPECT().Create(np).Return(np, nil),
+		s.mockNetworkPolicies.EXPECT().List(v1.ListOptions{LabelSelector: "juju-app==app-name", IncludeUninitialized: true}).
+			Return(&networkingv1.NetworkPolicyList{Items: []networkingv1.NetworkPolicy{*np, stale}}, nil),
+		s.mockNetworkPolicies.EXPECT().Delete("deny-all", s.deleteOptions(v1.DeletePropagationForeground, "deny-all-uid")).
+			Return(nil),
+	)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceNetworkPoliciesUpdate(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	podSelector := v1.LabelSelector{
+		MatchLabels: map[string]string{"tier": "db"},
+	}
+	k8sResources := &k8sspecs.KubernetesResources{
+		NetworkPolicies: []k8sspecs.K8sNetworkPolicySpec{{
+			Name: "deny-egress",
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: podSelector,
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+			},
+		}},
+	}
+	np := &networkingv1.NetworkPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "deny-egress",
+			Labels: map[string]string{"juju-app": "app-name"},
+			Annotations: map[string]string{
+				"juju.io/controller": testing.ControllerTag.Id(),
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: podSelector,
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+		},
+	}
+	s.assertKubernetesResources(c, k8sResources,
+		s.mockNetworkPolicies.EXPECT().Create(np).Return(nil, s.k8sAlreadyExistsError()),
+		s.mockNetworkPolicies.EXPECT().Get("deny-egress", v1.GetOptions{IncludeUninitialized: true}).Return(np, nil),
+		s.mockNetworkPolicies.EXPECT().Update(np).Return(np, nil),
+		s.mockNetworkPolicies.EXPECT().List(v1.ListOptions{LabelSelector: "juju-app==app-name", IncludeUninitialized: true}).
+			Return(&networkingv1.NetworkPolicyList{Items: []networkingv1.NetworkPolicy{*np}}, nil),
+	)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceRemovesAllPolicyResources(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	meta := func(name string) v1.ObjectMeta {
+		return v1.ObjectMeta{
+			Name:   name,
+			UID:    k8stypes.UID(name + "-uid"),
+			Labels: map[string]string{"juju-app": "app-name"},
+		}
+	}
+	listOptions := v1.ListOptions{LabelSelector: "juju-app==app-name", IncludeUninitialized: true}
+	s.ensureKubernetesResources(c, &k8sspecs.KubernetesResources{},
+		s.mockNetworkPolicies.EXPECT().List(listOptions).
+			Return(&networkingv1.NetworkPolicyList{Items: []networkingv1.NetworkPolicy{{ObjectMeta: meta("deny-all")}}}, nil),
+		s.mockNetworkPolicies.EXPECT().Delete("deny-all", s.deleteOptions(v1.DeletePropagationForeground, "deny-all-uid")).
+			Return(nil),
+		s.mockPodDisruptionBudgets.EXPECT().List(listOptions).
+			Return(&policyv1beta1.PodDisruptionBudgetList{Items: []policyv1beta1.PodDisruptionBudget{{ObjectMeta: meta("app-name-pdb")}}}, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name-pdb", s.deleteOptions(v1.DeletePropagationForeground, "app-name-pdb-uid")).
+			Return(nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().List(listOptions).
+			Return(&autoscalingv1.HorizontalPodAutoscalerList{Items: []autoscalingv1.HorizontalPodAutoscaler{{ObjectMeta: meta("app-name-hpa")}}}, nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().Delete("app-name-hpa", s.deleteOptions(v1.DeletePropagationForeground, "app-name-hpa-uid")).
+			Return(nil),
+	)
+}
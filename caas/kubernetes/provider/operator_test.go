@@ -778,6 +778,62 @@ func (s *K8sBrokerSuite) TestOperator(c *gc.C) {
 	c.Assert(operator.Config.OperatorInfo, gc.DeepEquals, []byte("operator-info-data"))
 }
 
+func (s *K8sBrokerSuite) TestOperatorImagePullBackOff(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	opPod := core.Pod{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "test-operator",
+		},
+		Status: core.PodStatus{
+			Phase: core.PodPending,
+			ContainerStatuses: []core.ContainerStatus{{
+				Name: "juju-operator",
+				State: core.ContainerState{
+					Waiting: &core.ContainerStateWaiting{
+						Reason:  "ImagePullBackOff",
+						Message: `Back-off pulling image "test-image"`,
+					},
+				},
+			}},
+		},
+	}
+	ss := apps.StatefulSet{
+		ObjectMeta: v1.ObjectMeta{
+			Annotations: map[string]string{
+				"juju-version":       "2.99.0",
+				"juju.io/controller": testing.ControllerTag.Id(),
+			},
+		},
+		Spec: apps.StatefulSetSpec{
+			Template: core.PodTemplateSpec{
+				Spec: core.PodSpec{
+					Containers: []core.Container{{
+						Name:  "juju-operator",
+						Image: "test-image",
+					}},
+				},
+			},
+		},
+	}
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-test", v1.GetOptions{IncludeUninitialized: true}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Get("test-operator", v1.GetOptions{IncludeUninitialized: true}).
+			Return(&ss, nil),
+		s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-operator==test"}).
+			Return(&core.PodList{Items: []core.Pod{opPod}}, nil),
+		s.mockConfigMaps.EXPECT().Get("test-operator-config", v1.GetOptions{IncludeUninitialized: true}).
+			Return(&core.ConfigMap{}, nil),
+	)
+
+	operator, err := s.broker.Operator("test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operator.Status.Status, gc.Equals, status.Blocked)
+	c.Assert(operator.Status.Message, gc.Equals, `ImagePullBackOff: Back-off pulling image "test-image"`)
+}
+
 func (s *K8sBrokerSuite) TestOperatorNoPodFound(c *gc.C) {
 	ctrl := s.setupController(c)
 	defer ctrl.Finish()
@@ -0,0 +1,174 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	k8sspecs "github.com/juju/juju/caas/kubernetes/provider/specs"
+	k8sannotations "github.com/juju/juju/core/annotations"
+)
+
+func (k *kubernetesClient) getPodDisruptionBudgetLabels(appName string) map[string]string {
+	return map[string]string{
+		labelApplication: appName,
+	}
+}
+
+// ensurePodDisruptionBudgets creates or updates the pod disruption budgets in the
+// spec, and deletes any others previously created for the application.
+func (k *kubernetesClient) ensurePodDisruptionBudgets(
+	appName string, annotations k8sannotations.Annotation, pdbSpecs []k8sspecs.K8sPodDisruptionBudgetSpec,
+) (cleanUps []func(), err error) {
+	wanted := set.NewStrings()
+	for _, v := range pdbSpecs {
+		spec := v.Spec
+		if spec.Selector == nil {
+			spec.Selector = &v1.LabelSelector{
+				MatchLabels: map[string]string{labelApplication: appName},
+			}
+		}
+		pdb := &policyv1beta1.PodDisruptionBudget{
+			ObjectMeta: v1.ObjectMeta{
+				Name:        v.Name,
+				Labels:      k8slabels.Merge(v.Labels, k.getPodDisruptionBudgetLabels(appName)),
+				Annotations: k8sannotations.New(v.Annotations).Merge(annotations),
+			},
+			Spec: spec,
+		}
+		cleanUp, err := k.ensurePodDisruptionBudget(appName, pdb)
+		cleanUps = append(cleanUps, cleanUp)
+		if err != nil {
+			return cleanUps, errors.Trace(err)
+		}
+		wanted.Add(v.Name)
+	}
+
+	existing, err := k.listPodDisruptionBudgets(k.getPodDisruptionBudgetLabels(appName))
+	if errors.IsNotFound(err) {
+		return cleanUps, nil
+	}
+	if err != nil {
+		return cleanUps, errors.Trace(err)
+	}
+	for _, pdb := range existing {
+		if wanted.Contains(pdb.GetName()) {
+			continue
+		}
+		logger.Debugf("deleting pod disruption budget %q no longer in the spec for %q", pdb.GetName(), appName)
+		if err := k.deletePodDisruptionBudget(pdb.GetName(), pdb.GetUID()); err != nil {
+			return cleanUps, errors.Trace(err)
+		}
+	}
+	return cleanUps, nil
+}
+
+func (k *kubernetesClient) ensurePodDisruptionBudget(appName string, spec *policyv1beta1.PodDisruptionBudget) (func(), error) {
+	cleanUp := func() {}
+	out, err := k.createPodDisruptionBudget(spec)
+	if err == nil {
+		cleanUp = func() { _ = k.deletePodDisruptionBudget(out.GetName(), out.GetUID()) }
+		return cleanUp, nil
+	}
+	if !errors.IsAlreadyExists(err) {
+		return cleanUp, errors.Trace(err)
+	}
+	existing, err := k.getPodDisruptionBudget(spec.GetName())
+	if err != nil {
+		return cleanUp, errors.Trace(err)
+	}
+	if len(existing.GetLabels()) == 0 || !k8slabels.AreLabelsInWhiteList(k.getPodDisruptionBudgetLabels(appName), existing.GetLabels()) {
+		return cleanUp, errors.NewAlreadyExists(nil, fmt.Sprintf("existing pod disruption budget %q found which does not belong to %q", spec.GetName(), appName))
+	}
+	// Pod disruption budgets can only be updated from the version read.
+	spec.SetResourceVersion(existing.GetResourceVersion())
+	_, err = k.updatePodDisruptionBudget(spec)
+	if !errors.IsNotValid(err) {
+		return cleanUp, errors.Trace(err)
+	}
+	// Clusters older than 1.15 refuse changes to the spec of a pod
+	// disruption budget, so it is replaced instead.
+	logger.Debugf("replacing pod disruption budget %q for %q", spec.GetName(), appName)
+	if err := k.deletePodDisruptionBudget(existing.GetName(), existing.GetUID()); err != nil {
+		return cleanUp, errors.Trace(err)
+	}
+	out, err := k.createPodDisruptionBudget(spec)
+	if err != nil {
+		return cleanUp, errors.Trace(err)
+	}
+	cleanUp = func() { _ = k.deletePodDisruptionBudget(out.GetName(), out.GetUID()) }
+	return cleanUp, nil
+}
+
+func (k *kubernetesClient) createPodDisruptionBudget(pdb *policyv1beta1.PodDisruptionBudget) (*policyv1beta1.PodDisruptionBudget, error) {
+	purifyResource(pdb)
+	out, err := k.client().PolicyV1beta1().PodDisruptionBudgets(k.namespace).Create(pdb)
+	if k8serrors.IsAlreadyExists(err) {
+		return nil, errors.AlreadyExistsf("pod disruption budget %q", pdb.GetName())
+	}
+	return out, errors.Trace(err)
+}
+
+func (k *kubernetesClient) getPodDisruptionBudget(name string) (*policyv1beta1.PodDisruptionBudget, error) {
+	out, err := k.client().PolicyV1beta1().PodDisruptionBudgets(k.namespace).Get(name, v1.GetOptions{IncludeUninitialized: true})
+	if k8serrors.IsNotFound(err) {
+		return nil, errors.NotFoundf("pod disruption budget %q", name)
+	}
+	return out, errors.Trace(err)
+}
+
+func (k *kubernetesClient) updatePodDisruptionBudget(pdb *policyv1beta1.PodDisruptionBudget) (*policyv1beta1.PodDisruptionBudget, error) {
+	out, err := k.client().PolicyV1beta1().PodDisruptionBudgets(k.namespace).Update(pdb)
+	if k8serrors.IsNotFound(err) {
+		return nil, errors.NotFoundf("pod disruption budget %q", pdb.GetName())
+	}
+	if k8serrors.IsInvalid(err) {
+		return nil, errors.NewNotValid(err, fmt.Sprintf("pod disruption budget %q", pdb.GetName()))
+	}
+	return out, errors.Trace(err)
+}
+
+func (k *kubernetesClient) deletePodDisruptionBudget(name string, uid k8stypes.UID) error {
+	err := k.client().PolicyV1beta1().PodDisruptionBudgets(k.namespace).Delete(name, newPreconditionDeleteOptions(uid))
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) listPodDisruptionBudgets(labels map[string]string) ([]policyv1beta1.PodDisruptionBudget, error) {
+	listOps := v1.ListOptions{
+		LabelSelector:        labelsToSelector(labels),
+		IncludeUninitialized: true,
+	}
+	pdbList, err := k.client().PolicyV1beta1().PodDisruptionBudgets(k.namespace).List(listOps)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(pdbList.Items) == 0 {
+		return nil, errors.NotFoundf("pod disruption budget with labels %v", labels)
+	}
+	return pdbList.Items, nil
+}
+
+func (k *kubernetesClient) deletePodDisruptionBudgets(appName string) error {
+	err := k.client().PolicyV1beta1().PodDisruptionBudgets(k.namespace).DeleteCollection(&v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	}, v1.ListOptions{
+		LabelSelector:        labelsToSelector(k.getPodDisruptionBudgetLabels(appName)),
+		IncludeUninitialized: true,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
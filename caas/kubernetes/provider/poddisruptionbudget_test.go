@@ -0,0 +1,123 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	gc "gopkg.in/check.v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	k8sspecs "github.com/juju/juju/caas/kubernetes/provider/specs"
+	"github.com/juju/juju/testing"
+)
+
+func (s *K8sBrokerSuite) TestEnsureServicePodDisruptionBudgetsCreate(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	minAvailable := intstr.FromInt(1)
+	k8sResources := &k8sspecs.KubernetesResources{
+		PodDisruptionBudgets: []k8sspecs.K8sPodDisruptionBudgetSpec{{
+			Name: "app-name-pdb",
+			Spec: policyv1beta1.PodDisruptionBudgetSpec{
+				MinAvailable: &minAvailable,
+			},
+		}},
+	}
+	pdb := &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "app-name-pdb",
+			Labels: map[string]string{"juju-app": "app-name"},
+			Annotations: map[string]string{
+				"juju.io/controller": testing.ControllerTag.Id(),
+			},
+		},
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			MinAvailable: &minAvailable,
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-app": "app-name"},
+			},
+		},
+	}
+	s.assertKubernetesResources(c, k8sResources,
+		s.mockPodDisruptionBudgets.EXPECT().Create(pdb).Return(pdb, nil),
+		s.mockPodDisruptionBudgets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-app==app-name", IncludeUninitialized: true}).
+			Return(&policyv1beta1.PodDisruptionBudgetList{Items: []policyv1beta1.PodDisruptionBudget{*pdb}}, nil),
+	)
+}
+
+func podDisruptionBudgetForUpdate() (*k8sspecs.KubernetesResources, *policyv1beta1.PodDisruptionBudget) {
+	maxUnavailable := intstr.FromInt(1)
+	k8sResources := &k8sspecs.KubernetesResources{
+		PodDisruptionBudgets: []k8sspecs.K8sPodDisruptionBudgetSpec{{
+			Name: "app-name-pdb",
+			Spec: policyv1beta1.PodDisruptionBudgetSpec{
+				MaxUnavailable: &maxUnavailable,
+			},
+		}},
+	}
+	pdb := &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "app-name-pdb",
+			Labels: map[string]string{"juju-app": "app-name"},
+			Annotations: map[string]string{
+				"juju.io/controller": testing.ControllerTag.Id(),
+			},
+		},
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-app": "app-name"},
+			},
+		},
+	}
+	return k8sResources, pdb
+}
+
+func (s *K8sBrokerSuite) TestEnsureServicePodDisruptionBudgetsUpdate(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	k8sResources, pdb := podDisruptionBudgetForUpdate()
+	existing := *pdb
+	existing.UID = "pdb-uid"
+	existing.ResourceVersion = "42"
+	updated := *pdb
+	updated.ResourceVersion = "42"
+	s.assertKubernetesResources(c, k8sResources,
+		s.mockPodDisruptionBudgets.EXPECT().Create(pdb).Return(nil, s.k8sAlreadyExistsError()),
+		s.mockPodDisruptionBudgets.EXPECT().Get("app-name-pdb", v1.GetOptions{IncludeUninitialized: true}).Return(&existing, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Update(&updated).Return(&updated, nil),
+		s.mockPodDisruptionBudgets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-app==app-name", IncludeUninitialized: true}).
+			Return(&policyv1beta1.PodDisruptionBudgetList{Items: []policyv1beta1.PodDisruptionBudget{existing}}, nil),
+	)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServicePodDisruptionBudgetsReplacedWhenSpecImmutable(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	k8sResources, pdb := podDisruptionBudgetForUpdate()
+	existing := *pdb
+	existing.UID = "pdb-uid"
+	existing.ResourceVersion = "42"
+	updated := *pdb
+	updated.ResourceVersion = "42"
+	immutable := k8serrors.NewInvalid(
+		schema.GroupKind{Group: "policy", Kind: "PodDisruptionBudget"}, "app-name-pdb", nil,
+	)
+	s.assertKubernetesResources(c, k8sResources,
+		s.mockPodDisruptionBudgets.EXPECT().Create(pdb).Return(nil, s.k8sAlreadyExistsError()),
+		s.mockPodDisruptionBudgets.EXPECT().Get("app-name-pdb", v1.GetOptions{IncludeUninitialized: true}).Return(&existing, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Update(&updated).Return(nil, immutable),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name-pdb", s.deleteOptions(v1.DeletePropagationForeground, "pdb-uid")).
+			Return(nil),
+		s.mockPodDisruptionBudgets.EXPECT().Create(pdb).Return(pdb, nil),
+		s.mockPodDisruptionBudgets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-app==app-name", IncludeUninitialized: true}).
+			Return(&policyv1beta1.PodDisruptionBudgetList{Items: []policyv1beta1.PodDisruptionBudget{*pdb}}, nil),
+	)
+}
@@ -0,0 +1,111 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package specs
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/juju/juju/caas/specs"
+)
+
+// ToK8sEnvFromSources converts the config maps and secrets
+// a container takes its environment from to k8s types.
+func ToK8sEnvFromSources(in []specs.EnvFromSource) []core.EnvFromSource {
+	var out []core.EnvFromSource
+	for _, e := range in {
+		optional := e.Optional
+		source := core.EnvFromSource{Prefix: e.Prefix}
+		if e.ConfigMap != "" {
+			source.ConfigMapRef = &core.ConfigMapEnvSource{
+				LocalObjectReference: core.LocalObjectReference{Name: e.ConfigMap},
+				Optional:             &optional,
+			}
+		} else {
+			source.SecretRef = &core.SecretEnvSource{
+				LocalObjectReference: core.LocalObjectReference{Name: e.Secret},
+				Optional:             &optional,
+			}
+		}
+		out = append(out, source)
+	}
+	return out
+}
+
+// ToK8sProbe converts a container probe to a k8s probe.
+func ToK8sProbe(in *specs.Probe) *core.Probe {
+	if in == nil {
+		return nil
+	}
+	out := &core.Probe{
+		InitialDelaySeconds: in.InitialDelaySeconds,
+		TimeoutSeconds:      in.TimeoutSeconds,
+		PeriodSeconds:       in.PeriodSeconds,
+		SuccessThreshold:    in.SuccessThreshold,
+		FailureThreshold:    in.FailureThreshold,
+	}
+	switch {
+	case in.Exec != nil:
+		out.Exec = &core.ExecAction{Command: in.Exec.Command}
+	case in.HTTPGet != nil:
+		out.HTTPGet = &core.HTTPGetAction{
+			Path:   in.HTTPGet.Path,
+			Port:   intstr.FromInt(int(in.HTTPGet.Port)),
+			Scheme: core.URIScheme(strings.ToUpper(in.HTTPGet.Scheme)),
+		}
+	case in.TCPSocket != nil:
+		out.TCPSocket = &core.TCPSocketAction{
+			Port: intstr.FromInt(int(in.TCPSocket.Port)),
+		}
+	}
+	return out
+}
+
+func toK8sResourceList(in specs.ResourceList) (core.ResourceList, error) {
+	out := core.ResourceList{}
+	for name, value := range map[core.ResourceName]string{
+		core.ResourceCPU:    in.CPU,
+		core.ResourceMemory: in.Memory,
+	} {
+		if value == "" {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, errors.NotValidf("%s quantity %q", name, value)
+		}
+		out[name] = quantity
+	}
+	if len(out) == 0 {
+		return nil, nil
+	}
+	return out, nil
+}
+
+// ToK8sResourceRequirements converts the compute resources of a
+// container to k8s resource requirements, returning an error if
+// any quantity is invalid or a request exceeds its limit.
+func ToK8sResourceRequirements(in *specs.ResourceRequirements) (core.ResourceRequirements, error) {
+	var out core.ResourceRequirements
+	if in == nil {
+		return out, nil
+	}
+	var err error
+	if out.Limits, err = toK8sResourceList(in.Limits); err != nil {
+		return out, errors.Annotate(err, "limits")
+	}
+	if out.Requests, err = toK8sResourceList(in.Requests); err != nil {
+		return out, errors.Annotate(err, "requests")
+	}
+	for name, request := range out.Requests {
+		if limit, ok := out.Limits[name]; ok && request.Cmp(limit) > 0 {
+			return out, errors.NotValidf("%s request %v greater than limit %v", name, request.String(), limit.String())
+		}
+	}
+	return out, nil
+}
@@ -65,11 +65,17 @@ func (cs *k8sContainersLegacy) Validate() error {
 		if err := c.Validate(); err != nil {
 			return errors.Trace(err)
 		}
+		if err := c.CheckVersion(specs.VersionLegacy); err != nil {
+			return errors.Trace(err)
+		}
 	}
 	for _, c := range cs.InitContainers {
 		if err := c.Validate(); err != nil {
 			return errors.Trace(err)
 		}
+		if err := c.CheckVersion(specs.VersionLegacy); err != nil {
+			return errors.Trace(err)
+		}
 	}
 	return nil
 }
@@ -110,6 +116,11 @@ func (p podSpecLegacy) Validate() error {
 	if err := p.k8sContainersLegacy.Validate(); err != nil {
 		return errors.Trace(err)
 	}
+	if p.Service != nil {
+		if err := p.Service.CheckVersion(specs.VersionLegacy); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	return nil
 }
 
@@ -36,6 +36,17 @@ func (c *k8sContainer) Validate() error {
 		if err := c.Kubernetes.Validate(); err != nil {
 			return errors.Trace(err)
 		}
+		if c.Probes != nil && c.Probes.Liveness != nil && c.Kubernetes.LivenessProbe != nil {
+			return errors.NotValidf("container %q liveness probe specified twice", c.Name)
+		}
+		if c.Probes != nil && c.Probes.Readiness != nil && c.Kubernetes.ReadinessProbe != nil {
+			return errors.NotValidf("container %q readiness probe specified twice", c.Name)
+		}
+	}
+	if c.Resources != nil {
+		if _, err := ToK8sResourceRequirements(c.Resources); err != nil {
+			return errors.Annotatef(err, "container %q", c.Name)
+		}
 	}
 	return nil
 }
@@ -59,6 +70,9 @@ func (c *k8sContainer) ToContainerSpec() specs.ContainerSpec {
 		Config:          c.Config,
 		Files:           c.Files,
 		ImagePullPolicy: c.ImagePullPolicy,
+		EnvFrom:         c.EnvFrom,
+		Probes:          c.Probes,
+		Resources:       c.Resources,
 	}
 	if c.Kubernetes != nil {
 		result.ProviderContainer = c.Kubernetes
@@ -193,6 +207,8 @@ type PodSpecConverter interface {
 
 func getParser(specVersion specs.Version) (parserType, error) {
 	switch specVersion {
+	case specs.Version3:
+		return parsePodSpecV3, nil
 	case specs.Version2:
 		return parsePodSpecV2, nil
 	case specs.VersionLegacy:
@@ -31,6 +31,21 @@ func (p podSpecV2) Validate() error {
 	if err := p.k8sContainers.Validate(); err != nil {
 		return errors.Trace(err)
 	}
+	for _, c := range p.Containers {
+		if err := c.CheckVersion(specs.Version2); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if p.KubernetesResources != nil {
+		if err := p.KubernetesResources.CheckVersion(specs.Version2); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if p.Service != nil {
+		if err := p.Service.CheckVersion(specs.Version2); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	return nil
 }
 
@@ -116,6 +131,12 @@ type KubernetesResources struct {
 
 	ServiceAccounts  []K8sServiceAccountSpec `json:"serviceAccounts,omitempty" yaml:"serviceAccounts,omitempty"`
 	IngressResources []K8sIngressSpec        `json:"ingressResources,omitempty" yaml:"ingressResources,omitempty"`
+
+	// NetworkPolicies, PodDisruptionBudgets and HorizontalPodAutoscalers
+	// are supported from podspec version 3.
+	NetworkPolicies          []K8sNetworkPolicySpec           `json:"networkPolicies,omitempty" yaml:"networkPolicies,omitempty"`
+	PodDisruptionBudgets     []K8sPodDisruptionBudgetSpec     `json:"podDisruptionBudgets,omitempty" yaml:"podDisruptionBudgets,omitempty"`
+	HorizontalPodAutoscalers []K8sHorizontalPodAutoscalerSpec `json:"horizontalPodAutoscalers,omitempty" yaml:"horizontalPodAutoscalers,omitempty"`
 }
 
 func validateCustomResourceDefinition(name string, crd apiextensionsv1beta1.CustomResourceDefinitionSpec) error {
@@ -153,6 +174,47 @@ func (krs *KubernetesResources) Validate() error {
 			return errors.Trace(err)
 		}
 	}
+
+	for _, np := range krs.NetworkPolicies {
+		if err := np.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	for _, pdb := range krs.PodDisruptionBudgets {
+		if err := pdb.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	for _, hpa := range krs.HorizontalPodAutoscalers {
+		if err := hpa.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// CheckVersion returns an error if the resources include kinds
+// which are not supported by the pod spec version.
+func (krs *KubernetesResources) CheckVersion(ver specs.Version) error {
+	if ver >= specs.Version3 {
+		return nil
+	}
+	var kinds []string
+	if len(krs.NetworkPolicies) > 0 {
+		kinds = append(kinds, "networkPolicies")
+	}
+	if len(krs.PodDisruptionBudgets) > 0 {
+		kinds = append(kinds, "podDisruptionBudgets")
+	}
+	if len(krs.HorizontalPodAutoscalers) > 0 {
+		kinds = append(kinds, "horizontalPodAutoscalers")
+	}
+	if len(kinds) > 0 {
+		return errors.NewNotSupported(nil, fmt.Sprintf(
+			"%s requires podspec version %d", strings.Join(kinds, ", "), specs.Version3))
+	}
 	return nil
 }
 
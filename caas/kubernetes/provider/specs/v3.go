@@ -0,0 +1,150 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package specs
+
+import (
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+
+	"github.com/juju/juju/caas/specs"
+)
+
+type caaSSpecV3 = specs.PodSpecV3
+
+type podSpecV3 struct {
+	caaSSpecV3    `json:",inline" yaml:",inline"`
+	K8sPodSpecV2  `json:",inline" yaml:",inline"`
+	k8sContainers `json:",inline" yaml:",inline"`
+}
+
+// Validate is defined on ProviderPod.
+func (p podSpecV3) Validate() error {
+	if err := p.K8sPodSpecV2.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := p.k8sContainers.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+
+	// Containers may only take their environment from the
+	// config maps and secrets defined in the spec.
+	configMaps := set.NewStrings()
+	for name := range p.ConfigMaps {
+		configMaps.Add(name)
+	}
+	secrets := set.NewStrings()
+	if p.KubernetesResources != nil {
+		for _, secret := range p.KubernetesResources.Secrets {
+			secrets.Add(secret.Name)
+		}
+	}
+	for _, c := range p.Containers {
+		for _, e := range c.EnvFrom {
+			if e.ConfigMap != "" && !configMaps.Contains(e.ConfigMap) {
+				return errors.NotValidf("container %q envFrom config map %q not defined in configMaps", c.Name, e.ConfigMap)
+			}
+			if e.Secret != "" && !secrets.Contains(e.Secret) {
+				return errors.NotValidf("container %q envFrom secret %q not defined in kubernetesResources secrets", c.Name, e.Secret)
+			}
+		}
+	}
+	return nil
+}
+
+func (p podSpecV3) ToLatest() *specs.PodSpec {
+	pSpec := &specs.PodSpec{}
+	pSpec.Version = specs.CurrentVersion
+	for _, c := range p.Containers {
+		pSpec.Containers = append(pSpec.Containers, c.ToContainerSpec())
+	}
+	pSpec.Service = p.caaSSpecV3.Service
+	pSpec.ConfigMaps = p.caaSSpecV3.ConfigMaps
+	pSpec.ServiceAccount = p.caaSSpecV3.ServiceAccount
+	pSpec.ProviderPod = &p.K8sPodSpecV2
+	return pSpec
+}
+
+func parsePodSpecV3(in string) (_ PodSpecConverter, err error) {
+	var spec podSpecV3
+	decoder := newStrictYAMLOrJSONDecoder(strings.NewReader(in), len(in))
+	if err = decoder.Decode(&spec); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &spec, nil
+}
+
+// K8sNetworkPolicySpec defines spec for creating or updating a network policy.
+// A policy without a pod selector applies to the application's pods.
+type K8sNetworkPolicySpec struct {
+	Name        string                         `json:"name" yaml:"name"`
+	Labels      map[string]string              `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Annotations map[string]string              `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+	Spec        networkingv1.NetworkPolicySpec `json:"spec" yaml:"spec"`
+}
+
+// Validate returns an error if the spec is not valid.
+func (np K8sNetworkPolicySpec) Validate() error {
+	if np.Name == "" {
+		return errors.New("network policy name is missing")
+	}
+	for _, t := range np.Spec.PolicyTypes {
+		if t != networkingv1.PolicyTypeIngress && t != networkingv1.PolicyTypeEgress {
+			return errors.NotValidf("network policy %q policy type %q", np.Name, t)
+		}
+	}
+	return nil
+}
+
+// K8sPodDisruptionBudgetSpec defines spec for creating or updating a pod
+// disruption budget. A budget without a selector applies to the
+// application's pods.
+type K8sPodDisruptionBudgetSpec struct {
+	Name        string                                `json:"name" yaml:"name"`
+	Labels      map[string]string                     `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Annotations map[string]string                     `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+	Spec        policyv1beta1.PodDisruptionBudgetSpec `json:"spec" yaml:"spec"`
+}
+
+// Validate returns an error if the spec is not valid.
+func (pdb K8sPodDisruptionBudgetSpec) Validate() error {
+	if pdb.Name == "" {
+		return errors.New("pod disruption budget name is missing")
+	}
+	if (pdb.Spec.MinAvailable == nil) == (pdb.Spec.MaxUnavailable == nil) {
+		return errors.NotValidf("pod disruption budget %q without exactly one of minAvailable or maxUnavailable", pdb.Name)
+	}
+	return nil
+}
+
+// K8sHorizontalPodAutoscalerSpec defines spec for creating or updating a
+// horizontal pod autoscaler. An autoscaler without a scale target
+// scales the application's deployment or stateful set.
+type K8sHorizontalPodAutoscalerSpec struct {
+	Name        string                                    `json:"name" yaml:"name"`
+	Labels      map[string]string                         `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Annotations map[string]string                         `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+	Spec        autoscalingv1.HorizontalPodAutoscalerSpec `json:"spec" yaml:"spec"`
+}
+
+// Validate returns an error if the spec is not valid.
+func (hpa K8sHorizontalPodAutoscalerSpec) Validate() error {
+	if hpa.Name == "" {
+		return errors.New("horizontal pod autoscaler name is missing")
+	}
+	if hpa.Spec.MaxReplicas < 1 {
+		return errors.NotValidf("horizontal pod autoscaler %q maxReplicas %d", hpa.Name, hpa.Spec.MaxReplicas)
+	}
+	if min := hpa.Spec.MinReplicas; min != nil && (*min < 1 || *min > hpa.Spec.MaxReplicas) {
+		return errors.NotValidf("horizontal pod autoscaler %q minReplicas %d", hpa.Name, *min)
+	}
+	if cpu := hpa.Spec.TargetCPUUtilizationPercentage; cpu != nil && *cpu < 1 {
+		return errors.NotValidf("horizontal pod autoscaler %q targetCPUUtilizationPercentage %d", hpa.Name, *cpu)
+	}
+	return nil
+}
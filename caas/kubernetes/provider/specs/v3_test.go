@@ -0,0 +1,389 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package specs_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	core "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	k8sspecs "github.com/juju/juju/caas/kubernetes/provider/specs"
+	"github.com/juju/juju/caas/specs"
+	"github.com/juju/juju/testing"
+)
+
+type v3SpecsSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&v3SpecsSuite{})
+
+var versionHeaderV3 = `
+version: 3
+`[1:]
+
+func (s *v3SpecsSuite) TestParse(c *gc.C) {
+	specStr := versionHeaderV3 + `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    envFrom:
+      - configMap: mydata
+      - secret: build-robot-secret
+        prefix: ROBOT_
+        optional: true
+    probes:
+      liveness:
+        initialDelaySeconds: 10
+        httpGet:
+          path: /ping
+          port: 8080
+      readiness:
+        periodSeconds: 5
+        tcpSocket:
+          port: 8080
+    resources:
+      limits:
+        cpu: 500m
+        memory: 1Gi
+      requests:
+        cpu: 250m
+configMaps:
+  mydata:
+    foo: bar
+kubernetesResources:
+  secrets:
+    - name: build-robot-secret
+      type: Opaque
+      stringData:
+        username: fred
+`[1:]
+
+	spec, err := k8sspecs.ParsePodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.Version, gc.Equals, specs.Version3)
+	c.Assert(spec.Containers, gc.HasLen, 1)
+	container := spec.Containers[0]
+	c.Assert(container.EnvFrom, jc.DeepEquals, []specs.EnvFromSource{
+		{ConfigMap: "mydata"},
+		{Secret: "build-robot-secret", Prefix: "ROBOT_", Optional: true},
+	})
+	c.Assert(container.Probes, jc.DeepEquals, &specs.ContainerProbes{
+		Liveness: &specs.Probe{
+			InitialDelaySeconds: 10,
+			HTTPGet:             &specs.HTTPGetAction{Path: "/ping", Port: 8080},
+		},
+		Readiness: &specs.Probe{
+			PeriodSeconds: 5,
+			TCPSocket:     &specs.TCPSocketAction{Port: 8080},
+		},
+	})
+	c.Assert(container.Resources, jc.DeepEquals, &specs.ResourceRequirements{
+		Limits:   specs.ResourceList{CPU: "500m", Memory: "1Gi"},
+		Requests: specs.ResourceList{CPU: "250m"},
+	})
+}
+
+func (s *v3SpecsSuite) TestValidateEnvFromUndefinedConfigMap(c *gc.C) {
+	specStr := versionHeaderV3 + `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    envFrom:
+      - configMap: mydata
+`[1:]
+
+	_, err := k8sspecs.ParsePodSpec(specStr)
+	c.Assert(err, gc.ErrorMatches, `container "gitlab" envFrom config map "mydata" not defined in configMaps not valid`)
+}
+
+func (s *v3SpecsSuite) TestValidateEnvFromUndefinedSecret(c *gc.C) {
+	specStr := versionHeaderV3 + `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    envFrom:
+      - secret: build-robot-secret
+`[1:]
+
+	_, err := k8sspecs.ParsePodSpec(specStr)
+	c.Assert(err, gc.ErrorMatches, `container "gitlab" envFrom secret "build-robot-secret" not defined in kubernetesResources secrets not valid`)
+}
+
+func (s *v3SpecsSuite) TestValidateInvalidQuantity(c *gc.C) {
+	specStr := versionHeaderV3 + `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    resources:
+      limits:
+        memory: lots
+`[1:]
+
+	_, err := k8sspecs.ParsePodSpec(specStr)
+	c.Assert(err, gc.ErrorMatches, `container "gitlab": limits: memory quantity "lots" not valid`)
+}
+
+func (s *v3SpecsSuite) TestValidateRequestGreaterThanLimit(c *gc.C) {
+	specStr := versionHeaderV3 + `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    resources:
+      limits:
+        cpu: 250m
+      requests:
+        cpu: "1"
+`[1:]
+
+	_, err := k8sspecs.ParsePodSpec(specStr)
+	c.Assert(err, gc.ErrorMatches, `container "gitlab": cpu request 1 greater than limit 250m not valid`)
+}
+
+func (s *v3SpecsSuite) TestValidateProbeSpecifiedTwice(c *gc.C) {
+	specStr := versionHeaderV3 + `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    probes:
+      liveness:
+        exec:
+          command: ["true"]
+    kubernetes:
+      livenessProbe:
+        httpGet:
+          path: /ping
+          port: 8080
+`[1:]
+
+	_, err := k8sspecs.ParsePodSpec(specStr)
+	c.Assert(err, gc.ErrorMatches, `container "gitlab" liveness probe specified twice not valid`)
+}
+
+func (s *v3SpecsSuite) TestVersion2RejectsVersion3Fields(c *gc.C) {
+	specStr := versionHeader + `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    resources:
+      limits:
+        memory: 1Gi
+`[1:]
+
+	_, err := k8sspecs.ParsePodSpec(specStr)
+	c.Assert(err, gc.ErrorMatches, `container "gitlab": resources requires podspec version 3`)
+}
+
+func (s *v3SpecsSuite) TestToK8sTypes(c *gc.C) {
+	c.Assert(k8sspecs.ToK8sEnvFromSources([]specs.EnvFromSource{
+		{ConfigMap: "mydata"},
+		{Secret: "mysecret", Prefix: "S_"},
+	}), jc.DeepEquals, []core.EnvFromSource{{
+		ConfigMapRef: &core.ConfigMapEnvSource{
+			LocalObjectReference: core.LocalObjectReference{Name: "mydata"},
+			Optional:             boolPtr(false),
+		},
+	}, {
+		Prefix: "S_",
+		SecretRef: &core.SecretEnvSource{
+			LocalObjectReference: core.LocalObjectReference{Name: "mysecret"},
+			Optional:             boolPtr(false),
+		},
+	}})
+
+	c.Assert(k8sspecs.ToK8sProbe(&specs.Probe{
+		FailureThreshold: 3,
+		HTTPGet:          &specs.HTTPGetAction{Path: "/ping", Port: 8080, Scheme: "https"},
+	}), jc.DeepEquals, &core.Probe{
+		FailureThreshold: 3,
+		Handler: core.Handler{
+			HTTPGet: &core.HTTPGetAction{
+				Path:   "/ping",
+				Port:   intstr.FromInt(8080),
+				Scheme: core.URISchemeHTTPS,
+			},
+		},
+	})
+
+	resources, err := k8sspecs.ToK8sResourceRequirements(&specs.ResourceRequirements{
+		Limits:   specs.ResourceList{CPU: "500m", Memory: "1Gi"},
+		Requests: specs.ResourceList{Memory: "512Mi"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources, jc.DeepEquals, core.ResourceRequirements{
+		Limits: core.ResourceList{
+			core.ResourceCPU:    resource.MustParse("500m"),
+			core.ResourceMemory: resource.MustParse("1Gi"),
+		},
+		Requests: core.ResourceList{
+			core.ResourceMemory: resource.MustParse("512Mi"),
+		},
+	})
+}
+
+func (s *v3SpecsSuite) TestParseKubernetesResources(c *gc.C) {
+	specStr := versionHeaderV3 + `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+kubernetesResources:
+  networkPolicies:
+    - name: allow-frontend
+      spec:
+        policyTypes: [Ingress]
+        ingress:
+          - from:
+              - podSelector:
+                  matchLabels:
+                    juju-app: frontend
+  podDisruptionBudgets:
+    - name: gitlab-pdb
+      spec:
+        minAvailable: 1
+  horizontalPodAutoscalers:
+    - name: gitlab-hpa
+      spec:
+        minReplicas: 2
+        maxReplicas: 10
+        targetCPUUtilizationPercentage: 80
+`[1:]
+
+	spec, err := k8sspecs.ParsePodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	k8sResources := spec.ProviderPod.(*k8sspecs.K8sPodSpec).KubernetesResources
+	minAvailable := intstr.FromInt(1)
+	minReplicas := int32(2)
+	targetCPU := int32(80)
+	c.Assert(k8sResources.NetworkPolicies, jc.DeepEquals, []k8sspecs.K8sNetworkPolicySpec{{
+		Name: "allow-frontend",
+		Spec: networkingv1.NetworkPolicySpec{
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				From: []networkingv1.NetworkPolicyPeer{{
+					PodSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"juju-app": "frontend"},
+					},
+				}},
+			}},
+		},
+	}})
+	c.Assert(k8sResources.PodDisruptionBudgets, jc.DeepEquals, []k8sspecs.K8sPodDisruptionBudgetSpec{{
+		Name: "gitlab-pdb",
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{MinAvailable: &minAvailable},
+	}})
+	c.Assert(k8sResources.HorizontalPodAutoscalers, jc.DeepEquals, []k8sspecs.K8sHorizontalPodAutoscalerSpec{{
+		Name: "gitlab-hpa",
+		Spec: autoscalingv1.HorizontalPodAutoscalerSpec{
+			MinReplicas:                    &minReplicas,
+			MaxReplicas:                    10,
+			TargetCPUUtilizationPercentage: &targetCPU,
+		},
+	}})
+}
+
+func (s *v3SpecsSuite) TestValidateKubernetesResources(c *gc.C) {
+	for i, t := range []struct {
+		resources string
+		err       string
+	}{{
+		resources: `
+  networkPolicies:
+    - spec:
+        policyTypes: [Ingress]
+`,
+		err: `network policy name is missing`,
+	}, {
+		resources: `
+  networkPolicies:
+    - name: allow-all
+      spec:
+        policyTypes: [Sideways]
+`,
+		err: `network policy "allow-all" policy type "Sideways" not valid`,
+	}, {
+		resources: `
+  podDisruptionBudgets:
+    - name: gitlab-pdb
+      spec:
+        minAvailable: 1
+        maxUnavailable: 1
+`,
+		err: `pod disruption budget "gitlab-pdb" without exactly one of minAvailable or maxUnavailable not valid`,
+	}, {
+		resources: `
+  horizontalPodAutoscalers:
+    - name: gitlab-hpa
+      spec:
+        minReplicas: 5
+        maxReplicas: 3
+`,
+		err: `horizontal pod autoscaler "gitlab-hpa" minReplicas 5 not valid`,
+	}} {
+		c.Logf("test %d", i)
+		specStr := versionHeaderV3 + `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+kubernetesResources:` + t.resources
+		_, err := k8sspecs.ParsePodSpec(specStr)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *v3SpecsSuite) TestVersion2RejectsVersion3KubernetesResources(c *gc.C) {
+	specStr := versionHeader + `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+kubernetesResources:
+  podDisruptionBudgets:
+    - name: gitlab-pdb
+      spec:
+        maxUnavailable: 1
+`[1:]
+
+	_, err := k8sspecs.ParsePodSpec(specStr)
+	c.Assert(err, gc.ErrorMatches, `podDisruptionBudgets requires podspec version 3`)
+}
+
+func (s *v3SpecsSuite) TestParseDeploymentTypeAndUpdateStrategy(c *gc.C) {
+	specStr := versionHeaderV3 + `
+service:
+  scalePolicy: serial
+  deploymentType: stateful
+  updateStrategy:
+    partition: 2
+containers:
+  - name: gitlab
+    image: gitlab/latest
+`[1:]
+
+	spec, err := k8sspecs.ParsePodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	partition := int32(2)
+	c.Assert(spec.Service, jc.DeepEquals, &specs.ServiceSpec{
+		ScalePolicy:    specs.SerialScale,
+		DeploymentType: specs.DeploymentStateful,
+		UpdateStrategy: &specs.UpdateStrategy{Partition: &partition},
+	})
+}
+
+func (s *v3SpecsSuite) TestVersion2RejectsDeploymentType(c *gc.C) {
+	specStr := versionHeader + `
+service:
+  deploymentType: daemon
+containers:
+  - name: gitlab
+    image: gitlab/latest
+`[1:]
+
+	_, err := k8sspecs.ParsePodSpec(specStr)
+	c.Assert(err, gc.ErrorMatches, `service: deploymentType requires podspec version 3`)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/schema"
 	core "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/juju/juju/environs/context"
@@ -79,15 +80,17 @@ type storageProvider struct {
 var _ storage.Provider = (*storageProvider)(nil)
 
 var storageConfigFields = schema.Fields{
-	StorageClass:       schema.String(),
-	storageProvisioner: schema.String(),
+	StorageClass:        schema.String(),
+	storageProvisioner:  schema.String(),
+	VolumeSnapshotClass: schema.String(),
 }
 
 var storageConfigChecker = schema.FieldMap(
 	storageConfigFields,
 	schema.Defaults{
-		StorageClass:       schema.Omit,
-		storageProvisioner: schema.Omit,
+		StorageClass:        schema.Omit,
+		storageProvisioner:  schema.Omit,
+		VolumeSnapshotClass: schema.Omit,
 	},
 )
 
@@ -106,6 +109,10 @@ type storageConfig struct {
 
 	// reclaimPolicy defines the volume reclaim policy.
 	reclaimPolicy core.PersistentVolumeReclaimPolicy
+
+	// volumeSnapshotClass is the snapshot class used
+	// to take snapshots of volumes.
+	volumeSnapshotClass string
 }
 
 func newStorageConfig(attrs map[string]interface{}) (*storageConfig, error) {
@@ -121,6 +128,9 @@ func newStorageConfig(attrs map[string]interface{}) (*storageConfig, error) {
 	if storageProvisioner, ok := coerced[storageProvisioner].(string); ok {
 		storageConfig.storageProvisioner = storageProvisioner
 	}
+	if snapshotClass, ok := coerced[VolumeSnapshotClass].(string); ok {
+		storageConfig.volumeSnapshotClass = snapshotClass
+	}
 	if storageConfig.storageProvisioner != "" && storageConfig.storageClass == "" {
 		return nil, errors.New("storage-class must be specified if storage-provisioner is specified")
 	}
@@ -133,6 +143,7 @@ func newStorageConfig(attrs map[string]interface{}) (*storageConfig, error) {
 	}
 	delete(storageConfig.parameters, StorageClass)
 	delete(storageConfig.parameters, storageProvisioner)
+	delete(storageConfig.parameters, VolumeSnapshotClass)
 
 	return storageConfig, nil
 }
@@ -287,6 +298,99 @@ func (v *volumeSource) DetachVolumes(ctx context.ProviderCallContext, attachPara
 	return make([]error, len(attachParams)), nil
 }
 
+var _ storage.VolumeResizer = (*volumeSource)(nil)
+
+// ResizeVolumes is specified on the storage.VolumeResizer interface.
+func (v *volumeSource) ResizeVolumes(ctx context.ProviderCallContext, params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	logger.Debugf("resize k8s volumes: %v", params)
+	results := make([]storage.ResizeVolumesResult, len(params))
+	var wg sync.WaitGroup
+	for i, p := range params {
+		wg.Add(1)
+		go func(i int, p storage.VolumeResizeParams) {
+			defer wg.Done()
+			results[i].Size, results[i].Error = v.resizeVolume(p.VolumeId, p.Size)
+		}(i, p)
+	}
+	wg.Wait()
+	return results, nil
+}
+
+// resizeVolume expands the claim bound to the volume, which
+// the storage class's provisioner then expands the volume for.
+// The claim's status reports the volume's size, which catches
+// up with the request once the expansion has completed.
+func (v *volumeSource) resizeVolume(volumeId string, size uint64) (uint64, error) {
+	_, pvc, err := v.volumeClaim(volumeId)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	requested, err := resource.ParseQuantity(fmt.Sprintf("%dMi", size))
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	current := pvc.Spec.Resources.Requests[core.ResourceStorage]
+	switch requested.Cmp(current) {
+	case 0:
+		return claimCapacity(pvc), nil
+	case -1:
+		return 0, errors.NotValidf("shrinking volume %q from %v to %v", volumeId, current.String(), requested.String())
+	}
+
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+		return 0, errors.NotSupportedf("expanding volume %q without a storage class", volumeId)
+	}
+	scName := *pvc.Spec.StorageClassName
+	sc, err := v.client.client().StorageV1().StorageClasses().Get(scName, v1.GetOptions{})
+	if err != nil {
+		return 0, errors.Annotatef(err, "getting storage class %q", scName)
+	}
+	if sc.AllowVolumeExpansion == nil || !*sc.AllowVolumeExpansion {
+		return 0, errors.NotSupportedf("expanding volumes in storage class %q", scName)
+	}
+
+	if pvc.Spec.Resources.Requests == nil {
+		pvc.Spec.Resources.Requests = core.ResourceList{}
+	}
+	pvc.Spec.Resources.Requests[core.ResourceStorage] = requested
+	pClaims := v.client.client().CoreV1().PersistentVolumeClaims(pvc.Namespace)
+	updated, err := pClaims.Update(pvc)
+	if err != nil {
+		return 0, errors.Annotatef(err, "expanding volume claim %v", pvc.Name)
+	}
+	return claimCapacity(updated), nil
+}
+
+// claimCapacity returns the size in MiB of the volume bound
+// to the claim, as reported by the claim's status.
+func claimCapacity(pvc *core.PersistentVolumeClaim) uint64 {
+	capacity := pvc.Status.Capacity[core.ResourceStorage]
+	return uint64(capacity.Value() / (1024 * 1024))
+}
+
+// volumeClaim returns the volume with the specified
+// id and the claim which it's bound to.
+func (v *volumeSource) volumeClaim(volumeId string) (*core.PersistentVolume, *core.PersistentVolumeClaim, error) {
+	vol, err := v.client.client().CoreV1().PersistentVolumes().Get(volumeId, v1.GetOptions{IncludeUninitialized: true})
+	if k8serrors.IsNotFound(err) {
+		return nil, nil, errors.NotFoundf("volume %q", volumeId)
+	} else if err != nil {
+		return nil, nil, errors.Annotatef(err, "getting volume %v", volumeId)
+	}
+	claimRef := vol.Spec.ClaimRef
+	if claimRef == nil {
+		return nil, nil, errors.NotFoundf("claim for volume %q", volumeId)
+	}
+	pClaims := v.client.client().CoreV1().PersistentVolumeClaims(claimRef.Namespace)
+	pvc, err := pClaims.Get(claimRef.Name, v1.GetOptions{IncludeUninitialized: true})
+	if k8serrors.IsNotFound(err) {
+		return nil, nil, errors.NotFoundf("claim %q for volume %q", claimRef.Name, volumeId)
+	} else if err != nil {
+		return nil, nil, errors.Annotatef(err, "getting volume claim %v", claimRef.Name)
+	}
+	return vol, pvc, nil
+}
+
 func foreachVolume(volumeIds []string, f func(string) error) []error {
 	results := make([]error, len(volumeIds))
 	var wg sync.WaitGroup
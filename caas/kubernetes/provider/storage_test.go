@@ -8,6 +8,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	core "k8s.io/api/core/v1"
+	k8sstorage "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -187,6 +188,127 @@ func (s *storageSuite) TestDescribeVolumes(c *gc.C) {
 	}})
 }
 
+func (s *storageSuite) expectVolumeClaim(pvc *core.PersistentVolumeClaim) []*gomock.Call {
+	return []*gomock.Call{
+		s.mockPersistentVolumes.EXPECT().Get("vol-1", v1.GetOptions{IncludeUninitialized: true}).
+			Return(&core.PersistentVolume{
+				ObjectMeta: v1.ObjectMeta{Name: "vol-1"},
+				Spec: core.PersistentVolumeSpec{
+					Capacity: core.ResourceList{core.ResourceStorage: resource.MustParse("100Mi")},
+					ClaimRef: &core.ObjectReference{Namespace: "test", Name: pvc.Name},
+				}}, nil),
+		s.mockPersistentVolumeClaims.EXPECT().Get(pvc.Name, v1.GetOptions{IncludeUninitialized: true}).
+			Return(pvc, nil),
+	}
+}
+
+func (s *storageSuite) volumeClaim(storageClass string) *core.PersistentVolumeClaim {
+	return &core.PersistentVolumeClaim{
+		ObjectMeta: v1.ObjectMeta{Name: "vol-1-pvc", Namespace: "test"},
+		Spec: core.PersistentVolumeClaimSpec{
+			StorageClassName: &storageClass,
+			Resources: core.ResourceRequirements{
+				Requests: core.ResourceList{core.ResourceStorage: resource.MustParse("100Mi")},
+			},
+		},
+		Status: core.PersistentVolumeClaimStatus{
+			Capacity: core.ResourceList{core.ResourceStorage: resource.MustParse("100Mi")},
+		},
+	}
+}
+
+func (s *storageSuite) TestResizeVolumes(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	allowExpansion := true
+	pvc := s.volumeClaim("workload-storage")
+	resized := s.volumeClaim("workload-storage")
+	resized.Spec.Resources.Requests[core.ResourceStorage] = resource.MustParse("200Mi")
+	calls := append(s.expectVolumeClaim(pvc),
+		s.mockStorageClass.EXPECT().Get("workload-storage", v1.GetOptions{}).
+			Return(&k8sstorage.StorageClass{AllowVolumeExpansion: &allowExpansion}, nil),
+		s.mockPersistentVolumeClaims.EXPECT().Update(resized).Return(resized, nil),
+	)
+	gomock.InOrder(calls...)
+
+	p := s.k8sProvider(c, ctrl)
+	vs, err := p.VolumeSource(&storage.Config{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The claim's status reports the old size
+	// until the expansion has completed.
+	results, err := vs.(storage.VolumeResizer).ResizeVolumes(&context.CloudCallContext{}, []storage.VolumeResizeParams{{
+		VolumeId: "vol-1",
+		Size:     200,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeVolumesResult{{Size: 100}})
+}
+
+func (s *storageSuite) TestResizeVolumesCompleted(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	pvc := s.volumeClaim("workload-storage")
+	pvc.Spec.Resources.Requests[core.ResourceStorage] = resource.MustParse("200Mi")
+	pvc.Status.Capacity = core.ResourceList{core.ResourceStorage: resource.MustParse("256Mi")}
+	gomock.InOrder(s.expectVolumeClaim(pvc)...)
+
+	p := s.k8sProvider(c, ctrl)
+	vs, err := p.VolumeSource(&storage.Config{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := vs.(storage.VolumeResizer).ResizeVolumes(&context.CloudCallContext{}, []storage.VolumeResizeParams{{
+		VolumeId: "vol-1",
+		Size:     200,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeVolumesResult{{Size: 256}})
+}
+
+func (s *storageSuite) TestResizeVolumesExpansionNotAllowed(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	calls := append(s.expectVolumeClaim(s.volumeClaim("workload-storage")),
+		s.mockStorageClass.EXPECT().Get("workload-storage", v1.GetOptions{}).
+			Return(&k8sstorage.StorageClass{}, nil),
+	)
+	gomock.InOrder(calls...)
+
+	p := s.k8sProvider(c, ctrl)
+	vs, err := p.VolumeSource(&storage.Config{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := vs.(storage.VolumeResizer).ResizeVolumes(&context.CloudCallContext{}, []storage.VolumeResizeParams{{
+		VolumeId: "vol-1",
+		Size:     200,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `expanding volumes in storage class "workload-storage" not supported`)
+}
+
+func (s *storageSuite) TestResizeVolumesShrink(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(s.expectVolumeClaim(s.volumeClaim("workload-storage"))...)
+
+	p := s.k8sProvider(c, ctrl)
+	vs, err := p.VolumeSource(&storage.Config{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := vs.(storage.VolumeResizer).ResizeVolumes(&context.CloudCallContext{}, []storage.VolumeResizeParams{{
+		VolumeId: "vol-1",
+		Size:     50,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `shrinking volume "vol-1" from 100Mi to 50Mi not valid`)
+}
+
 func (s *storageSuite) TestValidateStorageProvider(c *gc.C) {
 	for _, t := range []struct {
 		providerType storage.ProviderType
@@ -0,0 +1,209 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	// VolumeSnapshotClass is the name of the volume snapshot
+	// class used to take snapshots of a storage pool's volumes.
+	VolumeSnapshotClass = "volume-snapshot-class"
+
+	volumeSnapshotGroup = "snapshot.storage.k8s.io"
+	volumeSnapshotKind  = "VolumeSnapshot"
+)
+
+var volumeSnapshotsResource = schema.GroupVersionResource{
+	Group:    volumeSnapshotGroup,
+	Version:  "v1beta1",
+	Resource: "volumesnapshots",
+}
+
+var _ storage.VolumeSnapshotter = (*volumeSource)(nil)
+
+func (v *volumeSource) volumeSnapshots(namespace string) dynamic.ResourceInterface {
+	return v.client.dynamicClient().Resource(volumeSnapshotsResource).Namespace(namespace)
+}
+
+// SnapshotVolumes is specified on the storage.VolumeSnapshotter interface.
+func (v *volumeSource) SnapshotVolumes(ctx context.ProviderCallContext, params []storage.VolumeSnapshotParams) ([]storage.SnapshotVolumesResult, error) {
+	logger.Debugf("snapshot k8s volumes: %v", params)
+	results := make([]storage.SnapshotVolumesResult, len(params))
+	for i, p := range params {
+		snapshot, err := v.snapshotVolume(p)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "taking snapshot of volume %q", p.VolumeId)
+			continue
+		}
+		results[i].Snapshot = snapshot
+	}
+	return results, nil
+}
+
+func (v *volumeSource) snapshotVolume(p storage.VolumeSnapshotParams) (*storage.VolumeSnapshot, error) {
+	cfg, err := newStorageConfig(p.Attributes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	vol, pvc, err := v.volumeClaim(p.VolumeId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	name := p.Name
+	if name == "" {
+		name = fmt.Sprintf("%s-%d", pvc.Name, v.client.clock.Now().Unix())
+	}
+
+	spec := map[string]interface{}{
+		"source": map[string]interface{}{
+			"persistentVolumeClaimName": pvc.Name,
+		},
+	}
+	if cfg.volumeSnapshotClass != "" {
+		spec["volumeSnapshotClassName"] = cfg.volumeSnapshotClass
+	}
+	snapshot := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": volumeSnapshotsResource.GroupVersion().String(),
+			"kind":       volumeSnapshotKind,
+			"spec":       spec,
+		},
+	}
+	snapshot.SetName(name)
+	snapshot.SetNamespace(pvc.Namespace)
+	if len(p.ResourceTags) > 0 {
+		snapshot.SetLabels(p.ResourceTags)
+	}
+
+	snapshots := v.volumeSnapshots(pvc.Namespace)
+	out, err := snapshots.Create(snapshot)
+	if k8serrors.IsAlreadyExists(err) {
+		// The snapshot has already been taken;
+		// report whether it is ready to use yet.
+		out, err = snapshots.Get(name, v1.GetOptions{})
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ready, _, err := unstructured.NestedBool(out.Object, "status", "readyToUse")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	capacity := vol.Spec.Capacity[core.ResourceStorage]
+	return &storage.VolumeSnapshot{
+		SnapshotId: out.GetName(),
+		VolumeId:   vol.Name,
+		Size:       uint64(capacity.Value() / (1024 * 1024)),
+		Ready:      ready,
+	}, nil
+}
+
+// RestoreVolumes is specified on the storage.VolumeSnapshotter interface.
+// A claim is created for each volume, with the snapshot as its data
+// source; the volumes are reported once the claims are bound.
+func (v *volumeSource) RestoreVolumes(ctx context.ProviderCallContext, params []storage.VolumeRestoreParams) ([]storage.RestoreVolumesResult, error) {
+	logger.Debugf("restore k8s volumes: %v", params)
+	results := make([]storage.RestoreVolumesResult, len(params))
+	for i, p := range params {
+		info, err := v.restoreVolume(p)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "restoring snapshot %q", p.SnapshotId)
+			continue
+		}
+		results[i].VolumeInfo = info
+	}
+	return results, nil
+}
+
+func (v *volumeSource) restoreVolume(p storage.VolumeRestoreParams) (*storage.VolumeInfo, error) {
+	cfg, err := newStorageConfig(p.Attributes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	size, err := resource.ParseQuantity(fmt.Sprintf("%dMi", p.Size))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	namespace := v.client.namespace
+	if _, err := v.volumeSnapshots(namespace).Get(p.SnapshotId, v1.GetOptions{}); k8serrors.IsNotFound(err) {
+		return nil, errors.NotFoundf("volume snapshot %q", p.SnapshotId)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	apiGroup := volumeSnapshotGroup
+	pvc := &core.PersistentVolumeClaim{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   p.Storage.String(),
+			Labels: p.ResourceTags,
+		},
+		Spec: core.PersistentVolumeClaimSpec{
+			AccessModes: []core.PersistentVolumeAccessMode{core.ReadWriteOnce},
+			DataSource: &core.TypedLocalObjectReference{
+				APIGroup: &apiGroup,
+				Kind:     volumeSnapshotKind,
+				Name:     p.SnapshotId,
+			},
+			Resources: core.ResourceRequirements{
+				Requests: core.ResourceList{
+					core.ResourceStorage: size,
+				},
+			},
+		},
+	}
+	if cfg.storageClass != "" {
+		pvc.Spec.StorageClassName = &cfg.storageClass
+	}
+	pClaims := v.client.client().CoreV1().PersistentVolumeClaims(namespace)
+	out, err := pClaims.Create(pvc)
+	if k8serrors.IsAlreadyExists(err) {
+		// The claim was created by an earlier attempt;
+		// check whether it has been bound yet.
+		out, err = pClaims.Get(pvc.Name, v1.GetOptions{IncludeUninitialized: true})
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if out.Status.Phase != core.ClaimBound || out.Spec.VolumeName == "" {
+		return nil, errors.NotProvisionedf("volume claim %q", out.Name)
+	}
+	vol, err := v.client.client().CoreV1().PersistentVolumes().Get(out.Spec.VolumeName, v1.GetOptions{IncludeUninitialized: true})
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting volume %v", out.Spec.VolumeName)
+	}
+	capacity := vol.Spec.Capacity[core.ResourceStorage]
+	return &storage.VolumeInfo{
+		VolumeId:   vol.Name,
+		Size:       uint64(capacity.Value() / (1024 * 1024)),
+		Persistent: vol.Spec.PersistentVolumeReclaimPolicy == core.PersistentVolumeReclaimRetain,
+	}, nil
+}
+
+// DestroySnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *volumeSource) DestroySnapshots(ctx context.ProviderCallContext, snapshotIds []string) ([]error, error) {
+	logger.Debugf("destroy k8s volume snapshots: %v", snapshotIds)
+	snapshots := v.volumeSnapshots(v.client.namespace)
+	results := make([]error, len(snapshotIds))
+	for i, id := range snapshotIds {
+		err := snapshots.Delete(id, &v1.DeleteOptions{PropagationPolicy: &defaultPropagationPolicy})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			results[i] = errors.Annotatef(err, "destroying volume snapshot %v", id)
+		}
+	}
+	return results, nil
+}
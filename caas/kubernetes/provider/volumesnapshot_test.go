@@ -0,0 +1,252 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/storage"
+)
+
+var volumeSnapshotsResource = schema.GroupVersionResource{
+	Group:    "snapshot.storage.k8s.io",
+	Version:  "v1beta1",
+	Resource: "volumesnapshots",
+}
+
+func (s *storageSuite) volumeSnapshotter(c *gc.C, ctrl *gomock.Controller) storage.VolumeSnapshotter {
+	vs, err := s.k8sProvider(c, ctrl).VolumeSource(&storage.Config{})
+	c.Assert(err, jc.ErrorIsNil)
+	return vs.(storage.VolumeSnapshotter)
+}
+
+func (s *storageSuite) TestSnapshotVolumes(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	snapshot := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "snapshot.storage.k8s.io/v1beta1",
+			"kind":       "VolumeSnapshot",
+			"metadata": map[string]interface{}{
+				"name":      "database-snapshot",
+				"namespace": "test",
+				"labels":    map[string]interface{}{"juju-model-uuid": "deadbeef"},
+			},
+			"spec": map[string]interface{}{
+				"source": map[string]interface{}{
+					"persistentVolumeClaimName": "vol-1-pvc",
+				},
+				"volumeSnapshotClassName": "csi-snapshots",
+			},
+		},
+	}
+	created := snapshot.DeepCopy()
+	created.Object["status"] = map[string]interface{}{"readyToUse": true}
+
+	calls := append(s.expectVolumeClaim(s.volumeClaim("workload-storage")),
+		s.mockDynamicClient.EXPECT().Resource(volumeSnapshotsResource).Return(s.mockNamespaceableResourceClient),
+		s.mockResourceClient.EXPECT().Create(snapshot).Return(created, nil),
+	)
+	gomock.InOrder(calls...)
+
+	results, err := s.volumeSnapshotter(c, ctrl).SnapshotVolumes(&context.CloudCallContext{}, []storage.VolumeSnapshotParams{{
+		Tag:          names.NewVolumeTag("0"),
+		VolumeId:     "vol-1",
+		Name:         "database-snapshot",
+		Attributes:   map[string]interface{}{"volume-snapshot-class": "csi-snapshots"},
+		ResourceTags: map[string]string{"juju-model-uuid": "deadbeef"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.SnapshotVolumesResult{{
+		Snapshot: &storage.VolumeSnapshot{
+			SnapshotId: "database-snapshot",
+			VolumeId:   "vol-1",
+			Size:       100,
+			Ready:      true,
+		},
+	}})
+}
+
+func (s *storageSuite) TestSnapshotVolumesNotFound(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockPersistentVolumes.EXPECT().Get("vol-1", v1.GetOptions{IncludeUninitialized: true}).
+			Return(nil, s.k8sNotFoundError()),
+	)
+
+	results, err := s.volumeSnapshotter(c, ctrl).SnapshotVolumes(&context.CloudCallContext{}, []storage.VolumeSnapshotParams{{
+		VolumeId: "vol-1",
+		Name:     "database-snapshot",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `taking snapshot of volume "vol-1": volume "vol-1" not found`)
+}
+
+func (s *storageSuite) TestSnapshotVolumesAlreadyExists(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	existing := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "database-snapshot"},
+			"status":   map[string]interface{}{"readyToUse": false},
+		},
+	}
+	calls := append(s.expectVolumeClaim(s.volumeClaim("workload-storage")),
+		s.mockDynamicClient.EXPECT().Resource(volumeSnapshotsResource).Return(s.mockNamespaceableResourceClient),
+		s.mockResourceClient.EXPECT().Create(gomock.Any()).Return(nil, s.k8sAlreadyExistsError()),
+		s.mockResourceClient.EXPECT().Get("database-snapshot", v1.GetOptions{}).Return(existing, nil),
+	)
+	gomock.InOrder(calls...)
+
+	results, err := s.volumeSnapshotter(c, ctrl).SnapshotVolumes(&context.CloudCallContext{}, []storage.VolumeSnapshotParams{{
+		VolumeId: "vol-1",
+		Name:     "database-snapshot",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.SnapshotVolumesResult{{
+		Snapshot: &storage.VolumeSnapshot{
+			SnapshotId: "database-snapshot",
+			VolumeId:   "vol-1",
+			Size:       100,
+		},
+	}})
+}
+
+func (s *storageSuite) restoreClaim() *core.PersistentVolumeClaim {
+	storageClass := "workload-storage"
+	apiGroup := "snapshot.storage.k8s.io"
+	return &core.PersistentVolumeClaim{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "storage-pgdata-1",
+			Labels: map[string]string{"juju-model-uuid": "deadbeef"},
+		},
+		Spec: core.PersistentVolumeClaimSpec{
+			StorageClassName: &storageClass,
+			AccessModes:      []core.PersistentVolumeAccessMode{core.ReadWriteOnce},
+			DataSource: &core.TypedLocalObjectReference{
+				APIGroup: &apiGroup,
+				Kind:     "VolumeSnapshot",
+				Name:     "database-snapshot",
+			},
+			Resources: core.ResourceRequirements{
+				Requests: core.ResourceList{core.ResourceStorage: resource.MustParse("200Mi")},
+			},
+		},
+	}
+}
+
+func (s *storageSuite) restoreParams() []storage.VolumeRestoreParams {
+	return []storage.VolumeRestoreParams{{
+		Storage:      names.NewStorageTag("pgdata/1"),
+		SnapshotId:   "database-snapshot",
+		Size:         200,
+		Attributes:   map[string]interface{}{"storage-class": "workload-storage"},
+		ResourceTags: map[string]string{"juju-model-uuid": "deadbeef"},
+	}}
+}
+
+func (s *storageSuite) TestRestoreVolumes(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	pvc := s.restoreClaim()
+	bound := s.restoreClaim()
+	bound.Spec.VolumeName = "pvc-1234"
+	bound.Status.Phase = core.ClaimBound
+	gomock.InOrder(
+		s.mockDynamicClient.EXPECT().Resource(volumeSnapshotsResource).Return(s.mockNamespaceableResourceClient),
+		s.mockResourceClient.EXPECT().Get("database-snapshot", v1.GetOptions{}).
+			Return(&unstructured.Unstructured{}, nil),
+		s.mockPersistentVolumeClaims.EXPECT().Create(pvc).Return(nil, s.k8sAlreadyExistsError()),
+		s.mockPersistentVolumeClaims.EXPECT().Get("storage-pgdata-1", v1.GetOptions{IncludeUninitialized: true}).
+			Return(bound, nil),
+		s.mockPersistentVolumes.EXPECT().Get("pvc-1234", v1.GetOptions{IncludeUninitialized: true}).
+			Return(&core.PersistentVolume{
+				ObjectMeta: v1.ObjectMeta{Name: "pvc-1234"},
+				Spec: core.PersistentVolumeSpec{
+					Capacity:                      core.ResourceList{core.ResourceStorage: resource.MustParse("256Mi")},
+					PersistentVolumeReclaimPolicy: core.PersistentVolumeReclaimRetain,
+				},
+			}, nil),
+	)
+
+	results, err := s.volumeSnapshotter(c, ctrl).RestoreVolumes(&context.CloudCallContext{}, s.restoreParams())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.RestoreVolumesResult{{
+		VolumeInfo: &storage.VolumeInfo{
+			VolumeId:   "pvc-1234",
+			Size:       256,
+			Persistent: true,
+		},
+	}})
+}
+
+func (s *storageSuite) TestRestoreVolumesNotBound(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	pvc := s.restoreClaim()
+	gomock.InOrder(
+		s.mockDynamicClient.EXPECT().Resource(volumeSnapshotsResource).Return(s.mockNamespaceableResourceClient),
+		s.mockResourceClient.EXPECT().Get("database-snapshot", v1.GetOptions{}).
+			Return(&unstructured.Unstructured{}, nil),
+		s.mockPersistentVolumeClaims.EXPECT().Create(pvc).Return(pvc, nil),
+	)
+
+	results, err := s.volumeSnapshotter(c, ctrl).RestoreVolumes(&context.CloudCallContext{}, s.restoreParams())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `restoring snapshot "database-snapshot": volume claim "storage-pgdata-1" not provisioned`)
+	c.Assert(errors.Cause(results[0].Error), jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *storageSuite) TestRestoreVolumesSnapshotNotFound(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockDynamicClient.EXPECT().Resource(volumeSnapshotsResource).Return(s.mockNamespaceableResourceClient),
+		s.mockResourceClient.EXPECT().Get("database-snapshot", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+	)
+
+	results, err := s.volumeSnapshotter(c, ctrl).RestoreVolumes(&context.CloudCallContext{}, s.restoreParams())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `restoring snapshot "database-snapshot": volume snapshot "database-snapshot" not found`)
+}
+
+func (s *storageSuite) TestDestroySnapshots(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockDynamicClient.EXPECT().Resource(volumeSnapshotsResource).Return(s.mockNamespaceableResourceClient),
+		s.mockResourceClient.EXPECT().Delete("database-snapshot", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(nil),
+		s.mockResourceClient.EXPECT().Delete("gone-snapshot", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+	)
+
+	errs, err := s.volumeSnapshotter(c, ctrl).DestroySnapshots(
+		&context.CloudCallContext{}, []string{"database-snapshot", "gone-snapshot"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil, nil})
+}
@@ -0,0 +1,186 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package specs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// EnvFromSource defines a config map or secret whose
+// keys are all set as environment variables in a container.
+type EnvFromSource struct {
+	// Prefix is prepended to the name of each variable.
+	Prefix string `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+
+	// ConfigMap is the name of the config map.
+	ConfigMap string `json:"configMap,omitempty" yaml:"configMap,omitempty"`
+
+	// Secret is the name of the secret.
+	Secret string `json:"secret,omitempty" yaml:"secret,omitempty"`
+
+	// Optional indicates whether the container may start
+	// when the config map or secret does not exist.
+	Optional bool `json:"optional,omitempty" yaml:"optional,omitempty"`
+}
+
+// Validate returns an error if the spec is not valid.
+func (e EnvFromSource) Validate() error {
+	if e.ConfigMap == "" && e.Secret == "" {
+		return errors.New("envFrom requires a config map or secret")
+	}
+	if e.ConfigMap != "" && e.Secret != "" {
+		return errors.Errorf("envFrom config map %q and secret %q cannot both be specified", e.ConfigMap, e.Secret)
+	}
+	return nil
+}
+
+// ExecAction defines a command run in a container.
+type ExecAction struct {
+	Command []string `json:"command" yaml:"command"`
+}
+
+// HTTPGetAction defines a http request made to a container.
+type HTTPGetAction struct {
+	Path   string `json:"path,omitempty" yaml:"path,omitempty"`
+	Port   int32  `json:"port" yaml:"port"`
+	Scheme string `json:"scheme,omitempty" yaml:"scheme,omitempty"`
+}
+
+// TCPSocketAction defines a tcp connection made to a container.
+type TCPSocketAction struct {
+	Port int32 `json:"port" yaml:"port"`
+}
+
+// Probe defines a check made against a container to determine
+// whether it is alive or ready to receive traffic.
+type Probe struct {
+	Exec      *ExecAction      `json:"exec,omitempty" yaml:"exec,omitempty"`
+	HTTPGet   *HTTPGetAction   `json:"httpGet,omitempty" yaml:"httpGet,omitempty"`
+	TCPSocket *TCPSocketAction `json:"tcpSocket,omitempty" yaml:"tcpSocket,omitempty"`
+
+	InitialDelaySeconds int32 `json:"initialDelaySeconds,omitempty" yaml:"initialDelaySeconds,omitempty"`
+	TimeoutSeconds      int32 `json:"timeoutSeconds,omitempty" yaml:"timeoutSeconds,omitempty"`
+	PeriodSeconds       int32 `json:"periodSeconds,omitempty" yaml:"periodSeconds,omitempty"`
+	SuccessThreshold    int32 `json:"successThreshold,omitempty" yaml:"successThreshold,omitempty"`
+	FailureThreshold    int32 `json:"failureThreshold,omitempty" yaml:"failureThreshold,omitempty"`
+}
+
+func validatePort(port int32) error {
+	if port < 1 || port > 65535 {
+		return errors.NotValidf("port %d", port)
+	}
+	return nil
+}
+
+// Validate returns an error if the spec is not valid.
+func (p *Probe) Validate() error {
+	var handlers int
+	if p.Exec != nil {
+		handlers++
+		if len(p.Exec.Command) == 0 {
+			return errors.New("exec command is missing")
+		}
+	}
+	if p.HTTPGet != nil {
+		handlers++
+		if err := validatePort(p.HTTPGet.Port); err != nil {
+			return errors.Trace(err)
+		}
+		switch strings.ToUpper(p.HTTPGet.Scheme) {
+		case "", "HTTP", "HTTPS":
+		default:
+			return errors.NotValidf("httpGet scheme %q", p.HTTPGet.Scheme)
+		}
+	}
+	if p.TCPSocket != nil {
+		handlers++
+		if err := validatePort(p.TCPSocket.Port); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if handlers != 1 {
+		return errors.New("exactly one of exec, httpGet or tcpSocket is required")
+	}
+	for name, v := range map[string]int32{
+		"initialDelaySeconds": p.InitialDelaySeconds,
+		"timeoutSeconds":      p.TimeoutSeconds,
+		"periodSeconds":       p.PeriodSeconds,
+		"successThreshold":    p.SuccessThreshold,
+		"failureThreshold":    p.FailureThreshold,
+	} {
+		if v < 0 {
+			return errors.NotValidf("negative %s %d", name, v)
+		}
+	}
+	return nil
+}
+
+// ContainerProbes defines the checks made against a container.
+type ContainerProbes struct {
+	Liveness  *Probe `json:"liveness,omitempty" yaml:"liveness,omitempty"`
+	Readiness *Probe `json:"readiness,omitempty" yaml:"readiness,omitempty"`
+}
+
+// Validate returns an error if the spec is not valid.
+func (cp *ContainerProbes) Validate() error {
+	if cp.Liveness != nil {
+		if err := cp.Liveness.Validate(); err != nil {
+			return errors.Annotate(err, "liveness probe")
+		}
+	}
+	if cp.Readiness != nil {
+		if err := cp.Readiness.Validate(); err != nil {
+			return errors.Annotate(err, "readiness probe")
+		}
+	}
+	return nil
+}
+
+// ResourceList defines an amount of compute resources,
+// eg "500m" of cpu or "1Gi" of memory.
+type ResourceList struct {
+	CPU    string `json:"cpu,omitempty" yaml:"cpu,omitempty"`
+	Memory string `json:"memory,omitempty" yaml:"memory,omitempty"`
+}
+
+// ResourceRequirements defines the compute resources
+// requested by a container and the limits it may use.
+type ResourceRequirements struct {
+	Limits   ResourceList `json:"limits,omitempty" yaml:"limits,omitempty"`
+	Requests ResourceList `json:"requests,omitempty" yaml:"requests,omitempty"`
+}
+
+// Validate returns an error if the spec is not valid.
+func (r *ResourceRequirements) Validate() error {
+	if r.Limits == (ResourceList{}) && r.Requests == (ResourceList{}) {
+		return errors.New("resources require limits or requests")
+	}
+	return nil
+}
+
+// CheckVersion returns an error if the container spec uses
+// fields which are not supported by the pod spec version.
+func (spec *ContainerSpec) CheckVersion(ver Version) error {
+	if ver >= Version3 {
+		return nil
+	}
+	var fields []string
+	if len(spec.EnvFrom) > 0 {
+		fields = append(fields, "envFrom")
+	}
+	if spec.Probes != nil {
+		fields = append(fields, "probes")
+	}
+	if spec.Resources != nil {
+		fields = append(fields, "resources")
+	}
+	if len(fields) > 0 {
+		return errors.NewNotSupported(nil, fmt.Sprintf(
+			"container %q: %s requires podspec version %d", spec.Name, strings.Join(fields, ", "), Version3))
+	}
+	return nil
+}
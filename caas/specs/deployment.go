@@ -0,0 +1,75 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package specs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// DeploymentType defines the kind of workload used to run the
+// units of an application.
+type DeploymentType string
+
+const (
+	// DeploymentStateless runs units which share no state
+	// and have no stable identity, eg a k8s deployment.
+	DeploymentStateless DeploymentType = "stateless"
+
+	// DeploymentStateful runs units with a stable identity and
+	// storage of their own, eg a k8s stateful set.
+	DeploymentStateful DeploymentType = "stateful"
+
+	// DeploymentDaemon runs a unit on each node, eg a k8s daemon set.
+	DeploymentDaemon DeploymentType = "daemon"
+)
+
+// Validate returns an error if the deployment type is not valid.
+func (dt DeploymentType) Validate() error {
+	switch dt {
+	case "", DeploymentStateless, DeploymentStateful, DeploymentDaemon:
+		return nil
+	}
+	return errors.NotSupportedf("deployment type %q", dt)
+}
+
+// UpdateStrategy defines how the units of a stateful application
+// are replaced when the pod spec changes, eg on upgrade-charm.
+type UpdateStrategy struct {
+	// Partition is the ordinal at and above which units are
+	// updated; units with a lower ordinal keep the previous spec.
+	// Setting it to the number of units less one updates a single
+	// canary unit, and lowering it to 0 continues the upgrade.
+	Partition *int32 `json:"partition,omitempty"`
+}
+
+// Validate returns an error if the spec is not valid.
+func (us *UpdateStrategy) Validate() error {
+	if us.Partition != nil && *us.Partition < 0 {
+		return errors.NotValidf("negative update partition %d", *us.Partition)
+	}
+	return nil
+}
+
+// CheckVersion returns an error if the service spec uses
+// fields which are not supported by the pod spec version.
+func (ss *ServiceSpec) CheckVersion(ver Version) error {
+	if ver >= Version3 {
+		return nil
+	}
+	var fields []string
+	if ss.DeploymentType != "" {
+		fields = append(fields, "deploymentType")
+	}
+	if ss.UpdateStrategy != nil {
+		fields = append(fields, "updateStrategy")
+	}
+	if len(fields) > 0 {
+		return errors.NewNotSupported(nil, fmt.Sprintf(
+			"service: %s requires podspec version %d", strings.Join(fields, ", "), Version3))
+	}
+	return nil
+}
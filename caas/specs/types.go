@@ -11,10 +11,10 @@ import (
 )
 
 // CurrentVersion is the latest version of pod spec.
-const CurrentVersion Version = Version2
+const CurrentVersion Version = Version3
 
 // PodSpec is the current version of pod spec.
-type PodSpec = PodSpecV2
+type PodSpec = PodSpecV3
 
 // FileSet defines a set of files to mount
 // into the container.
@@ -71,6 +71,11 @@ type ContainerSpec struct {
 
 	ImagePullPolicy PullPolicy `json:"imagePullPolicy,omitempty" yaml:"imagePullPolicy,omitempty"`
 
+	// EnvFrom, Probes and Resources are supported from podspec version 3.
+	EnvFrom   []EnvFromSource       `json:"envFrom,omitempty" yaml:"envFrom,omitempty"`
+	Probes    *ContainerProbes      `json:"probes,omitempty" yaml:"probes,omitempty"`
+	Resources *ResourceRequirements `json:"resources,omitempty" yaml:"resources,omitempty"`
+
 	// ProviderContainer defines config which is specific to a substrate, eg k8s
 	ProviderContainer `json:"-" yaml:"-"`
 }
@@ -93,6 +98,21 @@ func (spec *ContainerSpec) Validate() error {
 			return errors.Trace(err)
 		}
 	}
+	for _, envFrom := range spec.EnvFrom {
+		if err := envFrom.Validate(); err != nil {
+			return errors.Annotatef(err, "container %q", spec.Name)
+		}
+	}
+	if spec.Probes != nil {
+		if err := spec.Probes.Validate(); err != nil {
+			return errors.Annotatef(err, "container %q", spec.Name)
+		}
+	}
+	if spec.Resources != nil {
+		if err := spec.Resources.Validate(); err != nil {
+			return errors.Annotatef(err, "container %q", spec.Name)
+		}
+	}
 	if spec.ProviderContainer != nil {
 		return spec.ProviderContainer.Validate()
 	}
@@ -138,11 +158,33 @@ var supportedPolicies = []ScalePolicyType{
 type ServiceSpec struct {
 	ScalePolicy ScalePolicyType   `json:"scalePolicy,omitempty"`
 	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// DeploymentType overrides the kind of workload used to run the
+	// application's units, which is otherwise chosen by the substrate.
+	DeploymentType DeploymentType `json:"deploymentType,omitempty"`
+
+	// UpdateStrategy controls how the units of a stateful
+	// application are updated.
+	UpdateStrategy *UpdateStrategy `json:"updateStrategy,omitempty"`
 }
 
 // Validate returns an error if the spec is not valid.
 func (ss ServiceSpec) Validate() error {
-	return errors.Trace(ss.ScalePolicy.Validate())
+	if err := ss.ScalePolicy.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := ss.DeploymentType.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if ss.UpdateStrategy != nil {
+		if ss.DeploymentType != "" && ss.DeploymentType != DeploymentStateful {
+			return errors.NotValidf("update strategy for %s deployment", ss.DeploymentType)
+		}
+		if err := ss.UpdateStrategy.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
 }
 
 // Version describes pod spec version type.
@@ -4,6 +4,7 @@
 package specs_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -99,6 +100,31 @@ func (s *typesSuite) TestValidateServiceSpec(c *gc.C) {
 		ScalePolicy: "serial",
 	}
 	c.Assert(spec.Validate(), jc.ErrorIsNil)
+
+	spec = specs.ServiceSpec{
+		DeploymentType: "batch",
+	}
+	c.Assert(spec.Validate(), gc.ErrorMatches, `deployment type "batch" not supported`)
+
+	partition := int32(-1)
+	spec = specs.ServiceSpec{
+		UpdateStrategy: &specs.UpdateStrategy{Partition: &partition},
+	}
+	c.Assert(spec.Validate(), gc.ErrorMatches, `negative update partition -1 not valid`)
+
+	partition = 2
+	spec = specs.ServiceSpec{
+		DeploymentType: specs.DeploymentStateless,
+		UpdateStrategy: &specs.UpdateStrategy{Partition: &partition},
+	}
+	c.Assert(spec.Validate(), gc.ErrorMatches, `update strategy for stateless deployment not valid`)
+
+	spec = specs.ServiceSpec{
+		ScalePolicy:    "serial",
+		DeploymentType: specs.DeploymentStateful,
+		UpdateStrategy: &specs.UpdateStrategy{Partition: &partition},
+	}
+	c.Assert(spec.Validate(), jc.ErrorIsNil)
 }
 
 func (s *typesSuite) TestValidateContainerSpec(c *gc.C) {
@@ -139,6 +165,81 @@ func (s *typesSuite) TestValidateContainerSpec(c *gc.C) {
 			},
 			errStr: "",
 		},
+		{
+			spec: &specs.ContainerSpec{
+				Name:    "container1",
+				Image:   "gitlab",
+				EnvFrom: []specs.EnvFromSource{{Prefix: "DB_"}},
+			},
+			errStr: `container "container1": envFrom requires a config map or secret`,
+		},
+		{
+			spec: &specs.ContainerSpec{
+				Name:    "container1",
+				Image:   "gitlab",
+				EnvFrom: []specs.EnvFromSource{{ConfigMap: "mydata", Secret: "mysecret"}},
+			},
+			errStr: `container "container1": envFrom config map "mydata" and secret "mysecret" cannot both be specified`,
+		},
+		{
+			spec: &specs.ContainerSpec{
+				Name:  "container1",
+				Image: "gitlab",
+				Probes: &specs.ContainerProbes{
+					Liveness: &specs.Probe{},
+				},
+			},
+			errStr: `container "container1": liveness probe: exactly one of exec, httpGet or tcpSocket is required`,
+		},
+		{
+			spec: &specs.ContainerSpec{
+				Name:  "container1",
+				Image: "gitlab",
+				Probes: &specs.ContainerProbes{
+					Readiness: &specs.Probe{
+						HTTPGet: &specs.HTTPGetAction{Path: "/ready", Port: 80, Scheme: "ftp"},
+					},
+				},
+			},
+			errStr: `container "container1": readiness probe: httpGet scheme "ftp" not valid`,
+		},
+		{
+			spec: &specs.ContainerSpec{
+				Name:  "container1",
+				Image: "gitlab",
+				Probes: &specs.ContainerProbes{
+					Liveness: &specs.Probe{
+						TCPSocket: &specs.TCPSocketAction{Port: 70000},
+					},
+				},
+			},
+			errStr: `container "container1": liveness probe: port 70000 not valid`,
+		},
+		{
+			spec: &specs.ContainerSpec{
+				Name:      "container1",
+				Image:     "gitlab",
+				Resources: &specs.ResourceRequirements{},
+			},
+			errStr: `container "container1": resources require limits or requests`,
+		},
+		{
+			spec: &specs.ContainerSpec{
+				Name:    "container1",
+				Image:   "gitlab",
+				EnvFrom: []specs.EnvFromSource{{Secret: "mysecret"}},
+				Probes: &specs.ContainerProbes{
+					Liveness: &specs.Probe{
+						Exec:                &specs.ExecAction{Command: []string{"true"}},
+						InitialDelaySeconds: 10,
+					},
+				},
+				Resources: &specs.ResourceRequirements{
+					Limits: specs.ResourceList{Memory: "1Gi"},
+				},
+			},
+			errStr: "",
+		},
 	} {
 		c.Logf("#%d: testing FileSet.Validate", i)
 		err := tc.spec.Validate()
@@ -168,4 +269,37 @@ func (s *typesSuite) TestValidatePodSpecBase(c *gc.C) {
 	c.Assert(minSpecs.Validate(specs.Version2), gc.ErrorMatches, `expected version 2, but found 0`)
 	minSpecs.Version = specs.Version2
 	c.Assert(minSpecs.Validate(specs.Version2), jc.ErrorIsNil)
+
+	minSpecs.Version = specs.Version3
+	c.Assert(minSpecs.Validate(specs.Version3), jc.ErrorIsNil)
+}
+
+func (s *typesSuite) TestContainerSpecCheckVersion(c *gc.C) {
+	spec := specs.ContainerSpec{
+		Name:  "container1",
+		Image: "gitlab",
+	}
+	c.Assert(spec.CheckVersion(specs.Version2), jc.ErrorIsNil)
+
+	spec.EnvFrom = []specs.EnvFromSource{{ConfigMap: "mydata"}}
+	spec.Resources = &specs.ResourceRequirements{
+		Requests: specs.ResourceList{CPU: "100m"},
+	}
+	c.Assert(spec.CheckVersion(specs.Version3), jc.ErrorIsNil)
+	err := spec.CheckVersion(specs.Version2)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `container "container1": envFrom, resources requires podspec version 3`)
+}
+
+func (s *typesSuite) TestServiceSpecCheckVersion(c *gc.C) {
+	spec := specs.ServiceSpec{
+		ScalePolicy: "serial",
+	}
+	c.Assert(spec.CheckVersion(specs.Version2), jc.ErrorIsNil)
+
+	spec.DeploymentType = specs.DeploymentDaemon
+	c.Assert(spec.CheckVersion(specs.Version3), jc.ErrorIsNil)
+	err := spec.CheckVersion(specs.Version2)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `service: deploymentType requires podspec version 3`)
 }
@@ -28,6 +28,11 @@ type APIClient interface {
 	// Action.
 	Enqueue(params.Actions) (params.ActionResults, error)
 
+	// EnqueueOperation queues up an action to be run on the given units
+	// a batch at a time, returning the id of the operation tracking it
+	// along with the actions queued for the first batch of units.
+	EnqueueOperation(params.EnqueueOperationArgs) (params.EnqueueOperationResult, error)
+
 	// ListAll takes a list of Tags representing ActionReceivers and returns
 	// all of the Actions that have been queued or run by each of those
 	// Entities.
@@ -55,7 +60,7 @@ type APIClient interface {
 	Actions(params.Entities) (params.ActionResults, error)
 
 	// Operations fetches the called functions (actions) for specified apps/units.
-	Operations(params.OperationQueryArgs) (params.ActionResults, error)
+	Operations(params.OperationQueryArgs) (params.OperationResults, error)
 
 	// FindActionTagsByPrefix takes a list of string prefixes and finds
 	// corresponding ActionTags that match that prefix.
@@ -67,6 +72,10 @@ type APIClient interface {
 
 	// WatchActionProgress reports on logged action progress messages.
 	WatchActionProgress(actionId string) (watcher.StringsWatcher, error)
+
+	// WatchActionOutput streams logged action progress messages, and
+	// lines the action writes to stdout and stderr, as they happen.
+	WatchActionOutput(actionId string) (<-chan params.ActionMessage, error)
 }
 
 // ActionCommandBase is the base type for action sub-commands.
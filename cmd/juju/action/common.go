@@ -150,7 +150,11 @@ func formatTimestamp(timestamp time.Time, progressFormat, utc, plain bool) strin
 }
 
 func formatLogMessage(actionMessage coreactions.ActionMessage, progressFormat, utc, plain bool) string {
-	return fmt.Sprintf("%v %v", formatTimestamp(actionMessage.Timestamp, progressFormat, utc, plain), actionMessage.Message)
+	timestamp := formatTimestamp(actionMessage.Timestamp, progressFormat, utc, plain)
+	if actionMessage.Stream != "" {
+		return fmt.Sprintf("%v [%v] %v", timestamp, actionMessage.Stream, actionMessage.Message)
+	}
+	return fmt.Sprintf("%v %v", timestamp, actionMessage.Message)
 }
 
 // processLogMessages starts a go routine to decode and handle any incoming
@@ -180,3 +184,23 @@ func processLogMessages(
 		}
 	}()
 }
+
+// processOutputMessages starts a go routine to handle the action messages
+// and lines of output streamed from the controller. The returned channel
+// is closed once the stream has ended.
+func processOutputMessages(
+	messages <-chan params.ActionMessage, ctx *cmd.Context, utc bool, handler func(*cmd.Context, string),
+) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for msg := range messages {
+			handler(ctx, formatLogMessage(coreactions.ActionMessage{
+				Timestamp: msg.Timestamp,
+				Message:   msg.Message,
+				Stream:    msg.Stream,
+			}, true, utc, true))
+		}
+	}()
+	return done
+}
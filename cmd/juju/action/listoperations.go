@@ -34,6 +34,7 @@ type listOperationsCommand struct {
 	unitNames        []string
 	functionNames    []string
 	statusValues     []string
+	operationIds     []string
 }
 
 const listOperationsDoc = `
@@ -43,6 +44,9 @@ A completed operation is one that has run successfully, been cancelled, or faile
 
 When an application is specified, all units from that application are relevant.
 
+When operation ids are specified, only the actions run as part of those
+operations, such as by 'juju run-action --batch-size', are listed.
+
 Examples:
     juju operations
     juju operations --format yaml
@@ -51,6 +55,7 @@ Examples:
     juju operations --units mysql/0,mediawiki/1
     juju operations --status pending,completed
     juju operations --apps mysql --units mediawiki/0 --status running --functions backup
+    juju operations --operations 1 --status pending,running,completed
 
 See also:
     call
@@ -73,6 +78,7 @@ func (c *listOperationsCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewStringsValue(nil, &c.unitNames), "units", "Comma separated list of units to filter on")
 	f.Var(cmd.NewStringsValue(nil, &c.functionNames), "functions", "Comma separated list of function names to filter on")
 	f.Var(cmd.NewStringsValue([]string{params.ActionCompleted}, &c.statusValues), "status", "Comma separated list of operation status values to filter on")
+	f.Var(cmd.NewStringsValue(nil, &c.operationIds), "operations", "Comma separated list of operation ids to filter on")
 }
 
 func (c *listOperationsCommand) Info() *cmd.Info {
@@ -128,6 +134,7 @@ func (c *listOperationsCommand) Run(ctx *cmd.Context) error {
 		Units:         c.unitNames,
 		FunctionNames: c.functionNames,
 		Status:        c.statusValues,
+		Operations:    c.operationIds,
 	}
 	results, err := api.Operations(args)
 	if err != nil {
@@ -135,7 +142,10 @@ func (c *listOperationsCommand) Run(ctx *cmd.Context) error {
 	}
 
 	out := make(map[string]interface{})
-	var actionResults byId = results.Results
+	var actionResults byId = results.Actions
+	for _, op := range results.Results {
+		actionResults = append(actionResults, op.Actions...)
+	}
 	if len(actionResults) == 0 {
 		fmt.Fprintln(ctx.Stderr, "no matching operations")
 		return nil
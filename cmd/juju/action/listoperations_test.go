@@ -84,6 +84,7 @@ func (s *ListOperationsSuite) TestRunQueryArgs(c *gc.C) {
 		"--units", "mysql/1,mediawiki/0",
 		"--functions", "backup",
 		"--status", "completed,pending",
+		"--operations", "1,2",
 	}
 	for _, modelFlag := range s.modelFlags {
 		s.wrappedCommand, s.command = action.NewListOperationsCommandForTest(s.store)
@@ -96,6 +97,7 @@ func (s *ListOperationsSuite) TestRunQueryArgs(c *gc.C) {
 			Units:         []string{"mysql/1", "mediawiki/0"},
 			FunctionNames: []string{"backup"},
 			Status:        []string{"completed", "pending"},
+			Operations:    []string{"1", "2"},
 		})
 	}
 }
@@ -206,3 +208,34 @@ func (s *ListOperationsSuite) TestRunYaml(c *gc.C) {
 		c.Check(ctx.Stdout.(*bytes.Buffer).String(), gc.Equals, expected)
 	}
 }
+
+func (s *ListOperationsSuite) TestRunOperationActions(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		actionResults: listOperationResults[2:3],
+		operationResults: []params.OperationResult{{
+			Operation:     "1",
+			Action:        "backup",
+			Status:        "running",
+			QueuedUnits:   []string{"mysql/0", "mysql/1"},
+			UnqueuedUnits: []string{"mysql/2"},
+			Actions:       listOperationResults[:2],
+		}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	s.wrappedCommand, _ = action.NewListOperationsCommandForTest(s.store)
+	for _, modelFlag := range s.modelFlags {
+		s.wrappedCommand, s.command = action.NewListOperationsCommandForTest(s.store)
+		ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, modelFlag, "admin", "--utc")
+		c.Assert(err, jc.ErrorIsNil)
+		expected := `
+Id  Operation  Status     Unit     Time
+ 3  vacuum     pending    mysql/1  2013-02-14T06:06:06
+ 2  restore    running    mysql/1  2014-02-14T06:06:06
+ 1  backup     completed  mysql/0  2015-02-14T06:06:06
+
+`[1:]
+		c.Check(ctx.Stdout.(*bytes.Buffer).String(), gc.Equals, expected)
+	}
+}
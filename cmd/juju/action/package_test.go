@@ -140,8 +140,10 @@ type fakeAPIClient struct {
 	delay              *time.Timer
 	timeout            *time.Timer
 	actionResults      []params.ActionResult
+	operationResults   []params.OperationResult
 	operationQueryArgs params.OperationQueryArgs
 	enqueuedActions    params.Actions
+	enqueuedOperation  params.EnqueueOperationArgs
 	actionsByReceivers []params.ActionsByReceiver
 	actionTagMatches   params.FindTagsResults
 	actionsByNames     params.ActionsByNames
@@ -149,6 +151,7 @@ type fakeAPIClient struct {
 	apiVersion         int
 	apiErr             error
 	logMessageCh       chan []string
+	outputMessageCh    chan params.ActionMessage
 	waitForResults     chan bool
 }
 
@@ -173,6 +176,14 @@ func (c *fakeAPIClient) Enqueue(args params.Actions) (params.ActionResults, erro
 	return params.ActionResults{Results: c.actionResults}, c.apiErr
 }
 
+func (c *fakeAPIClient) EnqueueOperation(args params.EnqueueOperationArgs) (params.EnqueueOperationResult, error) {
+	c.enqueuedOperation = args
+	return params.EnqueueOperationResult{
+		Operation: "1",
+		Actions:   c.actionResults,
+	}, c.apiErr
+}
+
 func (c *fakeAPIClient) ListAll(args params.Entities) (params.ActionsByReceivers, error) {
 	return params.ActionsByReceivers{
 		Actions: c.actionsByReceivers,
@@ -267,9 +278,17 @@ func (c *fakeAPIClient) WatchActionProgress(actionId string) (watcher.StringsWat
 	return watchertest.NewMockStringsWatcher(c.logMessageCh), nil
 }
 
-func (c *fakeAPIClient) Operations(args params.OperationQueryArgs) (params.ActionResults, error) {
+func (c *fakeAPIClient) WatchActionOutput(actionId string) (<-chan params.ActionMessage, error) {
+	if c.outputMessageCh == nil {
+		return nil, errors.NotSupportedf("WatchActionOutput")
+	}
+	return c.outputMessageCh, nil
+}
+
+func (c *fakeAPIClient) Operations(args params.OperationQueryArgs) (params.OperationResults, error) {
 	c.operationQueryArgs = args
-	return params.ActionResults{
-		Results: c.actionResults,
+	return params.OperationResults{
+		Results: c.operationResults,
+		Actions: c.actionResults,
 	}, c.apiErr
 }
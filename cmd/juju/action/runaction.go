@@ -35,6 +35,11 @@ type runActionCommand struct {
 	paramsYAML    cmd.FileVar
 	parseStrings  bool
 	wait          waitFlag
+	timeout       time.Duration
+	queueTimeout  time.Duration
+	batchSize     int
+	leaderFirst   bool
+	stopOnFailure bool
 	out           cmd.Output
 	args          [][]string
 }
@@ -63,6 +68,21 @@ is a boolean true in YAML.
 If --params is passed, along with key.key...=value explicit arguments, the
 explicit arguments will override the parameter file.
 
+The --timeout option limits how long the action may run for once started;
+an action still running after that is stopped and marked as failed. The
+--queue-timeout option limits how long the action may wait to be started;
+an action still pending after that is marked as cancelled. By default
+actions may wait and run indefinitely.
+
+The --batch-size option runs the action on the given units a batch at a
+time rather than all at once, as a single operation: the action is queued
+on the next batch of units only once it has finished on every unit of the
+previous batch. With --leader-first the leader unit of each application is
+run before any of the application's other units, and with --stop-on-failure
+no further batches are run once the action has failed on any unit. The
+actions run by the operation can be listed with 'juju operations
+--operations <ID>'.
+
 Examples:
 
     juju run-action mysql/3 backup --wait
@@ -74,6 +94,8 @@ Examples:
     juju run-action mysql/3 backup --params p.yml file.kind=xz file.quality=high
     juju run-action sleeper/0 pause time=1000
     juju run-action sleeper/0 pause --string-args time=1000
+    juju run-action mysql/3 backup --timeout 30m --queue-timeout 5m
+    juju run-action mysql/0 mysql/1 mysql/2 restart --batch-size 1 --leader-first --stop-on-failure
 `
 
 // SetFlags offers an option for YAML output.
@@ -83,6 +105,11 @@ func (c *runActionCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(&c.paramsYAML, "params", "Path to yaml-formatted params file")
 	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
 	f.Var(&c.wait, "wait", "Wait for results, with optional timeout")
+	f.DurationVar(&c.timeout, "timeout", 0, "Fail the action if it runs for longer than this")
+	f.DurationVar(&c.queueTimeout, "queue-timeout", 0, "Cancel the action if it is not started within this time")
+	f.IntVar(&c.batchSize, "batch-size", 0, "Run the action on this many units at a time")
+	f.BoolVar(&c.leaderFirst, "leader-first", false, "Run the action on application leaders first (requires --batch-size)")
+	f.BoolVar(&c.stopOnFailure, "stop-on-failure", false, "Stop running further batches once the action fails (requires --batch-size)")
 }
 
 func (c *runActionCommand) Info() *cmd.Info {
@@ -112,6 +139,24 @@ func (c *runActionCommand) Init(args []string) (err error) {
 	if c.actionName == "" {
 		return errors.New("no action specified")
 	}
+	if c.timeout < 0 {
+		return errors.New("timeout must not be negative")
+	}
+	if c.queueTimeout < 0 {
+		return errors.New("queue timeout must not be negative")
+	}
+	if c.batchSize < 0 {
+		return errors.New("batch size must not be negative")
+	}
+	if c.batchSize == 0 && c.leaderFirst {
+		return errors.New("--leader-first requires --batch-size")
+	}
+	if c.batchSize == 0 && c.stopOnFailure {
+		return errors.New("--stop-on-failure requires --batch-size")
+	}
+	if c.batchSize > 0 && (c.wait.forever || c.wait.d > 0) {
+		return errors.New("--wait is not supported with --batch-size")
+	}
 
 	// Parse CLI key-value args if they exist.
 	c.args = make([][]string, 0)
@@ -207,6 +252,11 @@ func (c *runActionCommand) Run(ctx *cmd.Context) error {
 		}
 		actions[i].Name = c.actionName
 		actions[i].Parameters = actionParams
+		actions[i].QueueTimeout = c.queueTimeout
+		actions[i].ExecutionTimeout = c.timeout
+	}
+	if c.batchSize > 0 {
+		return c.enqueueOperation(ctx, actions)
 	}
 	results, err := c.api.Enqueue(params.Actions{Actions: actions})
 	if err != nil {
@@ -285,6 +335,52 @@ func (c *runActionCommand) Run(ctx *cmd.Context) error {
 	return c.out.Write(ctx, out)
 }
 
+// enqueueOperation queues the given actions as a single operation, run
+// on their receivers a batch at a time, and reports the operation id
+// along with the actions queued for the first batch.
+func (c *runActionCommand) enqueueOperation(ctx *cmd.Context, actions []params.Action) error {
+	receivers := make([]string, len(actions))
+	for i, action := range actions {
+		receivers[i] = action.Receiver
+	}
+	result, err := c.api.EnqueueOperation(params.EnqueueOperationArgs{
+		Receivers:        receivers,
+		Name:             c.actionName,
+		Parameters:       actions[0].Parameters,
+		BatchSize:        c.batchSize,
+		LeaderFirst:      c.leaderFirst,
+		StopOnFailure:    c.stopOnFailure,
+		QueueTimeout:     c.queueTimeout,
+		ExecutionTimeout: c.timeout,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	queued := make(map[string]interface{}, len(result.Actions))
+	for _, actionResult := range result.Actions {
+		if actionResult.Error != nil {
+			return actionResult.Error
+		}
+		actionTag, err := names.ParseActionTag(actionResult.Action.Tag)
+		if err != nil {
+			return err
+		}
+		unitTag, err := names.ParseUnitTag(actionResult.Action.Receiver)
+		if err != nil {
+			return err
+		}
+		queued[actionResult.Action.Receiver] = map[string]string{
+			"id":   actionTag.Id(),
+			"unit": unitTag.Id(),
+		}
+	}
+	return c.out.Write(ctx, map[string]interface{}{
+		"operation": result.Operation,
+		"queued":    queued,
+	})
+}
+
 func (c *runActionCommand) ensureAPI() (err error) {
 	if c.api != nil {
 		return nil
@@ -5,7 +5,9 @@ package action_test
 
 import (
 	"bytes"
+	"fmt"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/juju/cmd/cmdtesting"
@@ -181,6 +183,30 @@ func (s *RunActionSuite) TestInit(c *gc.C) {
 		expectUnits:  []string{"mysql/leader"},
 		expectAction: "valid-action-name",
 		expectKVArgs: [][]string{},
+	}, {
+		should:      "fail with negative timeout",
+		args:        []string{validUnitId, "valid-action-name", "--timeout", "-1s"},
+		expectError: "timeout must not be negative",
+	}, {
+		should:      "fail with negative queue timeout",
+		args:        []string{validUnitId, "valid-action-name", "--queue-timeout", "-1s"},
+		expectError: "queue timeout must not be negative",
+	}, {
+		should:      "fail with negative batch size",
+		args:        []string{validUnitId, "valid-action-name", "--batch-size", "-1"},
+		expectError: "batch size must not be negative",
+	}, {
+		should:      "fail with leader first but no batch size",
+		args:        []string{validUnitId, "valid-action-name", "--leader-first"},
+		expectError: "--leader-first requires --batch-size",
+	}, {
+		should:      "fail with stop on failure but no batch size",
+		args:        []string{validUnitId, "valid-action-name", "--stop-on-failure"},
+		expectError: "--stop-on-failure requires --batch-size",
+	}, {
+		should:      "fail with wait and batch size",
+		args:        []string{validUnitId, "valid-action-name", "--batch-size", "1", "--wait"},
+		expectError: "--wait is not supported with --batch-size",
 	}}
 
 	for i, t := range tests {
@@ -394,6 +420,19 @@ func (s *RunActionSuite) TestRun(c *gc.C) {
 			Parameters: map[string]interface{}{},
 			Receiver:   "mysql/leader",
 		},
+	}, {
+		should:   "enqueue an action with timeouts",
+		withArgs: []string{validUnitId, "some-action", "--timeout", "30m", "--queue-timeout", "5m"},
+		withActionResults: []params.ActionResult{{
+			Action: &params.Action{Tag: validActionTagString},
+		}},
+		expectedActionEnqueued: params.Action{
+			Name:             "some-action",
+			Parameters:       map[string]interface{}{},
+			Receiver:         names.NewUnitTag(validUnitId).String(),
+			QueueTimeout:     5 * time.Minute,
+			ExecutionTimeout: 30 * time.Minute,
+		},
 	}}
 
 	for i, t := range tests {
@@ -447,3 +486,49 @@ func (s *RunActionSuite) TestRun(c *gc.C) {
 		}
 	}
 }
+
+func (s *RunActionSuite) TestRunBatched(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		actionResults: []params.ActionResult{{
+			Action: &params.Action{
+				Tag:      validActionTagString,
+				Receiver: names.NewUnitTag(validUnitId).String(),
+			},
+			Operation: "1",
+		}},
+		apiVersion: 6,
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewRunActionCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, wrappedCommand,
+		"-m", "admin", validUnitId, validUnitId2, "mysql/leader", "some-action",
+		"--batch-size", "1", "--leader-first", "--stop-on-failure", "--timeout", "1m")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(fakeClient.enqueuedOperation, jc.DeepEquals, params.EnqueueOperationArgs{
+		Receivers: []string{
+			names.NewUnitTag(validUnitId).String(),
+			names.NewUnitTag(validUnitId2).String(),
+			"mysql/leader",
+		},
+		Name:             "some-action",
+		Parameters:       map[string]interface{}{},
+		BatchSize:        1,
+		LeaderFirst:      true,
+		StopOnFailure:    true,
+		ExecutionTimeout: time.Minute,
+	})
+	c.Check(fakeClient.EnqueuedActions().Actions, gc.HasLen, 0)
+
+	tag, err := names.ParseActionTag(validActionTagString)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, fmt.Sprintf(`
+operation: "1"
+queued:
+  unit-mysql-0:
+    id: %s
+    unit: mysql/0
+`[1:], tag.Id()))
+}
@@ -61,7 +61,8 @@ Show the results returned by an action with the given ID.
 To block until the result is known completed or failed, use
 the --wait option with a duration, as in --wait 5s or --wait 1h.
 If units are left off, seconds are assumed.
-Use --watch to wait indefinitely.  
+Use --watch to wait indefinitely, showing messages logged by the
+action and the lines it writes to stdout and stderr as they happen.  
 
 The default behavior without --wait or --watch is to immediately check and return;
 if the results are "pending" then only the available information will be
@@ -178,7 +179,12 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 
 	actionDone := make(chan struct{})
 	var logsWatcher watcher.StringsWatcher
+	var outputDone <-chan struct{}
 	haveLogs := false
+	logMessageHandler := func(ctx *cmd.Context, msg string) {
+		haveLogs = true
+		c.logMessageHandler(ctx, msg)
+	}
 
 	shouldWatch := waitDur.Nanoseconds() >= 0
 	if shouldWatch {
@@ -190,15 +196,22 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 			result.Status == params.ActionRunning
 	}
 
-	if shouldWatch && api.BestAPIVersion() >= 5 {
+	if shouldWatch && c.watch {
+		// Tail the action's messages and output over a websocket
+		// if the controller supports it.
+		messages, err := api.WatchActionOutput(c.requestedId)
+		if err == nil {
+			outputDone = processOutputMessages(messages, ctx, c.utc, logMessageHandler)
+		} else if !errors.IsNotSupported(err) {
+			return errors.Trace(err)
+		}
+	}
+	if shouldWatch && outputDone == nil && api.BestAPIVersion() >= 5 {
 		logsWatcher, err = api.WatchActionProgress(c.requestedId)
 		if err != nil {
 			return errors.Trace(err)
 		}
-		processLogMessages(logsWatcher, actionDone, ctx, c.utc, func(ctx *cmd.Context, msg string) {
-			haveLogs = true
-			c.logMessageHandler(ctx, msg)
-		})
+		processLogMessages(logsWatcher, actionDone, ctx, c.utc, logMessageHandler)
 	}
 
 	result, err := GetActionResult(api, c.requestedId, wait, c.compat)
@@ -206,6 +219,11 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 	if logsWatcher != nil {
 		logsWatcher.Wait()
 	}
+	if outputDone != nil && err == nil {
+		// The stream ends once the action has finished and
+		// all its messages have been sent.
+		<-outputDone
+	}
 	if haveLogs {
 		// Make the logs a bit separate in the output.
 		fmt.Fprintln(ctx.Stderr, "")
@@ -326,6 +344,9 @@ func FormatActionResult(result params.ActionResult, utc, compat bool) map[string
 	if result.Message != "" {
 		response["message"] = result.Message
 	}
+	if result.Operation != "" {
+		response["operation"] = result.Operation
+	}
 	if len(result.Output) != 0 {
 		if compat {
 			output := ConvertActionOutput(result.Output, compat, false)
@@ -334,14 +355,19 @@ func FormatActionResult(result params.ActionResult, utc, compat bool) map[string
 			response["results"] = result.Output
 		}
 	}
-	if len(result.Log) > 0 {
-		var logs []string
-		for _, msg := range result.Log {
-			logs = append(logs, formatLogMessage(actions.ActionMessage{
-				Timestamp: msg.Timestamp,
-				Message:   msg.Message,
-			}, false, utc, false))
+	var logs []string
+	for _, msg := range result.Log {
+		// Lines written to stdout and stderr are already
+		// in the results once the action has finished.
+		if msg.Stream != "" {
+			continue
 		}
+		logs = append(logs, formatLogMessage(actions.ActionMessage{
+			Timestamp: msg.Timestamp,
+			Message:   msg.Message,
+		}, false, utc, false))
+	}
+	if len(logs) > 0 {
 		response["log"] = logs
 	}
 
@@ -377,6 +377,67 @@ timing:
 	}
 }
 
+func (s *ShowOutputSuite) TestWatchStreamsOutput(c *gc.C) {
+	fakeClient := makeFakeClient(
+		0, 5*time.Second,
+		tagsForIdPrefix(validActionId, validActionTagString),
+		[]params.ActionResult{{
+			Status:    "completed",
+			Output:    map[string]interface{}{"Stdout": "hello"},
+			Enqueued:  time.Date(2015, time.February, 14, 8, 13, 0, 0, time.UTC),
+			Completed: time.Date(2015, time.February, 14, 8, 15, 30, 0, time.UTC),
+			Log: []params.ActionMessage{{
+				Message:   "starting",
+				Timestamp: time.Date(2015, time.February, 14, 6, 6, 6, 0, time.UTC),
+			}, {
+				Message:   "hello",
+				Stream:    "stdout",
+				Timestamp: time.Date(2015, time.February, 14, 6, 6, 7, 0, time.UTC),
+			}},
+		}},
+		params.ActionsByNames{},
+		"",
+	)
+	fakeClient.waitForResults = make(chan bool)
+	fakeClient.outputMessageCh = make(chan params.ActionMessage, 2)
+	fakeClient.outputMessageCh <- params.ActionMessage{
+		Message:   "starting",
+		Timestamp: time.Date(2015, time.February, 14, 6, 6, 6, 0, time.UTC),
+	}
+	fakeClient.outputMessageCh <- params.ActionMessage{
+		Message:   "hello",
+		Stream:    "stdout",
+		Timestamp: time.Date(2015, time.February, 14, 6, 6, 7, 0, time.UTC),
+	}
+	close(fakeClient.outputMessageCh)
+	unpatch := s.BaseActionSuite.patchAPIClient(fakeClient)
+	defer unpatch()
+
+	var receivedMessages []string
+	expectedMessages := []string{"06:06:06 starting", "06:06:07 [stdout] hello"}
+	cmd, _ := action.NewShowOutputCommandForTest(s.store, func(_ *cmd.Context, msg string) {
+		receivedMessages = append(receivedMessages, msg)
+		if reflect.DeepEqual(receivedMessages, expectedMessages) {
+			close(fakeClient.waitForResults)
+		}
+	})
+	ctx, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", validActionId, "--utc", "--watch")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(receivedMessages, jc.DeepEquals, expectedMessages)
+	// Only messages logged by the action are included in the result,
+	// its output is already in the results.
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+log:
+- 2015-02-14 06:06:06 +0000 UTC starting
+results:
+  Stdout: hello
+status: completed
+timing:
+  completed: 2015-02-14 08:15:30 +0000 UTC
+  enqueued: 2015-02-14 08:13:00 +0000 UTC
+`[1:])
+}
+
 func testRunHelper(c *gc.C, s *ShowOutputSuite, client *fakeAPIClient,
 	expectedErr, expectedOutput, format, wait, query, modelFlag string,
 	watch bool,
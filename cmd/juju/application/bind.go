@@ -30,6 +30,7 @@ func NewBindCommand() cmd.Command {
 		NewSpacesClient: func(conn base.APICallCloser) SpacesAPI {
 			return spaces.NewAPI(conn)
 		},
+		NewBranchClient: newBranchChangesClient,
 	}
 	return modelcmd.Wrap(cmd)
 }
@@ -47,6 +48,7 @@ type bindCommand struct {
 
 	NewApplicationClient func(base.APICallCloser) ApplicationBindClient
 	NewSpacesClient      func(base.APICallCloser) SpacesAPI
+	NewBranchClient      func(base.APICallCloser) BranchChangesAPI
 
 	ApplicationName string
 	BindExpression  string
@@ -75,6 +77,9 @@ Finally, the above commands can be combined to update both the default space
 and individual endpoints in one go:
 
   juju bind foo new-default endpoint-1=space-1
+
+When a branch other than "master" is active, the binding changes are staged
+in that branch and applied to the application when the branch is committed.
 `
 
 func (c *bindCommand) Info() *cmd.Info {
@@ -157,15 +162,19 @@ func (c *bindCommand) Run(ctx *cmd.Context) error {
 	var bindingsChangelog []string
 	c.Bindings, bindingsChangelog = mergeBindings(curCharmEndpoints, curBindings, c.Bindings, appDefaultSpace)
 
-	err = applicationClient.MergeBindings(params.ApplicationMergeBindingsArgs{
-		Args: []params.ApplicationMergeBindings{
-			{
-				ApplicationTag: names.NewApplicationTag(c.ApplicationName).String(),
-				Bindings:       c.Bindings,
-				Force:          c.Force,
+	if isBranch(generation) {
+		err = c.NewBranchClient(apiRoot).MergeBindings(generation, c.ApplicationName, c.Bindings, c.Force)
+	} else {
+		err = applicationClient.MergeBindings(params.ApplicationMergeBindingsArgs{
+			Args: []params.ApplicationMergeBindings{
+				{
+					ApplicationTag: names.NewApplicationTag(c.ApplicationName).String(),
+					Bindings:       c.Bindings,
+					Force:          c.Force,
+				},
 			},
-		},
-	})
+		})
+	}
 	if err != nil {
 		return errors.Trace(err)
 	}
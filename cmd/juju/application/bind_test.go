@@ -29,6 +29,8 @@ type BindSuite struct {
 	apiConnection     mockAPIConnection
 	applicationClient mockApplicationBindClient
 	spacesClient      mockSpacesClient
+	branchClient      mockBranchChangesAPI
+	store             *jujuclient.MemStore
 	cmd               cmd.Command
 }
 
@@ -60,7 +62,10 @@ func (s *BindSuite) SetUpTest(c *gc.C) {
 		},
 	}
 
+	s.branchClient = mockBranchChangesAPI{Stub: &testing.Stub{}}
+
 	store := jujuclient.NewMemStore()
+	s.store = store
 	store.CurrentControllerName = "foo"
 	store.Controllers["foo"] = jujuclient.ControllerDetails{
 		APIEndpoints: []string{"0.1.2.3:1234"},
@@ -85,6 +90,10 @@ func (s *BindSuite) SetUpTest(c *gc.C) {
 			s.AddCall("NewSpacesClient", conn)
 			return &s.spacesClient
 		},
+		func(conn base.APICallCloser) BranchChangesAPI {
+			s.AddCall("NewBranchClient", conn)
+			return s.branchClient
+		},
 	)
 }
 
@@ -118,6 +127,26 @@ func (s *BindSuite) TestBind(c *gc.C) {
 	})
 }
 
+func (s *BindSuite) TestBindInBranch(c *gc.C) {
+	s.setupAPIConnection(11)
+	s.store.Models["foo"].Models["admin/bar"] = jujuclient.ModelDetails{ActiveBranch: "new-branch"}
+	s.applicationClient.getResults = &params.ApplicationGetResults{
+		EndpointBindings: map[string]string{
+			"ep1": network.AlphaSpaceName,
+			"ep2": "sp2",
+		},
+	}
+
+	_, err := s.runBind(c, "foo", "ep1=sp1")
+	c.Assert(err, jc.ErrorIsNil)
+	s.applicationClient.CheckCallNames(c, "Get")
+	s.branchClient.CheckCalls(c, []testing.StubCall{
+		{"MergeBindings", []interface{}{
+			"new-branch", "foo", map[string]string{"ep1": "sp1", "ep2": "sp2"}, false,
+		}},
+	})
+}
+
 func (s *BindSuite) TestBindWithNoBindings(c *gc.C) {
 	s.setupAPIConnection(11)
 
@@ -0,0 +1,32 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/modelgeneration"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/model"
+)
+
+// BranchChangesAPI defines a subset of the model generation facade,
+// as required for staging application changes under a branch.
+type BranchChangesAPI interface {
+	Close() error
+	SetConstraints(branchName, appName string, cons constraints.Value) error
+	ScaleApplication(branchName, appName string, scale int) error
+	MergeBindings(branchName, appName string, bindings map[string]string, force bool) error
+}
+
+// newBranchChangesClient returns a client for staging
+// application changes under a branch.
+func newBranchChangesClient(conn base.APICallCloser) BranchChangesAPI {
+	return modelgeneration.NewClient(conn)
+}
+
+// isBranch returns true if the input active branch is an in-flight branch
+// rather than master, indicating that changes should be staged under it.
+func isBranch(branchName string) bool {
+	return branchName != "" && branchName != model.GenerationMaster
+}
@@ -58,6 +58,8 @@ This command requires that the application to have at least one unit. To apply
 constraints to
 the first unit set them at the model level or pass them as an argument
 when deploying.
+When a branch other than "master" is active, the constraints are staged in
+that branch and applied to the application when the branch is committed.
 
 Examples:
     juju set-constraints mysql mem=8G cores=4
@@ -153,6 +155,7 @@ func (c *applicationGetConstraintsCommand) Run(ctx *cmd.Context) error {
 type applicationSetConstraintsCommand struct {
 	applicationConstraintsCommand
 	Constraints constraints.Value
+	branchAPI   BranchChangesAPI
 }
 
 // NewApplicationSetConstraintsCommand returns a command which sets application constraints.
@@ -183,7 +186,33 @@ func (c *applicationSetConstraintsCommand) Init(args []string) (err error) {
 	return err
 }
 
+func (c *applicationSetConstraintsCommand) getBranchAPI() (BranchChangesAPI, error) {
+	if c.branchAPI != nil {
+		return c.branchAPI, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newBranchChangesClient(root), nil
+}
+
 func (c *applicationSetConstraintsCommand) Run(_ *cmd.Context) (err error) {
+	branchName, err := c.ActiveBranch()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if isBranch(branchName) {
+		client, err := c.getBranchAPI()
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		err = client.SetConstraints(branchName, c.ApplicationName, c.Constraints)
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+
 	apiclient, err := c.getAPI()
 	if err != nil {
 		return err
@@ -145,10 +145,12 @@ func NewBindCommandForTest(
 	apiOpen api.OpenFunc,
 	newApplicationClient func(base.APICallCloser) ApplicationBindClient,
 	newSpacesClient func(base.APICallCloser) SpacesAPI,
+	newBranchClient func(base.APICallCloser) BranchChangesAPI,
 ) cmd.Command {
 	cmd := &bindCommand{
 		NewApplicationClient: newApplicationClient,
 		NewSpacesClient:      newSpacesClient,
+		NewBranchClient:      newBranchClient,
 	}
 	cmd.SetClientStore(store)
 	cmd.SetAPIOpen(apiOpen)
@@ -260,10 +262,15 @@ func NewRemoveSaasCommandForTest(api RemoveSaasAPI, store jujuclient.ClientStore
 }
 
 // NewScaleCommandForTest returns a ScaleCommand with the api provided as specified.
-func NewScaleCommandForTest(api scaleApplicationAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
-	cmd := &scaleApplicationCommand{newAPIFunc: func() (scaleApplicationAPI, error) {
-		return api, nil
-	}}
+func NewScaleCommandForTest(api scaleApplicationAPI, branchAPI BranchChangesAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &scaleApplicationCommand{
+		newAPIFunc: func() (scaleApplicationAPI, error) {
+			return api, nil
+		},
+		newBranchAPIFunc: func() (BranchChangesAPI, error) {
+			return branchAPI, nil
+		},
+	}
 	cmd.SetClientStore(store)
 	return modelcmd.Wrap(cmd)
 }
@@ -6,8 +6,10 @@ package application
 import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -20,8 +22,21 @@ var usageExposeDetails = `
 Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to allow public access to the application.
 
+By default, the ports opened by the application are accessible from
+0.0.0.0/0. The --to-spaces and --to-cidrs options restrict access to the
+subnets of the given spaces and to the given CIDRs respectively. With
+--endpoints, the options apply only to the given application endpoints;
+settings for endpoints exposed earlier are kept. Ports opened for an
+endpoint (see "open-port --endpoint") are accessible from the sources that
+endpoint is exposed to, or from those of all endpoints if it has no
+settings of its own. Other ports are accessible from the sources of all
+exposed endpoints. This is supported on clouds whose providers manage
+firewall ingress rules, such as ec2, gce and openstack.
+
 Examples:
     juju expose wordpress
+    juju expose wordpress --to-cidrs 10.0.0.0/24,192.168.1.0/24
+    juju expose wordpress --endpoints website --to-spaces public
 
 See also: 
     unexpose`[1:]
@@ -35,6 +50,10 @@ func NewExposeCommand() modelcmd.ModelCommand {
 type exposeCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationName string
+
+	Endpoints      []string
+	ExposeToSpaces []string
+	ExposeToCIDRs  []string
 }
 
 func (c *exposeCommand) Info() *cmd.Info {
@@ -46,6 +65,14 @@ func (c *exposeCommand) Info() *cmd.Info {
 	})
 }
 
+// SetFlags implements cmd.Command.
+func (c *exposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.Var(cmd.NewAppendStringsValue(&c.Endpoints), "endpoints", "Expose only the given comma-delimited application endpoints")
+	f.Var(cmd.NewAppendStringsValue(&c.ExposeToSpaces), "to-spaces", "Expose to the subnets of the given comma-delimited spaces")
+	f.Var(cmd.NewAppendStringsValue(&c.ExposeToCIDRs), "to-cidrs", "Expose to the given comma-delimited CIDRs")
+}
+
 func (c *exposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no application name specified")
@@ -57,7 +84,9 @@ func (c *exposeCommand) Init(args []string) error {
 type applicationExposeAPI interface {
 	Close() error
 	Expose(applicationName string) error
+	ExposeEndpoints(applicationName string, exposedEndpoints map[string]params.ExposedEndpoint) error
 	Unexpose(applicationName string) error
+	UnexposeEndpoints(applicationName string, endpoints []string) error
 }
 
 func (c *exposeCommand) getAPI() (applicationExposeAPI, error) {
@@ -76,5 +105,27 @@ func (c *exposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.Expose(c.ApplicationName), block.BlockChange)
+
+	endpoints := c.Endpoints
+	if len(endpoints) == 0 {
+		// The empty endpoint name applies to all endpoints.
+		endpoints = []string{""}
+	}
+	exposedEndpoints := make(map[string]params.ExposedEndpoint, len(endpoints))
+	for _, endpoint := range endpoints {
+		exposedEndpoints[endpoint] = params.ExposedEndpoint{
+			ExposeToSpaces: c.ExposeToSpaces,
+			ExposeToCIDRs:  c.ExposeToCIDRs,
+		}
+	}
+	err = client.ExposeEndpoints(c.ApplicationName, exposedEndpoints)
+	if errors.IsNotSupported(err) && !c.hasExposeSettings() {
+		// Older controllers can still expose the whole application.
+		err = client.Expose(c.ApplicationName)
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
+
+func (c *exposeCommand) hasExposeSettings() bool {
+	return len(c.Endpoints) > 0 || len(c.ExposeToSpaces) > 0 || len(c.ExposeToCIDRs) > 0
 }
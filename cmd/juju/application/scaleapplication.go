@@ -27,6 +27,13 @@ func NewScaleApplicationCommand() modelcmd.ModelCommand {
 		}
 		return application.NewClient(root), nil
 	}
+	cmd.newBranchAPIFunc = func() (BranchChangesAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return newBranchChangesClient(root), nil
+	}
 	return modelcmd.Wrap(cmd)
 }
 
@@ -35,9 +42,10 @@ type scaleApplicationCommand struct {
 	modelcmd.ModelCommandBase
 	modelcmd.CAASOnlyCommand
 
-	newAPIFunc      func() (scaleApplicationAPI, error)
-	applicationName string
-	scale           int
+	newAPIFunc       func() (scaleApplicationAPI, error)
+	newBranchAPIFunc func() (BranchChangesAPI, error)
+	applicationName  string
+	scale            int
 }
 
 const scaleApplicationDoc = `
@@ -45,6 +53,9 @@ Scale a k8s application by specifying how many units there should be.
 The new number of units can be greater or less than the current number, thus
 allowing both scale up and scale down.
 
+When a branch other than "master" is active, the new scale is staged in that
+branch and applied to the application when the branch is committed.
+
 Examples:
 
     juju scale-application mariadb 2
@@ -90,6 +101,14 @@ type scaleApplicationAPI interface {
 
 // Run implements cmd.Command.
 func (c *scaleApplicationCommand) Run(ctx *cmd.Context) error {
+	branchName, err := c.ActiveBranch()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if isBranch(branchName) {
+		return c.scaleInBranch(ctx, branchName)
+	}
+
 	client, err := c.newAPIFunc()
 	if err != nil {
 		return err
@@ -114,3 +133,18 @@ func (c *scaleApplicationCommand) Run(ctx *cmd.Context) error {
 	ctx.Infof("%v scaled to %d units", c.applicationName, result.Info.Scale)
 	return nil
 }
+
+// scaleInBranch stages the new scale under the input branch.
+func (c *scaleApplicationCommand) scaleInBranch(ctx *cmd.Context, branchName string) error {
+	client, err := c.newBranchAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := client.ScaleApplication(branchName, c.applicationName, c.scale); err != nil {
+		return block.ProcessBlockedError(errors.Annotatef(err, "could not scale application %q", c.applicationName), block.BlockChange)
+	}
+	ctx.Infof("%v scale set to %d units in branch %q", c.applicationName, c.scale, branchName)
+	return nil
+}
@@ -22,7 +22,8 @@ import (
 type ScaleApplicationSuite struct {
 	testing.IsolationSuite
 
-	mockAPI *mockScaleApplicationAPI
+	mockAPI       *mockScaleApplicationAPI
+	mockBranchAPI *mockBranchChangesAPI
 }
 
 var _ = gc.Suite(&ScaleApplicationSuite{})
@@ -47,20 +48,46 @@ func (s mockScaleApplicationAPI) BestAPIVersion() int {
 	return s.version
 }
 
+type mockBranchChangesAPI struct {
+	BranchChangesAPI
+	*testing.Stub
+}
+
+func (s mockBranchChangesAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return s.NextErr()
+}
+
+func (s mockBranchChangesAPI) ScaleApplication(branchName, appName string, scale int) error {
+	s.MethodCall(s, "ScaleApplication", branchName, appName, scale)
+	return s.NextErr()
+}
+
+func (s mockBranchChangesAPI) MergeBindings(branchName, appName string, bindings map[string]string, force bool) error {
+	s.MethodCall(s, "MergeBindings", branchName, appName, bindings, force)
+	return s.NextErr()
+}
+
 func (s *ScaleApplicationSuite) SetUpTest(c *gc.C) {
 	s.IsolationSuite.SetUpTest(c)
 	s.mockAPI = &mockScaleApplicationAPI{Stub: &testing.Stub{}, version: 8}
+	s.mockBranchAPI = &mockBranchChangesAPI{Stub: &testing.Stub{}}
 }
 
 func (s *ScaleApplicationSuite) runScaleApplication(c *gc.C, args ...string) (*cmd.Context, error) {
+	return s.runScaleApplicationInBranch(c, model.GenerationMaster, args...)
+}
+
+func (s *ScaleApplicationSuite) runScaleApplicationInBranch(c *gc.C, branchName string, args ...string) (*cmd.Context, error) {
 	store := jujuclienttesting.MinimalStore()
 	store.Models["arthur"] = &jujuclient.ControllerModels{
 		CurrentModel: "king/sword",
 		Models: map[string]jujuclient.ModelDetails{"king/sword": {
-			ModelType: model.CAAS,
+			ModelType:    model.CAAS,
+			ActiveBranch: branchName,
 		}},
 	}
-	return cmdtesting.RunCommand(c, NewScaleCommandForTest(s.mockAPI, store), args...)
+	return cmdtesting.RunCommand(c, NewScaleCommandForTest(s.mockAPI, s.mockBranchAPI, store), args...)
 }
 
 func (s *ScaleApplicationSuite) TestScaleApplication(c *gc.C) {
@@ -72,6 +99,21 @@ func (s *ScaleApplicationSuite) TestScaleApplication(c *gc.C) {
 	c.Assert(out, gc.Equals, `foo scaled to 2 units`)
 }
 
+func (s *ScaleApplicationSuite) TestScaleApplicationInBranch(c *gc.C) {
+	ctx, err := s.runScaleApplicationInBranch(c, "new-branch", "foo", "2")
+	c.Assert(err, jc.ErrorIsNil)
+
+	stderr := cmdtesting.Stderr(ctx)
+	out := strings.Replace(stderr, "\n", "", -1)
+	c.Assert(out, gc.Equals, `foo scale set to 2 units in branch "new-branch"`)
+
+	s.mockBranchAPI.CheckCalls(c, []testing.StubCall{
+		{"ScaleApplication", []interface{}{"new-branch", "foo", 2}},
+		{"Close", nil},
+	})
+	s.mockAPI.CheckNoCalls(c)
+}
+
 func (s *ScaleApplicationSuite) TestScaleApplicationBlocked(c *gc.C) {
 	s.mockAPI.SetErrors(&params.Error{Code: params.CodeOperationBlocked, Message: "nope"})
 	_, err := s.runScaleApplication(c, "foo", "2")
@@ -81,7 +123,7 @@ func (s *ScaleApplicationSuite) TestScaleApplicationBlocked(c *gc.C) {
 
 func (s *ScaleApplicationSuite) TestScaleApplicationWrongModel(c *gc.C) {
 	store := jujuclienttesting.MinimalStore()
-	_, err := cmdtesting.RunCommand(c, NewScaleCommandForTest(s.mockAPI, store), "foo", "2")
+	_, err := cmdtesting.RunCommand(c, NewScaleCommandForTest(s.mockAPI, s.mockBranchAPI, store), "foo", "2")
 	c.Assert(err, gc.ErrorMatches, `Juju command "scale-application" not supported on non-container models`)
 }
 
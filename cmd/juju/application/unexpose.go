@@ -6,6 +6,7 @@ package application
 import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/api/application"
 	jujucmd "github.com/juju/juju/cmd"
@@ -21,8 +22,13 @@ Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to deny public access to the application.
 An application is unexposed by default when it gets created.
 
+With --endpoints, only the expose settings of the given application
+endpoints are removed; the application remains exposed while settings
+remain for any of its other endpoints.
+
 Examples:
     juju unexpose wordpress
+    juju unexpose wordpress --endpoints website
 
 See also: 
     expose`[1:]
@@ -36,6 +42,7 @@ func NewUnexposeCommand() modelcmd.ModelCommand {
 type unexposeCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationName string
+	Endpoints       []string
 }
 
 func (c *unexposeCommand) Info() *cmd.Info {
@@ -47,6 +54,12 @@ func (c *unexposeCommand) Info() *cmd.Info {
 	})
 }
 
+// SetFlags implements cmd.Command.
+func (c *unexposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.Var(cmd.NewAppendStringsValue(&c.Endpoints), "endpoints", "Unexpose only the given comma-delimited application endpoints")
+}
+
 func (c *unexposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no application name specified")
@@ -71,5 +84,8 @@ func (c *unexposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
+	if len(c.Endpoints) > 0 {
+		return block.ProcessBlockedError(client.UnexposeEndpoints(c.ApplicationName, c.Endpoints), block.BlockChange)
+	}
 	return block.ProcessBlockedError(client.Unexpose(c.ApplicationName), block.BlockChange)
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/testcharms"
 	"github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
 )
 
 type UnexposeSuite struct {
@@ -68,6 +69,21 @@ func (s *UnexposeSuite) TestUnexpose(c *gc.C) {
 	})
 }
 
+func (s *UnexposeSuite) TestUnexposeEndpoints(c *gc.C) {
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "some-application-name"})
+
+	err := runExpose(c, "some-application-name", "--endpoints", "server,server-admin")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = runUnexpose(c, "some-application-name", "--endpoints", "server-admin")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertExposed(c, "some-application-name", true)
+
+	err = runUnexpose(c, "some-application-name", "--endpoints", "server")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertExposed(c, "some-application-name", false)
+}
+
 func (s *UnexposeSuite) TestBlockUnexpose(c *gc.C) {
 	ch := testcharms.RepoWithSeries("bionic").CharmArchivePath(c.MkDir(), "multi-series")
 	err := runDeploy(c, ch, "some-application-name", "--series", "trusty")
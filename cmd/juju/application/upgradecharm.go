@@ -232,9 +232,15 @@ Use of the --force-units option is not generally recommended; units upgraded whi
 error state will not have upgrade-charm hooks executed, and may cause unexpected
 behavior.
 
---force option for LXD Profiles is not generally recommended when upgrading an 
-application; overriding profiles on the container may cause unexpected 
-behavior. 
+--force option for LXD Profiles is not generally recommended when upgrading an
+application; overriding profiles on the container may cause unexpected
+behavior.
+
+When a branch other than "master" is active, the upgrade and any uploaded
+resources are staged in that branch. Only units tracking the branch run the
+new charm; the upgrade is applied to the whole application when the branch
+is committed, and discarded if the branch is aborted. Config, storage,
+bindings and --force-units cannot be combined with a branch upgrade.
 `
 
 func (c *upgradeCharmCommand) Info() *cmd.Info {
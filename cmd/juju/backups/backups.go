@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -29,7 +30,7 @@ import (
 type APIClient interface {
 	io.Closer
 	// Create sends an RPC request to create a new backup.
-	Create(notes string, keepCopy, noDownload bool) (*params.BackupsMetadataResult, error)
+	Create(notes string, keepCopy, noDownload, incremental bool) (*params.BackupsMetadataResult, error)
 	// Info gets the backup's metadata.
 	Info(id string) (*params.BackupsMetadataResult, error)
 	// List gets all stored metadata.
@@ -41,9 +42,15 @@ type APIClient interface {
 	// Remove removes the stored backups.
 	Remove(ids ...string) ([]params.ErrorResult, error)
 	// Restore will restore a backup with the given id into the controller.
-	Restore(string, backups.ClientConnection) error
+	Restore(string, time.Time, backups.ClientConnection) error
 	// RestoreReader will restore a backup file into the controller.
-	RestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, backups.ClientConnection) error
+	RestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, time.Time, backups.ClientConnection) error
+	// PreviewRestore summarises the result of restoring the backup with
+	// the given id, without restoring it.
+	PreviewRestore(string, time.Time) (*params.RestorePreviewResult, error)
+	// PreviewRestoreReader summarises the result of restoring a backup
+	// file, without restoring it.
+	PreviewRestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, time.Time) (*params.RestorePreviewResult, error)
 }
 
 // CommandBase is the base type for backups sub-commands.
@@ -35,6 +35,10 @@ at the end of the backup process.
 
 Use --keep-copy option to store a copy of backup remotely on the controller.
 
+Use --incremental to back up only the changes made since the most recent
+backup kept on the controller. Incremental backups are always kept on the
+controller, and restoring one also restores the backups it builds on.
+
 Use --verbose to see extra information about backup.
 
 To access remote backups stored on the controller, see 'juju download-backup'.
@@ -44,6 +48,7 @@ Examples:
     juju create-backup --no-download
     juju create-backup --no-download --keep-copy=false // ignores --keep-copy
     juju create-backup --keep-copy
+    juju create-backup --incremental --no-download
     juju create-backup --verbose
 
 See also:
@@ -67,7 +72,10 @@ type createCommand struct {
 	Notes string
 	// KeepCopy means the backup archive should be stored in the controller db.
 	KeepCopy bool
-	fs       *gnuflag.FlagSet
+	// Incremental means only the changes made since the latest backup
+	// kept on the controller should be backed up.
+	Incremental bool
+	fs          *gnuflag.FlagSet
 }
 
 // Info implements Command.Info.
@@ -85,6 +93,7 @@ func (c *createCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.BoolVar(&c.NoDownload, "no-download", false, "Do not download the archive, implies keep-copy")
 	f.BoolVar(&c.KeepCopy, "keep-copy", false, "Keep a copy of the archive on the controller")
+	f.BoolVar(&c.Incremental, "incremental", false, "Only back up the changes made since the latest backup kept on the controller, implies keep-copy")
 	f.StringVar(&c.Filename, "filename", notset, "Download to this file")
 	c.fs = f
 }
@@ -95,16 +104,19 @@ func (c *createCommand) Init(args []string) error {
 	// and they have EXPLICITLY not wanted to store a remote backup file copy
 	// (i.e keep-copy == false), then there is no point for us to proceed as
 	// all the backup will not be stored anywhere.
-	if c.NoDownload {
-		keepCopySet := false
-		c.fs.Visit(func(flag *gnuflag.Flag) {
-			if flag.Name == "keep-copy" {
-				keepCopySet = true
-			}
-		})
-		if keepCopySet && !c.KeepCopy {
-			return errors.Errorf("--no-download cannot be set when --keep-copy is not: the backup will not be created")
+	keepCopySet := false
+	c.fs.Visit(func(flag *gnuflag.Flag) {
+		if flag.Name == "keep-copy" {
+			keepCopySet = true
 		}
+	})
+	if c.NoDownload && keepCopySet && !c.KeepCopy {
+		return errors.Errorf("--no-download cannot be set when --keep-copy is not: the backup will not be created")
+	}
+	// Incremental backups are only useful if they are kept on the
+	// controller, since later backups and restores build on them.
+	if c.Incremental && keepCopySet && !c.KeepCopy {
+		return errors.Errorf("--incremental cannot be set when --keep-copy is not")
 	}
 	notes, err := cmd.ZeroOrOneArgs(args)
 	if err != nil {
@@ -147,6 +159,13 @@ func (c *createCommand) Run(ctx *cmd.Context) error {
 		c.KeepCopy = true
 	}
 
+	if c.Incremental {
+		if apiVersion < 3 {
+			return errors.New("--incremental is not supported by this controller")
+		}
+		c.KeepCopy = true
+	}
+
 	if c.NoDownload {
 		ctx.Warningf(downloadWarning)
 		c.KeepCopy = true
@@ -210,7 +229,7 @@ func (c *createCommand) download(ctx *cmd.Context, client APIClient, copyFrom st
 }
 
 func (c *createCommand) create(client APIClient, apiVersion int) (*params.BackupsMetadataResult, string, error) {
-	result, err := client.Create(c.Notes, c.KeepCopy, c.NoDownload)
+	result, err := client.Create(c.Notes, c.KeepCopy, c.NoDownload, c.Incremental)
 	if err != nil {
 		return nil, "", errors.Trace(err)
 	}
@@ -268,6 +268,31 @@ func (s *createSuite) TestKeepCopyV1Fail(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "--keep-copy is not supported by this controller")
 }
 
+func (s *createSuite) TestIncremental(c *gc.C) {
+	s.apiVersion = 3
+	client := s.setDownload()
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--incremental")
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.CheckCalls(c, "Create", "Download")
+	client.CheckArgs(c, "", "true", "false", "filename")
+	c.Check(client.incremental, jc.IsTrue)
+	s.checkDownload(c, ctx)
+}
+
+func (s *createSuite) TestIncrementalKeepCopyFalse(c *gc.C) {
+	s.apiVersion = 3
+	s.setDownload()
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--incremental", "--keep-copy=false")
+	c.Check(err, gc.ErrorMatches, "--incremental cannot be set when --keep-copy is not")
+}
+
+func (s *createSuite) TestIncrementalV2Fail(c *gc.C) {
+	s.setDownload()
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--incremental")
+	c.Assert(err, gc.ErrorMatches, "--incremental is not supported by this controller")
+}
+
 func (s *createSuite) TestFilenameAndNoDownload(c *gc.C) {
 	s.setSuccess()
 	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--no-download", "--filename", "backup.tgz")
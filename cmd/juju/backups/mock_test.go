@@ -7,6 +7,7 @@ package backups_test
 import (
 	io "io"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	backups "github.com/juju/juju/api/backups"
@@ -110,16 +111,16 @@ func (mr *MockAPIClientMockRecorder) Close() *gomock.Call {
 }
 
 // Create mocks base method
-func (m *MockAPIClient) Create(arg0 string, arg1, arg2 bool) (*params.BackupsMetadataResult, error) {
-	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2)
+func (m *MockAPIClient) Create(arg0 string, arg1, arg2, arg3 bool) (*params.BackupsMetadataResult, error) {
+	ret := m.ctrl.Call(m, "Create", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(*params.BackupsMetadataResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create
-func (mr *MockAPIClientMockRecorder) Create(arg0, arg1, arg2 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAPIClient)(nil).Create), arg0, arg1, arg2)
+func (mr *MockAPIClientMockRecorder) Create(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAPIClient)(nil).Create), arg0, arg1, arg2, arg3)
 }
 
 // Download mocks base method
@@ -161,6 +162,32 @@ func (mr *MockAPIClientMockRecorder) List() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAPIClient)(nil).List))
 }
 
+// PreviewRestore mocks base method
+func (m *MockAPIClient) PreviewRestore(arg0 string, arg1 time.Time) (*params.RestorePreviewResult, error) {
+	ret := m.ctrl.Call(m, "PreviewRestore", arg0, arg1)
+	ret0, _ := ret[0].(*params.RestorePreviewResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewRestore indicates an expected call of PreviewRestore
+func (mr *MockAPIClientMockRecorder) PreviewRestore(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewRestore", reflect.TypeOf((*MockAPIClient)(nil).PreviewRestore), arg0, arg1)
+}
+
+// PreviewRestoreReader mocks base method
+func (m *MockAPIClient) PreviewRestoreReader(arg0 io.ReadSeeker, arg1 *params.BackupsMetadataResult, arg2 time.Time) (*params.RestorePreviewResult, error) {
+	ret := m.ctrl.Call(m, "PreviewRestoreReader", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*params.RestorePreviewResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewRestoreReader indicates an expected call of PreviewRestoreReader
+func (mr *MockAPIClientMockRecorder) PreviewRestoreReader(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewRestoreReader", reflect.TypeOf((*MockAPIClient)(nil).PreviewRestoreReader), arg0, arg1, arg2)
+}
+
 // Remove mocks base method
 func (m *MockAPIClient) Remove(arg0 ...string) ([]params.ErrorResult, error) {
 	varargs := []interface{}{}
@@ -179,27 +206,27 @@ func (mr *MockAPIClientMockRecorder) Remove(arg0 ...interface{}) *gomock.Call {
 }
 
 // Restore mocks base method
-func (m *MockAPIClient) Restore(arg0 string, arg1 backups.ClientConnection) error {
-	ret := m.ctrl.Call(m, "Restore", arg0, arg1)
+func (m *MockAPIClient) Restore(arg0 string, arg1 time.Time, arg2 backups.ClientConnection) error {
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Restore indicates an expected call of Restore
-func (mr *MockAPIClientMockRecorder) Restore(arg0, arg1 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockAPIClient)(nil).Restore), arg0, arg1)
+func (mr *MockAPIClientMockRecorder) Restore(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockAPIClient)(nil).Restore), arg0, arg1, arg2)
 }
 
 // RestoreReader mocks base method
-func (m *MockAPIClient) RestoreReader(arg0 io.ReadSeeker, arg1 *params.BackupsMetadataResult, arg2 backups.ClientConnection) error {
-	ret := m.ctrl.Call(m, "RestoreReader", arg0, arg1, arg2)
+func (m *MockAPIClient) RestoreReader(arg0 io.ReadSeeker, arg1 *params.BackupsMetadataResult, arg2 time.Time, arg3 backups.ClientConnection) error {
+	ret := m.ctrl.Call(m, "RestoreReader", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RestoreReader indicates an expected call of RestoreReader
-func (mr *MockAPIClientMockRecorder) RestoreReader(arg0, arg1, arg2 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreReader", reflect.TypeOf((*MockAPIClient)(nil).RestoreReader), arg0, arg1, arg2)
+func (mr *MockAPIClientMockRecorder) RestoreReader(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreReader", reflect.TypeOf((*MockAPIClient)(nil).RestoreReader), arg0, arg1, arg2, arg3)
 }
 
 // Upload mocks base method
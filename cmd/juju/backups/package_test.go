@@ -10,6 +10,7 @@ import (
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -157,10 +158,11 @@ type fakeAPIClient struct {
 	archive    io.ReadCloser
 	err        error
 
-	calls []string
-	args  []string
-	idArg string
-	notes string
+	calls       []string
+	args        []string
+	idArg       string
+	notes       string
+	incremental bool
 }
 
 func (f *fakeAPIClient) Check(c *gc.C, id, notes string, calls ...string) {
@@ -177,9 +179,10 @@ func (f *fakeAPIClient) CheckArgs(c *gc.C, args ...string) {
 	c.Check(f.args, jc.DeepEquals, args)
 }
 
-func (c *fakeAPIClient) Create(notes string, keepCopy, noDownload bool) (*params.BackupsMetadataResult, error) {
+func (c *fakeAPIClient) Create(notes string, keepCopy, noDownload, incremental bool) (*params.BackupsMetadataResult, error) {
 	c.calls = append(c.calls, "Create")
 	c.args = append(c.args, notes, fmt.Sprintf("%t", keepCopy), fmt.Sprintf("%t", noDownload))
+	c.incremental = incremental
 	c.notes = notes
 	if c.err != nil {
 		return nil, c.err
@@ -242,10 +245,18 @@ func (c *fakeAPIClient) Close() error {
 	return nil
 }
 
-func (c *fakeAPIClient) RestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, apibackups.ClientConnection) error {
+func (c *fakeAPIClient) RestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, time.Time, apibackups.ClientConnection) error {
 	return nil
 }
 
-func (c *fakeAPIClient) Restore(string, apibackups.ClientConnection) error {
+func (c *fakeAPIClient) Restore(string, time.Time, apibackups.ClientConnection) error {
 	return nil
 }
+
+func (c *fakeAPIClient) PreviewRestore(string, time.Time) (*params.RestorePreviewResult, error) {
+	return nil, nil
+}
+
+func (c *fakeAPIClient) PreviewRestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, time.Time) (*params.RestorePreviewResult, error) {
+	return nil, nil
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -20,6 +21,7 @@ import (
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/controller"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/bootstrap"
 	"github.com/juju/juju/jujuclient"
@@ -40,6 +42,12 @@ type restoreCommand struct {
 
 	Filename string
 	BackupId string
+	// Until is the point in time to restore an incremental backup to.
+	Until time.Time
+	// DryRun means the restore should be previewed rather than made.
+	DryRun bool
+
+	until string
 }
 
 // RestoreAPI is used to invoke various API calls.
@@ -48,10 +56,16 @@ type RestoreAPI interface {
 	Close() error
 
 	// Restore is taken from backups.Client.
-	Restore(backupId string, newClient backups.ClientConnection) error
+	Restore(backupId string, until time.Time, newClient backups.ClientConnection) error
 
 	// RestoreReader is taken from backups.Client.
-	RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResult, newClient backups.ClientConnection) error
+	RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResult, until time.Time, newClient backups.ClientConnection) error
+
+	// PreviewRestore is taken from backups.Client.
+	PreviewRestore(backupId string, until time.Time) (*params.RestorePreviewResult, error)
+
+	// PreviewRestoreReader is taken from backups.Client.
+	PreviewRestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResult, until time.Time) (*params.RestorePreviewResult, error)
 }
 
 // ModelStatusAPI is used to invoke common.ModelStatus
@@ -74,8 +88,24 @@ machines will be added or removed during the restore process.
 Note: Extra care is needed to restore in an HA environment, please see
 https://jaas.ai/docs/controller-backups for more information.
 
+Use --dry-run to check that you have chosen the correct backup before
+restoring it. The backup is restored into a scratch database on the
+controller, and a summary of its models, machines, applications and agent
+versions is shown along with how they differ from the controller as it is
+now. Nothing is restored. A backup file given with --file is uploaded to
+the controller so that it can be previewed, and removed again afterwards
+unless the controller already held it.
+
+An incremental backup is restored together with the backups it follows on
+from. Use --until to restore it only up to a point in time, given in
+RFC3339 format.
+
 If the provided state cannot be restored, this command will fail with
 an explanation.
+
+Examples:
+    juju restore-backup --id <backup-id> --dry-run
+    juju restore-backup --id <backup-id> --until 2020-01-01T12:00:00Z
 `
 
 // Info returns the content for --help.
@@ -93,6 +123,8 @@ func (c *restoreCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.StringVar(&c.Filename, "file", "", "Provide a file to be used as the backup")
 	f.StringVar(&c.BackupId, "id", "", "Provide the name of the backup to be restored")
+	f.StringVar(&c.until, "until", "", "Restore an incremental backup only up to this time (RFC3339)")
+	f.BoolVar(&c.DryRun, "dry-run", false, "Show what restoring the backup would change, without restoring it")
 }
 
 // Init is where the preconditions for this command can be checked.
@@ -112,6 +144,14 @@ func (c *restoreCommand) Init(args []string) error {
 		}
 	}
 
+	if c.until != "" {
+		var err error
+		c.Until, err = time.Parse(time.RFC3339, c.until)
+		if err != nil {
+			return errors.Errorf("invalid --until time %q: expected RFC3339 format, e.g. 2020-01-01T12:00:00Z", c.until)
+		}
+	}
+
 	return nil
 }
 
@@ -167,6 +207,12 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 		}
 	}
 
+	// A preview leaves the controller untouched, so it is allowed
+	// in an HA environment.
+	if c.DryRun {
+		return errors.Trace(c.preview(ctx))
+	}
+
 	// Don't allow restore in an HA environment
 	controllerModelUUID, modelStatus, err := c.modelStatus()
 	if err != nil {
@@ -200,9 +246,9 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 	// We have a backup client, now use the relevant method
 	// to restore the backup.
 	if c.Filename != "" {
-		err = client.RestoreReader(archive, meta, c.newClient)
+		err = client.RestoreReader(archive, meta, c.Until, c.newClient)
 	} else {
-		err = client.Restore(c.BackupId, c.newClient)
+		err = client.Restore(c.BackupId, c.Until, c.newClient)
 	}
 	if err != nil {
 		return errors.Trace(err)
@@ -210,3 +256,75 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 	fmt.Fprintf(ctx.Stdout, "restore from %q completed\n", target)
 	return nil
 }
+
+func (c *restoreCommand) preview(ctx *cmd.Context) error {
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	var result *params.RestorePreviewResult
+	target := c.BackupId
+	if c.Filename != "" {
+		target = c.Filename
+		var archive ArchiveReader
+		var meta *params.BackupsMetadataResult
+		archive, meta, err = getArchive(c.Filename)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer archive.Close()
+		result, err = client.PreviewRestoreReader(archive, meta, c.Until)
+	} else {
+		result, err = client.PreviewRestore(c.BackupId, c.Until)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	printRestorePreview(ctx.Stdout, target, result)
+	return nil
+}
+
+// printRestorePreview writes a summary of the backup's contents
+// followed by the changes that restoring it would make.
+func printRestorePreview(out io.Writer, target string, result *params.RestorePreviewResult) {
+	fmt.Fprintf(out, "Backup %q holds:\n", target)
+	tw := output.TabWriter(out)
+	fmt.Fprintln(tw, "Model\tAgent version\tMachines\tApplications")
+	for _, model := range result.Models {
+		fmt.Fprintf(tw, "%s/%s\t%s\t%d\t%d\n",
+			model.Owner, model.Name, model.AgentVersion, len(model.Machines), len(model.Applications))
+	}
+	tw.Flush()
+	fmt.Fprintln(out)
+
+	diff := result.Diff
+	if len(diff.AddedModels) == 0 && len(diff.RemovedModels) == 0 && len(diff.ChangedModels) == 0 {
+		fmt.Fprintln(out, "Restoring it would not change the controller's models, machines or applications.")
+		return
+	}
+	fmt.Fprintln(out, "Restoring it would:")
+	for _, model := range diff.AddedModels {
+		fmt.Fprintf(out, "  add model %s\n", model)
+	}
+	for _, model := range diff.RemovedModels {
+		fmt.Fprintf(out, "  remove model %s\n", model)
+	}
+	for _, model := range diff.ChangedModels {
+		fmt.Fprintf(out, "  change model %s:\n", model.Model)
+		if model.CurrentAgentVersion != model.RestoredAgentVersion {
+			fmt.Fprintf(out, "    agent version %s -> %s\n", model.CurrentAgentVersion, model.RestoredAgentVersion)
+		}
+		printPreviewChange(out, "add machines", model.AddedMachines)
+		printPreviewChange(out, "remove machines", model.RemovedMachines)
+		printPreviewChange(out, "add applications", model.AddedApplications)
+		printPreviewChange(out, "remove applications", model.RemovedApplications)
+	}
+}
+
+func printPreviewChange(out io.Writer, change string, names []string) {
+	if len(names) > 0 {
+		fmt.Fprintf(out, "    %s %s\n", change, strings.Join(names, ", "))
+	}
+}
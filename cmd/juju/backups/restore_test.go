@@ -6,6 +6,7 @@ package backups_test
 import (
 	"fmt"
 	"path/filepath"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/juju/cmd"
@@ -112,6 +113,7 @@ type restoreBackupArgParsing struct {
 	errMatch string
 	id       string
 	filename string
+	until    time.Time
 }
 
 var testRestoreBackupArgParsing = []restoreBackupArgParsing{
@@ -135,6 +137,17 @@ var testRestoreBackupArgParsing = []restoreBackupArgParsing{
 		args:     []string{"--file", "afile"},
 		filename: "afile",
 	},
+	{
+		title: "until",
+		args:  []string{"--id", "anid", "--until", "2020-01-01T12:00:00Z"},
+		id:    "anid",
+		until: time.Date(2020, time.January, 1, 12, 0, 0, 0, time.UTC),
+	},
+	{
+		title:    "invalid until",
+		args:     []string{"--id", "anid", "--until", "yesterday"},
+		errMatch: `invalid --until time "yesterday": expected RFC3339 format, e.g. 2020-01-01T12:00:00Z`,
+	},
 }
 
 func (s *restoreSuite) TestArgParsing(c *gc.C) {
@@ -147,6 +160,7 @@ func (s *restoreSuite) TestArgParsing(c *gc.C) {
 			expectedName := filepath.Base(test.filename)
 			c.Assert(obtainedName, gc.Equals, expectedName)
 			c.Assert(s.command.BackupId, gc.Equals, test.id)
+			c.Assert(s.command.Until.Equal(test.until), jc.IsTrue)
 		} else {
 			c.Assert(err, gc.ErrorMatches, test.errMatch)
 		}
@@ -158,7 +172,7 @@ func (s *restoreSuite) TestRestoreFromBackupFilename(c *gc.C) {
 	defer ctlr.Finish()
 	expectModelStatus(modelStatusClient)
 	gomock.InOrder(
-		apiClient.EXPECT().RestoreReader(archiveReader, &params.BackupsMetadataResult{}, gomock.Any()).Return(
+		apiClient.EXPECT().RestoreReader(archiveReader, &params.BackupsMetadataResult{}, time.Time{}, gomock.Any()).Return(
 			nil,
 		),
 		apiClient.EXPECT().Close(),
@@ -175,7 +189,7 @@ func (s *restoreSuite) TestRestoreFromBackupFilenameFail(c *gc.C) {
 	defer ctlr.Finish()
 	expectModelStatus(modelStatusClient)
 	gomock.InOrder(
-		apiClient.EXPECT().RestoreReader(archiveReader, &params.BackupsMetadataResult{}, gomock.Any()).Return(
+		apiClient.EXPECT().RestoreReader(archiveReader, &params.BackupsMetadataResult{}, time.Time{}, gomock.Any()).Return(
 			errors.New("restore failed"),
 		),
 		apiClient.EXPECT().Close(),
@@ -190,7 +204,7 @@ func (s *restoreSuite) TestRestoreFromBackupId(c *gc.C) {
 	defer ctlr.Finish()
 	expectModelStatus(modelStatusClient)
 	gomock.InOrder(
-		apiClient.EXPECT().Restore("an_id", gomock.Any()).Return(
+		apiClient.EXPECT().Restore("an_id", time.Time{}, gomock.Any()).Return(
 			nil,
 		),
 		apiClient.EXPECT().Close(),
@@ -206,7 +220,7 @@ func (s *restoreSuite) TestRestoreFromBackupIdFail(c *gc.C) {
 	defer ctlr.Finish()
 	expectModelStatus(modelStatusClient)
 	gomock.InOrder(
-		apiClient.EXPECT().Restore("an_id", gomock.Any()).Return(
+		apiClient.EXPECT().Restore("an_id", time.Time{}, gomock.Any()).Return(
 			errors.New("restore failed"),
 		),
 		apiClient.EXPECT().Close(),
@@ -262,3 +276,100 @@ func (s *restoreSuite) TestRestoreFromBackupHAFail(c *gc.C) {
 	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "restore", "--id", "an_id")
 	c.Assert(err, gc.ErrorMatches, "unable to restore backup in HA configuration.  For help see https://jaas.ai/docs/controller-backups")
 }
+
+func (s *restoreSuite) TestRestoreUntil(c *gc.C) {
+	ctlr, apiClient, _, modelStatusClient := s.patch(c, nil)
+	defer ctlr.Finish()
+	expectModelStatus(modelStatusClient)
+	until := time.Date(2020, time.January, 1, 12, 0, 0, 0, time.UTC)
+	gomock.InOrder(
+		apiClient.EXPECT().Restore("an_id", until, gomock.Any()).Return(
+			nil,
+		),
+		apiClient.EXPECT().Close(),
+	)
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "restore", "--id", "an_id", "--until", "2020-01-01T12:00:00Z")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+var restorePreviewResult = &params.RestorePreviewResult{
+	Models: []params.RestorePreviewModel{{
+		Name:         "controller",
+		Owner:        "admin",
+		AgentVersion: "2.8.1",
+		Machines:     []string{"0"},
+	}, {
+		Name:         "default",
+		Owner:        "admin",
+		AgentVersion: "2.7.6",
+		Machines:     []string{"1", "2"},
+		Applications: []string{"mysql", "wordpress"},
+	}},
+	Diff: params.RestorePreviewDiff{
+		RemovedModels: []string{"bob/staging"},
+		ChangedModels: []params.RestorePreviewModelDiff{{
+			Model:                "admin/default",
+			CurrentAgentVersion:  "2.8.1",
+			RestoredAgentVersion: "2.7.6",
+			AddedMachines:        []string{"2"},
+			RemovedApplications:  []string{"mediawiki"},
+		}},
+	},
+}
+
+const restorePreviewOutput = `
+Backup "an_id" holds:
+Model             Agent version  Machines  Applications
+admin/controller  2.8.1          1         0
+admin/default     2.7.6          2         2
+
+Restoring it would:
+  remove model bob/staging
+  change model admin/default:
+    agent version 2.8.1 -> 2.7.6
+    add machines 2
+    remove applications mediawiki
+`
+
+func (s *restoreSuite) TestDryRun(c *gc.C) {
+	// The HA check is skipped, since nothing is restored.
+	ctlr, apiClient, _, _ := s.patch(c, nil)
+	defer ctlr.Finish()
+	gomock.InOrder(
+		apiClient.EXPECT().PreviewRestore("an_id", time.Time{}).Return(
+			restorePreviewResult, nil,
+		),
+		apiClient.EXPECT().Close(),
+	)
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "restore", "--id", "an_id", "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, restorePreviewOutput[1:])
+}
+
+func (s *restoreSuite) TestDryRunUnchanged(c *gc.C) {
+	ctlr, apiClient, archiveReader, _ := s.patch(c, nil)
+	defer ctlr.Finish()
+	gomock.InOrder(
+		apiClient.EXPECT().PreviewRestoreReader(archiveReader, &params.BackupsMetadataResult{}, time.Time{}).Return(
+			&params.RestorePreviewResult{}, nil,
+		),
+		archiveReader.EXPECT().Close(),
+		apiClient.EXPECT().Close(),
+	)
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "restore", "--file", "afile", "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), jc.HasSuffix, "Restoring it would not change the controller's models, machines or applications.\n")
+}
+
+func (s *restoreSuite) TestDryRunFail(c *gc.C) {
+	ctlr, apiClient, _, _ := s.patch(c, nil)
+	defer ctlr.Finish()
+	gomock.InOrder(
+		apiClient.EXPECT().PreviewRestore("an_id", time.Time{}).Return(
+			nil, errors.New("preview failed"),
+		),
+		apiClient.EXPECT().Close(),
+	)
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "restore", "--id", "an_id", "--dry-run")
+	c.Assert(err, gc.ErrorMatches, "preview failed")
+}
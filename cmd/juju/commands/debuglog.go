@@ -4,12 +4,19 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/juju/ansiterm"
+	"github.com/juju/clock"
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
@@ -22,6 +29,7 @@ import (
 	"github.com/juju/juju/api/common"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/logfields"
 	"github.com/juju/juju/core/model"
 )
 
@@ -29,6 +37,13 @@ import (
 // display, from the end of the consolidated log.
 const defaultLineCount = 10
 
+// The output formats supported by debug-log.
+const (
+	formatText   = "text"
+	formatJSON   = "json"
+	formatLogfmt = "logfmt"
+)
+
 var usageDebugLogSummary = `
 Displays log messages for a model.`[1:]
 
@@ -44,6 +59,13 @@ Each log line is emitted in this format:
 The "entity" is the source of the message: a machine or unit. The names for
 machines and units can be seen in the output of `[1:] + "`juju status`" + `.
 
+The '--format' option selects the output format: text (the default),
+json or logfmt. The json and logfmt formats emit one line per message,
+holding the model UUID, entity, timestamp, level, module, location,
+message and any structured fields, for processing by other tools. The
+--color, --date, --ms and --location options only apply to the text
+format, and --utc sets the timezone of the timestamps in all formats.
+
 The '--include' and '--exclude' options filter by entity. The entity can be
 a machine, unit, or application for vm models, but can be application only
 for k8s models.
@@ -52,13 +74,34 @@ The '--include-module' and '--exclude-module' options filter by (dotted)
 logging module name. The module name can be truncated such that all loggers
 with the prefix will match.
 
+Log messages written by units and agents may carry structured fields,
+such as the unit, hook, relation-id or action-id the message relates to.
+These are shown after the message. The '--field' option, given as
+key=value, only shows messages with a field holding that value.
+
+The '--grep' and '--exclude-grep' options filter by message, using
+regular expressions. Prefix the expression with (?i) to ignore case.
+
+The '--since' and '--until' options take either a time in RFC3339 format,
+or a duration such as 2h, which is taken to be that long ago. The --since
+option shows all messages logged from that time, in place of the most
+recent lines. When --until is given, the command stops once that time has
+passed.
+
+All of the filtering is done by the controller, so only the matching
+messages are sent.
+
 The filtering options combine as follows:
 * All --include options are logically ORed together.
 * All --exclude options are logically ORed together.
 * All --include-module options are logically ORed together.
 * All --exclude-module options are logically ORed together.
-* The combined --include, --exclude, --include-module and --exclude-module
-  selections are logically ANDed to form the complete filter.
+* All --field options are logically ANDed together.
+* All --grep options are logically ORed together.
+* All --exclude-grep options are logically ORed together.
+* The combined --include, --exclude, --include-module, --exclude-module,
+  --field, --grep, --exclude-grep, --since and --until selections are
+  logically ANDed to form the complete filter.
 
 Examples:
 
@@ -96,6 +139,25 @@ machine-3 or machine-4, and then stop:
         --exclude machine-3 \
         --exclude machine-4
 
+Show all messages logged while running the config-changed hook of unit
+mysql/0, and then stop:
+
+    juju debug-log --replay --no-tail
+        --field unit=mysql/0 \
+        --field hook=config-changed
+
+Show all messages mentioning "failed" or "timeout" logged in the hour up
+to 30 minutes ago, except those from the juju-log command:
+
+    juju debug-log --since 90m --until 30m
+        --grep failed --grep timeout \
+        --exclude-grep '^juju-log'
+
+Show all messages for the current model as JSON, one per line, and then
+exit:
+
+    juju debug-log --replay --no-tail --format json
+
 To see all WARNING and ERROR messages and then continue showing any
 new WARNING and ERROR messages as they are logged:
 
@@ -118,7 +180,7 @@ func newDebugLogCommand(store jujuclient.ClientStore) cmd.Command {
 }
 
 func newDebugLogCommandTZ(store jujuclient.ClientStore, tz *time.Location) cmd.Command {
-	cmd := &debugLogCommand{tz: tz}
+	cmd := &debugLogCommand{tz: tz, clock: clock.WallClock}
 	cmd.SetClientStore(store)
 	return modelcmd.Wrap(cmd)
 }
@@ -127,6 +189,9 @@ type debugLogCommand struct {
 	modelcmd.ModelCommandBase
 
 	level  string
+	fields []string
+	since  string
+	until  string
 	params common.DebugLogParams
 
 	utc      bool
@@ -138,8 +203,10 @@ type debugLogCommand struct {
 	notail bool
 	color  bool
 
-	format string
-	tz     *time.Location
+	outputFormat string
+	format       string
+	tz           *time.Location
+	clock        clock.Clock
 }
 
 func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
@@ -150,6 +217,11 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeEntity), "exclude", "Do not show log messages for these entities")
 	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeModule), "include-module", "Only show log messages for these logging modules")
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeModule), "exclude-module", "Do not show log messages for these logging modules")
+	f.Var(cmd.NewAppendStringsValue(&c.fields), "field", "Only show log messages with this field, given as key=value")
+	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeMessage), "grep", "Only show log messages matching these regular expressions")
+	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeMessage), "exclude-grep", "Do not show log messages matching these regular expressions")
+	f.StringVar(&c.since, "since", "", "Show log messages logged at or after this time")
+	f.StringVar(&c.until, "until", "", "Only show log messages logged before this time")
 
 	f.StringVar(&c.level, "l", "", "Log level to show, one of [TRACE, DEBUG, INFO, WARNING, ERROR]")
 	f.StringVar(&c.level, "level", "", "")
@@ -163,6 +235,8 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.tail, "tail", false, "Wait for new logs")
 	f.BoolVar(&c.color, "color", false, "Force use of ANSI color codes")
 
+	f.StringVar(&c.outputFormat, "format", formatText, "Specify output format (text|json|logfmt)")
+
 	f.BoolVar(&c.utc, "utc", false, "Show times in UTC")
 	f.BoolVar(&c.location, "location", false, "Show filename and line numbers")
 	f.BoolVar(&c.date, "date", false, "Show dates as well as times")
@@ -178,6 +252,53 @@ func (c *debugLogCommand) Init(args []string) error {
 		}
 		c.params.Level = level
 	}
+	for _, field := range c.fields {
+		key, value, err := logfields.ParseKeyValue(field)
+		if err != nil {
+			return errors.Annotate(err, "invalid --field")
+		}
+		if _, ok := c.params.IncludeFields[key]; ok {
+			return errors.Errorf("--field %q specified more than once", key)
+		}
+		if c.params.IncludeFields == nil {
+			c.params.IncludeFields = make(map[string]string)
+		}
+		c.params.IncludeFields[key] = value
+	}
+	for _, expr := range c.params.IncludeMessage {
+		if _, err := regexp.Compile(expr); err != nil {
+			return errors.Errorf("invalid --grep %q: %v", expr, err)
+		}
+	}
+	for _, expr := range c.params.ExcludeMessage {
+		if _, err := regexp.Compile(expr); err != nil {
+			return errors.Errorf("invalid --exclude-grep %q: %v", expr, err)
+		}
+	}
+	if c.since != "" {
+		since, err := c.parseTime("--since", c.since)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.params.StartTime = since
+		c.params.Replay = true
+	}
+	if c.until != "" {
+		until, err := c.parseTime("--until", c.until)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if until.Before(c.params.StartTime) {
+			return errors.New("--until cannot be before --since")
+		}
+		c.params.EndTime = until
+	}
+	switch c.outputFormat {
+	case formatText, formatJSON, formatLogfmt:
+	default:
+		return errors.Errorf("format value %q is not one of %q, %q, %q",
+			c.outputFormat, formatText, formatJSON, formatLogfmt)
+	}
 	if c.tail && c.notail {
 		return errors.NotValidf("setting --tail and --no-tail")
 	}
@@ -202,6 +323,19 @@ func (c *debugLogCommand) Init(args []string) error {
 	return cmd.CheckEmpty(args)
 }
 
+// parseTime parses value as either an RFC3339 time or as a duration
+// before now.
+func (c *debugLogCommand) parseTime(flag, value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return c.clock.Now().Add(-d).UTC(), nil
+	}
+	return time.Time{}, errors.Errorf(
+		"invalid %s time %q: expected RFC3339 format, e.g. 2020-01-01T12:00:00Z, or a duration, e.g. 2h", flag, value)
+}
+
 func (c *debugLogCommand) processEntities(isCAAS bool, entities []string) []string {
 	if entities == nil {
 		return nil
@@ -285,7 +419,17 @@ func (c *debugLogCommand) Run(ctx *cmd.Context) (err error) {
 		if !ok {
 			break
 		}
-		c.writeLogRecord(writer, msg)
+		switch c.outputFormat {
+		case formatJSON:
+			err = c.writeJSONRecord(ctx.Stdout, msg)
+		case formatLogfmt:
+			err = c.writeLogfmtRecord(ctx.Stdout, msg)
+		default:
+			c.writeLogRecord(writer, msg)
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	return nil
@@ -311,5 +455,71 @@ func (c *debugLogCommand) writeLogRecord(w *ansiterm.Writer, r common.LogMessage
 	if c.location {
 		loggocolor.LocationColor.Fprintf(w, "%s ", r.Location)
 	}
-	fmt.Fprintln(w, r.Message)
+	fmt.Fprintln(w, logfields.Format(r.Message, r.Fields))
+}
+
+// logRecordOutput is the serialization format for a log message in the
+// json output format.
+type logRecordOutput struct {
+	ModelUUID string            `json:"model-uuid"`
+	Entity    string            `json:"entity"`
+	Timestamp string            `json:"timestamp"`
+	Level     string            `json:"level"`
+	Module    string            `json:"module"`
+	Location  string            `json:"location"`
+	Message   string            `json:"message"`
+	Fields    map[string]string `json:"fields,omitempty"`
+}
+
+func (c *debugLogCommand) writeJSONRecord(w io.Writer, r common.LogMessage) error {
+	data, err := json.Marshal(logRecordOutput{
+		ModelUUID: r.ModelUUID,
+		Entity:    r.Entity,
+		Timestamp: r.Timestamp.In(c.tz).Format(time.RFC3339Nano),
+		Level:     r.Severity,
+		Module:    r.Module,
+		Location:  r.Location,
+		Message:   r.Message,
+		Fields:    r.Fields,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return errors.Trace(err)
+}
+
+func (c *debugLogCommand) writeLogfmtRecord(w io.Writer, r common.LogMessage) error {
+	pairs := []string{
+		"timestamp=" + r.Timestamp.In(c.tz).Format(time.RFC3339Nano),
+		"level=" + logfmtValue(r.Severity),
+		"model-uuid=" + logfmtValue(r.ModelUUID),
+		"entity=" + logfmtValue(r.Entity),
+		"module=" + logfmtValue(r.Module),
+		"location=" + logfmtValue(r.Location),
+		"message=" + logfmtValue(r.Message),
+	}
+	keys := make([]string, 0, len(r.Fields))
+	for key := range r.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+logfmtValue(r.Fields[key]))
+	}
+	_, err := fmt.Fprintln(w, strings.Join(pairs, " "))
+	return errors.Trace(err)
+}
+
+// logfmtValue returns value quoted if it is empty, or contains
+// characters that would prevent it being parsed as a logfmt value.
+func logfmtValue(value string) string {
+	if value == "" || strings.IndexFunc(value, needsQuoting) >= 0 {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+func needsQuoting(r rune) bool {
+	return r <= ' ' || r == '=' || r == '"' || r == '\\' || !unicode.IsPrint(r)
 }
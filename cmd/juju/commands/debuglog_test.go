@@ -6,6 +6,7 @@ package commands
 import (
 	"time"
 
+	"github.com/juju/clock/testclock"
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
@@ -89,6 +90,57 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 				ExcludeModule: []string{"juju.foo", "unit"},
 				Backlog:       10,
 			},
+		}, {
+			args: []string{"--field", "unit=mysql/0", "--field", "hook=config-changed"},
+			expected: common.DebugLogParams{
+				IncludeFields: map[string]string{"unit": "mysql/0", "hook": "config-changed"},
+				Backlog:       10,
+			},
+		}, {
+			args:     []string{"--field", "unit"},
+			errMatch: `invalid --field: log field "unit" \(expected key=value\) not valid`,
+		}, {
+			args:     []string{"--field", "Unit=mysql/0"},
+			errMatch: `invalid --field: log field name "Unit" not valid`,
+		}, {
+			args:     []string{"--field", "unit=mysql/0", "--field", "unit=mysql/1"},
+			errMatch: `--field "unit" specified more than once`,
+		}, {
+			args: []string{"--grep", "fail(ed)?", "--grep", "(?i)error", "--exclude-grep", "^juju-log"},
+			expected: common.DebugLogParams{
+				IncludeMessage: []string{"fail(ed)?", "(?i)error"},
+				ExcludeMessage: []string{"^juju-log"},
+				Backlog:        10,
+			},
+		}, {
+			args:     []string{"--grep", "fail(ed"},
+			errMatch: `invalid --grep "fail\(ed": error parsing regexp: .*`,
+		}, {
+			args:     []string{"--exclude-grep", "*"},
+			errMatch: `invalid --exclude-grep "\*": error parsing regexp: .*`,
+		}, {
+			args: []string{"--since", "2020-01-01T12:00:00+01:00", "--until", "2020-01-01T12:30:00Z"},
+			expected: common.DebugLogParams{
+				Backlog:   10,
+				Replay:    true,
+				StartTime: time.Date(2020, 1, 1, 11, 0, 0, 0, time.UTC),
+				EndTime:   time.Date(2020, 1, 1, 12, 30, 0, 0, time.UTC),
+			},
+		}, {
+			args: []string{"--until", "2020-01-01T12:30:00Z"},
+			expected: common.DebugLogParams{
+				Backlog: 10,
+				EndTime: time.Date(2020, 1, 1, 12, 30, 0, 0, time.UTC),
+			},
+		}, {
+			args:     []string{"--since", "yesterday"},
+			errMatch: `invalid --since time "yesterday": expected RFC3339 format, e.g. 2020-01-01T12:00:00Z, or a duration, e.g. 2h`,
+		}, {
+			args:     []string{"--since", "2020-01-02T00:00:00Z", "--until", "2020-01-01T00:00:00Z"},
+			errMatch: `--until cannot be before --since`,
+		}, {
+			args:     []string{"--format", "yaml"},
+			errMatch: `format value "yaml" is not one of "text", "json", "logfmt"`,
 		}, {
 			args: []string{"--replay"},
 			expected: common.DebugLogParams{
@@ -119,6 +171,20 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 	}
 }
 
+func (s *DebugLogSuite) TestSinceUntilDurations(c *gc.C) {
+	now := time.Date(2020, 1, 2, 12, 0, 0, 0, time.UTC)
+	command := &debugLogCommand{clock: testclock.NewClock(now)}
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	err := cmdtesting.InitCommand(modelcmd.Wrap(command), []string{"--since", "2h", "--until", "30m"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(command.params, jc.DeepEquals, common.DebugLogParams{
+		Backlog:   10,
+		Replay:    true,
+		StartTime: now.Add(-2 * time.Hour),
+		EndTime:   now.Add(-30 * time.Minute),
+	})
+}
+
 func (s *DebugLogSuite) TestParamsPassed(c *gc.C) {
 	fake := &fakeDebugLogAPI{}
 	s.PatchValue(&getDebugLogAPI, func(_ *debugLogCommand) (DebugLogAPI, error) {
@@ -184,6 +250,76 @@ func (s *DebugLogSuite) TestLogOutput(c *gc.C) {
 		"machine-0: 14:15:23 INFO test.module somefile.go:123 this is the log output\n")
 }
 
+func (s *DebugLogSuite) TestLogOutputFields(c *gc.C) {
+	s.PatchValue(&getDebugLogAPI, func(_ *debugLogCommand) (DebugLogAPI, error) {
+		return &fakeDebugLogAPI{log: []common.LogMessage{
+			{
+				Entity:    "unit-mysql-0",
+				Timestamp: time.Date(2016, 10, 9, 8, 15, 23, 0, time.UTC),
+				Severity:  "DEBUG",
+				Module:    "unit.mysql/0.juju-log",
+				Message:   "all good",
+				Fields:    map[string]string{"unit": "mysql/0", "hook": "db relation joined"},
+			},
+		}}, nil
+	})
+	ctx, err := cmdtesting.RunCommand(c, newDebugLogCommandTZ(jujuclienttesting.MinimalStore(), time.UTC))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals,
+		`unit-mysql-0: 08:15:23 DEBUG unit.mysql/0.juju-log all good [fields: hook="db relation joined" unit=mysql/0]`+"\n")
+}
+
+func (s *DebugLogSuite) patchStructuredLog() {
+	s.PatchValue(&getDebugLogAPI, func(_ *debugLogCommand) (DebugLogAPI, error) {
+		return &fakeDebugLogAPI{log: []common.LogMessage{
+			{
+				ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+				Entity:    "machine-0",
+				Timestamp: time.Date(2016, 10, 9, 8, 15, 23, 345000000, time.UTC),
+				Severity:  "INFO",
+				Module:    "test.module",
+				Location:  "somefile.go:123",
+				Message:   `this is "the" log output`,
+			}, {
+				ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+				Entity:    "unit-mysql-0",
+				Timestamp: time.Date(2016, 10, 9, 8, 15, 24, 0, time.UTC),
+				Severity:  "DEBUG",
+				Module:    "unit.mysql/0.juju-log",
+				Message:   "done",
+				Fields:    map[string]string{"unit": "mysql/0", "hook": "db relation joined"},
+			},
+		}}, nil
+	})
+}
+
+func (s *DebugLogSuite) TestLogOutputJSON(c *gc.C) {
+	s.patchStructuredLog()
+	tz := time.FixedZone("test", 6*60*60)
+	ctx, err := cmdtesting.RunCommand(c, newDebugLogCommandTZ(jujuclienttesting.MinimalStore(), tz), "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ``+
+		`{"model-uuid":"deadbeef-0bad-400d-8000-4b1d0d06f00d","entity":"machine-0",`+
+		`"timestamp":"2016-10-09T14:15:23.345+06:00","level":"INFO","module":"test.module",`+
+		`"location":"somefile.go:123","message":"this is \"the\" log output"}`+"\n"+
+		`{"model-uuid":"deadbeef-0bad-400d-8000-4b1d0d06f00d","entity":"unit-mysql-0",`+
+		`"timestamp":"2016-10-09T14:15:24+06:00","level":"DEBUG","module":"unit.mysql/0.juju-log",`+
+		`"location":"","message":"done","fields":{"hook":"db relation joined","unit":"mysql/0"}}`+"\n")
+}
+
+func (s *DebugLogSuite) TestLogOutputLogfmt(c *gc.C) {
+	s.patchStructuredLog()
+	tz := time.FixedZone("test", 6*60*60)
+	ctx, err := cmdtesting.RunCommand(c, newDebugLogCommandTZ(jujuclienttesting.MinimalStore(), tz), "--format", "logfmt", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ``+
+		`timestamp=2016-10-09T08:15:23.345Z level=INFO model-uuid=deadbeef-0bad-400d-8000-4b1d0d06f00d `+
+		`entity=machine-0 module=test.module location=somefile.go:123 message="this is \"the\" log output"`+"\n"+
+		`timestamp=2016-10-09T08:15:24Z level=DEBUG model-uuid=deadbeef-0bad-400d-8000-4b1d0d06f00d `+
+		`entity=unit-mysql-0 module=unit.mysql/0.juju-log location="" message=done `+
+		`hook="db relation joined" unit=mysql/0`+"\n")
+}
+
 type fakeDebugLogAPI struct {
 	log    []common.LogMessage
 	params common.DebugLogParams
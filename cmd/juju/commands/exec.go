@@ -61,6 +61,8 @@ type execCommand struct {
 	applications []string
 	units        []string
 	commands     string
+	failFast     bool
+	group        bool
 	timeAfter    func(time.Duration) <-chan time.Time
 }
 
@@ -105,12 +107,24 @@ targets.
 Since juju exec creates actions, you can query for the status of commands
 started with juju run by calling "juju show-action-status --name juju-run".
 
+By default the results are shown as YAML, or just the output of the
+commands if there is a single target. Use --format tabular for a summary
+with one line per target showing its exit code, how long the commands took
+and the first line of their output. Use --group to show identical results
+from several targets only once, along with the targets they came from, so
+that the results from many machines or units can be scanned easily.
+
+Use --fail-fast to stop as soon as the commands fail on any target; the
+commands are cancelled on targets where they have not yet started.
+
 If you need to pass options to the command being run, you must precede the
 command and its arguments with "--", to tell "juju exec" to stop processing
 those arguments. For example:
 
     juju exec --all -- hostname -f
 
+    juju exec --all --format tabular --group -- uname -r
+
 `
 
 func (c *execCommand) Info() *cmd.Info {
@@ -130,14 +144,17 @@ func (c *execCommand) Info() *cmd.Info {
 func (c *execCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	c.out.AddFlags(f, "default", map[string]cmd.Formatter{
-		"yaml": cmd.FormatYaml,
-		"json": cmd.FormatJson,
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": c.formatTabular,
 		// default is used to format a single result specially.
 		"default": cmd.FormatYaml,
 	})
 	f.BoolVar(&c.all, "all", false, "Run the commands on all the machines")
 	f.BoolVar(&c.operator, "operator", false, "Run the commands on the operator (k8s-only)")
 	f.DurationVar(&c.timeout, "timeout", 5*time.Minute, "How long to wait before the remote command is considered to have failed")
+	f.BoolVar(&c.failFast, "fail-fast", false, "Stop as soon as the commands fail on any target")
+	f.BoolVar(&c.group, "group", false, "Show identical results from several targets once")
 	f.Var(cmd.NewStringsValue(nil, &c.machines), "machine", "One or more machine ids")
 	f.Var(cmd.NewStringsValue(nil, &c.applications), "a", "One or more application names")
 	f.Var(cmd.NewStringsValue(nil, &c.applications), "app", "")
@@ -344,6 +361,8 @@ func (c *execCommand) Run(ctx *cmd.Context) error {
 
 	timeout := c.timeAfter(c.timeout)
 	values := []interface{}{}
+	var results []execResult
+	var failedOn string
 	for len(actionsToQuery) > 0 {
 		actionResults, err := client.Actions(entities(actionsToQuery))
 		if err != nil {
@@ -360,10 +379,25 @@ func (c *execCommand) Run(ctx *cmd.Context) error {
 				}
 			}
 
-			values = append(values, ConvertActionResults(result, actionsToQuery[i], c.compat))
+			value := ConvertActionResults(result, actionsToQuery[i], c.compat)
+			values = append(values, value)
+			execResult := newExecResult(result, actionsToQuery[i], value, c.compat)
+			results = append(results, execResult)
+			if c.failFast && failedOn == "" && execResult.failed() {
+				failedOn = execResult.receiver
+			}
 		}
 		actionsToQuery = newActionsToQuery
 
+		if failedOn != "" && len(actionsToQuery) > 0 {
+			// Don't start the commands anywhere they haven't
+			// been started already, and stop waiting for them.
+			if _, err := client.Cancel(entities(actionsToQuery)); err != nil {
+				fmt.Fprintf(ctx.GetStderr(), "cannot cancel remaining actions: %v\n", err)
+			}
+			break
+		}
+
 		if len(actionsToQuery) > 0 {
 			var timedOut bool
 			select {
@@ -383,7 +417,7 @@ func (c *execCommand) Run(ctx *cmd.Context) error {
 
 	// If we are just dealing with one result, AND we are using the default
 	// format, then pretend we were running it locally.
-	if len(actionsToQuery) == 0 && len(values) == 1 && c.out.Name() == "default" {
+	if len(actionsToQuery) == 0 && len(values) == 1 && c.out.Name() == "default" && !c.group {
 		result, ok := values[0].(map[string]interface{})
 		if !ok {
 			return errors.New("couldn't read action output")
@@ -391,29 +425,14 @@ func (c *execCommand) Run(ctx *cmd.Context) error {
 		if res, ok := result["Error"].(string); ok {
 			return errors.New(res)
 		}
-		stdoutKey := "stdout"
-		if c.compat {
-			stdoutKey = "Stdout"
-		}
-		stderrKey := "stderr"
-		if c.compat {
-			stderrKey = "Stderr"
-		}
-		codeKey := "return-code"
-		if c.compat {
-			codeKey = "ReturnCode"
-		}
-		ctx.Stdout.Write(formatOutput(result, stdoutKey, c.compat))
-		ctx.Stderr.Write(formatOutput(result, stderrKey, c.compat))
-		if code, ok := result[codeKey].(int); ok && code != 0 {
+		keys := resultKeys(c.compat)
+		ctx.Stdout.Write(formatOutput(result, keys.stdout, c.compat))
+		ctx.Stderr.Write(formatOutput(result, keys.stderr, c.compat))
+		if code, ok := result[keys.code].(int); ok && code != 0 {
 			return cmd.NewRcPassthroughError(code)
 		}
 		// Message should always contain only errors.
-		messageKey := "message"
-		if c.compat {
-			messageKey = "Message"
-		}
-		if res, ok := result[messageKey].(string); ok && res != "" {
+		if res, ok := result[keys.message].(string); ok && res != "" {
 			ctx.Stderr.Write([]byte(res))
 		}
 
@@ -421,11 +440,21 @@ func (c *execCommand) Run(ctx *cmd.Context) error {
 	}
 
 	if len(values) > 0 {
-		if err := c.out.Write(ctx, values); err != nil {
+		if err := c.writeResults(ctx, values, results); err != nil {
 			return err
 		}
 	}
 
+	if n := len(actionsToQuery); n > 0 && failedOn != "" {
+		receivers := make([]string, n)
+		for i, actionToQuery := range actionsToQuery {
+			receivers[i] = names.ReadableString(actionToQuery.receiver.tag)
+		}
+		return errors.Errorf(
+			"stopped after failure on %s, not waiting for results from: %s",
+			failedOn, strings.Join(receivers, ", "),
+		)
+	}
 	if n := len(actionsToQuery); n > 0 {
 		// There are action results remaining, so return an error.
 		suffix := ""
@@ -444,6 +473,26 @@ func (c *execCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+// writeResults writes the results in the requested format, grouping
+// identical results if asked to.
+func (c *execCommand) writeResults(ctx *cmd.Context, values []interface{}, results []execResult) error {
+	tabular := c.out.Name() == "tabular"
+	switch {
+	case c.group && tabular:
+		return c.out.Write(ctx, groupExecResults(results))
+	case c.group:
+		groups := groupExecResults(results)
+		grouped := make([]interface{}, len(groups))
+		for i, group := range groups {
+			grouped[i] = group.value()
+		}
+		return c.out.Write(ctx, grouped)
+	case tabular:
+		return c.out.Write(ctx, results)
+	}
+	return c.out.Write(ctx, values)
+}
+
 type actionReceiver struct {
 	receiverType string
 	tag          names.Tag
@@ -460,6 +460,141 @@ func (s *ExecSuite) TestTimeout(c *gc.C) {
 	})
 }
 
+func (s *ExecSuite) setupSummaryResponses(c *gc.C) *mockExecAPI {
+	mock := s.setupMockAPI()
+	mock.setMachinesAlive("0", "1", "2")
+	mock.setResponse("0", mockResponse{
+		stdout:     "megatron\nmore\n",
+		code:       0,
+		machineTag: "machine-0",
+	})
+	mock.setResponse("1", mockResponse{
+		stdout:     "megatron\nmore\n",
+		code:       0,
+		machineTag: "machine-1",
+	})
+	mock.setResponse("2", mockResponse{
+		message:    "command timed out",
+		machineTag: "machine-2",
+	})
+	started := time.Date(2020, time.February, 14, 8, 13, 0, 0, time.UTC)
+	mock.actionResponses = make(map[string]params.ActionResult)
+	for _, id := range []string{"0", "1", "2"} {
+		result := mock.execResponses[id]
+		if id != "2" {
+			result.Started = started
+			result.Completed = started.Add(1520 * time.Millisecond)
+		}
+		mock.actionResponses[mock.receiverIdMap[id]] = result
+	}
+	return mock
+}
+
+func (s *ExecSuite) TestTabularOutput(c *gc.C) {
+	s.setupSummaryResponses(c)
+
+	context, err := cmdtesting.RunCommand(c, newTestExecCommand(&mockClock{}, model.IAAS), "--format=tabular", "--all", "hostname")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, `
+Target  Code  Duration  Output
+0       0     1.5s      megatron
+1       0     1.5s      megatron
+2       -               command timed out
+`[1:])
+	c.Check(cmdtesting.Stderr(context), gc.Equals, "")
+}
+
+func (s *ExecSuite) TestTabularGroupedOutput(c *gc.C) {
+	s.setupSummaryResponses(c)
+
+	context, err := cmdtesting.RunCommand(c, newTestExecCommand(&mockClock{}, model.IAAS), "--format=tabular", "--group", "--all", "hostname")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, `
+Targets  Code  Output
+0,1      0     megatron
+2        -     command timed out
+`[1:])
+}
+
+func (s *ExecSuite) TestGroupedOutput(c *gc.C) {
+	s.setupSummaryResponses(c)
+
+	var buf bytes.Buffer
+	err := cmd.FormatJson(&buf, []interface{}{
+		map[string]interface{}{
+			"stdout":      "megatron\nmore\n",
+			"return-code": 0,
+			"targets":     []string{"0", "1"},
+		},
+		map[string]interface{}{
+			"message": "command timed out",
+			"targets": []string{"2"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	context, err := cmdtesting.RunCommand(c, newTestExecCommand(&mockClock{}, model.IAAS), "--format=json", "--group", "--all", "hostname")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, buf.String())
+}
+
+func (s *ExecSuite) TestGroupTargetsSummarised(c *gc.C) {
+	c.Check(formatTargets([]string{"0", "1", "2"}), gc.Equals, "0,1,2")
+	c.Check(formatTargets([]string{"0", "1", "2", "3", "4"}), gc.Equals, "0,1,2 (+2 more)")
+}
+
+func (s *ExecSuite) TestSortExecResults(c *gc.C) {
+	var results []execResult
+	for _, tag := range []names.Tag{
+		names.NewUnitTag("mysql/0"),
+		names.NewMachineTag("10"),
+		names.NewMachineTag("2"),
+		names.NewUnitTag("mysql/0"),
+	} {
+		results = append(results, execResult{target: tag.Id(), receiver: names.ReadableString(tag)})
+	}
+	sortExecResults(results)
+	var receivers []string
+	for _, r := range results {
+		receivers = append(receivers, r.receiver)
+	}
+	c.Check(receivers, jc.DeepEquals, []string{"machine 2", "machine 10", "unit mysql/0", "unit mysql/0"})
+}
+
+func (s *ExecSuite) TestFailFast(c *gc.C) {
+	mock := s.setupMockAPI()
+	mock.setMachinesAlive("0", "1", "2")
+	mock.setResponse("0", mockResponse{
+		stderr:     "boom\n",
+		code:       1,
+		machineTag: "machine-0",
+	})
+	mock.setResponse("1", mockResponse{
+		machineTag: "machine-1",
+		status:     params.ActionPending,
+	})
+	mock.setResponse("2", mockResponse{
+		machineTag: "machine-2",
+		status:     params.ActionRunning,
+	})
+	mock.actionResponses = map[string]params.ActionResult{
+		mock.receiverIdMap["0"]: mock.execResponses["0"],
+		mock.receiverIdMap["1"]: mock.execResponses["1"],
+		mock.receiverIdMap["2"]: mock.execResponses["2"],
+	}
+
+	context, err := cmdtesting.RunCommand(c, newTestExecCommand(&mockClock{}, model.IAAS), "--format=tabular", "--fail-fast", "--all", "hostname")
+	c.Assert(err, gc.ErrorMatches, "stopped after failure on machine 0, not waiting for results from: machine 1, machine 2")
+	c.Check(cmdtesting.Stdout(context), gc.Equals, `
+Target  Code  Duration  Output
+0       1               boom
+`[1:])
+	c.Check(mock.cancelled, jc.DeepEquals, []string{
+		names.NewActionTag(mock.receiverIdMap["1"]).String(),
+		names.NewActionTag(mock.receiverIdMap["2"]).String(),
+	})
+}
+
 func (s *ExecSuite) TestUnitLeaderSyntaxWithUnsupportedAPIVersion(c *gc.C) {
 	var (
 		clock mockClock
@@ -726,6 +861,7 @@ type mockExecAPI struct {
 	bestAPIVersion int
 	// recevied values
 	execParams *params.RunParams
+	cancelled  []string
 }
 
 type mockResponse struct {
@@ -876,6 +1012,13 @@ func (m *mockExecAPI) Actions(actionTags params.Entities) (params.ActionResults,
 	return results, nil
 }
 
+func (m *mockExecAPI) Cancel(actionTags params.Entities) (params.ActionResults, error) {
+	for _, entity := range actionTags.Entities {
+		m.cancelled = append(m.cancelled, entity.Tag)
+	}
+	return params.ActionResults{}, nil
+}
+
 func (m *mockExecAPI) BestAPIVersion() int {
 	return m.bestAPIVersion
 }
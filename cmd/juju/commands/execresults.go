@@ -0,0 +1,220 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/naturalsort"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/output"
+)
+
+// maxGroupTargets is the number of targets listed for a group
+// of identical results in tabular output before the rest are
+// summarised by count.
+const maxGroupTargets = 3
+
+// execResultKeys holds the keys under which the results of running
+// commands are found in the values built by ConvertActionResults.
+type execResultKeys struct {
+	stdout  string
+	stderr  string
+	code    string
+	message string
+}
+
+func resultKeys(compat bool) execResultKeys {
+	if compat {
+		return execResultKeys{
+			stdout:  "Stdout",
+			stderr:  "Stderr",
+			code:    "ReturnCode",
+			message: "Message",
+		}
+	}
+	return execResultKeys{
+		stdout:  "stdout",
+		stderr:  "stderr",
+		code:    "return-code",
+		message: "message",
+	}
+}
+
+// execResult summarises the result of running the commands on a
+// single target.
+type execResult struct {
+	target   string
+	receiver string
+	code     int
+	duration time.Duration
+	stdout   string
+	stderr   string
+	message  string
+	err      string
+	status   string
+
+	// value holds the result as converted for YAML or JSON output.
+	value map[string]interface{}
+}
+
+func newExecResult(result params.ActionResult, query actionQuery, value map[string]interface{}, compat bool) execResult {
+	r := execResult{
+		target:   query.receiver.tag.Id(),
+		receiver: names.ReadableString(query.receiver.tag),
+		status:   result.Status,
+		value:    value,
+	}
+	if err, ok := value["Error"].(string); ok {
+		r.err = err
+		return r
+	}
+	keys := resultKeys(compat)
+	r.stdout = string(formatOutput(value, keys.stdout, compat))
+	r.stderr = string(formatOutput(value, keys.stderr, compat))
+	r.code, _ = value[keys.code].(int)
+	r.message, _ = value[keys.message].(string)
+	if !result.Started.IsZero() && !result.Completed.IsZero() {
+		r.duration = result.Completed.Sub(result.Started)
+	}
+	return r
+}
+
+// failed returns whether the commands could not be run on the target,
+// or exited with a non-zero code.
+func (r execResult) failed() bool {
+	switch {
+	case r.err != "", r.message != "", r.code != 0:
+		return true
+	case r.status == params.ActionFailed, r.status == params.ActionCancelled:
+		return true
+	}
+	return false
+}
+
+// summary returns the first line of the commands' output, or of the
+// reason for their failure.
+func (r execResult) summary() string {
+	for _, s := range []string{r.err, r.message, r.stdout, r.stderr} {
+		if s = strings.TrimSpace(s); s != "" {
+			return strings.SplitN(s, "\n", 2)[0]
+		}
+	}
+	return ""
+}
+
+// groupKey identifies results which are the same on different targets.
+func (r execResult) groupKey() string {
+	return fmt.Sprintf("%d\x00%s\x00%s\x00%s\x00%s\x00%s", r.code, r.stdout, r.stderr, r.message, r.err, r.status)
+}
+
+// execResultGroup holds the results which are identical on
+// several targets.
+type execResultGroup struct {
+	targets []string
+	result  execResult
+}
+
+// groupExecResults groups the results which are identical across
+// targets, largest groups first.
+func groupExecResults(results []execResult) []*execResultGroup {
+	var groups []*execResultGroup
+	byKey := make(map[string]*execResultGroup)
+	for _, r := range sortExecResults(results) {
+		key := r.groupKey()
+		group, ok := byKey[key]
+		if !ok {
+			group = &execResultGroup{result: r}
+			byKey[key] = group
+			groups = append(groups, group)
+		}
+		group.targets = append(group.targets, r.target)
+	}
+	// Groups of the same size stay in the order of their first target.
+	sort.SliceStable(groups, func(i, j int) bool {
+		return len(groups[i].targets) > len(groups[j].targets)
+	})
+	return groups
+}
+
+// value returns the group's result as converted for YAML or JSON
+// output, with the targets it was seen on replacing the receiver.
+func (g *execResultGroup) value() map[string]interface{} {
+	value := make(map[string]interface{})
+	for k, v := range g.result.value {
+		switch k {
+		case "MachineId", "UnitId", "ReceiverId", "unit", "Action":
+			continue
+		}
+		value[k] = v
+	}
+	value["targets"] = g.targets
+	return value
+}
+
+// sortExecResults sorts the results in place by receiver, and
+// returns them.
+func sortExecResults(results []execResult) []execResult {
+	sort.SliceStable(results, func(i, j int) bool {
+		return naturalLess(results[i].receiver, results[j].receiver)
+	})
+	return results
+}
+
+// naturalLess reports whether a sorts before b in natural order, so
+// that "machine 2" comes before "machine 10".
+func naturalLess(a, b string) bool {
+	return a != b && naturalsort.Sort([]string{a, b})[0] == a
+}
+
+// formatTabular writes a summary of the results, one line per target,
+// or per group of targets with identical results.
+func (c *execCommand) formatTabular(writer io.Writer, value interface{}) error {
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	switch value := value.(type) {
+	case []execResult:
+		w.Println("Target", "Code", "Duration", "Output")
+		for _, r := range sortExecResults(value) {
+			w.Println(r.target, formatCode(r), formatDuration(r), r.summary())
+		}
+	case []*execResultGroup:
+		w.Println("Targets", "Code", "Output")
+		for _, g := range value {
+			w.Println(formatTargets(g.targets), formatCode(g.result), g.result.summary())
+		}
+	default:
+		return errors.Errorf("unexpected value of type %T", value)
+	}
+	return tw.Flush()
+}
+
+func formatCode(r execResult) string {
+	if r.err != "" || (r.code == 0 && r.failed()) {
+		return "-"
+	}
+	return fmt.Sprint(r.code)
+}
+
+func formatDuration(r execResult) string {
+	if r.duration == 0 {
+		return ""
+	}
+	return r.duration.Round(100 * time.Millisecond).String()
+}
+
+func formatTargets(targets []string) string {
+	if len(targets) <= maxGroupTargets {
+		return strings.Join(targets, ",")
+	}
+	return fmt.Sprintf("%s (+%d more)",
+		strings.Join(targets[:maxGroupTargets], ","), len(targets)-maxGroupTargets)
+}
@@ -442,6 +442,7 @@ var commandNames = []string{
 	"attach",
 	"attach-resource",
 	"attach-storage",
+	"audit-log",
 	"autoload-credentials",
 	"backups",
 	"bind",
@@ -460,6 +461,7 @@ var commandNames = []string{
 	"controllers",
 	"create-backup",
 	"create-storage-pool",
+	"create-storage-snapshot",
 	"create-wallet",
 	"credentials",
 	"debug-hook",
@@ -485,6 +487,7 @@ var commandNames = []string{
 	"expose",
 	"find-offers",
 	"firewall-rules",
+	"firewall-services",
 	"get-constraints",
 	"get-model-constraints",
 	"grant",
@@ -507,6 +510,7 @@ var commandNames = []string{
 	"list-credentials",
 	"list-disabled-commands",
 	"list-firewall-rules",
+	"list-firewall-services",
 	"list-machines",
 	"list-models",
 	"list-offers",
@@ -518,6 +522,7 @@ var commandNames = []string{
 	"list-ssh-keys",
 	"list-storage",
 	"list-storage-pools",
+	"list-storage-snapshots",
 	"list-subnets",
 	"list-users",
 	"list-wallets",
@@ -544,6 +549,7 @@ var commandNames = []string{
 	"remove-cloud",
 	"remove-consumed-application",
 	"remove-credential",
+	"remove-firewall-service",
 	"remove-k8s",
 	"remove-machine",
 	"remove-offer",
@@ -552,12 +558,15 @@ var commandNames = []string{
 	"remove-ssh-key",
 	"remove-storage",
 	"remove-storage-pool",
+	"remove-storage-snapshot",
 	"remove-unit",
 	"remove-user",
+	"resize-storage",
 	"resolved",
 	"resolve",
 	"resources",
 	"restore-backup",
+	"restore-storage-snapshot",
 	"resume-relation",
 	"retry-provisioning",
 	"revoke",
@@ -570,6 +579,7 @@ var commandNames = []string{
 	"set-default-credential",
 	"set-default-region",
 	"set-firewall-rule",
+	"set-firewall-service",
 	"set-meter-status",
 	"set-model-constraints",
 	"set-plan",
@@ -597,6 +607,8 @@ var commandNames = []string{
 	"status",
 	"storage",
 	"storage-pools",
+	"storage-resize",
+	"storage-snapshots",
 	"subnets",
 	"suspend-relation",
 	"switch",
@@ -0,0 +1,284 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	apiauditlog "github.com/juju/juju/api/auditlog"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const defaultAuditLogLimit = 50
+
+var auditLogDoc = `
+Shows the API requests recorded in the controller's audit log, oldest
+first. Only controller superusers can read the audit log.
+
+Each request is shown along with the user who made it, the model it was
+made against and the command being run. Requests can be selected by
+user, by model (either its qualified name or its UUID), by the API
+facade or method called, and by time. Use --errors-only to show only
+requests that failed.
+
+The --from and --to options take either a time in RFC3339 format, or a
+duration such as 2h, which is taken to be that long ago. Times are shown
+in UTC.
+
+The most recent requests matching the filters are shown, up to the
+number given by --limit; --limit 0 shows all of them. Older records are
+discarded once the audit log reaches the size set by the
+audit-log-db-size controller config key.
+
+Arguments to API calls are only recorded if the audit-log-capture-args
+controller config key is true, and are only shown in the yaml and json
+formats.
+
+Examples:
+
+    juju audit-log
+    juju audit-log --user bob --from 24h
+    juju audit-log --model admin/default --method Application.DestroyApplication
+    juju audit-log --errors-only --from 2020-01-01T00:00:00Z --to 2020-01-02T00:00:00Z
+    juju audit-log --format yaml --limit 0
+
+See also:
+    controller-config
+`
+
+// NewAuditLogCommand returns a command that queries the controller's
+// audit log.
+func NewAuditLogCommand() cmd.Command {
+	return modelcmd.WrapController(&auditLogCommand{clock: clock.WallClock})
+}
+
+// auditLogCommand shows the requests recorded in the controller's
+// audit log.
+type auditLogCommand struct {
+	modelcmd.ControllerCommandBase
+	api   auditLogAPI
+	clock clock.Clock
+	out   cmd.Output
+
+	user       string
+	model      string
+	facade     string
+	method     string
+	from       string
+	to         string
+	errorsOnly bool
+	limit      int
+
+	args params.AuditLogQueryArgs
+}
+
+type auditLogAPI interface {
+	Close() error
+	Query(args params.AuditLogQueryArgs) ([]params.AuditLogEntry, error)
+}
+
+// Info implements Command.Info.
+func (c *auditLogCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "audit-log",
+		Purpose: "Shows the requests recorded in the controller's audit log.",
+		Doc:     auditLogDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *auditLogCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.user, "user", "", "Only show requests made by this user")
+	f.StringVar(&c.model, "model", "", "Only show requests made against this model")
+	f.StringVar(&c.facade, "facade", "", "Only show requests to this API facade")
+	f.StringVar(&c.method, "method", "", "Only show calls to this API method, given as Method or Facade.Method")
+	f.StringVar(&c.from, "from", "", "Only show requests made at or after this time")
+	f.StringVar(&c.to, "to", "", "Only show requests made at or before this time")
+	f.BoolVar(&c.errorsOnly, "errors-only", false, "Only show requests that failed")
+	f.IntVar(&c.limit, "limit", defaultAuditLogLimit, "The maximum number of requests to show")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatAuditLogTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *auditLogCommand) Init(args []string) error {
+	if c.limit < 0 {
+		return errors.Errorf("--limit cannot be negative")
+	}
+	c.args = params.AuditLogQueryArgs{
+		User:       c.user,
+		Model:      c.model,
+		Facade:     c.facade,
+		Method:     c.method,
+		ErrorsOnly: c.errorsOnly,
+		Limit:      c.limit,
+	}
+	if parts := strings.Split(c.method, "."); len(parts) == 2 {
+		if c.facade != "" && c.facade != parts[0] {
+			return errors.Errorf("--method %q does not belong to --facade %q", c.method, c.facade)
+		}
+		c.args.Facade, c.args.Method = parts[0], parts[1]
+	} else if len(parts) > 2 {
+		return errors.Errorf("invalid --method %q: expected Method or Facade.Method", c.method)
+	}
+
+	now := c.clock.Now()
+	if c.from != "" {
+		from, err := parseAuditLogTime("--from", c.from, now)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.args.From = &from
+	}
+	if c.to != "" {
+		to, err := parseAuditLogTime("--to", c.to, now)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.args.To = &to
+	}
+	if c.args.From != nil && c.args.To != nil && c.args.To.Before(*c.args.From) {
+		return errors.New("--to cannot be before --from")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// parseAuditLogTime parses value as either an RFC3339 time or as a
+// duration before now.
+func parseAuditLogTime(flag, value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d).UTC(), nil
+	}
+	return time.Time{}, errors.Errorf(
+		"invalid %s time %q: expected RFC3339 format, e.g. 2020-01-01T12:00:00Z, or a duration, e.g. 2h", flag, value)
+}
+
+func (c *auditLogCommand) getAPI() (auditLogAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return apiauditlog.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *auditLogCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	entries, err := client.Query(c.args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(entries) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No matching requests in the audit log.")
+		return nil
+	}
+	result := make([]auditLogEntry, len(entries))
+	for i, entry := range entries {
+		result[i] = newAuditLogEntry(entry)
+	}
+	return c.out.Write(ctx, result)
+}
+
+// auditLogEntry is the serialization format for a request in the
+// audit log.
+type auditLogEntry struct {
+	Time           time.Time       `yaml:"time" json:"time"`
+	User           string          `yaml:"user" json:"user"`
+	Model          string          `yaml:"model" json:"model"`
+	ModelUUID      string          `yaml:"model-uuid" json:"model-uuid"`
+	Command        string          `yaml:"command,omitempty" json:"command,omitempty"`
+	ConversationID string          `yaml:"conversation-id" json:"conversation-id"`
+	ConnectionID   string          `yaml:"connection-id" json:"connection-id"`
+	RequestID      uint64          `yaml:"request-id" json:"request-id"`
+	Facade         string          `yaml:"facade" json:"facade"`
+	Method         string          `yaml:"method" json:"method"`
+	Version        int             `yaml:"version" json:"version"`
+	Args           string          `yaml:"args,omitempty" json:"args,omitempty"`
+	Errors         []auditLogError `yaml:"errors,omitempty" json:"errors,omitempty"`
+}
+
+type auditLogError struct {
+	Message string `yaml:"message" json:"message"`
+	Code    string `yaml:"code,omitempty" json:"code,omitempty"`
+}
+
+func newAuditLogEntry(entry params.AuditLogEntry) auditLogEntry {
+	result := auditLogEntry{
+		Time:           entry.When.UTC(),
+		User:           entry.Who,
+		Model:          entry.ModelName,
+		ModelUUID:      entry.ModelUUID,
+		Command:        entry.What,
+		ConversationID: entry.ConversationID,
+		ConnectionID:   entry.ConnectionID,
+		RequestID:      entry.RequestID,
+		Facade:         entry.Facade,
+		Method:         entry.Method,
+		Version:        entry.Version,
+		Args:           entry.Args,
+	}
+	for _, e := range entry.Errors {
+		result.Errors = append(result.Errors, auditLogError{
+			Message: e.Message,
+			Code:    e.Code,
+		})
+	}
+	return result
+}
+
+func formatAuditLogTabular(writer io.Writer, value interface{}) error {
+	entries, ok := value.([]auditLogEntry)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Time", "User", "Model", "Method", "Command", "Error")
+	for _, entry := range entries {
+		var errorMessage string
+		switch len(entry.Errors) {
+		case 0:
+		case 1:
+			errorMessage = entry.Errors[0].Message
+		default:
+			errorMessage = fmt.Sprintf("%s (and %d more)", entry.Errors[0].Message, len(entry.Errors)-1)
+		}
+		w.Println(
+			entry.Time.Format(time.RFC3339),
+			entry.User,
+			entry.Model,
+			entry.Facade+"."+entry.Method,
+			entry.Command,
+			errorMessage,
+		)
+	}
+	return tw.Flush()
+}
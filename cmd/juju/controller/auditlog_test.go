@@ -0,0 +1,200 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+)
+
+type AuditLogSuite struct {
+	baseControllerSuite
+	api   *fakeAuditLogAPI
+	clock *testclock.Clock
+}
+
+var _ = gc.Suite(&AuditLogSuite{})
+
+func (s *AuditLogSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.createTestClientStore(c)
+	s.api = &fakeAuditLogAPI{}
+	s.clock = testclock.NewClock(time.Date(2020, 1, 2, 12, 0, 0, 0, time.UTC))
+}
+
+func (s *AuditLogSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := controller.NewAuditLogCommandForTest(s.api, s.clock, s.store)
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *AuditLogSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--limit", "-1"},
+		err:  "--limit cannot be negative",
+	}, {
+		args: []string{"--method", "A.B.C"},
+		err:  `invalid --method "A.B.C": expected Method or Facade.Method`,
+	}, {
+		args: []string{"--facade", "Client", "--method", "Application.Deploy"},
+		err:  `--method "Application.Deploy" does not belong to --facade "Client"`,
+	}, {
+		args: []string{"--from", "yesterday"},
+		err:  `invalid --from time "yesterday": expected RFC3339 format, e.g. 2020-01-01T12:00:00Z, or a duration, e.g. 2h`,
+	}, {
+		args: []string{"--from", "1h", "--to", "2h"},
+		err:  "--to cannot be before --from",
+	}, {
+		args: []string{"extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		command := controller.NewAuditLogCommandForTest(s.api, s.clock, s.store)
+		err := cmdtesting.InitCommand(command, test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *AuditLogSuite) TestQueryArgs(c *gc.C) {
+	_, err := s.run(c,
+		"--user", "bob",
+		"--model", "bob/prod",
+		"--method", "Application.DestroyApplication",
+		"--from", "24h",
+		"--to", "2020-01-02T11:00:00+01:00",
+		"--errors-only",
+		"--limit", "10",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	from := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	to := time.Date(2020, 1, 2, 10, 0, 0, 0, time.UTC)
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"Query", []interface{}{params.AuditLogQueryArgs{
+			User:       "bob",
+			Model:      "bob/prod",
+			Facade:     "Application",
+			Method:     "DestroyApplication",
+			From:       &from,
+			To:         &to,
+			ErrorsOnly: true,
+			Limit:      10,
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *AuditLogSuite) TestDefaultLimit(c *gc.C) {
+	_, err := s.run(c, "--method", "Deploy")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "Query", params.AuditLogQueryArgs{
+		Method: "Deploy",
+		Limit:  50,
+	})
+}
+
+func (s *AuditLogSuite) TestTabular(c *gc.C) {
+	s.api.entries = []params.AuditLogEntry{{
+		Who:       "bob",
+		What:      "juju deploy mysql",
+		ModelName: "bob/prod",
+		When:      time.Date(2020, 1, 1, 10, 0, 1, 0, time.UTC),
+		Facade:    "Application",
+		Method:    "Deploy",
+	}, {
+		Who:       "alice",
+		What:      "juju remove-application mysql wordpress",
+		ModelName: "bob/prod",
+		When:      time.Date(2020, 1, 1, 11, 0, 2, 0, time.UTC),
+		Facade:    "Application",
+		Method:    "DestroyApplication",
+		Errors: []params.AuditLogError{
+			{Message: "application not found", Code: "not found"},
+			{Message: "permission denied", Code: "unauthorized access"},
+		},
+	}}
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Time                  User   Model     Method                          Command                                  Error
+2020-01-01T10:00:01Z  bob    bob/prod  Application.Deploy              juju deploy mysql                        
+2020-01-01T11:00:02Z  alice  bob/prod  Application.DestroyApplication  juju remove-application mysql wordpress  application not found (and 1 more)
+`[1:])
+}
+
+func (s *AuditLogSuite) TestYAML(c *gc.C) {
+	s.api.entries = []params.AuditLogEntry{{
+		ConversationID: "aaaa",
+		ConnectionID:   "A1",
+		Who:            "bob",
+		What:           "juju deploy mysql",
+		ModelName:      "bob/prod",
+		ModelUUID:      "uuid-1",
+		RequestID:      3,
+		When:           time.Date(2020, 1, 1, 10, 0, 1, 0, time.UTC),
+		Facade:         "Application",
+		Method:         "Deploy",
+		Version:        11,
+		Args:           `{"applications":[]}`,
+		Errors:         []params.AuditLogError{{Message: "boom"}},
+	}}
+	ctx, err := s.run(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- time: 2020-01-01T10:00:01Z
+  user: bob
+  model: bob/prod
+  model-uuid: uuid-1
+  command: juju deploy mysql
+  conversation-id: aaaa
+  connection-id: A1
+  request-id: 3
+  facade: Application
+  method: Deploy
+  version: 11
+  args: '{"applications":[]}'
+  errors:
+  - message: boom
+`[1:])
+}
+
+func (s *AuditLogSuite) TestNoEntries(c *gc.C) {
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No matching requests in the audit log.\n")
+}
+
+func (s *AuditLogSuite) TestQueryError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.api.CheckCallNames(c, "Query", "Close")
+}
+
+type fakeAuditLogAPI struct {
+	testing.Stub
+	entries []params.AuditLogEntry
+}
+
+func (f *fakeAuditLogAPI) Query(args params.AuditLogQueryArgs) ([]params.AuditLogEntry, error) {
+	f.MethodCall(f, "Query", args)
+	return f.entries, f.NextErr()
+}
+
+func (f *fakeAuditLogAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
@@ -33,3 +33,39 @@ func NewSetRulesCommandForTest(
 	aCmd.SetClientStore(jujuclienttesting.MinimalStore())
 	return modelcmd.Wrap(aCmd)
 }
+
+func NewSetServiceCommandForTest(
+	api SetFirewallServiceAPI,
+) cmd.Command {
+	aCmd := &setFirewallServiceCommand{
+		newAPIFunc: func() (SetFirewallServiceAPI, error) {
+			return api, nil
+		},
+	}
+	aCmd.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(aCmd)
+}
+
+func NewRemoveServiceCommandForTest(
+	api RemoveFirewallServiceAPI,
+) cmd.Command {
+	aCmd := &removeFirewallServiceCommand{
+		newAPIFunc: func() (RemoveFirewallServiceAPI, error) {
+			return api, nil
+		},
+	}
+	aCmd.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(aCmd)
+}
+
+func NewListServicesCommandForTest(
+	api ListFirewallServicesAPI,
+) cmd.Command {
+	aCmd := &listFirewallServicesCommand{
+		newAPIFunc: func() (ListFirewallServicesAPI, error) {
+			return api, nil
+		},
+	}
+	aCmd.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(aCmd)
+}
@@ -48,3 +48,39 @@ func formatFirewallRulesTabular(writer io.Writer, rules firewallRules) {
 	}
 	tw.Flush()
 }
+
+type firewallService struct {
+	Name  string `yaml:"name" json:"name"`
+	Ports string `yaml:"ports" json:"ports"`
+}
+
+type firewallServices []firewallService
+
+func (o firewallServices) Len() int      { return len(o) }
+func (o firewallServices) Swap(i, j int) { o[i], o[j] = o[j], o[i] }
+func (o firewallServices) Less(i, j int) bool {
+	return o[i].Name < o[j].Name
+}
+
+func formatListServicesTabular(writer io.Writer, value interface{}) error {
+	services, ok := value.([]firewallService)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", services, value)
+	}
+	formatFirewallServicesTabular(writer, firewallServices(services))
+	return nil
+}
+
+// formatFirewallServicesTabular returns a tabular summary of firewall services.
+func formatFirewallServicesTabular(writer io.Writer, services firewallServices) {
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+
+	sort.Sort(services)
+
+	w.Println("Service", "Ports")
+	for _, service := range services {
+		w.Println(service.Name, service.Ports)
+	}
+	tw.Flush()
+}
@@ -76,6 +76,28 @@ func (s *ListSuite) TestListYAML(c *gc.C) {
 	)
 }
 
+func (s *ListSuite) TestListServicesTabular(c *gc.C) {
+	s.mockAPI.services = []params.FirewallService{{
+		Name:     "node-exporter",
+		Protocol: "tcp",
+		FromPort: 9100,
+		ToPort:   9100,
+	}, {
+		Name:     "jmx",
+		Protocol: "tcp",
+		FromPort: 7199,
+		ToPort:   7200,
+	}}
+	context, err := cmdtesting.RunCommand(c, firewall.NewListServicesCommandForTest(s.mockAPI))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, `
+Service        Ports
+jmx            7199-7200/tcp
+node-exporter  9100/tcp
+
+`[1:])
+}
+
 func (s *ListSuite) runList(c *gc.C, args []string) (*cmd.Context, error) {
 	return cmdtesting.RunCommand(c, firewall.NewListRulesCommandForTest(s.mockAPI), args...)
 }
@@ -92,8 +114,9 @@ func (s *ListSuite) assertValidList(c *gc.C, args []string, expectedValid, expec
 }
 
 type mockListAPI struct {
-	rules []params.FirewallRule
-	err   error
+	rules    []params.FirewallRule
+	services []params.FirewallService
+	err      error
 }
 
 func (s *mockListAPI) Close() error {
@@ -106,3 +129,10 @@ func (s *mockListAPI) ListFirewallRules() ([]params.FirewallRule, error) {
 	}
 	return s.rules, nil
 }
+
+func (s *mockListAPI) ListFirewallServices() ([]params.FirewallService, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.services, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewall
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/firewallrules"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/network"
+)
+
+var listServicesHelpSummary = `
+Prints the services defined for firewall rules.`[1:]
+
+var listServicesHelpDetails = `
+Lists the services defined with set-firewall-service, to which
+firewall rules may be applied.
+
+Examples:
+    juju list-firewall-services
+    juju firewall-services
+
+See also: 
+    set-firewall-service
+    list-firewall-rules`
+
+// NewListFirewallServicesCommand returns a command to list firewall services.
+func NewListFirewallServicesCommand() cmd.Command {
+	cmd := &listFirewallServicesCommand{}
+	cmd.newAPIFunc = func() (ListFirewallServicesAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return firewallrules.NewClient(root), nil
+
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type listFirewallServicesCommand struct {
+	modelcmd.ModelCommandBase
+	modelcmd.IAASOnlyCommand
+	out cmd.Output
+
+	newAPIFunc func() (ListFirewallServicesAPI, error)
+}
+
+// Info implements cmd.Command.
+func (c *listFirewallServicesCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "list-firewall-services",
+		Purpose: listServicesHelpSummary,
+		Doc:     listServicesHelpDetails,
+		Aliases: []string{"firewall-services"},
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *listFirewallServicesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatListServicesTabular,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *listFirewallServicesCommand) Init(args []string) (err error) {
+	return cmd.CheckEmpty(args)
+}
+
+// ListFirewallServicesAPI defines the API methods that the list firewall
+// services command uses.
+type ListFirewallServicesAPI interface {
+	Close() error
+	ListFirewallServices() ([]params.FirewallService, error)
+}
+
+// Run implements cmd.Command.
+func (c *listFirewallServicesCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	servicesResult, err := client.ListFirewallServices()
+	if err != nil {
+		return err
+	}
+
+	services := make([]firewallService, len(servicesResult))
+	for i, s := range servicesResult {
+		services[i] = firewallService{
+			Name: string(s.Name),
+			Ports: network.PortRange{
+				Protocol: s.Protocol,
+				FromPort: s.FromPort,
+				ToPort:   s.ToPort,
+			}.String(),
+		}
+	}
+	return c.out.Write(ctx, services)
+}
@@ -0,0 +1,88 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewall
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/firewallrules"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var removeServiceHelpSummary = `
+Removes a service defined with set-firewall-service.`[1:]
+
+var removeServiceHelpDetails = `
+Removes the definition of a service, along with any firewall
+rule set up for it. Only services defined by an operator may
+be removed.
+
+Examples:
+    juju remove-firewall-service node-exporter
+
+See also: 
+    set-firewall-service
+    list-firewall-services`
+
+// NewRemoveFirewallServiceCommand returns a command to remove firewall services.
+func NewRemoveFirewallServiceCommand() cmd.Command {
+	cmd := &removeFirewallServiceCommand{}
+	cmd.newAPIFunc = func() (RemoveFirewallServiceAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return firewallrules.NewClient(root), nil
+
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type removeFirewallServiceCommand struct {
+	modelcmd.ModelCommandBase
+	modelcmd.IAASOnlyCommand
+	service string
+
+	newAPIFunc func() (RemoveFirewallServiceAPI, error)
+}
+
+// Info implements cmd.Command.
+func (c *removeFirewallServiceCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "remove-firewall-service",
+		Args:    "<service-name>",
+		Purpose: removeServiceHelpSummary,
+		Doc:     removeServiceHelpDetails,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *removeFirewallServiceCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("no service specified")
+	}
+	c.service = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// RemoveFirewallServiceAPI defines the API methods that the remove
+// firewall service command uses.
+type RemoveFirewallServiceAPI interface {
+	Close() error
+	RemoveFirewallService(name string) error
+}
+
+// Run implements cmd.Command.
+func (c *removeFirewallServiceCommand) Run(_ *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	err = client.RemoveFirewallService(c.service)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
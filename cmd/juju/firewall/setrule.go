@@ -29,11 +29,15 @@ and a whitelist of allowed ingress subnets.
 The currently supported services are:
 %v
 
+Rules may also be set for services defined with set-firewall-service.
+
 Examples:
     juju set-firewall-rule ssh --whitelist 192.168.1.0/16
+    juju set-firewall-rule node-exporter --whitelist 10.0.0.0/8
 
 See also: 
-    list-firewall-rules`
+    list-firewall-rules
+    set-firewall-service`
 
 // NewSetFirewallRuleCommand returns a command to set firewall rules.
 func NewSetFirewallRuleCommand() cmd.Command {
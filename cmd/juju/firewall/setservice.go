@@ -0,0 +1,107 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewall
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/firewallrules"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/network"
+)
+
+var setServiceHelpSummary = `
+Defines a service to which firewall rules may be applied.`[1:]
+
+var setServiceHelpDetails = `
+Firewall rules control ingress to well known services within a
+Juju model. As well as the services known to Juju, operators may
+define their own services, made up of a port range and protocol.
+Once a service is defined, set-firewall-rule may be used to
+whitelist the subnets allowed access to it.
+
+A firewall rule for a service applies to any port range opened by
+the units of an exposed application which lies within the service's
+port range, in place of the subnets the application is exposed to.
+
+If no protocol is given with the port range, tcp is used.
+Defining a service that already exists updates its port range.
+
+Examples:
+    juju set-firewall-service node-exporter 9100/tcp
+    juju set-firewall-service jmx 7199-7200
+
+See also: 
+    remove-firewall-service
+    list-firewall-services
+    set-firewall-rule`
+
+// NewSetFirewallServiceCommand returns a command to define firewall services.
+func NewSetFirewallServiceCommand() cmd.Command {
+	cmd := &setFirewallServiceCommand{}
+	cmd.newAPIFunc = func() (SetFirewallServiceAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return firewallrules.NewClient(root), nil
+
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type setFirewallServiceCommand struct {
+	modelcmd.ModelCommandBase
+	modelcmd.IAASOnlyCommand
+	service   string
+	portRange network.PortRange
+
+	newAPIFunc func() (SetFirewallServiceAPI, error)
+}
+
+// Info implements cmd.Command.
+func (c *setFirewallServiceCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "set-firewall-service",
+		Args:    "<service-name> <port>[-<port>][/<protocol>]",
+		Purpose: setServiceHelpSummary,
+		Doc:     setServiceHelpDetails,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *setFirewallServiceCommand) Init(args []string) (err error) {
+	switch len(args) {
+	case 0:
+		return errors.New("no service specified")
+	case 1:
+		return errors.New("no port range specified")
+	}
+	c.service = args[0]
+	if c.portRange, err = network.ParsePortRange(args[1]); err != nil {
+		return errors.Annotatef(err, "invalid port range %q", args[1])
+	}
+	return cmd.CheckEmpty(args[2:])
+}
+
+// SetFirewallServiceAPI defines the API methods that the set firewall
+// service command uses.
+type SetFirewallServiceAPI interface {
+	Close() error
+	SetFirewallService(name string, portRange network.PortRange) error
+}
+
+// Run implements cmd.Command.
+func (c *setFirewallServiceCommand) Run(_ *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	err = client.SetFirewallService(c.service, c.portRange)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
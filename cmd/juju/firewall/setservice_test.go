@@ -0,0 +1,98 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewall_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/firewall"
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/testing"
+)
+
+type SetServiceSuite struct {
+	testing.BaseSuite
+
+	mockAPI *mockServiceAPI
+}
+
+var _ = gc.Suite(&SetServiceSuite{})
+
+func (s *SetServiceSuite) SetUpTest(c *gc.C) {
+	s.mockAPI = &mockServiceAPI{}
+}
+
+func (s *SetServiceSuite) TestInitMissingService(c *gc.C) {
+	_, err := s.runSetService(c)
+	c.Assert(err, gc.ErrorMatches, "no service specified")
+}
+
+func (s *SetServiceSuite) TestInitMissingPortRange(c *gc.C) {
+	_, err := s.runSetService(c, "node-exporter")
+	c.Assert(err, gc.ErrorMatches, "no port range specified")
+}
+
+func (s *SetServiceSuite) TestInitInvalidPortRange(c *gc.C) {
+	_, err := s.runSetService(c, "node-exporter", "9100/foo")
+	c.Assert(err, gc.ErrorMatches, `invalid port range "9100/foo": .*`)
+}
+
+func (s *SetServiceSuite) TestSetService(c *gc.C) {
+	_, err := s.runSetService(c, "node-exporter", "9100")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.name, gc.Equals, "node-exporter")
+	c.Assert(s.mockAPI.portRange, jc.DeepEquals, network.PortRange{Protocol: "tcp", FromPort: 9100, ToPort: 9100})
+}
+
+func (s *SetServiceSuite) TestSetServiceError(c *gc.C) {
+	s.mockAPI.err = errors.New("fail")
+	_, err := s.runSetService(c, "node-exporter", "9100-9110/udp")
+	c.Assert(err, gc.ErrorMatches, ".*fail.*")
+}
+
+func (s *SetServiceSuite) TestRemoveService(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, firewall.NewRemoveServiceCommandForTest(s.mockAPI), "node-exporter")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mockAPI.name, gc.Equals, "node-exporter")
+}
+
+func (s *SetServiceSuite) TestRemoveServiceMissingService(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, firewall.NewRemoveServiceCommandForTest(s.mockAPI))
+	c.Assert(err, gc.ErrorMatches, "no service specified")
+}
+
+func (s *SetServiceSuite) runSetService(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, firewall.NewSetServiceCommandForTest(s.mockAPI), args...)
+}
+
+type mockServiceAPI struct {
+	name      string
+	portRange network.PortRange
+	err       error
+}
+
+func (s *mockServiceAPI) Close() error {
+	return nil
+}
+
+func (s *mockServiceAPI) SetFirewallService(name string, portRange network.PortRange) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.name = name
+	s.portRange = portRange
+	return nil
+}
+
+func (s *mockServiceAPI) RemoveFirewallService(name string) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.name = name
+	return nil
+}
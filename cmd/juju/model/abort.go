@@ -22,6 +22,9 @@ const (
 Aborting a branch aborts changes made to that branch.  A branch
 can only be aborted if no units are tracked by that branch.
 
+Aborting a branch that is being rolled out with "juju commit --batch-size"
+stops the rollout, after which no further units are set to track the branch.
+
 Examples:
     juju abort upgrade-postgresql
 
@@ -5,6 +5,7 @@ package model
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -23,8 +24,18 @@ Committing a branch writes changes to charm configuration made under the
 branch, to the model. All units who's applications were changed under the 
 branch realise those changes, as will any new units.
 
+By default the changes are realised by all units at once. Supplying
+--batch-size rolls the changes out progressively instead: units are set
+to track the branch in batches of the given size, waiting for
+--batch-interval after each batch. With --halt-on-error, the rollout stops
+if any unit tracking the branch has a workload status of "error". The
+branch is committed once all units are tracking it and the interval after
+the last batch has passed. Progress is reported by "juju diff", and
+a rollout can be stopped with "juju abort".
+
 Examples:
     juju commit upgrade-postgresql
+    juju commit upgrade-postgresql --batch-size 2 --batch-interval 5m --halt-on-error
 
 See also:
     add-branch
@@ -48,6 +59,7 @@ type commitCommand struct {
 	api CommitCommandAPI
 
 	branchName string
+	policy     model.RolloutPolicy
 }
 
 // CommitCommandAPI defines an API interface to be used during testing.
@@ -60,6 +72,10 @@ type CommitCommandAPI interface {
 	// all branch changes across the model.
 	// The new generation ID of the model is returned.
 	CommitBranch(branchName string) (int, error)
+
+	// RolloutBranch starts a progressive rollout of the branch with the
+	// input name, committing it once all units are tracking it.
+	RolloutBranch(branchName string, policy model.RolloutPolicy) error
 }
 
 // Info implements part of the cmd.Command interface.
@@ -76,6 +92,9 @@ func (c *commitCommand) Info() *cmd.Info {
 // SetFlags implements part of the cmd.Command interface.
 func (c *commitCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
+	f.IntVar(&c.policy.BatchSize, "batch-size", 0, "Roll out the branch to this many units at a time")
+	f.DurationVar(&c.policy.BatchInterval, "batch-interval", time.Duration(0), "Time to wait after each batch of a rollout")
+	f.BoolVar(&c.policy.HaltOnError, "halt-on-error", false, "Stop a rollout if any unit tracking the branch is in error")
 }
 
 // Init implements part of the cmd.Command interface.
@@ -84,6 +103,16 @@ func (c *commitCommand) Init(args []string) error {
 		return errors.Errorf("must specify a branch name to commit")
 	}
 	c.branchName = args[0]
+
+	if c.policy.BatchSize == 0 {
+		if c.policy.BatchInterval != 0 || c.policy.HaltOnError {
+			return errors.New("--batch-interval and --halt-on-error require --batch-size")
+		}
+		return nil
+	}
+	if err := c.policy.Validate(); err != nil {
+		return errors.Trace(err)
+	}
 	return nil
 }
 
@@ -109,6 +138,10 @@ func (c *commitCommand) Run(ctx *cmd.Context) error {
 	}
 	defer func() { _ = client.Close() }()
 
+	if c.policy.BatchSize > 0 {
+		return c.rollout(ctx, client)
+	}
+
 	newGenId, err := client.CommitBranch(c.branchName)
 	if err != nil {
 		return err
@@ -133,3 +166,23 @@ func (c *commitCommand) Run(ctx *cmd.Context) error {
 	_, err = ctx.Stdout.Write([]byte(msg))
 	return err
 }
+
+// rollout starts a progressive rollout of the branch,
+// which commits it once all units are tracking it.
+func (c *commitCommand) rollout(ctx *cmd.Context, client CommitCommandAPI) error {
+	if err := client.RolloutBranch(c.branchName, c.policy); err != nil {
+		return err
+	}
+
+	// Set the active branch to be the master.
+	if err := c.SetActiveBranch(model.GenerationMaster); err != nil {
+		return err
+	}
+
+	msg := fmt.Sprintf("Rolling out branch %q in batches of %d units; ", c.branchName, c.policy.BatchSize)
+	msg = msg + "it will be committed once all units are tracking it"
+	msg = msg + fmt.Sprintf("\nActive branch set to %q\n", model.GenerationMaster)
+
+	_, err := ctx.Stdout.Write([]byte(msg))
+	return err
+}
@@ -4,6 +4,8 @@
 package model_test
 
 import (
+	"time"
+
 	"github.com/golang/mock/gomock"
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -32,6 +34,33 @@ func (s *commitSuite) TestInitFail(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "must specify a branch name to commit")
 }
 
+func (s *commitSuite) TestInitRolloutFlags(c *gc.C) {
+	err := s.runInit(s.branchName, "--batch-size", "2", "--batch-interval", "5m", "--halt-on-error")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.runInit(s.branchName, "--halt-on-error")
+	c.Assert(err, gc.ErrorMatches, "--batch-interval and --halt-on-error require --batch-size")
+
+	err = s.runInit(s.branchName, "--batch-size", "-1")
+	c.Assert(err, gc.ErrorMatches, "batch size -1 not valid")
+}
+
+func (s *commitSuite) TestRunCommandRollout(c *gc.C) {
+	ctrl, api := setUpCancelMocks(c)
+	defer ctrl.Finish()
+
+	policy := coremodel.RolloutPolicy{BatchSize: 2, BatchInterval: 5 * time.Minute, HaltOnError: true}
+	api.EXPECT().RolloutBranch(s.branchName, policy).Return(nil)
+
+	ctx, err := cmdtesting.RunCommand(c, model.NewCommitCommandForTest(api, s.store),
+		s.branchName, "--batch-size", "2", "--batch-interval", "5m", "--halt-on-error")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Rolling out branch "new-branch" in batches of 2 units; it will be committed once all units are tracking it
+Active branch set to "master"
+`[1:])
+}
+
 func (s *commitSuite) TestRunCommandAborted(c *gc.C) {
 	ctrl, api := setUpCancelMocks(c)
 	defer ctrl.Finish()
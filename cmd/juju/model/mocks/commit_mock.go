@@ -6,6 +6,7 @@ package mocks
 
 import (
 	gomock "github.com/golang/mock/gomock"
+	model "github.com/juju/juju/core/model"
 	reflect "reflect"
 )
 
@@ -56,3 +57,15 @@ func (m *MockCommitCommandAPI) CommitBranch(arg0 string) (int, error) {
 func (mr *MockCommitCommandAPIMockRecorder) CommitBranch(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitBranch", reflect.TypeOf((*MockCommitCommandAPI)(nil).CommitBranch), arg0)
 }
+
+// RolloutBranch mocks base method
+func (m *MockCommitCommandAPI) RolloutBranch(arg0 string, arg1 model.RolloutPolicy) error {
+	ret := m.ctrl.Call(m, "RolloutBranch", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RolloutBranch indicates an expected call of RolloutBranch
+func (mr *MockCommitCommandAPIMockRecorder) RolloutBranch(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RolloutBranch", reflect.TypeOf((*MockCommitCommandAPI)(nil).RolloutBranch), arg0, arg1)
+}
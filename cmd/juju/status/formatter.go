@@ -278,6 +278,7 @@ func (sf *statusFormatter) formatApplication(name string, application params.App
 		StatusInfo:       sf.getApplicationStatusInfo(application),
 		Version:          application.WorkloadVersion,
 		EndpointBindings: application.EndpointBindings,
+		ExposedEndpoints: formatExposedEndpoints(application.ExposedEndpoints),
 	}
 
 	for k, m := range application.Units {
@@ -293,6 +294,25 @@ func (sf *statusFormatter) formatApplication(name string, application params.App
 	return out
 }
 
+// formatExposedEndpoints returns the input expose settings as displayed by
+// status, with the settings that apply to all endpoints keyed by "*".
+func formatExposedEndpoints(in map[string]params.ExposedEndpoint) map[string]exposedEndpoint {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make(map[string]exposedEndpoint, len(in))
+	for endpoint, exposed := range in {
+		if endpoint == "" {
+			endpoint = "*"
+		}
+		out[endpoint] = exposedEndpoint{
+			ExposeToSpaces: exposed.ExposeToSpaces,
+			ExposeToCIDRs:  exposed.ExposeToCIDRs,
+		}
+	}
+	return out
+}
+
 func (sf *statusFormatter) formatRemoteApplication(name string, application params.RemoteApplicationStatus) remoteApplicationStatus {
 	out := remoteApplicationStatus{
 		Err:        typedNilCheck(application.Err),
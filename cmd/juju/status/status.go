@@ -68,6 +68,18 @@ type statusCommand struct {
 
 	// storage indicates if 'storage' section is displayed
 	storage bool
+
+	// watch indicates if status is displayed again as the model changes
+	watch bool
+
+	// diff indicates if only the changes to status are displayed when watching
+	diff bool
+
+	// watcher reports changes to the model when watching
+	watcher allWatcher
+
+	// formatters holds the available output formats, by name
+	formatters map[string]cmd.Formatter
 }
 
 var usageSummary = `
@@ -110,12 +122,20 @@ In tabular format, 'Relations' section is not displayed by default.
 Use --relations option to see this section. This option is ignored in all other
 formats.
 
+The --watch option keeps the command running, displaying the status again
+each time the model changes, until interrupted. With --diff, only the lines
+that have changed since the status was last displayed are shown, prefixed
+with '+' if they were added or '-' if they were removed; --diff implies
+--watch.
+
 Examples:
     juju show-status
     juju show-status mysql
     juju show-status nova-*
     juju show-status --relations
     juju show-status --storage
+    juju show-status --watch
+    juju show-status --diff mysql
 
 See also:
     machines
@@ -145,6 +165,9 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.IntVar(&c.retryCount, "retry-count", 3, "Number of times to retry API failures")
 	f.DurationVar(&c.retryDelay, "retry-delay", 100*time.Millisecond, "Time to wait between retry attempts")
 
+	f.BoolVar(&c.watch, "watch", false, "Display status again whenever the model changes")
+	f.BoolVar(&c.diff, "diff", false, "Only display what has changed when watching status")
+
 	c.checkProvidedIgnoredFlagF = func() set.Strings {
 		ignoredFlagForNonTabularFormat := set.NewStrings(
 			"relations",
@@ -162,7 +185,7 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 
 	defaultFormat := "tabular"
 
-	c.out.AddFlags(f, defaultFormat, map[string]cmd.Formatter{
+	c.formatters = map[string]cmd.Formatter{
 		"yaml":    cmd.FormatYaml,
 		"json":    cmd.FormatJson,
 		"short":   FormatOneline,
@@ -170,7 +193,8 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 		"line":    FormatOneline,
 		"tabular": c.FormatTabular,
 		"summary": FormatSummary,
-	})
+	}
+	c.out.AddFlags(f, defaultFormat, c.formatters)
 }
 
 func (c *statusCommand) Init(args []string) error {
@@ -189,6 +213,9 @@ func (c *statusCommand) Init(args []string) error {
 	if c.clock == nil {
 		c.clock = clock.WallClock
 	}
+	if c.diff {
+		c.watch = true
+	}
 	return nil
 }
 
@@ -223,6 +250,9 @@ func (c *statusCommand) close() {
 	if c.storageAPI != nil {
 		c.storageAPI.Close()
 	}
+	if c.watcher != nil {
+		c.watcher.Stop()
+	}
 	return
 }
 
@@ -253,6 +283,49 @@ func (c *statusCommand) getStorageInfo(ctx *cmd.Context) (*storage.CombinedStora
 func (c *statusCommand) Run(ctx *cmd.Context) error {
 	defer c.close()
 
+	if c.watch {
+		return c.runWatch(ctx)
+	}
+
+	status, err := c.getStatusWithRetries(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.warnIgnoredFlags(ctx)
+	formatted, err := c.formatStatus(ctx, status)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	if err = c.out.Write(ctx, formatted); err != nil {
+		return err
+	}
+
+	if !status.IsEmpty() {
+		return nil
+	}
+	if len(c.patterns) == 0 {
+		modelName, err := c.ModelIdentifier()
+		if err != nil {
+			return err
+		}
+		ctx.Infof("Model %q is empty.", modelName)
+	} else {
+		plural := func() string {
+			if len(c.patterns) == 1 {
+				return ""
+			}
+			return "s"
+		}
+		ctx.Infof("Nothing matched specified filter%v.", plural())
+	}
+	return nil
+}
+
+// getStatusWithRetries gets the status of the model, retrying if the
+// call fails. Any error is written to stderr if some status was still
+// returned.
+func (c *statusCommand) getStatusWithRetries(ctx *cmd.Context) (*params.FullStatus, error) {
 	// Always attempt to get the status at least once, and retry if it fails.
 	status, err := c.getStatus()
 	if err != nil && !modelcmd.IsModelMigratedError(err) {
@@ -271,21 +344,46 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		if status == nil {
 			// Status call completely failed, there is nothing to report
-			return errors.Trace(err)
+			return nil, errors.Trace(err)
 		}
 		// Display any error, but continue to print status if some was returned
 		fmt.Fprintf(ctx.Stderr, "%v\n", err)
 	} else if status == nil {
-		return errors.Errorf("unable to obtain the current status")
+		return nil, errors.Errorf("unable to obtain the current status")
 	}
+	return status, nil
+}
 
+// warnIgnoredFlags tells the user about any options provided which
+// have no effect in the selected output format.
+func (c *statusCommand) warnIgnoredFlags(ctx *cmd.Context) {
+	if c.out.Name() == "tabular" {
+		return
+	}
+	providedIgnoredFlags := c.checkProvidedIgnoredFlagF()
+	if providedIgnoredFlags.IsEmpty() {
+		return
+	}
+	// For non-tabular formats this is redundant and needs to be mentioned to the user.
+	joinedMsg := strings.Join(providedIgnoredFlags.SortedValues(), ", ")
+	if providedIgnoredFlags.Size() > 1 {
+		joinedMsg += " options are"
+	} else {
+		joinedMsg += " option is"
+	}
+	ctx.Infof("provided %s always enabled in non tabular formats", joinedMsg)
+}
+
+// formatStatus converts the status into the value written by the
+// selected output format.
+func (c *statusCommand) formatStatus(ctx *cmd.Context, status *params.FullStatus) (interface{}, error) {
 	controllerName, err := c.ControllerName()
 	if err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 	activeBranch, err := c.ActiveBranch()
 	if err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 
 	showRelations := c.relations
@@ -293,17 +391,6 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	if c.out.Name() != "tabular" {
 		showRelations = true
 		showStorage = true
-		providedIgnoredFlags := c.checkProvidedIgnoredFlagF()
-		if !providedIgnoredFlags.IsEmpty() {
-			// For non-tabular formats this is redundant and needs to be mentioned to the user.
-			joinedMsg := strings.Join(providedIgnoredFlags.SortedValues(), ", ")
-			if providedIgnoredFlags.Size() > 1 {
-				joinedMsg += " options are"
-			} else {
-				joinedMsg += " option is"
-			}
-			ctx.Infof("provided %s always enabled in non tabular formats", joinedMsg)
-		}
 	}
 	formatterParams := newStatusFormatterParams{
 		status:         status,
@@ -316,7 +403,7 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	if showStorage {
 		storageInfo, err := c.getStorageInfo(ctx)
 		if err != nil {
-			return errors.Trace(err)
+			return nil, errors.Trace(err)
 		}
 		formatterParams.storage = storageInfo
 		if storageInfo == nil || storageInfo.Empty() {
@@ -329,32 +416,9 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 
 	formatted, err := newStatusFormatter(formatterParams).format()
 	if err != nil {
-		return errors.Trace(err)
-	}
-
-	if err = c.out.Write(ctx, formatted); err != nil {
-		return err
-	}
-
-	if !status.IsEmpty() {
-		return nil
-	}
-	if len(c.patterns) == 0 {
-		modelName, err := c.ModelIdentifier()
-		if err != nil {
-			return err
-		}
-		ctx.Infof("Model %q is empty.", modelName)
-	} else {
-		plural := func() string {
-			if len(c.patterns) == 1 {
-				return ""
-			}
-			return "s"
-		}
-		ctx.Infof("Nothing matched specified filter%v.", plural())
+		return nil, errors.Trace(err)
 	}
-	return nil
+	return formatted, nil
 }
 
 func (c *statusCommand) FormatTabular(writer io.Writer, value interface{}) error {
@@ -5618,6 +5618,17 @@ func (s *StatusSuite) TestFilterToExposedApplication(c *gc.C) {
 }
 
 // Scenario: User filters to non-exposed applications
+func (s *StatusSuite) TestFormatExposedEndpoints(c *gc.C) {
+	c.Assert(formatExposedEndpoints(nil), gc.IsNil)
+	c.Assert(formatExposedEndpoints(map[string]params.ExposedEndpoint{
+		"":   {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+		"db": {ExposeToSpaces: []string{"dmz"}},
+	}), jc.DeepEquals, map[string]exposedEndpoint{
+		"*":  {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+		"db": {ExposeToSpaces: []string{"dmz"}},
+	})
+}
+
 func (s *StatusSuite) TestFilterToNotExposedApplication(c *gc.C) {
 	ctx := s.FilteringTestSetup(c)
 	defer s.resetContext(c, ctx)
@@ -130,12 +130,133 @@ func (s *MinimalStatusSuite) TestRetryCountOfZero(c *gc.C) {
 	c.Assert(s.clock.waits, gc.HasLen, 0)
 }
 
+func (s *MinimalStatusSuite) runStatusWatch(c *gc.C, watcher *fakeAllWatcher, args ...string) (*cmd.Context, error) {
+	statusCmd := status.NewTestStatusWatchCommand(s.statusapi, s.storageapi, watcher, s.clock)
+	return cmdtesting.RunCommand(c, statusCmd, args...)
+}
+
+// setRegion returns a change which replaces the status, rather than
+// modifying it, as the previous status may still be being displayed.
+func (s *MinimalStatusSuite) setRegion(region string) func() {
+	return func() {
+		result := *s.statusapi.result
+		result.Model.CloudRegion = region
+		s.statusapi.result = &result
+	}
+}
+
+// newWatcher returns a watcher which applies each change once the
+// status has been fetched since the last.
+func (s *MinimalStatusSuite) newWatcher(changes ...func()) *fakeAllWatcher {
+	s.statusapi.fetched = make(chan struct{}, 10)
+	return &fakeAllWatcher{
+		changes: changes,
+		fetched: s.statusapi.fetched,
+		applied: make(chan struct{}),
+	}
+}
+
+func (s *MinimalStatusSuite) TestWatch(c *gc.C) {
+	watcher := s.newWatcher(s.setRegion("east"), s.setRegion("east"))
+	context, err := s.runStatusWatch(c, watcher, "--watch")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(watcher.next, gc.Equals, 3)
+	c.Assert(watcher.stopped, jc.IsTrue)
+
+	// The status is only displayed again when it has changed.
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, `
+Model  Controller  Cloud/Region  Version
+test   test        foo           
+
+Model  Controller  Cloud/Region  Version
+test   test        foo/east      
+
+`[1:])
+}
+
+func (s *MinimalStatusSuite) TestWatchDiff(c *gc.C) {
+	watcher := s.newWatcher(s.setRegion("east"), s.setRegion("east"), s.setRegion("west"))
+	context, err := s.runStatusWatch(c, watcher, "--diff")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(watcher.next, gc.Equals, 4)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, `
+Model  Controller  Cloud/Region  Version
+test   test        foo           
+
+- test   test        foo           
++ test   test        foo/east      
+- test   test        foo/east      
++ test   test        foo/west      
+`[1:])
+}
+
+func (s *MinimalStatusSuite) TestWatchCoalescesChanges(c *gc.C) {
+	watcher := s.newWatcher(s.setRegion("east"), s.setRegion("west"))
+	watcher.burst = true
+	// Only finish waiting to fetch the status again
+	// once both changes have been made.
+	delay := make(chan time.Time)
+	s.clock.result = delay
+	go func() {
+		<-watcher.applied
+		close(delay)
+	}()
+
+	context, err := s.runStatusWatch(c, watcher, "--watch")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, `
+Model  Controller  Cloud/Region  Version
+test   test        foo           
+
+Model  Controller  Cloud/Region  Version
+test   test        foo/west      
+
+`[1:])
+}
+
+func (s *MinimalStatusSuite) TestWatchContinuesAfterStatusError(c *gc.C) {
+	watcher := s.newWatcher(func() {
+		s.statusapi.errors = []error{errors.New("boom")}
+	}, s.setRegion("east"))
+	context, err := s.runStatusWatch(c, watcher, "--watch", "--retry-count", "0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(watcher.next, gc.Equals, 3)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, `
+Model  Controller  Cloud/Region  Version
+test   test        foo           
+
+Model  Controller  Cloud/Region  Version
+test   test        foo/east      
+
+`[1:])
+	c.Assert(c.GetTestLog(), jc.Contains, "cannot get status: boom")
+}
+
+func (s *MinimalStatusSuite) TestWatchInitialStatusError(c *gc.C) {
+	s.statusapi.errors = []error{errors.New("boom")}
+	watcher := s.newWatcher()
+	_, err := s.runStatusWatch(c, watcher, "--watch", "--retry-count", "0")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *MinimalStatusSuite) TestWatchError(c *gc.C) {
+	watcher := &fakeAllWatcher{err: errors.New("boom")}
+	_, err := s.runStatusWatch(c, watcher, "--watch")
+	c.Assert(err, gc.ErrorMatches, "watching model: boom")
+}
+
 type fakeStatusAPI struct {
 	result *params.FullStatus
 	errors []error
+
+	// fetched, if not nil, receives each time Status is called.
+	fetched chan struct{}
 }
 
 func (f *fakeStatusAPI) Status(patterns []string) (*params.FullStatus, error) {
+	if f.fetched != nil {
+		defer func() { f.fetched <- struct{}{} }()
+	}
 	if len(f.errors) > 0 {
 		err, rest := f.errors[0], f.errors[1:]
 		f.errors = rest
@@ -148,6 +269,49 @@ func (*fakeStatusAPI) Close() error {
 	return nil
 }
 
+type fakeAllWatcher struct {
+	changes []func()
+	err     error
+	next    int
+	stopped bool
+
+	// fetched, if not nil, receives each time the status is fetched.
+	// Each change waits until the status has been fetched since the
+	// last, unless burst is set, in which case only the first does.
+	// The watcher only reports it has stopped once the status has
+	// been fetched since the last change.
+	fetched <-chan struct{}
+	burst   bool
+
+	// applied, if not nil, is closed once all the changes are made.
+	applied chan struct{}
+}
+
+func (w *fakeAllWatcher) Next() ([]params.Delta, error) {
+	w.next++
+	if w.err != nil {
+		return nil, w.err
+	}
+	if w.fetched != nil && (w.next == 1 || !w.burst || len(w.changes) == 0) {
+		<-w.fetched
+	}
+	if len(w.changes) == 0 {
+		return nil, &params.Error{Code: params.CodeStopped, Message: "watcher was stopped"}
+	}
+	change := w.changes[0]
+	w.changes = w.changes[1:]
+	change()
+	if len(w.changes) == 0 && w.applied != nil {
+		close(w.applied)
+	}
+	return []params.Delta{{}}, nil
+}
+
+func (w *fakeAllWatcher) Stop() error {
+	w.stopped = true
+	return nil
+}
+
 type timeRecorder struct {
 	waits  []time.Duration
 	result chan time.Time
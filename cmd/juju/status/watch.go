@@ -0,0 +1,256 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/mattn/go-isatty"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// clearScreen moves the cursor to the top of the terminal and clears
+// it, so that each frame of watched status replaces the last.
+const clearScreen = "\x1b[H\x1b[2J"
+
+// allWatcher reports the changes made to the entities in a model.
+// It is implemented by *api.AllWatcher.
+type allWatcher interface {
+	Next() ([]params.Delta, error)
+	Stop() error
+}
+
+// watchAllAPI is implemented by status API clients which are able
+// to watch all the entities in the model.
+type watchAllAPI interface {
+	WatchAll() (*api.AllWatcher, error)
+}
+
+var newAllWatcherForStatus = func(c *statusCommand) (allWatcher, error) {
+	if c.watcher == nil {
+		apiclient, err := newAPIClientForStatus(c)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		watchAPI, ok := apiclient.(watchAllAPI)
+		if !ok {
+			return nil, errors.NotSupportedf("watching status")
+		}
+		w, err := watchAPI.WatchAll()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		c.watcher = w
+	}
+	return c.watcher, nil
+}
+
+// watchCoalesceDelay is how long to wait after the model changes
+// before fetching its status again, so that a burst of changes, such
+// as those made while deploying, is displayed once.
+const watchCoalesceDelay = time.Second
+
+// runWatch displays the status of the model, and then displays it
+// again each time the model changes, until interrupted or the
+// watcher is stopped.
+func (c *statusCommand) runWatch(ctx *cmd.Context) error {
+	w, err := newAllWatcherForStatus(c)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	interrupted := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+
+	// Stopping the watcher unblocks any pending call to Next.
+	aborted := make(chan struct{})
+	finished := make(chan struct{})
+	defer close(finished)
+	go func() {
+		select {
+		case <-interrupted:
+			close(aborted)
+			w.Stop()
+		case <-finished:
+		}
+	}()
+
+	// The deltas themselves aren't needed; the status is fetched
+	// again so that it is displayed consistently. They are read in
+	// the background so that changes made while the status is being
+	// fetched are coalesced into a single update.
+	changes := make(chan struct{}, 1)
+	watchErr := make(chan error, 1)
+	go func() {
+		for {
+			if _, err := w.Next(); err != nil {
+				watchErr <- err
+				return
+			}
+			select {
+			case changes <- struct{}{}:
+			default:
+			}
+		}
+	}()
+
+	c.warnIgnoredFlags(ctx)
+	clearFrames := !c.diff && isTerminal(ctx.Stdout)
+	var previous []string
+	for first := true; ; first = false {
+		frame, err := c.renderStatus(ctx)
+		switch {
+		case err != nil && first:
+			return errors.Trace(err)
+		case err != nil:
+			// The next change will try again.
+			logger.Warningf("cannot get status: %v", err)
+		case first || !c.diff:
+			if first || !linesEqual(previous, frame) {
+				if clearFrames {
+					fmt.Fprint(ctx.Stdout, clearScreen)
+				}
+				writeLines(ctx.Stdout, frame)
+			}
+			previous = frame
+		default:
+			writeLines(ctx.Stdout, diffLines(previous, frame))
+			previous = frame
+		}
+
+		select {
+		case <-changes:
+		case err := <-watchErr:
+			select {
+			case <-aborted:
+				return nil
+			default:
+			}
+			if params.IsCodeStopped(err) {
+				return nil
+			}
+			return errors.Annotate(err, "watching model")
+		}
+		select {
+		case <-c.clock.After(watchCoalesceDelay):
+		case <-aborted:
+			return nil
+		}
+		// The status fetched next includes any changes
+		// made while waiting.
+		select {
+		case <-changes:
+		default:
+		}
+	}
+}
+
+// renderStatus gets the current status and returns the lines written
+// for it by the selected output format.
+func (c *statusCommand) renderStatus(ctx *cmd.Context) ([]string, error) {
+	status, err := c.getStatusWithRetries(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	formatted, err := c.formatStatus(ctx, status)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	formatter, ok := c.formatters[c.out.Name()]
+	if !ok {
+		return nil, errors.NotValidf("format %q", c.out.Name())
+	}
+	var buf bytes.Buffer
+	if err := formatter(&buf, formatted); err != nil {
+		return nil, errors.Trace(err)
+	}
+	lines := strings.SplitAfter(buf.String(), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines, nil
+}
+
+// diffLines returns the lines which differ between the previous and
+// current frames, in the order they appear, prefixed with "- " if they
+// were removed or "+ " if they were added.
+func diffLines(previous, current []string) []string {
+	// lcs[i][j] holds the length of the longest common
+	// subsequence of previous[i:] and current[j:].
+	lcs := make([][]int, len(previous)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(current)+1)
+	}
+	for i := len(previous) - 1; i >= 0; i-- {
+		for j := len(current) - 1; j >= 0; j-- {
+			if previous[i] == current[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var diff []string
+	i, j := 0, 0
+	for i < len(previous) || j < len(current) {
+		switch {
+		case i < len(previous) && j < len(current) && previous[i] == current[j]:
+			i++
+			j++
+		case j == len(current) || (i < len(previous) && lcs[i+1][j] >= lcs[i][j+1]):
+			diff = append(diff, "- "+withNewline(previous[i]))
+			i++
+		default:
+			diff = append(diff, "+ "+withNewline(current[j]))
+			j++
+		}
+	}
+	return diff
+}
+
+func linesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func withNewline(line string) string {
+	if strings.HasSuffix(line, "\n") {
+		return line
+	}
+	return line + "\n"
+}
+
+func writeLines(w io.Writer, lines []string) {
+	for _, line := range lines {
+		fmt.Fprint(w, line)
+	}
+}
+
+func isTerminal(f interface{}) bool {
+	f_, ok := f.(*os.File)
+	if !ok {
+		return false
+	}
+	return isatty.IsTerminal(f_.Fd())
+}
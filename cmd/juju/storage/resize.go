@@ -0,0 +1,136 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewResizeStorageCommandWithAPI returns a command
+// used to expand the volumes backing storage.
+func NewResizeStorageCommandWithAPI() cmd.Command {
+	command := &resizeStorageCommand{}
+	command.newEntityResizerCloser = func() (EntityResizerCloser, error) {
+		return command.NewStorageAPI()
+	}
+	return modelcmd.Wrap(command)
+}
+
+// NewResizeStorageCommand returns a command used
+// to expand the volumes backing storage.
+func NewResizeStorageCommand(new NewEntityResizerCloserFunc) cmd.Command {
+	command := &resizeStorageCommand{}
+	command.newEntityResizerCloser = new
+	return modelcmd.Wrap(command)
+}
+
+const (
+	resizeStorageCommandDoc = `
+Expands the volumes backing storage to a new size. Specify one or more
+unit/application storage IDs, as output by "juju storage", followed by
+the new size. The size is a number with an optional M, G, T or P suffix;
+the default unit is MiB.
+
+Volumes can only be grown, and only if the storage provider supports
+expanding volumes, eg kubernetes storage classes which allow volume
+expansion. The volumes are expanded asynchronously; "juju storage"
+reports the new size once the expansion has completed.
+
+Examples:
+    juju resize-storage pgdata/0 20G
+    juju resize-storage pgdata/0 pgdata/1 100G
+`
+
+	resizeStorageCommandArgs = `<storage> [<storage> ...] <size>`
+)
+
+// resizeStorageCommand expands the volumes backing storage instances.
+type resizeStorageCommand struct {
+	StorageCommandBase
+	newEntityResizerCloser NewEntityResizerCloserFunc
+	storageIds             []string
+	size                   uint64
+}
+
+// Init implements Command.Init.
+func (c *resizeStorageCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.New("resize-storage requires at least one storage ID and a size")
+	}
+	last := len(args) - 1
+	size, err := utils.ParseSize(args[last])
+	if err != nil {
+		return errors.Annotatef(err, "cannot parse size %q", args[last])
+	}
+	if size == 0 {
+		return errors.Errorf("size must be greater than zero, got %q", args[last])
+	}
+	c.storageIds = args[:last]
+	c.size = size
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *resizeStorageCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "resize-storage",
+		Purpose: "Expands the volumes backing storage.",
+		Doc:     resizeStorageCommandDoc,
+		Args:    resizeStorageCommandArgs,
+		Aliases: []string{"storage-resize"},
+	})
+}
+
+// Run implements Command.Run.
+func (c *resizeStorageCommand) Run(ctx *cmd.Context) error {
+	resizer, err := c.newEntityResizerCloser()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resizer.Close()
+
+	results, err := resizer.Resize(c.storageIds, c.size)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "resize storage")
+		}
+		return err
+	}
+	anyFailed := false
+	for i, result := range results {
+		if result.Error != nil {
+			ctx.Infof("failed to resize %s: %s", c.storageIds[i], result.Error)
+			anyFailed = true
+			continue
+		}
+		ctx.Infof("resizing %s", c.storageIds[i])
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// NewEntityResizerCloserFunc is the type of a function that returns an
+// EntityResizerCloser.
+type NewEntityResizerCloserFunc func() (EntityResizerCloser, error)
+
+// EntityResizerCloser extends EntityResizer with a Closer method.
+type EntityResizerCloser interface {
+	EntityResizer
+	Close() error
+}
+
+// EntityResizer defines an interface for expanding the volumes
+// backing storage with the specified IDs to a size in MiB.
+type EntityResizer interface {
+	Resize([]string, uint64) ([]params.ErrorResult, error)
+}
@@ -0,0 +1,98 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type ResizeStorageSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ResizeStorageSuite{})
+
+func (s *ResizeStorageSuite) TestResize(c *gc.C) {
+	fake := fakeEntityResizer{results: []params.ErrorResult{
+		{},
+		{},
+	}}
+	command := storage.NewResizeStorageCommandForTest(fake.new, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, command, "foo/0", "bar/1", "20G")
+	c.Assert(err, jc.ErrorIsNil)
+	fake.CheckCallNames(c, "NewEntityResizerCloser", "Resize", "Close")
+	fake.CheckCall(c, 1, "Resize", []string{"foo/0", "bar/1"}, uint64(20*1024))
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+resizing foo/0
+resizing bar/1
+`[1:])
+}
+
+func (s *ResizeStorageSuite) TestResizeError(c *gc.C) {
+	fake := fakeEntityResizer{results: []params.ErrorResult{
+		{Error: &params.Error{Message: "foo"}},
+		{},
+	}}
+	command := storage.NewResizeStorageCommandForTest(fake.new, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, command, "baz/0", "qux/1", "1024")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+failed to resize baz/0: foo
+resizing qux/1
+`[1:])
+}
+
+func (s *ResizeStorageSuite) TestResizeUnauthorizedError(c *gc.C) {
+	var fake fakeEntityResizer
+	fake.SetErrors(nil, &params.Error{Code: params.CodeUnauthorized, Message: "nope"})
+	command := storage.NewResizeStorageCommandForTest(fake.new, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, command, "foo/0", "1G")
+	c.Assert(err, gc.ErrorMatches, "nope")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+You do not have permission to resize storage.
+You may ask an administrator to grant you access with "juju grant".
+
+`)
+}
+
+func (s *ResizeStorageSuite) TestResizeInitErrors(c *gc.C) {
+	s.testResizeInitError(c, []string{}, "resize-storage requires at least one storage ID and a size")
+	s.testResizeInitError(c, []string{"foo/0"}, "resize-storage requires at least one storage ID and a size")
+	s.testResizeInitError(c, []string{"foo/0", "big"}, `cannot parse size "big": .*`)
+	s.testResizeInitError(c, []string{"foo/0", "0"}, `size must be greater than zero, got "0"`)
+}
+
+func (s *ResizeStorageSuite) testResizeInitError(c *gc.C, args []string, expect string) {
+	command := storage.NewResizeStorageCommandForTest(nil, jujuclienttesting.MinimalStore())
+	_, err := cmdtesting.RunCommand(c, command, args...)
+	c.Assert(err, gc.ErrorMatches, expect)
+}
+
+type fakeEntityResizer struct {
+	testing.Stub
+	results []params.ErrorResult
+}
+
+func (f *fakeEntityResizer) new() (storage.EntityResizerCloser, error) {
+	f.MethodCall(f, "NewEntityResizerCloser")
+	return f, f.NextErr()
+}
+
+func (f *fakeEntityResizer) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeEntityResizer) Resize(ids []string, size uint64) ([]params.ErrorResult, error) {
+	f.MethodCall(f, "Resize", ids, size)
+	return f.results, f.NextErr()
+}
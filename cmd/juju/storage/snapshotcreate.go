@@ -0,0 +1,95 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// SnapshotCreateAPI defines the API methods that the create storage
+// snapshot command uses.
+type SnapshotCreateAPI interface {
+	Close() error
+	CreateSnapshot(storageId, snapshotName string) error
+}
+
+const snapshotCreateCommandDoc = `
+Takes a snapshot of the volume backing storage. Specify the storage ID,
+as output by "juju storage", and a name for the snapshot, which must be
+unique within the model.
+
+Snapshots can only be taken if the storage provider supports them, eg
+kubernetes storage pools configured with a "volume-snapshot-class". The
+snapshot is taken asynchronously; "juju storage-snapshots" reports when
+it is ready to be restored.
+
+Examples:
+    juju create-storage-snapshot pgdata/0 pgdata-nightly
+
+See also:
+    storage-snapshots
+    restore-storage-snapshot
+    remove-storage-snapshot
+`
+
+// NewSnapshotCreateCommand returns a command that takes a snapshot of
+// the volume backing storage.
+func NewSnapshotCreateCommand() cmd.Command {
+	cmd := &snapshotCreateCommand{}
+	cmd.newAPIFunc = func() (SnapshotCreateAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// snapshotCreateCommand takes a snapshot of the volume backing storage.
+type snapshotCreateCommand struct {
+	StorageCommandBase
+	newAPIFunc   func() (SnapshotCreateAPI, error)
+	storageId    string
+	snapshotName string
+}
+
+// Init implements Command.Init.
+func (c *snapshotCreateCommand) Init(args []string) error {
+	if len(args) != 2 {
+		return errors.New("create-storage-snapshot requires a storage ID and a snapshot name")
+	}
+	c.storageId, c.snapshotName = args[0], args[1]
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *snapshotCreateCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "create-storage-snapshot",
+		Args:    "<storage> <name>",
+		Purpose: "Takes a snapshot of the volume backing storage.",
+		Doc:     snapshotCreateCommandDoc,
+	})
+}
+
+// Run implements Command.Run.
+func (c *snapshotCreateCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if err := api.CreateSnapshot(c.storageId, c.snapshotName); err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "snapshot storage")
+		}
+		return err
+	}
+	ctx.Infof("taking snapshot %q of %s", c.snapshotName, c.storageId)
+	return nil
+}
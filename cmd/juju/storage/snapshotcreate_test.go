@@ -0,0 +1,71 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+)
+
+type SnapshotCreateSuite struct {
+	SubStorageSuite
+	mockAPI *mockSnapshotCreateAPI
+}
+
+var _ = gc.Suite(&SnapshotCreateSuite{})
+
+func (s *SnapshotCreateSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockSnapshotCreateAPI{}
+}
+
+func (s *SnapshotCreateSuite) TestCreate(c *gc.C) {
+	command := storage.NewSnapshotCreateCommandForTest(s.mockAPI, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command, "pgdata/0", "nightly")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"CreateSnapshot", []interface{}{"pgdata/0", "nightly"}},
+		{"Close", nil},
+	})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "taking snapshot \"nightly\" of pgdata/0\n")
+}
+
+func (s *SnapshotCreateSuite) TestCreateError(c *gc.C) {
+	s.mockAPI.SetErrors(&params.Error{Code: params.CodeUnauthorized, Message: "nope"})
+	command := storage.NewSnapshotCreateCommandForTest(s.mockAPI, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command, "pgdata/0", "nightly")
+	c.Assert(err, gc.ErrorMatches, "nope")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+You do not have permission to snapshot storage.
+You may ask an administrator to grant you access with "juju grant".
+
+`)
+}
+
+func (s *SnapshotCreateSuite) TestCreateInitErrors(c *gc.C) {
+	for _, args := range [][]string{{}, {"pgdata/0"}, {"pgdata/0", "nightly", "weekly"}} {
+		command := storage.NewSnapshotCreateCommandForTest(s.mockAPI, s.store)
+		_, err := cmdtesting.RunCommand(c, command, args...)
+		c.Check(err, gc.ErrorMatches, "create-storage-snapshot requires a storage ID and a snapshot name")
+	}
+}
+
+type mockSnapshotCreateAPI struct {
+	testing.Stub
+}
+
+func (m *mockSnapshotCreateAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockSnapshotCreateAPI) CreateSnapshot(storageId, snapshotName string) error {
+	m.MethodCall(m, "CreateSnapshot", storageId, snapshotName)
+	return m.NextErr()
+}
@@ -0,0 +1,168 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// SnapshotListAPI defines the API methods that the storage snapshots
+// command uses.
+type SnapshotListAPI interface {
+	Close() error
+	ListSnapshots() ([]params.StorageSnapshotDetails, error)
+}
+
+// SnapshotInfo defines the serialization behaviour of the storage
+// snapshot information.
+type SnapshotInfo struct {
+	Volume      string `yaml:"volume" json:"volume"`
+	Pool        string `yaml:"pool" json:"pool"`
+	Life        string `yaml:"life" json:"life"`
+	SnapshotId  string `yaml:"snapshot-id,omitempty" json:"snapshot-id,omitempty"`
+	Size        uint64 `yaml:"size,omitempty" json:"size,omitempty"`
+	Ready       bool   `yaml:"ready" json:"ready"`
+	RestoringTo string `yaml:"restoring-to,omitempty" json:"restoring-to,omitempty"`
+}
+
+func formatSnapshotInfo(all []params.StorageSnapshotDetails) (map[string]SnapshotInfo, error) {
+	output := make(map[string]SnapshotInfo)
+	for _, one := range all {
+		volumeTag, err := names.ParseVolumeTag(one.VolumeTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		info := SnapshotInfo{
+			Volume:     volumeTag.Id(),
+			Pool:       one.Pool,
+			Life:       string(one.Life),
+			SnapshotId: one.SnapshotId,
+			Size:       one.Size,
+			Ready:      one.Ready,
+		}
+		if one.RestoringTo != "" {
+			storageTag, err := names.ParseStorageTag(one.RestoringTo)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			info.RestoringTo = storageTag.Id()
+		}
+		output[one.Name] = info
+	}
+	return output, nil
+}
+
+const snapshotListCommandDoc = `
+Lists the storage snapshots in the model, with the volume each was taken
+of and whether it is ready to be restored.
+
+See also:
+    create-storage-snapshot
+    restore-storage-snapshot
+    remove-storage-snapshot
+`
+
+// NewSnapshotListCommand returns a command that lists storage snapshots.
+func NewSnapshotListCommand() cmd.Command {
+	cmd := &snapshotListCommand{}
+	cmd.newAPIFunc = func() (SnapshotListAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// snapshotListCommand lists storage snapshots.
+type snapshotListCommand struct {
+	StorageCommandBase
+	newAPIFunc func() (SnapshotListAPI, error)
+	out        cmd.Output
+}
+
+// Info implements Command.Info.
+func (c *snapshotListCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "storage-snapshots",
+		Purpose: "Lists storage snapshots.",
+		Doc:     snapshotListCommandDoc,
+		Aliases: []string{"list-storage-snapshots"},
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *snapshotListCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSnapshotListTabular,
+	})
+}
+
+// Run implements Command.Run.
+func (c *snapshotListCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+	result, err := api.ListSnapshots()
+	if err != nil {
+		return err
+	}
+	if len(result) == 0 {
+		ctx.Infof("No storage snapshots to display.")
+		return nil
+	}
+	output, err := formatSnapshotInfo(result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, output)
+}
+
+// formatSnapshotListTabular returns a tabular summary of storage
+// snapshots or errors out if parameter is not a map of SnapshotInfo.
+func formatSnapshotListTabular(writer io.Writer, value interface{}) error {
+	snapshots, ok := value.(map[string]SnapshotInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", snapshots, value)
+	}
+	tw := output.TabWriter(writer)
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+
+	print("Name", "Volume", "Pool", "Size", "Status", "Restoring to")
+
+	snapshotNames := make([]string, 0, len(snapshots))
+	for name := range snapshots {
+		snapshotNames = append(snapshotNames, name)
+	}
+	sort.Strings(snapshotNames)
+	for _, name := range snapshotNames {
+		snapshot := snapshots[name]
+		status := snapshot.Life
+		if status == "alive" {
+			status = "pending"
+			if snapshot.Ready {
+				status = "ready"
+			}
+		}
+		print(name, snapshot.Volume, snapshot.Pool, humanizeStorageSize(snapshot.Size), status, snapshot.RestoringTo)
+	}
+	return tw.Flush()
+}
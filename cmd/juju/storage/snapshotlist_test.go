@@ -0,0 +1,94 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+)
+
+type SnapshotListSuite struct {
+	SubStorageSuite
+	mockAPI *mockSnapshotListAPI
+}
+
+var _ = gc.Suite(&SnapshotListSuite{})
+
+func (s *SnapshotListSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockSnapshotListAPI{snapshots: []params.StorageSnapshotDetails{{
+		Name:      "weekly",
+		VolumeTag: "volume-1",
+		Pool:      "kubernetes",
+		Life:      "alive",
+	}, {
+		Name:        "nightly",
+		VolumeTag:   "volume-0",
+		Pool:        "kubernetes",
+		Life:        "alive",
+		SnapshotId:  "nightly",
+		Size:        1024,
+		Ready:       true,
+		RestoringTo: "storage-pgdata-2",
+	}, {
+		Name:       "old",
+		VolumeTag:  "volume-0",
+		Pool:       "kubernetes",
+		Life:       "dying",
+		SnapshotId: "old",
+		Size:       512,
+		Ready:      true,
+	}}}
+}
+
+func (s *SnapshotListSuite) TestListTabular(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, storage.NewSnapshotListCommandForTest(s.mockAPI, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Name     Volume  Pool        Size     Status   Restoring to
+nightly  0       kubernetes  1.0 GiB  ready    pgdata/2
+old      0       kubernetes  512 MiB  dying    
+weekly   1       kubernetes           pending  
+`[1:])
+}
+
+func (s *SnapshotListSuite) TestListYAML(c *gc.C) {
+	s.mockAPI.snapshots = s.mockAPI.snapshots[1:2]
+	ctx, err := cmdtesting.RunCommand(c, storage.NewSnapshotListCommandForTest(s.mockAPI, s.store), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+nightly:
+  volume: "0"
+  pool: kubernetes
+  life: alive
+  snapshot-id: nightly
+  size: 1024
+  ready: true
+  restoring-to: pgdata/2
+`[1:])
+}
+
+func (s *SnapshotListSuite) TestListEmpty(c *gc.C) {
+	s.mockAPI.snapshots = nil
+	ctx, err := cmdtesting.RunCommand(c, storage.NewSnapshotListCommandForTest(s.mockAPI, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No storage snapshots to display.\n")
+}
+
+type mockSnapshotListAPI struct {
+	snapshots []params.StorageSnapshotDetails
+}
+
+func (m *mockSnapshotListAPI) Close() error {
+	return nil
+}
+
+func (m *mockSnapshotListAPI) ListSnapshots() ([]params.StorageSnapshotDetails, error) {
+	return m.snapshots, nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// SnapshotRemoveAPI defines the API methods that the remove storage
+// snapshot command uses.
+type SnapshotRemoveAPI interface {
+	Close() error
+	RemoveSnapshots(snapshotNames []string) ([]params.ErrorResult, error)
+}
+
+const snapshotRemoveCommandDoc = `
+Removes storage snapshots. The snapshots are destroyed in the storage
+provider, and then removed from the model.
+
+Examples:
+    juju remove-storage-snapshot pgdata-nightly
+    juju remove-storage-snapshot pgdata-nightly pgdata-weekly
+
+See also:
+    create-storage-snapshot
+    storage-snapshots
+`
+
+// NewSnapshotRemoveCommand returns a command that removes storage
+// snapshots.
+func NewSnapshotRemoveCommand() cmd.Command {
+	cmd := &snapshotRemoveCommand{}
+	cmd.newAPIFunc = func() (SnapshotRemoveAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// snapshotRemoveCommand removes storage snapshots.
+type snapshotRemoveCommand struct {
+	StorageCommandBase
+	newAPIFunc    func() (SnapshotRemoveAPI, error)
+	snapshotNames []string
+}
+
+// Init implements Command.Init.
+func (c *snapshotRemoveCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("remove-storage-snapshot requires at least one snapshot name")
+	}
+	c.snapshotNames = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *snapshotRemoveCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "remove-storage-snapshot",
+		Args:    "<name> [<name> ...]",
+		Purpose: "Removes storage snapshots.",
+		Doc:     snapshotRemoveCommandDoc,
+	})
+}
+
+// Run implements Command.Run.
+func (c *snapshotRemoveCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	results, err := api.RemoveSnapshots(c.snapshotNames)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "remove storage snapshots")
+		}
+		return err
+	}
+	anyFailed := false
+	for i, result := range results {
+		if result.Error != nil {
+			ctx.Infof("failed to remove snapshot %q: %s", c.snapshotNames[i], result.Error)
+			anyFailed = true
+			continue
+		}
+		ctx.Infof("removing snapshot %q", c.snapshotNames[i])
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
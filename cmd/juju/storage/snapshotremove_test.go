@@ -0,0 +1,76 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+)
+
+type SnapshotRemoveSuite struct {
+	SubStorageSuite
+	mockAPI *mockSnapshotRemoveAPI
+}
+
+var _ = gc.Suite(&SnapshotRemoveSuite{})
+
+func (s *SnapshotRemoveSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockSnapshotRemoveAPI{results: []params.ErrorResult{
+		{},
+		{},
+	}}
+}
+
+func (s *SnapshotRemoveSuite) TestRemove(c *gc.C) {
+	command := storage.NewSnapshotRemoveCommandForTest(s.mockAPI, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command, "nightly", "weekly")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"RemoveSnapshots", []interface{}{[]string{"nightly", "weekly"}}},
+		{"Close", nil},
+	})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+removing snapshot "nightly"
+removing snapshot "weekly"
+`[1:])
+}
+
+func (s *SnapshotRemoveSuite) TestRemoveError(c *gc.C) {
+	s.mockAPI.results[0].Error = &params.Error{Message: "foo"}
+	command := storage.NewSnapshotRemoveCommandForTest(s.mockAPI, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command, "nightly", "weekly")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+failed to remove snapshot "nightly": foo
+removing snapshot "weekly"
+`[1:])
+}
+
+func (s *SnapshotRemoveSuite) TestRemoveInitError(c *gc.C) {
+	command := storage.NewSnapshotRemoveCommandForTest(s.mockAPI, s.store)
+	_, err := cmdtesting.RunCommand(c, command)
+	c.Assert(err, gc.ErrorMatches, "remove-storage-snapshot requires at least one snapshot name")
+}
+
+type mockSnapshotRemoveAPI struct {
+	testing.Stub
+	results []params.ErrorResult
+}
+
+func (m *mockSnapshotRemoveAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockSnapshotRemoveAPI) RemoveSnapshots(snapshotNames []string) ([]params.ErrorResult, error) {
+	m.MethodCall(m, "RemoveSnapshots", snapshotNames)
+	return m.results, m.NextErr()
+}
@@ -0,0 +1,95 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// SnapshotRestoreAPI defines the API methods that the restore storage
+// snapshot command uses.
+type SnapshotRestoreAPI interface {
+	Close() error
+	RestoreSnapshot(snapshotName, storageName string) (names.StorageTag, error)
+}
+
+const snapshotRestoreCommandDoc = `
+Restores a storage snapshot to new storage. Specify the name of the
+snapshot, as output by "juju storage-snapshots", and the storage name
+declared by the charm that the new storage is for, eg "pgdata".
+
+The new storage is detached; once the volume has been restored, attach
+it to a unit with "juju attach-storage".
+
+Examples:
+    juju restore-storage-snapshot pgdata-nightly pgdata
+
+See also:
+    attach-storage
+    create-storage-snapshot
+    storage-snapshots
+`
+
+// NewSnapshotRestoreCommand returns a command that restores a storage
+// snapshot to new storage.
+func NewSnapshotRestoreCommand() cmd.Command {
+	cmd := &snapshotRestoreCommand{}
+	cmd.newAPIFunc = func() (SnapshotRestoreAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// snapshotRestoreCommand restores a storage snapshot to new storage.
+type snapshotRestoreCommand struct {
+	StorageCommandBase
+	newAPIFunc   func() (SnapshotRestoreAPI, error)
+	snapshotName string
+	storageName  string
+}
+
+// Init implements Command.Init.
+func (c *snapshotRestoreCommand) Init(args []string) error {
+	if len(args) != 2 {
+		return errors.New("restore-storage-snapshot requires a snapshot name and a storage name")
+	}
+	c.snapshotName, c.storageName = args[0], args[1]
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *snapshotRestoreCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "restore-storage-snapshot",
+		Args:    "<name> <storage-name>",
+		Purpose: "Restores a storage snapshot to new storage.",
+		Doc:     snapshotRestoreCommandDoc,
+	})
+}
+
+// Run implements Command.Run.
+func (c *snapshotRestoreCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	storageTag, err := api.RestoreSnapshot(c.snapshotName, c.storageName)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "restore storage snapshots")
+		}
+		return err
+	}
+	ctx.Infof("restoring snapshot %q to %s", c.snapshotName, storageTag.Id())
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+)
+
+type SnapshotRestoreSuite struct {
+	SubStorageSuite
+	mockAPI *mockSnapshotRestoreAPI
+}
+
+var _ = gc.Suite(&SnapshotRestoreSuite{})
+
+func (s *SnapshotRestoreSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockSnapshotRestoreAPI{}
+}
+
+func (s *SnapshotRestoreSuite) TestRestore(c *gc.C) {
+	command := storage.NewSnapshotRestoreCommandForTest(s.mockAPI, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command, "nightly", "pgdata")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"RestoreSnapshot", []interface{}{"nightly", "pgdata"}},
+		{"Close", nil},
+	})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "restoring snapshot \"nightly\" to pgdata/1\n")
+}
+
+func (s *SnapshotRestoreSuite) TestRestoreError(c *gc.C) {
+	s.mockAPI.SetErrors(&params.Error{Code: params.CodeUnauthorized, Message: "nope"})
+	command := storage.NewSnapshotRestoreCommandForTest(s.mockAPI, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command, "nightly", "pgdata")
+	c.Assert(err, gc.ErrorMatches, "nope")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+You do not have permission to restore storage snapshots.
+You may ask an administrator to grant you access with "juju grant".
+
+`)
+}
+
+func (s *SnapshotRestoreSuite) TestRestoreInitErrors(c *gc.C) {
+	for _, args := range [][]string{{}, {"nightly"}, {"nightly", "pgdata", "extra"}} {
+		command := storage.NewSnapshotRestoreCommandForTest(s.mockAPI, s.store)
+		_, err := cmdtesting.RunCommand(c, command, args...)
+		c.Check(err, gc.ErrorMatches, "restore-storage-snapshot requires a snapshot name and a storage name")
+	}
+}
+
+type mockSnapshotRestoreAPI struct {
+	testing.Stub
+}
+
+func (m *mockSnapshotRestoreAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockSnapshotRestoreAPI) RestoreSnapshot(snapshotName, storageName string) (names.StorageTag, error) {
+	m.MethodCall(m, "RestoreSnapshot", snapshotName, storageName)
+	if err := m.NextErr(); err != nil {
+		return names.StorageTag{}, err
+	}
+	return names.NewStorageTag(storageName + "/1"), nil
+}
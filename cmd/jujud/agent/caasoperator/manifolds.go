@@ -283,6 +283,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewExecClient:                  config.NewExecClient,
 			NewContainerStartWatcherClient: config.NewContainerStartWatcherClient,
 			RunListenerSocket:              config.RunListenerSocket,
+			PrometheusRegisterer:           config.PrometheusRegisterer,
 		})),
 
 		unitInitWorkerName: ifNotMigrating(caasunitinit.Manifold(caasunitinit.ManifoldConfig{
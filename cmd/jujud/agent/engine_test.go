@@ -32,8 +32,10 @@ var (
 		"valid-credential-flag",
 	}
 	requireValidCredentialModelWorkers = []string{
+		"action-expirer",         // tertiary dependency: will be inactive because migration workers will be inactive
 		"action-pruner",          // tertiary dependency: will be inactive because migration workers will be inactive
 		"application-scaler",     // tertiary dependency: will be inactive because migration workers will be inactive
+		"branch-rollout",         // tertiary dependency: will be inactive because migration workers will be inactive
 		"charm-revision-updater", // tertiary dependency: will be inactive because migration workers will be inactive
 		"compute-provisioner",
 		"environ-tracker",
@@ -55,8 +57,10 @@ var (
 		"unit-assigner", // tertiary dependency: will be inactive because migration workers will be inactive
 	}
 	aliveModelWorkers = []string{
+		"action-expirer",
 		"action-pruner",
 		"application-scaler",
+		"branch-rollout",
 		"charm-revision-updater",
 		"compute-provisioner",
 		"environ-tracker",
@@ -44,6 +44,7 @@ import (
 	"github.com/juju/juju/worker/apiservercertwatcher"
 	"github.com/juju/juju/worker/auditconfigupdater"
 	"github.com/juju/juju/worker/authenticationworker"
+	"github.com/juju/juju/worker/backupscheduler"
 	"github.com/juju/juju/worker/caasupgrader"
 	"github.com/juju/juju/worker/centralhub"
 	"github.com/juju/juju/worker/certupdater"
@@ -906,6 +907,19 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName: apiCallerName,
 		})),
 
+		// The backup scheduler creates controller backups according
+		// to the backup-schedule controller config. Only the primary
+		// controller runs it, so each backup is only taken once.
+		backupSchedulerName: ifNotMigrating(ifPrimaryController(backupscheduler.Manifold(backupscheduler.ManifoldConfig{
+			AgentName:  agentName,
+			ClockName:  clockName,
+			StateName:  stateName,
+			Logger:     loggo.GetLogger("juju.worker.backupscheduler"),
+			NewBackend: backupscheduler.NewBackend,
+			NewTarget:  backupscheduler.NewTarget,
+			NewWorker:  backupscheduler.NewWorker,
+		}))),
+
 		machineActionName: ifNotMigrating(machineactions.Manifold(machineactions.ManifoldConfig{
 			AgentName:     agentName,
 			APICallerName: apiCallerName,
@@ -1159,6 +1173,7 @@ const (
 	restoreWatcherName            = "restore-watcher"
 	certificateUpdaterName        = "certificate-updater"
 	auditConfigUpdaterName        = "audit-config-updater"
+	backupSchedulerName           = "backup-scheduler"
 	leaseManagerName              = "lease-manager"
 	legacyLeasesFlagName          = "legacy-leases-flag"
 
@@ -22,11 +22,13 @@ import (
 	"github.com/juju/juju/cmd/jujud/agent/engine"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/worker/actionexpirer"
 	"github.com/juju/juju/worker/actionpruner"
 	"github.com/juju/juju/worker/agent"
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
 	"github.com/juju/juju/worker/applicationscaler"
+	"github.com/juju/juju/worker/branchrollout"
 	"github.com/juju/juju/worker/caasbroker"
 	"github.com/juju/juju/worker/caasenvironupgrader"
 	"github.com/juju/juju/worker/caasfirewaller"
@@ -45,7 +47,7 @@ import (
 	"github.com/juju/juju/worker/instancepoller"
 	"github.com/juju/juju/worker/lifeflag"
 	"github.com/juju/juju/worker/logforwarder"
-	"github.com/juju/juju/worker/logforwarder/sinks"
+	_ "github.com/juju/juju/worker/logforwarder/sinks" // Register the log sinks.
 	"github.com/juju/juju/worker/logger"
 	"github.com/juju/juju/worker/machineundertaker"
 	"github.com/juju/juju/worker/metricworker"
@@ -97,6 +99,10 @@ type ManifoldsConfig struct {
 	// revision worker will check for new revisions of known charms.
 	CharmRevisionUpdateInterval time.Duration
 
+	// BranchRolloutInterval determines how often the branch-rollout
+	// worker advances the rollouts of branches in progress.
+	BranchRolloutInterval time.Duration
+
 	// StatusHistoryPruner* values control status-history pruning
 	// behaviour.
 	StatusHistoryPrunerInterval time.Duration
@@ -105,6 +111,10 @@ type ManifoldsConfig struct {
 	// worker is run.
 	ActionPrunerInterval time.Duration
 
+	// ActionExpirerInterval determines how often the action-expirer
+	// worker checks for actions that have exceeded their timeouts.
+	ActionExpirerInterval time.Duration
+
 	// NewEnvironFunc is a function opens a provider "environment"
 	// (typically environs.New).
 	NewEnvironFunc environs.NewEnvironFunc
@@ -290,13 +300,26 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			PruneInterval: config.ActionPrunerInterval,
 			Logger:        config.LoggingContext.GetLogger("juju.worker.pruner.action"),
 		})),
+		actionExpirerName: ifNotMigrating(actionexpirer.Manifold(actionexpirer.ManifoldConfig{
+			APICallerName: apiCallerName,
+			Clock:         config.Clock,
+			Period:        config.ActionExpirerInterval,
+			Logger:        config.LoggingContext.GetLogger("juju.worker.actionexpirer"),
+			NewFacade:     actionexpirer.NewFacade,
+			NewWorker:     actionexpirer.NewWorker,
+		})),
+		branchRolloutName: ifNotMigrating(branchrollout.Manifold(branchrollout.ManifoldConfig{
+			APICallerName: apiCallerName,
+			Clock:         config.Clock,
+			Period:        config.BranchRolloutInterval,
+			Logger:        config.LoggingContext.GetLogger("juju.worker.branchrollout"),
+			NewFacade:     branchrollout.NewFacade,
+			NewWorker:     branchrollout.NewWorker,
+		})),
 		logForwarderName: ifNotDead(logforwarder.Manifold(logforwarder.ManifoldConfig{
 			APICallerName: apiCallerName,
-			Sinks: []logforwarder.LogSinkSpec{{
-				Name:   "juju-log-forward",
-				OpenFn: sinks.OpenSyslog,
-			}},
-			Logger: config.LoggingContext.GetLogger("juju.worker.logforwarder"),
+			Sinks:         logforwarder.RegisteredSinks(),
+			Logger:        config.LoggingContext.GetLogger("juju.worker.logforwarder"),
 		})),
 		// The environ upgrader runs on all controller agents, and
 		// unlocks the gate when the environ is up-to-date. The
@@ -630,12 +653,14 @@ const (
 	firewallerName           = "firewaller"
 	unitAssignerName         = "unit-assigner"
 	applicationScalerName    = "application-scaler"
+	branchRolloutName        = "branch-rollout"
 	instancePollerName       = "instance-poller"
 	charmRevisionUpdaterName = "charm-revision-updater"
 	metricWorkerName         = "metric-worker"
 	stateCleanerName         = "state-cleaner"
 	statusHistoryPrunerName  = "status-history-pruner"
 	actionPrunerName         = "action-pruner"
+	actionExpirerName        = "action-expirer"
 	machineUndertakerName    = "machine-undertaker"
 	remoteRelationsName      = "remote-relations"
 	logForwarderName         = "log-forwarder"
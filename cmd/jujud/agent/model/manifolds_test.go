@@ -34,11 +34,13 @@ func (s *ManifoldsSuite) TestIAASNames(c *gc.C) {
 	// NOTE: if this test failed, the cmd/jujud/agent tests will
 	// also fail. Search for 'ModelWorkers' to find affected vars.
 	c.Check(actual.SortedValues(), jc.DeepEquals, []string{
+		"action-expirer",
 		"action-pruner",
 		"agent",
 		"api-caller",
 		"api-config-watcher",
 		"application-scaler",
+		"branch-rollout",
 		"charm-revision-updater",
 		"clock",
 		"compute-provisioner",
@@ -81,10 +83,12 @@ func (s *ManifoldsSuite) TestCAASNames(c *gc.C) {
 	// NOTE: if this test failed, the cmd/jujud/agent tests will
 	// also fail. Search for 'ModelWorkers' to find affected vars.
 	c.Check(actual.SortedValues(), jc.DeepEquals, []string{
+		"action-expirer",
 		"action-pruner",
 		"agent",
 		"api-caller",
 		"api-config-watcher",
+		"branch-rollout",
 		"caas-broker-tracker",
 		"caas-firewaller",
 		"caas-operator-provisioner",
@@ -199,6 +203,16 @@ func (s *ManifoldsSuite) TestCAASManifold(c *gc.C) {
 }
 
 var expectedCAASModelManifoldsWithDependencies = map[string][]string{
+	"action-expirer": {
+		"agent",
+		"api-caller",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag"},
+
 	"action-pruner": {
 		"agent",
 		"api-caller",
@@ -215,6 +229,16 @@ var expectedCAASModelManifoldsWithDependencies = map[string][]string{
 
 	"api-config-watcher": {"agent"},
 
+	"branch-rollout": {
+		"agent",
+		"api-caller",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag"},
+
 	"caas-broker-tracker": {"agent", "api-caller", "is-responsible-flag"},
 
 	"caas-firewaller": {
@@ -374,6 +398,17 @@ var expectedCAASModelManifoldsWithDependencies = map[string][]string{
 
 var expectedIAASModelManifoldsWithDependencies = map[string][]string{
 
+	"action-expirer": {
+		"agent",
+		"api-caller",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag",
+	},
+
 	"action-pruner": {
 		"agent",
 		"api-caller",
@@ -401,6 +436,16 @@ var expectedIAASModelManifoldsWithDependencies = map[string][]string{
 		"model-upgraded-flag",
 		"not-dead-flag"},
 
+	"branch-rollout": {
+		"agent",
+		"api-caller",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag"},
+
 	"charm-revision-updater": {
 		"agent",
 		"api-caller",
@@ -21,6 +21,7 @@ import (
 	"gopkg.in/macaroon-bakery.v2/bakery"
 
 	"github.com/juju/juju/cert"
+	"github.com/juju/juju/core/cron"
 	"github.com/juju/juju/core/resources"
 )
 
@@ -73,6 +74,10 @@ const (
 	// (compressed).
 	AuditLogMaxBackups = "audit-log-max-backups"
 
+	// AuditLogDBSize is the size of the capped collection in which
+	// the controller keeps audit log records for querying, eg "100M".
+	AuditLogDBSize = "audit-log-db-size"
+
 	// AuditLogExcludeMethods is a list of Facade.Method names that
 	// aren't interesting for audit logging purposes. A conversation
 	// with only calls to these will be excluded from the
@@ -88,6 +93,30 @@ const (
 	// new versions of Juju will be honoured.
 	ReadOnlyMethodsWildcard = "ReadOnlyMethods"
 
+	// BackupSchedule is a cron-like expression describing when the
+	// controller should create backups of itself, eg "0 2 * * *". No
+	// scheduled backups are created when it is empty.
+	BackupSchedule = "backup-schedule"
+
+	// BackupRetainDaily is the number of days for which the most
+	// recent scheduled backup of each day is kept.
+	BackupRetainDaily = "backup-retain-daily"
+
+	// BackupRetainWeekly is the number of weeks for which the most
+	// recent scheduled backup of each week is kept.
+	BackupRetainWeekly = "backup-retain-weekly"
+
+	// BackupTarget is the URL of a location to which scheduled backups
+	// are uploaded, eg "file:///var/backups/juju" or
+	// "s3://host/bucket/prefix?region=us-east-1".
+	BackupTarget = "backup-target"
+
+	// BackupTargetAccessKey and BackupTargetSecretKey are the
+	// credentials used to upload scheduled backups to an S3 backup
+	// target. They are never returned by the API.
+	BackupTargetAccessKey = "backup-target-access-key"
+	BackupTargetSecretKey = "backup-target-secret-key"
+
 	// StatePort is the port used for mongo connections.
 	StatePort = "state-port"
 
@@ -203,6 +232,18 @@ const (
 	// keep.
 	DefaultAuditLogMaxBackups = 10
 
+	// DefaultAuditLogDBSizeMB is the default size in MB of the capped
+	// collection holding queryable audit log records.
+	DefaultAuditLogDBSizeMB = 100
+
+	// DefaultBackupRetainDaily is the default number of daily
+	// scheduled backups to keep.
+	DefaultBackupRetainDaily = 7
+
+	// DefaultBackupRetainWeekly is the default number of weekly
+	// scheduled backups to keep.
+	DefaultBackupRetainWeekly = 4
+
 	// DefaultNUMAControlPolicy should not be used by default.
 	// Only use numactl if user specifically requests it
 	DefaultNUMAControlPolicy = false
@@ -313,7 +354,14 @@ var (
 		AuditLogCaptureArgs,
 		AuditLogMaxSize,
 		AuditLogMaxBackups,
+		AuditLogDBSize,
 		AuditLogExcludeMethods,
+		BackupSchedule,
+		BackupRetainDaily,
+		BackupRetainWeekly,
+		BackupTarget,
+		BackupTargetAccessKey,
+		BackupTargetSecretKey,
 		CAASOperatorImagePath,
 		CAASImageRepo,
 		Features,
@@ -330,6 +378,12 @@ var (
 		AuditingEnabled,
 		AuditLogCaptureArgs,
 		AuditLogExcludeMethods,
+		BackupSchedule,
+		BackupRetainDaily,
+		BackupRetainWeekly,
+		BackupTarget,
+		BackupTargetAccessKey,
+		BackupTargetSecretKey,
 		// TODO Juju 3.0: ControllerAPIPort should be required and treated
 		// more like api-port.
 		ControllerAPIPort,
@@ -349,6 +403,13 @@ var (
 		Features,
 	)
 
+	// SecretAttributes contains the controller config attributes
+	// whose values are never returned by the API.
+	SecretAttributes = set.NewStrings(
+		BackupTargetAccessKey,
+		BackupTargetSecretKey,
+	)
+
 	// DefaultAuditLogExcludeMethods is the default list of methods to
 	// exclude from the audit log.
 	DefaultAuditLogExcludeMethods = []string{
@@ -419,6 +480,18 @@ func (c Config) intOrDefault(name string, defaultVal int) int {
 	return defaultVal
 }
 
+// intOrZero is like intOrDefault, but allows the attribute to be
+// explicitly set to zero.
+func (c Config) intOrZero(name string, defaultVal int) int {
+	switch v := c[name].(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	}
+	return defaultVal
+}
+
 func (c Config) sizeMBOrDefault(name string, defaultVal int) int {
 	size := c.asString(name)
 	if size != "" {
@@ -547,6 +620,12 @@ func (c Config) AuditLogMaxBackups() int {
 	return c.intOrDefault(AuditLogMaxBackups, DefaultAuditLogMaxBackups)
 }
 
+// AuditLogDBSizeMB returns the size in MB of the capped collection
+// holding queryable audit log records.
+func (c Config) AuditLogDBSizeMB() int {
+	return c.sizeMBOrDefault(AuditLogDBSize, DefaultAuditLogDBSizeMB)
+}
+
 // AuditLogExcludeMethods returns the set of method names that are
 // considered uninteresting for audit logging. Conversations
 // containing only these will be excluded from the audit log.
@@ -562,6 +641,42 @@ func (c Config) AuditLogExcludeMethods() set.Strings {
 	return set.NewStrings(DefaultAuditLogExcludeMethods...)
 }
 
+// BackupSchedule returns the cron-like expression describing when
+// scheduled backups are created, or "" if they are disabled.
+func (c Config) BackupSchedule() string {
+	return c.asString(BackupSchedule)
+}
+
+// BackupRetainDaily returns the number of days for which a daily
+// scheduled backup is kept.
+func (c Config) BackupRetainDaily() int {
+	return c.intOrZero(BackupRetainDaily, DefaultBackupRetainDaily)
+}
+
+// BackupRetainWeekly returns the number of weeks for which a weekly
+// scheduled backup is kept.
+func (c Config) BackupRetainWeekly() int {
+	return c.intOrZero(BackupRetainWeekly, DefaultBackupRetainWeekly)
+}
+
+// BackupTarget returns the URL to which scheduled backups are
+// uploaded, or "" if they are only kept in the controller.
+func (c Config) BackupTarget() string {
+	return c.asString(BackupTarget)
+}
+
+// BackupTargetAccessKey returns the access key used to upload
+// scheduled backups to an S3 backup target.
+func (c Config) BackupTargetAccessKey() string {
+	return c.asString(BackupTargetAccessKey)
+}
+
+// BackupTargetSecretKey returns the secret key used to upload
+// scheduled backups to an S3 backup target.
+func (c Config) BackupTargetSecretKey() string {
+	return c.asString(BackupTargetSecretKey)
+}
+
 // Features returns the controller config set features flags.
 func (c Config) Features() set.Strings {
 	features := set.NewStrings()
@@ -882,6 +997,16 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[AuditLogDBSize].(string); ok {
+		mb, err := utils.ParseSize(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid audit log db size in configuration")
+		}
+		if mb < 1 {
+			return errors.NotValidf("audit log db size less than 1 MB")
+		}
+	}
+
 	if v, ok := c[AuditingEnabled].(bool); ok {
 		if v && auditLogMaxSize == 0 {
 			return errors.Errorf("invalid audit log max size: can't be 0 if auditing is enabled")
@@ -907,6 +1032,34 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[BackupSchedule].(string); ok && v != "" {
+		if _, err := cron.Parse(v); err != nil {
+			return errors.Annotate(err, "invalid backup schedule")
+		}
+	}
+
+	for _, key := range []string{BackupRetainDaily, BackupRetainWeekly} {
+		if v, ok := c[key].(int); ok && v < 0 {
+			return errors.NotValidf("negative %s", key)
+		}
+	}
+
+	if v, ok := c[BackupTarget].(string); ok && v != "" {
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid backup target")
+		}
+		switch u.Scheme {
+		case "file", "s3":
+		default:
+			return errors.NotValidf("backup target scheme %q", u.Scheme)
+		}
+		if u.User != nil {
+			return errors.NotValidf("backup target with credentials; use %s and %s instead",
+				BackupTargetAccessKey, BackupTargetSecretKey)
+		}
+	}
+
 	if v, ok := c[ControllerAPIPort].(int); ok {
 		// TODO: change the validation so 0 is invalid and --reset is used.
 		// However that doesn't exist yet.
@@ -992,7 +1145,14 @@ var configChecker = schema.FieldMap(schema.Fields{
 	AuditLogCaptureArgs:     schema.Bool(),
 	AuditLogMaxSize:         schema.String(),
 	AuditLogMaxBackups:      schema.ForceInt(),
+	AuditLogDBSize:          schema.String(),
 	AuditLogExcludeMethods:  schema.List(schema.String()),
+	BackupSchedule:          schema.String(),
+	BackupRetainDaily:       schema.ForceInt(),
+	BackupRetainWeekly:      schema.ForceInt(),
+	BackupTarget:            schema.String(),
+	BackupTargetAccessKey:   schema.String(),
+	BackupTargetSecretKey:   schema.String(),
 	APIPort:                 schema.ForceInt(),
 	APIPortOpenDelay:        schema.String(),
 	ControllerAPIPort:       schema.ForceInt(),
@@ -1030,7 +1190,14 @@ var configChecker = schema.FieldMap(schema.Fields{
 	AuditLogCaptureArgs:     DefaultAuditLogCaptureArgs,
 	AuditLogMaxSize:         fmt.Sprintf("%vM", DefaultAuditLogMaxSizeMB),
 	AuditLogMaxBackups:      DefaultAuditLogMaxBackups,
+	AuditLogDBSize:          fmt.Sprintf("%vM", DefaultAuditLogDBSizeMB),
 	AuditLogExcludeMethods:  DefaultAuditLogExcludeMethods,
+	BackupSchedule:          schema.Omit,
+	BackupRetainDaily:       DefaultBackupRetainDaily,
+	BackupRetainWeekly:      DefaultBackupRetainWeekly,
+	BackupTarget:            schema.Omit,
+	BackupTargetAccessKey:   schema.Omit,
+	BackupTargetSecretKey:   schema.Omit,
 	StatePort:               DefaultStatePort,
 	IdentityURL:             schema.Omit,
 	IdentityPublicKey:       schema.Omit,
@@ -1085,10 +1252,38 @@ var ConfigSchema = environschema.Fields{
 		Type:        environschema.Tint,
 		Description: "The number of old audit log files to keep (compressed)",
 	},
+	AuditLogDBSize: {
+		Type:        environschema.Tstring,
+		Description: "The size of the capped collection holding audit log records that can be queried with juju audit-log",
+	},
 	AuditLogExcludeMethods: {
 		Type:        environschema.FieldType("list of strings"),
 		Description: "The list of Facade.Method names that aren't interesting for audit logging purposes.",
 	},
+	BackupSchedule: {
+		Type:        environschema.Tstring,
+		Description: `A cron-like schedule at which the controller backs itself up, eg "0 2 * * *"`,
+	},
+	BackupRetainDaily: {
+		Type:        environschema.Tint,
+		Description: "The number of daily scheduled backups to keep",
+	},
+	BackupRetainWeekly: {
+		Type:        environschema.Tint,
+		Description: "The number of weekly scheduled backups to keep",
+	},
+	BackupTarget: {
+		Type:        environschema.Tstring,
+		Description: `The URL of a directory ("file://") or S3-compatible bucket ("s3://") to upload scheduled backups to`,
+	},
+	BackupTargetAccessKey: {
+		Type:        environschema.Tstring,
+		Description: "The access key used to upload scheduled backups to an S3 backup target",
+	},
+	BackupTargetSecretKey: {
+		Type:        environschema.Tstring,
+		Description: "The secret key used to upload scheduled backups to an S3 backup target",
+	},
 	APIPort: {
 		Type:        environschema.Tint,
 		Description: "The port used for api connections",
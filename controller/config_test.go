@@ -190,12 +190,48 @@ var newConfigTests = []struct {
 		controller.AuditLogMaxBackups: -10,
 	},
 	expectError: `invalid audit log max backups: should be a number of files \(or 0 to keep all\), got -10`,
+}, {
+	about: "invalid audit log db size",
+	config: controller.Config{
+		controller.AuditLogDBSize: "abcd",
+	},
+	expectError: `invalid audit log db size in configuration: expected a non-negative number, got "abcd"`,
+}, {
+	about: "zero audit log db size",
+	config: controller.Config{
+		controller.AuditLogDBSize: "0",
+	},
+	expectError: "audit log db size less than 1 MB not valid",
 }, {
 	about: "invalid audit log exclude",
 	config: controller.Config{
 		controller.AuditLogExcludeMethods: []interface{}{"Dap.Kings", "ReadOnlyMethods", "Sharon Jones"},
 	},
 	expectError: `invalid audit log exclude methods: should be a list of "Facade.Method" names \(or "ReadOnlyMethods"\), got "Sharon Jones" at position 3`,
+}, {
+	about: "invalid backup schedule",
+	config: controller.Config{
+		controller.BackupSchedule: "0 25 * * *",
+	},
+	expectError: `invalid backup schedule: hour field of "0 25 \* \* \*": value 25 outside 0-23 not valid`,
+}, {
+	about: "negative backup retain daily",
+	config: controller.Config{
+		controller.BackupRetainDaily: -1,
+	},
+	expectError: `negative backup-retain-daily not valid`,
+}, {
+	about: "invalid backup target scheme",
+	config: controller.Config{
+		controller.BackupTarget: "ftp://example.com/backups",
+	},
+	expectError: `backup target scheme "ftp" not valid`,
+}, {
+	about: "backup target with credentials",
+	config: controller.Config{
+		controller.BackupTarget: "s3://AKID:secret@s3.example.com/backups",
+	},
+	expectError: `backup target with credentials; use backup-target-access-key and backup-target-secret-key instead not valid`,
 }, {
 	about: "invalid model log max size",
 	config: controller.Config{
@@ -473,6 +509,7 @@ func (s *ConfigSuite) TestAuditLogDefaults(c *gc.C) {
 	c.Assert(cfg.AuditLogCaptureArgs(), gc.Equals, false)
 	c.Assert(cfg.AuditLogMaxSizeMB(), gc.Equals, 300)
 	c.Assert(cfg.AuditLogMaxBackups(), gc.Equals, 10)
+	c.Assert(cfg.AuditLogDBSizeMB(), gc.Equals, 100)
 	c.Assert(cfg.AuditLogExcludeMethods(), gc.DeepEquals,
 		set.NewStrings(controller.DefaultAuditLogExcludeMethods...))
 }
@@ -486,6 +523,7 @@ func (s *ConfigSuite) TestAuditLogValues(c *gc.C) {
 			"audit-log-capture-args":    true,
 			"audit-log-max-size":        "100M",
 			"audit-log-max-backups":     10.0,
+			"audit-log-db-size":         "2G",
 			"audit-log-exclude-methods": []string{"Fleet.Foxes", "King.Gizzard", "ReadOnlyMethods"},
 		},
 	)
@@ -494,6 +532,7 @@ func (s *ConfigSuite) TestAuditLogValues(c *gc.C) {
 	c.Assert(cfg.AuditLogCaptureArgs(), gc.Equals, true)
 	c.Assert(cfg.AuditLogMaxSizeMB(), gc.Equals, 100)
 	c.Assert(cfg.AuditLogMaxBackups(), gc.Equals, 10)
+	c.Assert(cfg.AuditLogDBSizeMB(), gc.Equals, 2048)
 	c.Assert(cfg.AuditLogExcludeMethods(), gc.DeepEquals, set.NewStrings(
 		"Fleet.Foxes",
 		"King.Gizzard",
@@ -636,6 +675,41 @@ func (s *ConfigSuite) TestMeteringURLSettingValue(c *gc.C) {
 	c.Assert(cfg.MeteringURL(), gc.Equals, mURL)
 }
 
+func (s *ConfigSuite) TestBackupDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.BackupSchedule(), gc.Equals, "")
+	c.Check(cfg.BackupRetainDaily(), gc.Equals, 7)
+	c.Check(cfg.BackupRetainWeekly(), gc.Equals, 4)
+	c.Check(cfg.BackupTarget(), gc.Equals, "")
+}
+
+func (s *ConfigSuite) TestBackupSettingValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			controller.BackupSchedule:        "@daily",
+			controller.BackupRetainDaily:     0,
+			controller.BackupRetainWeekly:    12.0,
+			controller.BackupTarget:          "s3://s3.example.com/backups",
+			controller.BackupTargetAccessKey: "AKID",
+			controller.BackupTargetSecretKey: "secret",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.BackupSchedule(), gc.Equals, "@daily")
+	c.Check(cfg.BackupRetainDaily(), gc.Equals, 0)
+	c.Check(cfg.BackupRetainWeekly(), gc.Equals, 12)
+	c.Check(cfg.BackupTarget(), gc.Equals, "s3://s3.example.com/backups")
+	c.Check(cfg.BackupTargetAccessKey(), gc.Equals, "AKID")
+	c.Check(cfg.BackupTargetSecretKey(), gc.Equals, "secret")
+}
+
 func (s *ConfigSuite) TestMaxDebugLogDuration(c *gc.C) {
 	cfg, err := controller.NewConfig(
 		testing.ControllerTag.Id(),
@@ -5,8 +5,22 @@ package actions
 
 import "time"
 
+const (
+	// StdoutStream identifies messages holding lines written to
+	// stdout by a running action.
+	StdoutStream = "stdout"
+
+	// StderrStream identifies messages holding lines written to
+	// stderr by a running action.
+	StderrStream = "stderr"
+)
+
 // ActionMessage is a timestamped message logged by a running action.
+// Messages logged with action-log have no stream; lines written to
+// stdout or stderr by the action are recorded with the stream they
+// were written to.
 type ActionMessage struct {
 	Message   string    `json:"message"`
 	Timestamp time.Time `json:"timestamp"`
+	Stream    string    `json:"stream,omitempty"`
 }
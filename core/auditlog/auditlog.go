@@ -238,6 +238,54 @@ func (a *auditLogFile) addRecord(r Record) error {
 	return errors.Trace(err)
 }
 
+// teeLog is an AuditLog that writes each record to several logs.
+type teeLog []AuditLog
+
+// Tee returns an audit entry sink which writes each record to all of
+// the logs passed in. A failure to write to one log doesn't stop the
+// record being written to the others; the first error is returned.
+func Tee(logs ...AuditLog) AuditLog {
+	return teeLog(logs)
+}
+
+// AddConversation implements AuditLog.
+func (t teeLog) AddConversation(c Conversation) error {
+	return t.each(func(log AuditLog) error {
+		return log.AddConversation(c)
+	})
+}
+
+// AddRequest implements AuditLog.
+func (t teeLog) AddRequest(r Request) error {
+	return t.each(func(log AuditLog) error {
+		return log.AddRequest(r)
+	})
+}
+
+// AddResponse implements AuditLog.
+func (t teeLog) AddResponse(r ResponseErrors) error {
+	return t.each(func(log AuditLog) error {
+		return log.AddResponse(r)
+	})
+}
+
+// Close implements AuditLog.
+func (t teeLog) Close() error {
+	return t.each(func(log AuditLog) error {
+		return log.Close()
+	})
+}
+
+func (t teeLog) each(f func(AuditLog) error) error {
+	var result error
+	for _, log := range t {
+		if err := f(log); err != nil && result == nil {
+			result = errors.Trace(err)
+		}
+	}
+	return result
+}
+
 func idString(id uint64) string {
 	return fmt.Sprintf("%X", id)
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/core/paths"
 
 	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -142,6 +143,34 @@ func (s *AuditLogSuite) TestRecorder(c *gc.C) {
 	})
 }
 
+func (s *AuditLogSuite) TestTee(c *gc.C) {
+	var log1, log2 fakeLog
+	log1.stub.SetErrors(nil, errors.New("request failed"))
+	tee := auditlog.Tee(&log1, &log2)
+
+	conversation := auditlog.Conversation{ConversationID: "0123456789abcdef"}
+	err := tee.AddConversation(conversation)
+	c.Assert(err, jc.ErrorIsNil)
+	request := auditlog.Request{ConversationID: "0123456789abcdef", RequestID: 1}
+	err = tee.AddRequest(request)
+	c.Assert(err, gc.ErrorMatches, "request failed")
+	response := auditlog.ResponseErrors{ConversationID: "0123456789abcdef", RequestID: 1}
+	err = tee.AddResponse(response)
+	c.Assert(err, jc.ErrorIsNil)
+	err = tee.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Both logs see every record, even when writing to one fails.
+	for _, log := range []*fakeLog{&log1, &log2} {
+		log.stub.CheckCalls(c, []testing.StubCall{
+			{"AddConversation", []interface{}{conversation}},
+			{"AddRequest", []interface{}{request}},
+			{"AddResponse", []interface{}{response}},
+			{"Close", nil},
+		})
+	}
+}
+
 type fakeLog struct {
 	stub testing.Stub
 }
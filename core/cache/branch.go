@@ -58,6 +58,19 @@ func (b *Branch) AppConfig(appName string) settings.ItemChanges {
 	return b.details.Config[appName]
 }
 
+// AppCharmURL returns the URL of the charm that the input application
+// has been upgraded to under the branch, or an empty string if it has
+// not been upgraded.
+func (b *Branch) AppCharmURL(appName string) string {
+	return b.details.CharmURLs[appName]
+}
+
+// AppResources returns the IDs of the pending resources staged under
+// the branch for a specific application, keyed by resource name.
+func (b *Branch) AppResources(appName string) map[string]string {
+	return b.details.Resources[appName]
+}
+
 // Created returns a Unix timestamp indicating when this generation
 // was created.
 func (b *Branch) Created() int64 {
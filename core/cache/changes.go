@@ -252,6 +252,8 @@ type BranchChange struct {
 	Name          string
 	AssignedUnits map[string][]string
 	Config        map[string]settings.ItemChanges
+	CharmURLs     map[string]string
+	Resources     map[string]map[string]string
 	Created       int64
 	CreatedBy     string
 	Completed     int64
@@ -293,6 +295,30 @@ func (b BranchChange) copy() BranchChange {
 	}
 	b.Config = cConfig
 
+	var cCharmURLs map[string]string
+	bCharmURLs := b.CharmURLs
+	if bCharmURLs != nil {
+		cCharmURLs = make(map[string]string, len(bCharmURLs))
+		for k, v := range bCharmURLs {
+			cCharmURLs[k] = v
+		}
+	}
+	b.CharmURLs = cCharmURLs
+
+	var cResources map[string]map[string]string
+	bResources := b.Resources
+	if bResources != nil {
+		cResources = make(map[string]map[string]string, len(bResources))
+		for k, v := range bResources {
+			ids := make(map[string]string, len(v))
+			for name, id := range v {
+				ids[name] = id
+			}
+			cResources[k] = ids
+		}
+	}
+	b.Resources = cResources
+
 	return b
 }
 
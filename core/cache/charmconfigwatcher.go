@@ -48,6 +48,9 @@ type charmConfigWatcherConfig struct {
 // to the unit's effective configuration:
 // - Changes to the charm config settings for the unit's application.
 // - Changes to a model branch being tracked by the unit.
+// Charm upgrades and resources staged in the tracked branch are included
+// in the unit's effective configuration, so that the unit is notified
+// when they change.
 type CharmConfigWatcher struct {
 	*stringsWatcherBase
 
@@ -63,9 +66,11 @@ type CharmConfigWatcher struct {
 	CharmConfigHashCacheHitInc  func()
 	CharmConfigHashCacheMissInc func()
 
-	masterSettings map[string]interface{}
-	branchDeltas   settings.ItemChanges
-	configHash     string
+	masterSettings  map[string]interface{}
+	branchDeltas    settings.ItemChanges
+	branchCharmURL  string
+	branchResources map[string]string
+	configHash      string
 }
 
 // newUnitConfigWatcher returns a new watcher for the unit indicated in the
@@ -116,8 +121,7 @@ func (w *CharmConfigWatcher) init(model charmConfigModel) error {
 	branches := model.Branches()
 	for _, b := range branches {
 		if w.isTracking(b) {
-			w.branchName = b.Name()
-			w.branchDeltas = b.AppConfig(w.appName)
+			w.setBranchDetails(b)
 			break
 		}
 	}
@@ -172,10 +176,19 @@ func (w *CharmConfigWatcher) branchChanged(_ string, msg interface{}) {
 		return
 	}
 
-	w.branchDeltas = b.AppConfig(w.appName)
+	w.setBranchDetails(b)
 	w.checkConfig()
 }
 
+// setBranchDetails records the changes to the watcher's application
+// made under the input branch, which the unit is tracking.
+func (w *CharmConfigWatcher) setBranchDetails(b Branch) {
+	w.branchName = b.Name()
+	w.branchDeltas = b.AppConfig(w.appName)
+	w.branchCharmURL = b.AppCharmURL(w.appName)
+	w.branchResources = b.AppResources(w.appName)
+}
+
 // branchRemoved is called when we receive a message to say that a branch has
 // been removed from the cache.
 // If this watcher's unit was tracking the branch, clean the branch-based
@@ -203,6 +216,8 @@ func (w *CharmConfigWatcher) branchRemoved(topic string, msg interface{}) {
 	// without reevaluating the hash.
 	w.branchName = ""
 	w.branchDeltas = nil
+	w.branchCharmURL = ""
+	w.branchResources = nil
 }
 
 // isTracking returns true if this watcher's unit is tracking the input branch.
@@ -241,7 +256,16 @@ func (w *CharmConfigWatcher) setConfigHash() (bool, error) {
 		}
 	}
 
-	newHash, err := hash(cfg, w.charmURL)
+	extra := []string{w.charmURL, w.branchCharmURL}
+	resourceNames := set.NewStrings()
+	for name := range w.branchResources {
+		resourceNames.Add(name)
+	}
+	for _, name := range resourceNames.SortedValues() {
+		extra = append(extra, name, w.branchResources[name])
+	}
+
+	newHash, err := hash(cfg, extra...)
 	if err != nil {
 		return false, errors.Trace(err)
 	}
@@ -54,6 +54,31 @@ func (s *charmConfigWatcherSuite) TestTrackingBranchChangedNotified(c *gc.C) {
 	w.AssertStops()
 }
 
+func (s *charmConfigWatcherSuite) TestTrackingBranchCharmUpgradeNotified(c *gc.C) {
+	w := s.newWatcher(c, defaultUnitName, defaultCharmURL)
+	cfg := map[string]interface{}{"password": defaultPassword}
+	s.assertOneChange(c, w, cfg, defaultCharmURL)
+
+	// Publish a tracked branch change with a charm upgrade.
+	b := Branch{
+		details: BranchChange{
+			Name:      branchName,
+			Config:    map[string]settings.ItemChanges{"redis": {settings.MakeAddition("password", defaultPassword)}},
+			CharmURLs: map[string]string{"redis": "cs:redis-2"},
+		},
+	}
+	s.Hub.Publish(branchChange, b)
+	s.assertOneChange(c, w, cfg, defaultCharmURL, "cs:redis-2")
+
+	// Stage a resource for the upgrade.
+	b = b.copy()
+	b.details.Resources = map[string]map[string]string{"redis": {"data": "pending-id"}}
+	s.Hub.Publish(branchChange, b)
+	s.assertOneChange(c, w, cfg, defaultCharmURL, "cs:redis-2", "data", "pending-id")
+
+	w.AssertStops()
+}
+
 func (s *charmConfigWatcherSuite) TestNotTrackingBranchChangedNotNotified(c *gc.C) {
 	// This will initialise the watcher without branch info.
 	w := s.newWatcher(c, "redis/9", defaultCharmURL)
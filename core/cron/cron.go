@@ -0,0 +1,234 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package cron parses cron-like schedule expressions and computes
+// the times at which they fire.
+//
+// The standard five field format is supported:
+//
+//	minute hour day-of-month month day-of-week
+//
+// Each field may be "*", a single value, a range ("1-5"), a list
+// ("1,3,5") or any of those followed by a step ("*/15", "0-30/10").
+// Months and days of the week may also be given by their three letter
+// English names. As with cron, when both day-of-month and day-of-week
+// are restricted a day matches if either of them matches.
+//
+// The descriptors "@hourly", "@daily" (or "@midnight"), "@weekly" and
+// "@monthly" are accepted as shorthands.
+package cron
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// Schedule describes when a cron-like job should run.
+type Schedule struct {
+	expr string
+
+	minute, hour, dom, month, dow uint64
+
+	// domStar and dowStar record whether the day-of-month and
+	// day-of-week fields were unrestricted, which changes how
+	// the two fields are combined.
+	domStar, dowStar bool
+}
+
+// String returns the expression the schedule was parsed from.
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+type bounds struct {
+	min, max uint
+	names    map[string]uint
+}
+
+var (
+	minuteBounds = bounds{min: 0, max: 59}
+	hourBounds   = bounds{min: 0, max: 23}
+	domBounds    = bounds{min: 1, max: 31}
+	monthBounds  = bounds{min: 1, max: 12, names: map[string]uint{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// Sunday may be written as either 0 or 7.
+	dowBounds = bounds{min: 0, max: 7, names: map[string]uint{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var descriptors = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+}
+
+// Parse parses the supplied cron expression and returns the
+// corresponding schedule.
+func Parse(expr string) (*Schedule, error) {
+	trimmed := strings.TrimSpace(expr)
+	if trimmed == "" {
+		return nil, errors.NotValidf("empty schedule")
+	}
+	spec := trimmed
+	if strings.HasPrefix(spec, "@") {
+		var ok bool
+		if spec, ok = descriptors[strings.ToLower(spec)]; !ok {
+			return nil, errors.NotValidf("schedule descriptor %q", trimmed)
+		}
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, errors.NotValidf("schedule %q: expected 5 fields, got %d", trimmed, len(fields))
+	}
+
+	s := &Schedule{expr: trimmed}
+	var err error
+	if s.minute, err = parseField(fields[0], minuteBounds); err != nil {
+		return nil, errors.Annotatef(err, "minute field of %q", trimmed)
+	}
+	if s.hour, err = parseField(fields[1], hourBounds); err != nil {
+		return nil, errors.Annotatef(err, "hour field of %q", trimmed)
+	}
+	if s.dom, err = parseField(fields[2], domBounds); err != nil {
+		return nil, errors.Annotatef(err, "day-of-month field of %q", trimmed)
+	}
+	if s.month, err = parseField(fields[3], monthBounds); err != nil {
+		return nil, errors.Annotatef(err, "month field of %q", trimmed)
+	}
+	if s.dow, err = parseField(fields[4], dowBounds); err != nil {
+		return nil, errors.Annotatef(err, "day-of-week field of %q", trimmed)
+	}
+	// Fold a Sunday expressed as 7 onto 0.
+	if s.dow&(1<<7) != 0 {
+		s.dow = (s.dow | 1) &^ (1 << 7)
+	}
+	s.domStar = fields[2] == "*" || fields[2] == "?"
+	s.dowStar = fields[4] == "*" || fields[4] == "?"
+	return s, nil
+}
+
+// parseField returns a bit set with a bit set for every value
+// matched by the supplied field.
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		partBits, err := parsePart(part, b)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		bits |= partBits
+	}
+	return bits, nil
+}
+
+func parsePart(part string, b bounds) (uint64, error) {
+	rangeAndStep := strings.Split(part, "/")
+	if len(rangeAndStep) > 2 {
+		return 0, errors.NotValidf("%q", part)
+	}
+	var start, end uint
+	switch rng := rangeAndStep[0]; {
+	case rng == "*" || rng == "?":
+		start, end = b.min, b.max
+	default:
+		lowHigh := strings.Split(rng, "-")
+		if len(lowHigh) > 2 {
+			return 0, errors.NotValidf("range %q", rng)
+		}
+		var err error
+		if start, err = parseValue(lowHigh[0], b); err != nil {
+			return 0, errors.Trace(err)
+		}
+		end = start
+		if len(lowHigh) == 2 {
+			if end, err = parseValue(lowHigh[1], b); err != nil {
+				return 0, errors.Trace(err)
+			}
+		} else if len(rangeAndStep) == 2 {
+			// "N/step" means from N to the maximum.
+			end = b.max
+		}
+	}
+	step := uint(1)
+	if len(rangeAndStep) == 2 {
+		n, err := strconv.ParseUint(rangeAndStep[1], 10, 8)
+		if err != nil || n == 0 {
+			return 0, errors.NotValidf("step %q", rangeAndStep[1])
+		}
+		step = uint(n)
+	}
+	if start > end {
+		return 0, errors.NotValidf("range %q: start after end", part)
+	}
+	var bits uint64
+	for v := start; v <= end; v += step {
+		bits |= 1 << v
+	}
+	return bits, nil
+}
+
+func parseValue(value string, b bounds) (uint, error) {
+	if n, ok := b.names[strings.ToLower(value)]; ok {
+		return n, nil
+	}
+	n, err := strconv.ParseUint(value, 10, 8)
+	if err != nil {
+		return 0, errors.NotValidf("value %q", value)
+	}
+	if uint(n) < b.min || uint(n) > b.max {
+		return 0, errors.NotValidf("value %d outside %d-%d", n, b.min, b.max)
+	}
+	return uint(n), nil
+}
+
+// maxSearch bounds how far into the future Next will look for a
+// matching time. Expressions such as "0 0 30 2 *" never match.
+const maxSearch = 5 * 366 * 24 * time.Hour
+
+// Next returns the first time after t that matches the schedule,
+// in t's location. A zero time is returned if the schedule never
+// fires.
+func (s *Schedule) Next(t time.Time) time.Time {
+	// Start at the beginning of the next whole minute.
+	t = t.Add(time.Minute - time.Duration(t.Second())*time.Second - time.Duration(t.Nanosecond()))
+	limit := t.Add(maxSearch)
+
+	loc := t.Location()
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Truncate(time.Minute).Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
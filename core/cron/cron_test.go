@@ -0,0 +1,128 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cron_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/cron"
+)
+
+type CronSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&CronSuite{})
+
+func mustTime(c *gc.C, value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	c.Assert(err, jc.ErrorIsNil)
+	return t
+}
+
+func (s *CronSuite) TestNext(c *gc.C) {
+	for i, test := range []struct {
+		expr string
+		from string
+		next string
+	}{{
+		expr: "*/15 * * * *",
+		from: "2020-01-01T10:07:31Z",
+		next: "2020-01-01T10:15:00Z",
+	}, {
+		expr: "30 2 * * *",
+		from: "2020-01-01T02:30:00Z",
+		next: "2020-01-02T02:30:00Z",
+	}, {
+		expr: "@daily",
+		from: "2020-02-28T23:59:59Z",
+		next: "2020-02-29T00:00:00Z",
+	}, {
+		expr: "@weekly",
+		from: "2020-01-01T00:00:00Z",
+		next: "2020-01-05T00:00:00Z",
+	}, {
+		expr: "@monthly",
+		from: "2020-01-15T12:00:00Z",
+		next: "2020-02-01T00:00:00Z",
+	}, {
+		expr: "0 4 * * mon-fri",
+		from: "2020-01-03T05:00:00Z", // Friday
+		next: "2020-01-06T04:00:00Z", // Monday
+	}, {
+		expr: "0 0 * * 7",
+		from: "2020-01-01T00:00:00Z",
+		next: "2020-01-05T00:00:00Z",
+	}, {
+		expr: "0 0 13 * fri",
+		from: "2020-01-01T00:00:00Z",
+		next: "2020-01-03T00:00:00Z",
+	}, {
+		expr: "0 12 1,15 jan,jul *",
+		from: "2020-01-15T12:00:00Z",
+		next: "2020-07-01T12:00:00Z",
+	}, {
+		expr: "5/20 1-3 * * *",
+		from: "2020-01-01T01:50:00Z",
+		next: "2020-01-01T02:05:00Z",
+	}} {
+		c.Logf("test %d: %q from %s", i, test.expr, test.from)
+		schedule, err := cron.Parse(test.expr)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(schedule.Next(mustTime(c, test.from)), gc.Equals, mustTime(c, test.next))
+	}
+}
+
+func (s *CronSuite) TestNextNeverMatches(c *gc.C) {
+	schedule, err := cron.Parse("0 0 30 feb *")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.Next(mustTime(c, "2020-01-01T00:00:00Z")).IsZero(), jc.IsTrue)
+}
+
+func (s *CronSuite) TestString(c *gc.C) {
+	schedule, err := cron.Parse(" @hourly ")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.String(), gc.Equals, "@hourly")
+}
+
+func (s *CronSuite) TestParseErrors(c *gc.C) {
+	for i, test := range []struct {
+		expr string
+		err  string
+	}{{
+		expr: "",
+		err:  "empty schedule not valid",
+	}, {
+		expr: "@yearly",
+		err:  `schedule descriptor "@yearly" not valid`,
+	}, {
+		expr: "* * * *",
+		err:  `schedule "\* \* \* \*": expected 5 fields, got 4 not valid`,
+	}, {
+		expr: "60 * * * *",
+		err:  `minute field of "60 \* \* \* \*": value 60 outside 0-59 not valid`,
+	}, {
+		expr: "* * 0 * *",
+		err:  `day-of-month field of .*: value 0 outside 1-31 not valid`,
+	}, {
+		expr: "* * * foo *",
+		err:  `month field of .*: value "foo" not valid`,
+	}, {
+		expr: "*/0 * * * *",
+		err:  `minute field of .*: step "0" not valid`,
+	}, {
+		expr: "* 5-1 * * *",
+		err:  `hour field of .*: range "5-1": start after end not valid`,
+	}} {
+		c.Logf("test %d: %q", i, test.expr)
+		_, err := cron.Parse(test.expr)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
@@ -3,7 +3,13 @@
 
 package firewall
 
-import "github.com/juju/errors"
+import (
+	"regexp"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/network"
+)
 
 const (
 	// SSHRule is a rule for SSH connections.
@@ -19,6 +25,8 @@ const (
 // WellKnownService defines a service for which firewall rules may be applied.
 type WellKnownServiceType string
 
+// Validate returns an error if the service is not one of the
+// services defined by Juju.
 func (v WellKnownServiceType) Validate() error {
 	switch v {
 	case SSHRule, JujuControllerRule, JujuApplicationOfferRule:
@@ -26,3 +34,45 @@ func (v WellKnownServiceType) Validate() error {
 	}
 	return errors.NotValidf("well known service type %q", v)
 }
+
+// IsBuiltin returns true if the service is one of the services
+// defined by Juju, as opposed to one defined by an operator.
+func (v WellKnownServiceType) IsBuiltin() bool {
+	return v.Validate() == nil
+}
+
+var validServiceName = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+// Service describes a service, defined by an operator, for which
+// firewall rules may be applied. Rules for a service apply to any
+// opened port range within the service's port range.
+type Service struct {
+	// Name identifies the service in firewall rules.
+	Name WellKnownServiceType
+
+	// PortRange is the protocol and ports on which
+	// the service accepts connections.
+	PortRange network.PortRange
+}
+
+// Validate returns an error if the service definition is not valid.
+func (s Service) Validate() error {
+	if !validServiceName.MatchString(string(s.Name)) {
+		return errors.NotValidf("service name %q", s.Name)
+	}
+	if s.Name.IsBuiltin() {
+		return errors.NotValidf("redefining built-in service %q", s.Name)
+	}
+	if err := s.PortRange.Validate(); err != nil {
+		return errors.NewNotValid(err, "service "+string(s.Name))
+	}
+	return nil
+}
+
+// Contains returns true if the input port range lies within
+// the service's port range.
+func (s Service) Contains(portRange network.PortRange) bool {
+	return s.PortRange.Protocol == portRange.Protocol &&
+		s.PortRange.FromPort <= portRange.FromPort &&
+		s.PortRange.ToPort >= portRange.ToPort
+}
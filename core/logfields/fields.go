@@ -0,0 +1,207 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package logfields supports attaching structured key/value fields,
+// such as the unit or hook being run, to agent log messages.
+//
+// Loggo entries only carry a message, so the fields are appended to
+// the message in a form that is readable in the agent's own log file,
+// e.g.
+//
+//	ran "install" hook [fields: hook=install unit=mysql/0]
+//
+// The log sender parses them back out before the record is sent to
+// the controller, where they are stored and can be filtered on.
+package logfields
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// These are the names of fields attached by the agents.
+const (
+	Unit       = "unit"
+	Hook       = "hook"
+	RelationID = "relation-id"
+	RemoteUnit = "remote-unit"
+	ActionID   = "action-id"
+	Worker     = "worker"
+)
+
+const (
+	fieldsPrefix = " [fields: "
+	fieldsSuffix = "]"
+)
+
+var validKey = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+// Fields holds the structured fields attached to a log message.
+type Fields map[string]string
+
+// ValidateKey returns an error if key can't be used as a field name.
+// Field names are lower case words separated by hyphens, so that they
+// can be used unescaped in queries and on the command line.
+func ValidateKey(key string) error {
+	if !validKey.MatchString(key) {
+		return errors.NotValidf("log field name %q", key)
+	}
+	return nil
+}
+
+// Validate returns an error if any of the field names is not valid.
+func (f Fields) Validate() error {
+	for key := range f {
+		if err := ValidateKey(key); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// Merge returns a new set of fields holding both f and other. Fields
+// in other take precedence.
+func (f Fields) Merge(other Fields) Fields {
+	result := make(Fields, len(f)+len(other))
+	for key, value := range f {
+		result[key] = value
+	}
+	for key, value := range other {
+		result[key] = value
+	}
+	return result
+}
+
+// String returns the fields as space-separated key=value pairs, sorted
+// by key. Values are quoted if needed.
+func (f Fields) String() string {
+	keys := make([]string, 0, len(f))
+	for key := range f {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = key + "=" + quoteValue(f[key])
+	}
+	return strings.Join(pairs, " ")
+}
+
+func quoteValue(value string) string {
+	if value == "" || strings.ContainsAny(value, " \"=[]\\") || strconv.Quote(value) != `"`+value+`"` {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+// Format returns the message with the fields appended, in the form
+// understood by Parse.
+func Format(message string, fields Fields) string {
+	if len(fields) == 0 {
+		return message
+	}
+	return message + fieldsPrefix + fields.String() + fieldsSuffix
+}
+
+// Parse splits a message formatted by Format into the original message
+// and its fields. Messages without fields are returned unchanged, with
+// nil fields.
+func Parse(message string) (string, Fields) {
+	if !strings.HasSuffix(message, fieldsSuffix) {
+		return message, nil
+	}
+	i := strings.LastIndex(message, fieldsPrefix)
+	if i < 0 {
+		return message, nil
+	}
+	encoded := message[i+len(fieldsPrefix) : len(message)-len(fieldsSuffix)]
+	fields, err := parseFields(encoded)
+	if err != nil {
+		return message, nil
+	}
+	return message[:i], fields
+}
+
+func parseFields(s string) (Fields, error) {
+	fields := make(Fields)
+	for s != "" {
+		eq := strings.Index(s, "=")
+		if eq < 0 {
+			return nil, errors.Errorf("missing value")
+		}
+		key := s[:eq]
+		if err := ValidateKey(key); err != nil {
+			return nil, errors.Trace(err)
+		}
+		s = s[eq+1:]
+
+		var value string
+		if strings.HasPrefix(s, `"`) {
+			quoted := quotedPrefix(s)
+			var err error
+			if value, err = strconv.Unquote(quoted); err != nil {
+				return nil, errors.Trace(err)
+			}
+			s = s[len(quoted):]
+		} else {
+			end := strings.Index(s, " ")
+			if end < 0 {
+				end = len(s)
+			}
+			value, s = s[:end], s[end:]
+		}
+		fields[key] = value
+
+		if s == "" {
+			break
+		}
+		if !strings.HasPrefix(s, " ") {
+			return nil, errors.Errorf("unexpected %q after value", s[:1])
+		}
+		s = s[1:]
+	}
+	if len(fields) == 0 {
+		return nil, errors.New("no fields")
+	}
+	return fields, nil
+}
+
+// quotedPrefix returns the quoted string at the start of s, up to
+// and including the closing quote.
+func quotedPrefix(s string) string {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return s[:i+1]
+		}
+	}
+	return s
+}
+
+// ParseKeyValue parses a field given as key=value, as on the
+// command line.
+func ParseKeyValue(s string) (string, string, error) {
+	parts := strings.SplitN(s, "=", 2)
+	if len(parts) != 2 {
+		return "", "", errors.NotValidf("log field %q (expected key=value)", s)
+	}
+	if err := ValidateKey(parts[0]); err != nil {
+		return "", "", errors.Trace(err)
+	}
+	return parts[0], parts[1], nil
+}
+
+// formatMessage formats the message and args as loggo does.
+func formatMessage(message string, args []interface{}) string {
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
@@ -0,0 +1,98 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfields_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/logfields"
+)
+
+type FieldsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FieldsSuite{})
+
+func (s *FieldsSuite) TestValidateKey(c *gc.C) {
+	for _, key := range []string{"unit", "relation-id", "k8s-pod"} {
+		c.Check(logfields.ValidateKey(key), jc.ErrorIsNil, gc.Commentf("%q", key))
+	}
+	for _, key := range []string{"", "Unit", "relation_id", "a.b", "$where", "-unit", "unit-", "1st"} {
+		err := logfields.ValidateKey(key)
+		c.Check(err, jc.Satisfies, errors.IsNotValid, gc.Commentf("%q", key))
+	}
+}
+
+func (s *FieldsSuite) TestFormat(c *gc.C) {
+	message := logfields.Format(`ran "install" hook`, logfields.Fields{
+		"unit":        "mysql/0",
+		"hook":        "install",
+		"relation-id": "",
+		"note":        `say "hi" [now]`,
+	})
+	c.Assert(message, gc.Equals,
+		`ran "install" hook [fields: hook=install note="say \"hi\" [now]" relation-id="" unit=mysql/0]`)
+}
+
+func (s *FieldsSuite) TestFormatNoFields(c *gc.C) {
+	c.Assert(logfields.Format("hello", nil), gc.Equals, "hello")
+}
+
+func (s *FieldsSuite) TestParseRoundTrip(c *gc.C) {
+	fields := logfields.Fields{
+		"unit":        "mysql/0",
+		"hook":        "db-relation-changed",
+		"relation-id": "7",
+		"empty":       "",
+		"quoted":      `a "b" = c]`,
+		"control":     "line1\nline2",
+	}
+	message, parsed := logfields.Parse(logfields.Format("hook failed: exit status 1", fields))
+	c.Check(message, gc.Equals, "hook failed: exit status 1")
+	c.Check(parsed, jc.DeepEquals, fields)
+}
+
+func (s *FieldsSuite) TestParseNoFields(c *gc.C) {
+	for _, message := range []string{
+		"hello",
+		"listening on [::1]",
+		"odd [fields: ]",
+		"odd [fields: NotAKey=x]",
+		"odd [fields: a=b c]",
+		`odd [fields: a="b]`,
+		`odd [fields: a="b"c]`,
+	} {
+		parsed, fields := logfields.Parse(message)
+		c.Check(parsed, gc.Equals, message)
+		c.Check(fields, gc.IsNil)
+	}
+}
+
+func (s *FieldsSuite) TestMerge(c *gc.C) {
+	f := logfields.Fields{"unit": "mysql/0", "hook": "install"}
+	merged := f.Merge(logfields.Fields{"hook": "start", "worker": "uniter"})
+	c.Check(merged, jc.DeepEquals, logfields.Fields{
+		"unit":   "mysql/0",
+		"hook":   "start",
+		"worker": "uniter",
+	})
+	c.Check(f, jc.DeepEquals, logfields.Fields{"unit": "mysql/0", "hook": "install"})
+}
+
+func (s *FieldsSuite) TestParseKeyValue(c *gc.C) {
+	key, value, err := logfields.ParseKeyValue("relation-id=7")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(key, gc.Equals, "relation-id")
+	c.Check(value, gc.Equals, "7")
+
+	_, _, err = logfields.ParseKeyValue("relation-id")
+	c.Check(err, gc.ErrorMatches, `log field "relation-id" \(expected key=value\) not valid`)
+
+	_, _, err = logfields.ParseKeyValue("Relation=7")
+	c.Check(err, gc.ErrorMatches, `log field name "Relation" not valid`)
+}
@@ -0,0 +1,88 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfields
+
+import (
+	"github.com/juju/loggo"
+)
+
+// Logger wraps a loggo.Logger, attaching the same fields to every
+// message it logs.
+type Logger struct {
+	logger loggo.Logger
+	fields Fields
+}
+
+// NewLogger returns a Logger that logs to logger, attaching fields to
+// each message.
+func NewLogger(logger loggo.Logger, fields Fields) Logger {
+	return Logger{
+		logger: logger,
+		fields: fields,
+	}
+}
+
+// With returns a Logger that attaches the given fields as well as
+// those already attached by l.
+func (l Logger) With(fields Fields) Logger {
+	return Logger{
+		logger: l.logger,
+		fields: l.fields.Merge(fields),
+	}
+}
+
+// Fields returns the fields attached by the logger.
+func (l Logger) Fields() Fields {
+	return l.fields.Merge(nil)
+}
+
+// Logf logs a printf-formatted message at the given level.
+func (l Logger) Logf(level loggo.Level, message string, args ...interface{}) {
+	l.logf(level, message, args)
+}
+
+// Criticalf logs the printf-formatted message at critical level.
+func (l Logger) Criticalf(message string, args ...interface{}) {
+	l.logf(loggo.CRITICAL, message, args)
+}
+
+// Errorf logs the printf-formatted message at error level.
+func (l Logger) Errorf(message string, args ...interface{}) {
+	l.logf(loggo.ERROR, message, args)
+}
+
+// Warningf logs the printf-formatted message at warning level.
+func (l Logger) Warningf(message string, args ...interface{}) {
+	l.logf(loggo.WARNING, message, args)
+}
+
+// Infof logs the printf-formatted message at info level.
+func (l Logger) Infof(message string, args ...interface{}) {
+	l.logf(loggo.INFO, message, args)
+}
+
+// Debugf logs the printf-formatted message at debug level.
+func (l Logger) Debugf(message string, args ...interface{}) {
+	l.logf(loggo.DEBUG, message, args)
+}
+
+// Tracef logs the printf-formatted message at trace level.
+func (l Logger) Tracef(message string, args ...interface{}) {
+	l.logf(loggo.TRACE, message, args)
+}
+
+// IsLevelEnabled returns whether debugging is enabled at the given
+// level.
+func (l Logger) IsLevelEnabled(level loggo.Level) bool {
+	return l.logger.IsLevelEnabled(level)
+}
+
+// logf must only be called by the exported logging methods, so that
+// the location logged is that of their caller.
+func (l Logger) logf(level loggo.Level, message string, args []interface{}) {
+	if !l.logger.IsLevelEnabled(level) {
+		return
+	}
+	l.logger.LogCallf(3, level, "%s", Format(formatMessage(message, args), l.fields))
+}
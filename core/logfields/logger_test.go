@@ -0,0 +1,46 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfields_test
+
+import (
+	"path/filepath"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/logfields"
+)
+
+type LoggerSuite struct {
+	testing.LoggingCleanupSuite
+	writer *loggo.TestWriter
+}
+
+var _ = gc.Suite(&LoggerSuite{})
+
+func (s *LoggerSuite) SetUpTest(c *gc.C) {
+	s.LoggingCleanupSuite.SetUpTest(c)
+	s.writer = &loggo.TestWriter{}
+	err := loggo.RegisterWriter("test", s.writer)
+	c.Assert(err, jc.ErrorIsNil)
+	loggo.GetLogger("test.logfields").SetLogLevel(loggo.DEBUG)
+}
+
+func (s *LoggerSuite) TestLogger(c *gc.C) {
+	logger := logfields.NewLogger(loggo.GetLogger("test.logfields"), logfields.Fields{
+		"unit": "mysql/0",
+	})
+	logger.With(logfields.Fields{"hook": "install"}).Infof("ran %q hook", "install")
+	logger.Tracef("not logged")
+
+	entries := s.writer.Log()
+	c.Assert(entries, gc.HasLen, 1)
+	c.Check(entries[0].Level, gc.Equals, loggo.INFO)
+	c.Check(entries[0].Module, gc.Equals, "test.logfields")
+	c.Check(filepath.Base(entries[0].Filename), gc.Equals, "logger_test.go")
+	c.Check(entries[0].Message, gc.Equals, `ran "install" hook [fields: hook=install unit=mysql/0]`)
+	c.Check(logger.Fields(), jc.DeepEquals, logfields.Fields{"unit": "mysql/0"})
+}
@@ -4,8 +4,9 @@
 package model
 
 import (
-	"github.com/juju/errors"
 	"time"
+
+	"github.com/juju/errors"
 )
 
 // TODO (manadart 2019-04-21) Change the nomenclature here to indicate "branch"
@@ -27,6 +28,35 @@ func ValidateBranchName(name string) error {
 	return nil
 }
 
+// RolloutPolicy describes how the changes made under a branch are rolled
+// out to the units that are not yet tracking it, before the branch is
+// committed to the model.
+type RolloutPolicy struct {
+	// BatchSize is the maximum number of units
+	// set to track the branch in each batch.
+	BatchSize int
+
+	// BatchInterval is the time to wait after each batch
+	// before the next batch is set to track the branch.
+	BatchInterval time.Duration
+
+	// HaltOnError indicates that the rollout should stop if any unit
+	// tracking the branch has a workload status of "error".
+	HaltOnError bool
+}
+
+// Validate returns an error if the policy
+// can not be used to roll out a branch.
+func (p RolloutPolicy) Validate() error {
+	if p.BatchSize < 1 {
+		return errors.NotValidf("batch size %d", p.BatchSize)
+	}
+	if p.BatchInterval < 0 {
+		return errors.NotValidf("negative batch interval")
+	}
+	return nil
+}
+
 // GenerationUnits indicates which units from an application are and are not
 // tracking a model branch.
 type GenerationUnits struct {
@@ -56,6 +86,24 @@ type GenerationApplication struct {
 	// TODO (manadart 2018-02-22) This data-type will evolve as more aspects
 	// of the application are made generational.
 	ConfigChanges map[string]interface{} `yaml:"config"`
+
+	// CharmURL is the URL of the charm that the application has been
+	// upgraded to in this generation.
+	CharmURL string `yaml:"charm,omitempty"`
+
+	// Resources maps resource names to the IDs of the pending resources
+	// staged in this generation.
+	Resources map[string]string `yaml:"resources,omitempty"`
+
+	// Constraints is the application constraints set in this generation.
+	Constraints *string `yaml:"constraints,omitempty"`
+
+	// Scale is the desired application scale set in this generation.
+	Scale *int `yaml:"scale,omitempty"`
+
+	// Bindings maps endpoint names to the names of the spaces that they
+	// are bound to in this generation.
+	Bindings map[string]string `yaml:"bindings,omitempty"`
 }
 
 // Generation represents detail of a model generation including config changes.
@@ -69,6 +117,36 @@ type Generation struct {
 	// Applications is a collection of applications with changes in this
 	// generation including advanced units and modified configuration.
 	Applications []GenerationApplication `yaml:"applications"`
+
+	// Rollout, if set, is the progress of the rollout
+	// that will commit the generation.
+	Rollout *GenerationRollout `yaml:"rollout,omitempty"`
+}
+
+// GenerationRollout represents the progress of a branch rollout.
+type GenerationRollout struct {
+	// BatchSize is the maximum number of units
+	// set to track the branch in each batch.
+	BatchSize int `yaml:"batch-size"`
+
+	// BatchInterval is the time waited between batches.
+	BatchInterval string `yaml:"batch-interval"`
+
+	// HaltOnError indicates that the rollout stops if any unit
+	// tracking the branch has a workload status of "error".
+	HaltOnError bool `yaml:"halt-on-error,omitempty"`
+
+	// Started is the formatted time at which the rollout started.
+	Started string `yaml:"started"`
+
+	// StartedBy is the user who started the rollout.
+	StartedBy string `yaml:"started-by"`
+
+	// Batches is the number of batches rolled out so far.
+	Batches int `yaml:"batches"`
+
+	// Halted, if set, is the reason that the rollout was stopped.
+	Halted string `yaml:"halted,omitempty"`
 }
 
 // GenerationCommit represents a model generation's commit details.
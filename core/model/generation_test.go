@@ -0,0 +1,40 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/model"
+)
+
+type generationSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&generationSuite{})
+
+func (*generationSuite) TestRolloutPolicyValidate(c *gc.C) {
+	for _, t := range []struct {
+		policy model.RolloutPolicy
+		err    string
+	}{
+		{model.RolloutPolicy{BatchSize: 1}, ""},
+		{model.RolloutPolicy{BatchSize: 5, BatchInterval: time.Minute, HaltOnError: true}, ""},
+		{model.RolloutPolicy{}, "batch size 0 not valid"},
+		{model.RolloutPolicy{BatchSize: -1}, "batch size -1 not valid"},
+		{model.RolloutPolicy{BatchSize: 1, BatchInterval: -time.Second}, "negative batch interval not valid"},
+	} {
+		err := t.policy.Validate()
+		if t.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, t.err)
+		}
+	}
+}
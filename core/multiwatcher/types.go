@@ -396,6 +396,8 @@ type BranchInfo struct {
 	Name          string
 	AssignedUnits map[string][]string
 	Config        map[string][]ItemChange
+	CharmURLs     map[string]string
+	Resources     map[string]map[string]string
 	Created       int64
 	CreatedBy     string
 	Completed     int64
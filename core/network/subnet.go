@@ -9,6 +9,10 @@ import (
 	"github.com/juju/errors"
 )
 
+// AllNetworksIPv4CIDR is the CIDR matching any IPv4 address.
+// Ports exposed to it are accessible from anywhere.
+const AllNetworksIPv4CIDR = "0.0.0.0/0"
+
 // FanCIDRs describes the subnets relevant to a fan network.
 type FanCIDRs struct {
 	// FanLocalUnderlay is the CIDR of the local underlying fan network.
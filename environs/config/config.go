@@ -25,6 +25,7 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/logfwd/httpjson"
 	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/network"
 )
@@ -189,6 +190,33 @@ const (
 	// forwarding.
 	LogFwdSyslogClientKey = "syslog-client-key"
 
+	// LogFwdHTTPEnabled determines whether log records are forwarded
+	// to an HTTP endpoint.
+	LogFwdHTTPEnabled = "logforward-http-enabled"
+
+	// LogFwdHTTPURL sets the URL to which log records are posted.
+	LogFwdHTTPURL = "logforward-http-url"
+
+	// LogFwdHTTPUsername sets the username with which log records
+	// are posted, using basic authentication.
+	LogFwdHTTPUsername = "logforward-http-username"
+
+	// LogFwdHTTPPassword sets the password with which log records
+	// are posted, using basic authentication.
+	LogFwdHTTPPassword = "logforward-http-password"
+
+	// LogFwdHTTPFormat sets the format in which log records are
+	// posted; one of json-lines, loki or elasticsearch.
+	LogFwdHTTPFormat = "logforward-http-format"
+
+	// LogFwdHTTPCACert sets the certificate of the CA that signed the
+	// HTTP endpoint's certificate.
+	LogFwdHTTPCACert = "logforward-http-ca-cert"
+
+	// LogFwdHTTPBatchSize sets the maximum number of log records
+	// posted in a single request.
+	LogFwdHTTPBatchSize = "logforward-http-batch-size"
+
 	// AutomaticallyRetryHooks determines whether the uniter will
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
@@ -608,6 +636,12 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if lfCfg, ok := cfg.LogFwdHTTP(); ok {
+		if err := lfCfg.Validate(); err != nil {
+			return errors.Annotate(err, "invalid HTTP log forwarding config")
+		}
+	}
+
 	if uuid := cfg.UUID(); !utils.IsValidUUIDString(uuid) {
 		return errors.Errorf("uuid: expected UUID, got string(%q)", uuid)
 	}
@@ -1111,6 +1145,52 @@ func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 	return &lfCfg, true
 }
 
+// LogFwdHTTP returns the HTTP log forwarding config.
+func (c *Config) LogFwdHTTP() (*httpjson.RawConfig, bool) {
+	partial := false
+	var lfCfg httpjson.RawConfig
+
+	if s, ok := c.defined[LogFwdHTTPEnabled]; ok {
+		partial = true
+		lfCfg.Enabled = s.(bool)
+	}
+
+	if s, ok := c.defined[LogFwdHTTPURL]; ok && s != "" {
+		partial = true
+		lfCfg.URL = s.(string)
+	}
+
+	if s, ok := c.defined[LogFwdHTTPUsername]; ok && s != "" {
+		partial = true
+		lfCfg.Username = s.(string)
+	}
+
+	if s, ok := c.defined[LogFwdHTTPPassword]; ok && s != "" {
+		partial = true
+		lfCfg.Password = s.(string)
+	}
+
+	if s, ok := c.defined[LogFwdHTTPFormat]; ok && s != "" {
+		partial = true
+		lfCfg.Format = s.(string)
+	}
+
+	if s, ok := c.defined[LogFwdHTTPCACert]; ok && s != "" {
+		partial = true
+		lfCfg.CACert = s.(string)
+	}
+
+	if s, ok := c.defined[LogFwdHTTPBatchSize]; ok {
+		partial = true
+		lfCfg.BatchSize = s.(int)
+	}
+
+	if !partial {
+		return nil, false
+	}
+	return &lfCfg, true
+}
+
 // FirewallMode returns whether the firewall should
 // manage ports per machine, globally, or not at all.
 // (FwInstance, FwGlobal, or FwNone).
@@ -1477,6 +1557,13 @@ var alwaysOptional = schema.Defaults{
 	LogFwdSyslogCACert:     schema.Omit,
 	LogFwdSyslogClientCert: schema.Omit,
 	LogFwdSyslogClientKey:  schema.Omit,
+	LogFwdHTTPEnabled:      schema.Omit,
+	LogFwdHTTPURL:          schema.Omit,
+	LogFwdHTTPUsername:     schema.Omit,
+	LogFwdHTTPPassword:     schema.Omit,
+	LogFwdHTTPFormat:       schema.Omit,
+	LogFwdHTTPCACert:       schema.Omit,
+	LogFwdHTTPBatchSize:    schema.Omit,
 
 	// Storage related config.
 	// Environ providers will specify their own defaults.
@@ -1925,6 +2012,42 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	LogFwdHTTPEnabled: {
+		Description: `Whether log forwarding to an HTTP endpoint is enabled.`,
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPURL: {
+		Description: `The URL to which forwarded log records are posted.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPUsername: {
+		Description: `The username with which forwarded log records are posted, using basic authentication.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPPassword: {
+		Description: `The password with which forwarded log records are posted, using basic authentication.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+		Secret:      true,
+	},
+	LogFwdHTTPFormat: {
+		Description: `The format in which forwarded log records are posted: json-lines (default), loki or elasticsearch.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPCACert: {
+		Description: `The certificate of the CA that signed the HTTP log forwarding endpoint's certificate, in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPBatchSize: {
+		Description: `The maximum number of log records posted to the HTTP log forwarding endpoint in a single request (default 100).`,
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
 	"ssl-hostname-verification": {
 		Description: "Whether SSL hostname verification is enabled (default true)",
 		Type:        environschema.Tbool,
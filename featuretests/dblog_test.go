@@ -233,6 +233,7 @@ func (s *debugLogDbSuite1) TestLogsAPI(c *gc.C) {
 
 	// Read the 2 lines that are in the logs collection.
 	assertMessage(common.LogMessage{
+		ModelUUID: s.State.ModelUUID(),
 		Entity:    "not-a-tag",
 		Timestamp: t,
 		Severity:  "INFO",
@@ -241,6 +242,7 @@ func (s *debugLogDbSuite1) TestLogsAPI(c *gc.C) {
 		Message:   "all is well",
 	})
 	assertMessage(common.LogMessage{
+		ModelUUID: s.State.ModelUUID(),
 		Entity:    "not-a-tag",
 		Timestamp: t.Add(time.Second),
 		Severity:  "ERROR",
@@ -260,6 +262,7 @@ func (s *debugLogDbSuite1) TestLogsAPI(c *gc.C) {
 		Message:  "beep beep",
 	}})
 	assertMessage(common.LogMessage{
+		ModelUUID: s.State.ModelUUID(),
 		Entity:    "not-a-tag",
 		Timestamp: t.Add(2 * time.Second),
 		Severity:  "WARNING",
@@ -337,6 +340,7 @@ func (s *debugLogDbSuite2) TestLogsUsesStartTime(c *gc.C) {
 		}
 	}
 	assertMessage(common.LogMessage{
+		ModelUUID: s.State.ModelUUID(),
 		Entity:    "not-a-tag",
 		Timestamp: t3,
 		Severity:  "ERROR",
@@ -345,6 +349,7 @@ func (s *debugLogDbSuite2) TestLogsUsesStartTime(c *gc.C) {
 		Message:   "born ruffians",
 	})
 	assertMessage(common.LogMessage{
+		ModelUUID: s.State.ModelUUID(),
 		Entity:    "not-a-tag",
 		Timestamp: t4,
 		Severity:  "WARNING",
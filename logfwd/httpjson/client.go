@@ -0,0 +1,339 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/retry"
+
+	"github.com/juju/juju/logfwd"
+)
+
+const (
+	// sendAttempts is the number of times a batch of records is
+	// posted before giving up.
+	sendAttempts = 5
+
+	// sendDelay and sendMaxDelay bound the backoff between attempts.
+	sendDelay    = time.Second
+	sendMaxDelay = 30 * time.Second
+
+	// requestTimeout bounds the time taken by a single request.
+	requestTimeout = 30 * time.Second
+)
+
+// HTTPClient exposes the underlying functionality needed by Client.
+type HTTPClient interface {
+	// Do sends the HTTP request and returns its response.
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Client posts log records to an HTTP endpoint as JSON.
+type Client struct {
+	// HTTPClient is the HTTP client this client wraps.
+	HTTPClient HTTPClient
+
+	// Stop, if not nil, is closed to give up on posting records
+	// that are waiting to be retried.
+	Stop <-chan struct{}
+
+	cfg   RawConfig
+	clock clock.Clock
+}
+
+// Open returns a new client that posts records to the endpoint
+// identified by the config.
+func Open(cfg RawConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	tlsCfg, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "constructing TLS config")
+	}
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			Proxy:               http.ProxyFromEnvironment,
+			TLSClientConfig:     tlsCfg,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+		Timeout: requestTimeout,
+	}
+	client, err := OpenForClient(cfg, httpClient, clock.WallClock)
+	return client, errors.Trace(err)
+}
+
+// OpenForClient returns a new client that uses the given HTTP client
+// to post records to the endpoint identified by the config, using the
+// clock to back off between failed attempts.
+func OpenForClient(cfg RawConfig, httpClient HTTPClient, clock clock.Clock) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Client{
+		HTTPClient: httpClient,
+		cfg:        cfg,
+		clock:      clock,
+	}, nil
+}
+
+// Close closes any idle connections held by the client.
+func (client *Client) Close() error {
+	if c, ok := client.HTTPClient.(*http.Client); ok {
+		if t, ok := c.Transport.(*http.Transport); ok {
+			t.CloseIdleConnections()
+		}
+	}
+	return nil
+}
+
+// Send posts the records to the endpoint, in batches no larger than
+// the configured batch size. A batch that can't be posted because of
+// a network failure, or because the endpoint is overloaded or failing,
+// is retried with an increasing delay between attempts.
+func (client *Client) Send(records []logfwd.Record) error {
+	batchSize := client.cfg.batchSize()
+	for len(records) > 0 {
+		n := batchSize
+		if n > len(records) {
+			n = len(records)
+		}
+		if err := client.sendBatch(records[:n]); err != nil {
+			return errors.Trace(err)
+		}
+		records = records[n:]
+	}
+	return nil
+}
+
+func (client *Client) sendBatch(records []logfwd.Record) error {
+	body, contentType, err := encodeRecords(client.cfg.format(), records)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = retry.Call(retry.CallArgs{
+		Func: func() error {
+			return client.post(body, contentType)
+		},
+		IsFatalError: func(err error) bool {
+			_, retryable := errors.Cause(err).(*retryableError)
+			return !retryable
+		},
+		Attempts:    sendAttempts,
+		Delay:       sendDelay,
+		MaxDelay:    sendMaxDelay,
+		BackoffFunc: retry.DoubleDelay,
+		Stop:        client.Stop,
+		Clock:       client.clock,
+	})
+	if retry.IsAttemptsExceeded(err) {
+		err = retry.LastError(err)
+	}
+	return errors.Annotatef(err, "posting %d log records", len(records))
+}
+
+// retryableError is returned for failed requests that are worth
+// trying again.
+type retryableError struct {
+	error
+}
+
+func (client *Client) post(body []byte, contentType string) error {
+	req, err := http.NewRequest("POST", client.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	if client.cfg.Username != "" {
+		req.SetBasicAuth(client.cfg.Username, client.cfg.Password)
+	}
+	resp, err := client.HTTPClient.Do(req)
+	if err != nil {
+		return &retryableError{err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		err := errors.Errorf("%s: %s", resp.Status, readErrorBody(resp.Body))
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			return &retryableError{err}
+		}
+		return err
+	}
+	if client.cfg.format() == FormatElasticsearch {
+		return errors.Trace(checkBulkResponse(resp.Body))
+	}
+	return nil
+}
+
+// readErrorBody returns the start of the body of a failed response,
+// for inclusion in an error message.
+func readErrorBody(r io.Reader) string {
+	data, _ := ioutil.ReadAll(io.LimitReader(r, 512))
+	return strings.TrimSpace(string(data))
+}
+
+// checkBulkResponse returns an error if any of the actions in an
+// Elasticsearch bulk request failed; the bulk API reports failures
+// in the body of a successful response.
+func checkBulkResponse(r io.Reader) error {
+	var resp struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			Status int `json:"status"`
+			Error  *struct {
+				Type   string `json:"type"`
+				Reason string `json:"reason"`
+			} `json:"error"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(r).Decode(&resp); err != nil {
+		return errors.Annotate(err, "decoding bulk response")
+	}
+	if !resp.Errors {
+		return nil
+	}
+	for _, item := range resp.Items {
+		for _, result := range item {
+			if result.Error != nil {
+				return errors.Errorf("indexing log record: %s: %s", result.Error.Type, result.Error.Reason)
+			}
+		}
+	}
+	return errors.New("indexing log records failed")
+}
+
+// document is the JSON representation of a log record.
+type document struct {
+	ID             int64             `json:"id"`
+	Timestamp      time.Time         `json:"timestamp"`
+	Level          string            `json:"level"`
+	ControllerUUID string            `json:"controller-uuid"`
+	ModelUUID      string            `json:"model-uuid"`
+	OriginType     string            `json:"origin-type"`
+	OriginName     string            `json:"origin-name,omitempty"`
+	Hostname       string            `json:"hostname,omitempty"`
+	Software       string            `json:"software,omitempty"`
+	Version        string            `json:"version,omitempty"`
+	Module         string            `json:"module,omitempty"`
+	Location       string            `json:"location,omitempty"`
+	Message        string            `json:"message"`
+	Fields         map[string]string `json:"fields,omitempty"`
+}
+
+func newDocument(rec logfwd.Record) document {
+	doc := document{
+		ID:             rec.ID,
+		Timestamp:      rec.Timestamp.UTC(),
+		Level:          rec.Level.String(),
+		ControllerUUID: rec.Origin.ControllerUUID,
+		ModelUUID:      rec.Origin.ModelUUID,
+		OriginType:     rec.Origin.Type.String(),
+		OriginName:     rec.Origin.Name,
+		Hostname:       rec.Origin.Hostname,
+		Software:       rec.Origin.Software.Name,
+		Module:         rec.Location.Module,
+		Location:       rec.Location.String(),
+		Message:        rec.Message,
+		Fields:         rec.Fields,
+	}
+	if doc.Software != "" {
+		doc.Version = rec.Origin.Software.Version.String()
+	}
+	return doc
+}
+
+// encodeRecords returns the body of a request posting the records in
+// the given format, along with its content type.
+func encodeRecords(format string, records []logfwd.Record) ([]byte, string, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	switch format {
+	case FormatJSONLines:
+		for _, rec := range records {
+			if err := enc.Encode(newDocument(rec)); err != nil {
+				return nil, "", errors.Trace(err)
+			}
+		}
+		return buf.Bytes(), "application/x-ndjson", nil
+	case FormatElasticsearch:
+		for _, rec := range records {
+			if _, err := buf.WriteString(`{"index":{}}` + "\n"); err != nil {
+				return nil, "", errors.Trace(err)
+			}
+			if err := enc.Encode(newDocument(rec)); err != nil {
+				return nil, "", errors.Trace(err)
+			}
+		}
+		return buf.Bytes(), "application/x-ndjson", nil
+	case FormatLoki:
+		push, err := newLokiPush(records)
+		if err != nil {
+			return nil, "", errors.Trace(err)
+		}
+		if err := enc.Encode(push); err != nil {
+			return nil, "", errors.Trace(err)
+		}
+		return buf.Bytes(), "application/json", nil
+	}
+	return nil, "", errors.NotValidf("format %q", format)
+}
+
+// lokiPush is the body of a request to Loki's push API.
+type lokiPush struct {
+	Streams []*lokiStream `json:"streams"`
+}
+
+// lokiStream holds the entries for a set of labels, each entry being
+// a timestamp in nanoseconds and a line.
+type lokiStream struct {
+	Labels map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// newLokiPush groups the records into streams labelled by controller,
+// model, origin and level. The records' JSON documents are used as the
+// lines, so that they can be parsed by Loki's json stage.
+func newLokiPush(records []logfwd.Record) (*lokiPush, error) {
+	var push lokiPush
+	streams := make(map[string]*lokiStream)
+	for _, rec := range records {
+		labels := map[string]string{
+			"controller_uuid": rec.Origin.ControllerUUID,
+			"model_uuid":      rec.Origin.ModelUUID,
+			"origin_type":     rec.Origin.Type.String(),
+			"origin_name":     rec.Origin.Name,
+			"level":           strings.ToLower(rec.Level.String()),
+		}
+		key := fmt.Sprintf("%s/%s/%s/%s/%s",
+			labels["controller_uuid"], labels["model_uuid"],
+			labels["origin_type"], labels["origin_name"], labels["level"])
+		stream, ok := streams[key]
+		if !ok {
+			stream = &lokiStream{Labels: labels}
+			streams[key] = stream
+			push.Streams = append(push.Streams, stream)
+		}
+		line, err := json.Marshal(newDocument(rec))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		stream.Values = append(stream.Values, [2]string{
+			strconv.FormatInt(rec.Timestamp.UnixNano(), 10),
+			string(line),
+		})
+	}
+	return &push, nil
+}
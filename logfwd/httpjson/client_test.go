@@ -0,0 +1,291 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/httpjson"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+
+	mu        sync.Mutex
+	requests  []request
+	responses []response
+	server    *httptest.Server
+	clock     *testclock.Clock
+	rec       logfwd.Record
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+type request struct {
+	contentType string
+	user        string
+	body        string
+}
+
+type response struct {
+	status int
+	body   string
+}
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.requests = nil
+	s.responses = nil
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+	s.clock = testclock.NewClock(time.Time{})
+	s.rec = logfwd.Record{
+		ID: 10,
+		Origin: logfwd.Origin{
+			ControllerUUID: "feebdaed-2f18-4fd2-967d-db9663db7bea",
+			ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+			Hostname:       "machine-99.deadbeef-2f18-4fd2-967d-db9663db7bea",
+			Type:           logfwd.OriginTypeMachine,
+			Name:           "99",
+			Software: logfwd.Software{
+				PrivateEnterpriseNumber: 28978,
+				Name:                    "jujud-machine-agent",
+				Version:                 version.MustParse("2.8.0"),
+			},
+		},
+		Timestamp: time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC),
+		Level:     loggo.ERROR,
+		Location: logfwd.SourceLocation{
+			Module:   "juju.worker.uniter",
+			Filename: "uniter.go",
+			Line:     42,
+		},
+		Message: "hook failed",
+	}
+}
+
+func (s *ClientSuite) handle(w http.ResponseWriter, req *http.Request) {
+	body, _ := ioutil.ReadAll(req.Body)
+	user, _, _ := req.BasicAuth()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, request{
+		contentType: req.Header.Get("Content-Type"),
+		user:        user,
+		body:        string(body),
+	})
+	resp := response{status: http.StatusOK}
+	if len(s.responses) > 0 {
+		resp, s.responses = s.responses[0], s.responses[1:]
+	}
+	w.WriteHeader(resp.status)
+	w.Write([]byte(resp.body))
+}
+
+func (s *ClientSuite) open(c *gc.C, cfg httpjson.RawConfig) *httpjson.Client {
+	if cfg.URL == "" {
+		cfg.URL = s.server.URL
+	}
+	cfg.Enabled = true
+	client, err := httpjson.OpenForClient(cfg, s.server.Client(), s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	return client
+}
+
+const recordDocument = `{"id":10,"timestamp":"2020-01-02T03:04:05.000000006Z","level":"ERROR",` +
+	`"controller-uuid":"feebdaed-2f18-4fd2-967d-db9663db7bea","model-uuid":"deadbeef-2f18-4fd2-967d-db9663db7bea",` +
+	`"origin-type":"machine","origin-name":"99","hostname":"machine-99.deadbeef-2f18-4fd2-967d-db9663db7bea",` +
+	`"software":"jujud-machine-agent","version":"2.8.0","module":"juju.worker.uniter","location":"uniter.go:42",` +
+	`"message":"hook failed"}`
+
+func (s *ClientSuite) TestSendJSONLines(c *gc.C) {
+	client := s.open(c, httpjson.RawConfig{})
+
+	err := client.Send([]logfwd.Record{s.rec, s.rec})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, jc.DeepEquals, []request{{
+		contentType: "application/x-ndjson",
+		body:        recordDocument + "\n" + recordDocument + "\n",
+	}})
+}
+
+func (s *ClientSuite) TestSendFields(c *gc.C) {
+	client := s.open(c, httpjson.RawConfig{})
+	s.rec.Fields = map[string]string{"unit": "mysql/0", "hook": "install"}
+
+	err := client.Send([]logfwd.Record{s.rec})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 1)
+	c.Assert(s.requests[0].body, gc.Equals,
+		recordDocument[:len(recordDocument)-1]+`,"fields":{"hook":"install","unit":"mysql/0"}}`+"\n")
+}
+
+func (s *ClientSuite) TestSendElasticsearch(c *gc.C) {
+	s.responses = []response{{status: http.StatusOK, body: `{"took":3,"errors":false,"items":[]}`}}
+	client := s.open(c, httpjson.RawConfig{Format: httpjson.FormatElasticsearch})
+
+	err := client.Send([]logfwd.Record{s.rec})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, jc.DeepEquals, []request{{
+		contentType: "application/x-ndjson",
+		body:        `{"index":{}}` + "\n" + recordDocument + "\n",
+	}})
+}
+
+func (s *ClientSuite) TestSendElasticsearchItemError(c *gc.C) {
+	s.responses = []response{{status: http.StatusOK, body: `{"took":3,"errors":true,"items":[` +
+		`{"index":{"status":201}},` +
+		`{"index":{"status":400,"error":{"type":"mapper_parsing_exception","reason":"failed to parse"}}}]}`}}
+	client := s.open(c, httpjson.RawConfig{Format: httpjson.FormatElasticsearch})
+
+	err := client.Send([]logfwd.Record{s.rec, s.rec})
+	c.Assert(err, gc.ErrorMatches, `posting 2 log records: indexing log record: mapper_parsing_exception: failed to parse`)
+	c.Assert(s.requests, gc.HasLen, 1)
+}
+
+func (s *ClientSuite) TestSendLoki(c *gc.C) {
+	client := s.open(c, httpjson.RawConfig{Format: httpjson.FormatLoki})
+	rec2 := s.rec
+	rec2.Origin.ModelUUID = "cafef00d-2f18-4fd2-967d-db9663db7bea"
+
+	err := client.Send([]logfwd.Record{s.rec, rec2, s.rec})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 1)
+	c.Check(s.requests[0].contentType, gc.Equals, "application/json")
+	var push struct {
+		Streams []struct {
+			Stream map[string]string `json:"stream"`
+			Values [][]string        `json:"values"`
+		} `json:"streams"`
+	}
+	err = json.Unmarshal([]byte(s.requests[0].body), &push)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(push.Streams, gc.HasLen, 2)
+	c.Check(push.Streams[0].Stream, jc.DeepEquals, map[string]string{
+		"controller_uuid": "feebdaed-2f18-4fd2-967d-db9663db7bea",
+		"model_uuid":      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		"origin_type":     "machine",
+		"origin_name":     "99",
+		"level":           "error",
+	})
+	c.Check(push.Streams[0].Values, jc.DeepEquals, [][]string{
+		{"1577934245000000006", recordDocument},
+		{"1577934245000000006", recordDocument},
+	})
+	c.Check(push.Streams[1].Stream["model_uuid"], gc.Equals, "cafef00d-2f18-4fd2-967d-db9663db7bea")
+	c.Check(push.Streams[1].Values, gc.HasLen, 1)
+}
+
+func (s *ClientSuite) TestSendBatches(c *gc.C) {
+	client := s.open(c, httpjson.RawConfig{BatchSize: 2})
+
+	err := client.Send([]logfwd.Record{s.rec, s.rec, s.rec})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 2)
+	c.Check(strings.Count(s.requests[0].body, "\n"), gc.Equals, 2)
+	c.Check(strings.Count(s.requests[1].body, "\n"), gc.Equals, 1)
+}
+
+func (s *ClientSuite) TestSendBasicAuth(c *gc.C) {
+	client := s.open(c, httpjson.RawConfig{
+		Username: "bob",
+		Password: "secret",
+	})
+
+	err := client.Send([]logfwd.Record{s.rec})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 1)
+	c.Check(s.requests[0].user, gc.Equals, "bob")
+}
+
+func (s *ClientSuite) TestSendRetries(c *gc.C) {
+	s.responses = []response{
+		{status: http.StatusServiceUnavailable, body: "busy"},
+		{status: http.StatusTooManyRequests, body: "slow down"},
+	}
+	client := s.open(c, httpjson.RawConfig{})
+
+	done := make(chan error)
+	go func() {
+		done <- client.Send([]logfwd.Record{s.rec})
+	}()
+	for i := 0; i < 2; i++ {
+		err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	select {
+	case err := <-done:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for send")
+	}
+	c.Assert(s.requests, gc.HasLen, 3)
+}
+
+func (s *ClientSuite) TestSendGivesUp(c *gc.C) {
+	for i := 0; i < 5; i++ {
+		s.responses = append(s.responses, response{status: http.StatusInternalServerError, body: "oops"})
+	}
+	client := s.open(c, httpjson.RawConfig{})
+
+	done := make(chan error)
+	go func() {
+		done <- client.Send([]logfwd.Record{s.rec})
+	}()
+	for i := 0; i < 4; i++ {
+		err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	select {
+	case err := <-done:
+		c.Assert(err, gc.ErrorMatches, `posting 1 log records: 500 Internal Server Error: oops`)
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for send")
+	}
+	c.Assert(s.requests, gc.HasLen, 5)
+}
+
+func (s *ClientSuite) TestSendStops(c *gc.C) {
+	s.responses = []response{{status: http.StatusServiceUnavailable, body: "busy"}}
+	client := s.open(c, httpjson.RawConfig{})
+	stop := make(chan struct{})
+	client.Stop = stop
+	close(stop)
+
+	// The failed post is not retried once the client is stopped.
+	err := client.Send([]logfwd.Record{s.rec})
+	c.Assert(err, gc.ErrorMatches, `posting 1 log records: retry stopped`)
+	c.Assert(s.requests, gc.HasLen, 1)
+}
+
+func (s *ClientSuite) TestSendClientErrorNotRetried(c *gc.C) {
+	s.responses = []response{{status: http.StatusBadRequest, body: "entry out of order"}}
+	client := s.open(c, httpjson.RawConfig{})
+
+	err := client.Send([]logfwd.Record{s.rec})
+	c.Assert(err, gc.ErrorMatches, `posting 1 log records: 400 Bad Request: entry out of order`)
+	c.Assert(s.requests, gc.HasLen, 1)
+}
@@ -0,0 +1,143 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/cert"
+)
+
+// These are the formats in which records may be posted.
+const (
+	// FormatJSONLines posts each record as a JSON document on its
+	// own line.
+	FormatJSONLines = "json-lines"
+
+	// FormatLoki posts the records to Loki's push API, as streams
+	// labelled with the controller, model and origin of the records.
+	FormatLoki = "loki"
+
+	// FormatElasticsearch posts the records to Elasticsearch's bulk
+	// API, with an index action for each record. The index is taken
+	// from the URL, e.g. https://es.example.com:9200/juju/_bulk.
+	FormatElasticsearch = "elasticsearch"
+)
+
+// DefaultBatchSize is the maximum number of records posted in a single
+// request, if not configured.
+const DefaultBatchSize = 100
+
+// RawConfig holds the raw configuration data for posting log records
+// to an HTTP endpoint.
+type RawConfig struct {
+	// Enabled is true if forwarding to the endpoint is enabled.
+	Enabled bool
+
+	// URL is the http or https URL to which records are posted. It
+	// may not hold credentials; they are set with Username and
+	// Password.
+	URL string
+
+	// Username and Password, if set, are used for basic
+	// authentication.
+	Username string
+	Password string
+
+	// Format is the format in which records are posted. If empty,
+	// FormatJSONLines is used.
+	Format string
+
+	// CACert is the TLS CA certificate (x.509, PEM-encoded) to use
+	// for validating the server certificate. If empty, the system
+	// certificates are used.
+	CACert string
+
+	// BatchSize is the maximum number of records posted in a single
+	// request. If zero, DefaultBatchSize is used.
+	BatchSize int
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg RawConfig) Validate() error {
+	if err := cfg.validateURL(); err != nil {
+		return errors.Trace(err)
+	}
+	if cfg.Password != "" && cfg.Username == "" {
+		return errors.NotValidf("password without username")
+	}
+	switch cfg.Format {
+	case "", FormatJSONLines, FormatLoki, FormatElasticsearch:
+	default:
+		return errors.NotValidf("format %q", cfg.Format)
+	}
+	if cfg.CACert != "" {
+		if _, err := cfg.tlsConfig(); err != nil {
+			return errors.Annotate(err, "validating TLS config")
+		}
+	}
+	if cfg.BatchSize < 0 {
+		return errors.NotValidf("negative batch size")
+	}
+	return nil
+}
+
+func (cfg RawConfig) validateURL() error {
+	if cfg.URL == "" {
+		if cfg.Enabled {
+			return errors.NotValidf("empty URL")
+		}
+		return nil
+	}
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return errors.NotValidf("URL %q", cfg.URL)
+	}
+	if u.User != nil {
+		// The URL is not echoed, so that the credentials
+		// in it do not end up in logs.
+		return errors.NewNotValid(nil, "URL with user info not valid; set a username and password instead")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.NotValidf("URL scheme %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return errors.NotValidf("URL %q with no host", cfg.URL)
+	}
+	return nil
+}
+
+func (cfg RawConfig) format() string {
+	if cfg.Format == "" {
+		return FormatJSONLines
+	}
+	return cfg.Format
+}
+
+func (cfg RawConfig) batchSize() int {
+	if cfg.BatchSize == 0 {
+		return DefaultBatchSize
+	}
+	return cfg.BatchSize
+}
+
+// tlsConfig returns the TLS config to use when posting records, or
+// nil if the system certificates should be used.
+func (cfg RawConfig) tlsConfig() (*tls.Config, error) {
+	if cfg.CACert == "" {
+		return nil, nil
+	}
+	caCert, err := cert.ParseCert(cfg.CACert)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing CA certificate")
+	}
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(caCert)
+	return &tls.Config{
+		RootCAs: rootCAs,
+	}, nil
+}
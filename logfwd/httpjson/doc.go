@@ -0,0 +1,7 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The httpjson package holds the tools needed to perform log forwarding
+// from Juju to a remote HTTP endpoint accepting JSON documents, such as
+// Loki's push API or Elasticsearch's bulk API.
+package httpjson
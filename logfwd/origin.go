@@ -24,6 +24,7 @@ const (
 	OriginTypeUser               = iota
 	OriginTypeMachine
 	OriginTypeUnit
+	OriginTypeAudit
 )
 
 var originTypes = map[OriginType]string{
@@ -31,6 +32,7 @@ var originTypes = map[OriginType]string{
 	OriginTypeUser:    names.UserTagKind,
 	OriginTypeMachine: names.MachineTagKind,
 	OriginTypeUnit:    names.UnitTagKind,
+	OriginTypeAudit:   "audit",
 }
 
 // OriginType is the "enum" type for the different kinds of log record
@@ -85,6 +87,11 @@ func (ot OriginType) ValidateName(name string) error {
 		if !names.IsValidUnit(name) {
 			return errors.NewNotValid(nil, "bad unit name")
 		}
+	case OriginTypeAudit:
+		// Audit records are named for the user who made the request.
+		if !names.IsValidUser(name) {
+			return errors.NewNotValid(nil, "bad user name")
+		}
 	}
 	return nil
 }
@@ -138,6 +145,14 @@ func OriginForJuju(tag names.Tag, controller, model string, ver version.Number)
 	return originForJuju(oType, tag.Id(), controller, model, ver), nil
 }
 
+// OriginForAudit populates a new origin for a record from the
+// controller's audit log, made on behalf of the given user.
+func OriginForAudit(tag names.UserTag, controller, model string, ver version.Number) Origin {
+	origin := originForJuju(OriginTypeAudit, tag.Id(), controller, model, ver)
+	origin.Software.Name = "juju-audit-log"
+	return origin
+}
+
 func originForJuju(oType OriginType, name, controller, model string, ver version.Number) Origin {
 	return Origin{
 		ControllerUUID: controller,
@@ -78,6 +78,25 @@ func (s *OriginSuite) TestOriginForJuju(c *gc.C) {
 	})
 }
 
+func (s *OriginSuite) TestOriginForAudit(c *gc.C) {
+	tag := names.NewUserTag("bob")
+
+	origin := logfwd.OriginForAudit(tag, validOrigin.ControllerUUID, validOrigin.ModelUUID, validOrigin.Software.Version)
+
+	c.Check(origin, jc.DeepEquals, logfwd.Origin{
+		ControllerUUID: validOrigin.ControllerUUID,
+		ModelUUID:      validOrigin.ModelUUID,
+		Hostname:       "",
+		Type:           logfwd.OriginTypeAudit,
+		Name:           "bob",
+		Software: logfwd.Software{
+			PrivateEnterpriseNumber: 28978,
+			Name:                    "juju-audit-log",
+			Version:                 version.MustParse("2.0.1"),
+		},
+	})
+}
+
 func (s *OriginSuite) TestValidateValid(c *gc.C) {
 	origin := validOrigin
 
@@ -11,6 +11,8 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+
+	"github.com/juju/juju/core/logfields"
 )
 
 // Record holds all the information for a single log record.
@@ -33,6 +35,10 @@ type Record struct {
 
 	// Message is the record's body. It may be empty.
 	Message string
+
+	// Fields holds the structured fields attached to the record, such
+	// as the unit and hook it was logged from. It may be empty.
+	Fields map[string]string
 }
 
 // Validate ensures that the record is correct.
@@ -53,6 +59,10 @@ func (rec Record) Validate() error {
 
 	// rec.Message may be anything, so we don't check it.
 
+	if err := logfields.Fields(rec.Fields).Validate(); err != nil {
+		return errors.Annotate(err, "invalid Fields")
+	}
+
 	return nil
 }
 
@@ -67,6 +67,16 @@ func (s *RecordSuite) TestValidateBadLocation(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `invalid Location: Line set but Filename empty`)
 }
 
+func (s *RecordSuite) TestValidateBadFields(c *gc.C) {
+	rec := validRecord
+	rec.Fields = map[string]string{"Unit": "mysql/0"}
+
+	err := rec.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `invalid Fields: log field name "Unit" not valid`)
+}
+
 type LocationSuite struct {
 	testing.IsolationSuite
 }
@@ -7,6 +7,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"io"
+	"sort"
 	"time"
 
 	"github.com/juju/errors"
@@ -112,6 +113,24 @@ func (client Client) Send(records []logfwd.Record) error {
 	return nil
 }
 
+// fieldParams returns the record's fields as structured data params,
+// sorted by name.
+func fieldParams(fields map[string]string) []rfc5424.StructuredDataParam {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	params := make([]rfc5424.StructuredDataParam, len(names))
+	for i, name := range names {
+		params[i] = rfc5424.StructuredDataParam{
+			Name:  rfc5424.StructuredDataName(name),
+			Value: rfc5424.StructuredDataParamValue(fields[name]),
+		}
+	}
+	return params
+}
+
 func messageFromRecord(rec logfwd.Record) (rfc5424.Message, error) {
 	msg := rfc5424.Message{
 		Header: rfc5424.Header{
@@ -158,6 +177,13 @@ func messageFromRecord(rec logfwd.Record) (rfc5424.Message, error) {
 		},
 		Msg: rec.Message,
 	}
+	if len(rec.Fields) > 0 {
+		msg.StructuredData = append(msg.StructuredData, &sdelements.Private{
+			Name: "fields",
+			PEN:  sdelements.PrivateEnterpriseNumber(rec.Origin.Software.PrivateEnterpriseNumber),
+			Data: fieldParams(rec.Fields),
+		})
+	}
 
 	switch rec.Level {
 	case loggo.ERROR:
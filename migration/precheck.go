@@ -5,6 +5,7 @@ package migration
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/version"
@@ -12,7 +13,9 @@ import (
 	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/core/firewall"
 	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/resource"
@@ -35,6 +38,11 @@ type PrecheckBackend interface {
 	ControllerBackend() (PrecheckBackend, error)
 	CloudCredential(tag names.CloudCredentialTag) (state.Credential, error)
 	ListPendingResources(string) ([]resource.Resource, error)
+	AllFirewallServices() ([]firewall.Service, error)
+	AllVolumes() ([]PrecheckVolume, error)
+	AllVolumeSnapshots() ([]PrecheckVolumeSnapshot, error)
+	AllOperations() ([]PrecheckOperation, error)
+	AllActions() ([]PrecheckAction, error)
 }
 
 // Pool defines the interface to a StatePool used by the migration
@@ -75,6 +83,7 @@ type PrecheckApplication interface {
 	CharmURL() (*charm.URL, bool)
 	AllUnits() ([]PrecheckUnit, error)
 	MinUnits() int
+	ExposedEndpoints() map[string]state.ExposedEndpoint
 }
 
 // PrecheckUnit describes state interface for a unit needed by
@@ -88,6 +97,7 @@ type PrecheckUnit interface {
 	Status() (status.StatusInfo, error)
 	AgentPresence() (bool, error)
 	ShouldBeAssigned() bool
+	OpenedPortEndpoints() (map[network.PortRange]string, error)
 }
 
 // PrecheckRelation describes the state interface for relations needed
@@ -99,6 +109,34 @@ type PrecheckRelation interface {
 	Unit(PrecheckUnit) (PrecheckRelationUnit, error)
 }
 
+// PrecheckVolume describes the state interface for a volume needed
+// by migration prechecks.
+type PrecheckVolume interface {
+	VolumeTag() names.VolumeTag
+	RequestedSize() (uint64, bool)
+}
+
+// PrecheckVolumeSnapshot describes the state interface for a volume
+// snapshot needed by migration prechecks.
+type PrecheckVolumeSnapshot interface {
+	Name() string
+}
+
+// PrecheckOperation describes the state interface for an operation
+// rolling an action out across units needed by migration prechecks.
+type PrecheckOperation interface {
+	Id() string
+	Status() state.ActionStatus
+}
+
+// PrecheckAction describes the state interface for an action needed
+// by migration prechecks.
+type PrecheckAction interface {
+	Id() string
+	Status() state.ActionStatus
+	Timeouts() state.ActionTimeouts
+}
+
 // PrecheckRelationUnit describes the interface for relation units
 // needed for migration prechecks.
 type PrecheckRelationUnit interface {
@@ -138,6 +176,26 @@ func SourcePrecheck(
 		return errors.Trace(err)
 	}
 
+	if err := ctx.checkFirewallServices(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := ctx.checkVolumes(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := ctx.checkVolumeSnapshots(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := ctx.checkOperations(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := ctx.checkActionTimeouts(); err != nil {
+		return errors.Trace(err)
+	}
+
 	if cleanupNeeded, err := backend.NeedsCleanup(); err != nil {
 		return errors.Annotate(err, "checking cleanups")
 	} else if cleanupNeeded {
@@ -184,6 +242,115 @@ func (ctx *precheckContext) checkModel() error {
 	return nil
 }
 
+// checkFirewallServices returns an error if the model has any operator
+// defined firewall services. Their definitions are not part of the
+// model description, so migrating would silently drop them along with
+// the restrictions their rules apply.
+func (ctx *precheckContext) checkFirewallServices() error {
+	services, err := ctx.backend.AllFirewallServices()
+	if err != nil {
+		return errors.Annotate(err, "retrieving firewall services")
+	}
+	if len(services) == 0 {
+		return nil
+	}
+	names := make([]string, len(services))
+	for i, service := range services {
+		names[i] = string(service.Name)
+	}
+	return errors.Errorf("model has firewall services defined (%s); remove them before migrating",
+		strings.Join(names, ", "))
+}
+
+// checkVolumes returns an error if any of the model's volumes has
+// a resize pending. The requested size is not part of the model
+// description, so migrating would silently drop the request.
+func (ctx *precheckContext) checkVolumes() error {
+	volumes, err := ctx.backend.AllVolumes()
+	if err != nil {
+		return errors.Annotate(err, "retrieving volumes")
+	}
+	var resizing []string
+	for _, volume := range volumes {
+		if _, ok := volume.RequestedSize(); ok {
+			resizing = append(resizing, volume.VolumeTag().Id())
+		}
+	}
+	if len(resizing) == 0 {
+		return nil
+	}
+	return errors.Errorf("volumes have resizes pending (%s); wait for them to complete before migrating",
+		strings.Join(resizing, ", "))
+}
+
+// checkVolumeSnapshots returns an error if the model has any volume
+// snapshots. Snapshots are not part of the model description, and
+// the provider's snapshots are not moved to the target cloud.
+func (ctx *precheckContext) checkVolumeSnapshots() error {
+	snapshots, err := ctx.backend.AllVolumeSnapshots()
+	if err != nil {
+		return errors.Annotate(err, "retrieving volume snapshots")
+	}
+	if len(snapshots) == 0 {
+		return nil
+	}
+	names := make([]string, len(snapshots))
+	for i, snapshot := range snapshots {
+		names[i] = snapshot.Name()
+	}
+	return errors.Errorf("model has volume snapshots (%s); remove them before migrating",
+		strings.Join(names, ", "))
+}
+
+// checkOperations returns an error if any operations are running.
+// Operations are not part of the model description, so a running
+// operation would not queue its action for its remaining units once
+// the model has been migrated.
+func (ctx *precheckContext) checkOperations() error {
+	operations, err := ctx.backend.AllOperations()
+	if err != nil {
+		return errors.Annotate(err, "retrieving operations")
+	}
+	var running []string
+	for _, op := range operations {
+		if op.Status() == state.ActionRunning {
+			running = append(running, op.Id())
+		}
+	}
+	if len(running) == 0 {
+		return nil
+	}
+	return errors.Errorf("operations are running (%s); wait for them to finish before migrating",
+		strings.Join(running, ", "))
+}
+
+// checkActionTimeouts returns an error if any pending or running
+// actions have timeouts. Action timeouts are not part of the model
+// description, so such actions would never expire once the model has
+// been migrated.
+func (ctx *precheckContext) checkActionTimeouts() error {
+	actions, err := ctx.backend.AllActions()
+	if err != nil {
+		return errors.Annotate(err, "retrieving actions")
+	}
+	var timed []string
+	for _, action := range actions {
+		switch action.Status() {
+		case state.ActionPending, state.ActionRunning:
+		default:
+			continue
+		}
+		if action.Timeouts() != (state.ActionTimeouts{}) {
+			timed = append(timed, action.Id())
+		}
+	}
+	if len(timed) == 0 {
+		return nil
+	}
+	return errors.Errorf("actions with timeouts are pending or running (%s); wait for them to finish or cancel them before migrating",
+		strings.Join(timed, ", "))
+}
+
 // TargetPrecheck checks the state of the target controller to make
 // sure that the preconditions for model migration are met. The
 // backend provided must be for the target controller.
@@ -347,6 +514,9 @@ func (ctx *precheckContext) checkApplications() (map[string][]PrecheckUnit, erro
 		if app.Life() != state.Alive {
 			return nil, errors.Errorf("application %s is %s", app.Name(), app.Life())
 		}
+		if err := checkExposeSettings(app); err != nil {
+			return nil, errors.Trace(err)
+		}
 		units, err := app.AllUnits()
 		if err != nil {
 			return nil, errors.Annotatef(err, "retrieving units for %s", app.Name())
@@ -360,6 +530,27 @@ func (ctx *precheckContext) checkApplications() (map[string][]PrecheckUnit, erro
 	return appUnits, nil
 }
 
+// checkExposeSettings returns an error if the application is exposed
+// with anything other than the default settings. The per-endpoint
+// settings are not part of the model description, so migrating would
+// expose the application to 0.0.0.0/0 on all of its endpoints.
+func checkExposeSettings(app PrecheckApplication) error {
+	exposedEndpoints := app.ExposedEndpoints()
+	if len(exposedEndpoints) == 0 {
+		return nil
+	}
+	if len(exposedEndpoints) == 1 {
+		exposed, ok := exposedEndpoints[""]
+		if ok && len(exposed.ExposeToSpaceIDs) == 0 &&
+			len(exposed.ExposeToCIDRs) == 1 &&
+			exposed.ExposeToCIDRs[0] == network.AllNetworksIPv4CIDR {
+			return nil
+		}
+	}
+	return errors.Errorf("application %s has expose settings for specific endpoints, spaces or CIDRs; "+
+		"unexpose it or expose it to 0.0.0.0/0 before migrating", app.Name())
+}
+
 func (ctx *precheckContext) checkUnits(app PrecheckApplication, units []PrecheckUnit, modelVersion version.Number, modelType state.ModelType) error {
 	if len(units) < app.MinUnits() {
 		return errors.Errorf("application %s is below its minimum units threshold", app.Name())
@@ -380,6 +571,9 @@ func (ctx *precheckContext) checkUnits(app PrecheckApplication, units []Precheck
 			if err := checkAgentTools(modelVersion, unit, "unit "+unit.Name()); err != nil {
 				return errors.Trace(err)
 			}
+			if err := checkOpenedPortEndpoints(unit); err != nil {
+				return errors.Trace(err)
+			}
 		}
 
 		unitCharmURL, _ := unit.CharmURL()
@@ -390,6 +584,26 @@ func (ctx *precheckContext) checkUnits(app PrecheckApplication, units []Precheck
 	return nil
 }
 
+// checkOpenedPortEndpoints returns an error if the unit has opened
+// ports for a specific endpoint. The endpoints are not part of the
+// model description, so migrating would make those ports accessible
+// from the sources of all exposed endpoints.
+func checkOpenedPortEndpoints(unit PrecheckUnit) error {
+	portEndpoints, err := unit.OpenedPortEndpoints()
+	if errors.IsNotAssigned(err) {
+		return nil
+	} else if err != nil {
+		return errors.Annotatef(err, "retrieving opened ports for unit %s", unit.Name())
+	}
+	for portRange, endpoint := range portEndpoints {
+		if endpoint != "" {
+			return errors.Errorf("unit %s has opened ports %s for endpoint %q; "+
+				"close them or open them for all endpoints before migrating", unit.Name(), portRange, endpoint)
+		}
+	}
+	return nil
+}
+
 func (ctx *precheckContext) checkUnitAgentStatus(unit PrecheckUnit) error {
 	modelPresenceContext := common.ModelPresenceContext{ctx.presence}
 	statusData, _ := modelPresenceContext.UnitStatus(unit)
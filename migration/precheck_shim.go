@@ -7,6 +7,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/version"
 
+	"github.com/juju/juju/core/firewall"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
 )
@@ -105,6 +106,80 @@ func (s *precheckShim) ListPendingResources(app string) ([]resource.Resource, er
 	return resources, errors.Trace(err)
 }
 
+// AllFirewallServices implements PrecheckBackend.
+func (s *precheckShim) AllFirewallServices() ([]firewall.Service, error) {
+	services, err := state.NewFirewallRules(s.State).AllServices()
+	return services, errors.Trace(err)
+}
+
+// AllVolumes implements PrecheckBackend.
+func (s *precheckShim) AllVolumes() ([]PrecheckVolume, error) {
+	sb, err := state.NewStorageBackend(s.State)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumes, err := sb.AllVolumes()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]PrecheckVolume, len(volumes))
+	for i, volume := range volumes {
+		out[i] = volume
+	}
+	return out, nil
+}
+
+// AllVolumeSnapshots implements PrecheckBackend.
+func (s *precheckShim) AllVolumeSnapshots() ([]PrecheckVolumeSnapshot, error) {
+	sb, err := state.NewStorageBackend(s.State)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshots, err := sb.AllVolumeSnapshots()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]PrecheckVolumeSnapshot, len(snapshots))
+	for i, snapshot := range snapshots {
+		out[i] = snapshot
+	}
+	return out, nil
+}
+
+// AllOperations implements PrecheckBackend.
+func (s *precheckShim) AllOperations() ([]PrecheckOperation, error) {
+	model, err := s.State.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	operations, err := model.AllOperations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]PrecheckOperation, len(operations))
+	for i, op := range operations {
+		out[i] = op
+	}
+	return out, nil
+}
+
+// AllActions implements PrecheckBackend.
+func (s *precheckShim) AllActions() ([]PrecheckAction, error) {
+	model, err := s.State.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	actions, err := model.AllActions()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]PrecheckAction, len(actions))
+	for i, action := range actions {
+		out[i] = action
+	}
+	return out, nil
+}
+
 // ControllerBackend implements PrecheckBackend.
 func (s *precheckShim) ControllerBackend() (PrecheckBackend, error) {
 	return PrecheckShim(s.controllerState, s.controllerState)
@@ -4,6 +4,8 @@
 package migration_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version"
@@ -11,7 +13,9 @@ import (
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v3"
 
+	"github.com/juju/juju/core/firewall"
 	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/migration"
@@ -107,6 +111,120 @@ func (*SourcePrecheckSuite) TestCleanupsNeeded(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "cleanup needed")
 }
 
+func (*SourcePrecheckSuite) TestFirewallServicesError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.firewallServicesErr = errors.New("boom")
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "retrieving firewall services: boom")
+}
+
+func (*SourcePrecheckSuite) TestFirewallServicesDefined(c *gc.C) {
+	backend := newFakeBackend()
+	backend.firewallServices = []firewall.Service{{
+		Name:      "postgres",
+		PortRange: network.MustParsePortRange("5432/tcp"),
+	}, {
+		Name:      "memcached",
+		PortRange: network.MustParsePortRange("11211/tcp"),
+	}}
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, `model has firewall services defined \(postgres, memcached\); remove them before migrating`)
+}
+
+func (*SourcePrecheckSuite) TestAllVolumesError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.allVolumesErr = errors.New("boom")
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "retrieving volumes: boom")
+}
+
+func (*SourcePrecheckSuite) TestVolumeResizePending(c *gc.C) {
+	backend := newFakeBackend()
+	backend.volumes = []migration.PrecheckVolume{
+		&fakeVolume{id: "0"},
+		&fakeVolume{id: "1", requestedSize: 2048},
+		&fakeVolume{id: "2", requestedSize: 4096},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, `volumes have resizes pending \(1, 2\); wait for them to complete before migrating`)
+}
+
+func (*SourcePrecheckSuite) TestAllVolumeSnapshotsError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.allVolumeSnapshotsErr = errors.New("boom")
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "retrieving volume snapshots: boom")
+}
+
+func (*SourcePrecheckSuite) TestVolumeSnapshots(c *gc.C) {
+	backend := newFakeBackend()
+	backend.volumeSnapshots = []migration.PrecheckVolumeSnapshot{
+		&fakeVolumeSnapshot{name: "nightly"},
+		&fakeVolumeSnapshot{name: "weekly"},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, `model has volume snapshots \(nightly, weekly\); remove them before migrating`)
+}
+
+func (*SourcePrecheckSuite) TestAllOperationsError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.allOperationsErr = errors.New("boom")
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "retrieving operations: boom")
+}
+
+func (*SourcePrecheckSuite) TestOperationsRunning(c *gc.C) {
+	backend := newFakeBackend()
+	backend.operations = []migration.PrecheckOperation{
+		&fakeOperation{id: "1", status: state.ActionCompleted},
+		&fakeOperation{id: "2", status: state.ActionRunning},
+		&fakeOperation{id: "3", status: state.ActionFailed},
+		&fakeOperation{id: "4", status: state.ActionRunning},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, `operations are running \(2, 4\); wait for them to finish before migrating`)
+}
+
+func (*SourcePrecheckSuite) TestOperationsFinished(c *gc.C) {
+	backend := newFakeBackend()
+	backend.operations = []migration.PrecheckOperation{
+		&fakeOperation{id: "1", status: state.ActionCompleted},
+		&fakeOperation{id: "2", status: state.ActionFailed},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (*SourcePrecheckSuite) TestAllActionsError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.allActionsErr = errors.New("boom")
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "retrieving actions: boom")
+}
+
+func (*SourcePrecheckSuite) TestActionsWithTimeouts(c *gc.C) {
+	backend := newFakeBackend()
+	backend.actions = []migration.PrecheckAction{
+		&fakeAction{id: "1", status: state.ActionPending},
+		&fakeAction{id: "2", status: state.ActionPending, timeouts: state.ActionTimeouts{Queue: time.Minute}},
+		&fakeAction{id: "3", status: state.ActionRunning, timeouts: state.ActionTimeouts{Execution: time.Minute}},
+		&fakeAction{id: "4", status: state.ActionCompleted, timeouts: state.ActionTimeouts{Execution: time.Minute}},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, `actions with timeouts are pending or running \(2, 3\); wait for them to finish or cancel them before migrating`)
+}
+
+func (*SourcePrecheckSuite) TestFinishedActionsWithTimeouts(c *gc.C) {
+	backend := newFakeBackend()
+	backend.actions = []migration.PrecheckAction{
+		&fakeAction{id: "1", status: state.ActionPending},
+		&fakeAction{id: "2", status: state.ActionFailed, timeouts: state.ActionTimeouts{Execution: time.Minute}},
+		&fakeAction{id: "3", status: state.ActionCancelled, timeouts: state.ActionTimeouts{Queue: time.Minute}},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *SourcePrecheckSuite) TestIsUpgradingError(c *gc.C) {
 	backend := newFakeBackend()
 	backend.controllerBackend.isUpgradingErr = errors.New("boom")
@@ -190,6 +308,60 @@ func (s *SourcePrecheckSuite) TestWithPendingMinUnits(c *gc.C) {
 	c.Assert(err.Error(), gc.Equals, "application foo is below its minimum units threshold")
 }
 
+func (s *SourcePrecheckSuite) TestWithDefaultExposeSettings(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name: "foo",
+				exposedEndpoints: map[string]state.ExposedEndpoint{
+					"": {ExposeToCIDRs: []string{"0.0.0.0/0"}},
+				},
+			},
+		},
+	}
+	c.Assert(sourcePrecheck(backend), jc.ErrorIsNil)
+}
+
+func (s *SourcePrecheckSuite) TestWithRestrictedExposeSettings(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name: "foo",
+				exposedEndpoints: map[string]state.ExposedEndpoint{
+					"":      {ExposeToCIDRs: []string{"0.0.0.0/0"}},
+					"admin": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+				},
+			},
+		},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, "application foo has expose settings for specific endpoints, spaces or CIDRs; "+
+		"unexpose it or expose it to 0.0.0.0/0 before migrating")
+}
+
+func (s *SourcePrecheckSuite) TestUnitWithPortsOpenedForEndpoint(c *gc.C) {
+	backend := &fakeBackend{
+		model: fakeModel{modelType: state.ModelTypeIAAS},
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name: "foo",
+				units: []migration.PrecheckUnit{
+					&fakeUnit{
+						name: "foo/0",
+						portEndpoints: map[network.PortRange]string{
+							network.MustParsePortRange("80/tcp"):  "",
+							network.MustParsePortRange("443/tcp"): "website",
+						},
+					},
+				},
+			},
+		},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, `unit foo/0 has opened ports 443/tcp for endpoint "website"; `+
+		"close them or open them for all endpoints before migrating")
+}
+
 func (s *SourcePrecheckSuite) TestUnitVersionsDontMatch(c *gc.C) {
 	backend := &fakeBackend{
 		model: fakeModel{modelType: state.ModelTypeIAAS},
@@ -784,6 +956,21 @@ type fakeBackend struct {
 	pendingResources    []resource.Resource
 	pendingResourcesErr error
 
+	firewallServices    []firewall.Service
+	firewallServicesErr error
+
+	volumes       []migration.PrecheckVolume
+	allVolumesErr error
+
+	volumeSnapshots       []migration.PrecheckVolumeSnapshot
+	allVolumeSnapshotsErr error
+
+	operations       []migration.PrecheckOperation
+	allOperationsErr error
+
+	actions       []migration.PrecheckAction
+	allActionsErr error
+
 	controllerBackend *fakeBackend
 }
 
@@ -831,6 +1018,26 @@ func (b *fakeBackend) ListPendingResources(app string) ([]resource.Resource, err
 	return b.pendingResources, b.pendingResourcesErr
 }
 
+func (b *fakeBackend) AllFirewallServices() ([]firewall.Service, error) {
+	return b.firewallServices, b.firewallServicesErr
+}
+
+func (b *fakeBackend) AllVolumes() ([]migration.PrecheckVolume, error) {
+	return b.volumes, b.allVolumesErr
+}
+
+func (b *fakeBackend) AllVolumeSnapshots() ([]migration.PrecheckVolumeSnapshot, error) {
+	return b.volumeSnapshots, b.allVolumeSnapshotsErr
+}
+
+func (b *fakeBackend) AllOperations() ([]migration.PrecheckOperation, error) {
+	return b.operations, b.allOperationsErr
+}
+
+func (b *fakeBackend) AllActions() ([]migration.PrecheckAction, error) {
+	return b.actions, b.allActionsErr
+}
+
 func (b *fakeBackend) ControllerBackend() (migration.PrecheckBackend, error) {
 	if b.controllerBackend == nil {
 		return b, nil
@@ -960,11 +1167,12 @@ func (m *fakeMachine) ShouldRebootOrShutdown() (state.RebootAction, error) {
 }
 
 type fakeApp struct {
-	name     string
-	life     state.Life
-	charmURL string
-	units    []migration.PrecheckUnit
-	minunits int
+	name             string
+	life             state.Life
+	charmURL         string
+	units            []migration.PrecheckUnit
+	minunits         int
+	exposedEndpoints map[string]state.ExposedEndpoint
 }
 
 func (a *fakeApp) Name() string {
@@ -991,14 +1199,19 @@ func (a *fakeApp) MinUnits() int {
 	return a.minunits
 }
 
+func (a *fakeApp) ExposedEndpoints() map[string]state.ExposedEndpoint {
+	return a.exposedEndpoints
+}
+
 type fakeUnit struct {
-	name        string
-	version     version.Binary
-	noTools     bool
-	life        state.Life
-	charmURL    string
-	agentStatus status.Status
-	lost        bool
+	name          string
+	version       version.Binary
+	noTools       bool
+	life          state.Life
+	charmURL      string
+	agentStatus   status.Status
+	lost          bool
+	portEndpoints map[network.PortRange]string
 }
 
 func (u *fakeUnit) Name() string {
@@ -1053,6 +1266,10 @@ func (u *fakeUnit) AgentPresence() (bool, error) {
 	return !u.lost, nil
 }
 
+func (u *fakeUnit) OpenedPortEndpoints() (map[network.PortRange]string, error) {
+	return u.portEndpoints, nil
+}
+
 type fakeRelation struct {
 	key           string
 	crossModel    bool
@@ -1091,6 +1308,58 @@ func (ru *fakeRelationUnit) InScope() (bool, error) {
 	return ru.inScope, ru.scopeErr
 }
 
+type fakeVolume struct {
+	id            string
+	requestedSize uint64
+}
+
+func (v *fakeVolume) VolumeTag() names.VolumeTag {
+	return names.NewVolumeTag(v.id)
+}
+
+func (v *fakeVolume) RequestedSize() (uint64, bool) {
+	return v.requestedSize, v.requestedSize != 0
+}
+
+type fakeVolumeSnapshot struct {
+	name string
+}
+
+func (s *fakeVolumeSnapshot) Name() string {
+	return s.name
+}
+
+type fakeOperation struct {
+	id     string
+	status state.ActionStatus
+}
+
+func (op *fakeOperation) Id() string {
+	return op.id
+}
+
+func (op *fakeOperation) Status() state.ActionStatus {
+	return op.status
+}
+
+type fakeAction struct {
+	id       string
+	status   state.ActionStatus
+	timeouts state.ActionTimeouts
+}
+
+func (a *fakeAction) Id() string {
+	return a.id
+}
+
+func (a *fakeAction) Status() state.ActionStatus {
+	return a.status
+}
+
+func (a *fakeAction) Timeouts() state.ActionTimeouts {
+	return a.timeouts
+}
+
 func allAlivePresence() migration.ModelPresence {
 	return &fakePresence{}
 }
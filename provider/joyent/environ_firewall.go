@@ -97,10 +97,27 @@ func getRules(envName string, fwrules []cloudapi.FirewallRule) ([]network.Ingres
 	return rules, nil
 }
 
+// checkAllowAllSources returns an error if any of the rules restricts
+// the source of traffic. Joyent firewall rules are expressed in terms of
+// tags, so access cannot be restricted to source CIDRs.
+func checkAllowAllSources(rules []network.IngressRule) error {
+	for _, rule := range rules {
+		for _, cidr := range rule.SourceCIDRs {
+			if cidr != corenetwork.AllNetworksIPv4CIDR {
+				return errors.NotSupportedf("opening %v to source CIDR %q", rule.PortRange, cidr)
+			}
+		}
+	}
+	return nil
+}
+
 func (env *joyentEnviron) OpenPorts(ctx context.ProviderCallContext, ports []network.IngressRule) error {
 	if env.Config().FirewallMode() != config.FwGlobal {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on model", env.Config().FirewallMode())
 	}
+	if err := checkAllowAllSources(ports); err != nil {
+		return errors.Trace(err)
+	}
 
 	fwRules, err := env.compute.cloudapi.ListFirewallRules()
 	if err != nil {
@@ -4,6 +4,7 @@ package joyent_test
 
 import (
 	"github.com/joyent/gosdc/cloudapi"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -73,3 +74,17 @@ func (s *FirewallSuite) TestRuleCreation(c *gc.C) {
 		c.Check(rule, gc.Equals, t.expected)
 	}
 }
+
+func (s *FirewallSuite) TestCheckAllowAllSources(c *gc.C) {
+	err := joyent.CheckAllowAllSources([]network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80),
+		network.MustNewIngressRule("tcp", 443, 443, "0.0.0.0/0"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = joyent.CheckAllowAllSources([]network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0", "10.0.0.0/24"),
+	})
+	c.Assert(err, gc.ErrorMatches, `opening 80/tcp to source CIDR "10.0.0.0/24" not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -22,4 +22,5 @@ var (
 	GetPorts              = getRules
 	CreateFirewallRuleAll = createFirewallRuleAll
 	CreateFirewallRuleVm  = createFirewallRuleVm
+	CheckAllowAllSources  = checkAllowAllSources
 )
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/joyent/gosdc/cloudapi"
+	"github.com/juju/errors"
 
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
@@ -37,6 +38,9 @@ func (inst *joyentInstance) OpenPorts(ctx context.ProviderCallContext, machineId
 	if inst.env.Config().FirewallMode() != config.FwInstance {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on instance", inst.env.Config().FirewallMode())
 	}
+	if err := checkAllowAllSources(ports); err != nil {
+		return errors.Trace(err)
+	}
 
 	fwRules, err := inst.env.compute.cloudapi.ListFirewallRules()
 	if err != nil {
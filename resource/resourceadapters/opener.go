@@ -53,6 +53,23 @@ func (ro *resourceOpener) OpenResource(name string) (o resource.Opened, err erro
 	if ro.unit == nil {
 		return resource.Opened{}, errors.Errorf("missing unit")
 	}
+
+	// Units tracking a branch get the resources staged in the branch.
+	pendingIDs, err := ro.unit.BranchResourceIDs()
+	if err != nil {
+		return resource.Opened{}, errors.Trace(err)
+	}
+	if pendingID, ok := pendingIDs[name]; ok {
+		res, reader, err := ro.res.OpenPendingResource(ro.unit.ApplicationName(), name, pendingID)
+		if err != nil {
+			return resource.Opened{}, errors.Trace(err)
+		}
+		return resource.Opened{
+			Resource:   res,
+			ReadCloser: reader,
+		}, nil
+	}
+
 	app, err := ro.unit.Application()
 	if err != nil {
 		return resource.Opened{}, errors.Trace(err)
@@ -4,6 +4,7 @@
 package state
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
@@ -16,6 +17,8 @@ import (
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/actions"
 )
 
 const (
@@ -110,12 +113,45 @@ type actionDoc struct {
 
 	// Logs holds the progress messages logged by the action.
 	Logs []ActionMessage `bson:"messages"`
+
+	// QueueTimeout is how long the action may remain pending before it
+	// is cancelled. Zero means the action may wait indefinitely.
+	QueueTimeout time.Duration `bson:"queue-timeout,omitempty"`
+
+	// ExecutionTimeout is how long the action may run for before it is
+	// failed. Zero means the action may run indefinitely.
+	ExecutionTimeout time.Duration `bson:"execution-timeout,omitempty"`
+
+	// Operation is the id of the operation rolling out this action
+	// across a set of units, if any.
+	Operation string `bson:"operation,omitempty"`
+}
+
+// ActionTimeouts holds the limits on how long an action may wait to be
+// started, and how long it may run for once started. A zero value for
+// either means no limit.
+type ActionTimeouts struct {
+	Queue     time.Duration
+	Execution time.Duration
+}
+
+// Validate returns an error if either timeout is negative.
+func (t ActionTimeouts) Validate() error {
+	if t.Queue < 0 {
+		return errors.NotValidf("negative queue timeout %v", t.Queue)
+	}
+	if t.Execution < 0 {
+		return errors.NotValidf("negative execution timeout %v", t.Execution)
+	}
+	return nil
 }
 
-// ActionMessage represents a progress message logged by an action.
+// ActionMessage represents a progress message logged by an action,
+// or a line of output written by it to stdout or stderr.
 type ActionMessage struct {
 	MessageValue   string    `bson:"message"`
 	TimestampValue time.Time `bson:"timestamp"`
+	StreamValue    string    `bson:"stream,omitempty"`
 }
 
 // Timestamp returns the message timestamp.
@@ -128,6 +164,12 @@ func (m ActionMessage) Message() string {
 	return m.MessageValue
 }
 
+// Stream returns the output stream the message was written to, or ""
+// for a progress message logged by the action.
+func (m ActionMessage) Stream() string {
+	return m.StreamValue
+}
+
 // action represents an instruction to do some "action" and is expected
 // to match an action definition in a charm.
 type action struct {
@@ -184,6 +226,23 @@ func (a *action) Results() (map[string]interface{}, string) {
 	return a.doc.Results, a.doc.Message
 }
 
+// Operation returns the id of the operation the action is part of,
+// or "" if it was queued by itself.
+func (a *action) Operation() string {
+	if a.doc.Operation == "" {
+		return ""
+	}
+	return a.st.localID(a.doc.Operation)
+}
+
+// Timeouts returns the queue and execution timeouts of the action.
+func (a *action) Timeouts() ActionTimeouts {
+	return ActionTimeouts{
+		Queue:     a.doc.QueueTimeout,
+		Execution: a.doc.ExecutionTimeout,
+	}
+}
+
 // Tag implements the Entity interface and returns a names.Tag that
 // is a names.ActionTag.
 func (a *action) Tag() names.Tag {
@@ -271,9 +330,52 @@ func (a *action) removeAndLog(finalStatus ActionStatus, results map[string]inter
 	if err != nil {
 		return nil, err
 	}
+	a.advanceOperation()
 	return m.Action(a.Id())
 }
 
+// advanceOperation moves on the operation the finished action is part
+// of, if any. Failing to do so does not fail the action; the operation
+// is advanced again when its next action finishes, or by ExpireActions.
+func (a *action) advanceOperation() {
+	if a.doc.Operation == "" {
+		return
+	}
+	if err := advanceOperation(a.st, a.doc.Operation); err != nil {
+		actionLogger.Errorf("cannot advance operation %q after action %q finished: %v", a.Operation(), a.Id(), err)
+	}
+}
+
+// expire finishes the action with the given status and message once it
+// has exceeded one of its timeouts. It asserts that the action's status
+// has not changed since it was read, so that an action that is started or
+// finished in the meantime is left alone.
+func (a *action) expire(finalStatus ActionStatus, message string) error {
+	err := a.st.db().RunTransaction([]txn.Op{
+		{
+			C:      actionsC,
+			Id:     a.doc.DocId,
+			Assert: bson.D{{"status", a.doc.Status}},
+			Update: bson.D{{"$set", bson.D{
+				{"status", finalStatus},
+				{"message", message},
+				{"completed", a.st.nowToTheSecond()},
+			}}},
+		}, {
+			C:      actionNotificationsC,
+			Id:     a.st.docID(ensureActionMarker(a.Receiver()) + a.Id()),
+			Remove: true,
+		}})
+	if err == txn.ErrAborted {
+		return nil
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	a.advanceOperation()
+	return nil
+}
+
 // Messages returns the action's progress messages.
 func (a *action) Messages() []ActionMessage {
 	// Timestamps are not decoded as UTC, so we need to convert :-(
@@ -282,6 +384,7 @@ func (a *action) Messages() []ActionMessage {
 		result[i] = ActionMessage{
 			MessageValue:   m.MessageValue,
 			TimestampValue: m.TimestampValue.UTC(),
+			StreamValue:    m.StreamValue,
 		}
 	}
 	return result
@@ -291,7 +394,7 @@ func (a *action) Messages() []ActionMessage {
 func (a *action) Log(message string) error {
 	// Just to ensure we do not allow bad actions to fill up disk.
 	// 1000 messages should be enough for anyone.
-	if len(a.doc.Logs) > 1000 {
+	if a.countMessages(false) > 1000 {
 		logger.Warningf("exceeded 1000 log messages, action may be stuck")
 		return nil
 	}
@@ -325,6 +428,80 @@ func (a *action) Log(message string) error {
 	return errors.Trace(err)
 }
 
+// maxActionOutputLines limits the number of lines of output recorded
+// against an action, so that a chatty action cannot fill up the disk.
+const maxActionOutputLines = 10000
+
+// LogOutput adds lines written by the running action to the given output
+// stream to the action's message array. Once the action has recorded
+// maxActionOutputLines lines of output, further lines are discarded and
+// a Forbidden error is returned.
+func (a *action) LogOutput(stream string, lines []string) error {
+	switch stream {
+	case actions.StdoutStream, actions.StderrStream:
+	default:
+		return errors.NotValidf("action output stream %q", stream)
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	m, err := a.st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			anAction, err := m.Action(a.Id())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			a = anAction.(*action)
+		}
+		if s := a.Status(); s != ActionRunning {
+			return nil, errors.Errorf("cannot log output to task %q with status %v", a.Id(), s)
+		}
+		remaining := maxActionOutputLines - a.countMessages(true)
+		if remaining <= 0 {
+			return nil, errors.Forbiddenf("more than %d lines of output for task %q", maxActionOutputLines, a.Id())
+		}
+		if len(lines) > remaining {
+			lines = lines[:remaining]
+		}
+		now := a.st.nowToTheSecond().UTC()
+		messages := make([]ActionMessage, len(lines))
+		for i, line := range lines {
+			messages[i] = ActionMessage{
+				MessageValue:   line,
+				TimestampValue: now,
+				StreamValue:    stream,
+			}
+		}
+		return []txn.Op{{
+			C:      actionsC,
+			Id:     a.doc.DocId,
+			Assert: bson.D{{"status", ActionRunning}},
+			Update: bson.D{{"$push", bson.D{
+				{"messages", bson.D{{"$each", messages}}},
+			}}},
+		}}, nil
+	}
+	err = a.st.db().Run(buildTxn)
+	return errors.Trace(err)
+}
+
+// countMessages returns the number of the action's messages holding
+// output written to stdout or stderr if output is true, or the number
+// of progress messages otherwise.
+func (a *action) countMessages(output bool) int {
+	var count int
+	for _, m := range a.doc.Logs {
+		if (m.StreamValue != "") == output {
+			count++
+		}
+	}
+	return count
+}
+
 // newAction builds an Action for the given State and actionDoc.
 func newAction(st *State, adoc actionDoc) Action {
 	return &action{
@@ -343,7 +520,7 @@ func IsNewActionIDSupported(ver version.Number) bool {
 }
 
 // newActionDoc builds the actionDoc with the given name and parameters.
-func newActionDoc(mb modelBackend, receiverTag names.Tag, actionName string, parameters map[string]interface{}, modelAgentVersion version.Number) (actionDoc, actionNotificationDoc, error) {
+func newActionDoc(mb modelBackend, receiverTag names.Tag, actionName string, parameters map[string]interface{}, timeouts ActionTimeouts, modelAgentVersion version.Number) (actionDoc, actionNotificationDoc, error) {
 	prefix := ensureActionMarker(receiverTag.Id())
 	// For actions run on units, we want to use a user friendly action id.
 	// Theoretically, an action receiver could also be a machine, but for
@@ -368,13 +545,15 @@ func newActionDoc(mb modelBackend, receiverTag names.Tag, actionName string, par
 	actionLogger.Debugf("newActionDoc name: '%s', receiver: '%s', actionId: '%s'", actionName, receiverTag, actionId)
 	modelUUID := mb.modelUUID()
 	return actionDoc{
-			DocId:      mb.docID(actionId),
-			ModelUUID:  modelUUID,
-			Receiver:   receiverTag.Id(),
-			Name:       actionName,
-			Parameters: parameters,
-			Enqueued:   mb.nowToTheSecond(),
-			Status:     ActionPending,
+			DocId:            mb.docID(actionId),
+			ModelUUID:        modelUUID,
+			Receiver:         receiverTag.Id(),
+			Name:             actionName,
+			Parameters:       parameters,
+			Enqueued:         mb.nowToTheSecond(),
+			Status:           ActionPending,
+			QueueTimeout:     timeouts.Queue,
+			ExecutionTimeout: timeouts.Execution,
 		}, actionNotificationDoc{
 			DocId:     mb.docID(prefix + actionId),
 			ModelUUID: modelUUID,
@@ -488,9 +667,19 @@ func (m *Model) FindActionsByName(name string) ([]Action, error) {
 
 // EnqueueAction caches the action doc to the database.
 func (m *Model) EnqueueAction(receiver names.Tag, actionName string, payload map[string]interface{}) (Action, error) {
+	return m.EnqueueActionWithTimeouts(receiver, actionName, payload, ActionTimeouts{})
+}
+
+// EnqueueActionWithTimeouts caches the action doc to the database,
+// recording the supplied limits on how long the action may be pending
+// and running for.
+func (m *Model) EnqueueActionWithTimeouts(receiver names.Tag, actionName string, payload map[string]interface{}, timeouts ActionTimeouts) (Action, error) {
 	if len(actionName) == 0 {
 		return nil, errors.New("action name required")
 	}
+	if err := timeouts.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	receiverCollectionName, receiverId, err := m.st.tagToCollectionAndId(receiver)
 	if err != nil {
@@ -500,26 +689,12 @@ func (m *Model) EnqueueAction(receiver names.Tag, actionName string, payload map
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	doc, ndoc, err := newActionDoc(m.st, receiver, actionName, payload, agentVersion)
+	doc, ndoc, err := newActionDoc(m.st, receiver, actionName, payload, timeouts, agentVersion)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 
-	ops := []txn.Op{{
-		C:      receiverCollectionName,
-		Id:     receiverId,
-		Assert: notDeadDoc,
-	}, {
-		C:      actionsC,
-		Id:     doc.DocId,
-		Assert: txn.DocMissing,
-		Insert: doc,
-	}, {
-		C:      actionNotificationsC,
-		Id:     ndoc.DocId,
-		Assert: txn.DocMissing,
-		Insert: ndoc,
-	}}
+	ops := enqueueActionOps(receiverCollectionName, receiverId, doc, ndoc)
 
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		if notDead, err := isNotDead(m.st, receiverCollectionName, receiverId); err != nil {
@@ -537,6 +712,26 @@ func (m *Model) EnqueueAction(receiver names.Tag, actionName string, payload map
 	return nil, err
 }
 
+// enqueueActionOps returns the operations needed to queue the action
+// described by the supplied docs for a receiver that is not dead.
+func enqueueActionOps(receiverCollectionName, receiverId string, doc actionDoc, ndoc actionNotificationDoc) []txn.Op {
+	return []txn.Op{{
+		C:      receiverCollectionName,
+		Id:     receiverId,
+		Assert: notDeadDoc,
+	}, {
+		C:      actionsC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}, {
+		C:      actionNotificationsC,
+		Id:     ndoc.DocId,
+		Assert: txn.DocMissing,
+		Insert: ndoc,
+	}}
+}
+
 // matchingActions finds actions that match ActionReceiver.
 func (st *State) matchingActions(ar ActionReceiver) ([]Action, error) {
 	return st.matchingActionsByReceiverId(ar.Tag().Id())
@@ -608,3 +803,61 @@ func PruneActions(st *State, maxHistoryTime time.Duration, maxHistoryMB int) err
 	err := pruneCollection(st, maxHistoryTime, maxHistoryMB, actionsC, "completed", GoTime)
 	return errors.Trace(err)
 }
+
+// runningActionGracePeriod is how long past its execution timeout a
+// running action is left alone, so that the unit agent has a chance to
+// kill the action and report its outcome itself.
+const runningActionGracePeriod = time.Minute
+
+// ExpireActions cancels pending actions that have been waiting for longer
+// than their queue timeout, and fails running actions that have been
+// running for longer than their execution timeout plus a grace period.
+// Actions without timeouts are never expired. It then advances any
+// running operations whose actions have all finished. A failure to
+// expire one action does not stop the others from being expired; all
+// the failures are reported together.
+func ExpireActions(st *State) error {
+	actions, closer := st.db().GetCollection(actionsC)
+	defer closer()
+
+	var docs []actionDoc
+	err := actions.Find(bson.D{{"$or", []bson.D{
+		{{"status", ActionPending}, {"queue-timeout", bson.D{{"$gt", 0}}}},
+		{{"status", ActionRunning}, {"execution-timeout", bson.D{{"$gt", 0}}}},
+	}}}).All(&docs)
+	if err != nil {
+		return errors.Annotate(err, "cannot get actions with timeouts")
+	}
+
+	var failed []string
+	now := st.clock().Now()
+	for _, doc := range docs {
+		a := &action{st: st, doc: doc}
+		var (
+			finalStatus ActionStatus
+			message     string
+		)
+		switch {
+		case doc.Status == ActionPending && now.After(doc.Enqueued.Add(doc.QueueTimeout)):
+			finalStatus = ActionCancelled
+			message = fmt.Sprintf("action not started within queue timeout of %v", doc.QueueTimeout)
+		case doc.Status == ActionRunning && now.After(doc.Started.Add(doc.ExecutionTimeout+runningActionGracePeriod)):
+			finalStatus = ActionFailed
+			message = fmt.Sprintf("action not completed within execution timeout of %v", doc.ExecutionTimeout)
+		default:
+			continue
+		}
+		actionLogger.Debugf("expiring action %q on %q: %s", a.Id(), doc.Receiver, message)
+		if err := a.expire(finalStatus, message); err != nil {
+			actionLogger.Errorf("cannot expire action %q: %v", a.Id(), err)
+			failed = append(failed, fmt.Sprintf("cannot expire action %q: %v", a.Id(), err))
+		}
+	}
+	if err := advanceRunningOperations(st); err != nil {
+		failed = append(failed, err.Error())
+	}
+	if len(failed) > 0 {
+		return errors.New(strings.Join(failed, "; "))
+	}
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	"unicode"
 
 	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/txn"
 	"github.com/juju/version"
@@ -314,6 +315,78 @@ func (s *ActionSuite) TestActionMessages(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `cannot log message to task "1" with status completed`)
 }
 
+func (s *ActionSuite) TestActionLogOutput(c *gc.C) {
+	s.toSupportNewActionID(c)
+
+	clock := testclock.NewClock(coretesting.NonZeroTime().Round(time.Second))
+	err := s.State.SetClockForTesting(clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	anAction, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Cannot log output until action is running.
+	err = anAction.LogOutput("stdout", []string{"hello"})
+	c.Assert(err, gc.ErrorMatches, `cannot log output to task "1" with status pending`)
+
+	anAction, err = anAction.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = anAction.LogOutput("stdin", []string{"hello"})
+	c.Assert(err, gc.ErrorMatches, `action output stream "stdin" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	err = anAction.LogOutput("stdout", []string{"one", "two"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = anAction.Log("progress")
+	c.Assert(err, jc.ErrorIsNil)
+	err = anAction.LogOutput("stderr", []string{"three"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	a, err := s.Model.Action(anAction.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	var obtained []string
+	for _, m := range a.Messages() {
+		c.Assert(m.Timestamp(), gc.Equals, clock.Now().UTC())
+		obtained = append(obtained, m.Stream()+":"+m.Message())
+	}
+	c.Assert(obtained, jc.DeepEquals, []string{
+		"stdout:one", "stdout:two", ":progress", "stderr:three",
+	})
+
+	// Cannot log output after action finishes.
+	_, err = anAction.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	err = anAction.LogOutput("stdout", []string{"hello"})
+	c.Assert(err, gc.ErrorMatches, `cannot log output to task "1" with status completed`)
+}
+
+func (s *ActionSuite) TestActionLogOutputLimit(c *gc.C) {
+	s.toSupportNewActionID(c)
+
+	anAction, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	anAction, err = anAction.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Lines beyond the limit are discarded, and the next call is
+	// refused so that the unit agent stops sending output.
+	lines := make([]string, 10001)
+	for i := range lines {
+		lines[i] = strconv.Itoa(i)
+	}
+	err = anAction.LogOutput("stdout", lines)
+	c.Assert(err, jc.ErrorIsNil)
+	err = anAction.LogOutput("stdout", []string{"more"})
+	c.Assert(err, gc.ErrorMatches, `more than 10000 lines of output for task "1"`)
+	c.Assert(err, jc.Satisfies, errors.IsForbidden)
+
+	a, err := s.Model.Action(anAction.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := a.Messages()
+	c.Assert(messages, gc.HasLen, 10000)
+	c.Assert(messages[9999].Message(), gc.Equals, "9999")
+}
+
 func (s *ActionSuite) toSupportNewActionID(c *gc.C) {
 	ver, err := s.Model.AgentVersion()
 	c.Assert(err, jc.ErrorIsNil)
@@ -568,6 +641,93 @@ func (s *ActionSuite) TestComplete(c *gc.C) {
 	c.Assert(len(actions), gc.Equals, 0)
 }
 
+func (s *ActionSuite) TestAddActionWithTimeouts(c *gc.C) {
+	timeouts := state.ActionTimeouts{Queue: time.Minute, Execution: time.Hour}
+	a, err := s.unit.AddActionWithTimeouts("snapshot", nil, timeouts)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(a.Timeouts(), gc.Equals, timeouts)
+
+	a, err = s.Model.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(a.Timeouts(), gc.Equals, timeouts)
+}
+
+func (s *ActionSuite) TestAddActionWithNegativeTimeout(c *gc.C) {
+	_, err := s.unit.AddActionWithTimeouts("snapshot", nil, state.ActionTimeouts{Execution: -time.Second})
+	c.Assert(err, gc.ErrorMatches, "negative execution timeout -1s not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ActionSuite) TestExpireActions(c *gc.C) {
+	clock := testclock.NewClock(coretesting.NonZeroTime().Round(time.Second))
+	err := s.State.SetClockForTesting(clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	queued, err := s.unit.AddActionWithTimeouts("snapshot", nil, state.ActionTimeouts{Queue: time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+	running, err := s.unit.AddActionWithTimeouts("snapshot", nil, state.ActionTimeouts{Execution: 5 * time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+	running, err = running.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	unlimited, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Nothing has timed out yet.
+	clock.Advance(time.Minute)
+	err = state.ExpireActions(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertActionStatus(c, queued, state.ActionPending, "")
+	s.assertActionStatus(c, running, state.ActionRunning, "")
+
+	clock.Advance(time.Second)
+	err = state.ExpireActions(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertActionStatus(c, queued, state.ActionCancelled, "action not started within queue timeout of 1m0s")
+	s.assertActionStatus(c, running, state.ActionRunning, "")
+
+	// The running action is past its execution timeout, but is given
+	// a grace period in which the unit can report its outcome.
+	clock.Advance(4 * time.Minute)
+	err = state.ExpireActions(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertActionStatus(c, running, state.ActionRunning, "")
+
+	clock.Advance(state.RunningActionGracePeriod)
+	err = state.ExpireActions(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertActionStatus(c, running, state.ActionFailed, "action not completed within execution timeout of 5m0s")
+	s.assertActionStatus(c, unlimited, state.ActionPending, "")
+
+	pending, err := s.unit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pending, gc.HasLen, 1)
+	c.Assert(pending[0].Id(), gc.Equals, unlimited.Id())
+}
+
+func (s *ActionSuite) TestExpireActionsIgnoresCompleted(c *gc.C) {
+	clock := testclock.NewClock(coretesting.NonZeroTime().Round(time.Second))
+	err := s.State.SetClockForTesting(clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	a, err := s.unit.AddActionWithTimeouts("snapshot", nil, state.ActionTimeouts{Queue: time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+
+	clock.Advance(time.Hour)
+	err = state.ExpireActions(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertActionStatus(c, a, state.ActionCompleted, "")
+}
+
+func (s *ActionSuite) assertActionStatus(c *gc.C, a state.Action, status state.ActionStatus, message string) {
+	a, err := s.Model.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(a.Status(), gc.Equals, status)
+	_, actual := a.Results()
+	c.Check(actual, gc.Equals, message)
+}
+
 func (s *ActionSuite) TestFindActionTagsById(c *gc.C) {
 	s.toSupportNewActionID(c)
 
@@ -1133,6 +1293,9 @@ var _ state.ActionReceiver = (*mockAR)(nil)
 func (r mockAR) AddAction(name string, payload map[string]interface{}) (state.Action, error) {
 	return nil, nil
 }
+func (r mockAR) AddActionWithTimeouts(name string, payload map[string]interface{}, timeouts state.ActionTimeouts) (state.Action, error) {
+	return nil, nil
+}
 func (r mockAR) CancelAction(state.Action) (state.Action, error) { return nil, nil }
 func (r mockAR) WatchActionNotifications() state.StringsWatcher  { return nil }
 func (r mockAR) Actions() ([]state.Action, error)                { return nil, nil }
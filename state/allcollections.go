@@ -380,6 +380,7 @@ func allCollections() CollectionSchema {
 		},
 		volumeAttachmentsC:    {},
 		volumeAttachmentPlanC: {},
+		volumeSnapshotsC:      {},
 
 		// -----
 
@@ -407,10 +408,16 @@ func allCollections() CollectionSchema {
 		actionsC: {
 			indexes: []mgo.Index{{
 				Key: []string{"model-uuid", "name"},
+			}, {
+				Key: []string{"model-uuid", "operation"},
 			}},
 		},
 		actionNotificationsC: {},
 
+		// operationsC holds the actions being rolled out across
+		// a set of units a batch at a time.
+		operationsC: {},
+
 		// -----
 
 		// This collection holds information associated with charm payloads.
@@ -514,6 +521,10 @@ func allCollections() CollectionSchema {
 		// firewallRulesC holds firewall rules for defined service types.
 		firewallRulesC: {},
 
+		// firewallServicesC holds the operator defined services
+		// to which firewall rules may be applied.
+		firewallServicesC: {},
+
 		// podSpecsC holds the CAAS pod specifications,
 		// for applications.
 		podSpecsC: {},
@@ -590,6 +601,7 @@ const (
 	modelsC                    = "models"
 	modelEntityRefsC           = "modelEntityRefs"
 	openedPortsC               = "openedPorts"
+	operationsC                = "operations"
 	payloadsC                  = "payloads"
 	permissionsC               = "permissions"
 	podSpecsC                  = "podSpecs"
@@ -627,6 +639,7 @@ const (
 	volumeAttachmentsC         = "volumeattachments"
 	volumeAttachmentPlanC      = "volumeattachmentplan"
 	volumesC                   = "volumes"
+	volumeSnapshotsC           = "volumesnapshots"
 
 	// "resources" (see resource/persistence/mongo.go)
 
@@ -638,4 +651,5 @@ const (
 	externalControllersC = "externalControllers"
 	relationNetworksC    = "relationNetworks"
 	firewallRulesC       = "firewallRules"
+	firewallServicesC    = "firewallServices"
 )
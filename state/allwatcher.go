@@ -1261,12 +1261,35 @@ func (g *backingGeneration) updated(ctx *allWatcherContext) error {
 		assigned[k] = units
 	}
 
+	// Flatten the charm upgrades into charm URLs and resources.
+	var charmURLs map[string]string
+	var resources map[string]map[string]string
+	for app, upgrade := range g.CharmUpgrades {
+		if charmURLs == nil {
+			charmURLs = make(map[string]string, len(g.CharmUpgrades))
+		}
+		charmURLs[app] = upgrade.CharmURL
+		if len(upgrade.ResourceIDs) == 0 {
+			continue
+		}
+		if resources == nil {
+			resources = make(map[string]map[string]string)
+		}
+		ids := make(map[string]string, len(upgrade.ResourceIDs))
+		for name, id := range upgrade.ResourceIDs {
+			ids[name] = id
+		}
+		resources[app] = ids
+	}
+
 	info := &multiwatcher.BranchInfo{
 		ModelUUID:     g.ModelUUID,
 		ID:            ctx.id, // Id not stored on the doc.
 		Name:          g.Name,
 		AssignedUnits: assigned,
 		Config:        cfg,
+		CharmURLs:     charmURLs,
+		Resources:     resources,
 		Created:       g.Created,
 		CreatedBy:     g.CreatedBy,
 		Completed:     g.Completed,
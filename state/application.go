@@ -6,6 +6,7 @@ package state
 import (
 	stderrors "errors"
 	"fmt"
+	"net"
 	"sort"
 	"strconv"
 	"strings"
@@ -31,6 +32,7 @@ import (
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/settings"
 	"github.com/juju/juju/core/status"
 	mgoutils "github.com/juju/juju/mongo/utils"
 	"github.com/juju/juju/state/presence"
@@ -46,23 +48,24 @@ type Application struct {
 // applicationDoc represents the internal state of an application in MongoDB.
 // Note the correspondence with ApplicationInfo in apiserver.
 type applicationDoc struct {
-	DocID                string       `bson:"_id"`
-	Name                 string       `bson:"name"`
-	ModelUUID            string       `bson:"model-uuid"`
-	Series               string       `bson:"series"`
-	Subordinate          bool         `bson:"subordinate"`
-	CharmURL             *charm.URL   `bson:"charmurl"`
-	Channel              string       `bson:"cs-channel"`
-	CharmModifiedVersion int          `bson:"charmmodifiedversion"`
-	ForceCharm           bool         `bson:"forcecharm"`
-	Life                 Life         `bson:"life"`
-	UnitCount            int          `bson:"unitcount"`
-	RelationCount        int          `bson:"relationcount"`
-	Exposed              bool         `bson:"exposed"`
-	MinUnits             int          `bson:"minunits"`
-	Tools                *tools.Tools `bson:",omitempty"`
-	TxnRevno             int64        `bson:"txn-revno"`
-	MetricCredentials    []byte       `bson:"metric-credentials"`
+	DocID                string                     `bson:"_id"`
+	Name                 string                     `bson:"name"`
+	ModelUUID            string                     `bson:"model-uuid"`
+	Series               string                     `bson:"series"`
+	Subordinate          bool                       `bson:"subordinate"`
+	CharmURL             *charm.URL                 `bson:"charmurl"`
+	Channel              string                     `bson:"cs-channel"`
+	CharmModifiedVersion int                        `bson:"charmmodifiedversion"`
+	ForceCharm           bool                       `bson:"forcecharm"`
+	Life                 Life                       `bson:"life"`
+	UnitCount            int                        `bson:"unitcount"`
+	RelationCount        int                        `bson:"relationcount"`
+	Exposed              bool                       `bson:"exposed"`
+	ExposedEndpoints     map[string]ExposedEndpoint `bson:"exposed-endpoints,omitempty"`
+	MinUnits             int                        `bson:"minunits"`
+	Tools                *tools.Tools               `bson:",omitempty"`
+	TxnRevno             int64                      `bson:"txn-revno"`
+	MetricCredentials    []byte                     `bson:"metric-credentials"`
 
 	// CAAS related attributes.
 	DesiredScale int    `bson:"scale"`
@@ -496,7 +499,11 @@ func (op *DestroyApplicationOperation) unassignBranchOps() ([]txn.Op, error) {
 		// assumption: branches from applicationBranches will
 		// ALWAYS have the appName in assigned-units, but not
 		// always in config.
-		ops = append(ops, b.unassignAppOps(appName)...)
+		unassignOps, err := b.unassignAppOps(appName, &op.ForcedOperation)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, unassignOps...)
 	}
 	return ops, nil
 }
@@ -589,29 +596,221 @@ func (a *Application) IsExposed() bool {
 	return a.doc.Exposed
 }
 
+// ExposedEndpoint encapsulates the expose-related details of a particular
+// application endpoint with respect to the sources (CIDRs or space IDs) that
+// should be able to access the ports opened by the application charm if the
+// application is exposed.
+type ExposedEndpoint struct {
+	// ExposeToSpaceIDs contains a list of spaces whose subnet CIDRs
+	// should be able to access the ports opened by the application.
+	ExposeToSpaceIDs []string `bson:"to-space-ids,omitempty"`
+
+	// ExposeToCIDRs contains a list of CIDRs that should be able to
+	// access the ports opened by the application.
+	ExposeToCIDRs []string `bson:"to-cidrs,omitempty"`
+}
+
+// AllowTrafficFromAnyNetwork returns true if the exposed endpoint parameters
+// include the 0.0.0.0/0 CIDR.
+func (ep ExposedEndpoint) AllowTrafficFromAnyNetwork() bool {
+	for _, cidr := range ep.ExposeToCIDRs {
+		if cidr == network.AllNetworksIPv4CIDR {
+			return true
+		}
+	}
+	return false
+}
+
+// ExposedEndpoints returns the expose settings of the application, keyed by
+// endpoint name. The settings for the empty endpoint name apply to all of the
+// application's endpoints. An application exposed without any settings is
+// reported as exposing all of its endpoints to 0.0.0.0/0.
+func (a *Application) ExposedEndpoints() map[string]ExposedEndpoint {
+	if !a.doc.Exposed {
+		return nil
+	}
+	if len(a.doc.ExposedEndpoints) == 0 {
+		return map[string]ExposedEndpoint{
+			"": {ExposeToCIDRs: []string{network.AllNetworksIPv4CIDR}},
+		}
+	}
+	result := make(map[string]ExposedEndpoint, len(a.doc.ExposedEndpoints))
+	for endpoint, exposed := range a.doc.ExposedEndpoints {
+		result[endpoint] = exposed
+	}
+	return result
+}
+
 // SetExposed marks the application as exposed.
 // See ClearExposed and IsExposed.
 func (a *Application) SetExposed() error {
 	return a.setExposed(true)
 }
 
-// ClearExposed removes the exposed flag from the application.
+// ClearExposed removes the exposed flag from the application,
+// along with any expose settings for its endpoints.
 // See SetExposed and IsExposed.
 func (a *Application) ClearExposed() error {
 	return a.setExposed(false)
 }
 
 func (a *Application) setExposed(exposed bool) (err error) {
+	update := bson.D{{"$set", bson.D{{"exposed", exposed}}}}
+	if !exposed {
+		update = append(update, bson.DocElem{"$unset", bson.D{{"exposed-endpoints", nil}}})
+	}
 	ops := []txn.Op{{
 		C:      applicationsC,
 		Id:     a.doc.DocID,
 		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{{"exposed", exposed}}}},
+		Update: update,
 	}}
 	if err := a.st.db().RunTransaction(ops); err != nil {
 		return errors.Errorf("cannot set exposed flag for application %q to %v: %v", a, exposed, onAbort(err, applicationNotAliveErr))
 	}
 	a.doc.Exposed = exposed
+	if !exposed {
+		a.doc.ExposedEndpoints = nil
+	}
+	return nil
+}
+
+// MergeExposeSettings marks the application as exposed and merges the
+// provided expose settings into the application's existing settings,
+// replacing the settings of any endpoint already present. Settings that
+// specify neither spaces nor CIDRs expose the endpoint to 0.0.0.0/0.
+// The empty endpoint name applies the settings to all endpoints.
+func (a *Application) MergeExposeSettings(exposedEndpoints map[string]ExposedEndpoint) error {
+	var merged map[string]ExposedEndpoint
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if err := a.validateExposeSettings(exposedEndpoints); err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		merged = a.ExposedEndpoints()
+		if merged == nil {
+			merged = make(map[string]ExposedEndpoint)
+		}
+		for endpoint, exposed := range exposedEndpoints {
+			if len(exposed.ExposeToSpaceIDs) == 0 && len(exposed.ExposeToCIDRs) == 0 {
+				exposed.ExposeToCIDRs = []string{network.AllNetworksIPv4CIDR}
+			}
+			merged[endpoint] = exposed
+		}
+		if len(merged) == 0 {
+			// Exposing without settings exposes all endpoints.
+			merged[""] = ExposedEndpoint{ExposeToCIDRs: []string{network.AllNetworksIPv4CIDR}}
+		}
+		return a.exposeSettingsOps(merged), nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot merge expose settings for application %q", a)
+	}
+	a.setExposedEndpoints(merged)
+	return nil
+}
+
+// UnsetExposeSettings removes the expose settings for the provided endpoints.
+// The application is no longer exposed once no settings remain.
+func (a *Application) UnsetExposeSettings(endpoints []string) error {
+	var remaining map[string]ExposedEndpoint
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if !a.doc.Exposed {
+			return nil, errors.NotValidf("unexposing endpoints of an application that is not exposed")
+		}
+		remaining = a.ExposedEndpoints()
+		for _, endpoint := range endpoints {
+			if _, found := remaining[endpoint]; !found {
+				return nil, errors.NotFoundf("expose settings for endpoint %q", endpoint)
+			}
+			delete(remaining, endpoint)
+		}
+		return a.exposeSettingsOps(remaining), nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot unset expose settings for application %q", a)
+	}
+	a.setExposedEndpoints(remaining)
+	return nil
+}
+
+// setExposedEndpoints updates the local copy of the application's
+// expose settings to match those written by exposeSettingsOps.
+func (a *Application) setExposedEndpoints(exposedEndpoints map[string]ExposedEndpoint) {
+	a.doc.Exposed = len(exposedEndpoints) > 0
+	a.doc.ExposedEndpoints = exposedEndpoints
+	if !a.doc.Exposed {
+		a.doc.ExposedEndpoints = nil
+	}
+}
+
+// exposeSettingsOps returns the operations that replace the expose settings
+// of the application, leaving it exposed only if any settings remain.
+func (a *Application) exposeSettingsOps(exposedEndpoints map[string]ExposedEndpoint) []txn.Op {
+	update := bson.D{{"$set", bson.D{
+		{"exposed", len(exposedEndpoints) > 0},
+		{"exposed-endpoints", exposedEndpoints},
+	}}}
+	if len(exposedEndpoints) == 0 {
+		update = bson.D{
+			{"$set", bson.D{{"exposed", false}}},
+			{"$unset", bson.D{{"exposed-endpoints", nil}}},
+		}
+	}
+	return []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: append(isAliveDoc, bson.DocElem{"txn-revno", a.doc.TxnRevno}),
+		Update: update,
+	}}
+}
+
+// validateExposeSettings checks that the endpoints, spaces
+// and CIDRs in the input expose settings are all valid.
+func (a *Application) validateExposeSettings(exposedEndpoints map[string]ExposedEndpoint) error {
+	if a.doc.Life != Alive {
+		return applicationNotAliveErr
+	}
+	if len(exposedEndpoints) == 0 {
+		return nil
+	}
+	eps, err := a.Endpoints()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	endpointNames := set.NewStrings()
+	for _, ep := range eps {
+		endpointNames.Add(ep.Name)
+	}
+	spaceInfos, err := a.st.AllSpaceInfos()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for endpoint, exposed := range exposedEndpoints {
+		if endpoint != "" && !endpointNames.Contains(endpoint) {
+			return errors.NotValidf("endpoint %q", endpoint)
+		}
+		for _, spaceID := range exposed.ExposeToSpaceIDs {
+			if !spaceInfos.ContainsID(spaceID) {
+				return errors.NotFoundf("space with ID %q", spaceID)
+			}
+		}
+		for _, cidr := range exposed.ExposeToCIDRs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return errors.NotValidf("CIDR %q", cidr)
+			}
+		}
+	}
 	return nil
 }
 
@@ -851,11 +1050,14 @@ func (a *Application) checkStorageUpgrade(newMeta, oldMeta *charm.Meta, units []
 }
 
 // changeCharmOps returns the operations necessary to set a application's
-// charm URL to a new value.
+// charm URL to a new value. Any config delta is applied to the old
+// settings before they are carried over to the new charm; the old
+// settings themselves are left unchanged.
 func (a *Application) changeCharmOps(
 	ch *Charm,
 	channel string,
 	updatedSettings charm.Settings,
+	configDelta settings.ItemChanges,
 	forceUnits bool,
 	resourceIDs map[string]string,
 	updatedStorageConstraints map[string]StorageConstraints,
@@ -864,6 +1066,7 @@ func (a *Application) changeCharmOps(
 	var newSettings charm.Settings
 	oldKey, err := readSettings(a.st.db(), settingsC, a.charmConfigKey())
 	if err == nil {
+		oldKey.applyChanges(configDelta)
 		// Filter the old settings through to get the new settings.
 		newSettings = ch.Config().FilterSettings(oldKey.Map())
 		for k, v := range updatedSettings {
@@ -1230,9 +1433,17 @@ type SetCharmConfig struct {
 	// EndpointBindings is an operator-defined map of endpoint names to
 	// space names that should be merged with any existing bindings.
 	EndpointBindings map[string]string
+
+	// BranchName identifies the "in-flight" branch in which the upgrade
+	// is staged. If it is empty or indicates the master generation, the
+	// application is upgraded immediately. Otherwise only units tracking
+	// the branch are upgraded, and the application is upgraded when the
+	// branch is committed.
+	BranchName string
 }
 
 // SetCharm changes the charm for the application.
+// If the config indicates a branch, the upgrade is staged in the branch.
 func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 	defer errors.DeferredAnnotatef(
 		&err, "cannot upgrade application %q to charm %q", a, cfg.Charm,
@@ -1314,6 +1525,53 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 		}
 	}
 
+	if cfg.BranchName != "" && cfg.BranchName != model.GenerationMaster {
+		return errors.Trace(a.upgradeBranchCharm(cfg, updatedSettings))
+	}
+
+	// An upgrade staged in a branch holds a reference to the charm that the
+	// application was using when it was staged, so the application's charm
+	// must not change underneath it.
+	if branch, err := a.stagedCharmBranch(); err != nil {
+		return errors.Trace(err)
+	} else if branch != nil {
+		return errors.Errorf("charm upgrade is staged in branch %q; commit or abort it first", branch.BranchName())
+	}
+	return errors.Trace(a.setCharm(cfg, updatedSettings))
+}
+
+// upgradeBranchCharm stages the upgrade described by the input config in
+// the branch that it names. Only the charm, channel and resources can be
+// changed by an upgrade in a branch.
+func (a *Application) upgradeBranchCharm(cfg SetCharmConfig, updatedSettings charm.Settings) error {
+	if len(updatedSettings) > 0 || len(cfg.StorageConstraints) > 0 || len(cfg.EndpointBindings) > 0 || cfg.ForceUnits {
+		return errors.NotSupportedf("changing config, storage, bindings or forcing units when upgrading in a branch")
+	}
+	branch, err := a.st.Branch(cfg.BranchName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(branch.upgradeCharm(a.doc.Name, cfg.Charm, string(cfg.Channel), cfg.ResourceIDs))
+}
+
+// stagedCharmBranch returns the "in-flight" branch in which a charm upgrade
+// is staged for this application, or nil if there is none.
+func (a *Application) stagedCharmBranch() (*Generation, error) {
+	branches, err := a.st.Branches()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, b := range branches {
+		if _, ok := b.doc.CharmUpgrades[a.doc.Name]; ok {
+			return b, nil
+		}
+	}
+	return nil, nil
+}
+
+// setCharm changes the charm for the application, without validating the
+// input config.
+func (a *Application) setCharm(cfg SetCharmConfig, updatedSettings charm.Settings) error {
 	var newCharmModifiedVersion int
 	channel := string(cfg.Channel)
 	acopy := &Application{a.st, a.doc}
@@ -1324,77 +1582,12 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 				return nil, errors.Trace(err)
 			}
 		}
-
-		// NOTE: We're explicitly allowing SetCharm to succeed
-		// when the application is Dying, because application/charm
-		// upgrades should still be allowed to apply to dying
-		// applications and units, so that bugs in departed/broken
-		// hooks can be addressed at runtime.
-		if a.Life() == Dead {
-			return nil, ErrDead
-		}
-
-		// Record the current value of charmModifiedVersion, so we can
-		// set the value on the method receiver's in-memory document
-		// structure. We increment the version only when we change the
-		// charm URL.
-		newCharmModifiedVersion = a.doc.CharmModifiedVersion
-
-		ops := []txn.Op{{
-			C:  applicationsC,
-			Id: a.doc.DocID,
-			Assert: append(notDeadDoc, bson.DocElem{
-				"charmmodifiedversion", a.doc.CharmModifiedVersion,
-			}),
-		}}
-
-		if a.doc.CharmURL.String() == cfg.Charm.URL().String() {
-			// Charm URL already set; just update the force flag and channel.
-			ops = append(ops, txn.Op{
-				C:  applicationsC,
-				Id: a.doc.DocID,
-				Update: bson.D{{"$set", bson.D{
-					{"cs-channel", channel},
-					{"forcecharm", cfg.ForceUnits},
-				}}},
-			})
-		} else {
-			chng, err := a.changeCharmOps(
-				cfg.Charm,
-				channel,
-				updatedSettings,
-				cfg.ForceUnits,
-				cfg.ResourceIDs,
-				cfg.StorageConstraints,
-			)
-			if err != nil {
-				return nil, errors.Trace(err)
-			}
-			ops = append(ops, chng...)
-			newCharmModifiedVersion++
-		}
-
-		// Always update bindings regardless of whether we upgrade to a
-		// new version or stay at the previous version.
-		currentMap, txnRevno, err := readEndpointBindings(a.st, a.globalKey())
-		if err != nil && !errors.IsNotFound(err) {
-			return ops, errors.Trace(err)
-		}
-		b, err := a.bindingsForOps(currentMap)
-		if err != nil {
-			return nil, errors.Trace(err)
-		}
-		endpointBindingsOps, err := b.updateOps(txnRevno, cfg.EndpointBindings, cfg.Charm.Meta(), cfg.Force)
-		if err == nil {
-			ops = append(ops, endpointBindingsOps...)
-		} else if !errors.IsNotFound(err) && err != jujutxn.ErrNoOperations {
-			// If endpoint bindings do not exist this most likely means the application
-			// itself no longer exists, which will be caught soon enough anyway.
-			// ErrNoOperations on the other hand means there's nothing to update.
-			return nil, errors.Trace(err)
-		}
-
-		return ops, nil
+		var (
+			ops []txn.Op
+			err error
+		)
+		ops, newCharmModifiedVersion, err = a.setCharmOps(cfg, updatedSettings, nil)
+		return ops, err
 	}
 
 	if err := a.st.db().Run(buildTxn); err != nil {
@@ -1407,6 +1600,87 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 	return nil
 }
 
+// setCharmOps returns the operations that set the application's charm as
+// described by the input config, along with the application's charm
+// modified version once they are applied. Any config delta is applied to
+// the application's settings as they are carried over to the new charm.
+func (a *Application) setCharmOps(
+	cfg SetCharmConfig, updatedSettings charm.Settings, configDelta settings.ItemChanges,
+) ([]txn.Op, int, error) {
+	// NOTE: We're explicitly allowing SetCharm to succeed
+	// when the application is Dying, because application/charm
+	// upgrades should still be allowed to apply to dying
+	// applications and units, so that bugs in departed/broken
+	// hooks can be addressed at runtime.
+	if a.Life() == Dead {
+		return nil, 0, ErrDead
+	}
+
+	// Record the current value of charmModifiedVersion, so we can
+	// set the value on the method receiver's in-memory document
+	// structure. We increment the version only when we change the
+	// charm URL.
+	newCharmModifiedVersion := a.doc.CharmModifiedVersion
+	channel := string(cfg.Channel)
+
+	ops := []txn.Op{{
+		C:  applicationsC,
+		Id: a.doc.DocID,
+		Assert: append(notDeadDoc, bson.DocElem{
+			"charmmodifiedversion", a.doc.CharmModifiedVersion,
+		}),
+	}}
+
+	if a.doc.CharmURL.String() == cfg.Charm.URL().String() {
+		// Charm URL already set; just update the force flag and channel.
+		ops = append(ops, txn.Op{
+			C:  applicationsC,
+			Id: a.doc.DocID,
+			Update: bson.D{{"$set", bson.D{
+				{"cs-channel", channel},
+				{"forcecharm", cfg.ForceUnits},
+			}}},
+		})
+	} else {
+		chng, err := a.changeCharmOps(
+			cfg.Charm,
+			channel,
+			updatedSettings,
+			configDelta,
+			cfg.ForceUnits,
+			cfg.ResourceIDs,
+			cfg.StorageConstraints,
+		)
+		if err != nil {
+			return nil, 0, errors.Trace(err)
+		}
+		ops = append(ops, chng...)
+		newCharmModifiedVersion++
+	}
+
+	// Always update bindings regardless of whether we upgrade to a
+	// new version or stay at the previous version.
+	currentMap, txnRevno, err := readEndpointBindings(a.st, a.globalKey())
+	if err != nil && !errors.IsNotFound(err) {
+		return ops, 0, errors.Trace(err)
+	}
+	b, err := a.bindingsForOps(currentMap)
+	if err != nil {
+		return nil, 0, errors.Trace(err)
+	}
+	endpointBindingsOps, err := b.updateOps(txnRevno, cfg.EndpointBindings, cfg.Charm.Meta(), cfg.Force)
+	if err == nil {
+		ops = append(ops, endpointBindingsOps...)
+	} else if !errors.IsNotFound(err) && err != jujutxn.ErrNoOperations {
+		// If endpoint bindings do not exist this most likely means the application
+		// itself no longer exists, which will be caught soon enough anyway.
+		// ErrNoOperations on the other hand means there's nothing to update.
+		return nil, 0, errors.Trace(err)
+	}
+
+	return ops, newCharmModifiedVersion, nil
+}
+
 // MergeBindings merges the provided bindings map with the existing application
 // bindings.
 func (a *Application) MergeBindings(operatorBindings *Bindings, force bool) error {
@@ -0,0 +1,444 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/auditlog"
+)
+
+// auditLogC is the capped collection in the logs database that holds
+// the controller's audit log records.
+const auditLogC = "audit"
+
+const (
+	auditConversationKind = "conversation"
+	auditRequestKind      = "request"
+	auditResponseKind     = "response"
+)
+
+// auditLogIndexes defines the indexes we need on the audit log
+// collection.
+var auditLogIndexes = [][]string{
+	{"kind", "t"},
+	{"conversation-id", "request-id"},
+}
+
+// auditLogDoc holds a conversation, request or response record from
+// the audit log. Capped collection documents can't grow once written,
+// so each record is stored as a separate document and they are joined
+// by conversation and request ID when queried.
+type auditLogDoc struct {
+	Id             bson.ObjectId `bson:"_id"`
+	Kind           string        `bson:"kind"`
+	ConversationID string        `bson:"conversation-id"`
+	ConnectionID   string        `bson:"connection-id"`
+	Time           time.Time     `bson:"t"`
+
+	// Conversation fields.
+	Who       string `bson:"who,omitempty"`
+	What      string `bson:"what,omitempty"`
+	ModelName string `bson:"model-name,omitempty"`
+	ModelUUID string `bson:"model-uuid,omitempty"`
+
+	// Request and response fields.
+	RequestID uint64 `bson:"request-id"`
+	Facade    string `bson:"facade,omitempty"`
+	Method    string `bson:"method,omitempty"`
+	Version   int    `bson:"version,omitempty"`
+	Args      string `bson:"args,omitempty"`
+
+	// Errors holds one entry per result in a bulk call's response,
+	// with a nil entry for each result that succeeded. Failed is true
+	// if any entry is an error.
+	Errors []*auditErrorDoc `bson:"errors,omitempty"`
+	Failed bool             `bson:"failed,omitempty"`
+}
+
+type auditErrorDoc struct {
+	Message string `bson:"message"`
+	Code    string `bson:"code"`
+}
+
+// initDbAuditLog sets up the capped collection holding the audit log,
+// along with its indexes, sized according to the controller config. It
+// is called by InitDbLogs, and is idempotent.
+func initDbAuditLog(session *mgo.Session) error {
+	size, err := controllerSizeSetting(session, controller.AuditLogDBSize, controller.DefaultAuditLogDBSizeMB)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	coll := session.DB(logsDB).C(auditLogC)
+	capped, maxSize, err := getCollectionCappedInfo(coll)
+	if errors.IsNotFound(err) {
+		logger.Infof("creating audit log collection, capped at %v MiB", size)
+		err := coll.Create(&mgo.CollectionInfo{
+			Capped:   true,
+			MaxBytes: size * humanize.MiByte,
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+	} else if err != nil {
+		return errors.Trace(err)
+	} else if !capped || maxSize != size {
+		logger.Infof("capping audit log collection at %v MiB", size)
+		if err := convertToCapped(coll, size); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	for _, key := range auditLogIndexes {
+		if err := coll.EnsureIndex(mgo.Index{Key: key}); err != nil {
+			return errors.Annotatef(err, "cannot create index for audit log collection")
+		}
+	}
+	return nil
+}
+
+// DbAuditLog is an auditlog.AuditLog that writes records to the
+// controller's audit log collection, so that they can be queried.
+type DbAuditLog struct {
+	coll *mgo.Collection
+}
+
+var _ auditlog.AuditLog = (*DbAuditLog)(nil)
+
+// NewDbAuditLog returns a DbAuditLog writing to the audit log
+// collection of the controller that st is connected to.
+func NewDbAuditLog(st MongoSessioner) *DbAuditLog {
+	_, db := initLogsSessionDB(st)
+	return &DbAuditLog{coll: db.C(auditLogC)}
+}
+
+// AddConversation implements auditlog.AuditLog.
+func (l *DbAuditLog) AddConversation(c auditlog.Conversation) error {
+	when, err := parseAuditTime(c.When)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(l.coll.Insert(&auditLogDoc{
+		Id:             bson.NewObjectId(),
+		Kind:           auditConversationKind,
+		ConversationID: c.ConversationID,
+		ConnectionID:   c.ConnectionID,
+		Time:           when,
+		Who:            c.Who,
+		What:           c.What,
+		ModelName:      c.ModelName,
+		ModelUUID:      c.ModelUUID,
+	}))
+}
+
+// AddRequest implements auditlog.AuditLog.
+func (l *DbAuditLog) AddRequest(r auditlog.Request) error {
+	when, err := parseAuditTime(r.When)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(l.coll.Insert(&auditLogDoc{
+		Id:             bson.NewObjectId(),
+		Kind:           auditRequestKind,
+		ConversationID: r.ConversationID,
+		ConnectionID:   r.ConnectionID,
+		Time:           when,
+		RequestID:      r.RequestID,
+		Facade:         r.Facade,
+		Method:         r.Method,
+		Version:        r.Version,
+		Args:           r.Args,
+	}))
+}
+
+// AddResponse implements auditlog.AuditLog.
+func (l *DbAuditLog) AddResponse(r auditlog.ResponseErrors) error {
+	when, err := parseAuditTime(r.When)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	doc := auditLogDoc{
+		Id:             bson.NewObjectId(),
+		Kind:           auditResponseKind,
+		ConversationID: r.ConversationID,
+		ConnectionID:   r.ConnectionID,
+		Time:           when,
+		RequestID:      r.RequestID,
+	}
+	for _, e := range r.Errors {
+		if e == nil {
+			doc.Errors = append(doc.Errors, nil)
+			continue
+		}
+		doc.Errors = append(doc.Errors, &auditErrorDoc{
+			Message: e.Message,
+			Code:    e.Code,
+		})
+		doc.Failed = true
+	}
+	return errors.Trace(l.coll.Insert(&doc))
+}
+
+// Close implements auditlog.AuditLog.
+func (l *DbAuditLog) Close() error {
+	l.coll.Database.Session.Close()
+	return nil
+}
+
+func parseAuditTime(when string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, when)
+	if err != nil {
+		return time.Time{}, errors.NotValidf("audit record time %q", when)
+	}
+	return t.UTC(), nil
+}
+
+// AuditLogFilter selects the requests returned by QueryAuditLog. Zero
+// valued fields match everything.
+type AuditLogFilter struct {
+	// User matches the user who made the requests.
+	User string
+
+	// Model matches either the qualified name or the UUID of the
+	// model the requests were made against.
+	Model string
+
+	// Facade and Method match the API method called.
+	Facade string
+	Method string
+
+	// From and To bound the times of the requests.
+	From time.Time
+	To   time.Time
+
+	// ErrorsOnly restricts the results to requests whose response
+	// included an error.
+	ErrorsOnly bool
+
+	// Limit is the maximum number of requests to return. The most
+	// recent matching requests are returned.
+	Limit int
+}
+
+// AuditLogEntry describes a request found in the audit log, along
+// with the conversation it was made in and any errors in its response.
+type AuditLogEntry struct {
+	Conversation auditlog.Conversation
+	Request      auditlog.Request
+
+	// Errors is nil if the response hasn't been recorded, or if it
+	// has been discarded from the capped collection.
+	Errors *auditlog.ResponseErrors
+}
+
+type auditRequestKey struct {
+	conversationID string
+	requestID      uint64
+}
+
+// QueryAuditLog returns the requests in the controller's audit log
+// that match the filter, oldest first.
+func QueryAuditLog(st MongoSessioner, filter AuditLogFilter) ([]AuditLogEntry, error) {
+	session := st.MongoSession().Copy()
+	defer session.Close()
+	coll := session.DB(logsDB).C(auditLogC)
+
+	timeRange := bson.D{}
+	if !filter.From.IsZero() {
+		timeRange = append(timeRange, bson.DocElem{"$gte", filter.From.UTC()})
+	}
+	if !filter.To.IsZero() {
+		timeRange = append(timeRange, bson.DocElem{"$lte", filter.To.UTC()})
+	}
+
+	requestQuery := bson.D{{"kind", auditRequestKind}}
+	if filter.User != "" || filter.Model != "" {
+		conversationQuery := bson.D{{"kind", auditConversationKind}}
+		if filter.User != "" {
+			conversationQuery = append(conversationQuery, bson.DocElem{"who", filter.User})
+		}
+		if filter.Model != "" {
+			conversationQuery = append(conversationQuery, bson.DocElem{"$or", []bson.D{
+				{{"model-name", filter.Model}},
+				{{"model-uuid", filter.Model}},
+			}})
+		}
+		// A conversation always starts before its requests, so only
+		// the end of the time range applies to it.
+		if !filter.To.IsZero() {
+			conversationQuery = append(conversationQuery, bson.DocElem{"t", bson.D{{"$lte", filter.To.UTC()}}})
+		}
+		var ids []string
+		if err := coll.Find(conversationQuery).Distinct("conversation-id", &ids); err != nil {
+			return nil, errors.Annotate(err, "reading audit log conversations")
+		}
+		if len(ids) == 0 {
+			return nil, nil
+		}
+		requestQuery = append(requestQuery, bson.DocElem{"conversation-id", bson.D{{"$in", ids}}})
+	}
+	if filter.Facade != "" {
+		requestQuery = append(requestQuery, bson.DocElem{"facade", filter.Facade})
+	}
+	if filter.Method != "" {
+		requestQuery = append(requestQuery, bson.DocElem{"method", filter.Method})
+	}
+	if len(timeRange) > 0 {
+		requestQuery = append(requestQuery, bson.DocElem{"t", timeRange})
+	}
+
+	var failed map[auditRequestKey]bool
+	if filter.ErrorsOnly {
+		// A response always follows its request, so only the start
+		// of the time range applies to it.
+		responseQuery := bson.D{
+			{"kind", auditResponseKind},
+			{"failed", true},
+		}
+		if !filter.From.IsZero() {
+			responseQuery = append(responseQuery, bson.DocElem{"t", bson.D{{"$gte", filter.From.UTC()}}})
+		}
+		var docs []auditLogDoc
+		fields := bson.M{"conversation-id": 1, "request-id": 1}
+		if err := coll.Find(responseQuery).Select(fields).All(&docs); err != nil {
+			return nil, errors.Annotate(err, "reading audit log responses")
+		}
+		if len(docs) == 0 {
+			return nil, nil
+		}
+		failed = make(map[auditRequestKey]bool)
+		for _, doc := range docs {
+			failed[auditRequestKey{doc.ConversationID, doc.RequestID}] = true
+		}
+	}
+
+	// Walk back from the most recent request so that the limit keeps
+	// the latest ones.
+	var requests []auditLogDoc
+	iter := coll.Find(requestQuery).Sort("-t", "-_id").Iter()
+	for {
+		var doc auditLogDoc
+		if !iter.Next(&doc) {
+			break
+		}
+		if failed != nil && !failed[auditRequestKey{doc.ConversationID, doc.RequestID}] {
+			continue
+		}
+		requests = append(requests, doc)
+		if filter.Limit > 0 && len(requests) >= filter.Limit {
+			break
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Annotate(err, "reading audit log requests")
+	}
+	if len(requests) == 0 {
+		return nil, nil
+	}
+	return joinAuditRequests(coll, requests)
+}
+
+// joinAuditRequests looks up the conversations and responses of the
+// requests passed, which are newest first, and returns the joined
+// entries oldest first.
+func joinAuditRequests(coll *mgo.Collection, requests []auditLogDoc) ([]AuditLogEntry, error) {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, doc := range requests {
+		if !seen[doc.ConversationID] {
+			seen[doc.ConversationID] = true
+			ids = append(ids, doc.ConversationID)
+		}
+	}
+
+	var docs []auditLogDoc
+	query := bson.D{
+		{"conversation-id", bson.D{{"$in", ids}}},
+		{"kind", bson.D{{"$in", []string{auditConversationKind, auditResponseKind}}}},
+	}
+	if err := coll.Find(query).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "reading audit log conversations")
+	}
+	conversations := make(map[string]auditlog.Conversation)
+	responses := make(map[auditRequestKey]*auditlog.ResponseErrors)
+	for _, doc := range docs {
+		switch doc.Kind {
+		case auditConversationKind:
+			conversations[doc.ConversationID] = doc.conversation()
+		case auditResponseKind:
+			responses[auditRequestKey{doc.ConversationID, doc.RequestID}] = doc.responseErrors()
+		}
+	}
+
+	entries := make([]AuditLogEntry, len(requests))
+	for i, doc := range requests {
+		conversation, ok := conversations[doc.ConversationID]
+		if !ok {
+			// The conversation has been discarded from the capped
+			// collection, but its IDs are still useful.
+			conversation = auditlog.Conversation{
+				ConversationID: doc.ConversationID,
+				ConnectionID:   doc.ConnectionID,
+			}
+		}
+		entries[len(requests)-1-i] = AuditLogEntry{
+			Conversation: conversation,
+			Request:      doc.request(),
+			Errors:       responses[auditRequestKey{doc.ConversationID, doc.RequestID}],
+		}
+	}
+	return entries, nil
+}
+
+func (doc *auditLogDoc) conversation() auditlog.Conversation {
+	return auditlog.Conversation{
+		Who:            doc.Who,
+		What:           doc.What,
+		When:           doc.Time.UTC().Format(time.RFC3339),
+		ModelName:      doc.ModelName,
+		ModelUUID:      doc.ModelUUID,
+		ConversationID: doc.ConversationID,
+		ConnectionID:   doc.ConnectionID,
+	}
+}
+
+func (doc *auditLogDoc) request() auditlog.Request {
+	return auditlog.Request{
+		ConversationID: doc.ConversationID,
+		ConnectionID:   doc.ConnectionID,
+		RequestID:      doc.RequestID,
+		When:           doc.Time.UTC().Format(time.RFC3339),
+		Facade:         doc.Facade,
+		Method:         doc.Method,
+		Version:        doc.Version,
+		Args:           doc.Args,
+	}
+}
+
+func (doc *auditLogDoc) responseErrors() *auditlog.ResponseErrors {
+	result := &auditlog.ResponseErrors{
+		ConversationID: doc.ConversationID,
+		ConnectionID:   doc.ConnectionID,
+		RequestID:      doc.RequestID,
+		When:           doc.Time.UTC().Format(time.RFC3339),
+	}
+	for _, e := range doc.Errors {
+		if e == nil {
+			result.Errors = append(result.Errors, nil)
+			continue
+		}
+		result.Errors = append(result.Errors, &auditlog.Error{
+			Message: e.Message,
+			Code:    e.Code,
+		})
+	}
+	return result
+}
@@ -0,0 +1,328 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type AuditLogSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&AuditLogSuite{})
+
+func (s *AuditLogSuite) TestCollectionCapped(c *gc.C) {
+	var stats bson.M
+	err := s.State.MongoSession().DB("logs").Run(bson.D{{"collStats", "audit"}}, &stats)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stats["capped"], jc.IsTrue)
+}
+
+func auditTime(hour, second int) string {
+	return time.Date(2020, 1, 1, hour, 0, second, 0, time.UTC).Format(time.RFC3339)
+}
+
+// addAuditRecords records two conversations, each with two requests.
+func (s *AuditLogSuite) addAuditRecords(c *gc.C) {
+	log := state.NewDbAuditLog(s.State)
+	defer log.Close()
+
+	for _, conversation := range []auditlog.Conversation{{
+		Who:            "bob",
+		What:           "juju remove-application mysql",
+		When:           auditTime(10, 0),
+		ModelName:      "bob/prod",
+		ModelUUID:      "uuid-1",
+		ConversationID: "aaaa",
+		ConnectionID:   "A1",
+	}, {
+		Who:            "alice",
+		What:           "juju status",
+		When:           auditTime(11, 0),
+		ModelName:      "alice/dev",
+		ModelUUID:      "uuid-2",
+		ConversationID: "bbbb",
+		ConnectionID:   "B2",
+	}} {
+		err := log.AddConversation(conversation)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	for _, request := range []auditlog.Request{{
+		ConversationID: "aaaa",
+		ConnectionID:   "A1",
+		RequestID:      1,
+		When:           auditTime(10, 1),
+		Facade:         "Application",
+		Method:         "Deploy",
+		Version:        11,
+		Args:           `{"applications":[]}`,
+	}, {
+		ConversationID: "aaaa",
+		ConnectionID:   "A1",
+		RequestID:      2,
+		When:           auditTime(10, 2),
+		Facade:         "Application",
+		Method:         "DestroyApplication",
+		Version:        11,
+	}, {
+		ConversationID: "bbbb",
+		ConnectionID:   "B2",
+		RequestID:      1,
+		When:           auditTime(11, 1),
+		Facade:         "Application",
+		Method:         "DestroyApplication",
+		Version:        11,
+	}, {
+		ConversationID: "bbbb",
+		ConnectionID:   "B2",
+		RequestID:      2,
+		When:           auditTime(11, 2),
+		Facade:         "Client",
+		Method:         "FullStatus",
+		Version:        2,
+	}} {
+		err := log.AddRequest(request)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	for _, response := range []auditlog.ResponseErrors{{
+		ConversationID: "aaaa",
+		ConnectionID:   "A1",
+		RequestID:      1,
+		When:           auditTime(10, 1),
+	}, {
+		ConversationID: "aaaa",
+		ConnectionID:   "A1",
+		RequestID:      2,
+		When:           auditTime(10, 3),
+		Errors:         []*auditlog.Error{nil, {Message: "boom", Code: "not found"}},
+	}, {
+		ConversationID: "bbbb",
+		ConnectionID:   "B2",
+		RequestID:      2,
+		When:           auditTime(11, 2),
+		Errors:         []*auditlog.Error{{Message: "denied", Code: "unauthorized access"}},
+	}} {
+		err := log.AddResponse(response)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *AuditLogSuite) TestQueryAuditLog(c *gc.C) {
+	s.addAuditRecords(c)
+
+	entries, err := state.QueryAuditLog(s.State, state.AuditLogFilter{Method: "DestroyApplication"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, []state.AuditLogEntry{{
+		Conversation: auditlog.Conversation{
+			Who:            "bob",
+			What:           "juju remove-application mysql",
+			When:           "2020-01-01T10:00:00Z",
+			ModelName:      "bob/prod",
+			ModelUUID:      "uuid-1",
+			ConversationID: "aaaa",
+			ConnectionID:   "A1",
+		},
+		Request: auditlog.Request{
+			ConversationID: "aaaa",
+			ConnectionID:   "A1",
+			RequestID:      2,
+			When:           "2020-01-01T10:00:02Z",
+			Facade:         "Application",
+			Method:         "DestroyApplication",
+			Version:        11,
+		},
+		Errors: &auditlog.ResponseErrors{
+			ConversationID: "aaaa",
+			ConnectionID:   "A1",
+			RequestID:      2,
+			When:           "2020-01-01T10:00:03Z",
+			Errors:         []*auditlog.Error{nil, {Message: "boom", Code: "not found"}},
+		},
+	}, {
+		Conversation: auditlog.Conversation{
+			Who:            "alice",
+			What:           "juju status",
+			When:           "2020-01-01T11:00:00Z",
+			ModelName:      "alice/dev",
+			ModelUUID:      "uuid-2",
+			ConversationID: "bbbb",
+			ConnectionID:   "B2",
+		},
+		Request: auditlog.Request{
+			ConversationID: "bbbb",
+			ConnectionID:   "B2",
+			RequestID:      1,
+			When:           "2020-01-01T11:00:01Z",
+			Facade:         "Application",
+			Method:         "DestroyApplication",
+			Version:        11,
+		},
+	}})
+}
+
+func (s *AuditLogSuite) TestQueryAuditLogFilters(c *gc.C) {
+	s.addAuditRecords(c)
+
+	at := func(hour, second int) time.Time {
+		return time.Date(2020, 1, 1, hour, 0, second, 0, time.UTC)
+	}
+	for i, test := range []struct {
+		about    string
+		filter   state.AuditLogFilter
+		expected []string
+	}{{
+		about:    "everything",
+		expected: []string{"aaaa/1", "aaaa/2", "bbbb/1", "bbbb/2"},
+	}, {
+		about:    "user",
+		filter:   state.AuditLogFilter{User: "bob"},
+		expected: []string{"aaaa/1", "aaaa/2"},
+	}, {
+		about:  "unknown user",
+		filter: state.AuditLogFilter{User: "eve"},
+	}, {
+		about:    "model name",
+		filter:   state.AuditLogFilter{Model: "alice/dev"},
+		expected: []string{"bbbb/1", "bbbb/2"},
+	}, {
+		about:    "model uuid",
+		filter:   state.AuditLogFilter{Model: "uuid-1"},
+		expected: []string{"aaaa/1", "aaaa/2"},
+	}, {
+		about:    "facade",
+		filter:   state.AuditLogFilter{Facade: "Client"},
+		expected: []string{"bbbb/2"},
+	}, {
+		about:    "facade and method",
+		filter:   state.AuditLogFilter{Facade: "Application", Method: "Deploy"},
+		expected: []string{"aaaa/1"},
+	}, {
+		about:    "time range",
+		filter:   state.AuditLogFilter{From: at(10, 2), To: at(11, 1)},
+		expected: []string{"aaaa/2", "bbbb/1"},
+	}, {
+		about:    "errors only",
+		filter:   state.AuditLogFilter{ErrorsOnly: true},
+		expected: []string{"aaaa/2", "bbbb/2"},
+	}, {
+		about:    "errors only for user",
+		filter:   state.AuditLogFilter{ErrorsOnly: true, User: "alice"},
+		expected: []string{"bbbb/2"},
+	}, {
+		about:    "limit keeps the latest",
+		filter:   state.AuditLogFilter{Limit: 3},
+		expected: []string{"aaaa/2", "bbbb/1", "bbbb/2"},
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		entries, err := state.QueryAuditLog(s.State, test.filter)
+		c.Assert(err, jc.ErrorIsNil)
+		var ids []string
+		for _, entry := range entries {
+			ids = append(ids, fmt.Sprintf("%s/%d", entry.Request.ConversationID, entry.Request.RequestID))
+		}
+		c.Check(ids, jc.DeepEquals, test.expected)
+	}
+}
+
+func (s *AuditLogSuite) TestAddRequestInvalidTime(c *gc.C) {
+	log := state.NewDbAuditLog(s.State)
+	defer log.Close()
+
+	err := log.AddRequest(auditlog.Request{When: "yesterday"})
+	c.Assert(err, gc.ErrorMatches, `audit record time "yesterday" not valid`)
+}
+
+func (s *AuditLogSuite) readAuditTailer(c *gc.C, tailer state.LogTailer, count int) []*state.LogRecord {
+	var records []*state.LogRecord
+	for len(records) < count {
+		select {
+		case rec, ok := <-tailer.Logs():
+			c.Assert(ok, jc.IsTrue, gc.Commentf("tailer stopped: %v", tailer.Err()))
+			records = append(records, rec)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for audit records, got %d", len(records))
+		}
+	}
+	return records
+}
+
+func (s *AuditLogSuite) TestAuditLogTailerNoTail(c *gc.C) {
+	s.addAuditRecords(c)
+
+	tailer, err := state.NewAuditLogTailer(s.State, state.LogTailerParams{
+		StartTime: time.Date(2020, 1, 1, 10, 0, 2, 0, time.UTC),
+		NoTail:    true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+
+	records := s.readAuditTailer(c, tailer, 6)
+	var entities, messages []string
+	for _, rec := range records {
+		c.Check(rec.ModelUUID, gc.Equals, s.State.ModelUUID())
+		c.Check(rec.Module, gc.Equals, state.AuditLogModule)
+		c.Check(rec.ID, gc.Equals, rec.Time.UnixNano())
+		entities = append(entities, rec.Entity)
+		messages = append(messages, rec.Message)
+	}
+	c.Check(entities, jc.DeepEquals, []string{
+		"user-bob", "user-bob", "user-alice", "user-alice", "user-alice", "user-alice",
+	})
+	c.Check(messages[0], gc.Equals, `{"request":{"conversation-id":"aaaa","connection-id":"A1","request-id":2,"when":"2020-01-01T10:00:02Z","facade":"Application","method":"DestroyApplication","version":11}}`)
+	c.Check(messages[1], gc.Equals, `{"errors":{"conversation-id":"aaaa","connection-id":"A1","request-id":2,"when":"2020-01-01T10:00:03Z","errors":[null,{"message":"boom","code":"not found"}]}}`)
+	c.Check(messages[2], gc.Equals, `{"conversation":{"who":"alice","what":"juju status","when":"2020-01-01T11:00:00Z","model-name":"alice/dev","model-uuid":"uuid-2","conversation-id":"bbbb","connection-id":"B2"}}`)
+	c.Check(records[1].Level, gc.Equals, loggo.WARNING)
+	c.Check(records[2].Level, gc.Equals, loggo.INFO)
+
+	select {
+	case _, ok := <-tailer.Logs():
+		c.Assert(ok, jc.IsFalse)
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for tailer to stop")
+	}
+}
+
+func (s *AuditLogSuite) TestAuditLogTailerOtherModel(c *gc.C) {
+	s.addAuditRecords(c)
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	tailer, err := state.NewAuditLogTailer(st, state.LogTailerParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+
+	select {
+	case rec := <-tailer.Logs():
+		c.Fatalf("unexpected record %v", rec)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *AuditLogSuite) TestAuditLogTailerTails(c *gc.C) {
+	tailer, err := state.NewAuditLogTailer(s.State, state.LogTailerParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+
+	s.addAuditRecords(c)
+	records := s.readAuditTailer(c, tailer, 9)
+	c.Check(records[0].Entity, gc.Equals, "user-bob")
+	c.Check(records[8].Entity, gc.Equals, "user-alice")
+
+	select {
+	case rec := <-tailer.Logs():
+		c.Fatalf("unexpected record %v", rec)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
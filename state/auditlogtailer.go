@@ -0,0 +1,269 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/core/auditlog"
+	jujuversion "github.com/juju/juju/version"
+)
+
+// AuditLogModule is the module given to the log records produced by
+// an audit log tailer.
+const AuditLogModule = "juju.apiserver.auditlog"
+
+// auditTailTimeout is how long the audit log tailer waits for new
+// records before checking whether it should stop.
+const auditTailTimeout = time.Second
+
+// maxAuditConversations limits the number of conversations the audit
+// log tailer remembers, so that the users making requests can be
+// reported without looking up each request's conversation.
+const maxAuditConversations = 1000
+
+// NewAuditLogTailer returns a LogTailer that streams the records in the
+// controller's audit log as log records, starting with those made at or
+// after params.StartTime. Each log record's message holds the audit
+// record in the format used by the audit log file, and its entity is
+// the user who made the request. Only StartTime and NoTail are used
+// from the params.
+//
+// The audit log belongs to the controller, so the records are only
+// streamed to tailers for the controller model; tailers for other
+// models never return any.
+//
+// Audit records are only written to the second, so a tailer started
+// from the time of the last record received by a previous one will
+// repeat the records made during that second.
+func NewAuditLogTailer(st LogTailerState, params LogTailerParams) (LogTailer, error) {
+	session := st.MongoSession().Copy()
+	t := &auditLogTailer{
+		modelUUID:     st.ModelUUID(),
+		isController:  st.IsController(),
+		coll:          session.DB(logsDB).C(auditLogC).With(session),
+		params:        params,
+		logCh:         make(chan *LogRecord),
+		lastTime:      params.StartTime.UTC(),
+		lastIds:       make(map[bson.ObjectId]bool),
+		conversations: make(map[string]*auditLogDoc),
+	}
+	t.tomb.Go(func() error {
+		defer close(t.logCh)
+		defer session.Close()
+		err := t.loop()
+		return errors.Cause(err)
+	})
+	return t, nil
+}
+
+type auditLogTailer struct {
+	tomb         tomb.Tomb
+	modelUUID    string
+	isController bool
+	coll         *mgo.Collection
+	params       LogTailerParams
+	logCh        chan *LogRecord
+
+	// lastTime is the time of the most recent record sent, and lastIds
+	// holds the ids of the records sent with that time, so they can be
+	// skipped when the tail is restarted.
+	lastTime time.Time
+	lastIds  map[bson.ObjectId]bool
+
+	conversations map[string]*auditLogDoc
+}
+
+// Logs implements the LogTailer interface.
+func (t *auditLogTailer) Logs() <-chan *LogRecord {
+	return t.logCh
+}
+
+// Dying implements the LogTailer interface.
+func (t *auditLogTailer) Dying() <-chan struct{} {
+	return t.tomb.Dying()
+}
+
+// Stop implements the LogTailer interface.
+func (t *auditLogTailer) Stop() error {
+	t.tomb.Kill(nil)
+	return t.tomb.Wait()
+}
+
+// Err implements the LogTailer interface.
+func (t *auditLogTailer) Err() error {
+	return t.tomb.Err()
+}
+
+func (t *auditLogTailer) loop() error {
+	// NOTE: don't trace or annotate the errors returned
+	// from this method as the error may be tomb.ErrDying, and
+	// the tomb code is sensitive about equality.
+	if !t.isController {
+		if t.params.NoTail {
+			return nil
+		}
+		<-t.tomb.Dying()
+		return tomb.ErrDying
+	}
+	for {
+		query := t.coll.Find(bson.D{{"t", bson.D{{"$gte", t.lastTime}}}})
+		var iter *mgo.Iter
+		if t.params.NoTail {
+			iter = query.Sort("t", "_id").Iter()
+		} else {
+			// The audit log is a capped collection, so it can be
+			// tailed in insertion order.
+			iter = query.Tail(auditTailTimeout)
+		}
+		err := t.processIter(iter)
+		if closeErr := iter.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+		if t.params.NoTail {
+			return nil
+		}
+		// The cursor dies if the collection is empty, or if it falls
+		// behind the records being discarded, so wait and restart it.
+		select {
+		case <-t.tomb.Dying():
+			return tomb.ErrDying
+		case <-time.After(auditTailTimeout):
+		}
+	}
+}
+
+// processIter sends the records returned by iter, until the cursor
+// dies.
+func (t *auditLogTailer) processIter(iter *mgo.Iter) error {
+	for {
+		var doc auditLogDoc
+		for iter.Next(&doc) {
+			if err := t.sendDoc(&doc); err != nil {
+				return err
+			}
+			doc = auditLogDoc{}
+		}
+		if err := iter.Err(); err != nil {
+			return errors.Annotate(err, "cannot read audit log")
+		}
+		if !iter.Timeout() {
+			return nil
+		}
+		select {
+		case <-t.tomb.Dying():
+			return tomb.ErrDying
+		default:
+		}
+	}
+}
+
+func (t *auditLogTailer) sendDoc(doc *auditLogDoc) error {
+	if t.lastIds[doc.Id] {
+		return nil
+	}
+	if !doc.Time.Equal(t.lastTime) {
+		t.lastTime = doc.Time
+		t.lastIds = make(map[bson.ObjectId]bool)
+	}
+	t.lastIds[doc.Id] = true
+
+	if doc.Kind == auditConversationKind {
+		if len(t.conversations) >= maxAuditConversations {
+			t.conversations = make(map[string]*auditLogDoc)
+		}
+		conversation := *doc
+		t.conversations[doc.ConversationID] = &conversation
+	}
+	conversation, err := t.conversation(doc.ConversationID)
+	if err != nil {
+		return err
+	}
+	if conversation == nil || !names.IsValidUser(conversation.Who) {
+		// The conversation has already been discarded from the
+		// capped collection, so there's no user to report.
+		logger.Debugf("skipping audit %s record for unknown conversation %q", doc.Kind, doc.ConversationID)
+		return nil
+	}
+	rec, err := auditLogRecord(doc, conversation.Who, t.modelUUID)
+	if err != nil {
+		return err
+	}
+	select {
+	case <-t.tomb.Dying():
+		return tomb.ErrDying
+	case t.logCh <- rec:
+	}
+	return nil
+}
+
+// conversation returns the conversation with the given ID, or nil if
+// it can't be found.
+func (t *auditLogTailer) conversation(id string) (*auditLogDoc, error) {
+	if conversation, ok := t.conversations[id]; ok {
+		return conversation, nil
+	}
+	var doc auditLogDoc
+	err := t.coll.Find(bson.D{
+		{"kind", auditConversationKind},
+		{"conversation-id", id},
+	}).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot read audit log conversation")
+	}
+	if len(t.conversations) >= maxAuditConversations {
+		t.conversations = make(map[string]*auditLogDoc)
+	}
+	t.conversations[id] = &doc
+	return &doc, nil
+}
+
+// auditLogRecord converts an audit log document into a log record
+// attributed to the given user, and belonging to the given model.
+func auditLogRecord(doc *auditLogDoc, who, modelUUID string) (*LogRecord, error) {
+	var record auditlog.Record
+	level := loggo.INFO
+	switch doc.Kind {
+	case auditConversationKind:
+		conversation := doc.conversation()
+		record.Conversation = &conversation
+	case auditRequestKind:
+		request := doc.request()
+		record.Request = &request
+	case auditResponseKind:
+		record.Errors = doc.responseErrors()
+		if doc.Failed {
+			level = loggo.WARNING
+		}
+	default:
+		return nil, errors.Errorf("unknown audit record kind %q", doc.Kind)
+	}
+	message, err := json.Marshal(record)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &LogRecord{
+		ID:        doc.Time.UnixNano(),
+		Time:      doc.Time,
+		ModelUUID: modelUUID,
+		Entity:    names.NewUserTag(who).String(),
+		Version:   jujuversion.Current,
+		Level:     level,
+		Module:    AuditLogModule,
+		Message:   string(message),
+	}, nil
+}
@@ -39,6 +39,7 @@ import (
 	"io"
 	"os"
 	"path"
+	"sort"
 	"strings"
 	"time"
 
@@ -61,6 +62,7 @@ var logger = loggo.GetLogger("juju.state.backups")
 var (
 	getFilesToBackUp = GetFilesToBackUp
 	getDBDumper      = NewDBDumper
+	getOplogDumper   = NewOplogDumper
 	runCreate        = create
 	finishMeta       = func(meta *Metadata, result *createResult) error {
 		return meta.MarkComplete(result.size, result.checksum)
@@ -87,7 +89,9 @@ func StoreArchive(stor filestorage.FileStorage, meta *Metadata, file io.Reader)
 // Backups is an abstraction around all juju backup-related functionality.
 type Backups interface {
 	// Create creates a new juju backup archive. It updates
-	// the provided metadata.
+	// the provided metadata. If the metadata has a Parent, an
+	// incremental backup holding only the oplog entries recorded
+	// since the parent backup is created.
 	Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy, noDownload bool) (string, error)
 
 	// Add stores the backup archive and returns its new ID.
@@ -99,13 +103,19 @@ type Backups interface {
 	// List returns the metadata for all stored backups.
 	List() ([]*Metadata, error)
 
-	// Remove deletes the backup from storage.
+	// Remove deletes the backup from storage. A backup that
+	// incremental backups follow on from cannot be removed.
 	Remove(id string) error
 
 	// Restore updates juju's state to the contents of the backup archive,
 	// it returns the tag string for the machine where the backup originated
 	// or error if the process fails.
 	Restore(backupId string, args RestoreArgs) (names.Tag, error)
+
+	// Preview restores the backup into a scratch database, as Restore
+	// would with the same until time, and returns a summary of the
+	// result without changing juju's state.
+	Preview(backupId string, until time.Time) (*RestoreSummary, error)
 }
 
 type backups struct {
@@ -123,6 +133,12 @@ func NewBackups(stor filestorage.FileStorage) Backups {
 // Create creates and stores a new juju backup archive (based on arguments)
 // and updates the provided metadata.  A filename to download the backup is provided.
 func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy, noDownload bool) (string, error) {
+	// Incremental backups are only useful alongside the backups they
+	// follow on from, so they must be kept where restore can find them.
+	if meta.Incremental() && !keepCopy {
+		return "", errors.New("incremental backups must be kept on the controller")
+	}
+
 	// TODO(fwereade): 2016-03-17 lp:1558657
 	meta.Started = time.Now().UTC()
 
@@ -142,12 +158,17 @@ func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy,
 		return "", errors.Annotate(err, "while listing files to back up")
 	}
 
-	dumper, err := getDBDumper(dbInfo)
+	var dumper DBDumper
+	if meta.Incremental() {
+		dumper, err = b.newOplogDumper(meta.Parent, dbInfo)
+	} else {
+		dumper, err = getDBDumper(dbInfo)
+	}
 	if err != nil {
 		return "", errors.Annotate(err, "while preparing for DB dump")
 	}
 
-	args := createArgs{paths.BackupDir, filesToBackUp, dumper, metadataFile, noDownload}
+	args := createArgs{paths.BackupDir, filesToBackUp, dumper, metadataFile, noDownload, oplogDatabases(dbInfo.Targets)}
 	result, err := runCreate(&args)
 	if err != nil {
 		return "", errors.Annotate(err, "while creating backup archive")
@@ -159,6 +180,13 @@ func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy,
 	if err != nil {
 		return "", errors.Annotate(err, "while updating metadata")
 	}
+	meta.OplogStart = result.oplogStart
+	meta.OplogEnd = result.oplogEnd
+	if meta.OplogEnd < dbInfo.OplogHead {
+		// Nothing was written to the oplog during the dump, so
+		// the backup is current as of the head read beforehand.
+		meta.OplogEnd = dbInfo.OplogHead
+	}
 
 	// Store the archive if asked by user
 	if keepCopy {
@@ -171,6 +199,19 @@ func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy,
 	return result.filename, nil
 }
 
+// newOplogDumper returns a dumper for an incremental backup that
+// follows on from the identified parent backup.
+func (b *backups) newOplogDumper(parentID string, dbInfo *DBInfo) (DBDumper, error) {
+	parent, err := b.metadata(parentID)
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting parent backup %q", parentID)
+	}
+	if parent.OplogEnd == 0 {
+		return nil, errors.Errorf("parent backup %q does not record an oplog position", parentID)
+	}
+	return getOplogDumper(dbInfo, parent.OplogEnd)
+}
+
 // Add stores the backup archive and returns its new ID.
 func (b *backups) Add(archive io.Reader, meta *Metadata) (string, error) {
 	// Store the archive.
@@ -233,6 +274,19 @@ func (b *backups) getArchiveFromFilename(name string) (_ *Metadata, _ io.ReadClo
 	return meta, readCloser, nil
 }
 
+// metadata returns the metadata of the identified stored backup.
+func (b *backups) metadata(id string) (*Metadata, error) {
+	rawmeta, err := b.storage.Metadata(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta, ok := rawmeta.(*Metadata)
+	if !ok {
+		return nil, errors.New("did not get a backups.Metadata value from storage")
+	}
+	return meta, nil
+}
+
 // List returns the metadata for all stored backups.
 func (b *backups) List() ([]*Metadata, error) {
 	metaList, err := b.storage.List()
@@ -251,7 +305,26 @@ func (b *backups) List() ([]*Metadata, error) {
 	return result, nil
 }
 
-// Remove deletes the backup from storage.
+// Remove deletes the backup from storage. Incremental backups cannot
+// be restored without the backups they follow on from, so a backup
+// with incremental children is only removed once they have been.
 func (b *backups) Remove(id string) error {
+	metas, err := b.List()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var children []string
+	for _, meta := range metas {
+		if meta.Parent == id {
+			children = append(children, meta.ID())
+		}
+	}
+	if len(children) > 0 {
+		sort.Strings(children)
+		return errors.Errorf(
+			"cannot remove backup %q: incremental backups %s follow on from it",
+			id, strings.Join(children, ", "),
+		)
+	}
 	return errors.Trace(b.storage.Remove(id))
 }
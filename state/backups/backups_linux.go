@@ -68,19 +68,24 @@ func ensureMongoService(agentConfig agent.Config) error {
 // * updates existing db entries to make sure they hold no references to
 // old instances
 // * updates config in all agents.
+//
+// An incremental backup is restored by restoring the full backup at the
+// base of its chain, then replaying the oplog entries held by each of the
+// incremental backups in turn, up to args.Until if that is set.
 func (b *backups) Restore(backupId string, args RestoreArgs) (names.Tag, error) {
-	meta, backupReader, err := b.Get(backupId)
+	chain, err := b.restoreChain(backupId, args.Until)
 	if err != nil {
 		return nil, errors.Annotatef(err, "could not fetch backup %q", backupId)
 	}
 
-	defer backupReader.Close()
-
-	workspace, err := NewArchiveWorkspaceReader(backupReader)
+	// The archives are kept in the database being restored, so they
+	// must all be unpacked before mongo is stopped.
+	workspaces, err := b.openChain(chain)
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot unpack backup file")
 	}
-	defer workspace.Close()
+	defer closeWorkspaces(workspaces)
+	meta, workspace := chain[0], workspaces[0]
 
 	// This might actually work, but we don't have a guarantee so we don't allow it.
 	if meta.Origin.Series != args.NewInstSeries {
@@ -223,6 +228,12 @@ func (b *backups) Restore(backupId string, args RestoreArgs) (names.Tag, error)
 	if err := restorer.Restore(workspace.DBDumpDir, oldDialInfo); err != nil {
 		return nil, errors.Annotate(err, "error restoring state from backup")
 	}
+	for i, increment := range workspaces[1:] {
+		logger.Infof("replaying incremental backup %q", chain[i+1].ID())
+		if err := restorer.ReplayOplog(increment.DBDumpDir, args.Until); err != nil {
+			return nil, errors.Annotatef(err, "error replaying incremental backup %q", chain[i+1].ID())
+		}
+	}
 
 	// Re-start replicaset with the new value for server address
 	logger.Infof("restarting replicaset")
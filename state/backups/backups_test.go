@@ -58,7 +58,7 @@ func (s *backupsSuite) checkFailure(c *gc.C, expected string) {
 
 	paths := backups.Paths{DataDir: "/var/lib/juju"}
 	targets := set.NewStrings("juju", "admin")
-	dbInfo := backups.DBInfo{"a", "b", "c", targets, mongo.Mongo32wt}
+	dbInfo := backups.DBInfo{"a", "b", "c", targets, mongo.Mongo32wt, 0}
 	meta := backupstesting.NewMetadataStarted()
 	meta.Notes = "some notes"
 
@@ -114,7 +114,7 @@ func (s *backupsSuite) testCreateOkay(c *gc.C, keepCopy, noDownload bool) {
 	// Run the backup.
 	paths := backups.Paths{BackupDir: backupDir, DataDir: dataDir}
 	targets := set.NewStrings("juju", "admin")
-	dbInfo := backups.DBInfo{"a", "b", "c", targets, mongo.Mongo32wt}
+	dbInfo := backups.DBInfo{"a", "b", "c", targets, mongo.Mongo32wt, 0}
 	meta := backupstesting.NewMetadataStarted()
 	backupstesting.SetOrigin(meta, "<model ID>", "<machine ID>", "<hostname>")
 	meta.Notes = "some notes"
@@ -165,6 +165,111 @@ func (s *backupsSuite) testCreateOkay(c *gc.C, keepCopy, noDownload bool) {
 	}
 }
 
+func (s *backupsSuite) assertCreateOplogEnd(c *gc.C, oplogHead, dumpedEnd, expected int64) {
+	result := backups.NewTestCreateResult(
+		ioutil.NopCloser(bytes.NewBufferString("<compressed tarball>")),
+		10,
+		"<checksum>",
+		"")
+	if dumpedEnd != 0 {
+		backups.SetCreateResultOplog(result, dumpedEnd-5, dumpedEnd)
+	}
+	_, testCreate := backups.NewTestCreate(result)
+	s.PatchValue(backups.RunCreate, testCreate)
+	s.PatchValue(backups.TestGetFilesToBackUp, func(root string, paths *backups.Paths, oldmachine string) ([]string, error) {
+		return []string{"<some file>"}, nil
+	})
+	s.PatchValue(backups.GetDBDumper, func(*backups.DBInfo) (backups.DBDumper, error) {
+		return &fakeDumper{}, nil
+	})
+	s.setStored("spam")
+
+	paths := backups.Paths{BackupDir: c.MkDir(), DataDir: c.MkDir()}
+	dbInfo := backups.DBInfo{"a", "b", "c", set.NewStrings("juju"), mongo.Mongo32wt, oplogHead}
+	meta := backupstesting.NewMetadataStarted()
+	_, err := s.api.Create(meta, &paths, &dbInfo, true, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(meta.OplogEnd, gc.Equals, expected)
+}
+
+func (s *backupsSuite) TestCreateNothingWrittenDuringDump(c *gc.C) {
+	// The dumped oplog is empty, so the backup is
+	// current as of the oplog head.
+	s.assertCreateOplogEnd(c, 42, 0, 42)
+}
+
+func (s *backupsSuite) TestCreateWrittenDuringDump(c *gc.C) {
+	s.assertCreateOplogEnd(c, 42, 50, 50)
+}
+
+func (s *backupsSuite) patchIncrementalCreate(c *gc.C, parentOplogEnd int64) *int64 {
+	parent := backupstesting.NewMetadataStarted()
+	parent.SetID("parent")
+	parent.OplogEnd = parentOplogEnd
+	s.Storage.ID = "spam"
+	s.Storage.Meta = parent
+
+	result := backups.NewTestCreateResult(
+		ioutil.NopCloser(bytes.NewBufferString("<compressed tarball>")),
+		10,
+		"<checksum>",
+		"")
+	backups.SetCreateResultOplog(result, parentOplogEnd, parentOplogEnd+10)
+	_, testCreate := backups.NewTestCreate(result)
+	s.PatchValue(backups.RunCreate, testCreate)
+	s.PatchValue(backups.TestGetFilesToBackUp, func(root string, paths *backups.Paths, oldmachine string) ([]string, error) {
+		return []string{"<some file>"}, nil
+	})
+	s.PatchValue(backups.GetDBDumper, func(*backups.DBInfo) (backups.DBDumper, error) {
+		c.Errorf("full DB dump requested")
+		return &fakeDumper{}, nil
+	})
+	since := new(int64)
+	s.PatchValue(backups.GetOplogDumper, func(info *backups.DBInfo, from int64) (backups.DBDumper, error) {
+		*since = from
+		return &fakeDumper{}, nil
+	})
+	return since
+}
+
+func (s *backupsSuite) createIncremental(keepCopy bool) (*backups.Metadata, error) {
+	paths := backups.Paths{BackupDir: "/var/lib/juju/backups", DataDir: "/var/lib/juju"}
+	dbInfo := backups.DBInfo{"a", "b", "c", set.NewStrings("juju"), mongo.Mongo32wt, 0}
+	meta := backupstesting.NewMetadataStarted()
+	meta.Parent = "parent"
+	_, err := s.api.Create(meta, &paths, &dbInfo, keepCopy, true)
+	return meta, err
+}
+
+func (s *backupsSuite) TestCreateIncremental(c *gc.C) {
+	since := s.patchIncrementalCreate(c, 42)
+
+	meta, err := s.createIncremental(true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(*since, gc.Equals, int64(42))
+	c.Check(s.Storage.Calls, jc.DeepEquals, []string{"Metadata", "Add", "Metadata"})
+	c.Check(meta.ID(), gc.Equals, "spam")
+	c.Check(meta.Incremental(), jc.IsTrue)
+	c.Check(meta.OplogStart, gc.Equals, int64(42))
+	c.Check(meta.OplogEnd, gc.Equals, int64(52))
+}
+
+func (s *backupsSuite) TestCreateIncrementalParentWithoutOplogPosition(c *gc.C) {
+	s.patchIncrementalCreate(c, 0)
+
+	_, err := s.createIncremental(true)
+	c.Assert(err, gc.ErrorMatches, `while preparing for DB dump: parent backup "parent" does not record an oplog position`)
+}
+
+func (s *backupsSuite) TestCreateIncrementalNotKept(c *gc.C) {
+	s.patchIncrementalCreate(c, 42)
+
+	_, err := s.createIncremental(false)
+	c.Assert(err, gc.ErrorMatches, "incremental backups must be kept on the controller")
+	c.Check(s.Storage.Calls, gc.HasLen, 0)
+}
+
 func (s *backupsSuite) TestCreateFailToListFiles(c *gc.C) {
 	s.PatchValue(backups.TestGetFilesToBackUp, func(root string, paths *backups.Paths, oldmachine string) ([]string, error) {
 		return nil, errors.New("failed!")
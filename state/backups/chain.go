@@ -0,0 +1,93 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+)
+
+// restoreChain returns the metadata of the backups needed to restore
+// the identified backup, starting with the full backup at the base of
+// its chain and followed by the incremental backups to replay on top
+// of it, in order. If until is not zero, incremental backups that are
+// not needed to reach that point in time are left out.
+func (b *backups) restoreChain(id string, until time.Time) ([]*Metadata, error) {
+	meta, err := b.metadata(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	chain := []*Metadata{meta}
+	seen := set.NewStrings(meta.ID())
+	for meta.Incremental() {
+		if seen.Contains(meta.Parent) {
+			return nil, errors.Errorf("backup %q is its own ancestor", meta.Parent)
+		}
+		seen.Add(meta.Parent)
+		parent, err := b.metadata(meta.Parent)
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting parent backup %q of %q", meta.Parent, meta.ID())
+		}
+		if meta.OplogStart != parent.OplogEnd {
+			return nil, errors.Errorf("backup %q does not follow on from its parent %q", meta.ID(), parent.ID())
+		}
+		chain = append([]*Metadata{parent}, chain...)
+		meta = parent
+	}
+
+	if until.IsZero() {
+		return chain, nil
+	}
+	base := chain[0]
+	if base.OplogEnd == 0 {
+		return nil, errors.NotSupportedf("restoring backup %q to a point in time", base.ID())
+	}
+	if OplogTime(base.OplogEnd).After(until) {
+		return nil, errors.Errorf("cannot restore to %s: backup %q was completed after then", until.UTC(), base.ID())
+	}
+	for i, increment := range chain[1:] {
+		if OplogTime(increment.OplogStart).After(until) {
+			return chain[:i+1], nil
+		}
+	}
+	return chain, nil
+}
+
+// openChain fetches the archives of the supplied backups and unpacks
+// each of them into a workspace. The caller is responsible for closing
+// the workspaces.
+func (b *backups) openChain(chain []*Metadata) (_ []*ArchiveWorkspace, err error) {
+	var workspaces []*ArchiveWorkspace
+	defer func() {
+		if err != nil {
+			closeWorkspaces(workspaces)
+		}
+	}()
+	for _, meta := range chain {
+		_, archive, err := b.storage.Get(meta.ID())
+		if err != nil {
+			return nil, errors.Annotatef(err, "could not fetch backup %q", meta.ID())
+		}
+		workspace, err := NewArchiveWorkspaceReader(archive)
+		archive.Close()
+		if workspace != nil {
+			workspaces = append(workspaces, workspace)
+		}
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot unpack backup %q", meta.ID())
+		}
+	}
+	return workspaces, nil
+}
+
+func closeWorkspaces(workspaces []*ArchiveWorkspace) {
+	for _, workspace := range workspaces {
+		if err := workspace.Close(); err != nil {
+			logger.Errorf("while removing backup workspace: %v", err)
+		}
+	}
+}
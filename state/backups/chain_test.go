@@ -0,0 +1,195 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/filestorage"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+	"github.com/juju/juju/testing"
+)
+
+type chainSuite struct {
+	testing.BaseSuite
+
+	storage chainStorage
+	api     backups.Backups
+}
+
+var _ = gc.Suite(&chainSuite{})
+
+func (s *chainSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+
+	s.storage = newChainStorage()
+	s.api = backups.NewBackups(s.storage)
+}
+
+func chainIDs(chain []*backups.Metadata) []string {
+	var ids []string
+	for _, meta := range chain {
+		ids = append(ids, meta.ID())
+	}
+	return ids
+}
+
+func (s *chainSuite) TestFullBackup(c *gc.C) {
+	chain, err := backups.RestoreChain(s.api, "full", time.Time{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(chainIDs(chain), jc.DeepEquals, []string{"full"})
+}
+
+func (s *chainSuite) TestIncrementalBackup(c *gc.C) {
+	chain, err := backups.RestoreChain(s.api, "inc2", time.Time{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(chainIDs(chain), jc.DeepEquals, []string{"full", "inc1", "inc2"})
+}
+
+func (s *chainSuite) TestUntil(c *gc.C) {
+	for i, test := range []struct {
+		until    int64
+		expected []string
+	}{{
+		until:    3600,
+		expected: []string{"full", "inc1"},
+	}, {
+		until:    7199,
+		expected: []string{"full", "inc1"},
+	}, {
+		until:    7200,
+		expected: []string{"full", "inc1", "inc2"},
+	}, {
+		until:    20000,
+		expected: []string{"full", "inc1", "inc2"},
+	}} {
+		c.Logf("test %d: until %d", i, test.until)
+		chain, err := backups.RestoreChain(s.api, "inc2", time.Unix(test.until, 0))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(chainIDs(chain), jc.DeepEquals, test.expected)
+	}
+}
+
+func (s *chainSuite) TestUntilBeforeBase(c *gc.C) {
+	_, err := backups.RestoreChain(s.api, "inc2", time.Unix(3599, 0))
+	c.Assert(err, gc.ErrorMatches, `cannot restore to 1970-01-01 00:59:59 \+0000 UTC: backup "full" was completed after then`)
+}
+
+func (s *chainSuite) TestUntilWithoutOplogPosition(c *gc.C) {
+	s.storage.add("legacy", "", 0, 0)
+	_, err := backups.RestoreChain(s.api, "legacy", time.Unix(3600, 0))
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *chainSuite) TestMissingParent(c *gc.C) {
+	delete(s.storage, "inc1")
+	_, err := backups.RestoreChain(s.api, "inc2", time.Time{})
+	c.Assert(err, gc.ErrorMatches, `getting parent backup "inc1" of "inc2": backup "inc1" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *chainSuite) TestDiscontinuous(c *gc.C) {
+	s.storage.add("inc3", "inc2", oplogTimestamp(10801, 1), oplogTimestamp(14400, 1))
+	_, err := backups.RestoreChain(s.api, "inc3", time.Time{})
+	c.Assert(err, gc.ErrorMatches, `backup "inc3" does not follow on from its parent "inc2"`)
+}
+
+func (s *chainSuite) TestCycle(c *gc.C) {
+	s.storage["full"].(*backups.Metadata).Parent = "inc2"
+	_, err := backups.RestoreChain(s.api, "inc2", time.Time{})
+	c.Assert(err, gc.ErrorMatches, `backup "inc2" is its own ancestor`)
+}
+
+func (s *chainSuite) TestRemoveWithChildren(c *gc.C) {
+	s.storage.add("inc1b", "inc1", oplogTimestamp(7200, 1), oplogTimestamp(9000, 1))
+	err := s.api.Remove("inc1")
+	c.Assert(err, gc.ErrorMatches, `cannot remove backup "inc1": incremental backups inc1b, inc2 follow on from it`)
+	c.Assert(s.storage, gc.HasLen, 4)
+}
+
+func (s *chainSuite) TestRemoveChain(c *gc.C) {
+	for _, id := range []string{"inc2", "inc1", "full"} {
+		err := s.api.Remove(id)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(s.storage, gc.HasLen, 0)
+}
+
+// chainStorage is a filestorage.FileStorage holding backup metadata
+// keyed by ID.
+type chainStorage map[string]filestorage.Metadata
+
+// newChainStorage returns a chainStorage holding a full backup followed
+// by two incremental backups, an hour apart.
+func newChainStorage() chainStorage {
+	s := make(chainStorage)
+	s.add("full", "", 0, oplogTimestamp(3600, 1))
+	s.add("inc1", "full", oplogTimestamp(3600, 1), oplogTimestamp(7200, 1))
+	s.add("inc2", "inc1", oplogTimestamp(7200, 1), oplogTimestamp(10800, 1))
+	return s
+}
+
+func (s chainStorage) add(id, parent string, start, end int64) {
+	meta := backupstesting.NewMetadata()
+	meta.SetID(id)
+	meta.Parent = parent
+	meta.OplogStart = start
+	meta.OplogEnd = end
+	s[id] = meta
+}
+
+func (s chainStorage) Metadata(id string) (filestorage.Metadata, error) {
+	meta, ok := s[id]
+	if !ok {
+		return nil, errors.NotFoundf("backup %q", id)
+	}
+	return meta, nil
+}
+
+func (s chainStorage) Get(id string) (filestorage.Metadata, io.ReadCloser, error) {
+	meta, err := s.Metadata(id)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	archive, err := backupstesting.NewArchiveBasic(meta.(*backups.Metadata))
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return meta, ioutil.NopCloser(archive), nil
+}
+
+func (s chainStorage) List() ([]filestorage.Metadata, error) {
+	var metas []filestorage.Metadata
+	for _, meta := range s {
+		metas = append(metas, meta)
+	}
+	return metas, nil
+}
+
+func (s chainStorage) Add(meta filestorage.Metadata, file io.Reader) (string, error) {
+	return "", errors.NotImplementedf("Add")
+}
+
+func (s chainStorage) SetFile(id string, file io.Reader) error {
+	return errors.NotImplementedf("SetFile")
+}
+
+func (s chainStorage) Remove(id string) error {
+	if _, ok := s[id]; !ok {
+		return errors.NotFoundf("backup %q", id)
+	}
+	delete(s, id)
+	return nil
+}
+
+func (s chainStorage) Close() error {
+	return nil
+}
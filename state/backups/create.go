@@ -12,6 +12,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/hash"
@@ -33,6 +34,9 @@ type createArgs struct {
 	db             DBDumper
 	metadataReader io.Reader
 	noDownload     bool
+	// oplogDatabases holds the databases whose changes are
+	// captured from the oplog.
+	oplogDatabases set.Strings
 }
 
 type createResult struct {
@@ -40,13 +44,15 @@ type createResult struct {
 	size        int64
 	checksum    string
 	filename    string
+	oplogStart  int64
+	oplogEnd    int64
 }
 
 // create builds a new backup archive file and returns it.  It also
 // updates the metadata with the file info.
 func create(args *createArgs) (_ *createResult, err error) {
 	// Prepare the backup builder.
-	builder, err := newBuilder(args.backupDir, args.filesToBackUp, args.db)
+	builder, err := newBuilder(args.backupDir, args.filesToBackUp, args.db, args.oplogDatabases)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -105,13 +111,19 @@ type builder struct {
 	// bundleFile is the inner archive file containing all the juju
 	// state-related files gathered during backup.
 	bundleFile io.WriteCloser
+	// oplogDatabases holds the databases whose oplog entries are
+	// taken into account when recording the span of the oplog.
+	oplogDatabases set.Strings
+	// oplogStart and oplogEnd are the timestamps of the first and
+	// last oplog entries for oplogDatabases in the DB dump.
+	oplogStart, oplogEnd int64
 }
 
 // newBuilder returns a new backup archive builder.  It creates the temp
 // directories which backup uses as its staging area while building the
 // archive.  It also creates the archive
 // (temp root, tarball root, DB dumpdir), along with any error.
-func newBuilder(backupDir string, filesToBackUp []string, db DBDumper) (b *builder, err error) {
+func newBuilder(backupDir string, filesToBackUp []string, db DBDumper, oplogDatabases set.Strings) (b *builder, err error) {
 	// Create the backups workspace root directory.
 	rootDir, err := ioutil.TempDir(backupDir, tempPrefix)
 	if err != nil {
@@ -120,11 +132,12 @@ func newBuilder(backupDir string, filesToBackUp []string, db DBDumper) (b *build
 
 	// Populate the builder.
 	b = &builder{
-		rootDir:       rootDir,
-		archivePaths:  NewNonCanonicalArchivePaths(rootDir),
-		filename:      filepath.Join(rootDir, TempFilename),
-		filesToBackUp: filesToBackUp,
-		db:            db,
+		rootDir:        rootDir,
+		archivePaths:   NewNonCanonicalArchivePaths(rootDir),
+		filename:       filepath.Join(rootDir, TempFilename),
+		filesToBackUp:  filesToBackUp,
+		db:             db,
+		oplogDatabases: oplogDatabases,
 	}
 	defer func() {
 		if err != nil {
@@ -286,6 +299,14 @@ func (b *builder) buildDBDump() error {
 		return errors.Annotate(err, "while dumping juju state database")
 	}
 
+	// Record the span of the oplog captured in the dump; the end is
+	// where an incremental backup taken later picks up from.
+	var err error
+	b.oplogStart, b.oplogEnd, err = readOplogRange(filepath.Join(dumpDir, oplogFilename), b.oplogDatabases)
+	if err != nil {
+		return errors.Annotate(err, "while reading dumped oplog")
+	}
+
 	return nil
 }
 
@@ -389,6 +410,8 @@ func (b *builder) result() (*createResult, error) {
 		size:        size,
 		checksum:    checksum,
 		filename:    b.filename,
+		oplogStart:  b.oplogStart,
+		oplogEnd:    b.oplogEnd,
 	}
 	return &result, nil
 }
@@ -9,6 +9,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"time"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -44,6 +45,10 @@ type DBInfo struct {
 	Targets set.Strings
 	// MongoVersion the version of the running mongo db.
 	MongoVersion mongo.Version
+	// OplogHead is the timestamp of the latest oplog entry for the
+	// targeted databases when the information was gathered, before
+	// anything is dumped.
+	OplogHead int64
 }
 
 // ignoredDatabases is the list of databases that should not be
@@ -60,6 +65,12 @@ type DBSession interface {
 	DatabaseNames() ([]string, error)
 }
 
+// oplogSession is implemented by sessions, such as *mgo.Session,
+// which can read the oplog.
+type oplogSession interface {
+	DB(name string) *mgo.Database
+}
+
 // NewDBInfo returns the information needed by backups to dump
 // the database.
 func NewDBInfo(mgoInfo *mongo.MongoInfo, session DBSession, version mongo.Version) (*DBInfo, error) {
@@ -80,9 +91,35 @@ func NewDBInfo(mgoInfo *mongo.MongoInfo, session DBSession, version mongo.Versio
 		info.Username = mgoInfo.Tag.String()
 	}
 
+	// The dump only records the oplog entries written while it runs,
+	// so the head is read first; a backup taken while nothing changes
+	// still records where a later incremental backup picks up from.
+	if session, ok := session.(oplogSession); ok {
+		info.OplogHead, err = oplogHead(session, oplogDatabases(targets))
+		if err != nil {
+			return nil, errors.Annotate(err, "reading oplog head")
+		}
+	}
+
 	return &info, nil
 }
 
+// oplogHead returns the timestamp of the latest oplog entry recording
+// a change to one of the supplied databases, or zero if there is none.
+func oplogHead(session oplogSession, databases set.Strings) (int64, error) {
+	var entry struct {
+		Timestamp bson.MongoTimestamp `bson:"ts"`
+	}
+	query := bson.M{"ns": bson.RegEx{Pattern: oplogNamespacePattern(databases.SortedValues())}}
+	err := session.DB("local").C("oplog.rs").Find(query).Sort("-$natural").One(&entry)
+	if err == mgo.ErrNotFound {
+		return 0, nil
+	} else if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return int64(entry.Timestamp), nil
+}
+
 func getBackupTargetDatabases(session DBSession) (set.Strings, error) {
 	dbNames, err := session.DatabaseNames()
 	if err != nil {
@@ -253,6 +290,11 @@ var getMongorestorePath = func() (string, error) {
 type DBRestorer interface {
 	// Dump something to dumpDir.
 	Restore(dumpDir string, dialInfo *mgo.DialInfo) error
+
+	// ReplayOplog applies the oplog entries dumped in dumpDir to the
+	// restored database. If limit is not zero, entries recorded after
+	// it are not applied.
+	ReplayOplog(dumpDir string, limit time.Time) error
 }
 
 type mongoRestorer struct {
@@ -304,6 +346,32 @@ func (md *mongoRestorer24) Restore(dumpDir string, _ *mgo.DialInfo) error {
 	return nil
 }
 
+func (md *mongoRestorer24) replayOptions(dumpDir string, limit time.Time) []string {
+	dbDir := filepath.Join(agent.DefaultPaths.DataDir, "db")
+	options := []string{
+		"--journal",
+		"--oplogReplay",
+	}
+	options = append(options, oplogLimitOptions(limit)...)
+	return append(options, "--dbpath", dbDir, dumpDir)
+}
+
+func (md *mongoRestorer24) ReplayOplog(dumpDir string, limit time.Time) error {
+	logger.Debugf("stopping mongo service for oplog replay")
+	if err := md.stopMongo(); err != nil {
+		return errors.Annotate(err, "cannot stop mongo to replay oplog")
+	}
+	options := md.replayOptions(dumpDir, limit)
+	logger.Infof("replaying oplog with params %v", options)
+	if err := md.runCommandFn(md.binPath, options...); err != nil {
+		return errors.Annotate(err, "error replaying oplog")
+	}
+	if err := md.startMongo(); err != nil {
+		return errors.Annotate(err, "cannot start mongo after oplog replay")
+	}
+	return nil
+}
+
 // GetDB wraps mgo.Session.DB to ease testing.
 func GetDB(s string, session MongoSession) MongoDB {
 	return session.DB(s)
@@ -403,6 +471,20 @@ func (md *mongoRestorer32) options(dumpDir string) []string {
 	return options
 }
 
+func (md *mongoRestorer32) replayOptions(dumpDir string, limit time.Time) []string {
+	options := []string{
+		"--ssl",
+		"--sslAllowInvalidCertificates",
+		"--authenticationDatabase", "admin",
+		"--host", md.Addrs[0],
+		"--username", md.Username,
+		"--password", md.Password,
+		"--oplogReplay",
+	}
+	options = append(options, oplogLimitOptions(limit)...)
+	return append(options, dumpDir)
+}
+
 // MongoDB represents a mgo.DB.
 type MongoDB interface {
 	UpsertUser(*mgo.User) error
@@ -523,3 +605,12 @@ func (md *mongoRestorer32) Restore(dumpDir string, dialInfo *mgo.DialInfo) error
 	}
 	return nil
 }
+
+func (md *mongoRestorer32) ReplayOplog(dumpDir string, limit time.Time) error {
+	options := md.replayOptions(dumpDir, limit)
+	logger.Infof("replaying oplog with params %v", options)
+	if err := md.runCommandFn(md.binPath, options...); err != nil {
+		return errors.Annotate(err, "error replaying oplog")
+	}
+	return nil
+}
@@ -31,7 +31,7 @@ func (s *dumpSuite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
 
 	targets := set.NewStrings("juju", "admin")
-	s.dbInfo = &backups.DBInfo{"a", "b", "c", targets, mongo.Mongo24}
+	s.dbInfo = &backups.DBInfo{"a", "b", "c", targets, mongo.Mongo24, 0}
 	s.targets = targets
 	s.dumpDir = c.MkDir()
 }
@@ -5,6 +5,7 @@ package backups_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -175,3 +176,55 @@ func (s *mongoRestoreSuite) TestRestoreFailsOnOlderMongo(c *gc.C) {
 	_, err := backups.NewDBRestorer(args)
 	c.Assert(err, gc.ErrorMatches, "restore mongo version 3.2/wiredTiger into version 2.4/mmapv1 not supported")
 }
+
+func (s *mongoRestoreSuite) TestReplayOplog24(c *gc.C) {
+	s.PatchValue(backups.GetMongorestorePath, func() (string, error) { return "/a/fake/mongorestore", nil })
+	var ranWithArgs []string
+	var calls []string
+	args := backups.RestorerArgs{
+		Version: mongo.Mongo24,
+		RunCommandFn: func(c string, args ...string) error {
+			calls = append(calls, "run")
+			ranWithArgs = args
+			return nil
+		},
+		StartMongo: func() error { calls = append(calls, "start"); return nil },
+		StopMongo:  func() error { calls = append(calls, "stop"); return nil },
+	}
+
+	s.PatchValue(backups.MongoInstalledVersion, func() mongo.Version { return mongo.Mongo24 })
+	restorer, err := backups.NewDBRestorer(args)
+	c.Assert(err, jc.ErrorIsNil)
+	err = restorer.ReplayOplog("fakePath", time.Time{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(calls, jc.DeepEquals, []string{"stop", "run", "start"})
+	c.Assert(ranWithArgs, gc.DeepEquals, []string{"--journal", "--oplogReplay", "--dbpath", "/var/lib/juju/db", "fakePath"})
+}
+
+func (s *mongoRestoreSuite) TestReplayOplog32(c *gc.C) {
+	s.PatchValue(backups.GetMongorestorePath, func() (string, error) { return "/a/fake/mongorestore", nil })
+	var ranCommand string
+	var ranWithArgs []string
+	args := backups.RestorerArgs{
+		DialInfo: &mgo.DialInfo{
+			Username: "fakeUsername",
+			Password: "fakePassword",
+			Addrs:    []string{"127.0.0.1"},
+		},
+		Version: mongo.Mongo32wt,
+		RunCommandFn: func(c string, args ...string) error {
+			ranCommand = c
+			ranWithArgs = args
+			return nil
+		},
+	}
+	s.PatchValue(backups.MongoInstalledVersion, func() mongo.Version { return mongo.Mongo32wt })
+	restorer, err := backups.NewDBRestorer(args)
+	c.Assert(err, jc.ErrorIsNil)
+	err = restorer.ReplayOplog("fakePath", time.Unix(1577880000, 500))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(ranCommand, gc.Equals, "/a/fake/mongorestore")
+	c.Assert(ranWithArgs, gc.DeepEquals, []string{"--ssl", "--sslAllowInvalidCertificates", "--authenticationDatabase", "admin", "--host", "127.0.0.1", "--username", "fakeUsername", "--password", "fakePassword", "--oplogReplay", "--oplogLimit", "1577880001", "fakePath"})
+}
@@ -12,16 +12,19 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	"github.com/juju/utils/filestorage"
+	"gopkg.in/mgo.v2"
 
 	"github.com/juju/juju/state"
 )
 
 var (
-	Create        = create
-	FileTimestamp = fileTimestamp
+	Create         = create
+	FileTimestamp  = fileTimestamp
+	ReadOplogRange = readOplogRange
 
 	TestGetFilesToBackUp  = &getFilesToBackUp
 	GetDBDumper           = &getDBDumper
+	GetOplogDumper        = &getOplogDumper
 	RunCreate             = &runCreate
 	FinishMeta            = &finishMeta
 	StoreArchiveRef       = &storeArchive
@@ -121,6 +124,49 @@ func NewTestCreateResult(file io.ReadCloser, size int64, checksum, filename stri
 	return &result
 }
 
+// SetCreateResultOplog sets the oplog range recorded in a create() result.
+func SetCreateResultOplog(result *createResult, start, end int64) {
+	result.oplogStart = start
+	result.oplogEnd = end
+}
+
+// RestoreChain returns the backups needed to restore the identified backup.
+func RestoreChain(b Backups, id string, until time.Time) ([]*Metadata, error) {
+	return b.(*backups).restoreChain(id, until)
+}
+
+// FakeScratchDB is a scratch database backed by an existing session.
+type FakeScratchDB struct {
+	Address string
+	Session *mgo.Session
+	Stopped bool
+}
+
+func (db *FakeScratchDB) Addr() string {
+	return db.Address
+}
+
+func (db *FakeScratchDB) Dial() (*mgo.Session, error) {
+	return db.Session.Copy(), nil
+}
+
+func (db *FakeScratchDB) Stop() error {
+	db.Stopped = true
+	return nil
+}
+
+// PatchScratchMongo arranges for previews to use the supplied scratch
+// database.
+func PatchScratchMongo(patcher patcher, db *FakeScratchDB) {
+	patcher.PatchValue(&startScratchMongo, func() (scratchDB, error) {
+		return db, nil
+	})
+}
+
+type patcher interface {
+	PatchValue(dest, value interface{})
+}
+
 // NewTestCreate builds a new replacement for create() with the given result.
 func NewTestCreate(result *createResult) (*createArgs, func(*createArgs) (*createResult, error)) {
 	var received createArgs
@@ -68,6 +68,17 @@ type Metadata struct {
 	// Notes is an optional user-supplied annotation.
 	Notes string
 
+	// Parent is the ID of the backup that an incremental backup
+	// follows on from. It is empty for a full backup.
+	Parent string
+
+	// OplogStart and OplogEnd are the mongo oplog timestamps of the
+	// first and last oplog entries held in the backup. An incremental
+	// backup holds only oplog entries, starting from its parent's
+	// OplogEnd.
+	OplogStart int64
+	OplogEnd   int64
+
 	// TODO(wallyworld) - remove these ASAP
 	// These are only used by the restore CLI when re-bootstrapping.
 	// We will use a better solution but the way restore currently
@@ -147,6 +158,12 @@ func (m *Metadata) MarkComplete(size int64, checksum string) error {
 	return nil
 }
 
+// Incremental reports whether the backup holds only the oplog entries
+// recorded since its parent backup, rather than a full database dump.
+func (m *Metadata) Incremental() bool {
+	return m.Parent != ""
+}
+
 type flatMetadata struct {
 	ID string
 
@@ -168,6 +185,12 @@ type flatMetadata struct {
 	Version     version.Number
 	Series      string
 
+	// The incremental backup fields are omitted when empty, so
+	// that the metadata of a full backup is written as before.
+	Parent     string `json:",omitempty"`
+	OplogStart int64  `json:",omitempty"`
+	OplogEnd   int64  `json:",omitempty"`
+
 	CACert       string
 	CAPrivateKey string
 }
@@ -190,6 +213,9 @@ func (m *Metadata) AsJSONBuffer() (io.Reader, error) {
 		Hostname:     m.Origin.Hostname,
 		Version:      m.Origin.Version,
 		Series:       m.Origin.Series,
+		Parent:       m.Parent,
+		OplogStart:   m.OplogStart,
+		OplogEnd:     m.OplogEnd,
 		CACert:       m.CACert,
 		CAPrivateKey: m.CAPrivateKey,
 	}
@@ -241,6 +267,9 @@ func NewMetadataJSONReader(in io.Reader) (*Metadata, error) {
 		Version:  flat.Version,
 		Series:   flat.Series,
 	}
+	meta.Parent = flat.Parent
+	meta.OplogStart = flat.OplogStart
+	meta.OplogEnd = flat.OplogEnd
 
 	// TODO(wallyworld) - put these in a separate file.
 	meta.CACert = flat.CACert
@@ -98,6 +98,26 @@ func (s *metadataSuite) TestNewMetadataJSONReader(c *gc.C) {
 	c.Check(meta.Origin.Version.String(), gc.Equals, "1.21-alpha3")
 }
 
+func (s *metadataSuite) TestIncrementalJSONRoundTrip(c *gc.C) {
+	meta := backups.NewMetadata()
+	meta.Started = time.Date(2020, time.Month(1), 1, 12, 0, 0, 0, time.UTC)
+	err := meta.MarkComplete(10, "123af2cef")
+	c.Assert(err, jc.ErrorIsNil)
+	meta.Parent = "20200101-110000.asdf-zxcv-qwe"
+	meta.OplogStart = 6776038617366757377
+	meta.OplogEnd = 6776054080288522241
+
+	buf, err := meta.AsJSONBuffer()
+	c.Assert(err, jc.ErrorIsNil)
+	read, err := backups.NewMetadataJSONReader(buf)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(read.Incremental(), jc.IsTrue)
+	c.Check(read.Parent, gc.Equals, "20200101-110000.asdf-zxcv-qwe")
+	c.Check(read.OplogStart, gc.Equals, int64(6776038617366757377))
+	c.Check(read.OplogEnd, gc.Equals, int64(6776054080288522241))
+}
+
 func (s *metadataSuite) TestBuildMetadata(c *gc.C) {
 	archive, err := os.Create(filepath.Join(c.MkDir(), "juju-backup.tgz"))
 	c.Assert(err, jc.ErrorIsNil)
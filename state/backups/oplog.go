@@ -0,0 +1,239 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// oplogFilename is the name of the file in a dump directory holding
+// the oplog entries that mongorestore --oplogReplay applies.
+const oplogFilename = "oplog.bson"
+
+// ErrOplogGap is returned when the oplog no longer holds every entry
+// recorded since the parent of an incremental backup, in which case
+// a full backup is needed instead.
+var ErrOplogGap = errors.New("oplog does not reach back to the parent backup; a full backup is required")
+
+// oplogExcludedDatabases holds the databases whose changes are never
+// captured in an incremental backup: the stored backup archives
+// themselves, mongo's own local database and the logs, none of which
+// are restored.
+var oplogExcludedDatabases = set.NewStrings(storageDBName, "local", "logs")
+
+// oplogDatabases returns the databases, out of those targeted by a
+// backup, whose changes are captured from the oplog.
+func oplogDatabases(targets set.Strings) set.Strings {
+	return targets.Difference(oplogExcludedDatabases)
+}
+
+// oplogIncludes reports whether the oplog entry for the supplied
+// namespace records a change to one of the databases. A nil set
+// includes every entry.
+func oplogIncludes(databases set.Strings, ns string) bool {
+	if databases == nil {
+		return true
+	}
+	parts := strings.SplitN(ns, ".", 2)
+	return len(parts) == 2 && databases.Contains(parts[0])
+}
+
+// oplogNamespacePattern returns a regular expression matching the
+// oplog namespaces of the named databases.
+func oplogNamespacePattern(databases []string) string {
+	quoted := make([]string, len(databases))
+	for i, name := range databases {
+		quoted[i] = regexp.QuoteMeta(name)
+	}
+	return fmt.Sprintf(`^(%s)\.`, strings.Join(quoted, "|"))
+}
+
+// OplogTime returns the time of the supplied mongo oplog timestamp,
+// which has a resolution of one second.
+func OplogTime(ts int64) time.Time {
+	return time.Unix(int64(uint64(ts)>>32), 0).UTC()
+}
+
+type oplogDumper struct {
+	*DBInfo
+	// binPath is the path to the dump executable.
+	binPath string
+	// since is the oplog timestamp of the first entry to dump.
+	since int64
+	// databases holds the databases whose changes are dumped.
+	databases set.Strings
+}
+
+// NewOplogDumper returns a new value with a Dump method for dumping
+// the oplog entries recorded at or after the since timestamp, which
+// is the OplogEnd of the backup that the dump follows on from. Only
+// entries for the databases targeted by the backup are dumped. The
+// entries are written to the top of the dump directory, where
+// mongorestore expects to find them when replaying an oplog.
+func NewOplogDumper(info *DBInfo, since int64) (DBDumper, error) {
+	mongodumpPath, err := getMongodumpPath()
+	if err != nil {
+		return nil, errors.Annotate(err, "mongodump not available")
+	}
+
+	dumper := oplogDumper{
+		DBInfo:    info,
+		binPath:   mongodumpPath,
+		since:     since,
+		databases: oplogDatabases(info.Targets),
+	}
+	return &dumper, nil
+}
+
+func (od *oplogDumper) options(dumpDir string) []string {
+	options := []string{
+		"--ssl",
+		"--sslAllowInvalidCertificates",
+		"--authenticationDatabase", "admin",
+		"--host", od.Address,
+		"--username", od.Username,
+		"--password", od.Password,
+		"--out", dumpDir,
+		"--db", "local",
+		"--collection", "oplog.rs",
+		"--query", oplogQuery(od.since, od.databases),
+	}
+	return options
+}
+
+// oplogQuery returns a mongodump query selecting the oplog entries
+// for the supplied databases recorded at or after the supplied
+// timestamp. The entry at since itself is included so that Dump can
+// tell whether the oplog has been truncated beyond it; since is
+// always the timestamp of an entry for one of the databases. Entries
+// for the excluded databases are never selected, even if asked for,
+// so that replaying an increment cannot recreate them.
+func oplogQuery(since int64, databases set.Strings) string {
+	quoted := func(names []string) string {
+		pattern, _ := json.Marshal(oplogNamespacePattern(names))
+		return string(pattern)
+	}
+	return fmt.Sprintf(
+		`{"ts": {"$gte": {"$timestamp": {"t": %d, "i": %d}}}, "$and": [`+
+			`{"ns": {"$regex": %s, "$options": ""}}, `+
+			`{"ns": {"$not": {"$regex": %s, "$options": ""}}}]}`,
+		uint64(since)>>32, uint32(since),
+		quoted(databases.SortedValues()),
+		quoted(oplogExcludedDatabases.SortedValues()),
+	)
+}
+
+// Dump dumps the oplog entries recorded since the parent backup.
+func (od *oplogDumper) Dump(dumpDir string) error {
+	options := od.options(dumpDir)
+	if err := runCommandFn(od.binPath, options...); err != nil {
+		return errors.Annotate(err, "error dumping oplog")
+	}
+
+	// mongodump writes the collection as local/oplog.rs.bson, but
+	// mongorestore only replays a file named oplog.bson at the top
+	// of the dump.
+	localDir := filepath.Join(dumpDir, "local")
+	oplogFile := filepath.Join(dumpDir, oplogFilename)
+	if err := os.Rename(filepath.Join(localDir, "oplog.rs.bson"), oplogFile); err != nil {
+		return errors.Annotate(err, "while moving oplog dump")
+	}
+	if err := os.RemoveAll(localDir); err != nil {
+		return errors.Trace(err)
+	}
+
+	first, _, err := readOplogRange(oplogFile, od.databases)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if first != od.since {
+		return ErrOplogGap
+	}
+	return nil
+}
+
+// readOplogRange returns the timestamps of the first and last entries
+// for the supplied databases in the named oplog dump. Zero values are
+// returned if the file does not exist or holds no such entries.
+func readOplogRange(filename string, databases set.Strings) (first, last int64, err error) {
+	f, err := os.Open(filename)
+	if os.IsNotExist(err) {
+		return 0, 0, nil
+	} else if err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	for {
+		doc, err := readBSONDocument(r)
+		if err == io.EOF {
+			return first, last, nil
+		} else if err != nil {
+			return 0, 0, errors.Annotatef(err, "while reading %q", filename)
+		}
+		var entry struct {
+			Timestamp bson.MongoTimestamp `bson:"ts"`
+			Namespace string              `bson:"ns"`
+		}
+		if err := bson.Unmarshal(doc, &entry); err != nil {
+			return 0, 0, errors.Annotatef(err, "while reading %q", filename)
+		}
+		if !oplogIncludes(databases, entry.Namespace) {
+			continue
+		}
+		if first == 0 {
+			first = int64(entry.Timestamp)
+		}
+		last = int64(entry.Timestamp)
+	}
+}
+
+// readBSONDocument reads a single length-prefixed BSON document. It
+// returns io.EOF only if there are no more documents to read.
+func readBSONDocument(r io.Reader) ([]byte, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+	size := int(binary.LittleEndian.Uint32(header[:]))
+	if size < len(header)+1 {
+		return nil, errors.Errorf("invalid document size %d", size)
+	}
+	doc := make([]byte, size)
+	copy(doc, header[:])
+	if _, err := io.ReadFull(r, doc[len(header):]); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return doc, nil
+}
+
+// oplogLimitOptions returns the mongorestore options that stop an
+// oplog replay from applying entries recorded after the supplied
+// time. No options are returned for the zero time.
+func oplogLimitOptions(limit time.Time) []string {
+	if limit.IsZero() {
+		return nil
+	}
+	// The limit is exclusive, and oplog timestamps only have a
+	// resolution of one second.
+	return []string{"--oplogLimit", strconv.FormatInt(limit.Unix()+1, 10)}
+}
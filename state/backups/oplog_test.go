@@ -0,0 +1,202 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/testing"
+)
+
+type oplogSuite struct {
+	testing.BaseSuite
+
+	dbInfo  *backups.DBInfo
+	dumpDir string
+}
+
+var _ = gc.Suite(&oplogSuite{})
+
+func (s *oplogSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+
+	s.dbInfo = &backups.DBInfo{"a", "b", "c", set.NewStrings("juju"), mongo.Mongo32wt, 0}
+	s.dumpDir = c.MkDir()
+	s.PatchValue(backups.GetMongodumpPath, func() (string, error) {
+		return "bogusmongodump", nil
+	})
+}
+
+func oplogTimestamp(seconds, ordinal int64) int64 {
+	return seconds<<32 | ordinal
+}
+
+func writeOplog(c *gc.C, filename string, timestamps ...int64) {
+	entries := make([]bson.D, len(timestamps))
+	for i, ts := range timestamps {
+		entries[i] = oplogEntry(ts, "juju.units")
+	}
+	writeOplogEntries(c, filename, entries...)
+}
+
+func oplogEntry(ts int64, ns string) bson.D {
+	return bson.D{
+		{"ts", bson.MongoTimestamp(ts)},
+		{"op", "u"},
+		{"ns", ns},
+	}
+}
+
+func writeOplogEntries(c *gc.C, filename string, entries ...bson.D) {
+	var data []byte
+	for _, entry := range entries {
+		doc, err := bson.Marshal(entry)
+		c.Assert(err, jc.ErrorIsNil)
+		data = append(data, doc...)
+	}
+	err := ioutil.WriteFile(filename, data, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *oplogSuite) patchDump(c *gc.C, ranWithArgs *[]string, timestamps ...int64) {
+	s.PatchValue(backups.RunCommand, func(cmd string, args ...string) error {
+		c.Check(cmd, gc.Equals, "bogusmongodump")
+		*ranWithArgs = args
+		localDir := filepath.Join(s.dumpDir, "local")
+		err := os.Mkdir(localDir, 0700)
+		c.Assert(err, jc.ErrorIsNil)
+		writeOplog(c, filepath.Join(localDir, "oplog.rs.bson"), timestamps...)
+		return nil
+	})
+}
+
+func (s *oplogSuite) TestOplogTime(c *gc.C) {
+	ts := oplogTimestamp(1577880000, 7)
+	c.Assert(backups.OplogTime(ts), gc.Equals, time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC))
+}
+
+func (s *oplogSuite) TestReadOplogRange(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "oplog.bson")
+	writeOplog(c, filename, oplogTimestamp(100, 1), oplogTimestamp(100, 2), oplogTimestamp(105, 1))
+
+	first, last, err := backups.ReadOplogRange(filename, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(first, gc.Equals, oplogTimestamp(100, 1))
+	c.Check(last, gc.Equals, oplogTimestamp(105, 1))
+}
+
+func (s *oplogSuite) TestReadOplogRangeDatabases(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "oplog.bson")
+	writeOplogEntries(c, filename,
+		oplogEntry(oplogTimestamp(100, 1), "logs.logs.deadbeef"),
+		oplogEntry(oplogTimestamp(100, 2), "juju.units"),
+		oplogEntry(oplogTimestamp(101, 1), "blobstore.blobstore.chunks"),
+		oplogEntry(oplogTimestamp(102, 1), "backups.backups.chunks"),
+		oplogEntry(oplogTimestamp(103, 1), ""),
+	)
+
+	first, last, err := backups.ReadOplogRange(filename, set.NewStrings("juju", "blobstore"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(first, gc.Equals, oplogTimestamp(100, 2))
+	c.Check(last, gc.Equals, oplogTimestamp(101, 1))
+}
+
+func (s *oplogSuite) TestReadOplogRangeMissing(c *gc.C) {
+	first, last, err := backups.ReadOplogRange(filepath.Join(c.MkDir(), "oplog.bson"), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(first, gc.Equals, int64(0))
+	c.Check(last, gc.Equals, int64(0))
+}
+
+func (s *oplogSuite) TestReadOplogRangeTruncated(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "oplog.bson")
+	writeOplog(c, filename, oplogTimestamp(100, 1))
+	data, err := ioutil.ReadFile(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filename, data[:len(data)-2], 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, _, err = backups.ReadOplogRange(filename, nil)
+	c.Assert(err, gc.ErrorMatches, `while reading ".*oplog.bson": unexpected EOF`)
+}
+
+func (s *oplogSuite) TestDump(c *gc.C) {
+	since := oplogTimestamp(100, 2)
+	var ranWithArgs []string
+	s.patchDump(c, &ranWithArgs, since, oplogTimestamp(101, 1))
+
+	dumper, err := backups.NewOplogDumper(s.dbInfo, since)
+	c.Assert(err, jc.ErrorIsNil)
+	err = dumper.Dump(s.dumpDir)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(ranWithArgs, jc.DeepEquals, []string{
+		"--ssl",
+		"--sslAllowInvalidCertificates",
+		"--authenticationDatabase", "admin",
+		"--host", "a",
+		"--username", "b",
+		"--password", "c",
+		"--out", s.dumpDir,
+		"--db", "local",
+		"--collection", "oplog.rs",
+		"--query", `{"ts": {"$gte": {"$timestamp": {"t": 100, "i": 2}}}, "$and": [` +
+			`{"ns": {"$regex": "^(juju)\\.", "$options": ""}}, ` +
+			`{"ns": {"$not": {"$regex": "^(backups|local|logs)\\.", "$options": ""}}}]}`,
+	})
+
+	// The oplog is moved to where mongorestore expects it.
+	first, last, err := backups.ReadOplogRange(filepath.Join(s.dumpDir, "oplog.bson"), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(first, gc.Equals, since)
+	c.Check(last, gc.Equals, oplogTimestamp(101, 1))
+	_, err = os.Stat(filepath.Join(s.dumpDir, "local"))
+	c.Check(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *oplogSuite) TestDumpGap(c *gc.C) {
+	var ranWithArgs []string
+	s.patchDump(c, &ranWithArgs, oplogTimestamp(101, 1))
+
+	dumper, err := backups.NewOplogDumper(s.dbInfo, oplogTimestamp(100, 2))
+	c.Assert(err, jc.ErrorIsNil)
+	err = dumper.Dump(s.dumpDir)
+	c.Assert(errors.Cause(err), gc.Equals, backups.ErrOplogGap)
+}
+
+func (s *oplogSuite) TestDumpExcludesDatabases(c *gc.C) {
+	s.dbInfo.Targets = set.NewStrings("juju", "blobstore", "logs", "local")
+	var ranWithArgs []string
+	s.patchDump(c, &ranWithArgs, oplogTimestamp(100, 2))
+
+	dumper, err := backups.NewOplogDumper(s.dbInfo, oplogTimestamp(100, 2))
+	c.Assert(err, jc.ErrorIsNil)
+	err = dumper.Dump(s.dumpDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ranWithArgs[len(ranWithArgs)-1], gc.Equals,
+		`{"ts": {"$gte": {"$timestamp": {"t": 100, "i": 2}}}, "$and": [`+
+			`{"ns": {"$regex": "^(blobstore|juju)\\.", "$options": ""}}, `+
+			`{"ns": {"$not": {"$regex": "^(backups|local|logs)\\.", "$options": ""}}}]}`)
+}
+
+func (s *oplogSuite) TestDumpEmpty(c *gc.C) {
+	var ranWithArgs []string
+	s.patchDump(c, &ranWithArgs)
+
+	dumper, err := backups.NewOplogDumper(s.dbInfo, oplogTimestamp(100, 2))
+	c.Assert(err, jc.ErrorIsNil)
+	err = dumper.Dump(s.dumpDir)
+	c.Assert(errors.Cause(err), gc.Equals, backups.ErrOplogGap)
+}
@@ -4,6 +4,8 @@
 package backups
 
 import (
+	"time"
+
 	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/core/instance"
@@ -16,4 +18,8 @@ type RestoreArgs struct {
 	NewInstId      instance.Id
 	NewInstTag     names.Tag
 	NewInstSeries  string
+
+	// Until, if set, is the point in time to restore an incremental
+	// backup to. Oplog entries recorded after it are not replayed.
+	Until time.Time
 }
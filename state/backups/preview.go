@@ -0,0 +1,182 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+)
+
+// scratchMongoTimeout is how long to wait for a scratch mongod to
+// start accepting connections.
+const scratchMongoTimeout = 2 * time.Minute
+
+var startScratchMongo = newScratchMongo
+
+// scratchDB is a temporary database server that backups can be
+// restored into for inspection.
+type scratchDB interface {
+	// Addr returns the address that the server listens on.
+	Addr() string
+
+	// Dial returns a new session connected to the server.
+	Dial() (*mgo.Session, error)
+
+	// Stop stops the server and removes its data.
+	Stop() error
+}
+
+// Preview restores the identified backup, as Restore would with the
+// same until time, into a scratch mongod and returns a summary of the
+// result. The controller's own database is left untouched.
+func (b *backups) Preview(backupId string, until time.Time) (*RestoreSummary, error) {
+	chain, err := b.restoreChain(backupId, until)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	workspaces, err := b.openChain(chain)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer closeWorkspaces(workspaces)
+
+	mongorestorePath, err := getMongorestorePath()
+	if err != nil {
+		return nil, errors.Annotate(err, "mongorestore not available")
+	}
+	db, err := startScratchMongo()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot start scratch mongo")
+	}
+	defer func() {
+		if err := db.Stop(); err != nil {
+			logger.Errorf("while stopping scratch mongo: %v", err)
+		}
+	}()
+
+	for i, workspace := range workspaces {
+		options := scratchRestoreOptions(db.Addr(), workspace.DBDumpDir, i > 0, until)
+		logger.Debugf("restoring backup %q into scratch mongo with params %v", chain[i].ID(), options)
+		if err := runCommandFn(mongorestorePath, options...); err != nil {
+			return nil, errors.Annotatef(err, "cannot restore backup %q into scratch mongo", chain[i].ID())
+		}
+	}
+
+	session, err := db.Dial()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot connect to scratch mongo")
+	}
+	defer session.Close()
+	summary, err := SummarizeDB(session)
+	return summary, errors.Trace(err)
+}
+
+// scratchRestoreOptions returns the mongorestore options for restoring
+// a dump into a scratch mongod. A full backup is restored along with
+// the oplog entries recorded while it was dumped; an incremental backup
+// only holds oplog entries, which are replayed up to the limit.
+func scratchRestoreOptions(addr, dumpDir string, incremental bool, limit time.Time) []string {
+	options := []string{
+		"--host", addr,
+		"--oplogReplay",
+	}
+	if incremental {
+		options = append(options, oplogLimitOptions(limit)...)
+	}
+	return append(options, dumpDir)
+}
+
+type scratchMongo struct {
+	dir  string
+	addr string
+	cmd  *exec.Cmd
+}
+
+// newScratchMongo starts a mongod listening only on the loopback
+// interface, without authentication, with its data kept in a new
+// temporary directory.
+func newScratchMongo() (scratchDB, error) {
+	mongodPath, err := getMongodPath()
+	if err != nil {
+		return nil, errors.Annotate(err, "mongod not available")
+	}
+	port, err := findFreePort()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	dir, err := ioutil.TempDir("", "juju-restore-preview")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	m := &scratchMongo{
+		dir:  dir,
+		addr: net.JoinHostPort("127.0.0.1", strconv.Itoa(port)),
+	}
+	m.cmd = exec.Command(mongodPath,
+		"--dbpath", dir,
+		"--bind_ip", "127.0.0.1",
+		"--port", strconv.Itoa(port),
+	)
+	if err := m.cmd.Start(); err != nil {
+		os.RemoveAll(dir)
+		return nil, errors.Annotate(err, "cannot start mongod")
+	}
+
+	// Wait until the server accepts connections.
+	session, err := m.dial(scratchMongoTimeout)
+	if err != nil {
+		m.Stop()
+		return nil, errors.Trace(err)
+	}
+	session.Close()
+	return m, nil
+}
+
+// Addr is part of the scratchDB interface.
+func (m *scratchMongo) Addr() string {
+	return m.addr
+}
+
+// Dial is part of the scratchDB interface.
+func (m *scratchMongo) Dial() (*mgo.Session, error) {
+	return m.dial(10 * time.Second)
+}
+
+func (m *scratchMongo) dial(timeout time.Duration) (*mgo.Session, error) {
+	session, err := mgo.DialWithInfo(&mgo.DialInfo{
+		Addrs:   []string{m.addr},
+		Direct:  true,
+		Timeout: timeout,
+	})
+	return session, errors.Trace(err)
+}
+
+// Stop is part of the scratchDB interface.
+func (m *scratchMongo) Stop() error {
+	if err := m.cmd.Process.Kill(); err != nil {
+		return errors.Trace(err)
+	}
+	// The error from Wait only reports that the process was killed.
+	m.cmd.Wait()
+	return errors.Trace(os.RemoveAll(m.dir))
+}
+
+// findFreePort returns a TCP port on the loopback interface that is
+// not currently in use.
+func findFreePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"path/filepath"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+)
+
+type previewSuite struct {
+	mgoSuite
+
+	storage chainStorage
+	api     backups.Backups
+	db      *backups.FakeScratchDB
+	ran     [][]string
+}
+
+var _ = gc.Suite(&previewSuite{})
+
+func (s *previewSuite) SetUpTest(c *gc.C) {
+	s.mgoSuite.SetUpTest(c)
+
+	s.storage = newChainStorage()
+	s.api = backups.NewBackups(s.storage)
+
+	// The backup is restored into the test mongo, standing in for
+	// the scratch mongod.
+	s.db = &backups.FakeScratchDB{Address: "127.0.0.1:1234", Session: s.Session}
+	backups.PatchScratchMongo(s, s.db)
+	s.PatchValue(backups.GetMongorestorePath, func() (string, error) {
+		return "bogusmongorestore", nil
+	})
+	s.ran = nil
+	s.PatchValue(backups.RunCommand, func(cmd string, args ...string) error {
+		c.Check(cmd, gc.Equals, "bogusmongorestore")
+		s.ran = append(s.ran, args)
+		if len(s.ran) == 1 {
+			insertModelDocs(c, s.Session, controllerModelUUID, "controller", "2.8.1", []string{"0"}, nil)
+		}
+		return nil
+	})
+}
+
+// checkRestoreArgs checks the mongorestore arguments used for each
+// backup restored, ignoring the path of the dump directory.
+func (s *previewSuite) checkRestoreArgs(c *gc.C, expected ...[]string) {
+	c.Assert(s.ran, gc.HasLen, len(expected))
+	for i, args := range s.ran {
+		c.Check(filepath.Base(args[len(args)-1]), gc.Equals, "dump")
+		c.Check(args[:len(args)-1], jc.DeepEquals, expected[i])
+	}
+}
+
+func (s *previewSuite) TestPreview(c *gc.C) {
+	summary, err := s.api.Preview("full", time.Time{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.checkRestoreArgs(c, []string{"--host", "127.0.0.1:1234", "--oplogReplay"})
+	c.Check(summary, jc.DeepEquals, &backups.RestoreSummary{
+		Models: []backups.ModelSummary{{
+			UUID:         controllerModelUUID,
+			Name:         "controller",
+			Owner:        "admin",
+			AgentVersion: "2.8.1",
+			Machines:     []string{"0"},
+		}},
+	})
+	c.Check(s.db.Stopped, jc.IsTrue)
+}
+
+func (s *previewSuite) TestPreviewIncrementalUntil(c *gc.C) {
+	_, err := s.api.Preview("inc2", time.Unix(7000, 0))
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.checkRestoreArgs(c,
+		[]string{"--host", "127.0.0.1:1234", "--oplogReplay"},
+		[]string{"--host", "127.0.0.1:1234", "--oplogReplay", "--oplogLimit", "7001"},
+	)
+	c.Check(s.db.Stopped, jc.IsTrue)
+}
+
+func (s *previewSuite) TestPreviewChainError(c *gc.C) {
+	delete(s.storage, "inc1")
+	_, err := s.api.Preview("inc2", time.Time{})
+	c.Assert(err, gc.ErrorMatches, `getting parent backup "inc1" of "inc2": .*`)
+	c.Check(s.ran, gc.HasLen, 0)
+	c.Check(s.db.Stopped, jc.IsFalse)
+}
@@ -48,6 +48,12 @@ type storageMetaDoc struct {
 	Finished int64  `bson:"finished,minsize"`
 	Notes    string `bson:"notes,omitempty"`
 
+	// incremental backups
+
+	Parent     string `bson:"parent,omitempty"`
+	OplogStart int64  `bson:"oplogstart,omitempty"`
+	OplogEnd   int64  `bson:"oplogend,omitempty"`
+
 	// origin
 
 	Model    string         `bson:"model"`
@@ -124,6 +130,9 @@ func docAsMetadata(doc *storageMetaDoc) *Metadata {
 	meta := NewMetadata()
 	meta.Started = metadocUnixToTime(doc.Started)
 	meta.Notes = doc.Notes
+	meta.Parent = doc.Parent
+	meta.OplogStart = doc.OplogStart
+	meta.OplogEnd = doc.OplogEnd
 
 	meta.Origin.Model = doc.Model
 	meta.Origin.Machine = doc.Machine
@@ -175,6 +184,9 @@ func newStorageMetaDoc(meta *Metadata) storageMetaDoc {
 		doc.Finished = metadocTimeToUnix(*meta.Finished)
 	}
 	doc.Notes = meta.Notes
+	doc.Parent = meta.Parent
+	doc.OplogStart = meta.OplogStart
+	doc.OplogEnd = meta.OplogEnd
 
 	doc.Model = meta.Origin.Model
 	doc.Machine = meta.Origin.Machine
@@ -44,6 +44,9 @@ func (s *storageSuite) checkMeta(c *gc.C, meta, expected *backups.Metadata, id s
 	c.Check(meta.Origin.Machine, gc.Equals, expected.Origin.Machine)
 	c.Check(meta.Origin.Hostname, gc.Equals, expected.Origin.Hostname)
 	c.Check(meta.Origin.Version, gc.Equals, expected.Origin.Version)
+	c.Check(meta.Parent, gc.Equals, expected.Parent)
+	c.Check(meta.OplogStart, gc.Equals, expected.OplogStart)
+	c.Check(meta.OplogEnd, gc.Equals, expected.OplogEnd)
 	if meta.Stored() != nil && expected.Stored() != nil {
 		c.Check(meta.Stored().Unix(), gc.Equals, expected.Stored().Unix())
 	} else {
@@ -71,6 +74,20 @@ func (s *storageSuite) TestGetBackupMetadataFound(c *gc.C) {
 	s.checkMeta(c, meta, original, id)
 }
 
+func (s *storageSuite) TestGetBackupMetadataIncremental(c *gc.C) {
+	original := s.metadata(c)
+	original.Parent = "20140912-131927.spam"
+	original.OplogStart = 100<<32 | 1
+	original.OplogEnd = 200<<32 | 3
+	id, err := backups.AddBackupMetadata(s.State, original)
+	c.Assert(err, jc.ErrorIsNil)
+
+	meta, err := backups.GetBackupMetadata(s.State, id)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.checkMeta(c, meta, original, id)
+}
+
 func (s *storageSuite) TestGetBackupMetadataNotFound(c *gc.C) {
 	_, err := backups.GetBackupMetadata(s.State, "spam")
 
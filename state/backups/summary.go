@@ -0,0 +1,229 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"sort"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/naturalsort"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// jujuDBName is the name of the database holding juju's state.
+const jujuDBName = "juju"
+
+// RestoreSummary describes the contents of a controller's database,
+// so that operators can check that a backup holds what they expect
+// before restoring it.
+type RestoreSummary struct {
+	// Models holds a summary of each model, ordered by owner and
+	// then name.
+	Models []ModelSummary
+}
+
+// ModelSummary describes a single model in a controller's database.
+type ModelSummary struct {
+	UUID  string
+	Name  string
+	Owner string
+
+	// AgentVersion is the agent-version in the model's config.
+	AgentVersion string
+
+	// Machines holds the IDs of the model's machines.
+	Machines []string
+
+	// Applications holds the names of the model's applications.
+	Applications []string
+}
+
+// QualifiedName returns the name of the model qualified by its owner.
+func (m ModelSummary) QualifiedName() string {
+	return m.Owner + "/" + m.Name
+}
+
+// SummarizeDB returns a summary of the juju database served by the
+// supplied session. It reads the database documents directly, since
+// the database may be a scratch copy that no State can be opened on.
+func SummarizeDB(session *mgo.Session) (*RestoreSummary, error) {
+	db := session.DB(jujuDBName)
+
+	var modelDocs []struct {
+		UUID  string `bson:"_id"`
+		Name  string `bson:"name"`
+		Owner string `bson:"owner"`
+	}
+	if err := db.C("models").Find(nil).All(&modelDocs); err != nil {
+		return nil, errors.Annotate(err, "reading models")
+	}
+	models := make(map[string]*ModelSummary)
+	for _, doc := range modelDocs {
+		models[doc.UUID] = &ModelSummary{
+			UUID:  doc.UUID,
+			Name:  doc.Name,
+			Owner: doc.Owner,
+		}
+	}
+
+	var machineDocs []struct {
+		ModelUUID string `bson:"model-uuid"`
+		Id        string `bson:"machineid"`
+	}
+	fields := bson.M{"model-uuid": 1, "machineid": 1}
+	if err := db.C("machines").Find(nil).Select(fields).All(&machineDocs); err != nil {
+		return nil, errors.Annotate(err, "reading machines")
+	}
+	for _, doc := range machineDocs {
+		if model, ok := models[doc.ModelUUID]; ok {
+			model.Machines = append(model.Machines, doc.Id)
+		}
+	}
+
+	var applicationDocs []struct {
+		ModelUUID string `bson:"model-uuid"`
+		Name      string `bson:"name"`
+	}
+	fields = bson.M{"model-uuid": 1, "name": 1}
+	if err := db.C("applications").Find(nil).Select(fields).All(&applicationDocs); err != nil {
+		return nil, errors.Annotate(err, "reading applications")
+	}
+	for _, doc := range applicationDocs {
+		if model, ok := models[doc.ModelUUID]; ok {
+			model.Applications = append(model.Applications, doc.Name)
+		}
+	}
+
+	var summary RestoreSummary
+	for _, model := range models {
+		var settingsDoc struct {
+			Settings map[string]interface{} `bson:"settings"`
+		}
+		// The model's config is held in the settings document with the
+		// model's global key.
+		err := db.C("settings").FindId(model.UUID + ":e").One(&settingsDoc)
+		if err != nil && err != mgo.ErrNotFound {
+			return nil, errors.Annotatef(err, "reading config of model %q", model.QualifiedName())
+		}
+		if version, ok := settingsDoc.Settings["agent-version"].(string); ok {
+			model.AgentVersion = version
+		}
+		naturalsort.Sort(model.Machines)
+		sort.Strings(model.Applications)
+		summary.Models = append(summary.Models, *model)
+	}
+	sort.Slice(summary.Models, func(i, j int) bool {
+		return summary.Models[i].QualifiedName() < summary.Models[j].QualifiedName()
+	})
+	return &summary, nil
+}
+
+// RestoreDiff describes how restoring a backup would change the
+// contents of a controller.
+type RestoreDiff struct {
+	// AddedModels holds the qualified names of the models in the
+	// backup that the controller does not currently have.
+	AddedModels []string
+
+	// RemovedModels holds the qualified names of the models that the
+	// controller currently has but the backup does not.
+	RemovedModels []string
+
+	// ChangedModels describes the models held by both the controller
+	// and the backup whose contents differ.
+	ChangedModels []ModelDiff
+}
+
+// Empty returns whether restoring the backup would leave the summarised
+// contents of the controller unchanged.
+func (d RestoreDiff) Empty() bool {
+	return len(d.AddedModels) == 0 && len(d.RemovedModels) == 0 && len(d.ChangedModels) == 0
+}
+
+// ModelDiff describes how restoring a backup would change a model.
+type ModelDiff struct {
+	// Model is the qualified name of the model in the backup.
+	Model string
+
+	// CurrentAgentVersion and RestoredAgentVersion are the model's
+	// agent versions before and after the restore. They are only set
+	// if they differ.
+	CurrentAgentVersion  string
+	RestoredAgentVersion string
+
+	// AddedMachines and RemovedMachines hold the IDs of the machines
+	// that the restore would add to and remove from the model.
+	AddedMachines   []string
+	RemovedMachines []string
+
+	// AddedApplications and RemovedApplications hold the names of the
+	// applications that the restore would add to and remove from the
+	// model.
+	AddedApplications   []string
+	RemovedApplications []string
+}
+
+// DiffSummaries compares a summary of a controller's current contents
+// with a summary of a backup, and returns the changes that restoring
+// the backup would make. Models are matched by UUID.
+func DiffSummaries(current, restored *RestoreSummary) RestoreDiff {
+	currentModels := make(map[string]ModelSummary)
+	for _, model := range current.Models {
+		currentModels[model.UUID] = model
+	}
+
+	var diff RestoreDiff
+	for _, model := range restored.Models {
+		currentModel, ok := currentModels[model.UUID]
+		if !ok {
+			diff.AddedModels = append(diff.AddedModels, model.QualifiedName())
+			continue
+		}
+		delete(currentModels, model.UUID)
+		if modelDiff, changed := diffModels(currentModel, model); changed {
+			diff.ChangedModels = append(diff.ChangedModels, modelDiff)
+		}
+	}
+	for _, model := range current.Models {
+		if _, ok := currentModels[model.UUID]; ok {
+			diff.RemovedModels = append(diff.RemovedModels, model.QualifiedName())
+		}
+	}
+	return diff
+}
+
+func diffModels(current, restored ModelSummary) (ModelDiff, bool) {
+	diff := ModelDiff{Model: restored.QualifiedName()}
+	changed := false
+	if current.AgentVersion != restored.AgentVersion {
+		diff.CurrentAgentVersion = current.AgentVersion
+		diff.RestoredAgentVersion = restored.AgentVersion
+		changed = true
+	}
+
+	currentMachines := set.NewStrings(current.Machines...)
+	restoredMachines := set.NewStrings(restored.Machines...)
+	if added := restoredMachines.Difference(currentMachines); !added.IsEmpty() {
+		diff.AddedMachines = naturalsort.Sort(added.Values())
+		changed = true
+	}
+	if removed := currentMachines.Difference(restoredMachines); !removed.IsEmpty() {
+		diff.RemovedMachines = naturalsort.Sort(removed.Values())
+		changed = true
+	}
+
+	currentApplications := set.NewStrings(current.Applications...)
+	restoredApplications := set.NewStrings(restored.Applications...)
+	if added := restoredApplications.Difference(currentApplications); !added.IsEmpty() {
+		diff.AddedApplications = added.SortedValues()
+		changed = true
+	}
+	if removed := currentApplications.Difference(restoredApplications); !removed.IsEmpty() {
+		diff.RemovedApplications = removed.SortedValues()
+		changed = true
+	}
+	return diff, changed
+}
@@ -0,0 +1,176 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/testing"
+)
+
+// mgoSuite provides a mongo server for tests that read juju databases.
+type mgoSuite struct {
+	testing.BaseSuite
+	gitjujutesting.MgoSuite
+}
+
+func (s *mgoSuite) SetUpSuite(c *gc.C) {
+	s.BaseSuite.SetUpSuite(c)
+	s.MgoSuite.SetUpSuite(c)
+}
+
+func (s *mgoSuite) TearDownSuite(c *gc.C) {
+	s.MgoSuite.TearDownSuite(c)
+	s.BaseSuite.TearDownSuite(c)
+}
+
+func (s *mgoSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.MgoSuite.SetUpTest(c)
+}
+
+func (s *mgoSuite) TearDownTest(c *gc.C) {
+	s.MgoSuite.TearDownTest(c)
+	s.BaseSuite.TearDownTest(c)
+}
+
+type summarySuite struct {
+	mgoSuite
+}
+
+var _ = gc.Suite(&summarySuite{})
+
+const (
+	controllerModelUUID = "deadbeef-0bad-400d-8000-4b1d0d06f000"
+	defaultModelUUID    = "deadbeef-0bad-400d-8000-4b1d0d06f001"
+)
+
+// insertModelDocs writes the documents that SummarizeDB reads for a
+// model, in the shape that state writes them.
+func insertModelDocs(c *gc.C, session *mgo.Session, uuid, name, version string, machines, applications []string) {
+	db := session.DB("juju")
+	err := db.C("models").Insert(bson.M{"_id": uuid, "name": name, "owner": "admin"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = db.C("settings").Insert(bson.M{
+		"_id":        uuid + ":e",
+		"model-uuid": uuid,
+		"settings":   bson.M{"name": name, "agent-version": version},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	for _, id := range machines {
+		err := db.C("machines").Insert(bson.M{"_id": uuid + ":" + id, "model-uuid": uuid, "machineid": id})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	for _, name := range applications {
+		err := db.C("applications").Insert(bson.M{"_id": uuid + ":" + name, "model-uuid": uuid, "name": name})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *summarySuite) TestSummarizeDB(c *gc.C) {
+	insertModelDocs(c, s.Session, defaultModelUUID, "default", "2.8.1", []string{"10", "2", "1"}, []string{"mysql", "wordpress"})
+	insertModelDocs(c, s.Session, controllerModelUUID, "controller", "2.8.1", []string{"0"}, nil)
+
+	summary, err := backups.SummarizeDB(s.Session)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(summary, jc.DeepEquals, &backups.RestoreSummary{
+		Models: []backups.ModelSummary{{
+			UUID:         controllerModelUUID,
+			Name:         "controller",
+			Owner:        "admin",
+			AgentVersion: "2.8.1",
+			Machines:     []string{"0"},
+		}, {
+			UUID:         defaultModelUUID,
+			Name:         "default",
+			Owner:        "admin",
+			AgentVersion: "2.8.1",
+			Machines:     []string{"1", "2", "10"},
+			Applications: []string{"mysql", "wordpress"},
+		}},
+	})
+}
+
+func (s *summarySuite) TestSummarizeDBEmpty(c *gc.C) {
+	summary, err := backups.SummarizeDB(s.Session)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(summary.Models, gc.HasLen, 0)
+}
+
+func (s *summarySuite) TestDiffSummariesUnchanged(c *gc.C) {
+	summary := &backups.RestoreSummary{
+		Models: []backups.ModelSummary{{
+			UUID:     controllerModelUUID,
+			Name:     "controller",
+			Owner:    "admin",
+			Machines: []string{"0"},
+		}},
+	}
+	diff := backups.DiffSummaries(summary, summary)
+	c.Check(diff.Empty(), jc.IsTrue)
+}
+
+func (s *summarySuite) TestDiffSummaries(c *gc.C) {
+	current := &backups.RestoreSummary{
+		Models: []backups.ModelSummary{{
+			UUID:         controllerModelUUID,
+			Name:         "controller",
+			Owner:        "admin",
+			AgentVersion: "2.8.1",
+			Machines:     []string{"0"},
+		}, {
+			UUID:         defaultModelUUID,
+			Name:         "default",
+			Owner:        "admin",
+			AgentVersion: "2.8.1",
+			Machines:     []string{"1", "3"},
+			Applications: []string{"mysql", "wordpress"},
+		}, {
+			UUID:  "deadbeef-0bad-400d-8000-4b1d0d06f002",
+			Name:  "staging",
+			Owner: "bob",
+		}},
+	}
+	restored := &backups.RestoreSummary{
+		Models: []backups.ModelSummary{{
+			UUID:         controllerModelUUID,
+			Name:         "controller",
+			Owner:        "admin",
+			AgentVersion: "2.8.1",
+			Machines:     []string{"0"},
+		}, {
+			UUID:         defaultModelUUID,
+			Name:         "default",
+			Owner:        "admin",
+			AgentVersion: "2.7.6",
+			Machines:     []string{"1", "2", "10"},
+			Applications: []string{"mysql", "mediawiki"},
+		}, {
+			UUID:  "deadbeef-0bad-400d-8000-4b1d0d06f003",
+			Name:  "testing",
+			Owner: "bob",
+		}},
+	}
+
+	diff := backups.DiffSummaries(current, restored)
+	c.Check(diff, jc.DeepEquals, backups.RestoreDiff{
+		AddedModels:   []string{"bob/testing"},
+		RemovedModels: []string{"bob/staging"},
+		ChangedModels: []backups.ModelDiff{{
+			Model:                "admin/default",
+			CurrentAgentVersion:  "2.8.1",
+			RestoredAgentVersion: "2.7.6",
+			AddedMachines:        []string{"2", "10"},
+			RemovedMachines:      []string{"3"},
+			AddedApplications:    []string{"mediawiki"},
+			RemovedApplications:  []string{"wordpress"},
+		}},
+	})
+	c.Check(diff.Empty(), jc.IsFalse)
+}
@@ -5,6 +5,7 @@ package testing
 
 import (
 	"io"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -53,6 +54,10 @@ type FakeBackups struct {
 	KeepCopy bool
 	// NoDownload holds the noDownload bool that was passed in.
 	NoDownload bool
+	// Summary holds the restore summary to return.
+	Summary *backups.RestoreSummary
+	// UntilArg holds the until time that was passed in.
+	UntilArg time.Time
 }
 
 var _ backups.Backups = (*FakeBackups)(nil)
@@ -120,6 +125,14 @@ func (b *FakeBackups) Restore(bkpId string, args backups.RestoreArgs) (names.Tag
 	return nil, errors.Trace(b.Error)
 }
 
+// Preview returns a summary of a restored backup.
+func (b *FakeBackups) Preview(bkpId string, until time.Time) (*backups.RestoreSummary, error) {
+	b.Calls = append(b.Calls, "Preview")
+	b.IDArg = bkpId
+	b.UntilArg = until
+	return b.Summary, errors.Trace(b.Error)
+}
+
 // TODO(ericsnow) FakeStorage should probably move over to the utils repo.
 
 // FakeStorage is a FileStorage implementation to use when testing
@@ -36,6 +36,10 @@ func (s *ControllerSuite) TestControllerAndModelConfigInitialisation(c *gc.C) {
 		controller.AuditLogExcludeMethods,
 		controller.AutocertURLKey,
 		controller.AutocertDNSNameKey,
+		controller.BackupSchedule,
+		controller.BackupTarget,
+		controller.BackupTargetAccessKey,
+		controller.BackupTargetSecretKey,
 		controller.CAASImageRepo,
 		controller.CAASOperatorImagePath,
 		controller.CharmStoreURL,
@@ -53,6 +53,8 @@ const (
 	SettingsC         = settingsC
 )
 
+const RunningActionGracePeriod = runningActionGracePeriod
+
 var (
 	BinarystorageNew              = &binarystorageNew
 	ImageStorageNewStorage        = &imageStorageNewStorage
@@ -527,6 +529,7 @@ func MakeLogDoc(
 	location string,
 	level loggo.Level,
 	msg string,
+	fields map[string]string,
 ) *logDoc {
 	return &logDoc{
 		Id:       bson.NewObjectId(),
@@ -537,6 +540,7 @@ func MakeLogDoc(
 		Location: location,
 		Level:    int(level),
 		Message:  msg,
+		Fields:   fields,
 	}
 }
 
@@ -907,6 +907,25 @@ func (sb *storageBackend) AddExistingFilesystem(
 		return names.StorageTag{}, errors.Trace(err)
 	}
 	storageTag := names.NewStorageTag(storageId)
+	ops, err := sb.addExistingFilesystemOps(info, backingVolume, storageTag, storageName)
+	if err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	if err := sb.mb.db().RunTransaction(ops); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	return storageTag, nil
+}
+
+// addExistingFilesystemOps returns the txn operations to add a storage
+// instance with the specified tag, assigned an already provisioned
+// filesystem with the given info, and backing volume if any.
+func (sb *storageBackend) addExistingFilesystemOps(
+	info FilesystemInfo,
+	backingVolume *VolumeInfo,
+	storageTag names.StorageTag,
+	storageName string,
+) ([]txn.Op, error) {
 	fsOps, _, volumeTag, err := sb.addFilesystemOps(
 		FilesystemParams{
 			Pool:         info.Pool,
@@ -918,17 +937,17 @@ func (sb *storageBackend) AddExistingFilesystem(
 		"", // no machine ID
 	)
 	if err != nil {
-		return names.StorageTag{}, errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 	if volumeTag != (names.VolumeTag{}) && backingVolume == nil {
-		return names.StorageTag{}, errors.Errorf("backing volume info missing")
+		return nil, errors.Errorf("backing volume info missing")
 	}
 	ops := []txn.Op{{
 		C:      storageInstancesC,
-		Id:     storageId,
+		Id:     storageTag.Id(),
 		Assert: txn.DocMissing,
 		Insert: &storageInstanceDoc{
-			Id:          storageId,
+			Id:          storageTag.Id(),
 			Kind:        StorageKindFilesystem,
 			StorageName: storageName,
 			Constraints: storageInstanceConstraints{
@@ -937,11 +956,7 @@ func (sb *storageBackend) AddExistingFilesystem(
 			},
 		},
 	}}
-	ops = append(ops, fsOps...)
-	if err := sb.mb.db().RunTransaction(ops); err != nil {
-		return names.StorageTag{}, errors.Trace(err)
-	}
-	return storageTag, nil
+	return append(ops, fsOps...), nil
 }
 
 var storageNameRE = regexp.MustCompile(names.StorageNameSnippet)
@@ -5,6 +5,7 @@ package state
 
 import (
 	"net"
+	"strings"
 
 	"github.com/juju/errors"
 	"gopkg.in/mgo.v2"
@@ -12,6 +13,7 @@ import (
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/core/firewall"
+	"github.com/juju/juju/core/network"
 )
 
 // FirewallRule instances describe the ingress networks
@@ -22,8 +24,9 @@ import (
 // cross model relations, where the source of traffic is
 // requested from the consuming side.
 // WellKnownService is either a well known internet service
-// (currently just SSH) or a Juju defined value.
-// Supported values are:
+// (currently just SSH), a Juju defined value, or the name of
+// a service defined by an operator (see SaveService).
+// Supported Juju values are:
 // - ssh
 // - juju-controller
 // - juju-application-offer
@@ -94,8 +97,20 @@ func (fw *firewallRulesState) Save(rule FirewallRule) error {
 		return errors.Trace(err)
 	}
 	buildTxn := func(int) ([]txn.Op, error) {
+		var ops []txn.Op
 		if err := rule.WellKnownService().Validate(); err != nil {
-			return nil, errors.Trace(err)
+			// Rules may also be saved for services defined
+			// by an operator, as long as the service exists.
+			if _, svcErr := fw.Service(rule.WellKnownService()); errors.IsNotFound(svcErr) {
+				return nil, errors.Trace(err)
+			} else if svcErr != nil {
+				return nil, errors.Trace(svcErr)
+			}
+			ops = append(ops, txn.Op{
+				C:      firewallServicesC,
+				Id:     string(rule.WellKnownService()),
+				Assert: txn.DocExists,
+			})
 		}
 		for _, cidr := range rule.WhitelistCIDRs() {
 			if _, _, err := net.ParseCIDR(cidr); err != nil {
@@ -117,24 +132,23 @@ func (fw *firewallRulesState) Save(rule FirewallRule) error {
 		if err != nil && !errors.IsNotFound(err) {
 			return nil, errors.Trace(err)
 		}
-		var ops []txn.Op
 		if err == nil {
-			ops = []txn.Op{{
+			ops = append(ops, txn.Op{
 				C:      firewallRulesC,
 				Id:     serviceStr,
 				Assert: txn.DocExists,
 				Update: bson.D{
 					{"$set", bson.D{{"whitelist-cidrs", rule.WhitelistCIDRs()}}},
 				},
-			}}
+			})
 		} else {
 			doc.WhitelistCIDRS = rule.WhitelistCIDRs()
-			ops = []txn.Op{{
+			ops = append(ops, txn.Op{
 				C:      firewallRulesC,
 				Id:     doc.Id,
 				Assert: txn.DocMissing,
 				Insert: doc,
-			}}
+			})
 		}
 		return append(ops, model.assertActiveOp()), nil
 	}
@@ -188,3 +202,131 @@ func (fw *firewallRulesState) AllRules() ([]*FirewallRule, error) {
 	}
 	return result, nil
 }
+
+type firewallServiceDoc struct {
+	Id       string `bson:"_id"`
+	Name     string `bson:"name"`
+	Protocol string `bson:"protocol"`
+	FromPort int    `bson:"from-port"`
+	ToPort   int    `bson:"to-port"`
+}
+
+func (d *firewallServiceDoc) toService() firewall.Service {
+	return firewall.Service{
+		Name: firewall.WellKnownServiceType(d.Name),
+		PortRange: network.PortRange{
+			Protocol: d.Protocol,
+			FromPort: d.FromPort,
+			ToPort:   d.ToPort,
+		},
+	}
+}
+
+// SaveService creates or updates the definition of a service to which
+// firewall rules may then be applied. The names of the services
+// defined by Juju may not be used.
+func (fw *firewallRulesState) SaveService(service firewall.Service) error {
+	if err := checkModelActive(fw.st); err != nil {
+		return errors.Trace(err)
+	}
+	if err := service.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	name := string(service.Name)
+	doc := firewallServiceDoc{
+		Id:       name,
+		Name:     name,
+		Protocol: strings.ToLower(service.PortRange.Protocol),
+		FromPort: service.PortRange.FromPort,
+		ToPort:   service.PortRange.ToPort,
+	}
+	buildTxn := func(int) ([]txn.Op, error) {
+		model, err := fw.st.Model()
+		if err != nil {
+			return nil, errors.Annotate(err, "failed to load model")
+		}
+		_, err = fw.Service(service.Name)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		var ops []txn.Op
+		if err == nil {
+			ops = []txn.Op{{
+				C:      firewallServicesC,
+				Id:     name,
+				Assert: txn.DocExists,
+				Update: bson.D{
+					{"$set", bson.D{
+						{"protocol", doc.Protocol},
+						{"from-port", doc.FromPort},
+						{"to-port", doc.ToPort},
+					}},
+				},
+			}}
+		} else {
+			ops = []txn.Op{{
+				C:      firewallServicesC,
+				Id:     name,
+				Assert: txn.DocMissing,
+				Insert: doc,
+			}}
+		}
+		return append(ops, model.assertActiveOp()), nil
+	}
+	if err := fw.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "failed to save firewall service %q", name)
+	}
+	return nil
+}
+
+// RemoveService deletes the definition of the specified service,
+// along with any firewall rule for it.
+func (fw *firewallRulesState) RemoveService(name firewall.WellKnownServiceType) error {
+	if name.IsBuiltin() {
+		return errors.NotValidf("removing built-in service %q", name)
+	}
+	ops := []txn.Op{{
+		C:      firewallServicesC,
+		Id:     string(name),
+		Remove: true,
+	}, {
+		C:      firewallRulesC,
+		Id:     string(name),
+		Remove: true,
+	}}
+	err := fw.st.db().RunTransaction(ops)
+	return errors.Annotatef(err, "failed to remove firewall service %q", name)
+}
+
+// Service returns the definition of the specified operator defined service.
+func (fw *firewallRulesState) Service(name firewall.WellKnownServiceType) (firewall.Service, error) {
+	coll, closer := fw.st.db().GetCollection(firewallServicesC)
+	defer closer()
+
+	var doc firewallServiceDoc
+	err := coll.FindId(string(name)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return firewall.Service{}, errors.NotFoundf("firewall service %q", name)
+	}
+	if err != nil {
+		return firewall.Service{}, errors.Trace(err)
+	}
+	return doc.toService(), nil
+}
+
+// AllServices returns the definitions of all operator defined services.
+func (fw *firewallRulesState) AllServices() ([]firewall.Service, error) {
+	coll, closer := fw.st.db().GetCollection(firewallServicesC)
+	defer closer()
+
+	var docs []firewallServiceDoc
+	err := coll.Find(nil).Sort("_id").All(&docs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]firewall.Service, len(docs))
+	for i, doc := range docs {
+		result[i] = doc.toService()
+	}
+	return result, nil
+}
@@ -12,7 +12,9 @@ import (
 	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/core/firewall"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 )
 
 type FirewallRulesSuite struct {
@@ -123,3 +125,104 @@ func (s *FirewallRulesSuite) TestRemove(c *gc.C) {
 
 	c.Assert(result[0].WellKnownService(), gc.DeepEquals, firewall.JujuControllerRule)
 }
+
+var nodeExporter = firewall.Service{
+	Name:      "node-exporter",
+	PortRange: network.PortRange{Protocol: "tcp", FromPort: 9100, ToPort: 9100},
+}
+
+func (s *FirewallRulesSuite) TestSaveService(c *gc.C) {
+	rules := state.NewFirewallRules(s.State)
+	err := rules.SaveService(nodeExporter)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := rules.Service("node-exporter")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, nodeExporter)
+}
+
+func (s *FirewallRulesSuite) TestSaveServiceUpdate(c *gc.C) {
+	rules := state.NewFirewallRules(s.State)
+	err := rules.SaveService(nodeExporter)
+	c.Assert(err, jc.ErrorIsNil)
+
+	updated := nodeExporter
+	updated.PortRange = network.PortRange{Protocol: "TCP", FromPort: 9100, ToPort: 9110}
+	err = rules.SaveService(updated)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := rules.AllServices()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, []firewall.Service{{
+		Name:      "node-exporter",
+		PortRange: network.PortRange{Protocol: "tcp", FromPort: 9100, ToPort: 9110},
+	}})
+}
+
+func (s *FirewallRulesSuite) TestSaveServiceInvalid(c *gc.C) {
+	rules := state.NewFirewallRules(s.State)
+	err := rules.SaveService(firewall.Service{
+		Name:      firewall.SSHRule,
+		PortRange: network.PortRange{Protocol: "tcp", FromPort: 22, ToPort: 22},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `redefining built-in service "ssh" not valid`)
+
+	err = rules.SaveService(firewall.Service{
+		Name:      "node-exporter",
+		PortRange: network.PortRange{Protocol: "tcp", FromPort: 9110, ToPort: 9100},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `service node-exporter: invalid port range 9110-9100/tcp`)
+}
+
+func (s *FirewallRulesSuite) TestSaveRuleForService(c *gc.C) {
+	rules := state.NewFirewallRules(s.State)
+	err := rules.Save(state.NewFirewallRule("node-exporter", []string{"10.0.0.0/8"}))
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	err = rules.SaveService(nodeExporter)
+	c.Assert(err, jc.ErrorIsNil)
+	err = rules.Save(state.NewFirewallRule("node-exporter", []string{"10.0.0.0/8"}))
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertSavedRules(c, "node-exporter", []string{"10.0.0.0/8"})
+}
+
+func (s *FirewallRulesSuite) TestRemoveService(c *gc.C) {
+	rules := state.NewFirewallRules(s.State)
+	err := rules.SaveService(nodeExporter)
+	c.Assert(err, jc.ErrorIsNil)
+	err = rules.Save(state.NewFirewallRule("node-exporter", []string{"10.0.0.0/8"}))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = rules.RemoveService("node-exporter")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = rules.Service("node-exporter")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = rules.Rule("node-exporter")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = rules.RemoveService(firewall.SSHRule)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *FirewallRulesSuite) TestWatchFirewallRules(c *gc.C) {
+	w := s.State.WatchFirewallRules()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	rules := state.NewFirewallRules(s.State)
+	err := rules.SaveService(nodeExporter)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = rules.Save(state.NewFirewallRule("node-exporter", []string{"10.0.0.0/8"}))
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = rules.RemoveService("node-exporter")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -146,6 +146,11 @@ type ActionReceiver interface {
 	// ActionReceiver.
 	AddAction(name string, payload map[string]interface{}) (Action, error)
 
+	// AddActionWithTimeouts queues an action with the given name and
+	// payload for this ActionReceiver, which is expired if it is not
+	// started or completed within the given timeouts.
+	AddActionWithTimeouts(name string, payload map[string]interface{}, timeouts ActionTimeouts) (Action, error)
+
 	// CancelAction removes a pending Action from the queue for this
 	// ActionReceiver and marks it as cancelled.
 	CancelAction(action Action) (Action, error)
@@ -213,6 +218,13 @@ type Action interface {
 	// Results returns the structured output of the action and any error.
 	Results() (map[string]interface{}, string)
 
+	// Timeouts returns the queue and execution timeouts of the action.
+	Timeouts() ActionTimeouts
+
+	// Operation returns the id of the operation the action is part of,
+	// or "" if it was queued by itself.
+	Operation() string
+
 	// ActionTag returns an ActionTag constructed from this action's
 	// Prefix and Sequence.
 	ActionTag() names.ActionTag
@@ -228,6 +240,10 @@ type Action interface {
 	// Log adds message to the action's progress message array.
 	Log(message string) error
 
+	// LogOutput adds lines written by the action to the given output
+	// stream, stdout or stderr, to the action's message array.
+	LogOutput(stream string, lines []string) error
+
 	// Messages returns the action's progress messages.
 	Messages() []ActionMessage
 }
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"math"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -26,6 +27,7 @@ import (
 	"gopkg.in/tomb.v2"
 
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/logfields"
 	"github.com/juju/juju/mongo"
 )
 
@@ -76,9 +78,9 @@ func logCollectionName(modelUUID string) string {
 	return logsCPrefix + modelUUID
 }
 
-// InitDbLogs sets up the capped collections for the logging, along with the
-// indexes for the logs collection. It should be called as state is opened. It
-// is idempotent.
+// InitDbLogs sets up the capped collections for the logging and the audit log,
+// along with the indexes for the logs collection. It should be called as state
+// is opened. It is idempotent.
 func InitDbLogs(session *mgo.Session) error {
 	// Read the capped collection size from controller config.
 	size, err := modelLogsSize(session)
@@ -106,6 +108,10 @@ func InitDbLogs(session *mgo.Session) error {
 			logger.Errorf("unable to initialize model logs: %v", err)
 		}
 	}
+	if err := initDbAuditLog(session); err != nil {
+		encounteredError = true
+		logger.Errorf("unable to initialize audit log: %v", err)
+	}
 
 	if encounteredError {
 		return errors.New("one or more errors initializing logs")
@@ -117,6 +123,13 @@ func InitDbLogs(session *mgo.Session) error {
 // config document and returns it. If the value isn't found the default
 // size value is returned.
 func modelLogsSize(session *mgo.Session) (int, error) {
+	return controllerSizeSetting(session, controller.ModelLogsSize, controller.DefaultModelLogsSizeMB)
+}
+
+// controllerSizeSetting reads the size in MB held in the given key of
+// the controller config document. If the value isn't found the default
+// size is returned.
+func controllerSizeSetting(session *mgo.Session, key string, defaultMB int) (int, error) {
 	// This is executed very early in the opening of the database, so there
 	// is no State, Controller, nor StatePool objects just now. Use low level
 	// mgo to access the settings.
@@ -127,7 +140,7 @@ func modelLogsSize(session *mgo.Session) (int, error) {
 	}
 	// During initial migration there is no guarantee that the value exists
 	// in the settings document.
-	if value, ok := doc.Settings[controller.ModelLogsSize]; ok {
+	if value, ok := doc.Settings[key]; ok {
 		if s, ok := value.(string); ok {
 			size, _ := utils.ParseSize(s)
 			if size > 0 {
@@ -136,7 +149,7 @@ func modelLogsSize(session *mgo.Session) (int, error) {
 			}
 		}
 	}
-	return controller.DefaultModelLogsSizeMB, nil
+	return defaultMB, nil
 }
 
 // modelUUIDs returns the UUIDs of all models currently stored in the database.
@@ -313,14 +326,15 @@ func (logger *LastSentLogTracker) Get() (int64, int64, error) {
 // for increased precision.
 // TODO: remove version from this structure: https://pad.lv/1643743
 type logDoc struct {
-	Id       bson.ObjectId `bson:"_id"`
-	Time     int64         `bson:"t"` // unix nano UTC
-	Entity   string        `bson:"n"` // e.g. "machine-0"
-	Version  string        `bson:"r"`
-	Module   string        `bson:"m"` // e.g. "juju.worker.firewaller"
-	Location string        `bson:"l"` // "filename:lineno"
-	Level    int           `bson:"v"`
-	Message  string        `bson:"x"`
+	Id       bson.ObjectId     `bson:"_id"`
+	Time     int64             `bson:"t"` // unix nano UTC
+	Entity   string            `bson:"n"` // e.g. "machine-0"
+	Version  string            `bson:"r"`
+	Module   string            `bson:"m"` // e.g. "juju.worker.firewaller"
+	Location string            `bson:"l"` // "filename:lineno"
+	Level    int               `bson:"v"`
+	Message  string            `bson:"x"`
+	Fields   map[string]string `bson:"f,omitempty"` // e.g. {"unit": "mysql/0"}
 }
 
 type DbLogger struct {
@@ -368,6 +382,7 @@ func (logger *DbLogger) Log(records []LogRecord) error {
 			Location: r.Location,
 			Level:    int(r.Level),
 			Message:  r.Message,
+			Fields:   r.Fields,
 		})
 	}
 	_, err := bulk.Run()
@@ -378,6 +393,11 @@ func validateInputLogRecord(r LogRecord) error {
 	if r.Entity == "" {
 		return errors.NotValidf("missing Entity")
 	}
+	// The field names are used in queries, so they must be safe to use
+	// as document keys.
+	if err := logfields.Fields(r.Fields).Validate(); err != nil {
+		return errors.Trace(err)
+	}
 	return nil
 }
 
@@ -427,13 +447,23 @@ type LogRecord struct {
 	Module   string
 	Location string
 	Message  string
+
+	// Fields holds any structured fields attached to the message,
+	// such as the unit or hook that it relates to.
+	Fields map[string]string
 }
 
 // LogTailerParams specifies the filtering a LogTailer should apply to
 // logs in order to decide which to return.
 type LogTailerParams struct {
-	StartID       int64
-	StartTime     time.Time
+	StartID   int64
+	StartTime time.Time
+
+	// EndTime, if set, restricts the records returned to those logged
+	// before it. Once EndTime has passed, the tailer stops instead of
+	// waiting for new records.
+	EndTime time.Time
+
 	MinLevel      loggo.Level
 	InitialLines  int
 	NoTail        bool
@@ -441,7 +471,39 @@ type LogTailerParams struct {
 	ExcludeEntity []string
 	IncludeModule []string
 	ExcludeModule []string
-	Oplog         *mgo.Collection // For testing only
+
+	// IncludeFields restricts the records returned to those with all
+	// of the given field values.
+	IncludeFields map[string]string
+
+	// IncludeMessage and ExcludeMessage hold regular expressions
+	// matched against the records' messages. A record is returned if
+	// its message matches any of the IncludeMessage expressions, or
+	// there are none, and doesn't match any of the ExcludeMessage
+	// expressions. The expressions are evaluated by mongo, which uses
+	// PCRE, so they are limited to the syntax that PCRE shares with
+	// Go's regexp package: PCRE-only features such as backreferences
+	// and lookaround are refused by Go, and \v, which is a vertical
+	// tab to Go but any vertical whitespace to PCRE, is refused here.
+	// Note that PCRE's $ also matches before a trailing newline.
+	IncludeMessage []string
+	ExcludeMessage []string
+
+	Oplog *mgo.Collection // For testing only
+}
+
+func (params LogTailerParams) validate() error {
+	for _, exprs := range [][]string{params.IncludeMessage, params.ExcludeMessage} {
+		for _, expr := range exprs {
+			if err := validateMessagePattern(expr); err != nil {
+				return errors.NewNotValid(err, fmt.Sprintf("message pattern %q", expr))
+			}
+		}
+	}
+	if !params.StartTime.IsZero() && !params.EndTime.IsZero() && params.EndTime.Before(params.StartTime) {
+		return errors.NotValidf("end time before start time")
+	}
+	return nil
 }
 
 // oplogOverlap is used to decide on the initial oplog timestamp to
@@ -477,6 +539,9 @@ type LogTailerState interface {
 // NewLogTailer returns a LogTailer which filters according to the
 // parameters given.
 func NewLogTailer(st LogTailerState, params LogTailerParams) (LogTailer, error) {
+	if err := params.validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
 	session := st.MongoSession().Copy()
 	t := &logTailer{
 		modelUUID:       st.ModelUUID(),
@@ -539,13 +604,19 @@ func (t *logTailer) loop() error {
 		return err
 	}
 
-	if t.params.NoTail {
+	if t.params.NoTail || t.endTimePassed() {
 		return nil
 	}
 
 	return t.tailOplog()
 }
 
+// endTimePassed reports whether the tailer's end time, if any, has
+// passed, in which case there are no more records to wait for.
+func (t *logTailer) endTimePassed() bool {
+	return !t.params.EndTime.IsZero() && !time.Now().Before(t.params.EndTime)
+}
+
 func (t *logTailer) processReversed(query *mgo.Query) error {
 	// We must sort by exactly the fields in the index and exactly reversed
 	// so that Mongo will use the index and not try to sort in memory.
@@ -682,6 +753,15 @@ func (t *logTailer) tailOplog() error {
 	logger.Tracef("LogTailer starting oplog tailing: recent id count=%d, lastTime=%s, minOplogTs=%s",
 		recentIds.Length(), t.lastTime, minOplogTs)
 
+	// Stop tailing once the end time has passed, as there will be
+	// no more records to report.
+	var endTimeCh <-chan time.Time
+	if !t.params.EndTime.IsZero() {
+		endTimer := time.NewTimer(time.Until(t.params.EndTime))
+		defer endTimer.Stop()
+		endTimeCh = endTimer.C
+	}
+
 	// If we get a deserialisation error, write out the first failure,
 	// but don't write out any additional errors until we either hit
 	// a good value, or end the method.
@@ -691,6 +771,8 @@ func (t *logTailer) tailOplog() error {
 		select {
 		case <-t.tomb.Dying():
 			return tomb.ErrDying
+		case <-endTimeCh:
+			return nil
 		case oplogDoc, ok := <-oplogTailer.Out():
 			if !ok {
 				return errors.Annotate(oplogTailer.Err(), "oplog tailer died")
@@ -741,6 +823,9 @@ func (t *logTailer) paramsToSelector(params LogTailerParams, prefix string) bson
 	if !params.StartTime.IsZero() {
 		sel = append(sel, bson.DocElem{"t", bson.M{"$gte": params.StartTime.UnixNano()}})
 	}
+	if !params.EndTime.IsZero() {
+		sel = append(sel, bson.DocElem{"t", bson.M{"$lt": params.EndTime.UnixNano()}})
+	}
 	if params.MinLevel > loggo.UNSPECIFIED {
 		sel = append(sel, bson.DocElem{"v", bson.M{"$gte": int(params.MinLevel)}})
 	}
@@ -760,6 +845,25 @@ func (t *logTailer) paramsToSelector(params LogTailerParams, prefix string) bson
 		sel = append(sel,
 			bson.DocElem{"m", bson.M{"$not": bson.RegEx{Pattern: makeModulePattern(params.ExcludeModule)}}})
 	}
+	if len(params.IncludeMessage) > 0 {
+		sel = append(sel,
+			bson.DocElem{"x", bson.RegEx{Pattern: makeMessagePattern(params.IncludeMessage)}})
+	}
+	if len(params.ExcludeMessage) > 0 {
+		sel = append(sel,
+			bson.DocElem{"x", bson.M{"$not": bson.RegEx{Pattern: makeMessagePattern(params.ExcludeMessage)}}})
+	}
+	if len(params.IncludeFields) > 0 {
+		keys := make([]string, 0, len(params.IncludeFields))
+		for key := range params.IncludeFields {
+			keys = append(keys, key)
+		}
+		// Sort the fields so that the selector is stable.
+		sort.Strings(keys)
+		for _, key := range keys {
+			sel = append(sel, bson.DocElem{"f." + key, params.IncludeFields[key]})
+		}
+	}
 	if prefix != "" {
 		for i, elem := range sel {
 			sel[i].Name = prefix + elem.Name
@@ -786,6 +890,40 @@ func makeModulePattern(modules []string) string {
 	return `^(` + strings.Join(patterns, "|") + `)(\..+)?$`
 }
 
+// validateMessagePattern checks that expr means the same to mongo's
+// PCRE engine as it does to Go's regexp package.
+func validateMessagePattern(expr string) error {
+	if _, err := regexp.Compile(expr); err != nil {
+		return err
+	}
+	for i := 0; i < len(expr)-1; i++ {
+		if expr[i] != '\\' {
+			continue
+		}
+		i++
+		switch expr[i] {
+		case 'v':
+			return errors.New(`\v matches differently in mongo; use \x0b instead`)
+		case 'Q':
+			// Everything up to \E is literal.
+			end := strings.Index(expr[i:], `\E`)
+			if end < 0 {
+				return nil
+			}
+			i += end + 1
+		}
+	}
+	return nil
+}
+
+func makeMessagePattern(expressions []string) string {
+	var patterns []string
+	for _, expr := range expressions {
+		patterns = append(patterns, `(?:`+expr+`)`)
+	}
+	return strings.Join(patterns, "|")
+}
+
 func newRecentIdTracker(maxLen int) *recentIdTracker {
 	return &recentIdTracker{
 		ids: deque.NewWithMaxLen(maxLen),
@@ -861,6 +999,7 @@ func logDocToRecord(modelUUID string, doc *logDoc) (*LogRecord, error) {
 		Module:   doc.Module,
 		Location: doc.Location,
 		Message:  doc.Message,
+		Fields:   doc.Fields,
 	}
 	return rec, nil
 }
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version"
@@ -161,6 +162,7 @@ func (s *LogsSuite) TestDbLogger(c *gc.C) {
 		Location: "bar.go:42",
 		Level:    loggo.ERROR,
 		Message:  "oh noes",
+		Fields:   map[string]string{"unit": "mysql/0", "hook": "install"},
 	}})
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -182,6 +184,24 @@ func (s *LogsSuite) TestDbLogger(c *gc.C) {
 	c.Assert(docs[1]["l"], gc.Equals, "bar.go:42")
 	c.Assert(docs[1]["v"], gc.Equals, int(loggo.ERROR))
 	c.Assert(docs[1]["x"], gc.Equals, "oh noes")
+	c.Assert(docs[1]["f"], jc.DeepEquals, bson.M{"unit": "mysql/0", "hook": "install"})
+	_, hasFields := docs[0]["f"]
+	c.Assert(hasFields, jc.IsFalse)
+}
+
+func (s *LogsSuite) TestDbLoggerInvalidField(c *gc.C) {
+	logger := state.NewDbLogger(s.State)
+	defer logger.Close()
+
+	err := logger.Log([]state.LogRecord{{
+		Time:    coretesting.ZeroTime(),
+		Entity:  "machine-45",
+		Module:  "some.where",
+		Level:   loggo.INFO,
+		Message: "all is well",
+		Fields:  map[string]string{"$where": "x"},
+	}})
+	c.Assert(err, gc.ErrorMatches, `validating input log record: log field name "\$where" not valid`)
 }
 
 type LogTailerSuite struct {
@@ -274,6 +294,44 @@ func (s *LogTailerSuite) TestTimeFiltering(c *gc.C) {
 
 }
 
+func (s *LogTailerSuite) TestEndTimeFiltering(c *gc.C) {
+	threshT := coretesting.NonZeroTime()
+	want := logTemplate{Message: "want"}
+	s.writeLogsT(c, s.otherUUID, threshT.Add(-5*time.Second), threshT.Add(-time.Millisecond), 5, want)
+	s.writeLogsT(c,
+		s.otherUUID,
+		threshT, threshT.Add(5*time.Second), 5,
+		logTemplate{Message: "dont want"},
+	)
+
+	tailer, err := state.NewLogTailer(s.otherState, state.LogTailerParams{
+		EndTime: threshT,
+		Oplog:   s.oplogColl,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+	s.assertTailer(c, tailer, 5, want)
+
+	// The end time has passed, so the tailer stops once the logs
+	// collection has been read.
+	select {
+	case _, ok := <-tailer.Logs():
+		c.Assert(ok, jc.IsFalse)
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for logs channel to close")
+	}
+}
+
+func (s *LogTailerSuite) TestEndTimeBeforeStartTime(c *gc.C) {
+	threshT := coretesting.NonZeroTime()
+	_, err := state.NewLogTailer(s.otherState, state.LogTailerParams{
+		StartTime: threshT,
+		EndTime:   threshT.Add(-time.Second),
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, "end time before start time not valid")
+}
+
 func (s *LogTailerSuite) TestOplogTransition(c *gc.C) {
 	// Ensure that logs aren't repeated as the log tailer moves from
 	// reading from the logs collection to tailing the oplog.
@@ -627,6 +685,88 @@ func (s *LogTailerSuite) TestIncludeExcludeModule(c *gc.C) {
 	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
 }
 
+func (s *LogTailerSuite) TestIncludeFields(c *gc.C) {
+	none := logTemplate{}
+	rel7 := logTemplate{Fields: map[string]string{"unit": "mysql/0", "relation-id": "7"}}
+	rel8 := logTemplate{Fields: map[string]string{"unit": "mysql/0", "relation-id": "8"}}
+	otherUnit := logTemplate{Fields: map[string]string{"unit": "mysql/1", "relation-id": "7"}}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, none)
+		s.writeLogs(c, s.otherUUID, 1, rel7)
+		s.writeLogs(c, s.otherUUID, 1, rel8)
+		s.writeLogs(c, s.otherUUID, 1, otherUnit)
+		s.writeLogs(c, s.otherUUID, 1, rel7)
+	}
+	params := state.LogTailerParams{
+		IncludeFields: map[string]string{"unit": "mysql/0", "relation-id": "7"},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 2, rel7)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestIncludeMessage(c *gc.C) {
+	good := logTemplate{Message: "all good"}
+	failed := logTemplate{Message: "hook failed: exit status 1"}
+	errored := logTemplate{Message: "ERROR cannot connect"}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, good)
+		s.writeLogs(c, s.otherUUID, 1, failed)
+		s.writeLogs(c, s.otherUUID, 1, errored)
+	}
+	params := state.LogTailerParams{
+		IncludeMessage: []string{"fail(ed|ure)", "(?i)error"},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, failed)
+		s.assertTailer(c, tailer, 1, errored)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestExcludeMessage(c *gc.C) {
+	good := logTemplate{Message: "all good"}
+	failed := logTemplate{Message: "hook failed: exit status 1"}
+	errored := logTemplate{Message: "ERROR cannot connect"}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, good)
+		s.writeLogs(c, s.otherUUID, 1, failed)
+		s.writeLogs(c, s.otherUUID, 1, errored)
+	}
+	params := state.LogTailerParams{
+		IncludeMessage: []string{"fail", "good"},
+		ExcludeMessage: []string{"^hook "},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, good)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestInvalidMessagePattern(c *gc.C) {
+	_, err := state.NewLogTailer(s.otherState, state.LogTailerParams{
+		ExcludeMessage: []string{"fail(ed"},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `message pattern "fail\(ed": error parsing regexp: .*`)
+}
+
+func (s *LogTailerSuite) TestMessagePatternNotSharedWithMongo(c *gc.C) {
+	_, err := state.NewLogTailer(s.otherState, state.LogTailerParams{
+		IncludeMessage: []string{`fail\v`},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `message pattern "fail\\\\v": \\v matches differently in mongo; use \\x0b instead`)
+
+	tailer, err := state.NewLogTailer(s.otherState, state.LogTailerParams{
+		IncludeMessage: []string{`\Q\v\E`},
+		NoTail:         true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	tailer.Stop()
+}
+
 func (s *LogTailerSuite) checkLogTailerFiltering(
 	c *gc.C,
 	st *state.State,
@@ -656,6 +796,7 @@ type logTemplate struct {
 	Location string
 	Level    loggo.Level
 	Message  string
+	Fields   map[string]string
 }
 
 // emptyTag gives us an explicit way to specify an empty tag for the
@@ -744,6 +885,7 @@ func (s *LogTailerSuite) logTemplateToDoc(lt logTemplate, t time.Time) interface
 		lt.Location,
 		lt.Level,
 		lt.Message,
+		lt.Fields,
 	)
 }
 
@@ -764,6 +906,7 @@ func (s *LogTailerSuite) assertTailer(c *gc.C, tailer state.LogTailer, expectedC
 			c.Assert(log.Location, gc.Equals, lt.Location)
 			c.Assert(log.Level, gc.Equals, lt.Level)
 			c.Assert(log.Message, gc.Equals, lt.Message)
+			c.Assert(log.Fields, jc.DeepEquals, lt.Fields)
 			count++
 			if count == expectedCount {
 				return
@@ -2006,6 +2006,11 @@ func (m *Machine) VolumeAttachments() ([]VolumeAttachment, error) {
 
 // AddAction is part of the ActionReceiver interface.
 func (m *Machine) AddAction(name string, payload map[string]interface{}) (Action, error) {
+	return m.AddActionWithTimeouts(name, payload, ActionTimeouts{})
+}
+
+// AddActionWithTimeouts is part of the ActionReceiver interface.
+func (m *Machine) AddActionWithTimeouts(name string, payload map[string]interface{}, timeouts ActionTimeouts) (Action, error) {
 	spec, ok := actions.PredefinedActionsSpec[name]
 	if !ok {
 		return nil, errors.Errorf("cannot add action %q to a machine; only predefined actions allowed", name)
@@ -2026,7 +2031,7 @@ func (m *Machine) AddAction(name string, payload map[string]interface{}) (Action
 		return nil, errors.Trace(err)
 	}
 
-	return model.EnqueueAction(m.Tag(), name, payloadWithDefaults)
+	return model.EnqueueActionWithTimeouts(m.Tag(), name, payloadWithDefaults, timeouts)
 }
 
 // CancelAction is part of the ActionReceiver interface.
@@ -219,6 +219,18 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// sure the leader units' leases are claimed in the target
 		// controller when leases are managed in raft.
 		leaseHoldersC,
+		// The model description does not yet support operator
+		// defined firewall services; the migration prechecks
+		// refuse to migrate a model that has any.
+		firewallServicesC,
+		// The model description does not yet support volume
+		// snapshots; the migration prechecks refuse to migrate
+		// a model that has any.
+		volumeSnapshotsC,
+		// The model description does not yet support operations
+		// rolling actions out across units; the migration prechecks
+		// refuse to migrate a model while any are running.
+		operationsC,
 	)
 
 	modelCollections := set.NewStrings()
@@ -391,6 +403,10 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		// RelationCount is handled by the number of times the application name
 		// appears in relation endpoints.
 		"RelationCount",
+		// ExposedEndpoints is not part of the model description. The
+		// default settings are implied by Exposed, and the migration
+		// precheck refuses applications with any other settings.
+		"ExposedEndpoints",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -715,6 +731,15 @@ func (s *MigrationSuite) TestSSHHostKeyDocFields(c *gc.C) {
 func (s *MigrationSuite) TestActionDocFields(c *gc.C) {
 	ignored := set.NewStrings(
 		"ModelUUID",
+		// The model description does not yet support action timeouts;
+		// the migration prechecks refuse to migrate a model while any
+		// actions with timeouts are pending or running.
+		"QueueTimeout",
+		"ExecutionTimeout",
+		// The model description does not yet support operations; the
+		// migration prechecks refuse to migrate a model while any are
+		// running.
+		"Operation",
 	)
 	migrated := set.NewStrings(
 		"DocId",
@@ -737,8 +762,9 @@ func (s *MigrationSuite) TestVolumeDocFields(c *gc.C) {
 		"ModelUUID",
 		"DocID",
 		"Life",
-		"HostId",    // recreated from pool properties
-		"Releasing", // only when dying; can't migrate dying storage
+		"HostId",        // recreated from pool properties
+		"Releasing",     // only when dying; can't migrate dying storage
+		"RequestedSize", // migration prechecks refuse pending expansions
 	)
 	migrated := set.NewStrings(
 		"Name",
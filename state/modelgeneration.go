@@ -7,17 +7,21 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
 	jujutxn "github.com/juju/txn"
 	"github.com/juju/utils/set"
 	"gopkg.in/juju/charm.v6"
+	csparams "gopkg.in/juju/charmrepo.v4/csclient/params"
 	"gopkg.in/juju/names.v3"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/settings"
 	"github.com/juju/juju/mongo/utils"
 )
@@ -41,6 +45,83 @@ func (c *itemChange) coreChange() settings.ItemChange {
 	}
 }
 
+// charmUpgradeDoc is the state representation of a charm upgrade
+// staged in a branch.
+type charmUpgradeDoc struct {
+	// CharmURL is the URL of the charm that the application is upgraded to.
+	CharmURL string `bson:"charm-url"`
+
+	// OldCharmURL is the URL of the charm that the application was using
+	// when the upgrade was staged. The application's charm can not be
+	// changed while an upgrade is staged, so the branch holds a reference
+	// to the staged charm only when it differs from this one.
+	OldCharmURL string `bson:"old-charm-url"`
+
+	// Channel is the charm store channel from which the charm came.
+	Channel string `bson:"channel,omitempty"`
+
+	// ResourceIDs maps resource names to the IDs of pending resources
+	// that are resolved when the branch is committed.
+	ResourceIDs map[string]string `bson:"resource-ids,omitempty"`
+
+	// ModifiedVersion is incremented each time the upgrade is staged,
+	// so that units tracking the branch are told to upgrade when only
+	// the staged resources change.
+	ModifiedVersion int `bson:"modified-version"`
+}
+
+// holdsCharmRef returns true if the branch holds a reference to the
+// staged charm on behalf of the units that track it.
+func (u charmUpgradeDoc) holdsCharmRef() bool {
+	return u.CharmURL != u.OldCharmURL
+}
+
+// bindingsChangeDoc is the state representation of endpoint binding
+// changes staged in a branch.
+type bindingsChangeDoc struct {
+	// Bindings maps endpoint names to the IDs of the spaces that they
+	// are bound to when the branch is committed.
+	Bindings bindingsMap `bson:"bindings"`
+
+	// Force indicates that the bindings are applied without checking
+	// that the application's machines have addresses in the spaces.
+	Force bool `bson:"force,omitempty"`
+}
+
+// rolloutDoc describes the progressive rollout of the changes made under a
+// branch to the units that are not yet tracking it.
+type rolloutDoc struct {
+	// BatchSize is the maximum number of units
+	// set to track the branch in each batch.
+	BatchSize int `bson:"batch-size"`
+
+	// BatchInterval is the time to wait after each batch
+	// before the next batch is set to track the branch.
+	BatchInterval time.Duration `bson:"batch-interval"`
+
+	// HaltOnError indicates that the rollout is stopped if any unit
+	// tracking the branch has a workload status of "error".
+	HaltOnError bool `bson:"halt-on-error,omitempty"`
+
+	// Started is a Unix timestamp indicating when the rollout started.
+	Started int64 `bson:"started"`
+
+	// StartedBy is the user who started the rollout.
+	// The branch is committed on behalf of this user
+	// when the rollout is complete.
+	StartedBy string `bson:"started-by"`
+
+	// LastBatch is a Unix timestamp indicating when
+	// the last batch of units was set to track the branch.
+	LastBatch int64 `bson:"last-batch"`
+
+	// Batches is the number of batches rolled out so far.
+	Batches int `bson:"batches"`
+
+	// Halted, if set, is the reason that the rollout was stopped.
+	Halted string `bson:"halted,omitempty"`
+}
+
 // generationDoc represents the state of a model generation in MongoDB.
 type generationDoc struct {
 	DocId    string `bson:"_id"`
@@ -70,7 +151,27 @@ type generationDoc struct {
 	// Config is all changes made to charm configuration under this branch.
 	Config map[string][]itemChange `bson:"charm-config"`
 
-	// TODO (manadart 2019-04-02): CharmURLs, Resources.
+	// CharmUpgrades holds the charm upgrades staged under this branch,
+	// keyed by application name.
+	// Units tracking the branch run the staged charm and resources;
+	// the application is upgraded when the branch is committed.
+	CharmUpgrades map[string]charmUpgradeDoc `bson:"charm-upgrades,omitempty"`
+
+	// Constraints holds the application constraints staged under this
+	// branch, keyed by application name.
+	Constraints map[string]constraintsDoc `bson:"constraints,omitempty"`
+
+	// Scales holds the desired scales of CAAS applications staged under
+	// this branch, keyed by application name.
+	Scales map[string]int `bson:"scales,omitempty"`
+
+	// Bindings holds the endpoint binding changes staged under this branch,
+	// keyed by application name.
+	Bindings map[string]bindingsChangeDoc `bson:"bindings,omitempty"`
+
+	// Rollout, if set, describes the progressive rollout of this branch
+	// that will commit it when all units are tracking it.
+	Rollout *rolloutDoc `bson:"rollout,omitempty"`
 
 	// Created is a Unix timestamp indicating when this generation was created.
 	Created int64 `bson:"created"`
@@ -128,6 +229,113 @@ func (g *Generation) Config() map[string]settings.ItemChanges {
 	return changes
 }
 
+// CharmURLs returns the URLs of the charms that applications have been
+// upgraded to under the branch, keyed by application name.
+func (g *Generation) CharmURLs() map[string]string {
+	urls := make(map[string]string, len(g.doc.CharmUpgrades))
+	for appName, upgrade := range g.doc.CharmUpgrades {
+		urls[appName] = upgrade.CharmURL
+	}
+	return urls
+}
+
+// Resources returns the IDs of the pending resources staged under the
+// branch, keyed by application name and then by resource name.
+func (g *Generation) Resources() map[string]map[string]string {
+	resources := make(map[string]map[string]string)
+	for appName, upgrade := range g.doc.CharmUpgrades {
+		if len(upgrade.ResourceIDs) == 0 {
+			continue
+		}
+		ids := make(map[string]string, len(upgrade.ResourceIDs))
+		for name, id := range upgrade.ResourceIDs {
+			ids[name] = id
+		}
+		resources[appName] = ids
+	}
+	return resources
+}
+
+// Constraints returns the application constraints staged under the
+// branch, keyed by application name.
+func (g *Generation) Constraints() map[string]constraints.Value {
+	cons := make(map[string]constraints.Value, len(g.doc.Constraints))
+	for appName, doc := range g.doc.Constraints {
+		cons[appName] = doc.value()
+	}
+	return cons
+}
+
+// Scales returns the desired application scales staged under the
+// branch, keyed by application name.
+func (g *Generation) Scales() map[string]int {
+	scales := make(map[string]int, len(g.doc.Scales))
+	for appName, scale := range g.doc.Scales {
+		scales[appName] = scale
+	}
+	return scales
+}
+
+// Bindings returns the endpoint bindings staged under the branch, keyed by
+// application name and then by endpoint name. Endpoints are mapped to the
+// IDs of the spaces that they will be bound to.
+func (g *Generation) Bindings() map[string]map[string]string {
+	bindings := make(map[string]map[string]string, len(g.doc.Bindings))
+	for appName, change := range g.doc.Bindings {
+		appBindings := make(map[string]string, len(change.Bindings))
+		for endpoint, spaceID := range change.Bindings {
+			appBindings[endpoint] = spaceID
+		}
+		bindings[appName] = appBindings
+	}
+	return bindings
+}
+
+// BranchRollout describes the progress of a branch rollout.
+type BranchRollout struct {
+	model.RolloutPolicy
+
+	// Started is the time at which the rollout started.
+	Started time.Time
+
+	// StartedBy is the user who started the rollout.
+	StartedBy string
+
+	// LastBatch is the time at which the last batch of units was set
+	// to track the branch. It is zero if no batches have been rolled out.
+	LastBatch time.Time
+
+	// Batches is the number of batches rolled out so far.
+	Batches int
+
+	// Halted, if not empty, is the reason that the rollout was stopped.
+	Halted string
+}
+
+// Rollout returns the progress of the branch rollout,
+// and false if no rollout was started for the branch.
+func (g *Generation) Rollout() (BranchRollout, bool) {
+	doc := g.doc.Rollout
+	if doc == nil {
+		return BranchRollout{}, false
+	}
+	rollout := BranchRollout{
+		RolloutPolicy: model.RolloutPolicy{
+			BatchSize:     doc.BatchSize,
+			BatchInterval: doc.BatchInterval,
+			HaltOnError:   doc.HaltOnError,
+		},
+		Started:   time.Unix(doc.Started, 0),
+		StartedBy: doc.StartedBy,
+		Batches:   doc.Batches,
+		Halted:    doc.Halted,
+	}
+	if doc.LastBatch > 0 {
+		rollout.LastBatch = time.Unix(doc.LastBatch, 0)
+	}
+	return rollout, true
+}
+
 // Created returns the Unix timestamp at generation creation.
 func (g *Generation) Created() int64 {
 	return g.doc.Created
@@ -371,6 +579,293 @@ func (g *Generation) UpdateCharmConfig(appName string, master *Settings, validCh
 	return errors.Trace(g.st.db().Run(buildTxn))
 }
 
+// upgradeCharm stages an upgrade of the input application to the input
+// charm and pending resources under this branch.
+// The charm is assumed to have been validated for the application.
+func (g *Generation) upgradeCharm(appName string, ch *Charm, channel string, resourceIDs map[string]string) error {
+	curl := ch.URL().String()
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if err := g.CheckNotComplete(); err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		app, err := g.st.Application(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if other, err := app.stagedCharmBranch(); err != nil {
+			return nil, errors.Trace(err)
+		} else if other != nil && other.BranchName() != g.BranchName() {
+			return nil, errors.Errorf("charm upgrade for %q is already staged in branch %q", appName, other.BranchName())
+		}
+		appURL, _ := app.CharmURL()
+
+		staged, isStaged := g.doc.CharmUpgrades[appName]
+		upgrade := charmUpgradeDoc{
+			CharmURL:        curl,
+			OldCharmURL:     appURL.String(),
+			Channel:         channel,
+			ModifiedVersion: staged.ModifiedVersion + 1,
+		}
+		if isStaged && staged.CharmURL == curl {
+			upgrade.ResourceIDs = staged.ResourceIDs
+		}
+		for name, id := range resourceIDs {
+			if upgrade.ResourceIDs == nil {
+				upgrade.ResourceIDs = make(map[string]string)
+			}
+			upgrade.ResourceIDs[name] = id
+		}
+		if !upgrade.holdsCharmRef() && len(upgrade.ResourceIDs) == 0 {
+			return nil, errors.Errorf("application %q already uses charm %q", appName, curl)
+		}
+
+		ops := []txn.Op{{
+			C:      applicationsC,
+			Id:     app.doc.DocID,
+			Assert: bson.D{{"life", Alive}, {"charmurl", appURL}},
+		}}
+		if !isStaged || staged.CharmURL != curl {
+			if upgrade.holdsCharmRef() {
+				refOps, err := stageCharmRefOps(app, ch)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				ops = append(ops, refOps...)
+			}
+			if isStaged && staged.holdsCharmRef() {
+				decOps, err := releaseCharmRefOps(g.st, appName, staged.CharmURL, true, &ForcedOperation{})
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				ops = append(ops, decOps...)
+			}
+		}
+
+		return append(ops, g.stageApplicationOp(appName, "charm-upgrades", upgrade)), nil
+	}
+
+	return errors.Trace(g.st.db().Run(buildTxn))
+}
+
+// stageApplicationOp returns the operation that records the input value
+// for the application under the input field of the branch.
+// If the application has no entry in the branch's assigned units,
+// one is added so that the change is applied when the branch is committed.
+func (g *Generation) stageApplicationOp(appName, field string, value interface{}) txn.Op {
+	fields := bson.D{{field + "." + appName, value}}
+	if _, ok := g.doc.AssignedUnits[appName]; !ok {
+		fields = append(fields, bson.DocElem{"assigned-units." + appName, []string{}})
+	}
+	return txn.Op{
+		C:  generationsC,
+		Id: g.doc.DocId,
+		Assert: bson.D{{"$and", []bson.D{
+			{{"completed", 0}},
+			{{"txn-revno", g.doc.TxnRevno}},
+		}}},
+		Update: bson.D{{"$set", fields}},
+	}
+}
+
+// SetConstraints stages the replacement of the input application's
+// constraints under this branch.
+func (g *Generation) SetConstraints(appName string, cons constraints.Value) error {
+	unsupported, err := g.st.validateConstraints(cons)
+	if len(unsupported) > 0 {
+		logger.Warningf(
+			"setting constraints on application %q: unsupported constraints: %v", appName, strings.Join(unsupported, ","))
+	} else if err != nil {
+		return err
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if err := g.CheckNotComplete(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		app, err := g.st.Application(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if app.doc.Subordinate {
+			return nil, ErrSubordinateConstraints
+		}
+		doc := newConstraintsDoc(cons)
+		doc.ModelUUID = g.st.ModelUUID()
+		return []txn.Op{
+			{
+				C:      applicationsC,
+				Id:     app.doc.DocID,
+				Assert: isAliveDoc,
+			},
+			g.stageApplicationOp(appName, "constraints", doc),
+		}, nil
+	}
+
+	err = g.st.db().Run(buildTxn)
+	return errors.Annotatef(onAbort(err, applicationNotAliveErr), "cannot set constraints")
+}
+
+// SetScale stages a change to the input application's desired scale
+// under this branch.
+// This is used on CAAS models.
+func (g *Generation) SetScale(appName string, scale int) error {
+	if scale < 0 {
+		return errors.NotValidf("application scale %d", scale)
+	}
+	m, err := g.st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if m.Type() != ModelTypeCAAS {
+		return errors.NotSupportedf("scaling applications on a non-container model")
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if err := g.CheckNotComplete(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		app, err := g.st.Application(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{
+			{
+				C:      applicationsC,
+				Id:     app.doc.DocID,
+				Assert: isAliveDoc,
+			},
+			g.stageApplicationOp(appName, "scales", scale),
+		}, nil
+	}
+
+	err = g.st.db().Run(buildTxn)
+	return errors.Annotatef(onAbort(err, applicationNotAliveErr), "cannot set scale for application %q to %v", appName, scale)
+}
+
+// MergeBindings stages the merge of the input bindings with the input
+// application's endpoint bindings under this branch.
+// Endpoints may be mapped to space names or IDs.
+// The bindings are validated against the application's charm and,
+// unless force is true, against the spaces of its machines.
+func (g *Generation) MergeBindings(appName string, bindings map[string]string, force bool) error {
+	operatorBindings, err := NewBindings(g.st, bindings)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if err := g.CheckNotComplete(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		app, err := g.st.Application(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ch, _, err := app.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		// Merge the incoming bindings on top of those already staged.
+		staged := g.doc.Bindings[appName]
+		change := bindingsChangeDoc{
+			Bindings: make(bindingsMap),
+			Force:    staged.Force || force,
+		}
+		for endpoint, spaceID := range staged.Bindings {
+			change.Bindings[endpoint] = spaceID
+		}
+		for endpoint, spaceID := range operatorBindings.Map() {
+			change.Bindings[endpoint] = spaceID
+		}
+
+		// Validate the staged bindings as they would be applied now.
+		// The resulting operations are discarded.
+		currentMap, txnRevno, err := readEndpointBindings(g.st, app.globalKey())
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		b, err := app.bindingsForOps(currentMap)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, err := b.updateOps(txnRevno, change.Bindings, ch.Meta(), change.Force); err != nil && err != jujutxn.ErrNoOperations {
+			return nil, errors.Trace(err)
+		}
+
+		return []txn.Op{
+			{
+				C:      applicationsC,
+				Id:     app.doc.DocID,
+				Assert: isAliveDoc,
+			},
+			g.stageApplicationOp(appName, "bindings", change),
+		}, nil
+	}
+
+	err = g.st.db().Run(buildTxn)
+	return errors.Annotatef(onAbort(err, applicationNotAliveErr), "merging application bindings")
+}
+
+// stageCharmRefOps returns the operations that take a reference to the
+// input charm on behalf of units of the application that will be upgraded
+// to it under a branch. If the application has no settings for the charm,
+// they are created from the application's current settings.
+func stageCharmRefOps(app *Application, ch *Charm) ([]txn.Op, error) {
+	var ops []txn.Op
+	key := applicationCharmConfigKey(app.doc.Name, ch.URL())
+	if _, err := readSettings(app.st.db(), settingsC, key); errors.IsNotFound(err) {
+		current, err := readSettings(app.st.db(), settingsC, app.charmConfigKey())
+		if err != nil {
+			return nil, errors.Annotatef(err, "application %q", app.doc.Name)
+		}
+		ops = append(ops, createSettingsOp(settingsC, key, ch.Config().FilterSettings(current.Map())))
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "application %q", app.doc.Name)
+	}
+
+	incOps, err := appCharmIncRefOps(app.st, app.doc.Name, ch.URL(), true)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(ops, incOps...), nil
+}
+
+// releaseCharmRefOps returns the operations that drop a reference to a
+// charm taken by stageCharmRefOps.
+func releaseCharmRefOps(
+	st *State, appName, curl string, maybeDoFinal bool, op *ForcedOperation,
+) ([]txn.Op, error) {
+	url, err := charm.ParseURL(curl)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops, err := appCharmDecRefOps(st, appName, url, maybeDoFinal, op)
+	return ops, errors.Annotatef(err, "releasing charm %q staged for %q", curl, appName)
+}
+
 // Commit marks the generation as completed and assigns it the next value from
 // the generation sequence. The new generation ID is returned.
 func (g *Generation) Commit(userName string) (int, error) {
@@ -402,6 +897,21 @@ func (g *Generation) Commit(userName string) (int, error) {
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		upgradeOps, err := g.upgradeCharmTxnOps()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, upgradeOps...)
+		charmOps, err := g.commitCharmTxnOps()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, charmOps...)
+		appOps, err := g.commitApplicationTxnOps()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, appOps...)
 
 		// Get the new sequence as late as we can.
 		// If assigned is empty, indicating no changes under this branch,
@@ -440,6 +950,162 @@ func (g *Generation) Commit(userName string) (int, error) {
 	return newGenId, nil
 }
 
+// upgradeCharmTxnOps returns the operations that set the charm of each
+// application with an upgrade staged under the branch to the staged charm.
+// The config and endpoint bindings staged for such applications are
+// applied along with the upgrade, against the new charm.
+// The application's charm modified version is set to the version that
+// units tracking the branch have been seeing, so that it does not go
+// down for them, while it goes up for the application's other units.
+func (g *Generation) upgradeCharmTxnOps() ([]txn.Op, error) {
+	config := g.Config()
+	var ops []txn.Op
+	for appName, upgrade := range g.doc.CharmUpgrades {
+		app, err := g.st.Application(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		curl, err := charm.ParseURL(upgrade.CharmURL)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ch, err := g.st.Charm(curl)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		cfg := SetCharmConfig{
+			Charm:   ch,
+			Channel: csparams.Channel(upgrade.Channel),
+		}
+		if change, ok := g.doc.Bindings[appName]; ok {
+			cfg.EndpointBindings = change.Bindings
+			cfg.Force = change.Force
+		}
+		upgradeOps, _, err := app.setCharmOps(cfg, nil, config[appName])
+		if err != nil {
+			return nil, errors.Annotatef(err, "upgrading application %q to charm %q", appName, curl)
+		}
+		ops = append(ops, upgradeOps...)
+		ops = append(ops, txn.Op{
+			C:      applicationsC,
+			Id:     app.doc.DocID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"charmmodifiedversion", app.doc.CharmModifiedVersion + upgrade.ModifiedVersion},
+			}}},
+		})
+	}
+	return ops, nil
+}
+
+// hasCharmUpgrade returns true if a charm upgrade
+// is staged for the application under the branch.
+func (g *Generation) hasCharmUpgrade(appName string) bool {
+	_, ok := g.doc.CharmUpgrades[appName]
+	return ok
+}
+
+// commitCharmTxnOps returns the operations that resolve the pending
+// resources staged under the branch, and that release the references
+// held by the branch to charms that the applications have since been
+// upgraded to.
+func (g *Generation) commitCharmTxnOps() ([]txn.Op, error) {
+	var ops []txn.Op
+	for appName, upgrade := range g.doc.CharmUpgrades {
+		app, err := g.st.Application(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(upgrade.ResourceIDs) > 0 {
+			resOps, err := app.resolveResourceOps(upgrade.ResourceIDs)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, resOps...)
+		}
+		if upgrade.holdsCharmRef() {
+			decOps, err := releaseCharmRefOps(g.st, appName, upgrade.CharmURL, false, &ForcedOperation{})
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, decOps...)
+		}
+	}
+	return ops, nil
+}
+
+// commitApplicationTxnOps returns the operations that apply the
+// constraints, scales and endpoint bindings staged under the branch
+// to their applications.
+func (g *Generation) commitApplicationTxnOps() ([]txn.Op, error) {
+	var ops []txn.Op
+	for appName, doc := range g.doc.Constraints {
+		app, err := g.st.Application(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, txn.Op{
+			C:      applicationsC,
+			Id:     app.doc.DocID,
+			Assert: isAliveDoc,
+		}, setConstraintsOp(app.globalKey(), doc.value()))
+	}
+
+	for appName, scale := range g.doc.Scales {
+		app, err := g.st.Application(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, txn.Op{
+			C:      applicationsC,
+			Id:     app.doc.DocID,
+			Assert: isAliveDoc,
+			Update: bson.D{{"$set", bson.D{{"scale", scale}}}},
+		})
+		// As with scaling from the CLI, the new scale is protected
+		// from being overwritten by the cluster until applied.
+		cloudSvcOps, err := buildCloudServiceOps(g.st, cloudServiceDoc{
+			DocID:                 app.globalKey(),
+			DesiredScaleProtected: true,
+		})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, cloudSvcOps...)
+	}
+
+	for appName, change := range g.doc.Bindings {
+		if g.hasCharmUpgrade(appName) {
+			// The bindings are applied along with the upgrade.
+			continue
+		}
+		app, err := g.st.Application(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ch, _, err := app.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		currentMap, txnRevno, err := readEndpointBindings(g.st, app.globalKey())
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		b, err := app.bindingsForOps(currentMap)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		bindingsOps, err := b.updateOps(txnRevno, change.Bindings, ch.Meta(), change.Force)
+		if err == jujutxn.ErrNoOperations {
+			continue
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "merging bindings for %q", appName)
+		}
+		ops = append(ops, bindingsOps...)
+	}
+	return ops, nil
+}
+
 // assignedWithAllUnits generates a new value for the branch's
 // AssignedUnits field, to indicate that all units of changed applications
 // are tracking the branch.
@@ -462,7 +1128,9 @@ func (g *Generation) assignedWithAllUnits() (map[string][]string, error) {
 func (g *Generation) commitConfigTxnOps() ([]txn.Op, error) {
 	var ops []txn.Op
 	for appName, delta := range g.Config() {
-		if len(delta) == 0 {
+		if len(delta) == 0 || g.hasCharmUpgrade(appName) {
+			// Config for an upgraded application
+			// is applied along with the upgrade.
 			continue
 		}
 		app, err := g.st.Application(appName)
@@ -488,9 +1156,171 @@ func (g *Generation) commitConfigTxnOps() ([]txn.Op, error) {
 	return ops, nil
 }
 
+// StartRollout starts a progressive rollout of the branch using the input
+// policy. Units not yet tracking the branch are set to track it in batches,
+// and the branch is committed on behalf of the input user once they all are.
+// A rollout that was stopped can be restarted.
+func (g *Generation) StartRollout(policy model.RolloutPolicy, userName string) error {
+	if err := policy.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if err := g.CheckNotComplete(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if g.doc.Rollout != nil && g.doc.Rollout.Halted == "" {
+			return nil, errors.AlreadyExistsf("rollout of branch %q", g.BranchName())
+		}
+
+		now, err := g.st.ControllerTimestamp()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:  generationsC,
+			Id: g.doc.DocId,
+			Assert: bson.D{{"$and", []bson.D{
+				{{"completed", 0}},
+				{{"txn-revno", g.doc.TxnRevno}},
+			}}},
+			Update: bson.D{{"$set", bson.D{{"rollout", rolloutDoc{
+				BatchSize:     policy.BatchSize,
+				BatchInterval: policy.BatchInterval,
+				HaltOnError:   policy.HaltOnError,
+				Started:       now.Unix(),
+				StartedBy:     userName,
+			}}}}},
+		}}, nil
+	}
+
+	return errors.Trace(g.st.db().Run(buildTxn))
+}
+
+// AssignRolloutBatch sets the units with the input names to track the
+// branch, as a batch of its rollout.
+// Units already tracking the branch are ignored.
+func (g *Generation) AssignRolloutBatch(unitNames []string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if err := g.CheckNotComplete(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := g.checkRolloutActive(); err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		var ops []txn.Op
+		for _, unitName := range unitNames {
+			if g.IsTracking(unitName) {
+				continue
+			}
+			appName, err := names.UnitApplication(unitName)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			unit, err := g.st.Unit(unitName)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, assignGenerationUnitTxnOps(g.doc.DocId, appName, unit)...)
+		}
+
+		now, err := g.st.ControllerTimestamp()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, txn.Op{
+			C:  generationsC,
+			Id: g.doc.DocId,
+			Assert: bson.D{
+				{"rollout", bson.D{{"$exists", true}}},
+				{"rollout.halted", bson.D{{"$exists", false}}},
+			},
+			Update: bson.D{
+				{"$set", bson.D{{"rollout.last-batch", now.Unix()}}},
+				{"$inc", bson.D{{"rollout.batches", 1}}},
+			},
+		}), nil
+	}
+
+	return errors.Trace(g.st.db().Run(buildTxn))
+}
+
+// HaltRollout stops the rollout of the branch, recording the input reason.
+// Units already tracking the branch continue to do so.
+func (g *Generation) HaltRollout(reason string) error {
+	if reason == "" {
+		return errors.NotValidf("empty reason for halting rollout")
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if err := g.CheckNotComplete(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if g.doc.Rollout == nil {
+			return nil, errors.NotFoundf("rollout of branch %q", g.BranchName())
+		}
+		if g.doc.Rollout.Halted != "" {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:  generationsC,
+			Id: g.doc.DocId,
+			Assert: bson.D{
+				{"completed", 0},
+				{"rollout", bson.D{{"$exists", true}}},
+				{"rollout.halted", bson.D{{"$exists", false}}},
+			},
+			Update: bson.D{{"$set", bson.D{{"rollout.halted", reason}}}},
+		}}, nil
+	}
+
+	return errors.Trace(g.st.db().Run(buildTxn))
+}
+
+// checkRolloutActive returns an error if no rollout
+// of the branch was started, or if it was stopped.
+func (g *Generation) checkRolloutActive() error {
+	if g.doc.Rollout == nil {
+		return errors.NotFoundf("rollout of branch %q", g.BranchName())
+	}
+	if g.doc.Rollout.Halted != "" {
+		return errors.Errorf("rollout of branch %q was halted: %s", g.BranchName(), g.doc.Rollout.Halted)
+	}
+	return nil
+}
+
 // Abort marks the generation as completed however no value is assigned from
 // the generation sequence.
+// If the branch is being rolled out, the rollout is stopped first,
+// so that no more units are set to track the branch.
 func (g *Generation) Abort(userName string) error {
+	var rolloutStopped bool
+	if g.doc.Completed == 0 && g.doc.Rollout != nil && g.doc.Rollout.Halted == "" {
+		if err := g.HaltRollout("aborted by " + userName); err != nil {
+			return errors.Trace(err)
+		}
+		if err := g.Refresh(); err != nil {
+			return errors.Trace(err)
+		}
+		rolloutStopped = true
+	}
+
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		if attempt > 0 {
 			if err := g.Refresh(); err != nil {
@@ -509,14 +1339,27 @@ func (g *Generation) Abort(userName string) error {
 		assigned := g.AssignedUnits()
 		for _, units := range assigned {
 			if len(units) > 0 {
-				return nil, errors.New("branch is in progress. Either reset values on tracking units or remove them to abort.")
+				err := errors.New("branch is in progress. Either reset values on tracking units or remove them to abort.")
+				if rolloutStopped {
+					err = errors.Annotate(err, "rollout stopped")
+				}
+				return nil, err
 			}
 		}
 
-		// Must not have upgraded charm of tracked application.
-		// TODO (hml) 2019-06-26
-		// Implement cannot abort branch where tracked application has
-		// been upgraded.
+		// With no units tracking the branch, no units are running charms
+		// upgraded under it, so the references it holds can be released.
+		var ops []txn.Op
+		for appName, upgrade := range g.doc.CharmUpgrades {
+			if !upgrade.holdsCharmRef() {
+				continue
+			}
+			decOps, err := releaseCharmRefOps(g.st, appName, upgrade.CharmURL, true, &ForcedOperation{})
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, decOps...)
+		}
 
 		now, err := g.st.ControllerTimestamp()
 		if err != nil {
@@ -525,7 +1368,7 @@ func (g *Generation) Abort(userName string) error {
 		// As a proxy for checking that the generation has not changed,
 		// Assert that the txn rev-no has not changed since we materialised
 		// this generation object.
-		ops := []txn.Op{{
+		ops = append(ops, txn.Op{
 			C:      generationsC,
 			Id:     g.doc.DocId,
 			Assert: bson.D{{"txn-revno", g.doc.TxnRevno}},
@@ -535,11 +1378,32 @@ func (g *Generation) Abort(userName string) error {
 					{"completed-by", userName},
 				}},
 			},
-		}}
+		})
 		return ops, nil
 	}
 
-	return errors.Trace(g.st.db().Run(buildTxn))
+	if err := g.st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(g.removePendingResources())
+}
+
+// removePendingResources removes the pending resources staged under the
+// branch, which will never be resolved.
+func (g *Generation) removePendingResources() error {
+	resources, err := g.st.Resources()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for appName, upgrade := range g.doc.CharmUpgrades {
+		if len(upgrade.ResourceIDs) == 0 {
+			continue
+		}
+		if err := resources.RemovePendingAppResources(appName, upgrade.ResourceIDs); err != nil {
+			return errors.Annotatef(err, "removing resources staged for %q", appName)
+		}
+	}
+	return nil
 }
 
 // CheckNotComplete returns an error if this
@@ -597,16 +1461,30 @@ func (g *Generation) unassignUnitOps(unitName, appName string) []txn.Op {
 	}}
 }
 
-// HasChangesFor returns true when the generation has config changes for
-// the provided application.
+// HasChangesFor returns true when the generation has config changes,
+// a charm upgrade, constraints, scale or bindings for the provided
+// application.
 func (g *Generation) HasChangesFor(appName string) bool {
-	_, ok := g.doc.Config[appName]
+	if _, ok := g.doc.Config[appName]; ok {
+		return true
+	}
+	if _, ok := g.doc.CharmUpgrades[appName]; ok {
+		return true
+	}
+	if _, ok := g.doc.Constraints[appName]; ok {
+		return true
+	}
+	if _, ok := g.doc.Scales[appName]; ok {
+		return true
+	}
+	_, ok := g.doc.Bindings[appName]
 	return ok
 }
 
-// unassignAppOps returns operations to remove the tracking and config data
-// for the application from the generation.
-func (g *Generation) unassignAppOps(appName string) []txn.Op {
+// unassignAppOps returns operations to remove the tracking, config,
+// charm upgrade and other staged data for the application from the
+// generation.
+func (g *Generation) unassignAppOps(appName string, op *ForcedOperation) ([]txn.Op, error) {
 	assigned := g.doc.AssignedUnits
 	delete(assigned, appName)
 	ops := []txn.Op{{
@@ -635,7 +1513,42 @@ func (g *Generation) unassignAppOps(appName string) []txn.Op {
 			},
 		})
 	}
-	return ops
+	if upgrade, ok := g.doc.CharmUpgrades[appName]; ok {
+		if upgrade.holdsCharmRef() {
+			decOps, err := releaseCharmRefOps(g.st, appName, upgrade.CharmURL, true, op)
+			if op.FatalError(err) {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, decOps...)
+		}
+		ops = append(ops, txn.Op{
+			C:      generationsC,
+			Id:     g.doc.DocId,
+			Assert: bson.D{{"txn-revno", g.doc.TxnRevno}},
+			Update: bson.D{
+				{"$unset", bson.D{{"charm-upgrades." + appName, 1}}},
+			},
+		})
+	}
+	var unset bson.D
+	if _, ok := g.doc.Constraints[appName]; ok {
+		unset = append(unset, bson.DocElem{"constraints." + appName, 1})
+	}
+	if _, ok := g.doc.Scales[appName]; ok {
+		unset = append(unset, bson.DocElem{"scales." + appName, 1})
+	}
+	if _, ok := g.doc.Bindings[appName]; ok {
+		unset = append(unset, bson.DocElem{"bindings." + appName, 1})
+	}
+	if len(unset) > 0 {
+		ops = append(ops, txn.Op{
+			C:      generationsC,
+			Id:     g.doc.DocId,
+			Assert: bson.D{{"txn-revno", g.doc.TxnRevno}},
+			Update: bson.D{{"$unset", unset}},
+		})
+	}
+	return ops, nil
 }
 
 // AddBranch creates a new branch in the current model.
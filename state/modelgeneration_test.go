@@ -12,6 +12,7 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6"
 
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/settings"
 	"github.com/juju/juju/state"
@@ -24,6 +25,11 @@ const (
 	branchCommitter  = "commit-user"
 )
 
+const riakCfgYAML = `
+options:
+  http_port: {default: 8089, description: HTTP Port, type: int}
+`
+
 type generationSuite struct {
 	ConnSuite
 
@@ -284,6 +290,274 @@ func (s *generationSuite) TestCommitAppliesConfigDeltas(c *gc.C) {
 	c.Check(cfg, gc.DeepEquals, charm.Settings(newCfg))
 }
 
+func (s *generationSuite) TestCommitAppliesCharmUpgrade(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignUnits(c)
+	sch := s.AddConfigCharm(c, "riak", riakCfgYAML, 667)
+
+	app, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.SetCharm(state.SetCharmConfig{Charm: sch, BranchName: newBranchName}), jc.ErrorIsNil)
+
+	// Master is unchanged until the branch is committed.
+	c.Assert(app.Refresh(), jc.ErrorIsNil)
+	curl, _ := app.CharmURL()
+	c.Check(curl, gc.DeepEquals, s.ch.URL())
+
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.CharmURLs(), gc.DeepEquals, map[string]string{"riak": sch.URL().String()})
+
+	// Master may not be upgraded while the branch holds an upgrade.
+	err = app.SetCharm(state.SetCharmConfig{Charm: sch})
+	c.Assert(err, gc.ErrorMatches, `charm upgrade is staged in branch "new-branch"; commit or abort it first`)
+
+	_, err = gen.Commit(branchCommitter)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(app.Refresh(), jc.ErrorIsNil)
+	curl, _ = app.CharmURL()
+	c.Check(curl, gc.DeepEquals, sch.URL())
+}
+
+func (s *generationSuite) TestCommitAppliesCharmUpgradeWithConfigDeltas(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignUnits(c)
+	sch := s.AddConfigCharm(c, "riak", riakCfgYAML, 667)
+
+	app, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.SetCharm(state.SetCharmConfig{Charm: sch, BranchName: newBranchName}), jc.ErrorIsNil)
+
+	newCfg := map[string]interface{}{"http_port": int64(9999)}
+	c.Assert(app.UpdateCharmConfig(newBranchName, newCfg), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	_, err = gen.Commit(branchCommitter)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The upgrade and the config delta land together, with the delta
+	// applied to the settings of the new charm.
+	c.Assert(app.Refresh(), jc.ErrorIsNil)
+	curl, _ := app.CharmURL()
+	c.Check(curl, gc.DeepEquals, sch.URL())
+
+	cfg, err := app.CharmConfig(model.GenerationMaster)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg, gc.DeepEquals, charm.Settings(newCfg))
+}
+
+func (s *generationSuite) TestBranchCharmModifiedVersion(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+	sch := s.AddConfigCharm(c, "riak", riakCfgYAML, 667)
+	c.Assert(gen.AssignUnit("riak/0"), jc.ErrorIsNil)
+
+	tracking, err := s.State.Unit("riak/0")
+	c.Assert(err, jc.ErrorIsNil)
+	other, err := s.State.Unit("riak/1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	ver, err := tracking.BranchCharmModifiedVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ver, gc.Equals, 0)
+
+	app, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.SetCharm(state.SetCharmConfig{Charm: sch, BranchName: newBranchName}), jc.ErrorIsNil)
+
+	ver, err = tracking.BranchCharmModifiedVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ver, gc.Equals, 1)
+
+	// Staging the upgrade again, as is done when only its
+	// resources change, increments the version.
+	c.Assert(app.SetCharm(state.SetCharmConfig{Charm: sch, BranchName: newBranchName}), jc.ErrorIsNil)
+
+	ver, err = tracking.BranchCharmModifiedVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ver, gc.Equals, 2)
+
+	ver, err = other.BranchCharmModifiedVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ver, gc.Equals, 0)
+}
+
+func (s *generationSuite) TestCommitKeepsBranchCharmModifiedVersion(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+	sch := s.AddConfigCharm(c, "riak", riakCfgYAML, 667)
+	c.Assert(gen.AssignUnit("riak/0"), jc.ErrorIsNil)
+
+	app, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	before := app.CharmModifiedVersion()
+	c.Assert(app.SetCharm(state.SetCharmConfig{Charm: sch, BranchName: newBranchName}), jc.ErrorIsNil)
+	c.Assert(app.SetCharm(state.SetCharmConfig{Charm: sch, BranchName: newBranchName}), jc.ErrorIsNil)
+
+	tracking, err := s.State.Unit("riak/0")
+	c.Assert(err, jc.ErrorIsNil)
+	ver, err := tracking.BranchCharmModifiedVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ver, gc.Equals, 2)
+
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	_, err = gen.Commit(branchCommitter)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The units tracking the branch saw the application's version plus
+	// the branch's; the committed application's version is the same, so
+	// it neither goes down for them nor is left unchanged for the others.
+	c.Assert(app.Refresh(), jc.ErrorIsNil)
+	c.Check(app.CharmModifiedVersion(), gc.Equals, before+2)
+	ver, err = tracking.BranchCharmModifiedVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ver, gc.Equals, 0)
+}
+
+func (s *generationSuite) TestAbortDiscardsCharmUpgrade(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignUnits(c)
+	sch := s.AddConfigCharm(c, "riak", riakCfgYAML, 667)
+
+	app, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.SetCharm(state.SetCharmConfig{Charm: sch, BranchName: newBranchName}), jc.ErrorIsNil)
+
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Assert(gen.Abort(branchCommitter), jc.ErrorIsNil)
+
+	c.Assert(app.Refresh(), jc.ErrorIsNil)
+	curl, _ := app.CharmURL()
+	c.Check(curl, gc.DeepEquals, s.ch.URL())
+
+	// With the branch gone, master can be upgraded again.
+	c.Assert(app.SetCharm(state.SetCharmConfig{Charm: sch}), jc.ErrorIsNil)
+}
+
+func (s *generationSuite) TestBranchCharmUpgradeRejectsConfig(c *gc.C) {
+	s.setupTestingClock(c)
+	s.setupAssignUnits(c)
+	sch := s.AddConfigCharm(c, "riak", riakCfgYAML, 667)
+
+	app, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.SetCharm(state.SetCharmConfig{
+		Charm:          sch,
+		BranchName:     newBranchName,
+		ConfigSettings: charm.Settings{"http_port": int64(1234)},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *generationSuite) TestCommitAppliesConstraints(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignUnits(c)
+
+	cons := constraints.MustParse("mem=4G")
+	c.Assert(gen.SetConstraints("riak", cons), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.Constraints(), gc.DeepEquals, map[string]constraints.Value{"riak": cons})
+	c.Check(gen.AssignedUnits(), gc.DeepEquals, map[string][]string{"riak": {}})
+
+	// Master is unchanged until the branch is committed.
+	app, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	appCons, err := app.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(appCons, gc.DeepEquals, constraints.Value{})
+
+	genId, err := gen.Commit(branchCommitter)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(genId, gc.Not(gc.Equals), 0)
+
+	appCons, err = app.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(appCons, gc.DeepEquals, cons)
+}
+
+func (s *generationSuite) TestSetScaleNotCAAS(c *gc.C) {
+	gen := s.setupAssignUnits(c)
+
+	err := gen.SetScale("riak", 3)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *generationSuite) TestRolloutAssignsBatches(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+	c.Assert(gen.AssignUnit("riak/0"), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	_, ok := gen.Rollout()
+	c.Check(ok, jc.IsFalse)
+
+	policy := model.RolloutPolicy{BatchSize: 2, BatchInterval: time.Minute, HaltOnError: true}
+	c.Assert(gen.StartRollout(policy, branchCommitter), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	rollout, ok := gen.Rollout()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(rollout.RolloutPolicy, gc.DeepEquals, policy)
+	c.Check(rollout.StartedBy, gc.Equals, branchCommitter)
+	c.Check(rollout.LastBatch.IsZero(), jc.IsTrue)
+	c.Check(rollout.Batches, gc.Equals, 0)
+
+	err := gen.StartRollout(policy, branchCommitter)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+
+	// Units already tracking the branch are ignored.
+	c.Assert(gen.AssignRolloutBatch([]string{"riak/0", "riak/1", "riak/2"}), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.AssignedUnits()["riak"], jc.SameContents, []string{"riak/0", "riak/1", "riak/2"})
+
+	rollout, _ = gen.Rollout()
+	c.Check(rollout.LastBatch.IsZero(), jc.IsFalse)
+	c.Check(rollout.Batches, gc.Equals, 1)
+}
+
+func (s *generationSuite) TestHaltRolloutStopsBatches(c *gc.C) {
+	gen := s.setupAssignAllUnits(c)
+
+	err := gen.HaltRollout("no reason")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	c.Assert(gen.StartRollout(model.RolloutPolicy{BatchSize: 1}, branchCommitter), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Assert(gen.HaltRollout("riak/0 in error"), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	rollout, ok := gen.Rollout()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(rollout.Halted, gc.Equals, "riak/0 in error")
+
+	err = gen.AssignRolloutBatch([]string{"riak/1"})
+	c.Assert(err, gc.ErrorMatches, `rollout of branch "new-branch" was halted: riak/0 in error`)
+
+	// A halted rollout can be restarted.
+	c.Assert(gen.StartRollout(model.RolloutPolicy{BatchSize: 1}, branchCommitter), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	rollout, _ = gen.Rollout()
+	c.Check(rollout.Halted, gc.Equals, "")
+}
+
+func (s *generationSuite) TestAbortStopsRollout(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+
+	c.Assert(gen.StartRollout(model.RolloutPolicy{BatchSize: 1}, branchCommitter), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Assert(gen.AssignRolloutBatch([]string{"riak/0"}), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	err := gen.Abort(branchCommitter)
+	c.Assert(err, gc.ErrorMatches, "rollout stopped: branch is in progress. .*")
+
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.IsCompleted(), jc.IsFalse)
+	rollout, _ := gen.Rollout()
+	c.Check(rollout.Halted, gc.Equals, "aborted by "+branchCommitter)
+}
+
 func (s *generationSuite) TestAbortSuccess(c *gc.C) {
 	s.setupTestingClock(c)
 
@@ -509,11 +783,7 @@ func (s *generationSuite) TestDestroyCleansupBranches(c *gc.C) {
 }
 
 func (s *generationSuite) setupAssignAllUnits(c *gc.C) *state.Generation {
-	var cfgYAML = `
-options:
-  http_port: {default: 8089, description: HTTP Port, type: int}
-`
-	s.ch = s.AddConfigCharm(c, "riak", cfgYAML, 666)
+	s.ch = s.AddConfigCharm(c, "riak", riakCfgYAML, 666)
 
 	riak := s.AddTestingApplication(c, "riak", s.ch)
 	for i := 0; i < 4; i++ {
@@ -525,11 +795,7 @@ options:
 }
 
 func (s *generationSuite) setupAssignUnits(c *gc.C) *state.Generation {
-	var cfgYAML = `
-options:
-  http_port: {default: 8089, description: HTTP Port, type: int}
-`
-	s.ch = s.AddConfigCharm(c, "riak", cfgYAML, 666)
+	s.ch = s.AddConfigCharm(c, "riak", riakCfgYAML, 666)
 
 	s.AddTestingApplication(c, "riak", s.ch)
 
@@ -0,0 +1,432 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils/set"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// operationDoc records the progress of an action being rolled out
+// across a set of units, a batch of units at a time.
+type operationDoc struct {
+	DocId     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+
+	// ActionName is the name of the action run on each unit.
+	ActionName string `bson:"action-name"`
+
+	// Parameters holds the parameters the action is run with.
+	Parameters map[string]interface{} `bson:"parameters"`
+
+	// QueueTimeout and ExecutionTimeout are applied to each action
+	// queued by the operation.
+	QueueTimeout     time.Duration `bson:"queue-timeout,omitempty"`
+	ExecutionTimeout time.Duration `bson:"execution-timeout,omitempty"`
+
+	// Units holds the names of the units to run the action on,
+	// in the order in which they are to be run.
+	Units []string `bson:"units"`
+
+	// BatchSize is the number of units the action is run on at a time.
+	BatchSize int `bson:"batch-size"`
+
+	// StopOnFailure indicates that no further batches are to be run
+	// once the action has failed or been cancelled on any unit.
+	StopOnFailure bool `bson:"stop-on-failure"`
+
+	// Next is the index into Units of the first unit the action
+	// has not yet been queued for.
+	Next int `bson:"next"`
+
+	// Status is ActionRunning while the operation is in progress, and
+	// ActionCompleted or ActionFailed once it has finished.
+	Status ActionStatus `bson:"status"`
+
+	// Message describes why the operation failed, if it did.
+	Message string `bson:"message"`
+
+	Enqueued  time.Time `bson:"enqueued"`
+	Completed time.Time `bson:"completed"`
+}
+
+// OperationArgs holds the details of an action to be rolled out
+// across a set of units.
+type OperationArgs struct {
+	// ActionName is the name of the action to run on each unit.
+	ActionName string
+
+	// Parameters holds the parameters to run the action with.
+	Parameters map[string]interface{}
+
+	// Timeouts are applied to each action queued by the operation.
+	Timeouts ActionTimeouts
+
+	// Units holds the names of the units to run the action on, in the
+	// order in which they are to be run.
+	Units []string
+
+	// BatchSize is the number of units to run the action on at a time.
+	BatchSize int
+
+	// StopOnFailure, if true, stops the operation from queueing any
+	// further batches once the action fails on any unit.
+	StopOnFailure bool
+}
+
+// Validate returns an error if the arguments do not describe
+// a valid operation.
+func (args OperationArgs) Validate() error {
+	if args.ActionName == "" {
+		return errors.NotValidf("empty action name")
+	}
+	if len(args.Units) == 0 {
+		return errors.NotValidf("operation with no units")
+	}
+	if set.NewStrings(args.Units...).Size() != len(args.Units) {
+		return errors.NotValidf("duplicate units in operation")
+	}
+	if args.BatchSize <= 0 {
+		return errors.NotValidf("non-positive batch size %d", args.BatchSize)
+	}
+	return errors.Trace(args.Timeouts.Validate())
+}
+
+// Operation represents an action being rolled out across a set of units.
+type Operation struct {
+	st  *State
+	doc operationDoc
+}
+
+// Id returns the id of the operation.
+func (op *Operation) Id() string {
+	return op.st.localID(op.doc.DocId)
+}
+
+// ActionName returns the name of the action run by the operation.
+func (op *Operation) ActionName() string {
+	return op.doc.ActionName
+}
+
+// Units returns the names of the units the action is run on,
+// in the order in which they are run.
+func (op *Operation) Units() []string {
+	return op.doc.Units
+}
+
+// BatchSize returns the number of units the action is run on at a time.
+func (op *Operation) BatchSize() int {
+	return op.doc.BatchSize
+}
+
+// StopOnFailure reports whether the operation stops once the action
+// fails on any unit.
+func (op *Operation) StopOnFailure() bool {
+	return op.doc.StopOnFailure
+}
+
+// Queued returns the number of units the action has been queued for.
+func (op *Operation) Queued() int {
+	return op.doc.Next
+}
+
+// Status returns the status of the operation.
+func (op *Operation) Status() ActionStatus {
+	return op.doc.Status
+}
+
+// Message returns the reason the operation failed, if it did.
+func (op *Operation) Message() string {
+	return op.doc.Message
+}
+
+// Enqueued returns the time the operation was added.
+func (op *Operation) Enqueued() time.Time {
+	return op.doc.Enqueued
+}
+
+// Completed returns the time the operation finished.
+func (op *Operation) Completed() time.Time {
+	return op.doc.Completed
+}
+
+// Actions returns the actions queued by the operation so far, in the
+// order of the units they were queued for.
+func (op *Operation) Actions() ([]Action, error) {
+	docs, err := operationActionDocs(op.st, op.doc.DocId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	order := make(map[string]int, len(op.doc.Units))
+	for i, unitName := range op.doc.Units {
+		order[unitName] = i
+	}
+	sort.Slice(docs, func(i, j int) bool {
+		return order[docs[i].Receiver] < order[docs[j].Receiver]
+	})
+	actions := make([]Action, len(docs))
+	for i, doc := range docs {
+		actions[i] = newAction(op.st, doc)
+	}
+	return actions, nil
+}
+
+// Refresh refreshes the contents of the operation from the database.
+func (op *Operation) Refresh() error {
+	doc, err := getOperationDoc(op.st, op.doc.DocId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	op.doc = doc
+	return nil
+}
+
+// EnqueueOperation records an operation that runs the described action
+// on each of the units in turn, a batch at a time, and queues the action
+// for the first batch. Each subsequent batch is queued once the action
+// has finished on every unit of the previous one.
+func (m *Model) EnqueueOperation(args OperationArgs) (*Operation, error) {
+	if err := args.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := checkModelActive(m.st); err != nil {
+		return nil, errors.Trace(err)
+	}
+	id, err := sequence(m.st, "operation")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	doc := operationDoc{
+		// Start numbering from 1 not 0.
+		DocId:            m.st.docID(strconv.Itoa(id + 1)),
+		ModelUUID:        m.st.ModelUUID(),
+		ActionName:       args.ActionName,
+		Parameters:       args.Parameters,
+		QueueTimeout:     args.Timeouts.Queue,
+		ExecutionTimeout: args.Timeouts.Execution,
+		Units:            args.Units,
+		BatchSize:        args.BatchSize,
+		StopOnFailure:    args.StopOnFailure,
+		Status:           ActionRunning,
+		Enqueued:         m.st.nowToTheSecond(),
+	}
+	err = m.st.db().RunTransaction([]txn.Op{
+		m.assertActiveOp(),
+		{
+			C:      operationsC,
+			Id:     doc.DocId,
+			Assert: txn.DocMissing,
+			Insert: doc,
+		},
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot add operation")
+	}
+	if err := advanceOperation(m.st, doc.DocId); err != nil {
+		return nil, errors.Annotate(err, "cannot queue first batch of operation")
+	}
+	return m.Operation(m.st.localID(doc.DocId))
+}
+
+// Operation returns the operation with the given id.
+func (m *Model) Operation(id string) (*Operation, error) {
+	doc, err := getOperationDoc(m.st, m.st.docID(id))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Operation{st: m.st, doc: doc}, nil
+}
+
+// AllOperations returns all the operations in the model.
+func (m *Model) AllOperations() ([]*Operation, error) {
+	operations, closer := m.st.db().GetCollection(operationsC)
+	defer closer()
+
+	var docs []operationDoc
+	if err := operations.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get all operations")
+	}
+	result := make([]*Operation, len(docs))
+	for i, doc := range docs {
+		result[i] = &Operation{st: m.st, doc: doc}
+	}
+	return result, nil
+}
+
+func getOperationDoc(st *State, docID string) (operationDoc, error) {
+	operations, closer := st.db().GetCollection(operationsC)
+	defer closer()
+
+	var doc operationDoc
+	err := operations.FindId(docID).One(&doc)
+	if err == mgo.ErrNotFound {
+		return operationDoc{}, errors.NotFoundf("operation %q", st.localID(docID))
+	}
+	if err != nil {
+		return operationDoc{}, errors.Annotatef(err, "cannot get operation %q", st.localID(docID))
+	}
+	return doc, nil
+}
+
+func operationActionDocs(st *State, docID string) ([]actionDoc, error) {
+	actions, closer := st.db().GetCollection(actionsC)
+	defer closer()
+
+	var docs []actionDoc
+	err := actions.Find(bson.D{{"operation", docID}}).All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get actions of operation %q", st.localID(docID))
+	}
+	return docs, nil
+}
+
+// advanceOperation takes the next step in the operation with the given
+// doc id: nothing while actions in the current batch are unfinished,
+// otherwise queueing the action for the next batch of units, or
+// finishing the operation once there are no units left to run on or,
+// if it stops on failure, once the action has failed on any unit.
+func advanceOperation(st *State, docID string) error {
+	buildTxn := func(int) ([]txn.Op, error) {
+		doc, err := getOperationDoc(st, docID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if doc.Status != ActionRunning {
+			return nil, jujutxn.ErrNoOperations
+		}
+		actions, err := operationActionDocs(st, docID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var (
+			unfinished int
+			failed     []string
+		)
+		for _, a := range actions {
+			switch a.Status {
+			case ActionPending, ActionRunning:
+				unfinished++
+			case ActionFailed, ActionCancelled:
+				failed = append(failed, a.Receiver)
+			}
+		}
+
+		if len(failed) > 0 && doc.StopOnFailure {
+			message := fmt.Sprintf("stopped after action failed on %s", strings.Join(failed, ", "))
+			return finishOperationOps(st, doc, ActionFailed, message), nil
+		}
+		if unfinished > 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		if doc.Next >= len(doc.Units) {
+			if len(failed) > 0 {
+				message := fmt.Sprintf("action failed on %s", strings.Join(failed, ", "))
+				return finishOperationOps(st, doc, ActionFailed, message), nil
+			}
+			return finishOperationOps(st, doc, ActionCompleted, ""), nil
+		}
+
+		next := doc.Next + doc.BatchSize
+		if next > len(doc.Units) {
+			next = len(doc.Units)
+		}
+		ops := []txn.Op{{
+			C:      operationsC,
+			Id:     docID,
+			Assert: bson.D{{"status", ActionRunning}, {"next", doc.Next}},
+			Update: bson.D{{"$set", bson.D{{"next", next}}}},
+		}}
+		for _, unitName := range doc.Units[doc.Next:next] {
+			actionOps, err := queueOperationActionOps(st, doc, unitName)
+			if err != nil {
+				message := fmt.Sprintf("cannot run action on %s: %v", unitName, err)
+				return finishOperationOps(st, doc, ActionFailed, message), nil
+			}
+			ops = append(ops, actionOps...)
+		}
+		return ops, nil
+	}
+	return errors.Trace(st.db().Run(buildTxn))
+}
+
+// advanceRunningOperations advances every running operation, so that
+// an operation whose advance failed when one of its actions finished
+// does not wait forever on actions that are all done. A failure to
+// advance one operation does not stop the others from being advanced.
+func advanceRunningOperations(st *State) error {
+	operations, closer := st.db().GetCollection(operationsC)
+	defer closer()
+
+	var docs []operationDoc
+	err := operations.Find(bson.D{{"status", ActionRunning}}).Select(bson.D{{"_id", 1}}).All(&docs)
+	if err != nil {
+		return errors.Annotate(err, "cannot get running operations")
+	}
+	var failed []string
+	for _, doc := range docs {
+		if err := advanceOperation(st, doc.DocId); err != nil {
+			failed = append(failed, fmt.Sprintf("cannot advance operation %q: %v", st.localID(doc.DocId), err))
+		}
+	}
+	if len(failed) > 0 {
+		return errors.New(strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// queueOperationActionOps returns the operations needed to queue the
+// operation's action for the named unit.
+func queueOperationActionOps(st *State, doc operationDoc, unitName string) ([]txn.Op, error) {
+	unit, err := st.Unit(unitName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if unit.Life() == Dead {
+		return nil, ErrDead
+	}
+	payload, err := unit.actionPayload(doc.ActionName, doc.Parameters)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	agentVersion, err := m.AgentVersion()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	timeouts := ActionTimeouts{Queue: doc.QueueTimeout, Execution: doc.ExecutionTimeout}
+	adoc, ndoc, err := newActionDoc(st, unit.Tag(), doc.ActionName, payload, timeouts, agentVersion)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	adoc.Operation = doc.DocId
+	return enqueueActionOps(unitsC, unit.doc.DocID, adoc, ndoc), nil
+}
+
+// finishOperationOps returns the operations needed to finish the
+// operation with the given status and message.
+func finishOperationOps(st *State, doc operationDoc, status ActionStatus, message string) []txn.Op {
+	return []txn.Op{{
+		C:      operationsC,
+		Id:     doc.DocId,
+		Assert: bson.D{{"status", ActionRunning}, {"next", doc.Next}},
+		Update: bson.D{{"$set", bson.D{
+			{"status", status},
+			{"message", message},
+			{"completed", st.nowToTheSecond()},
+		}}},
+	}}
+}
@@ -0,0 +1,220 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state"
+)
+
+type OperationSuite struct {
+	ConnSuite
+	units []*state.Unit
+}
+
+var _ = gc.Suite(&OperationSuite{})
+
+func (s *OperationSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+
+	ch := s.AddTestingCharm(c, "dummy")
+	app := s.AddTestingApplication(c, "dummy", ch)
+	curl, _ := app.CharmURL()
+	s.units = nil
+	for i := 0; i < 3; i++ {
+		unit, err := app.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		err = unit.SetCharmURL(curl)
+		c.Assert(err, jc.ErrorIsNil)
+		s.units = append(s.units, unit)
+	}
+}
+
+func (s *OperationSuite) unitNames() []string {
+	names := make([]string, len(s.units))
+	for i, unit := range s.units {
+		names[i] = unit.Name()
+	}
+	return names
+}
+
+func (s *OperationSuite) TestAllOperations(c *gc.C) {
+	ops, err := s.Model.AllOperations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ops, gc.HasLen, 0)
+
+	for i := 0; i < 2; i++ {
+		_, err := s.Model.EnqueueOperation(state.OperationArgs{
+			ActionName: "snapshot",
+			Units:      s.unitNames(),
+			BatchSize:  1,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	ops, err = s.Model.AllOperations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ops, gc.HasLen, 2)
+	ids := []string{ops[0].Id(), ops[1].Id()}
+	c.Assert(ids, jc.SameContents, []string{"1", "2"})
+}
+
+func (s *OperationSuite) TestEnqueueOperationValidation(c *gc.C) {
+	for i, test := range []struct {
+		args   state.OperationArgs
+		expect string
+	}{{
+		args:   state.OperationArgs{Units: s.unitNames(), BatchSize: 1},
+		expect: "empty action name not valid",
+	}, {
+		args:   state.OperationArgs{ActionName: "snapshot", BatchSize: 1},
+		expect: "operation with no units not valid",
+	}, {
+		args:   state.OperationArgs{ActionName: "snapshot", Units: []string{"dummy/0", "dummy/0"}, BatchSize: 1},
+		expect: "duplicate units in operation not valid",
+	}, {
+		args:   state.OperationArgs{ActionName: "snapshot", Units: s.unitNames()},
+		expect: "non-positive batch size 0 not valid",
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.Model.EnqueueOperation(test.args)
+		c.Check(err, gc.ErrorMatches, test.expect)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *OperationSuite) TestEnqueueOperationRunsInBatches(c *gc.C) {
+	op, err := s.Model.EnqueueOperation(state.OperationArgs{
+		ActionName: "snapshot",
+		Parameters: map[string]interface{}{"outfile": "foo.txt"},
+		Units:      s.unitNames(),
+		BatchSize:  2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.Id(), gc.Equals, "1")
+	c.Assert(op.Status(), gc.Equals, state.ActionRunning)
+	c.Assert(op.Queued(), gc.Equals, 2)
+
+	actions := s.assertOperationActions(c, op, "dummy/0", "dummy/1")
+	c.Assert(actions[0].Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "foo.txt"})
+
+	// The next batch is not queued until every action in the current
+	// one has finished.
+	s.finish(c, actions[0], state.ActionCompleted)
+	s.assertOperationActions(c, op, "dummy/0", "dummy/1")
+
+	s.finish(c, actions[1], state.ActionFailed)
+	actions = s.assertOperationActions(c, op, "dummy/0", "dummy/1", "dummy/2")
+	c.Assert(op.Status(), gc.Equals, state.ActionRunning)
+
+	s.finish(c, actions[2], state.ActionCompleted)
+	err = op.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.Status(), gc.Equals, state.ActionFailed)
+	c.Assert(op.Message(), gc.Equals, "action failed on dummy/1")
+	c.Assert(op.Completed().IsZero(), jc.IsFalse)
+}
+
+func (s *OperationSuite) TestEnqueueOperationCompletes(c *gc.C) {
+	op, err := s.Model.EnqueueOperation(state.OperationArgs{
+		ActionName: "snapshot",
+		Units:      s.unitNames(),
+		BatchSize:  5,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	actions := s.assertOperationActions(c, op, "dummy/0", "dummy/1", "dummy/2")
+	for _, a := range actions {
+		c.Assert(a.Operation(), gc.Equals, op.Id())
+		s.finish(c, a, state.ActionCompleted)
+	}
+	err = op.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.Status(), gc.Equals, state.ActionCompleted)
+	c.Assert(op.Message(), gc.Equals, "")
+}
+
+func (s *OperationSuite) TestEnqueueOperationStopOnFailure(c *gc.C) {
+	op, err := s.Model.EnqueueOperation(state.OperationArgs{
+		ActionName:    "snapshot",
+		Units:         s.unitNames(),
+		BatchSize:     1,
+		StopOnFailure: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	actions := s.assertOperationActions(c, op, "dummy/0")
+
+	_, err = s.units[0].CancelAction(actions[0])
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertOperationActions(c, op, "dummy/0")
+	c.Assert(op.Status(), gc.Equals, state.ActionFailed)
+	c.Assert(op.Message(), gc.Equals, "stopped after action failed on dummy/0")
+}
+
+func (s *OperationSuite) TestEnqueueOperationBadUnit(c *gc.C) {
+	op, err := s.Model.EnqueueOperation(state.OperationArgs{
+		ActionName: "snapshot",
+		Units:      []string{"dummy/0", "dummy/42"},
+		BatchSize:  1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	actions := s.assertOperationActions(c, op, "dummy/0")
+
+	s.finish(c, actions[0], state.ActionCompleted)
+	s.assertOperationActions(c, op, "dummy/0")
+	c.Assert(op.Status(), gc.Equals, state.ActionFailed)
+	c.Assert(op.Message(), gc.Equals, `cannot run action on dummy/42: unit "dummy/42" not found`)
+}
+
+func (s *OperationSuite) TestExpireActionsAdvancesOperation(c *gc.C) {
+	op, err := s.Model.EnqueueOperation(state.OperationArgs{
+		ActionName: "snapshot",
+		Units:      s.unitNames(),
+		BatchSize:  1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	actions := s.assertOperationActions(c, op, "dummy/0")
+
+	// Finish the action without advancing the operation, as happens
+	// when advancing it fails.
+	coll, closer := state.GetRawCollection(s.State, "actions")
+	defer closer()
+	err = coll.UpdateId(state.DocID(s.State, actions[0].Id()), bson.D{{"$set", bson.D{{"status", state.ActionCompleted}}}})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertOperationActions(c, op, "dummy/0")
+
+	err = state.ExpireActions(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertOperationActions(c, op, "dummy/0", "dummy/1")
+}
+
+func (s *OperationSuite) TestOperationNotFound(c *gc.C) {
+	_, err := s.Model.Operation("42")
+	c.Assert(err, gc.ErrorMatches, `operation "42" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *OperationSuite) finish(c *gc.C, a state.Action, status state.ActionStatus) {
+	a, err := a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = a.Finish(state.ActionResults{Status: status})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+// assertOperationActions refreshes the operation and checks that it has
+// queued actions for exactly the named units, in order.
+func (s *OperationSuite) assertOperationActions(c *gc.C, op *state.Operation, units ...string) []state.Action {
+	err := op.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	actions, err := op.Actions()
+	c.Assert(err, jc.ErrorIsNil)
+	receivers := make([]string, len(actions))
+	for i, a := range actions {
+		receivers[i] = a.Receiver()
+	}
+	c.Assert(receivers, jc.DeepEquals, units)
+	return actions
+}
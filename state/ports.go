@@ -37,6 +37,12 @@ type PortRange struct {
 	FromPort int
 	ToPort   int
 	Protocol string
+
+	// Endpoint is the name of the application endpoint the range was
+	// opened for. Access to the range is limited to the sources that
+	// endpoint is exposed to. An empty endpoint opens the range for
+	// all of the application's endpoints.
+	Endpoint string `bson:"endpoint,omitempty"`
 }
 
 // NewPortRange create a new port range and validate it.
@@ -121,8 +127,9 @@ func (prA PortRange) CheckConflicts(prB PortRange) error {
 
 	// An exact port range match (including the associated unit name) is not
 	// considered a conflict due to the fact that many charms issue commands
-	// to open the same port multiple times.
-	if prA == prB {
+	// to open the same port multiple times. The endpoint is ignored, as
+	// opening the range for another endpoint replaces the existing one.
+	if prA.withoutEndpoint() == prB.withoutEndpoint() {
 		return nil
 	}
 	if prA.Protocol != prB.Protocol {
@@ -134,13 +141,24 @@ func (prA PortRange) CheckConflicts(prB PortRange) error {
 	return nil
 }
 
+// withoutEndpoint returns a copy of the port range that is not
+// associated with any endpoint.
+func (p PortRange) withoutEndpoint() PortRange {
+	p.Endpoint = ""
+	return p
+}
+
 // Strings returns the port range as a string.
 func (p PortRange) String() string {
 	proto := strings.ToLower(p.Protocol)
+	owner := fmt.Sprintf("%q", p.UnitName)
+	if p.Endpoint != "" {
+		owner += fmt.Sprintf(", endpoint %q", p.Endpoint)
+	}
 	if proto == "icmp" {
-		return fmt.Sprintf("%s (%q)", proto, p.UnitName)
+		return fmt.Sprintf("%s (%s)", proto, owner)
 	}
-	return fmt.Sprintf("%d-%d/%s (%q)", p.FromPort, p.ToPort, proto, p.UnitName)
+	return fmt.Sprintf("%d-%d/%s (%s)", p.FromPort, p.ToPort, proto, owner)
 }
 
 // portsDoc represents the state of ports opened on machines for networks
@@ -187,7 +205,8 @@ func (p *Ports) SubnetID() string {
 }
 
 // OpenPorts adds the specified port range to the list of ports
-// maintained by this document.
+// maintained by this document. Opening a range already opened by
+// the same unit for another endpoint replaces that endpoint.
 func (p *Ports) OpenPorts(portRange PortRange) (err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot open ports %s", portRange)
 
@@ -218,7 +237,7 @@ func (p *Ports) OpenPorts(portRange PortRange) (err error) {
 		}
 
 		// Check for conflicts with existing ports.
-		for _, existingPorts := range p.doc.Ports {
+		for i, existingPorts := range ports.doc.Ports {
 			if err := existingPorts.CheckConflicts(portRange); err != nil {
 				return nil, errors.Trace(err)
 			} else if existingPorts == portRange {
@@ -227,6 +246,16 @@ func (p *Ports) OpenPorts(portRange PortRange) (err error) {
 				// and hence its txn-revno and trigger unnecessary
 				// watcher notifications.
 				return nil, statetxn.ErrNoOperations
+			} else if existingPorts.withoutEndpoint() == portRange.withoutEndpoint() {
+				// The same range opened by the same unit for another
+				// endpoint is replaced.
+				newPorts := append([]PortRange(nil), ports.doc.Ports...)
+				newPorts[i] = portRange
+				assert := bson.D{{"txn-revno", ports.doc.TxnRevno}}
+				return append(
+					[]txn.Op{assertModelActiveOp(p.st.ModelUUID())},
+					setPortsDocOps(p.st, ports.doc, assert, newPorts...)...,
+				), nil
 			}
 		}
 
@@ -250,6 +279,12 @@ func (p *Ports) OpenPorts(portRange PortRange) (err error) {
 	}
 	// Mark object as created.
 	p.areNew = false
+	for i, existingPorts := range p.doc.Ports {
+		if existingPorts.withoutEndpoint() == portRange.withoutEndpoint() {
+			p.doc.Ports[i] = portRange
+			return nil
+		}
+	}
 	p.doc.Ports = append(p.doc.Ports, portRange)
 	return nil
 }
@@ -269,7 +304,8 @@ func (p *Ports) verifySubnetAliveWhenSet() error {
 }
 
 // ClosePorts removes the specified port range from the list of ports
-// maintained by this document.
+// maintained by this document, regardless of the endpoint it was
+// opened for.
 func (p *Ports) ClosePorts(portRange PortRange) (err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot close ports %s", portRange)
 
@@ -295,7 +331,7 @@ func (p *Ports) ClosePorts(portRange PortRange) (err error) {
 
 		found := false
 		for _, existingPortsDef := range ports.doc.Ports {
-			if existingPortsDef == portRange {
+			if existingPortsDef.withoutEndpoint() == portRange.withoutEndpoint() {
 				found = true
 				continue
 			}
@@ -364,6 +400,22 @@ func (p *Ports) AllPortRanges() map[network.PortRange]string {
 	return result
 }
 
+// AllPortRangeEndpoints returns a map with network.PortRange as keys and
+// the names of the endpoints the ranges were opened for as values. Ranges
+// opened for all endpoints map to the empty string.
+func (p *Ports) AllPortRangeEndpoints() map[network.PortRange]string {
+	result := make(map[network.PortRange]string)
+	for _, portRange := range p.doc.Ports {
+		rawRange := network.PortRange{
+			FromPort: portRange.FromPort,
+			ToPort:   portRange.ToPort,
+			Protocol: portRange.Protocol,
+		}
+		result[rawRange] = portRange.Endpoint
+	}
+	return result
+}
+
 // Remove removes the ports document from state.
 func (p *Ports) Remove() error {
 	ports := &Ports{st: p.st, doc: p.doc}
@@ -508,17 +560,10 @@ func removePortsForUnitOps(st *State, unit *Unit) ([]txn.Op, error) {
 	}
 	var ops []txn.Op
 	for _, ports := range allPorts {
-		allRanges := ports.AllPortRanges()
 		var keepPorts []PortRange
-		for portRange, unitName := range allRanges {
-			if unitName != unit.Name() {
-				unitRange := PortRange{
-					UnitName: unitName,
-					FromPort: portRange.FromPort,
-					ToPort:   portRange.ToPort,
-					Protocol: portRange.Protocol,
-				}
-				keepPorts = append(keepPorts, unitRange)
+		for _, portRange := range ports.doc.Ports {
+			if portRange.UnitName != unit.Name() {
+				keepPorts = append(keepPorts, portRange)
 			}
 		}
 		if len(keepPorts) > 0 {
@@ -313,6 +313,48 @@ func (s *PortsDocSuite) TestAllPortRanges(c *gc.C) {
 	c.Assert(ranges[network.PortRange{100, 200, "TCP"}], gc.Equals, s.unit1.Name())
 }
 
+func (s *PortsDocSuite) TestOpenAndClosePortsForEndpoint(c *gc.C) {
+	portRange := state.PortRange{
+		FromPort: 100,
+		ToPort:   200,
+		UnitName: s.unit1.Name(),
+		Protocol: "tcp",
+		Endpoint: "url",
+	}
+	err := s.portsWithoutSubnet.OpenPorts(portRange)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.portsWithoutSubnet.AllPortRangeEndpoints(), jc.DeepEquals, map[network.PortRange]string{
+		{100, 200, "tcp"}: "url",
+	})
+
+	// Opening the same range for all endpoints replaces the endpoint.
+	portRange.Endpoint = ""
+	err = s.portsWithoutSubnet.OpenPorts(portRange)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.portsWithoutSubnet.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.portsWithoutSubnet.AllPortRangeEndpoints(), jc.DeepEquals, map[network.PortRange]string{
+		{100, 200, "tcp"}: "",
+	})
+
+	// Another unit cannot open the range for any endpoint.
+	otherRange := portRange
+	otherRange.UnitName = s.unit2.Name()
+	otherRange.Endpoint = "url"
+	err = s.portsWithoutSubnet.OpenPorts(otherRange)
+	c.Assert(err, gc.ErrorMatches, `cannot open ports 100-200/tcp \("wordpress/1", endpoint "url"\): port ranges .* conflict`)
+
+	// Closing the range ignores the endpoint.
+	portRange.Endpoint = "url"
+	err = s.portsWithoutSubnet.OpenPorts(portRange)
+	c.Assert(err, jc.ErrorIsNil)
+	portRange.Endpoint = ""
+	err = s.portsWithoutSubnet.ClosePorts(portRange)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = state.GetPorts(s.State, s.machine.Id(), "")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *PortsDocSuite) TestICMP(c *gc.C) {
 	portRange := state.PortRange{
 		FromPort: -1,
@@ -503,6 +545,11 @@ func (p *PortRangeSuite) TestPortRangeConflicts(c *gc.C) {
 		MustPortRange("wordpress/0", 80, 100, "TCP"),
 		MustPortRange("wordpress/0", 80, 100, "TCP"),
 		nil,
+	}, {
+		"identical port ranges for different endpoints",
+		state.PortRange{UnitName: "wordpress/0", FromPort: 80, ToPort: 100, Protocol: "tcp", Endpoint: "url"},
+		MustPortRange("wordpress/0", 80, 100, "TCP"),
+		nil,
 	}, {
 		"different ports",
 		MustPortRange("wordpress/0", 80, 80, "TCP"),
@@ -555,7 +602,7 @@ func (p *PortRangeSuite) TestPortRangeConflicts(c *gc.C) {
 		"port ranges .* conflict",
 	}, {
 		"invalid port range",
-		state.PortRange{"wordpress/0", 100, 80, "TCP"},
+		state.PortRange{UnitName: "wordpress/0", FromPort: 100, ToPort: 80, Protocol: "TCP"},
 		MustPortRange("wordpress/0", 80, 80, "TCP"),
 		"invalid port range 100-80",
 	}, {
@@ -611,18 +658,22 @@ func swapProtocol(protocol string) string {
 }
 
 func (p *PortRangeSuite) TestPortRangeString(c *gc.C) {
-	c.Assert(state.PortRange{"wordpress/42", 80, 80, "TCP"}.String(),
+	c.Assert(state.PortRange{UnitName: "wordpress/42", FromPort: 80, ToPort: 80, Protocol: "TCP"}.String(),
 		gc.Equals,
 		`80-80/tcp ("wordpress/42")`,
 	)
-	c.Assert(state.PortRange{"wordpress/0", 80, 100, "TCP"}.String(),
+	c.Assert(state.PortRange{UnitName: "wordpress/0", FromPort: 80, ToPort: 100, Protocol: "TCP"}.String(),
 		gc.Equals,
 		`80-100/tcp ("wordpress/0")`,
 	)
-	c.Assert(state.PortRange{"wordpress/0", -1, -1, "ICMP"}.String(),
+	c.Assert(state.PortRange{UnitName: "wordpress/0", FromPort: -1, ToPort: -1, Protocol: "ICMP"}.String(),
 		gc.Equals,
 		`icmp ("wordpress/0")`,
 	)
+	c.Assert(state.PortRange{UnitName: "wordpress/0", FromPort: 80, ToPort: 100, Protocol: "TCP", Endpoint: "url"}.String(),
+		gc.Equals,
+		`80-100/tcp ("wordpress/0", endpoint "url")`,
+	)
 }
 
 func (p *PortRangeSuite) TestPortRangeValidityAndLength(c *gc.C) {
@@ -633,67 +684,67 @@ func (p *PortRangeSuite) TestPortRangeValidityAndLength(c *gc.C) {
 		expectedErr  string
 	}{{
 		"single valid port",
-		state.PortRange{"wordpress/0", 80, 80, "tcp"},
+		state.PortRange{UnitName: "wordpress/0", FromPort: 80, ToPort: 80, Protocol: "tcp"},
 		1,
 		"",
 	}, {
 		"valid tcp port range",
-		state.PortRange{"wordpress/0", 80, 90, "tcp"},
+		state.PortRange{UnitName: "wordpress/0", FromPort: 80, ToPort: 90, Protocol: "tcp"},
 		11,
 		"",
 	}, {
 		"valid udp port range",
-		state.PortRange{"wordpress/0", 80, 90, "UDP"},
+		state.PortRange{UnitName: "wordpress/0", FromPort: 80, ToPort: 90, Protocol: "UDP"},
 		11,
 		"",
 	}, {
 		"invalid port range boundaries",
-		state.PortRange{"wordpress/0", 90, 80, "tcp"},
+		state.PortRange{UnitName: "wordpress/0", FromPort: 90, ToPort: 80, Protocol: "tcp"},
 		0,
 		"invalid port range.*",
 	}, {
 		"invalid protocol",
-		state.PortRange{"wordpress/0", 80, 80, "some protocol"},
+		state.PortRange{UnitName: "wordpress/0", FromPort: 80, ToPort: 80, Protocol: "some protocol"},
 		0,
 		"invalid protocol.*",
 	}, {
 		"invalid unit",
-		state.PortRange{"invalid unit", 80, 80, "tcp"},
+		state.PortRange{UnitName: "invalid unit", FromPort: 80, ToPort: 80, Protocol: "tcp"},
 		0,
 		"invalid unit.*",
 	}, {
 		"negative lower bound",
-		state.PortRange{"wordpress/0", -10, 10, "tcp"},
+		state.PortRange{UnitName: "wordpress/0", FromPort: -10, ToPort: 10, Protocol: "tcp"},
 		0,
 		"port range bounds must be between 1 and 65535.*",
 	}, {
 		"zero lower bound",
-		state.PortRange{"wordpress/0", 0, 10, "tcp"},
+		state.PortRange{UnitName: "wordpress/0", FromPort: 0, ToPort: 10, Protocol: "tcp"},
 		0,
 		"port range bounds must be between 1 and 65535.*",
 	}, {
 		"negative upper bound",
-		state.PortRange{"wordpress/0", 10, -10, "tcp"},
+		state.PortRange{UnitName: "wordpress/0", FromPort: 10, ToPort: -10, Protocol: "tcp"},
 		0,
 		"invalid port range.*",
 	}, {
 		"zero upper bound",
-		state.PortRange{"wordpress/0", 10, 0, "tcp"},
+		state.PortRange{UnitName: "wordpress/0", FromPort: 10, ToPort: 0, Protocol: "tcp"},
 		0,
 		"invalid port range.*",
 	}, {
 		"too large lower bound",
-		state.PortRange{"wordpress/0", 65540, 99999, "tcp"},
+		state.PortRange{UnitName: "wordpress/0", FromPort: 65540, ToPort: 99999, Protocol: "tcp"},
 		0,
 		"port range bounds must be between 1 and 65535.*",
 	}, {
 		"too large upper bound",
-		state.PortRange{"wordpress/0", 10, 99999, "tcp"},
+		state.PortRange{UnitName: "wordpress/0", FromPort: 10, ToPort: 99999, Protocol: "tcp"},
 		0,
 		"port range bounds must be between 1 and 65535.*",
 	}, {
 		"longest valid range",
-		state.PortRange{"wordpress/0", 1, 65535, "tcp"},
+		state.PortRange{UnitName: "wordpress/0", FromPort: 1, ToPort: 65535, Protocol: "tcp"},
 		65535,
 		"",
 	}}
@@ -716,56 +767,56 @@ func (p *PortRangeSuite) TestSanitizeBounds(c *gc.C) {
 		output state.PortRange
 	}{{
 		"valid range",
-		state.PortRange{"", 100, 200, ""},
-		state.PortRange{"", 100, 200, ""},
+		state.PortRange{UnitName: "", FromPort: 100, ToPort: 200, Protocol: ""},
+		state.PortRange{UnitName: "", FromPort: 100, ToPort: 200, Protocol: ""},
 	}, {
 		"negative lower bound",
-		state.PortRange{"", -10, 10, ""},
-		state.PortRange{"", 1, 10, ""},
+		state.PortRange{UnitName: "", FromPort: -10, ToPort: 10, Protocol: ""},
+		state.PortRange{UnitName: "", FromPort: 1, ToPort: 10, Protocol: ""},
 	}, {
 		"zero lower bound",
-		state.PortRange{"", 0, 10, ""},
-		state.PortRange{"", 1, 10, ""},
+		state.PortRange{UnitName: "", FromPort: 0, ToPort: 10, Protocol: ""},
+		state.PortRange{UnitName: "", FromPort: 1, ToPort: 10, Protocol: ""},
 	}, {
 		"negative upper bound",
-		state.PortRange{"", 42, -20, ""},
-		state.PortRange{"", 1, 42, ""},
+		state.PortRange{UnitName: "", FromPort: 42, ToPort: -20, Protocol: ""},
+		state.PortRange{UnitName: "", FromPort: 1, ToPort: 42, Protocol: ""},
 	}, {
 		"zero upper bound",
-		state.PortRange{"", 42, 0, ""},
-		state.PortRange{"", 1, 42, ""},
+		state.PortRange{UnitName: "", FromPort: 42, ToPort: 0, Protocol: ""},
+		state.PortRange{UnitName: "", FromPort: 1, ToPort: 42, Protocol: ""},
 	}, {
 		"both bounds negative",
-		state.PortRange{"", -10, -20, ""},
-		state.PortRange{"", 1, 1, ""},
+		state.PortRange{UnitName: "", FromPort: -10, ToPort: -20, Protocol: ""},
+		state.PortRange{UnitName: "", FromPort: 1, ToPort: 1, Protocol: ""},
 	}, {
 		"both bounds zero",
-		state.PortRange{"", 0, 0, ""},
-		state.PortRange{"", 1, 1, ""},
+		state.PortRange{UnitName: "", FromPort: 0, ToPort: 0, Protocol: ""},
+		state.PortRange{UnitName: "", FromPort: 1, ToPort: 1, Protocol: ""},
 	}, {
 		"swapped bounds",
-		state.PortRange{"", 20, 10, ""},
-		state.PortRange{"", 10, 20, ""},
+		state.PortRange{UnitName: "", FromPort: 20, ToPort: 10, Protocol: ""},
+		state.PortRange{UnitName: "", FromPort: 10, ToPort: 20, Protocol: ""},
 	}, {
 		"too large upper bound",
-		state.PortRange{"", 20, 99999, ""},
-		state.PortRange{"", 20, 65535, ""},
+		state.PortRange{UnitName: "", FromPort: 20, ToPort: 99999, Protocol: ""},
+		state.PortRange{UnitName: "", FromPort: 20, ToPort: 65535, Protocol: ""},
 	}, {
 		"too large lower bound",
-		state.PortRange{"", 99999, 10, ""},
-		state.PortRange{"", 10, 65535, ""},
+		state.PortRange{UnitName: "", FromPort: 99999, ToPort: 10, Protocol: ""},
+		state.PortRange{UnitName: "", FromPort: 10, ToPort: 65535, Protocol: ""},
 	}, {
 		"both bounds too large",
-		state.PortRange{"", 88888, 99999, ""},
-		state.PortRange{"", 65535, 65535, ""},
+		state.PortRange{UnitName: "", FromPort: 88888, ToPort: 99999, Protocol: ""},
+		state.PortRange{UnitName: "", FromPort: 65535, ToPort: 65535, Protocol: ""},
 	}, {
 		"lower negative, upper too large",
-		state.PortRange{"", -10, 99999, ""},
-		state.PortRange{"", 1, 65535, ""},
+		state.PortRange{UnitName: "", FromPort: -10, ToPort: 99999, Protocol: ""},
+		state.PortRange{UnitName: "", FromPort: 1, ToPort: 65535, Protocol: ""},
 	}, {
 		"lower zero, upper too large",
-		state.PortRange{"", 0, 99999, ""},
-		state.PortRange{"", 1, 65535, ""},
+		state.PortRange{UnitName: "", FromPort: 0, ToPort: 99999, Protocol: ""},
+		state.PortRange{UnitName: "", FromPort: 1, ToPort: 65535, Protocol: ""},
 	}}
 	for i, t := range tests {
 		c.Logf("test %d: %s", i, t.about)
@@ -49,6 +49,10 @@ type Resources interface {
 	// OpenResourceForUniter returns the metadata for a resource and a reader for the resource.
 	OpenResourceForUniter(unit resource.Unit, name string) (resource.Resource, io.ReadCloser, error)
 
+	// OpenPendingResource returns the metadata for a pending resource
+	// and a reader for the resource.
+	OpenPendingResource(applicationID, name, pendingID string) (resource.Resource, io.ReadCloser, error)
+
 	// SetCharmStoreResources sets the "polled" resources for the
 	// application to the provided values.
 	SetCharmStoreResources(applicationID string, info []charmresource.Resource, lastPolled time.Time) error
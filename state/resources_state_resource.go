@@ -365,6 +365,30 @@ func (st resourceState) OpenResource(applicationID, name string) (resource.Resou
 	return resourceInfo, resourceReader, nil
 }
 
+// OpenPendingResource returns metadata about the pending resource, and
+// a reader for the resource. Only file resources can be opened before
+// they are resolved.
+func (st resourceState) OpenPendingResource(applicationID, name, pendingID string) (resource.Resource, io.ReadCloser, error) {
+	resourceInfo, err := st.GetPendingResource(applicationID, name, pendingID)
+	if err != nil {
+		return resource.Resource{}, nil, errors.Trace(err)
+	}
+	if resourceInfo.Type != charmresource.TypeFile {
+		return resource.Resource{}, nil, errors.NotSupportedf("opening pending %s resource %q", resourceInfo.Type, name)
+	}
+
+	resourceReader, resSize, err := st.storage.Get(storagePath(name, applicationID, pendingID))
+	if err != nil {
+		return resource.Resource{}, nil, errors.Annotate(err, "while retrieving resource data")
+	}
+	if resSize != resourceInfo.Size {
+		resourceReader.Close()
+		msg := "storage returned a size (%d) which doesn't match resource metadata (%d)"
+		return resource.Resource{}, nil, errors.Errorf(msg, resSize, resourceInfo.Size)
+	}
+	return resourceInfo, resourceReader, nil
+}
+
 // OpenResourceForUniter returns metadata about the resource and
 // a reader for the resource. The resource is associated with
 // the unit once the reader is completely exhausted.
@@ -13,6 +13,7 @@ import (
 
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 )
 
 type SubnetSuite struct {
@@ -390,3 +391,19 @@ func (s *SubnetSuite) TestUpdateNonEmpty(c *gc.C) {
 	c.Assert(subnet.VLANTag(), gc.Equals, expectedSubnetInfo.VLANTag)
 	c.Assert(subnet.AvailabilityZones(), gc.DeepEquals, expectedSubnetInfo.AvailabilityZones)
 }
+
+func (s *SubnetSuite) TestWatchSubnetsAndSpaces(c *gc.C) {
+	w := s.State.WatchSubnetsAndSpaces()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	subnet, err := s.State.AddSubnet(network.SubnetInfo{CIDR: "10.0.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Adding a space moves the subnet into it.
+	_, err = s.State.AddSpace("dmz", "", []string{subnet.ID()}, false)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
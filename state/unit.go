@@ -1412,12 +1412,30 @@ func (u *Unit) SetStatus(unitStatus status.StatusInfo) error {
 // opening the requested range conflicts with another already opened range on
 // the same subnet and and the unit's assigned machine.
 func (u *Unit) OpenPortsOnSubnet(subnetID, protocol string, fromPort, toPort int) (err error) {
+	return u.openPorts(subnetID, "", protocol, fromPort, toPort)
+}
+
+// OpenPortsForEndpoint opens the given port range and protocol for the unit,
+// limiting access to the range to the sources the given endpoint of the
+// unit's application is exposed to. The empty endpoint opens the range for
+// all of the application's endpoints. Opening a range already opened by the
+// unit for another endpoint replaces that endpoint.
+func (u *Unit) OpenPortsForEndpoint(endpoint, protocol string, fromPort, toPort int) error {
+	return u.openPorts("", endpoint, protocol, fromPort, toPort)
+}
+
+func (u *Unit) openPorts(subnetID, endpoint, protocol string, fromPort, toPort int) (err error) {
 	ports, err := NewPortRange(u.Name(), fromPort, toPort, protocol)
 	if err != nil {
 		return errors.Annotatef(err, "invalid port range %v-%v/%v", fromPort, toPort, protocol)
 	}
+	ports.Endpoint = endpoint
 	defer errors.DeferredAnnotatef(&err, "cannot open ports %v for unit %q on subnet %q", ports, u, subnetID)
 
+	if err := u.checkEndpointWhenSet(endpoint); err != nil {
+		return errors.Trace(err)
+	}
+
 	machineID, err := u.AssignedMachineId()
 	if err != nil {
 		return errors.Annotatef(err, "unit %q has no assigned machine", u)
@@ -1435,6 +1453,26 @@ func (u *Unit) OpenPortsOnSubnet(subnetID, protocol string, fromPort, toPort int
 	return machinePorts.OpenPorts(ports)
 }
 
+func (u *Unit) checkEndpointWhenSet(endpoint string) error {
+	if endpoint == "" {
+		return nil
+	}
+	app, err := u.Application()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	eps, err := app.Endpoints()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, ep := range eps {
+		if ep.Name == endpoint {
+			return nil
+		}
+	}
+	return errors.NotValidf("endpoint %q", endpoint)
+}
+
 func (u *Unit) checkSubnetAliveWhenSet(subnetID string) error {
 	if subnetID == "" {
 		return nil
@@ -1571,6 +1609,31 @@ func (u *Unit) OpenedPorts() ([]corenetwork.PortRange, error) {
 	return u.OpenedPortsOnSubnet("")
 }
 
+// OpenedPortEndpoints returns the endpoints the unit's open port ranges
+// were opened for, keyed by port range. Ranges opened for all of the
+// application's endpoints map to the empty string.
+func (u *Unit) OpenedPortEndpoints() (map[corenetwork.PortRange]string, error) {
+	machineID, err := u.AssignedMachineId()
+	if err != nil {
+		return nil, errors.Annotatef(err, "unit %q has no assigned machine", u)
+	}
+	result := make(map[corenetwork.PortRange]string)
+	machinePorts, err := getPorts(u.st, machineID, "")
+	if errors.IsNotFound(err) {
+		return result, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "failed getting ports for unit %q", u)
+	}
+	for _, port := range machinePorts.PortsForUnit(u.Name()) {
+		result[corenetwork.PortRange{
+			Protocol: port.Protocol,
+			FromPort: port.FromPort,
+			ToPort:   port.ToPort,
+		}] = port.Endpoint
+	}
+	return result, nil
+}
+
 // CharmURL returns the charm URL this unit is currently using.
 func (u *Unit) CharmURL() (*charm.URL, bool) {
 	if u.doc.CharmURL == nil {
@@ -1579,6 +1642,61 @@ func (u *Unit) CharmURL() (*charm.URL, bool) {
 	return u.doc.CharmURL, true
 }
 
+// BranchCharmURL returns the URL of the charm that the unit's application
+// has been upgraded to in the branch that the unit is tracking.
+// If the unit is not tracking a branch, or its application has not been
+// upgraded in the branch, nil is returned.
+func (u *Unit) BranchCharmURL() (*charm.URL, error) {
+	upgrade, err := u.branchCharmUpgrade()
+	if err != nil || upgrade == nil {
+		return nil, errors.Trace(err)
+	}
+	curl, err := charm.ParseURL(upgrade.CharmURL)
+	return curl, errors.Trace(err)
+}
+
+// BranchResourceIDs returns the IDs of the pending resources staged for
+// the unit's application in the branch that the unit is tracking, keyed
+// by resource name.
+func (u *Unit) BranchResourceIDs() (map[string]string, error) {
+	upgrade, err := u.branchCharmUpgrade()
+	if err != nil || upgrade == nil {
+		return nil, errors.Trace(err)
+	}
+	return upgrade.ResourceIDs, nil
+}
+
+// BranchCharmModifiedVersion returns the number of times that a charm
+// upgrade, or its resources, have been staged for the unit's application
+// in the branch that the unit is tracking. If the unit is not tracking
+// a branch, or its application has not been upgraded in the branch,
+// zero is returned.
+func (u *Unit) BranchCharmModifiedVersion() (int, error) {
+	upgrade, err := u.branchCharmUpgrade()
+	if err != nil || upgrade == nil {
+		return 0, errors.Trace(err)
+	}
+	return upgrade.ModifiedVersion, nil
+}
+
+// branchCharmUpgrade returns the charm upgrade staged for the unit's
+// application in the branch that the unit is tracking, if any.
+func (u *Unit) branchCharmUpgrade() (*charmUpgradeDoc, error) {
+	m, err := u.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	branch, err := m.unitBranch(u.Name())
+	if err != nil || branch == nil {
+		return nil, errors.Trace(err)
+	}
+	upgrade, ok := branch.doc.CharmUpgrades[u.doc.Application]
+	if !ok {
+		return nil, nil
+	}
+	return &upgrade, nil
+}
+
 // SetCharmURL marks the unit as currently using the supplied charm URL.
 // An error will be returned if the unit is dead, or the charm URL not known.
 func (u *Unit) SetCharmURL(curl *charm.URL) error {
@@ -2906,6 +3024,26 @@ type ActionSpecsByName map[string]charm.ActionSpec
 // this Unit, and returns its ID.  Note that the use of spec.InsertDefaults
 // mutates payload.
 func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, error) {
+	return u.AddActionWithTimeouts(name, payload, ActionTimeouts{})
+}
+
+// AddActionWithTimeouts is part of the ActionReceiver interface.
+func (u *Unit) AddActionWithTimeouts(name string, payload map[string]interface{}, timeouts ActionTimeouts) (Action, error) {
+	payloadWithDefaults, err := u.actionPayload(name, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := u.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return m.EnqueueActionWithTimeouts(u.Tag(), name, payloadWithDefaults, timeouts)
+}
+
+// actionPayload validates the payload of the named action against its
+// spec, and returns the payload with any defaults inserted.
+func (u *Unit) actionPayload(name string, payload map[string]interface{}) (map[string]interface{}, error) {
 	if len(name) == 0 {
 		return nil, errors.New("no action name given")
 	}
@@ -2927,16 +3065,7 @@ func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, e
 	if err != nil {
 		return nil, err
 	}
-	payloadWithDefaults, err := spec.InsertDefaults(payload)
-	if err != nil {
-		return nil, err
-	}
-
-	m, err := u.st.Model()
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-	return m.EnqueueAction(u.Tag(), name, payloadWithDefaults)
+	return spec.InsertDefaults(payload)
 }
 
 // ActionSpecs gets the ActionSpec map for the Unit's charm.
@@ -1525,6 +1525,28 @@ func (s *UnitSuite) testOpenedPorts(c *gc.C, subnetID, expectedErrorCauseMatches
 	}
 }
 
+func (s *UnitSuite) TestOpenPortsForEndpoint(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.OpenPortsForEndpoint("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.OpenPortsForEndpoint("", "tcp", 8080, 8090)
+	c.Assert(err, jc.ErrorIsNil)
+	endpoints, err := s.unit.OpenedPortEndpoints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(endpoints, jc.DeepEquals, map[corenetwork.PortRange]string{
+		{80, 80, "tcp"}:     "url",
+		{8080, 8090, "tcp"}: "",
+	})
+
+	err = s.unit.OpenPortsForEndpoint("bogus", "tcp", 443, 443)
+	c.Assert(err, gc.ErrorMatches, `cannot open ports 443-443/tcp \("wordpress/0", endpoint "bogus"\) for unit "wordpress/0" on subnet "": endpoint "bogus" not valid`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotValid)
+}
+
 func (s *UnitSuite) TestOpenClosePortWhenDying(c *gc.C) {
 	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
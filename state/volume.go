@@ -52,6 +52,11 @@ type Volume interface {
 	// Releasing reports whether or not the volume is to be released
 	// from the model when it is Dying/Dead.
 	Releasing() bool
+
+	// RequestedSize returns the size in MiB the volume has been
+	// requested to be expanded to. RequestedSize returns true if
+	// the expansion is yet to be performed, otherwise false.
+	RequestedSize() (uint64, bool)
 }
 
 // VolumeAttachment describes an attachment of a volume to a machine.
@@ -127,6 +132,11 @@ type volumeDoc struct {
 	Info            *VolumeInfo   `bson:"info,omitempty"`
 	Params          *VolumeParams `bson:"params,omitempty"`
 
+	// RequestedSize is the size in MiB a provisioned volume is
+	// to be expanded to by its storage provisioner. It is unset
+	// once the volume's info records the expanded size.
+	RequestedSize uint64 `bson:"requestedsize,omitempty"`
+
 	// HostId is the ID of the host that a non-detachable
 	// volume is initially attached to. We use this to identify
 	// the volume as being non-detachable, and to determine
@@ -278,6 +288,14 @@ func (v *volume) Releasing() bool {
 	return v.doc.Releasing
 }
 
+// RequestedSize is required to implement Volume.
+func (v *volume) RequestedSize() (uint64, bool) {
+	if v.doc.RequestedSize == 0 {
+		return 0, false
+	}
+	return v.doc.RequestedSize, true
+}
+
 // Status is required to implement StatusGetter.
 func (v *volume) Status() (status.StatusInfo, error) {
 	return getStatus(v.mb.db(), volumeGlobalKey(v.VolumeTag().Id()), "volume")
@@ -1440,6 +1458,16 @@ func (sb *storageBackend) SetVolumeInfo(tag names.VolumeTag, info VolumeInfo) (e
 			}
 		}
 		ops = append(ops, setVolumeInfoOps(tag, info, unsetParams)...)
+		// Once the volume has been expanded to the
+		// requested size, the request is complete.
+		if size, ok := v.RequestedSize(); ok && info.Size >= size {
+			ops = append(ops, txn.Op{
+				C:      volumesC,
+				Id:     tag.Id(),
+				Assert: bson.D{{"requestedsize", size}},
+				Update: bson.D{{"$unset", bson.D{{"requestedsize", nil}}}},
+			})
+		}
 		return ops, nil
 	}
 	return sb.mb.db().Run(buildTxn)
@@ -1479,6 +1507,66 @@ func setVolumeInfoOps(tag names.VolumeTag, info VolumeInfo, unsetParams bool) []
 	}}
 }
 
+// ResizeVolume requests that the provisioned volume with the specified
+// tag be expanded to the given size in MiB. The storage provisioner
+// responsible for the volume performs the expansion, and records the
+// new size with SetVolumeInfo.
+func (sb *storageBackend) ResizeVolume(tag names.VolumeTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize volume %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := getVolumeByTag(sb.mb, tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.Life() != Alive {
+			return nil, errors.New("volume is not alive")
+		}
+		info, err := v.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if size <= info.Size {
+			return nil, errors.NewNotValid(nil, fmt.Sprintf(
+				"new size %dMiB is not larger than the current size %dMiB", size, info.Size,
+			))
+		}
+		if requested, ok := v.RequestedSize(); ok && requested == size {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      volumesC,
+			Id:     tag.Id(),
+			Assert: append(isAliveDoc, bson.DocElem{"info", bson.D{{"$exists", true}}}),
+			Update: bson.D{{"$set", bson.D{{"requestedsize", size}}}},
+		}}, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
+// CancelVolumeResize cancels the request to expand the volume with
+// the specified tag, if there is one. The storage provisioner cancels
+// requests that the storage provider cannot satisfy.
+func (sb *storageBackend) CancelVolumeResize(tag names.VolumeTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot cancel resize of volume %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := getVolumeByTag(sb.mb, tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		size, ok := v.RequestedSize()
+		if !ok {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      volumesC,
+			Id:     tag.Id(),
+			Assert: bson.D{{"requestedsize", size}},
+			Update: bson.D{{"$unset", bson.D{{"requestedsize", nil}}}},
+		}}, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
 // AllVolumes returns all Volumes scoped to the model.
 func (sb *storageBackend) AllVolumes() ([]Volume, error) {
 	volumes, err := sb.volumes(nil)
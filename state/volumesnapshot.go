@@ -0,0 +1,371 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"regexp"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// VolumeSnapshot describes a point in time snapshot of a volume in
+// the model. Snapshots outlive the volumes they were taken of, and
+// can be restored to new storage instances.
+type VolumeSnapshot interface {
+	Lifer
+
+	// Name returns the name of the snapshot, which is unique
+	// within the model.
+	Name() string
+
+	// Volume returns the tag of the volume the snapshot was taken of.
+	Volume() names.VolumeTag
+
+	// Pool returns the name of the storage pool of the volume the
+	// snapshot was taken of.
+	Pool() string
+
+	// Info returns the snapshot's VolumeSnapshotInfo, or a
+	// NotProvisioned error if the snapshot has not yet been taken.
+	Info() (VolumeSnapshotInfo, error)
+
+	// PendingRestore returns the tag of the storage instance the
+	// snapshot has been requested to be restored to. PendingRestore
+	// returns true if the restore is yet to complete, otherwise false.
+	PendingRestore() (names.StorageTag, bool)
+}
+
+// VolumeSnapshotInfo describes information about a volume snapshot.
+type VolumeSnapshotInfo struct {
+	SnapshotId string `bson:"snapshotid"`
+	Size       uint64 `bson:"size"`
+	Ready      bool   `bson:"ready"`
+}
+
+type volumeSnapshot struct {
+	doc volumeSnapshotDoc
+}
+
+// volumeSnapshotDoc records information about a volume snapshot
+// in the model.
+type volumeSnapshotDoc struct {
+	DocID     string              `bson:"_id"`
+	Name      string              `bson:"name"`
+	ModelUUID string              `bson:"model-uuid"`
+	Life      Life                `bson:"life"`
+	Volume    string              `bson:"volumeid"`
+	Pool      string              `bson:"pool"`
+	Info      *VolumeSnapshotInfo `bson:"info,omitempty"`
+
+	// RestoreStorageId is the ID of the storage instance the
+	// snapshot is to be restored to by its storage provisioner.
+	// It is unset once the storage instance has been added.
+	RestoreStorageId string `bson:"restorestorageid,omitempty"`
+}
+
+// Name is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Name() string {
+	return s.doc.Name
+}
+
+// Volume is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Volume() names.VolumeTag {
+	return names.NewVolumeTag(s.doc.Volume)
+}
+
+// Life is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Life() Life {
+	return s.doc.Life
+}
+
+// Pool is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Pool() string {
+	return s.doc.Pool
+}
+
+// Info is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Info() (VolumeSnapshotInfo, error) {
+	if s.doc.Info == nil {
+		return VolumeSnapshotInfo{}, errors.NotProvisionedf("volume snapshot %q", s.doc.Name)
+	}
+	return *s.doc.Info, nil
+}
+
+// PendingRestore is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) PendingRestore() (names.StorageTag, bool) {
+	if s.doc.RestoreStorageId == "" {
+		return names.StorageTag{}, false
+	}
+	return names.NewStorageTag(s.doc.RestoreStorageId), true
+}
+
+// volumeSnapshotNameRE matches valid snapshot names. Snapshots are
+// named by their providers after them, so the names are restricted
+// to those that are valid as most providers' resource names.
+var volumeSnapshotNameRE = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]{0,61}[a-z0-9])?$`)
+
+// IsValidVolumeSnapshotName reports whether name is a valid volume
+// snapshot name.
+func IsValidVolumeSnapshotName(name string) bool {
+	return volumeSnapshotNameRE.MatchString(name)
+}
+
+// VolumeSnapshot returns the volume snapshot with the specified name.
+func (sb *storageBackend) VolumeSnapshot(name string) (VolumeSnapshot, error) {
+	return sb.volumeSnapshot(name)
+}
+
+func (sb *storageBackend) volumeSnapshot(name string) (*volumeSnapshot, error) {
+	coll, cleanup := sb.mb.db().GetCollection(volumeSnapshotsC)
+	defer cleanup()
+
+	var doc volumeSnapshotDoc
+	err := coll.FindId(name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("volume snapshot %q", name)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "getting volume snapshot %q", name)
+	}
+	return &volumeSnapshot{doc}, nil
+}
+
+// AllVolumeSnapshots returns all of the volume snapshots in the model.
+func (sb *storageBackend) AllVolumeSnapshots() ([]VolumeSnapshot, error) {
+	coll, cleanup := sb.mb.db().GetCollection(volumeSnapshotsC)
+	defer cleanup()
+
+	var docs []volumeSnapshotDoc
+	if err := coll.Find(nil).Sort("name").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "querying volume snapshots")
+	}
+	snapshots := make([]VolumeSnapshot, len(docs))
+	for i, doc := range docs {
+		snapshots[i] = &volumeSnapshot{doc}
+	}
+	return snapshots, nil
+}
+
+// CreateVolumeSnapshot requests that a snapshot with the given name be
+// taken of the provisioned volume with the specified tag. The storage
+// provisioner responsible for the volume takes the snapshot, and
+// records it with SetVolumeSnapshotInfo.
+func (sb *storageBackend) CreateVolumeSnapshot(tag names.VolumeTag, name string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot create snapshot %q of volume %q", name, tag.Id())
+	if !IsValidVolumeSnapshotName(name) {
+		return errors.NotValidf("snapshot name %q", name)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if _, err := sb.volumeSnapshot(name); err == nil {
+			return nil, errors.AlreadyExistsf("volume snapshot %q", name)
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		v, err := getVolumeByTag(sb.mb, tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.Life() != Alive {
+			return nil, errors.New("volume is not alive")
+		}
+		info, err := v.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      volumesC,
+			Id:     tag.Id(),
+			Assert: append(isAliveDoc, bson.DocElem{"info", bson.D{{"$exists", true}}}),
+		}, {
+			C:      volumeSnapshotsC,
+			Id:     name,
+			Assert: txn.DocMissing,
+			Insert: &volumeSnapshotDoc{
+				Name:   name,
+				Life:   Alive,
+				Volume: tag.Id(),
+				Pool:   info.Pool,
+			},
+		}}, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
+// SetVolumeSnapshotInfo records the information for the volume
+// snapshot with the specified name. The snapshot's provider ID
+// may not be changed once it has been set.
+func (sb *storageBackend) SetVolumeSnapshotInfo(name string, info VolumeSnapshotInfo) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set info for volume snapshot %q", name)
+	if info.SnapshotId == "" {
+		return errors.New("snapshot ID not set")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := sb.volumeSnapshot(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.Life() == Dead {
+			return nil, errors.New("volume snapshot is dead")
+		}
+		assert := bson.D{{"info", bson.D{{"$exists", false}}}}
+		if s.doc.Info != nil {
+			if s.doc.Info.SnapshotId != info.SnapshotId {
+				return nil, errors.Errorf("snapshot ID changed from %q to %q", s.doc.Info.SnapshotId, info.SnapshotId)
+			}
+			if *s.doc.Info == info {
+				return nil, jujutxn.ErrNoOperations
+			}
+			assert = bson.D{{"info.snapshotid", info.SnapshotId}}
+		}
+		return []txn.Op{{
+			C:      volumeSnapshotsC,
+			Id:     name,
+			Assert: append(notDeadDoc, assert...),
+			Update: bson.D{{"$set", bson.D{{"info", &info}}}},
+		}}, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
+// RestoreVolumeSnapshot requests that the volume snapshot with the
+// specified name be restored to a new storage instance with the given
+// storage name. The storage provisioner responsible for the snapshot
+// creates the volume, and records it with SetVolumeSnapshotRestored.
+// The tag of the storage instance that is to be added is returned.
+func (sb *storageBackend) RestoreVolumeSnapshot(name, storageName string) (_ names.StorageTag, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot restore volume snapshot %q", name)
+	if !storageNameRE.MatchString(storageName) {
+		return names.StorageTag{}, errors.NotValidf("storage name %q", storageName)
+	}
+	var storageId string
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := sb.volumeSnapshot(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.Life() != Alive {
+			return nil, errors.New("volume snapshot is not alive")
+		}
+		if _, err := s.Info(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if tag, ok := s.PendingRestore(); ok {
+			return nil, errors.Errorf("already being restored to %s", names.ReadableString(tag))
+		}
+		if storageId == "" {
+			if storageId, err = newStorageInstanceId(sb.mb, storageName); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		return []txn.Op{{
+			C:  volumeSnapshotsC,
+			Id: name,
+			Assert: append(isAliveDoc,
+				bson.DocElem{"info", bson.D{{"$exists", true}}},
+				bson.DocElem{"restorestorageid", bson.D{{"$exists", false}}},
+			),
+			Update: bson.D{{"$set", bson.D{{"restorestorageid", storageId}}}},
+		}}, nil
+	}
+	if err := sb.mb.db().Run(buildTxn); err != nil {
+		return names.StorageTag{}, err
+	}
+	return names.NewStorageTag(storageId), nil
+}
+
+// SetVolumeSnapshotRestored records the volume that the volume snapshot
+// with the specified name has been restored to, completing the pending
+// restore. A storage instance with a filesystem backed by the volume is
+// added to the model, detached, as with AddExistingFilesystem.
+func (sb *storageBackend) SetVolumeSnapshotRestored(name string, info VolumeInfo) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot complete restore of volume snapshot %q", name)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := sb.volumeSnapshot(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		storageTag, ok := s.PendingRestore()
+		if !ok {
+			return nil, errors.New("no restore pending")
+		}
+		storageName, err := names.StorageName(storageTag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if info.Pool == "" {
+			info.Pool = s.Pool()
+		}
+		filesystemInfo := FilesystemInfo{Pool: info.Pool, Size: info.Size}
+		if err := validateAddExistingFilesystem(sb, filesystemInfo, &info, storageName); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops, err := sb.addExistingFilesystemOps(filesystemInfo, &info, storageTag, storageName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, txn.Op{
+			C:      volumeSnapshotsC,
+			Id:     name,
+			Assert: bson.D{{"restorestorageid", storageTag.Id()}},
+			Update: bson.D{{"$unset", bson.D{{"restorestorageid", nil}}}},
+		}), nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
+// DestroyVolumeSnapshot ensures that the volume snapshot with the
+// specified name is Dying. The storage provisioner responsible for the
+// snapshot destroys it, and then removes it with RemoveVolumeSnapshot.
+// Any pending restore of the snapshot is cancelled.
+func (sb *storageBackend) DestroyVolumeSnapshot(name string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot destroy volume snapshot %q", name)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := sb.volumeSnapshot(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.Life() != Alive {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      volumeSnapshotsC,
+			Id:     name,
+			Assert: isAliveDoc,
+			Update: bson.D{
+				{"$set", bson.D{{"life", Dying}}},
+				{"$unset", bson.D{{"restorestorageid", nil}}},
+			},
+		}}, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
+// RemoveVolumeSnapshot removes the volume snapshot with the specified
+// name from state. RemoveVolumeSnapshot will fail if the snapshot is
+// still Alive.
+func (sb *storageBackend) RemoveVolumeSnapshot(name string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "removing volume snapshot %q", name)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := sb.volumeSnapshot(name)
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.Life() == Alive {
+			return nil, errors.New("volume snapshot is not dying")
+		}
+		return []txn.Op{{
+			C:      volumeSnapshotsC,
+			Id:     name,
+			Assert: bson.D{{"life", bson.D{{"$ne", Alive}}}},
+			Remove: true,
+		}}, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
@@ -0,0 +1,213 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+)
+
+type VolumeSnapshotSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&VolumeSnapshotSuite{})
+
+// addVolume adds a detached, provisioned volume to the model,
+// returning its tag.
+func (s *VolumeSnapshotSuite) addVolume(c *gc.C) names.VolumeTag {
+	storageTag, err := s.storageBackend.AddExistingFilesystem(
+		state.FilesystemInfo{Pool: "modelscoped-block", Size: 123},
+		&state.VolumeInfo{Pool: "modelscoped-block", Size: 123, VolumeId: "vol-0"},
+		"pgdata",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	volume, err := s.storageBackend.StorageInstanceVolume(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	return volume.VolumeTag()
+}
+
+func (s *VolumeSnapshotSuite) snapshot(c *gc.C, name string) state.VolumeSnapshot {
+	snapshot, err := s.storageBackend.VolumeSnapshot(name)
+	c.Assert(err, jc.ErrorIsNil)
+	return snapshot
+}
+
+func (s *VolumeSnapshotSuite) TestCreateVolumeSnapshot(c *gc.C) {
+	volumeTag := s.addVolume(c)
+	err := s.storageBackend.CreateVolumeSnapshot(volumeTag, "nightly")
+	c.Assert(err, jc.ErrorIsNil)
+
+	snapshot := s.snapshot(c, "nightly")
+	c.Assert(snapshot.Name(), gc.Equals, "nightly")
+	c.Assert(snapshot.Life(), gc.Equals, state.Alive)
+	c.Assert(snapshot.Volume(), gc.Equals, volumeTag)
+	c.Assert(snapshot.Pool(), gc.Equals, "modelscoped-block")
+	_, err = snapshot.Info()
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+	_, ok := snapshot.PendingRestore()
+	c.Assert(ok, jc.IsFalse)
+
+	snapshots, err := s.storageBackend.AllVolumeSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 1)
+	c.Assert(snapshots[0].Name(), gc.Equals, "nightly")
+
+	err = s.storageBackend.CreateVolumeSnapshot(volumeTag, "nightly")
+	c.Assert(err, gc.ErrorMatches, `cannot create snapshot "nightly" of volume "0": volume snapshot "nightly" already exists`)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *VolumeSnapshotSuite) TestCreateVolumeSnapshotInvalidName(c *gc.C) {
+	volumeTag := s.addVolume(c)
+	err := s.storageBackend.CreateVolumeSnapshot(volumeTag, "Nightly_1")
+	c.Assert(err, gc.ErrorMatches, `cannot create snapshot "Nightly_1" of volume "0": snapshot name "Nightly_1" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *VolumeSnapshotSuite) TestCreateVolumeSnapshotNotProvisioned(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+
+	err = s.storageBackend.CreateVolumeSnapshot(volumeTag, "nightly")
+	c.Assert(err, gc.ErrorMatches, `cannot create snapshot "nightly" of volume "0/0": volume "0/0" not provisioned`)
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *VolumeSnapshotSuite) TestSetVolumeSnapshotInfo(c *gc.C) {
+	volumeTag := s.addVolume(c)
+	err := s.storageBackend.CreateVolumeSnapshot(volumeTag, "nightly")
+	c.Assert(err, jc.ErrorIsNil)
+
+	info := state.VolumeSnapshotInfo{SnapshotId: "nightly", Size: 123}
+	err = s.storageBackend.SetVolumeSnapshotInfo("nightly", info)
+	c.Assert(err, jc.ErrorIsNil)
+	info.Ready = true
+	err = s.storageBackend.SetVolumeSnapshotInfo("nightly", info)
+	c.Assert(err, jc.ErrorIsNil)
+	infoOut, err := s.snapshot(c, "nightly").Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(infoOut, jc.DeepEquals, info)
+
+	info.SnapshotId = "other"
+	err = s.storageBackend.SetVolumeSnapshotInfo("nightly", info)
+	c.Assert(err, gc.ErrorMatches, `cannot set info for volume snapshot "nightly": snapshot ID changed from "nightly" to "other"`)
+}
+
+func (s *VolumeSnapshotSuite) TestRestoreVolumeSnapshot(c *gc.C) {
+	volumeTag := s.addVolume(c)
+	err := s.storageBackend.CreateVolumeSnapshot(volumeTag, "nightly")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.storageBackend.RestoreVolumeSnapshot("nightly", "pgdata")
+	c.Assert(err, gc.ErrorMatches, `cannot restore volume snapshot "nightly": volume snapshot "nightly" not provisioned`)
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+
+	err = s.storageBackend.SetVolumeSnapshotInfo("nightly", state.VolumeSnapshotInfo{
+		SnapshotId: "nightly", Size: 123, Ready: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	storageTag, err := s.storageBackend.RestoreVolumeSnapshot("nightly", "pgdata")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageTag, gc.Equals, names.NewStorageTag("pgdata/1"))
+	pending, ok := s.snapshot(c, "nightly").PendingRestore()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(pending, gc.Equals, storageTag)
+
+	_, err = s.storageBackend.RestoreVolumeSnapshot("nightly", "pgdata")
+	c.Assert(err, gc.ErrorMatches, `cannot restore volume snapshot "nightly": already being restored to pgdata/1`)
+
+	// Recording the restored volume adds the storage instance,
+	// and completes the request.
+	err = s.storageBackend.SetVolumeSnapshotRestored("nightly", state.VolumeInfo{
+		Size: 123, VolumeId: "vol-1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = s.snapshot(c, "nightly").PendingRestore()
+	c.Assert(ok, jc.IsFalse)
+
+	storageInstance, err := s.storageBackend.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageInstance.Kind(), gc.Equals, state.StorageKindFilesystem)
+	volume, err := s.storageBackend.StorageInstanceVolume(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeInfo, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeInfo, jc.DeepEquals, state.VolumeInfo{
+		Pool: "modelscoped-block", Size: 123, VolumeId: "vol-1",
+	})
+	volumeStatus, err := volume.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeStatus.Status, gc.Equals, status.Detached)
+
+	err = s.storageBackend.SetVolumeSnapshotRestored("nightly", state.VolumeInfo{
+		Size: 123, VolumeId: "vol-1",
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot complete restore of volume snapshot "nightly": no restore pending`)
+}
+
+func (s *VolumeSnapshotSuite) TestRestoreVolumeSnapshotInvalidStorageName(c *gc.C) {
+	_, err := s.storageBackend.RestoreVolumeSnapshot("nightly", "0data")
+	c.Assert(err, gc.ErrorMatches, `cannot restore volume snapshot "nightly": storage name "0data" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *VolumeSnapshotSuite) TestDestroyAndRemoveVolumeSnapshot(c *gc.C) {
+	volumeTag := s.addVolume(c)
+	err := s.storageBackend.CreateVolumeSnapshot(volumeTag, "nightly")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.SetVolumeSnapshotInfo("nightly", state.VolumeSnapshotInfo{
+		SnapshotId: "nightly", Size: 123, Ready: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.storageBackend.RestoreVolumeSnapshot("nightly", "pgdata")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.storageBackend.RemoveVolumeSnapshot("nightly")
+	c.Assert(err, gc.ErrorMatches, `removing volume snapshot "nightly": volume snapshot is not dying`)
+
+	// Destroying the snapshot cancels the pending restore.
+	err = s.storageBackend.DestroyVolumeSnapshot("nightly")
+	c.Assert(err, jc.ErrorIsNil)
+	snapshot := s.snapshot(c, "nightly")
+	c.Assert(snapshot.Life(), gc.Equals, state.Dying)
+	_, ok := snapshot.PendingRestore()
+	c.Assert(ok, jc.IsFalse)
+	err = s.storageBackend.DestroyVolumeSnapshot("nightly")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.storageBackend.RemoveVolumeSnapshot("nightly")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.storageBackend.VolumeSnapshot("nightly")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.storageBackend.RemoveVolumeSnapshot("nightly")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *VolumeSnapshotSuite) TestWatchModelVolumeSnapshots(c *gc.C) {
+	volumeTag := s.addVolume(c)
+	w := s.storageBackend.WatchModelVolumeSnapshots()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.st, w)
+	wc.AssertChangeInSingleEvent() // initial
+	wc.AssertNoChange()
+
+	err := s.storageBackend.CreateVolumeSnapshot(volumeTag, "nightly")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("nightly")
+	wc.AssertNoChange()
+
+	err = s.storageBackend.DestroyVolumeSnapshot("nightly")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("nightly")
+	wc.AssertNoChange()
+}
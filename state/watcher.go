@@ -215,6 +215,29 @@ func (sb *storageBackend) WatchModelVolumes() StringsWatcher {
 	return sb.watchModelHostStorage(volumesC)
 }
 
+// WatchModelVolumeResizes returns a StringsWatcher that notifies of
+// changes to any model-scoped volume, including requests to expand it.
+func (sb *storageBackend) WatchModelVolumeResizes() StringsWatcher {
+	mb := sb.mb
+	return newCollectionWatcher(mb, colWCfg{
+		col: volumesC,
+		filter: func(id interface{}) bool {
+			k, err := mb.strictLocalID(id.(string))
+			if err != nil {
+				return false
+			}
+			return !strings.Contains(k, "/")
+		},
+	})
+}
+
+// WatchModelVolumeSnapshots returns a StringsWatcher that notifies of
+// changes to any of the model's volume snapshots, including requests
+// to restore them. The watcher reports the names of the snapshots.
+func (sb *storageBackend) WatchModelVolumeSnapshots() StringsWatcher {
+	return newCollectionWatcher(sb.mb, colWCfg{col: volumeSnapshotsC})
+}
+
 // WatchModelFilesystems returns a StringsWatcher that notifies of changes
 // to the lifecycles of all model-scoped filesystems.
 func (sb *storageBackend) WatchModelFilesystems() StringsWatcher {
@@ -2691,6 +2714,13 @@ func (st *State) WatchActionLogs(actionId string) StringsWatcher {
 	return newActionLogsWatcher(st, actionId)
 }
 
+// WatchAction returns a NotifyWatcher that notifies of changes to the
+// action with the given id, including new messages being logged and
+// its status changing.
+func (st *State) WatchAction(actionId string) NotifyWatcher {
+	return newEntityWatcher(st, actionsC, st.docID(actionId))
+}
+
 // actionLogsWatcher reports new action progress messages.
 type actionLogsWatcher struct {
 	commonWatcher
@@ -2738,6 +2768,7 @@ func (w *actionLogsWatcher) messages() ([]string, error) {
 		mjson, err := json.Marshal(actions.ActionMessage{
 			Message:   m.MessageValue,
 			Timestamp: m.TimestampValue.UTC(),
+			Stream:    m.StreamValue,
 		})
 		if err != nil {
 			return nil, errors.Trace(err)
@@ -3626,20 +3657,37 @@ func (st *State) WatchMachineRemovals() NotifyWatcher {
 	return newNotifyCollWatcher(st, machineRemovalsC, isLocalID(st))
 }
 
+// WatchFirewallRules returns a NotifyWatcher which triggers whenever
+// a firewall rule or an operator defined service changes.
+func (st *State) WatchFirewallRules() NotifyWatcher {
+	return newNotifyCollsWatcher(st, isLocalID(st), firewallRulesC, firewallServicesC)
+}
+
+// WatchSubnetsAndSpaces returns a NotifyWatcher which triggers whenever
+// a subnet or a space in the model changes, including when subnets are
+// moved between spaces.
+func (st *State) WatchSubnetsAndSpaces() NotifyWatcher {
+	return newNotifyCollsWatcher(st, isLocalID(st), subnetsC, spacesC)
+}
+
 // notifyCollWatcher implements NotifyWatcher, triggering when a
-// change is seen in a specific collection matching the provided
+// change is seen in any of a set of collections matching the provided
 // filter function.
 type notifyCollWatcher struct {
 	commonWatcher
-	collName string
-	filter   func(interface{}) bool
-	sink     chan struct{}
+	collNames []string
+	filter    func(interface{}) bool
+	sink      chan struct{}
 }
 
 func newNotifyCollWatcher(backend modelBackend, collName string, filter func(interface{}) bool) NotifyWatcher {
+	return newNotifyCollsWatcher(backend, filter, collName)
+}
+
+func newNotifyCollsWatcher(backend modelBackend, filter func(interface{}) bool, collNames ...string) NotifyWatcher {
 	w := &notifyCollWatcher{
 		commonWatcher: newCommonWatcher(backend),
-		collName:      collName,
+		collNames:     collNames,
 		filter:        filter,
 		sink:          make(chan struct{}),
 	}
@@ -3658,8 +3706,10 @@ func (w *notifyCollWatcher) Changes() <-chan struct{} {
 func (w *notifyCollWatcher) loop() error {
 	in := make(chan watcher.Change)
 
-	w.watcher.WatchCollectionWithFilter(w.collName, in, w.filter)
-	defer w.watcher.UnwatchCollection(w.collName, in)
+	for _, collName := range w.collNames {
+		w.watcher.WatchCollectionWithFilter(collName, in, w.filter)
+		defer w.watcher.UnwatchCollection(collName, in)
+	}
 
 	// check if there are any pending changes before the first event
 	if _, ok := collect(watcher.Change{}, in, w.tomb.Dying()); !ok {
@@ -216,6 +216,44 @@ type VolumeImporter interface {
 	) (VolumeInfo, error)
 }
 
+// VolumeResizer provides an interface for expanding volumes
+// which have already been provisioned.
+type VolumeResizer interface {
+	// ResizeVolumes expands the volumes with the specified provider
+	// volume IDs to at least the corresponding sizes, and reports
+	// each volume's current size. Expansion may complete after
+	// ResizeVolumes returns, so callers may call ResizeVolumes again
+	// until the reported size reaches the requested size.
+	//
+	// Volumes may only grow; a size smaller than the volume's current
+	// size is an error satisfying errors.IsNotValid. Expansions the
+	// volume cannot support are reported with errors satisfying
+	// errors.IsNotSupported. Neither kind of error is retried.
+	ResizeVolumes(ctx context.ProviderCallContext, params []VolumeResizeParams) ([]ResizeVolumesResult, error)
+}
+
+// VolumeSnapshotter provides an interface for taking point in time
+// snapshots of volumes, and for restoring snapshots to new volumes.
+type VolumeSnapshotter interface {
+	// SnapshotVolumes takes a snapshot of each of the volumes with the
+	// specified provider volume IDs. Taking a snapshot with the name of
+	// one that already exists reports the existing snapshot, so callers
+	// may call SnapshotVolumes again to learn when a snapshot is ready.
+	SnapshotVolumes(ctx context.ProviderCallContext, params []VolumeSnapshotParams) ([]SnapshotVolumesResult, error)
+
+	// RestoreVolumes creates new volumes, each populated with the
+	// contents of the snapshot with the corresponding snapshot ID.
+	// Providers which provision volumes asynchronously return a
+	// NotProvisioned error for each volume that is yet to be
+	// provisioned; RestoreVolumes may be called again with the same
+	// parameters until the volume is reported.
+	RestoreVolumes(ctx context.ProviderCallContext, params []VolumeRestoreParams) ([]RestoreVolumesResult, error)
+
+	// DestroySnapshots destroys the snapshots with the specified
+	// snapshot IDs.
+	DestroySnapshots(ctx context.ProviderCallContext, snapshotIds []string) ([]error, error)
+}
+
 // VolumeParams is a fully specified set of parameters for volume creation,
 // derived from one or more of user-specified storage constraints, a
 // storage pool definition, and charm storage metadata.
@@ -252,6 +290,63 @@ type VolumeParams struct {
 	Attachment *VolumeAttachmentParams
 }
 
+// VolumeResizeParams is a set of parameters for expanding a volume.
+type VolumeResizeParams struct {
+	// Tag is the unique tag assigned by Juju for the volume.
+	Tag names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume.
+	VolumeId string
+
+	// Size is the size in MiB the volume should be expanded to.
+	Size uint64
+}
+
+// VolumeSnapshotParams is a set of parameters for taking a snapshot
+// of a volume.
+type VolumeSnapshotParams struct {
+	// Tag is the unique tag assigned by Juju for the volume.
+	Tag names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume.
+	VolumeId string
+
+	// Name is the name to give the snapshot, if the provider
+	// supports naming snapshots.
+	Name string
+
+	// Attributes is the set of provider-specific attributes of the
+	// storage pool the volume was created in.
+	Attributes map[string]interface{}
+
+	// ResourceTags is a set of tags to set on the created snapshot,
+	// if the storage provider supports tags.
+	ResourceTags map[string]string
+}
+
+// VolumeRestoreParams is a set of parameters for creating
+// a volume from a snapshot.
+type VolumeRestoreParams struct {
+	// Storage is the tag of the storage instance the
+	// restored volume is to be assigned to.
+	Storage names.StorageTag
+
+	// SnapshotId is the unique provider-supplied ID for the
+	// snapshot to populate the volume from.
+	SnapshotId string
+
+	// Size is the minimum size of the volume in MiB.
+	Size uint64
+
+	// Attributes is the set of provider-specific attributes of the
+	// storage pool the snapshot's volume was created in.
+	Attributes map[string]interface{}
+
+	// ResourceTags is a set of tags to set on the created volume,
+	// if the storage provider supports tags.
+	ResourceTags map[string]string
+}
+
 // VolumeAttachmentParams is a set of parameters for volume attachment or
 // detachment.
 type VolumeAttachmentParams struct {
@@ -355,6 +450,29 @@ type DescribeVolumesResult struct {
 	Error      error
 }
 
+// SnapshotVolumesResult contains the result of a VolumeSnapshotter.SnapshotVolumes
+// call for one volume. Snapshot should only be used if Error is nil.
+type SnapshotVolumesResult struct {
+	Snapshot *VolumeSnapshot
+	Error    error
+}
+
+// RestoreVolumesResult contains the result of a VolumeSnapshotter.RestoreVolumes
+// call for one volume. VolumeInfo should only be used if Error is nil.
+type RestoreVolumesResult struct {
+	VolumeInfo *VolumeInfo
+	Error      error
+}
+
+// ResizeVolumesResult contains the result of a VolumeResizer.ResizeVolumes
+// call for one volume. Size should only be used if Error is nil.
+type ResizeVolumesResult struct {
+	// Size is the volume's current size in MiB, which is smaller
+	// than the requested size until the expansion completes.
+	Size  uint64
+	Error error
+}
+
 // AttachVolumesResult contains the result of a VolumeSource.AttachVolumes call
 // for one volume. VolumeAttachment should only be used if Error is nil.
 type AttachVolumesResult struct {
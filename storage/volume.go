@@ -43,6 +43,23 @@ type VolumeInfo struct {
 	Persistent bool
 }
 
+// VolumeSnapshot describes a point in time snapshot of a volume.
+type VolumeSnapshot struct {
+	// SnapshotId is a unique provider-supplied ID for the snapshot.
+	SnapshotId string
+
+	// VolumeId is the provider-supplied ID of the volume
+	// the snapshot was taken of.
+	VolumeId string
+
+	// Size is the size of the volume the snapshot was taken of, in MiB.
+	Size uint64
+
+	// Ready reflects whether the snapshot has been taken and
+	// can be used to restore a volume.
+	Ready bool
+}
+
 // VolumeAttachment identifies and describes machine-specific volume
 // attachment information, including how the volume is exposed on the
 // machine.
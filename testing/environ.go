@@ -62,6 +62,7 @@ func FakeControllerConfig() controller.Config {
 		"audit-log-capture-args":    true,
 		"audit-log-max-size":        "200M",
 		"audit-log-max-backups":     5,
+		"audit-log-db-size":         "1M",
 	}
 }
 
@@ -0,0 +1,108 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionexpirer
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/tomb.v2"
+)
+
+// logger is here to stop the desire of creating a package level logger.
+// Don't do this, instead pass one in.
+var logger interface{}
+
+// Logger represents the methods used by the worker to log information.
+type Logger interface {
+	Debugf(string, ...interface{})
+}
+
+// Facade exposes the controller method used by the worker
+// to expire actions.
+type Facade interface {
+	ExpireActions() error
+}
+
+// Config defines the operation of an action expirer worker.
+type Config struct {
+
+	// Facade is the worker's view of the controller.
+	Facade Facade
+
+	// Clock is the worker's view of time.
+	Clock clock.Clock
+
+	// Period is the time between sweeps for expired actions.
+	Period time.Duration
+
+	// Logger is used to report on the worker's progress.
+	Logger Logger
+}
+
+// Validate returns an error if the configuration cannot be expected
+// to start a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Period <= 0 {
+		return errors.NotValidf("non-positive Period")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that, once when started and subsequently
+// every Period, cancels pending actions that have outlived their queue
+// timeout and fails running actions that have outlived their execution
+// timeout.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &expirerWorker{
+		config: config,
+	}
+	w.tomb.Go(w.loop)
+	return w, nil
+}
+
+type expirerWorker struct {
+	tomb   tomb.Tomb
+	config Config
+}
+
+func (w *expirerWorker) loop() error {
+	var delay time.Duration
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.config.Clock.After(delay):
+			w.config.Logger.Debugf("expiring timed out actions")
+			if err := w.config.Facade.ExpireActions(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		delay = w.config.Period
+	}
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *expirerWorker) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *expirerWorker) Wait() error {
+	return w.tomb.Wait()
+}
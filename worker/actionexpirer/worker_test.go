@@ -0,0 +1,111 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionexpirer_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/actionexpirer"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock  *testclock.Clock
+	facade *mockFacade
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.clock = testclock.NewClock(time.Unix(1000, 0))
+	s.facade = &mockFacade{
+		calls: make(chan string, 10),
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	cfg := s.config()
+	cfg.Facade = nil
+	_, err := actionexpirer.NewWorker(cfg)
+	c.Check(err, gc.ErrorMatches, "nil Facade not valid")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+
+	cfg = s.config()
+	cfg.Period = 0
+	_, err = actionexpirer.NewWorker(cfg)
+	c.Check(err, gc.ErrorMatches, "non-positive Period not valid")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *WorkerSuite) TestExpiresEachPeriod(c *gc.C) {
+	w, err := actionexpirer.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCall(c)
+	s.waitNoCall(c)
+
+	c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.waitCall(c)
+	s.facade.CheckCallNames(c, "ExpireActions", "ExpireActions")
+}
+
+func (s *WorkerSuite) TestExpireActionsError(c *gc.C) {
+	s.facade.SetErrors(errors.New("boom"))
+
+	w, err := actionexpirer.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *WorkerSuite) config() actionexpirer.Config {
+	return actionexpirer.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+		Period: time.Minute,
+		Logger: loggo.GetLogger("test"),
+	}
+}
+
+func (s *WorkerSuite) waitCall(c *gc.C) {
+	select {
+	case <-s.facade.calls:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for ExpireActions call")
+	}
+}
+
+func (s *WorkerSuite) waitNoCall(c *gc.C) {
+	select {
+	case call := <-s.facade.calls:
+		c.Fatalf("unexpected %s call", call)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+// mockFacade implements actionexpirer.Facade, recording calls made to it.
+type mockFacade struct {
+	testing.Stub
+
+	calls chan string
+}
+
+func (f *mockFacade) ExpireActions() error {
+	f.MethodCall(f, "ExpireActions")
+	f.calls <- "ExpireActions"
+	return f.NextErr()
+}
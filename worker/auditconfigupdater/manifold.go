@@ -10,6 +10,7 @@ import (
 
 	jujuagent "github.com/juju/juju/agent"
 	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/worker/common"
 	workerstate "github.com/juju/juju/worker/state"
 )
@@ -78,7 +79,12 @@ func (config ManifoldConfig) start(context dependency.Context) (_ worker.Worker,
 	st := statePool.SystemState()
 
 	logFactory := func(cfg auditlog.Config) auditlog.AuditLog {
-		return auditlog.NewLogFile(logDir, cfg.MaxSizeMB, cfg.MaxBackups)
+		// Records are also written to the controller's database so
+		// that they can be queried through the API.
+		return auditlog.Tee(
+			auditlog.NewLogFile(logDir, cfg.MaxSizeMB, cfg.MaxBackups),
+			state.NewDbAuditLog(st),
+		)
 	}
 	auditConfig, err := initialConfig(st)
 	if err != nil {
@@ -131,6 +131,34 @@ func (s *manifoldSuite) TestStart(c *gc.C) {
 	c.Assert(args[2], gc.NotNil)
 }
 
+func (s *manifoldSuite) TestTargetWritesToDatabase(c *gc.C) {
+	w, err := s.manifold.Start(s.context)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	target := s.stub.Calls()[0].Args[1].(auditlog.Config).Target
+	defer target.Close()
+	err = target.AddConversation(auditlog.Conversation{
+		Who:            "bob",
+		When:           "2020-01-01T10:00:00Z",
+		ConversationID: "0123456789abcdef",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = target.AddRequest(auditlog.Request{
+		ConversationID: "0123456789abcdef",
+		RequestID:      1,
+		When:           "2020-01-01T10:00:01Z",
+		Facade:         "Application",
+		Method:         "Deploy",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	entries, err := state.QueryAuditLog(s.State, state.AuditLogFilter{User: "bob"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Assert(entries[0].Request.Method, gc.Equals, "Deploy")
+}
+
 func (s *manifoldSuite) TestStartWithAuditingDisabled(c *gc.C) {
 	err := s.State.UpdateControllerConfig(map[string]interface{}{
 		"auditing-enabled": false,
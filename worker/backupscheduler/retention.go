@@ -0,0 +1,70 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// RetentionPolicy describes which scheduled backups are kept.
+type RetentionPolicy struct {
+	// Daily is the number of most recent days for which the latest
+	// backup of each day is kept.
+	Daily int
+
+	// Weekly is the number of most recent ISO weeks for which the
+	// latest backup of each week is kept.
+	Weekly int
+}
+
+// Backup identifies a single backup subject to a retention policy.
+type Backup struct {
+	// ID identifies the backup in the storage it is held in.
+	ID string
+
+	// Started is the time the backup was started.
+	Started time.Time
+}
+
+// Expired returns the backups that are not retained by the policy,
+// oldest first. The most recent backup is always retained. If neither
+// Daily nor Weekly are set, no backups expire.
+func (p RetentionPolicy) Expired(backups []Backup) []Backup {
+	if len(backups) == 0 || (p.Daily <= 0 && p.Weekly <= 0) {
+		return nil
+	}
+	sorted := make([]Backup, len(backups))
+	copy(sorted, backups)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Started.After(sorted[j].Started)
+	})
+
+	keep := map[int]bool{0: true}
+	days := make(map[string]bool)
+	weeks := make(map[string]bool)
+	for i, b := range sorted {
+		t := b.Started.UTC()
+		day := t.Format("2006-01-02")
+		if !days[day] && len(days) < p.Daily {
+			days[day] = true
+			keep[i] = true
+		}
+		year, week := t.ISOWeek()
+		weekKey := fmt.Sprintf("%d-W%02d", year, week)
+		if !weeks[weekKey] && len(weeks) < p.Weekly {
+			weeks[weekKey] = true
+			keep[i] = true
+		}
+	}
+
+	var expired []Backup
+	for i := len(sorted) - 1; i >= 0; i-- {
+		if !keep[i] {
+			expired = append(expired, sorted[i])
+		}
+	}
+	return expired
+}
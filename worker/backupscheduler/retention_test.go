@@ -0,0 +1,95 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/backupscheduler"
+)
+
+type RetentionSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&RetentionSuite{})
+
+// backupsEvery returns n backups, one every interval, with the most
+// recent started at 2020-01-31T02:00:00Z. They are named by index,
+// with "0" being the oldest.
+func backupsEvery(n int, interval time.Duration) []backupscheduler.Backup {
+	latest := time.Date(2020, 1, 31, 2, 0, 0, 0, time.UTC)
+	result := make([]backupscheduler.Backup, n)
+	for i := range result {
+		result[i] = backupscheduler.Backup{
+			ID:      string(rune('a' + i)),
+			Started: latest.Add(-time.Duration(n-1-i) * interval),
+		}
+	}
+	return result
+}
+
+func ids(backups []backupscheduler.Backup) []string {
+	var result []string
+	for _, b := range backups {
+		result = append(result, b.ID)
+	}
+	return result
+}
+
+func (s *RetentionSuite) TestNoPolicyKeepsEverything(c *gc.C) {
+	policy := backupscheduler.RetentionPolicy{}
+	c.Assert(policy.Expired(backupsEvery(10, 24*time.Hour)), gc.HasLen, 0)
+}
+
+func (s *RetentionSuite) TestDaily(c *gc.C) {
+	policy := backupscheduler.RetentionPolicy{Daily: 3}
+	expired := policy.Expired(backupsEvery(5, 24*time.Hour))
+	c.Assert(ids(expired), gc.DeepEquals, []string{"a", "b"})
+}
+
+func (s *RetentionSuite) TestDailyKeepsLatestOfEachDay(c *gc.C) {
+	policy := backupscheduler.RetentionPolicy{Daily: 2}
+	// "d" is at 02:00 on the 31st; "c", "b" and "a" are at 20:00,
+	// 14:00 and 08:00 on the 30th.
+	expired := policy.Expired(backupsEvery(4, 6*time.Hour))
+	c.Assert(ids(expired), gc.DeepEquals, []string{"a", "b"})
+}
+
+func (s *RetentionSuite) TestWeekly(c *gc.C) {
+	policy := backupscheduler.RetentionPolicy{Weekly: 2}
+	expired := policy.Expired(backupsEvery(15, 24*time.Hour))
+	// 2020-01-31 is a Friday in week 5; week 4 runs from the 20th
+	// to the 26th, so the backups kept are the 31st and the 26th.
+	c.Assert(ids(expired), gc.DeepEquals, []string{
+		"a", "b", "c", "d", "e", "f", "g", "h", "i", "k", "l", "m", "n",
+	})
+}
+
+func (s *RetentionSuite) TestDailyAndWeekly(c *gc.C) {
+	policy := backupscheduler.RetentionPolicy{Daily: 2, Weekly: 2}
+	expired := policy.Expired(backupsEvery(15, 24*time.Hour))
+	// Keep the 31st and 30th for the daily policy, and the 26th
+	// for the weekly policy.
+	c.Assert(ids(expired), gc.DeepEquals, []string{
+		"a", "b", "c", "d", "e", "f", "g", "h", "i", "k", "l", "m",
+	})
+}
+
+func (s *RetentionSuite) TestLatestAlwaysKept(c *gc.C) {
+	policy := backupscheduler.RetentionPolicy{Weekly: 1}
+	expired := policy.Expired(backupsEvery(1, time.Hour))
+	c.Assert(expired, gc.HasLen, 0)
+}
+
+func (s *RetentionSuite) TestUnsortedInput(c *gc.C) {
+	policy := backupscheduler.RetentionPolicy{Daily: 1}
+	backups := backupsEvery(3, 24*time.Hour)
+	backups[0], backups[2] = backups[2], backups[0]
+	expired := policy.Expired(backups)
+	c.Assert(ids(expired), gc.DeepEquals, []string{"a", "b"})
+}
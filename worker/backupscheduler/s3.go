@@ -0,0 +1,410 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+)
+
+const (
+	s3DefaultRegion    = "us-east-1"
+	s3UnsignedPayload  = "UNSIGNED-PAYLOAD"
+	s3SigningAlgorithm = "AWS4-HMAC-SHA256"
+	s3DateFormat       = "20060102"
+	s3TimeFormat       = "20060102T150405Z"
+
+	// s3DefaultPartSize is the size of each part of a multipart
+	// upload, unless the archive is so large that it would need
+	// more than s3MaxParts parts.
+	s3DefaultPartSize = 1 << 30
+	s3MaxParts        = 10000
+)
+
+// S3Config holds the information needed to upload backups to an
+// S3-compatible object store.
+type S3Config struct {
+	// Endpoint is the base URL of the object store, eg
+	// "https://s3.amazonaws.com" or "http://minio.internal:9000".
+	Endpoint string
+
+	// Bucket is the name of the bucket archives are stored in.
+	Bucket string
+
+	// Prefix is prepended to the name of each archive.
+	Prefix string
+
+	// Region is used when signing requests. It defaults to
+	// "us-east-1", which most S3-compatible stores accept.
+	Region string
+
+	// AccessKey and SecretKey are the credentials used to
+	// sign requests.
+	AccessKey string
+	SecretKey string
+
+	// PartSize is the largest archive uploaded with a single
+	// request, and the size of each part when larger archives are
+	// uploaded in parts. Amazon S3 refuses single uploads over 5GiB
+	// and parts under 5MiB. It defaults to 1GiB.
+	PartSize int64
+
+	// HTTPClient is used to make requests to the object store.
+	HTTPClient *http.Client
+
+	// Clock is used to timestamp signed requests.
+	Clock clock.Clock
+}
+
+// Validate checks that the configuration is usable.
+func (config S3Config) Validate() error {
+	if config.Endpoint == "" {
+		return errors.NotValidf("empty Endpoint")
+	}
+	if _, err := url.Parse(config.Endpoint); err != nil {
+		return errors.NotValidf("Endpoint %q", config.Endpoint)
+	}
+	if config.Bucket == "" {
+		return errors.NotValidf("empty Bucket")
+	}
+	if config.AccessKey == "" || config.SecretKey == "" {
+		return errors.NotValidf("missing credentials")
+	}
+	if config.PartSize < 0 {
+		return errors.NotValidf("negative PartSize")
+	}
+	if config.HTTPClient == nil {
+		return errors.NotValidf("nil HTTPClient")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+// NewS3Target returns a Target that stores archives in a bucket of
+// an S3-compatible object store, using path-style requests signed
+// with AWS signature version 4.
+func NewS3Target(config S3Config) (Target, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if config.Region == "" {
+		config.Region = s3DefaultRegion
+	}
+	if config.PartSize == 0 {
+		config.PartSize = s3DefaultPartSize
+	}
+	config.Endpoint = strings.TrimRight(config.Endpoint, "/")
+	return &s3Target{config: config}, nil
+}
+
+type s3Target struct {
+	config S3Config
+}
+
+func (t *s3Target) key(name string) string {
+	if t.config.Prefix == "" {
+		return name
+	}
+	return t.config.Prefix + "/" + name
+}
+
+func (t *s3Target) objectPath(name string) string {
+	return "/" + t.config.Bucket + "/" + t.key(name)
+}
+
+// Upload is part of the Target interface. Archives larger than the
+// configured part size are uploaded in parts.
+func (t *s3Target) Upload(name string, r io.Reader, size int64) error {
+	if size < 0 {
+		return errors.NotValidf("uploading %q of unknown size", name)
+	}
+	if size > t.config.PartSize {
+		return errors.Annotatef(t.uploadParts(name, r, size), "uploading %q", name)
+	}
+	resp, err := t.do("PUT", t.objectPath(name), nil, r, size)
+	if err != nil {
+		return errors.Annotatef(err, "uploading %q", name)
+	}
+	return errors.Trace(resp.Body.Close())
+}
+
+type s3InitiateMultipartUploadResult struct {
+	UploadId string `xml:"UploadId"`
+}
+
+type s3CompletedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+type s3CompleteMultipartUpload struct {
+	XMLName xml.Name          `xml:"CompleteMultipartUpload"`
+	Parts   []s3CompletedPart `xml:"Part"`
+}
+
+type s3CompleteMultipartUploadResult struct {
+	XMLName xml.Name
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// uploadParts uploads the archive read from r with a multipart
+// upload. The upload is aborted if any part fails, so that the
+// object store does not keep the parts already uploaded.
+func (t *s3Target) uploadParts(name string, r io.Reader, size int64) (err error) {
+	path := t.objectPath(name)
+	resp, err := t.do("POST", path, url.Values{"uploads": {""}}, nil, 0)
+	if err != nil {
+		return errors.Annotate(err, "starting multipart upload")
+	}
+	var initiated s3InitiateMultipartUploadResult
+	err = xml.NewDecoder(resp.Body).Decode(&initiated)
+	_ = resp.Body.Close()
+	if err != nil {
+		return errors.Annotate(err, "decoding multipart upload")
+	}
+	uploadQuery := url.Values{"uploadId": {initiated.UploadId}}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if resp, abortErr := t.do("DELETE", path, uploadQuery, nil, 0); abortErr == nil {
+			_ = resp.Body.Close()
+		}
+	}()
+
+	partSize := t.config.PartSize
+	if minSize := (size + s3MaxParts - 1) / s3MaxParts; partSize < minSize {
+		partSize = minSize
+	}
+	var complete s3CompleteMultipartUpload
+	for offset, number := int64(0), 1; offset < size; offset, number = offset+partSize, number+1 {
+		length := partSize
+		if remaining := size - offset; remaining < length {
+			length = remaining
+		}
+		query := url.Values{
+			"partNumber": {strconv.Itoa(number)},
+			"uploadId":   {initiated.UploadId},
+		}
+		resp, err := t.do("PUT", path, query, io.LimitReader(r, length), length)
+		if err != nil {
+			return errors.Annotatef(err, "uploading part %d", number)
+		}
+		_ = resp.Body.Close()
+		complete.Parts = append(complete.Parts, s3CompletedPart{
+			PartNumber: number,
+			ETag:       resp.Header.Get("ETag"),
+		})
+	}
+
+	body, err := xml.Marshal(complete)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp, err = t.do("POST", path, uploadQuery, bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		return errors.Annotate(err, "completing multipart upload")
+	}
+	// The object store may report a failure to complete the upload
+	// in the body of a successful response.
+	var result s3CompleteMultipartUploadResult
+	err = xml.NewDecoder(resp.Body).Decode(&result)
+	_ = resp.Body.Close()
+	if err != nil {
+		return errors.Annotate(err, "decoding multipart upload completion")
+	}
+	if result.XMLName.Local == "Error" {
+		return errors.Errorf("completing multipart upload: %s: %s", result.Code, result.Message)
+	}
+	return nil
+}
+
+type s3ListResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// List is part of the Target interface.
+func (t *s3Target) List() ([]string, error) {
+	prefix := t.key("")
+	var names []string
+	var token string
+	for {
+		query := url.Values{
+			"list-type": {"2"},
+			"prefix":    {prefix},
+		}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		resp, err := t.do("GET", "/"+t.config.Bucket, query, nil, 0)
+		if err != nil {
+			return nil, errors.Annotate(err, "listing backup archives")
+		}
+		var result s3ListResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, errors.Annotate(err, "decoding backup archive listing")
+		}
+		for _, object := range result.Contents {
+			name := strings.TrimPrefix(object.Key, prefix)
+			if name != "" && !strings.Contains(name, "/") {
+				names = append(names, name)
+			}
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return names, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+// Remove is part of the Target interface.
+func (t *s3Target) Remove(name string) error {
+	resp, err := t.do("DELETE", t.objectPath(name), nil, nil, 0)
+	if err != nil {
+		return errors.Annotatef(err, "removing %q", name)
+	}
+	return errors.Trace(resp.Body.Close())
+}
+
+// do sends a signed request to the object store. The response is
+// returned only if it indicates success, in which case the caller
+// must close its body.
+func (t *s3Target) do(method, path string, query url.Values, body io.Reader, size int64) (*http.Response, error) {
+	req, err := http.NewRequest(method, t.config.Endpoint+s3EscapePath(path), body)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	req.URL.RawQuery = s3CanonicalQuery(query)
+	if body != nil {
+		req.ContentLength = size
+	}
+	t.sign(req, s3EscapePath(path))
+
+	resp, err := t.config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if resp.StatusCode/100 != 2 {
+		defer resp.Body.Close()
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, errors.NotFoundf("%s", path)
+		}
+		return nil, errors.Errorf("%s %s: %s: %s", method, path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return resp, nil
+}
+
+// sign adds an AWS signature version 4 Authorization header to the
+// request. The payload itself is not signed, so that archives can be
+// streamed rather than read twice.
+func (t *s3Target) sign(req *http.Request, canonicalURI string) {
+	now := t.config.Clock.Now().UTC()
+	amzDate := now.Format(s3TimeFormat)
+	scope := strings.Join([]string{
+		now.Format(s3DateFormat), t.config.Region, "s3", "aws4_request",
+	}, "/")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", s3UnsignedPayload)
+	const signedHeaders = "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := fmt.Sprintf("host:%s\nx-amz-content-sha256:%s\nx-amz-date:%s\n",
+		req.URL.Host, s3UnsignedPayload, amzDate)
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI,
+		req.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		s3UnsignedPayload,
+	}, "\n")
+	hashed := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		s3SigningAlgorithm,
+		amzDate,
+		scope,
+		hex.EncodeToString(hashed[:]),
+	}, "\n")
+
+	key := s3HMAC([]byte("AWS4"+t.config.SecretKey), now.Format(s3DateFormat))
+	key = s3HMAC(key, t.config.Region)
+	key = s3HMAC(key, "s3")
+	key = s3HMAC(key, "aws4_request")
+	signature := hex.EncodeToString(s3HMAC(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3SigningAlgorithm, t.config.AccessKey, scope, signedHeaders, signature,
+	))
+}
+
+func s3HMAC(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// s3Escape escapes a string as required by AWS signature version 4:
+// every byte other than the unreserved characters is percent-encoded.
+func s3Escape(s string, keepSlash bool) string {
+	var buf strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			buf.WriteByte(c)
+		case c == '/' && keepSlash:
+			buf.WriteByte(c)
+		default:
+			fmt.Fprintf(&buf, "%%%02X", c)
+		}
+	}
+	return buf.String()
+}
+
+func s3EscapePath(path string) string {
+	return s3Escape(path, true)
+}
+
+func s3CanonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, k := range keys {
+		values := append([]string(nil), query[k]...)
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, s3Escape(k, false)+"="+s3Escape(v, false))
+		}
+	}
+	return strings.Join(parts, "&")
+}
@@ -0,0 +1,163 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"io"
+
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb. If you were
+// to change any part of it so that it were no longer *obviously* and
+// *trivially* correct, you would be Doing It Wrong.
+
+// backupsDB adapts a controller State to the backups.DB interface.
+type backupsDB struct {
+	*state.State
+	model *state.Model
+}
+
+// ModelTag is part of the backups.DB interface.
+func (db backupsDB) ModelTag() names.ModelTag {
+	return db.model.ModelTag()
+}
+
+// ModelConfig is part of the backups.DB interface.
+func (db backupsDB) ModelConfig() (*config.Config, error) {
+	return db.model.ModelConfig()
+}
+
+type stateBackend struct {
+	backupsDB
+	agentConfig agent.Config
+}
+
+// NewBackend returns a Backend that creates backups of the controller
+// the supplied agent is running on.
+func NewBackend(st *state.State, agentConfig agent.Config) (Backend, error) {
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &stateBackend{
+		backupsDB:   backupsDB{State: st, model: model},
+		agentConfig: agentConfig,
+	}, nil
+}
+
+func (b *stateBackend) withBackups(f func(backups.Backups) error) error {
+	stor := backups.NewStorage(b.backupsDB)
+	defer stor.Close()
+	return f(backups.NewBackups(stor))
+}
+
+// CreateBackup is part of the Backend interface.
+func (b *stateBackend) CreateBackup(notes string) (*backups.Metadata, error) {
+	session := b.MongoSession().Copy()
+	defer session.Close()
+
+	// Don't go if HA isn't ready.
+	if err := replicaset.WaitUntilReady(session, 60); err != nil {
+		return nil, errors.Annotatef(err, "HA not ready")
+	}
+
+	mgoInfo, ok := b.agentConfig.MongoInfo()
+	if !ok {
+		return nil, errors.New("no mongo info in agent config")
+	}
+	v, err := b.MongoVersion()
+	if err != nil {
+		return nil, errors.Annotate(err, "discovering mongo version")
+	}
+	mongoVersion, err := mongo.NewVersion(v)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	dbInfo, err := backups.NewDBInfo(mgoInfo, session, mongoVersion)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	machineID := b.agentConfig.Tag().Id()
+	machine, err := b.Machine(machineID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta, err := backups.NewMetadataState(b.backupsDB, machineID, machine.Series())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta.Notes = notes
+
+	modelConfig, err := b.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	paths := backups.Paths{
+		BackupDir: modelConfig.BackupDir(),
+		DataDir:   b.agentConfig.DataDir(),
+		LogsDir:   b.agentConfig.LogDir(),
+	}
+	err = b.withBackups(func(bk backups.Backups) error {
+		_, err := bk.Create(meta, &paths, dbInfo, true, true)
+		return err
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return meta, nil
+}
+
+// ListBackups is part of the Backend interface.
+func (b *stateBackend) ListBackups() (result []*backups.Metadata, err error) {
+	err = b.withBackups(func(bk backups.Backups) error {
+		result, err = bk.List()
+		return err
+	})
+	return result, errors.Trace(err)
+}
+
+// OpenBackup is part of the Backend interface.
+func (b *stateBackend) OpenBackup(id string) (io.ReadCloser, error) {
+	// The archive is read from the storage's own session, so the
+	// storage must remain open until the archive is closed.
+	stor := backups.NewStorage(b.backupsDB)
+	_, archive, err := backups.NewBackups(stor).Get(id)
+	if err != nil {
+		stor.Close()
+		return nil, errors.Trace(err)
+	}
+	return &archiveCloser{ReadCloser: archive, storage: stor}, nil
+}
+
+// RemoveBackup is part of the Backend interface.
+func (b *stateBackend) RemoveBackup(id string) error {
+	return errors.Trace(b.withBackups(func(bk backups.Backups) error {
+		return bk.Remove(id)
+	}))
+}
+
+type archiveCloser struct {
+	io.ReadCloser
+	storage io.Closer
+}
+
+// Close closes both the archive and the storage it was read from.
+func (c *archiveCloser) Close() error {
+	err := c.ReadCloser.Close()
+	if storageErr := c.storage.Close(); err == nil {
+		err = storageErr
+	}
+	return err
+}
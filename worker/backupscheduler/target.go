@@ -0,0 +1,151 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/controller"
+)
+
+// Target is a location outside of the controller to which backup
+// archives are uploaded.
+type Target interface {
+	// Upload stores the archive read from r under the given name.
+	Upload(name string, r io.Reader, size int64) error
+
+	// List returns the names of all archives held by the target.
+	List() ([]string, error)
+
+	// Remove deletes the named archive from the target.
+	Remove(name string) error
+}
+
+// NewTarget returns the Target described by the backup target
+// settings in the supplied controller config. A nil Target is
+// returned if no backup target is configured. The supported
+// target URL schemes are:
+//
+//	file:///path/to/directory
+//	s3://host[:port]/bucket[/prefix][?region=...&insecure=true]
+//
+// S3 targets use the backup target access and secret keys.
+func NewTarget(cfg controller.Config) (Target, error) {
+	rawURL := cfg.BackupTarget()
+	if rawURL == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing backup target")
+	}
+	if u.User != nil {
+		return nil, errors.NotValidf("backup target with credentials")
+	}
+	switch u.Scheme {
+	case "file":
+		if u.Path == "" {
+			return nil, errors.NotValidf("backup target %q without a path", rawURL)
+		}
+		return NewDirectoryTarget(u.Path), nil
+	case "s3":
+		config := s3ConfigFromURL(u)
+		config.AccessKey = cfg.BackupTargetAccessKey()
+		config.SecretKey = cfg.BackupTargetSecretKey()
+		return NewS3Target(config)
+	}
+	return nil, errors.NotValidf("backup target scheme %q", u.Scheme)
+}
+
+func s3ConfigFromURL(u *url.URL) S3Config {
+	parts := strings.SplitN(strings.TrimPrefix(u.Path, "/"), "/", 2)
+	config := S3Config{
+		Endpoint: "https://" + u.Host,
+		Bucket:   parts[0],
+		Region:   u.Query().Get("region"),
+	}
+	if len(parts) == 2 {
+		config.Prefix = strings.Trim(parts[1], "/")
+	}
+	if u.Query().Get("insecure") == "true" {
+		config.Endpoint = "http://" + u.Host
+	}
+	config.HTTPClient = http.DefaultClient
+	config.Clock = clock.WallClock
+	return config
+}
+
+// NewDirectoryTarget returns a Target that copies archives into the
+// supplied directory, which is created if necessary.
+func NewDirectoryTarget(dir string) Target {
+	return &directoryTarget{dir: dir}
+}
+
+type directoryTarget struct {
+	dir string
+}
+
+// Upload is part of the Target interface.
+func (t *directoryTarget) Upload(name string, r io.Reader, size int64) (err error) {
+	if err := os.MkdirAll(t.dir, 0700); err != nil {
+		return errors.Trace(err)
+	}
+	// Write to a temporary file first, so that a partial upload is
+	// never mistaken for a complete archive.
+	f, err := ioutil.TempFile(t.dir, "."+name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(f.Name())
+		}
+	}()
+	written, err := io.Copy(f, r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Annotatef(err, "writing %q", name)
+	}
+	if size >= 0 && written != size {
+		return errors.Errorf("writing %q: wrote %d bytes, expected %d", name, written, size)
+	}
+	return errors.Trace(os.Rename(f.Name(), filepath.Join(t.dir, name)))
+}
+
+// List is part of the Target interface.
+func (t *directoryTarget) List() ([]string, error) {
+	infos, err := ioutil.ReadDir(t.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var names []string
+	for _, info := range infos {
+		if info.Mode().IsRegular() && !strings.HasPrefix(info.Name(), ".") {
+			names = append(names, info.Name())
+		}
+	}
+	return names, nil
+}
+
+// Remove is part of the Target interface.
+func (t *directoryTarget) Remove(name string) error {
+	err := os.Remove(filepath.Join(t.dir, name))
+	if os.IsNotExist(err) {
+		return errors.NotFoundf("backup archive %q", name)
+	}
+	return errors.Trace(err)
+}
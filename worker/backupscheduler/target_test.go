@@ -0,0 +1,327 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/worker/backupscheduler"
+)
+
+type DirectoryTargetSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&DirectoryTargetSuite{})
+
+func (s *DirectoryTargetSuite) TestUploadListRemove(c *gc.C) {
+	dir := filepath.Join(c.MkDir(), "backups")
+	target := backupscheduler.NewDirectoryTarget(dir)
+
+	names, err := target.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(names, gc.HasLen, 0)
+
+	err = target.Upload("one.tar.gz", strings.NewReader("archive"), 7)
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadFile(filepath.Join(dir, "one.tar.gz"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "archive")
+
+	// Hidden files, such as partial uploads, are not listed.
+	err = ioutil.WriteFile(filepath.Join(dir, ".partial"), nil, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	names, err = target.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(names, jc.SameContents, []string{"one.tar.gz"})
+
+	err = target.Remove("one.tar.gz")
+	c.Assert(err, jc.ErrorIsNil)
+	err = target.Remove("one.tar.gz")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *DirectoryTargetSuite) TestUploadShort(c *gc.C) {
+	dir := c.MkDir()
+	target := backupscheduler.NewDirectoryTarget(dir)
+	err := target.Upload("one.tar.gz", strings.NewReader("arch"), 7)
+	c.Assert(err, gc.ErrorMatches, `writing "one.tar.gz": wrote 4 bytes, expected 7`)
+
+	infos, err := ioutil.ReadDir(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(infos, gc.HasLen, 0)
+}
+
+type S3TargetSuite struct {
+	testing.IsolationSuite
+
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   []string
+	server   *httptest.Server
+	handler  func(w http.ResponseWriter, r *http.Request)
+}
+
+var _ = gc.Suite(&S3TargetSuite{})
+
+func (s *S3TargetSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.requests = nil
+	s.bodies = nil
+	s.handler = func(w http.ResponseWriter, r *http.Request) {}
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		s.mu.Lock()
+		s.requests = append(s.requests, r)
+		s.bodies = append(s.bodies, string(body))
+		s.mu.Unlock()
+		s.handler(w, r)
+	}))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+}
+
+func (s *S3TargetSuite) newTarget(c *gc.C) backupscheduler.Target {
+	return s.newTargetWithPartSize(c, 0)
+}
+
+func (s *S3TargetSuite) newTargetWithPartSize(c *gc.C, partSize int64) backupscheduler.Target {
+	target, err := backupscheduler.NewS3Target(backupscheduler.S3Config{
+		Endpoint:   s.server.URL,
+		Bucket:     "juju",
+		Prefix:     "controller",
+		AccessKey:  "AKID",
+		SecretKey:  "secret",
+		PartSize:   partSize,
+		HTTPClient: s.server.Client(),
+		Clock:      testclock.NewClock(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return target
+}
+
+func (s *S3TargetSuite) TestUpload(c *gc.C) {
+	target := s.newTarget(c)
+	err := target.Upload("one.tar.gz", strings.NewReader("archive"), 7)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 1)
+	req := s.requests[0]
+	c.Check(req.Method, gc.Equals, "PUT")
+	c.Check(req.URL.Path, gc.Equals, "/juju/controller/one.tar.gz")
+	c.Check(s.bodies[0], gc.Equals, "archive")
+	c.Check(req.Header.Get("X-Amz-Date"), gc.Equals, "20200102T030405Z")
+	c.Check(req.Header.Get("X-Amz-Content-Sha256"), gc.Equals, "UNSIGNED-PAYLOAD")
+	c.Check(req.Header.Get("Authorization"), gc.Matches,
+		"AWS4-HMAC-SHA256 Credential=AKID/20200102/us-east-1/s3/aws4_request, "+
+			"SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=[0-9a-f]{64}")
+}
+
+func (s *S3TargetSuite) multipartHandler(failPart string) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case r.Method == "POST" && query.Get("uploadId") == "":
+			w.Write([]byte(`<InitiateMultipartUploadResult><UploadId>upload-1</UploadId></InitiateMultipartUploadResult>`))
+		case r.Method == "PUT" && query.Get("partNumber") == failPart:
+			w.WriteHeader(http.StatusInternalServerError)
+		case r.Method == "PUT":
+			w.Header().Set("ETag", `"etag-`+query.Get("partNumber")+`"`)
+		case r.Method == "POST":
+			w.Write([]byte(`<CompleteMultipartUploadResult><Key>controller/one.tar.gz</Key></CompleteMultipartUploadResult>`))
+		case r.Method == "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}
+}
+
+func (s *S3TargetSuite) TestUploadMultipart(c *gc.C) {
+	s.handler = s.multipartHandler("")
+	target := s.newTargetWithPartSize(c, 3)
+	err := target.Upload("one.tar.gz", strings.NewReader("archive"), 7)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 5)
+	for _, req := range s.requests {
+		c.Check(req.URL.Path, gc.Equals, "/juju/controller/one.tar.gz")
+	}
+	c.Check(s.requests[0].Method, gc.Equals, "POST")
+	c.Check(s.requests[0].URL.RawQuery, gc.Equals, "uploads=")
+	for i, part := range []string{"arc", "hiv", "e"} {
+		c.Check(s.requests[i+1].Method, gc.Equals, "PUT")
+		c.Check(s.requests[i+1].URL.RawQuery, gc.Equals, fmt.Sprintf("partNumber=%d&uploadId=upload-1", i+1))
+		c.Check(s.bodies[i+1], gc.Equals, part)
+	}
+	c.Check(s.requests[4].Method, gc.Equals, "POST")
+	c.Check(s.requests[4].URL.RawQuery, gc.Equals, "uploadId=upload-1")
+	c.Check(s.bodies[4], gc.Equals, "<CompleteMultipartUpload>"+
+		`<Part><PartNumber>1</PartNumber><ETag>&#34;etag-1&#34;</ETag></Part>`+
+		`<Part><PartNumber>2</PartNumber><ETag>&#34;etag-2&#34;</ETag></Part>`+
+		`<Part><PartNumber>3</PartNumber><ETag>&#34;etag-3&#34;</ETag></Part>`+
+		"</CompleteMultipartUpload>")
+}
+
+func (s *S3TargetSuite) TestUploadMultipartAbortsOnError(c *gc.C) {
+	s.handler = s.multipartHandler("2")
+	target := s.newTargetWithPartSize(c, 3)
+	err := target.Upload("one.tar.gz", strings.NewReader("archive"), 7)
+	c.Assert(err, gc.ErrorMatches, `uploading "one.tar.gz": uploading part 2: PUT /juju/controller/one.tar.gz: 500 Internal Server Error: `)
+
+	c.Assert(s.requests, gc.HasLen, 4)
+	c.Check(s.requests[3].Method, gc.Equals, "DELETE")
+	c.Check(s.requests[3].URL.RawQuery, gc.Equals, "uploadId=upload-1")
+}
+
+func (s *S3TargetSuite) TestUploadMultipartCompletionError(c *gc.C) {
+	handler := s.multipartHandler("")
+	s.handler = func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.Query().Get("uploadId") != "" {
+			w.Write([]byte(`<Error><Code>InternalError</Code><Message>try again</Message></Error>`))
+			return
+		}
+		handler(w, r)
+	}
+	target := s.newTargetWithPartSize(c, 4)
+	err := target.Upload("one.tar.gz", strings.NewReader("archive"), 7)
+	c.Assert(err, gc.ErrorMatches, `uploading "one.tar.gz": completing multipart upload: InternalError: try again`)
+
+	c.Assert(s.requests, gc.HasLen, 5)
+	c.Check(s.requests[4].Method, gc.Equals, "DELETE")
+}
+
+func (s *S3TargetSuite) TestList(c *gc.C) {
+	s.handler = func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("continuation-token") == "" {
+			w.Write([]byte(`<ListBucketResult>
+  <Contents><Key>controller/one.tar.gz</Key></Contents>
+  <Contents><Key>controller/nested/ignored.tar.gz</Key></Contents>
+  <IsTruncated>true</IsTruncated>
+  <NextContinuationToken>next</NextContinuationToken>
+</ListBucketResult>`))
+			return
+		}
+		w.Write([]byte(`<ListBucketResult>
+  <Contents><Key>controller/two.tar.gz</Key></Contents>
+  <IsTruncated>false</IsTruncated>
+</ListBucketResult>`))
+	}
+	target := s.newTarget(c)
+	names, err := target.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(names, jc.DeepEquals, []string{"one.tar.gz", "two.tar.gz"})
+
+	c.Assert(s.requests, gc.HasLen, 2)
+	c.Check(s.requests[0].Method, gc.Equals, "GET")
+	c.Check(s.requests[0].URL.Path, gc.Equals, "/juju")
+	c.Check(s.requests[0].URL.RawQuery, gc.Equals, "list-type=2&prefix=controller%2F")
+	c.Check(s.requests[1].URL.RawQuery, gc.Equals, "continuation-token=next&list-type=2&prefix=controller%2F")
+}
+
+func (s *S3TargetSuite) TestRemove(c *gc.C) {
+	s.handler = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}
+	target := s.newTarget(c)
+	err := target.Remove("one.tar.gz")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 1)
+	c.Check(s.requests[0].Method, gc.Equals, "DELETE")
+	c.Check(s.requests[0].URL.Path, gc.Equals, "/juju/controller/one.tar.gz")
+}
+
+func (s *S3TargetSuite) TestErrorResponse(c *gc.C) {
+	s.handler = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("<Error><Code>AccessDenied</Code></Error>"))
+	}
+	target := s.newTarget(c)
+	err := target.Upload("one.tar.gz", strings.NewReader("archive"), 7)
+	c.Assert(err, gc.ErrorMatches, `uploading "one.tar.gz": PUT /juju/controller/one.tar.gz: 403 Forbidden: <Error><Code>AccessDenied</Code></Error>`)
+}
+
+func (s *S3TargetSuite) TestNotFound(c *gc.C) {
+	s.handler = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}
+	target := s.newTarget(c)
+	err := target.Remove("one.tar.gz")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *S3TargetSuite) TestValidate(c *gc.C) {
+	_, err := backupscheduler.NewS3Target(backupscheduler.S3Config{
+		Endpoint: s.server.URL,
+		Bucket:   "juju",
+	})
+	c.Assert(err, gc.ErrorMatches, "missing credentials not valid")
+}
+
+func (s *S3TargetSuite) TestNewTargetS3(c *gc.C) {
+	host := strings.TrimPrefix(s.server.URL, "http://")
+	target, err := backupscheduler.NewTarget(controller.Config{
+		controller.BackupTarget:          "s3://" + host + "/juju/controller?insecure=true&region=eu-west-1",
+		controller.BackupTargetAccessKey: "AKID",
+		controller.BackupTargetSecretKey: "secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = target.Upload("one.tar.gz", strings.NewReader("archive"), 7)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 1)
+	c.Check(s.requests[0].URL.Path, gc.Equals, "/juju/controller/one.tar.gz")
+	c.Check(s.requests[0].Header.Get("Authorization"), gc.Matches, ".* Credential=AKID/[0-9]{8}/eu-west-1/s3/aws4_request, .*")
+}
+
+type NewTargetSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&NewTargetSuite{})
+
+func (s *NewTargetSuite) TestEmpty(c *gc.C) {
+	target, err := backupscheduler.NewTarget(controller.Config{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(target, gc.IsNil)
+}
+
+func (s *NewTargetSuite) TestDirectory(c *gc.C) {
+	dir := c.MkDir()
+	target, err := backupscheduler.NewTarget(controller.Config{
+		controller.BackupTarget: "file://" + dir,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = target.Upload("one.tar.gz", strings.NewReader("archive"), 7)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = os.Stat(filepath.Join(dir, "one.tar.gz"))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *NewTargetSuite) TestUnsupportedScheme(c *gc.C) {
+	_, err := backupscheduler.NewTarget(controller.Config{
+		controller.BackupTarget: "ftp://example.com/backups",
+	})
+	c.Assert(err, gc.ErrorMatches, `backup target scheme "ftp" not valid`)
+}
+
+func (s *NewTargetSuite) TestCredentialsInURL(c *gc.C) {
+	_, err := backupscheduler.NewTarget(controller.Config{
+		controller.BackupTarget: "s3://AKID:secret@s3.example.com/juju",
+	})
+	c.Assert(err, gc.ErrorMatches, `backup target with credentials not valid`)
+}
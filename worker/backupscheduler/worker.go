@@ -0,0 +1,311 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package backupscheduler provides a controller worker that creates
+// backups of the controller on a cron-like schedule, uploads them to
+// an optional remote target and prunes old scheduled backups according
+// to a retention policy.
+package backupscheduler
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/cron"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+// ScheduledBackupNotes is recorded in the metadata of every backup
+// created by the scheduler. Only backups carrying these notes are
+// subject to the retention policy; backups created by users with
+// "juju create-backup" are never removed.
+const ScheduledBackupNotes = "scheduled backup"
+
+// Backend provides the controller functionality needed by the
+// backup scheduler.
+type Backend interface {
+	// WatchControllerConfig returns a watcher that notifies of
+	// changes to controller config.
+	WatchControllerConfig() state.NotifyWatcher
+
+	// ControllerConfig returns the current controller config.
+	ControllerConfig() (controller.Config, error)
+
+	// CreateBackup creates a new backup of the controller, keeps it
+	// in the controller's backup storage and returns its metadata.
+	CreateBackup(notes string) (*backups.Metadata, error)
+
+	// ListBackups returns the metadata of every backup kept in the
+	// controller's backup storage.
+	ListBackups() ([]*backups.Metadata, error)
+
+	// OpenBackup returns the archive of the identified backup.
+	OpenBackup(id string) (io.ReadCloser, error)
+
+	// RemoveBackup removes the identified backup from the
+	// controller's backup storage.
+	RemoveBackup(id string) error
+}
+
+// Logger defines the methods used by the backup scheduler for logging.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+	Errorf(string, ...interface{})
+}
+
+// Config holds the configuration and dependencies for a backup
+// scheduler worker.
+type Config struct {
+	Backend   Backend
+	NewTarget func(controller.Config) (Target, error)
+	Clock     clock.Clock
+	Logger    Logger
+}
+
+// Validate returns an error if the config cannot be expected to
+// drive a functional worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.NewTarget == nil {
+		return errors.NotValidf("nil NewTarget")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that creates controller backups
+// according to the backup settings in controller config.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &backupWorker{config: config}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type backupWorker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	schedule  *cron.Schedule
+	retention RetentionPolicy
+	target    Target
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *backupWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *backupWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *backupWorker) loop() error {
+	watcher := w.config.Backend.WatchControllerConfig()
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var next <-chan time.Time
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("controller config watcher closed")
+			}
+			if err := w.updateConfig(); err != nil {
+				return errors.Trace(err)
+			}
+			next = w.scheduleNext()
+		case <-next:
+			w.backUp()
+			next = w.scheduleNext()
+		}
+	}
+}
+
+// updateConfig reads the backup settings from controller config.
+// Settings that cannot be used are logged and disabled rather than
+// stopping the worker, since they will not improve until the config
+// changes again.
+func (w *backupWorker) updateConfig() error {
+	cfg, err := w.config.Backend.ControllerConfig()
+	if err != nil {
+		return errors.Annotate(err, "getting controller config")
+	}
+	w.retention = RetentionPolicy{
+		Daily:  cfg.BackupRetainDaily(),
+		Weekly: cfg.BackupRetainWeekly(),
+	}
+
+	w.schedule = nil
+	if expr := cfg.BackupSchedule(); expr != "" {
+		if w.schedule, err = cron.Parse(expr); err != nil {
+			w.config.Logger.Errorf("scheduled backups disabled: %v", err)
+		}
+	}
+
+	if w.target, err = w.config.NewTarget(cfg); err != nil {
+		w.config.Logger.Errorf("backup uploads disabled: %v", err)
+	}
+	return nil
+}
+
+// scheduleNext returns a channel that will receive when the next
+// backup is due, or nil if no backups are scheduled.
+func (w *backupWorker) scheduleNext() <-chan time.Time {
+	if w.schedule == nil {
+		return nil
+	}
+	now := w.config.Clock.Now()
+	next := w.schedule.Next(now)
+	if next.IsZero() {
+		w.config.Logger.Errorf("backup schedule %q never fires", w.schedule)
+		return nil
+	}
+	w.config.Logger.Debugf("next scheduled backup at %s", next)
+	return w.config.Clock.After(next.Sub(now))
+}
+
+// backUp creates a scheduled backup, uploads it to the target, and
+// then applies the retention policy. Failures are logged rather than
+// returned, so that a single failed backup does not stop subsequent
+// ones from being attempted.
+func (w *backupWorker) backUp() {
+	logger := w.config.Logger
+	meta, err := w.config.Backend.CreateBackup(ScheduledBackupNotes)
+	if err != nil {
+		logger.Errorf("creating scheduled backup: %v", err)
+		return
+	}
+	logger.Infof("created scheduled backup %q", meta.ID())
+
+	if w.target != nil {
+		if err := w.upload(meta); err != nil {
+			logger.Errorf("uploading scheduled backup %q: %v", meta.ID(), err)
+		}
+	}
+	if err := w.pruneLocal(); err != nil {
+		logger.Errorf("pruning scheduled backups: %v", err)
+	}
+	if w.target != nil {
+		if err := w.pruneTarget(); err != nil {
+			logger.Errorf("pruning uploaded backups: %v", err)
+		}
+	}
+}
+
+// archiveName returns the name under which a backup is uploaded.
+func archiveName(meta *backups.Metadata) string {
+	return meta.Started.UTC().Format(backups.FilenameTemplate)
+}
+
+func (w *backupWorker) upload(meta *backups.Metadata) error {
+	archive, err := w.config.Backend.OpenBackup(meta.ID())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer archive.Close()
+
+	name := archiveName(meta)
+	if err := w.target.Upload(name, archive, meta.Size()); err != nil {
+		return errors.Trace(err)
+	}
+	w.config.Logger.Infof("uploaded scheduled backup %q as %q", meta.ID(), name)
+	return nil
+}
+
+func (w *backupWorker) pruneLocal() error {
+	metas, err := w.config.Backend.ListBackups()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	byID := make(map[string]*backups.Metadata)
+	var candidates []Backup
+	for _, meta := range metas {
+		byID[meta.ID()] = meta
+		if meta.Notes == ScheduledBackupNotes {
+			candidates = append(candidates, Backup{ID: meta.ID(), Started: meta.Started})
+		}
+	}
+	expired := w.retention.Expired(candidates)
+	remove := make(map[string]bool)
+	for _, b := range expired {
+		remove[b.ID] = true
+	}
+	// An incremental backup cannot be restored without the backups
+	// it follows on from, so the ancestors of every backup that is
+	// kept are kept too, whether or not they have expired.
+	for _, meta := range metas {
+		if remove[meta.ID()] {
+			continue
+		}
+		for parent := meta.Parent; remove[parent]; {
+			delete(remove, parent)
+			parent = byID[parent].Parent
+		}
+	}
+	// Expired backups are oldest first; remove the newest first so
+	// that incremental backups go before the backups they follow on
+	// from.
+	for i := len(expired) - 1; i >= 0; i-- {
+		b := expired[i]
+		if !remove[b.ID] {
+			w.config.Logger.Debugf("keeping expired scheduled backup %q, which other backups follow on from", b.ID)
+			continue
+		}
+		if err := w.config.Backend.RemoveBackup(b.ID); err != nil {
+			return errors.Annotatef(err, "removing backup %q", b.ID)
+		}
+		w.config.Logger.Infof("removed expired scheduled backup %q", b.ID)
+	}
+	return nil
+}
+
+func (w *backupWorker) pruneTarget() error {
+	names, err := w.target.List()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var candidates []Backup
+	for _, name := range names {
+		// Ignore anything that the scheduler did not upload.
+		started, err := time.Parse(backups.FilenameTemplate, name)
+		if err != nil {
+			continue
+		}
+		candidates = append(candidates, Backup{ID: name, Started: started})
+	}
+	for _, b := range w.retention.Expired(candidates) {
+		if err := w.target.Remove(b.ID); err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "removing uploaded backup %q", b.ID)
+		}
+		w.config.Logger.Infof("removed expired uploaded backup %q", b.ID)
+	}
+	return nil
+}
@@ -0,0 +1,313 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler_test
+
+import (
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/backupscheduler"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock   *testclock.Clock
+	backend *fakeBackend
+	dir     string
+	config  backupscheduler.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC))
+	s.dir = c.MkDir()
+	s.backend = &fakeBackend{
+		clock:   s.clock,
+		changes: make(chan struct{}),
+		config: controller.Config{
+			controller.BackupSchedule:     "@daily",
+			controller.BackupRetainDaily:  2,
+			controller.BackupRetainWeekly: 0,
+			controller.BackupTarget:       "file://" + s.dir,
+		},
+		backups: map[string]*backups.Metadata{},
+	}
+	// A backup created by a user is never pruned.
+	manual := backups.NewMetadata()
+	manual.Started = s.clock.Now().Add(-30 * 24 * time.Hour)
+	manual.SetID("manual")
+	s.backend.backups["manual"] = manual
+
+	s.config = backupscheduler.Config{
+		Backend:   s.backend,
+		NewTarget: backupscheduler.NewTarget,
+		Clock:     s.clock,
+		Logger:    loggo.GetLogger("test"),
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	s.config.Backend = nil
+	_, err := backupscheduler.NewWorker(s.config)
+	c.Assert(err, gc.ErrorMatches, "nil Backend not valid")
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := backupscheduler.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+	s.sendChange(c)
+	return w
+}
+
+func (s *WorkerSuite) sendChange(c *gc.C) {
+	select {
+	case s.backend.changes <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending config change")
+	}
+}
+
+// advance moves the clock on and waits for the worker to schedule
+// its next backup, so that any work triggered has completed.
+func (s *WorkerSuite) advance(c *gc.C, d time.Duration) {
+	err := s.clock.WaitAdvance(d, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.clock.WaitAdvance(0, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WorkerSuite) uploaded(c *gc.C) []string {
+	infos, err := ioutil.ReadDir(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	var names []string
+	for _, info := range infos {
+		names = append(names, info.Name())
+	}
+	return names
+}
+
+func (s *WorkerSuite) TestScheduledBackups(c *gc.C) {
+	s.startWorker(c)
+
+	// The first backup is due at midnight.
+	s.advance(c, 12*time.Hour)
+	c.Check(s.backend.ids(), jc.DeepEquals, []string{"20200102-000000", "manual"})
+	c.Check(s.uploaded(c), jc.DeepEquals, []string{"juju-backup-20200102-000000.tar.gz"})
+
+	s.advance(c, 24*time.Hour)
+	s.advance(c, 24*time.Hour)
+
+	// Only the two most recent scheduled backups are retained.
+	c.Check(s.backend.ids(), jc.DeepEquals, []string{"20200103-000000", "20200104-000000", "manual"})
+	c.Check(s.uploaded(c), jc.DeepEquals, []string{
+		"juju-backup-20200103-000000.tar.gz",
+		"juju-backup-20200104-000000.tar.gz",
+	})
+	data, err := ioutil.ReadFile(filepath.Join(s.dir, "juju-backup-20200104-000000.tar.gz"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "archive 20200104-000000")
+}
+
+func (s *WorkerSuite) addBackup(id, parent string, started time.Time, scheduled bool) {
+	meta := backups.NewMetadata()
+	meta.Started = started
+	meta.Parent = parent
+	if scheduled {
+		meta.Notes = backupscheduler.ScheduledBackupNotes
+	}
+	meta.SetID(id)
+	s.backend.mu.Lock()
+	defer s.backend.mu.Unlock()
+	s.backend.backups[id] = meta
+}
+
+func (s *WorkerSuite) TestPruneKeepsAncestors(c *gc.C) {
+	s.startWorker(c)
+	s.advance(c, 12*time.Hour)
+
+	// A user's incremental backup follows on from the scheduled one,
+	// which must be kept for the incremental backup to be restored.
+	s.addBackup("incremental", "20200102-000000", s.clock.Now(), false)
+	s.advance(c, 24*time.Hour)
+	s.advance(c, 24*time.Hour)
+
+	c.Check(s.backend.ids(), jc.DeepEquals, []string{
+		"20200102-000000", "20200103-000000", "20200104-000000", "incremental", "manual",
+	})
+}
+
+func (s *WorkerSuite) TestPruneIncrementalChain(c *gc.C) {
+	// Expired incremental backups are removed before
+	// the backups they follow on from.
+	now := s.clock.Now()
+	s.addBackup("full", "", now.Add(-72*time.Hour), true)
+	s.addBackup("inc1", "full", now.Add(-71*time.Hour), true)
+	s.addBackup("inc2", "inc1", now.Add(-70*time.Hour), true)
+	s.startWorker(c)
+	s.advance(c, 12*time.Hour)
+	s.advance(c, 24*time.Hour)
+
+	c.Check(s.backend.ids(), jc.DeepEquals, []string{"20200102-000000", "20200103-000000", "manual"})
+	var removed []string
+	for _, call := range s.backend.Calls() {
+		if call.FuncName == "RemoveBackup" {
+			removed = append(removed, call.Args[0].(string))
+		}
+	}
+	c.Check(removed, jc.DeepEquals, []string{"inc2", "inc1", "full"})
+}
+
+func (s *WorkerSuite) TestNoSchedule(c *gc.C) {
+	delete(s.backend.config, controller.BackupSchedule)
+	w := s.startWorker(c)
+
+	// No backup is ever scheduled.
+	err := s.clock.WaitAdvance(24*time.Hour, coretesting.ShortWait, 1)
+	c.Assert(err, gc.NotNil)
+	workertest.CheckAlive(c, w)
+	c.Check(s.backend.ids(), jc.DeepEquals, []string{"manual"})
+}
+
+func (s *WorkerSuite) TestConfigChangeEnablesSchedule(c *gc.C) {
+	delete(s.backend.config, controller.BackupSchedule)
+	s.startWorker(c)
+
+	s.backend.setConfig(controller.BackupSchedule, "0 * * * *")
+	s.sendChange(c)
+	s.advance(c, time.Hour)
+	c.Check(s.backend.ids(), jc.DeepEquals, []string{"20200101-130000", "manual"})
+}
+
+func (s *WorkerSuite) TestNoTarget(c *gc.C) {
+	delete(s.backend.config, controller.BackupTarget)
+	s.startWorker(c)
+
+	s.advance(c, 12*time.Hour)
+	c.Check(s.backend.ids(), jc.DeepEquals, []string{"20200102-000000", "manual"})
+	c.Check(s.uploaded(c), gc.HasLen, 0)
+}
+
+func (s *WorkerSuite) TestCreateFailureDoesNotStopWorker(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	w := s.startWorker(c)
+
+	s.advance(c, 12*time.Hour)
+	workertest.CheckAlive(c, w)
+	c.Check(s.backend.ids(), jc.DeepEquals, []string{"manual"})
+
+	s.advance(c, 24*time.Hour)
+	c.Check(s.backend.ids(), jc.DeepEquals, []string{"20200103-000000", "manual"})
+}
+
+type fakeBackend struct {
+	testing.Stub
+
+	mu      sync.Mutex
+	clock   *testclock.Clock
+	changes chan struct{}
+	config  controller.Config
+	backups map[string]*backups.Metadata
+}
+
+func (b *fakeBackend) setConfig(key string, value interface{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.config[key] = value
+}
+
+func (b *fakeBackend) ids() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var ids []string
+	for id := range b.backups {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (b *fakeBackend) WatchControllerConfig() state.NotifyWatcher {
+	b.MethodCall(b, "WatchControllerConfig")
+	return statetesting.NewMockNotifyWatcher(b.changes)
+}
+
+func (b *fakeBackend) ControllerConfig() (controller.Config, error) {
+	b.MethodCall(b, "ControllerConfig")
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	cfg := make(controller.Config)
+	for k, v := range b.config {
+		cfg[k] = v
+	}
+	return cfg, nil
+}
+
+func (b *fakeBackend) CreateBackup(notes string) (*backups.Metadata, error) {
+	b.MethodCall(b, "CreateBackup", notes)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	meta := backups.NewMetadata()
+	meta.Started = b.clock.Now()
+	meta.Notes = notes
+	id := meta.Started.Format("20060102-150405")
+	meta.SetID(id)
+	meta.SetFileInfo(int64(len("archive "+id)), "checksum", "format")
+	b.backups[id] = meta
+	return meta, nil
+}
+
+func (b *fakeBackend) ListBackups() ([]*backups.Metadata, error) {
+	b.MethodCall(b, "ListBackups")
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var result []*backups.Metadata
+	for _, meta := range b.backups {
+		result = append(result, meta)
+	}
+	return result, nil
+}
+
+func (b *fakeBackend) OpenBackup(id string) (io.ReadCloser, error) {
+	b.MethodCall(b, "OpenBackup", id)
+	return ioutil.NopCloser(strings.NewReader("archive " + id)), nil
+}
+
+func (b *fakeBackend) RemoveBackup(id string) error {
+	b.MethodCall(b, "RemoveBackup", id)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, meta := range b.backups {
+		if meta.Parent == id {
+			return errors.Errorf("backup %q has incremental backups", id)
+		}
+	}
+	delete(b.backups, id)
+	return nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchrollout
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/api/branchrollout"
+)
+
+// logger is here to stop the desire of creating a package level logger.
+// Don't do this, instead pass one in.
+var logger interface{}
+
+// Logger represents the methods used by the worker to log information.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+	Errorf(string, ...interface{})
+}
+
+// Facade exposes the controller methods used by the worker
+// to drive branch rollouts.
+type Facade interface {
+	Rollouts() ([]branchrollout.Rollout, error)
+	AssignRolloutBatch(branchName string, unitNames []string) error
+	HaltRollout(branchName, reason string) error
+	CompleteRollout(branchName string) error
+}
+
+// Config defines the operation of a branch rollout worker.
+type Config struct {
+
+	// Facade is the worker's view of the controller.
+	Facade Facade
+
+	// Clock is the worker's view of time.
+	Clock clock.Clock
+
+	// Period is the time between checks of the rollouts in progress.
+	Period time.Duration
+
+	// Logger is used to report on the progress of rollouts.
+	Logger Logger
+}
+
+// Validate returns an error if the configuration cannot be expected
+// to start a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Period <= 0 {
+		return errors.NotValidf("non-positive Period")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that advances the branch rollouts in progress
+// in the model, once when started and subsequently every Period.
+// Each time, a rollout is either halted because units tracking the branch
+// are in error, left alone until its batch interval has passed, advanced by
+// a batch of units, or completed by committing the branch once no units
+// remain to be rolled out to.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &rolloutWorker{
+		config: config,
+	}
+	w.tomb.Go(w.loop)
+	return w, nil
+}
+
+type rolloutWorker struct {
+	tomb   tomb.Tomb
+	config Config
+}
+
+func (w *rolloutWorker) loop() error {
+	var delay time.Duration
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.config.Clock.After(delay):
+			rollouts, err := w.config.Facade.Rollouts()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			for _, rollout := range rollouts {
+				// A failure to advance one rollout should not hold up
+				// the others; it is retried after the next period.
+				if err := w.advance(rollout); err != nil {
+					w.config.Logger.Errorf("cannot advance rollout of branch %q: %v", rollout.BranchName, err)
+				}
+			}
+		}
+		delay = w.config.Period
+	}
+}
+
+// advance takes the next step in the input branch rollout.
+func (w *rolloutWorker) advance(rollout branchrollout.Rollout) error {
+	branchName := rollout.BranchName
+	policy := rollout.Policy
+
+	if policy.HaltOnError && len(rollout.UnitsInError) > 0 {
+		reason := fmt.Sprintf("units in error: %s", strings.Join(rollout.UnitsInError, ", "))
+		w.config.Logger.Infof("halting rollout of branch %q; %s", branchName, reason)
+		return errors.Trace(w.config.Facade.HaltRollout(branchName, reason))
+	}
+
+	// Wait out the interval after each batch, including the last,
+	// so that errors in the last batch can halt the rollout before
+	// the branch is committed.
+	if !rollout.LastBatch.IsZero() && w.config.Clock.Now().Before(rollout.LastBatch.Add(policy.BatchInterval)) {
+		return nil
+	}
+
+	if len(rollout.UnitsPending) == 0 {
+		w.config.Logger.Infof("all units are tracking branch %q; committing it", branchName)
+		return errors.Trace(w.config.Facade.CompleteRollout(branchName))
+	}
+
+	batch := rollout.UnitsPending
+	if len(batch) > policy.BatchSize {
+		batch = batch[:policy.BatchSize]
+	}
+	w.config.Logger.Debugf("rolling out branch %q to units %v", branchName, batch)
+	return errors.Trace(w.config.Facade.AssignRolloutBatch(branchName, batch))
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *rolloutWorker) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *rolloutWorker) Wait() error {
+	return w.tomb.Wait()
+}
@@ -0,0 +1,186 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchrollout_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/api/branchrollout"
+	"github.com/juju/juju/core/model"
+	coretesting "github.com/juju/juju/testing"
+	rolloutworker "github.com/juju/juju/worker/branchrollout"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock  *testclock.Clock
+	facade *mockFacade
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.clock = testclock.NewClock(time.Unix(1000, 0))
+	s.facade = &mockFacade{
+		calls: make(chan string, 10),
+		rollouts: []branchrollout.Rollout{{
+			BranchName:   "canary",
+			Policy:       model.RolloutPolicy{BatchSize: 2, BatchInterval: time.Minute, HaltOnError: true},
+			StartedBy:    "bob",
+			UnitsPending: []string{"redis/1", "redis/2", "redis/3"},
+		}},
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	cfg := s.config()
+	cfg.Period = 0
+	_, err := rolloutworker.NewWorker(cfg)
+	c.Check(err, gc.ErrorMatches, "non-positive Period not valid")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *WorkerSuite) TestAssignsFirstBatch(c *gc.C) {
+	s.runWorker(c, func() {
+		s.waitCall(c, "Rollouts")
+		s.waitCall(c, "AssignRolloutBatch")
+		s.waitNoCall(c)
+	})
+	s.facade.CheckCall(c, 1, "AssignRolloutBatch", "canary", []string{"redis/1", "redis/2"})
+}
+
+func (s *WorkerSuite) TestWaitsForBatchInterval(c *gc.C) {
+	s.facade.rollouts[0].LastBatch = s.clock.Now().Add(-30 * time.Second)
+
+	s.runWorker(c, func() {
+		s.waitCall(c, "Rollouts")
+		s.waitNoCall(c)
+
+		c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+		s.waitCall(c, "Rollouts")
+		s.waitCall(c, "AssignRolloutBatch")
+	})
+	s.facade.CheckCallNames(c, "Rollouts", "Rollouts", "AssignRolloutBatch")
+}
+
+func (s *WorkerSuite) TestHaltsOnError(c *gc.C) {
+	s.facade.rollouts[0].UnitsInError = []string{"redis/0"}
+
+	s.runWorker(c, func() {
+		s.waitCall(c, "Rollouts")
+		s.waitCall(c, "HaltRollout")
+		s.waitNoCall(c)
+	})
+	s.facade.CheckCall(c, 1, "HaltRollout", "canary", "units in error: redis/0")
+}
+
+func (s *WorkerSuite) TestErrorsIgnoredWithoutHaltOnError(c *gc.C) {
+	s.facade.rollouts[0].Policy.HaltOnError = false
+	s.facade.rollouts[0].UnitsInError = []string{"redis/0"}
+
+	s.runWorker(c, func() {
+		s.waitCall(c, "Rollouts")
+		s.waitCall(c, "AssignRolloutBatch")
+	})
+}
+
+func (s *WorkerSuite) TestCompletesWhenNoUnitsPending(c *gc.C) {
+	s.facade.rollouts[0].LastBatch = s.clock.Now().Add(-time.Hour)
+	s.facade.rollouts[0].UnitsPending = nil
+
+	s.runWorker(c, func() {
+		s.waitCall(c, "Rollouts")
+		s.waitCall(c, "CompleteRollout")
+		s.waitNoCall(c)
+	})
+	s.facade.CheckCall(c, 1, "CompleteRollout", "canary")
+}
+
+func (s *WorkerSuite) TestRolloutsError(c *gc.C) {
+	s.facade.SetErrors(errors.New("boom"))
+
+	w, err := rolloutworker.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *WorkerSuite) config() rolloutworker.Config {
+	return rolloutworker.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+		Period: time.Minute,
+		Logger: loggo.GetLogger("test"),
+	}
+}
+
+func (s *WorkerSuite) runWorker(c *gc.C, test func()) {
+	w, err := rolloutworker.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+	test()
+}
+
+func (s *WorkerSuite) waitCall(c *gc.C, name string) {
+	select {
+	case call := <-s.facade.calls:
+		c.Assert(call, gc.Equals, name)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for %s call", name)
+	}
+}
+
+func (s *WorkerSuite) waitNoCall(c *gc.C) {
+	select {
+	case call := <-s.facade.calls:
+		c.Fatalf("unexpected %s call", call)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+// mockFacade implements branchrollout.Facade, recording calls made to it.
+type mockFacade struct {
+	testing.Stub
+
+	rollouts []branchrollout.Rollout
+	calls    chan string
+}
+
+func (f *mockFacade) Rollouts() ([]branchrollout.Rollout, error) {
+	f.MethodCall(f, "Rollouts")
+	f.calls <- "Rollouts"
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.rollouts, nil
+}
+
+func (f *mockFacade) AssignRolloutBatch(branchName string, unitNames []string) error {
+	f.MethodCall(f, "AssignRolloutBatch", branchName, unitNames)
+	f.calls <- "AssignRolloutBatch"
+	return f.NextErr()
+}
+
+func (f *mockFacade) HaltRollout(branchName, reason string) error {
+	f.MethodCall(f, "HaltRollout", branchName, reason)
+	f.calls <- "HaltRollout"
+	return f.NextErr()
+}
+
+func (f *mockFacade) CompleteRollout(branchName string) error {
+	f.MethodCall(f, "CompleteRollout", branchName)
+	f.calls <- "CompleteRollout"
+	return f.NextErr()
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/utils"
+	"github.com/prometheus/client_golang/prometheus"
 	"gopkg.in/juju/names.v3"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/dependency"
@@ -59,6 +60,10 @@ type ManifoldConfig struct {
 	LoadOperatorInfo func(paths Paths) (*caas.OperatorInfo, error)
 
 	NewContainerStartWatcherClient func(Client) ContainerStartWatcher
+
+	// PrometheusRegisterer, if set, is used to register the metrics
+	// collectors of the operator's uniters.
+	PrometheusRegisterer prometheus.Registerer
 }
 
 func (config ManifoldConfig) Validate() error {
@@ -221,6 +226,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				UpdateStatusSignal:      uniter.NewUpdateStatusTimer(),
 				HookRetryStrategy:       hookRetryStrategy,
 				TranslateResolverErr:    config.TranslateResolverErr,
+				PrometheusRegisterer:    config.PrometheusRegisterer,
 			}
 			wCfg.UniterParams.SocketConfig, err = socketConfig(operatorInfo)
 			if err != nil {
@@ -30,6 +30,10 @@ type applicationWorker struct {
 	applicationUpdater       ApplicationUpdater
 	unitUpdater              UnitUpdater
 
+	// eventsChanges receives when substrate events have been
+	// reported in the model. See notifyEvents.
+	eventsChanges chan struct{}
+
 	logger Logger
 }
 
@@ -53,6 +57,7 @@ func newApplicationWorker(
 		applicationGetter:        applicationGetter,
 		applicationUpdater:       applicationUpdater,
 		unitUpdater:              unitUpdater,
+		eventsChanges:            make(chan struct{}, 1),
 		logger:                   logger,
 	}
 	if err := catacomb.Invoke(catacomb.Plan{
@@ -74,6 +79,16 @@ func (aw *applicationWorker) Wait() error {
 	return aw.catacomb.Wait()
 }
 
+// notifyEvents tells the worker that substrate events have been
+// reported in the model. It never blocks; notifications which
+// arrive while one is already pending are coalesced into it.
+func (aw *applicationWorker) notifyEvents() {
+	select {
+	case aw.eventsChanges <- struct{}{}:
+	default:
+	}
+}
+
 func (aw *applicationWorker) loop() error {
 	deploymentWorker, err := newDeploymentWorker(
 		aw.application,
@@ -113,6 +128,8 @@ func (aw *applicationWorker) loop() error {
 	// so we only report true changes.
 	lastReportedStatus := make(map[string]status.StatusInfo)
 	lastReportedScale := -1
+	// Cache the substrate events already logged.
+	reportedEvents := make(map[eventKey]int32)
 	initialOperatorEvent := true
 	logger := aw.logger
 	for {
@@ -216,6 +233,25 @@ func (aw *applicationWorker) loop() error {
 			if err := aw.clusterChanged(service, lastReportedStatus, true); err != nil {
 				return errors.Trace(err)
 			}
+		case <-aw.eventsChanges:
+			events, err := aw.containerBroker.Events(aw.application)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			// The notification is for events about any workload in
+			// the model, so only refresh the units when ours have news.
+			if !aw.reportEvents(events, reportedEvents) {
+				continue
+			}
+			service, err := aw.serviceBroker.GetService(aw.application, false)
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return errors.Trace(err)
+			}
+			if err := aw.clusterChanged(service, lastReportedStatus, false); err != nil {
+				return errors.Trace(err)
+			}
 		case _, ok := <-appOperatorWatcher.Changes():
 			if !ok {
 				logger.Debugf("%v", appOperatorWatcher.Wait())
@@ -245,6 +281,45 @@ func (aw *applicationWorker) loop() error {
 	}
 }
 
+// eventKey identifies a substrate event, which is
+// reported again each time it recurs.
+type eventKey struct {
+	unitId  string
+	object  string
+	reason  string
+	message string
+}
+
+// reportEvents logs the events which have not been reported before,
+// or have recurred since, so they appear in the model's debug-log.
+// It returns true if any were logged.
+func (aw *applicationWorker) reportEvents(events []caas.Event, reported map[eventKey]int32) bool {
+	var haveNew bool
+	current := make(map[eventKey]bool)
+	for _, evt := range events {
+		key := eventKey{
+			unitId:  evt.UnitId,
+			object:  evt.Object,
+			reason:  evt.Reason,
+			message: evt.Message,
+		}
+		current[key] = true
+		if count, ok := reported[key]; ok && count >= evt.Count {
+			continue
+		}
+		reported[key] = evt.Count
+		haveNew = true
+		aw.logger.Warningf("%s for application %q: %s: %s", evt.Object, aw.application, evt.Reason, evt.Message)
+	}
+	// Forget the events which the substrate has expired.
+	for key := range reported {
+		if !current[key] {
+			delete(reported, key)
+		}
+	}
+	return haveNew
+}
+
 func (aw *applicationWorker) clusterChanged(
 	service *caas.Service,
 	lastReportedStatus map[string]status.StatusInfo,
@@ -17,6 +17,8 @@ type ContainerBroker interface {
 	Units(appName string) ([]caas.Unit, error)
 	WatchOperator(string) (watcher.NotifyWatcher, error)
 	Operator(string) (*caas.Operator, error)
+	WatchEvents() (watcher.NotifyWatcher, error)
+	Events(appName string) ([]caas.Event, error)
 	AnnotateUnit(appName, podName string, unit names.UnitTag) error
 }
 
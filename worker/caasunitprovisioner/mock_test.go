@@ -86,8 +86,10 @@ type mockContainerBroker struct {
 	caas.ContainerEnvironProvider
 	unitsWatcher           *watchertest.MockNotifyWatcher
 	operatorWatcher        *watchertest.MockNotifyWatcher
+	eventsWatcher          *watchertest.MockNotifyWatcher
 	reportedUnitStatus     status.Status
 	reportedOperatorStatus status.Status
+	events                 []caas.Event
 }
 
 func (m *mockContainerBroker) Provider() caas.ContainerEnvironProvider {
@@ -140,6 +142,16 @@ func (m *mockContainerBroker) WatchOperator(appName string) (watcher.NotifyWatch
 	return m.operatorWatcher, m.NextErr()
 }
 
+func (m *mockContainerBroker) WatchEvents() (watcher.NotifyWatcher, error) {
+	m.MethodCall(m, "WatchEvents")
+	return m.eventsWatcher, m.NextErr()
+}
+
+func (m *mockContainerBroker) Events(appName string) ([]caas.Event, error) {
+	m.MethodCall(m, "Events", appName)
+	return m.events, m.NextErr()
+}
+
 func (m *mockContainerBroker) AnnotateUnit(appName string, podName string, unit names.UnitTag) error {
 	m.MethodCall(m, "AnnotateUnit", appName, podName, unit)
 	return m.NextErr()
@@ -11,6 +11,7 @@ import (
 	"gopkg.in/juju/worker.v1/catacomb"
 
 	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/watcher"
 )
 
 // Logger is here to stop the desire of creating a package level Logger.
@@ -116,6 +117,17 @@ func (p *provisioner) deleteApplicationWorker(appName string) {
 	delete(p.appWorkers, appName)
 }
 
+// notifyEvents tells every application worker that substrate
+// events have been reported in the model.
+func (p *provisioner) notifyEvents() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, aw := range p.appWorkers {
+		aw.notifyEvents()
+	}
+}
+
 func (p *provisioner) getApplicationWorker(appName string) (*applicationWorker, bool) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -137,10 +149,36 @@ func (p *provisioner) loop() error {
 		return errors.Trace(err)
 	}
 
+	// One watcher for substrate events serves all the application
+	// workers, rather than each of them watching every event in
+	// the model. The caas watcher can just die from underneath, so
+	// it is stopped with a defer and recreated as needed.
+	var eventsWatcher watcher.NotifyWatcher
+	defer func() {
+		if eventsWatcher != nil {
+			worker.Stop(eventsWatcher)
+		}
+	}()
+
 	for {
+		if eventsWatcher == nil {
+			eventsWatcher, err = p.config.ContainerBroker.WatchEvents()
+			if err != nil {
+				return errors.Annotate(err, "failed to start events watcher")
+			}
+		}
+
 		select {
 		case <-p.catacomb.Dying():
 			return p.catacomb.ErrDying()
+		case _, ok := <-eventsWatcher.Changes():
+			if !ok {
+				logger.Debugf("%v", eventsWatcher.Wait())
+				worker.Stop(eventsWatcher)
+				eventsWatcher = nil
+				continue
+			}
+			p.notifyEvents()
 		case apps, ok := <-w.Changes():
 			if !ok {
 				return errors.New("watcher closed channel")
@@ -51,6 +51,7 @@ type WorkerSuite struct {
 	caasUnitsChanges        chan struct{}
 	caasServiceChanges      chan struct{}
 	caasOperatorChanges     chan struct{}
+	caasEventsChanges       chan struct{}
 	containerSpecChanges    chan struct{}
 	serviceDeleted          chan struct{}
 	serviceEnsured          chan struct{}
@@ -120,6 +121,7 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 	s.caasUnitsChanges = make(chan struct{})
 	s.caasServiceChanges = make(chan struct{})
 	s.caasOperatorChanges = make(chan struct{})
+	s.caasEventsChanges = make(chan struct{})
 	s.containerSpecChanges = make(chan struct{}, 1)
 	s.serviceDeleted = make(chan struct{})
 	s.serviceEnsured = make(chan struct{})
@@ -155,6 +157,7 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 	s.containerBroker = mockContainerBroker{
 		unitsWatcher:    watchertest.NewMockNotifyWatcher(s.caasUnitsChanges),
 		operatorWatcher: watchertest.NewMockNotifyWatcher(s.caasOperatorChanges),
+		eventsWatcher:   watchertest.NewMockNotifyWatcher(s.caasEventsChanges),
 	}
 	s.lifeGetter = mockLifeGetter{}
 	s.lifeGetter.setLife(life.Alive)
@@ -664,11 +667,11 @@ func (s *WorkerSuite) TestUnitsChange(c *gc.C) {
 	defer workertest.CleanKill(c, w)
 
 	for a := coretesting.LongAttempt.Start(); a.Next(); {
-		if len(s.containerBroker.Calls()) >= 2 {
+		if len(s.containerBroker.Calls()) >= 3 {
 			break
 		}
 	}
-	s.containerBroker.CheckCallNames(c, "WatchUnits", "WatchOperator")
+	s.containerBroker.CheckCallNames(c, "WatchEvents", "WatchUnits", "WatchOperator")
 
 	s.assertUnitChange(c, status.Allocating, status.Allocating)
 	s.assertUnitChange(c, status.Allocating, status.Unknown)
@@ -686,11 +689,11 @@ func (s *WorkerSuite) TestOperatorChange(c *gc.C) {
 	}
 
 	for a := coretesting.LongAttempt.Start(); a.Next(); {
-		if len(s.containerBroker.Calls()) >= 2 {
+		if len(s.containerBroker.Calls()) >= 3 {
 			break
 		}
 	}
-	s.containerBroker.CheckCallNames(c, "WatchUnits", "WatchOperator")
+	s.containerBroker.CheckCallNames(c, "WatchEvents", "WatchUnits", "WatchOperator")
 	s.containerBroker.ResetCalls()
 
 	// Initial event
@@ -730,6 +733,117 @@ func (s *WorkerSuite) TestOperatorChange(c *gc.C) {
 	})
 }
 
+func (s *WorkerSuite) TestEventsChange(c *gc.C) {
+	w, err := caasunitprovisioner.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.containerBroker.Calls()) >= 3 {
+			break
+		}
+	}
+	s.containerBroker.CheckCallNames(c, "WatchEvents", "WatchUnits", "WatchOperator")
+	s.containerBroker.ResetCalls()
+	s.serviceBroker.ResetCalls()
+
+	s.containerBroker.reportedUnitStatus = status.Blocked
+	s.containerBroker.events = []caas.Event{{
+		UnitId:  "u1",
+		Object:  "pod/gitlab-0",
+		Reason:  "Failed",
+		Message: `Failed to pull image "gitlab/latest"`,
+		Count:   1,
+	}}
+	select {
+	case s.caasEventsChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending events change")
+	}
+
+	// New events refresh the status of the units.
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.unitUpdater.Calls()) > 0 {
+			break
+		}
+	}
+	s.containerBroker.CheckCallNames(c, "Events", "Units")
+	c.Assert(s.containerBroker.Calls()[0].Args, jc.DeepEquals, []interface{}{"gitlab"})
+	s.serviceBroker.CheckCallNames(c, "GetService")
+	s.unitUpdater.CheckCallNames(c, "UpdateUnits")
+	args := s.unitUpdater.Calls()[0].Args[0].(params.UpdateApplicationUnits)
+	c.Assert(args.Scale, gc.IsNil)
+	c.Assert(args.Units, gc.HasLen, 1)
+	c.Assert(args.Units[0].Status, gc.Equals, "blocked")
+	s.containerBroker.ResetCalls()
+	s.unitUpdater.ResetCalls()
+
+	// Events which have already been reported are ignored.
+	select {
+	case s.caasEventsChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending events change")
+	}
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.containerBroker.Calls()) > 0 {
+			break
+		}
+	}
+	time.Sleep(coretesting.ShortWait)
+	s.containerBroker.CheckCallNames(c, "Events")
+	s.unitUpdater.CheckNoCalls(c)
+}
+
+func (s *WorkerSuite) TestEventsWatcherShared(c *gc.C) {
+	w, err := caasunitprovisioner.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab", "mysql"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.containerBroker.Calls()) >= 5 {
+			break
+		}
+	}
+	// Both application workers use the one events watcher.
+	var watchEvents int
+	for _, call := range s.containerBroker.Calls() {
+		if call.FuncName == "WatchEvents" {
+			watchEvents++
+		}
+	}
+	c.Assert(watchEvents, gc.Equals, 1)
+	s.containerBroker.ResetCalls()
+
+	select {
+	case s.caasEventsChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending events change")
+	}
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.containerBroker.Calls()) >= 2 {
+			break
+		}
+	}
+	s.containerBroker.CheckCallNames(c, "Events", "Events")
+	apps := []interface{}{
+		s.containerBroker.Calls()[0].Args[0],
+		s.containerBroker.Calls()[1].Args[0],
+	}
+	c.Assert(apps, jc.SameContents, []interface{}{"gitlab", "mysql"})
+}
+
 func (s *WorkerSuite) assertUnitChange(c *gc.C, reported, expectedUnitStatus status.Status) {
 	s.containerBroker.ResetCalls()
 	s.unitUpdater.ResetCalls()
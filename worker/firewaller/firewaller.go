@@ -5,6 +5,8 @@ package firewaller
 
 import (
 	"io"
+	"net"
+	"reflect"
 	"strings"
 	"time"
 
@@ -22,6 +24,7 @@ import (
 	"github.com/juju/juju/api/firewaller"
 	"github.com/juju/juju/api/remoterelations"
 	"github.com/juju/juju/apiserver/params"
+	corefirewall "github.com/juju/juju/core/firewall"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/life"
 	corenetwork "github.com/juju/juju/core/network"
@@ -48,6 +51,9 @@ type FirewallerAPI interface {
 	MacaroonForRelation(relationKey string) (*macaroon.Macaroon, error)
 	SetRelationStatus(relationKey string, status relation.Status, message string) error
 	FirewallRules(applicationNames ...string) ([]params.FirewallRule, error)
+	FirewallServiceRules() ([]params.FirewallServiceRule, error)
+	WatchFirewallRules() (watcher.NotifyWatcher, error)
+	WatchSubnetsAndSpaces() (watcher.NotifyWatcher, error)
 }
 
 // CrossModelFirewallerFacade exposes firewaller functionality on the
@@ -124,7 +130,10 @@ func (cfg Config) Validate() error {
 	return nil
 }
 
-type portRanges map[corenetwork.PortRange]bool
+// portRanges maps the port ranges opened by a unit to the endpoints
+// they were opened for. Ranges opened for all endpoints map to the
+// empty string.
+type portRanges map[corenetwork.PortRange]string
 
 // Firewaller watches the state for port ranges opened or closed on
 // machines and reflects those changes onto the backing environment.
@@ -138,6 +147,8 @@ type Firewaller struct {
 
 	machinesWatcher      watcher.StringsWatcher
 	portsWatcher         watcher.StringsWatcher
+	rulesWatcher         watcher.NotifyWatcher
+	subnetsWatcher       watcher.NotifyWatcher
 	machineds            map[names.MachineTag]*machineData
 	unitsChange          chan *unitsChange
 	unitds               map[names.UnitTag]*unitData
@@ -235,6 +246,24 @@ func (fw *Firewaller) setUp() error {
 		return errors.Trace(err)
 	}
 
+	fw.rulesWatcher, err = fw.firewallerApi.WatchFirewallRules()
+	if errors.IsNotSupported(err) {
+		fw.logger.Debugf("controller does not support firewall services")
+	} else if err != nil {
+		return errors.Annotatef(err, "failed to start firewall rules watcher")
+	} else if err := fw.catacomb.Add(fw.rulesWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	fw.subnetsWatcher, err = fw.firewallerApi.WatchSubnetsAndSpaces()
+	if errors.IsNotSupported(err) {
+		fw.logger.Debugf("controller does not support watching subnets and spaces")
+	} else if err != nil {
+		return errors.Annotatef(err, "failed to start subnets watcher")
+	} else if err := fw.catacomb.Add(fw.subnetsWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
 	fw.remoteRelationsWatcher, err = fw.remoteRelationsApi.WatchRemoteRelations()
 	if err != nil {
 		return errors.Trace(err)
@@ -253,6 +282,14 @@ func (fw *Firewaller) loop() error {
 	}
 	var reconciled bool
 	portsChange := fw.portsWatcher.Changes()
+	var rulesChange watcher.NotifyChannel
+	if fw.rulesWatcher != nil {
+		rulesChange = fw.rulesWatcher.Changes()
+	}
+	var subnetsChange watcher.NotifyChannel
+	if fw.subnetsWatcher != nil {
+		subnetsChange = fw.subnetsWatcher.Changes()
+	}
 	for {
 		select {
 		case <-fw.catacomb.Dying():
@@ -291,6 +328,20 @@ func (fw *Firewaller) loop() error {
 					return errors.Trace(err)
 				}
 			}
+		case _, ok := <-rulesChange:
+			if !ok {
+				return errors.New("firewall rules watcher closed")
+			}
+			// The sources allowed access to the ports already opened
+			// may have changed, so every machine is brought up to date.
+			if err := fw.flushAllMachines(); err != nil {
+				return errors.Annotate(err, "cannot change firewall ports")
+			}
+		case _, ok := <-subnetsChange:
+			if !ok {
+				return errors.New("subnets watcher closed")
+			}
+			fw.subnetsChanged()
 		case change, ok := <-fw.remoteRelationsWatcher.Changes():
 			if !ok {
 				return errors.New("remote relations watcher closed")
@@ -313,6 +364,7 @@ func (fw *Firewaller) loop() error {
 			}
 		case change := <-fw.exposedChange:
 			change.applicationd.exposed = change.exposed
+			change.applicationd.exposedEndpoints = change.exposedEndpoints
 			unitds := []*unitData{}
 			for _, unitd := range change.applicationd.unitds {
 				unitds = append(unitds, unitd)
@@ -475,22 +527,24 @@ func (fw *Firewaller) startUnit(unit *firewaller.Unit, machineTag names.MachineT
 // startApplication creates a new data value for tracking details of the
 // application and starts watching the application for exposure changes.
 func (fw *Firewaller) startApplication(app *firewaller.Application) error {
-	exposed, err := app.IsExposed()
+	exposed, exposedEndpoints, err := app.ExposeInfo()
 	if err != nil {
 		return err
 	}
 	applicationd := &applicationData{
-		fw:          fw,
-		application: app,
-		exposed:     exposed,
-		unitds:      make(map[names.UnitTag]*unitData),
+		fw:               fw,
+		application:      app,
+		exposed:          exposed,
+		exposedEndpoints: exposedEndpoints,
+		unitds:           make(map[names.UnitTag]*unitData),
+		subnetsChange:    make(chan struct{}, 1),
 	}
 	fw.applicationids[app.Tag()] = applicationd
 
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &applicationd.catacomb,
 		Work: func() error {
-			return applicationd.watchLoop(exposed)
+			return applicationd.watchLoop(exposed, exposedEndpoints)
 		},
 	})
 	if err != nil {
@@ -671,13 +725,13 @@ func (fw *Firewaller) openedPortsChanged(machineTag names.MachineTag, subnetTag
 	}
 
 	newPortRanges := make(map[names.UnitTag]portRanges)
-	for portRange, unitTag := range ports {
-		unitd, ok := machined.unitds[unitTag]
+	for portRange, opened := range ports {
+		unitd, ok := machined.unitds[opened.Unit]
 		if !ok {
 			// It is common to receive port change notification before
 			// registering a unit. Skip handling the port change - it will
 			// be handled when the unit is registered.
-			fw.logger.Debugf("failed to lookup %q, skipping port change", unitTag)
+			fw.logger.Debugf("failed to lookup %q, skipping port change", opened.Unit)
 			return nil
 		}
 		ranges, ok := newPortRanges[unitd.tag]
@@ -685,7 +739,7 @@ func (fw *Firewaller) openedPortsChanged(machineTag names.MachineTag, subnetTag
 			ranges = make(portRanges)
 			newPortRanges[unitd.tag] = ranges
 		}
-		ranges[portRange] = true
+		ranges[portRange] = opened.Endpoint
 	}
 
 	if !unitPortsEqual(machined.definedPorts, newPortRanges) {
@@ -741,6 +795,32 @@ func (fw *Firewaller) flushUnits(unitds []*unitData) error {
 	return nil
 }
 
+// subnetsChanged asks the applications to refresh their expose settings,
+// as the subnets of the spaces they are exposed to may have changed. Any
+// change is reported as an exposed change by the application's watch
+// loop, which brings its machines up to date. Applications that are not
+// exposed yet are asked too, as the change that exposes them may still
+// be pending.
+func (fw *Firewaller) subnetsChanged() {
+	for _, applicationd := range fw.applicationids {
+		select {
+		case applicationd.subnetsChange <- struct{}{}:
+		default:
+			// A refresh is already pending.
+		}
+	}
+}
+
+// flushAllMachines opens and closes ports for every machine.
+func (fw *Firewaller) flushAllMachines() error {
+	for _, machined := range fw.machineds {
+		if err := fw.flushMachine(machined); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // flushMachine opens and closes ports for the passed machine.
 func (fw *Firewaller) flushMachine(machined *machineData) error {
 	want, err := fw.gatherIngressRules(machined)
@@ -758,7 +838,11 @@ func (fw *Firewaller) flushMachine(machined *machineData) error {
 // gatherIngressRules returns the ingress rules to open and close
 // for the specified machines.
 func (fw *Firewaller) gatherIngressRules(machines ...*machineData) ([]network.IngressRule, error) {
-	var want []network.IngressRule
+	var (
+		want         []network.IngressRule
+		serviceRules []params.FirewallServiceRule
+		haveRules    bool
+	)
 	for _, machined := range machines {
 		for unitTag, portRanges := range machined.definedPorts {
 			unitd, known := machined.unitds[unitTag]
@@ -767,32 +851,94 @@ func (fw *Firewaller) gatherIngressRules(machines ...*machineData) ([]network.In
 				continue
 			}
 
-			cidrs := set.NewStrings()
-			// If the unit is exposed, allow access from everywhere.
-			if unitd.applicationd.exposed {
-				cidrs.Add("0.0.0.0/0")
-			} else {
-				// Not exposed, so add any ingress rules required by remote relations.
-				if err := fw.updateForRemoteRelationIngress(unitd.applicationd.application.Tag(), cidrs); err != nil {
-					return nil, errors.Trace(err)
+			for portRange, endpoint := range portRanges {
+				// If the unit is exposed, allow access from the sources
+				// that the endpoint the range was opened for is exposed to.
+				cidrs := unitd.applicationd.exposedCIDRs(endpoint)
+				if !cidrs.Contains(corenetwork.AllNetworksIPv4CIDR) {
+					// Not exposed to everywhere, so add any ingress rules
+					// required by remote relations.
+					if err := fw.updateForRemoteRelationIngress(unitd.applicationd.application.Tag(), cidrs); err != nil {
+						return nil, errors.Trace(err)
+					}
+					fw.logger.Debugf("CIDRS for %v on %v: %v", portRange, unitTag, cidrs.Values())
 				}
-				fw.logger.Debugf("CIDRS for %v: %v", unitTag, cidrs.Values())
-			}
-			if cidrs.Size() > 0 {
-				for portRange := range portRanges {
-					sourceCidrs := cidrs.SortedValues()
-					rule, err := network.NewIngressRule(portRange.Protocol, portRange.FromPort, portRange.ToPort, sourceCidrs...)
-					if err != nil {
+				if cidrs.Size() == 0 {
+					continue
+				}
+				if !haveRules {
+					var err error
+					if serviceRules, err = fw.firewallerApi.FirewallServiceRules(); err != nil {
 						return nil, errors.Trace(err)
 					}
-					want = append(want, rule)
+					haveRules = true
+				}
+				sourceCidrs := serviceSourceCIDRs(portRange, cidrs, serviceRules)
+				if len(sourceCidrs) == 0 {
+					fw.logger.Debugf("no whitelisted sources for %v on %v", portRange, unitTag)
+					continue
+				}
+				rule, err := network.NewIngressRule(portRange.Protocol, portRange.FromPort, portRange.ToPort, sourceCidrs...)
+				if err != nil {
+					return nil, errors.Trace(err)
 				}
+				want = append(want, rule)
 			}
 		}
 	}
 	return want, nil
 }
 
+// serviceSourceCIDRs returns the source CIDRs allowed access to the input
+// port range. If the port range lies within a service defined by an operator
+// for which there is a firewall rule, access is limited to the sources within
+// the subnets whitelisted by the first such rule.
+func serviceSourceCIDRs(portRange corenetwork.PortRange, cidrs set.Strings, serviceRules []params.FirewallServiceRule) []string {
+	for _, rule := range serviceRules {
+		service := corefirewall.Service{
+			Name: corefirewall.WellKnownServiceType(rule.Service.Name),
+			PortRange: corenetwork.PortRange{
+				Protocol: rule.Service.Protocol,
+				FromPort: rule.Service.FromPort,
+				ToPort:   rule.Service.ToPort,
+			},
+		}
+		if !service.Contains(portRange) {
+			continue
+		}
+		if cidrs.Contains(corenetwork.AllNetworksIPv4CIDR) {
+			return set.NewStrings(rule.WhitelistCIDRS...).SortedValues()
+		}
+		allowed := set.NewStrings()
+		for _, cidr := range cidrs.Values() {
+			for _, whitelisted := range rule.WhitelistCIDRS {
+				if cidrWithin(cidr, whitelisted) {
+					allowed.Add(cidr)
+					break
+				}
+			}
+		}
+		return allowed.SortedValues()
+	}
+	return cidrs.SortedValues()
+}
+
+// cidrWithin returns true if the subnet described by the
+// inner CIDR is part of the subnet described by the outer.
+func cidrWithin(inner, outer string) bool {
+	innerIP, innerNet, err := net.ParseCIDR(inner)
+	if err != nil {
+		return false
+	}
+	_, outerNet, err := net.ParseCIDR(outer)
+	if err != nil {
+		return false
+	}
+	innerOnes, innerBits := innerNet.Mask.Size()
+	outerOnes, outerBits := outerNet.Mask.Size()
+	return innerBits == outerBits && innerOnes >= outerOnes && outerNet.Contains(innerIP)
+}
+
 // TODO(wallyworld) - consider making this configurable.
 const maxAllowedCIDRS = 20
 
@@ -1093,23 +1239,60 @@ type unitData struct {
 	machined     *machineData
 }
 
-// exposedChange contains the changed exposed flag for one specific application.
+// exposedChange contains the changed exposed flag and expose
+// settings for one specific application.
 type exposedChange struct {
-	applicationd *applicationData
-	exposed      bool
+	applicationd     *applicationData
+	exposed          bool
+	exposedEndpoints map[string]params.ExposedEndpoint
 }
 
 // applicationData holds application details and watches exposure changes.
 type applicationData struct {
-	catacomb    catacomb.Catacomb
-	fw          *Firewaller
-	application *firewaller.Application
-	exposed     bool
-	unitds      map[names.UnitTag]*unitData
+	catacomb         catacomb.Catacomb
+	fw               *Firewaller
+	application      *firewaller.Application
+	exposed          bool
+	exposedEndpoints map[string]params.ExposedEndpoint
+	unitds           map[names.UnitTag]*unitData
+
+	// subnetsChange is signalled when the subnets or spaces change,
+	// so that the expose settings are refreshed by the watch loop.
+	subnetsChange chan struct{}
+}
+
+// exposedCIDRs returns the CIDRs that the ports opened for the given
+// endpoint are exposed to, or an empty set if the application is not
+// exposed. Ports opened for an endpoint use the settings of that
+// endpoint, falling back to the settings for all endpoints. Ports
+// opened for all endpoints are exposed to the sources of every
+// exposed endpoint.
+func (ad *applicationData) exposedCIDRs(endpoint string) set.Strings {
+	cidrs := set.NewStrings()
+	if !ad.exposed {
+		return cidrs
+	}
+	if endpoint != "" {
+		exposed, found := ad.exposedEndpoints[endpoint]
+		if !found {
+			exposed = ad.exposedEndpoints[""]
+		}
+		for _, cidr := range exposed.ExposeToCIDRs {
+			cidrs.Add(cidr)
+		}
+		return cidrs
+	}
+	for _, exposed := range ad.exposedEndpoints {
+		for _, cidr := range exposed.ExposeToCIDRs {
+			cidrs.Add(cidr)
+		}
+	}
+	return cidrs
 }
 
-// watchLoop watches the application's exposed flag for changes.
-func (ad *applicationData) watchLoop(exposed bool) error {
+// watchLoop watches the application's exposed flag and expose settings
+// for changes.
+func (ad *applicationData) watchLoop(exposed bool, exposedEndpoints map[string]params.ExposedEndpoint) error {
 	appWatcher, err := ad.application.Watch()
 	if err != nil {
 		if params.IsCodeNotFound(err) {
@@ -1128,26 +1311,31 @@ func (ad *applicationData) watchLoop(exposed bool) error {
 			if !ok {
 				return errors.New("application watcher closed")
 			}
-			change, err := ad.application.IsExposed()
-			if err != nil {
-				if errors.IsNotFound(err) {
-					ad.fw.logger.Debugf("application(%q).IsExposed() returned NotFound: %v", ad.application.Name(), err)
-					return nil
-				}
-				return errors.Trace(err)
-			}
-			if change == exposed {
-				ad.fw.logger.Tracef("application(%q).IsExposed() == %v (unchanged)", ad.application.Name(), exposed)
-				continue
+		case <-ad.subnetsChange:
+			// The CIDRs of the spaces the application is exposed
+			// to may have changed.
+		}
+		change, changedEndpoints, err := ad.application.ExposeInfo()
+		if err != nil {
+			if errors.IsNotFound(err) {
+				ad.fw.logger.Debugf("application(%q).ExposeInfo() returned NotFound: %v", ad.application.Name(), err)
+				return nil
 			}
-			ad.fw.logger.Tracef("application(%q).IsExposed() changed %v => %v", ad.application.Name(), exposed, change)
+			return errors.Trace(err)
+		}
+		if change == exposed && reflect.DeepEqual(changedEndpoints, exposedEndpoints) {
+			ad.fw.logger.Tracef("application(%q).ExposeInfo() == %v, %v (unchanged)", ad.application.Name(), exposed, exposedEndpoints)
+			continue
+		}
+		ad.fw.logger.Tracef("application(%q).ExposeInfo() changed %v, %v => %v, %v",
+			ad.application.Name(), exposed, exposedEndpoints, change, changedEndpoints)
 
-			exposed = change
-			select {
-			case <-ad.catacomb.Dying():
-				return ad.catacomb.ErrDying()
-			case ad.fw.exposedChange <- &exposedChange{ad, change}:
-			}
+		exposed = change
+		exposedEndpoints = changedEndpoints
+		select {
+		case <-ad.catacomb.Dying():
+			return ad.catacomb.ErrDying()
+		case ad.fw.exposedChange <- &exposedChange{ad, change, changedEndpoints}:
 		}
 	}
 }
@@ -540,6 +540,180 @@ func (s *InstanceModeSuite) TestSetClearExposedApplication(c *gc.C) {
 	s.assertPorts(c, inst, m.Id(), nil)
 }
 
+func (s *InstanceModeSuite) TestExposeToCIDRsAndSpaces(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	subnet, err := s.State.AddSubnet(corenetwork.SubnetInfo{CIDR: "10.0.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	space, err := s.State.AddSpace("dmz", "", []string{subnet.ID()}, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"url": {ExposeToCIDRs: []string{"192.168.0.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "192.168.0.0/24"),
+	})
+
+	// Ports opened for all endpoints are accessible from the sources
+	// of all exposed endpoints.
+	err = app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"monitoring-port": {ExposeToSpaceIDs: []string{space.Id()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/24", "192.168.0.0/24"),
+	})
+
+	// Ports opened for an endpoint are only accessible from the
+	// sources that endpoint is exposed to.
+	err = u.OpenPortsForEndpoint("url", "tcp", 443, 443)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.OpenPortsForEndpoint("monitoring-port", "tcp", 8080, 8080)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/24", "192.168.0.0/24"),
+		network.MustNewIngressRule("tcp", 443, 443, "192.168.0.0/24"),
+		network.MustNewIngressRule("tcp", 8080, 8080, "10.0.0.0/24"),
+	})
+
+	// Ports opened for an endpoint without expose settings use the
+	// settings for all endpoints, if any.
+	err = u.OpenPortsForEndpoint("logging-dir", "tcp", 9000, 9000)
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"172.16.0.0/16"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/24", "172.16.0.0/16", "192.168.0.0/24"),
+		network.MustNewIngressRule("tcp", 443, 443, "192.168.0.0/24"),
+		network.MustNewIngressRule("tcp", 8080, 8080, "10.0.0.0/24"),
+		network.MustNewIngressRule("tcp", 9000, 9000, "172.16.0.0/16"),
+	})
+
+	err = app.UnsetExposeSettings([]string{"", "url", "monitoring-port"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), nil)
+}
+
+func (s *InstanceModeSuite) TestExposeToSpaceSubnetsChanged(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	subnet, err := s.State.AddSubnet(corenetwork.SubnetInfo{CIDR: "10.0.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	space, err := s.State.AddSpace("dmz", "", []string{subnet.ID()}, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"": {ExposeToSpaceIDs: []string{space.Id()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/24"),
+	})
+
+	// Adding a subnet to the space makes the ports accessible
+	// from it, without any change to the application.
+	_, err = s.State.AddSubnet(corenetwork.SubnetInfo{CIDR: "10.1.0.0/24", SpaceID: space.Id()})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/24", "10.1.0.0/24"),
+	})
+}
+
+func (s *InstanceModeSuite) TestFirewallServiceRules(c *gc.C) {
+	fwRules := state.NewFirewallRules(s.State)
+	err := fwRules.SaveService(firewall.Service{
+		Name:      "node-exporter",
+		PortRange: corenetwork.PortRange{Protocol: "tcp", FromPort: 9100, ToPort: 9110},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = fwRules.Save(state.NewFirewallRule("node-exporter", []string{"10.0.0.0/8"}))
+	c.Assert(err, jc.ErrorIsNil)
+
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	err = u.OpenPorts("tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.OpenPorts("tcp", 9100, 9100)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Ports within the service are only accessible
+	// from the whitelisted subnets.
+	err = app.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 9100, 9100, "10.0.0.0/8"),
+	})
+
+	// Sources outside the whitelisted subnets are not allowed access.
+	err = app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"10.1.0.0/16", "192.168.0.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.1.0.0/16", "192.168.0.0/24"),
+		network.MustNewIngressRule("tcp", 9100, 9100, "10.1.0.0/16"),
+	})
+}
+
+func (s *InstanceModeSuite) TestFirewallServiceRulesChanged(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	err := u.OpenPorts("tcp", 9100, 9100)
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 9100, 9100, "0.0.0.0/0"),
+	})
+
+	// Defining a service with a rule restricts the ports already
+	// opened, without any other change to the machine.
+	fwRules := state.NewFirewallRules(s.State)
+	err = fwRules.SaveService(firewall.Service{
+		Name:      "node-exporter",
+		PortRange: corenetwork.PortRange{Protocol: "tcp", FromPort: 9100, ToPort: 9110},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = fwRules.Save(state.NewFirewallRule("node-exporter", []string{"10.0.0.0/8"}))
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 9100, 9100, "10.0.0.0/8"),
+	})
+
+	// Removing the service opens the ports to everyone again.
+	err = fwRules.RemoveService("node-exporter")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 9100, 9100, "0.0.0.0/0"),
+	})
+}
+
 func (s *InstanceModeSuite) TestRemoveUnit(c *gc.C) {
 	fw := s.newFirewaller(c)
 	defer statetesting.AssertKillAndWait(c, fw)
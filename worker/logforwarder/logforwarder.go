@@ -41,17 +41,24 @@ type sender interface {
 	Send([]logfwd.Record) error
 }
 
-// TODO(ericsnow) It is likely that eventually we will want to support
-// multiplexing to multiple senders, each in its own goroutine (or worker).
-
 // LogForwarder is a worker that forwards log records from a source
 // to a sender.
 type LogForwarder struct {
-	catacomb  catacomb.Catacomb
-	args      OpenLogForwarderArgs
-	enabledCh chan bool
-	mu        sync.Mutex
-	enabled   bool
+	catacomb catacomb.Catacomb
+	args     OpenLogForwarderArgs
+	mu       sync.Mutex
+	enabled  bool
+
+	// enabledCh is closed when forwarding is enabled, so that each
+	// of the log streams starts reading.
+	enabledCh chan struct{}
+}
+
+// auditSinkName returns the name under which the position in the
+// controller's audit log is tracked for the named sink, so that it is
+// kept apart from the position in the agents' logs.
+func auditSinkName(sink string) string {
+	return sink + "-audit"
 }
 
 // OpenLogForwarderArgs holds the info needed to open a LogForwarder.
@@ -68,6 +75,10 @@ type OpenLogForwarderArgs struct {
 	// Name is the name given to the log sink.
 	Name string
 
+	// SinkConfig is the function that reads the log sink's
+	// configuration from the model config.
+	SinkConfig SinkConfigFn
+
 	// OpenSink is the function that opens the underlying log sink that
 	// will be wrapped.
 	OpenSink LogSinkFn
@@ -79,13 +90,16 @@ type OpenLogForwarderArgs struct {
 	Logger Logger
 }
 
-// processNewConfig acts on a new syslog forward config change.
+// processNewConfig acts on a new log forward config change.
 func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser, error) {
 	lf.mu.Lock()
 	defer lf.mu.Unlock()
 
 	closeExisting := func() error {
-		lf.enabled = false
+		if lf.enabled {
+			lf.enabled = false
+			lf.enabledCh = make(chan struct{})
+		}
 		// If we are already sending, close the current sender.
 		if currentSender != nil {
 			return currentSender.Close()
@@ -94,13 +108,14 @@ func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser,
 	}
 
 	// Get the new config and set up log forwarding if enabled.
-	cfg, ok, err := lf.args.LogForwardConfig.LogForwardConfig()
+	modelCfg, err := lf.args.LogForwardConfig.ModelConfig()
 	if err != nil {
 		closeExisting()
 		return nil, errors.Trace(err)
 	}
-	if !ok || !cfg.Enabled {
-		lf.args.Logger.Infof("config change - log forwarding not enabled")
+	cfg, enabled := lf.args.SinkConfig(modelCfg)
+	if !enabled {
+		lf.args.Logger.Infof("config change - log forwarding to %s not enabled", lf.args.Name)
 		return nil, closeExisting()
 	}
 	// If the config is not valid, we don't want to exit with an error
@@ -121,37 +136,30 @@ func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser,
 		Config:   cfg,
 		Caller:   lf.args.Caller,
 		OpenSink: lf.args.OpenSink,
+		Stop:     lf.catacomb.Dying(),
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	lf.enabledCh <- true
+	lf.args.Logger.Infof("log forward enabled, starting to stream logs to %s sink", lf.args.Name)
+	lf.enabled = true
+	close(lf.enabledCh)
 	return sink, nil
 }
 
-// waitForEnabled returns true if streaming is enabled.
-// Otherwise if blocks and waits for enabled to be true.
-func (lf *LogForwarder) waitForEnabled() (bool, error) {
+// waitForEnabled returns once streaming is enabled, or with
+// tomb.ErrDying if the worker is stopping first.
+func (lf *LogForwarder) waitForEnabled() error {
 	lf.mu.Lock()
-	enabled := lf.enabled
+	enabledCh := lf.enabledCh
 	lf.mu.Unlock()
-	if enabled {
-		return true, nil
-	}
 
 	select {
 	case <-lf.catacomb.Dying():
-		return false, tomb.ErrDying
-	case enabled = <-lf.enabledCh:
-	}
-	lf.mu.Lock()
-	defer lf.mu.Unlock()
-
-	if !lf.enabled && enabled {
-		lf.args.Logger.Infof("log forward enabled, starting to stream logs to syslog sink")
+		return tomb.ErrDying
+	case <-enabledCh:
+		return nil
 	}
-	lf.enabled = enabled
-	return enabled, nil
 }
 
 // NewLogForwarder returns a worker that forwards logs received from
@@ -159,7 +167,7 @@ func (lf *LogForwarder) waitForEnabled() (bool, error) {
 func NewLogForwarder(args OpenLogForwarderArgs) (*LogForwarder, error) {
 	lf := &LogForwarder{
 		args:      args,
-		enabledCh: make(chan bool, 1),
+		enabledCh: make(chan struct{}),
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &lf.catacomb,
@@ -182,43 +190,18 @@ func (lf *LogForwarder) loop() error {
 		return errors.Trace(err)
 	}
 
+	// Agent log records and audit log records are streamed from
+	// the controller separately, and forwarded to the same sink.
 	records := make(chan []logfwd.Record)
-	var stream LogStream
-	go func() {
-		for {
-			enabled, err := lf.waitForEnabled()
-			if err == tomb.ErrDying {
-				return
-			}
-			if !enabled {
-				continue
-			}
-			// Lazily create log streamer if needed.
-			if stream == nil {
-				streamCfg := params.LogStreamConfig{
-					Sink: lf.args.Name,
-					// TODO(wallyworld) - this should be configurable via lf.args.LogForwardConfig
-					MaxLookbackRecords: 100,
-				}
-				stream, err = lf.args.OpenLogStream(lf.args.Caller, streamCfg, lf.args.ControllerUUID)
-				if err != nil {
-					lf.catacomb.Kill(errors.Annotate(err, "creating log stream"))
-					break
-				}
-
-			}
-			rec, err := stream.Next()
-			if err != nil {
-				lf.catacomb.Kill(errors.Annotate(err, "getting next log record"))
-				break
-			}
-			select {
-			case <-lf.catacomb.Dying():
-				return
-			case records <- rec: // Wait until the last one is sent.
-			}
-		}
-	}()
+	go lf.streamRecords(records, params.LogStreamConfig{
+		Sink: lf.args.Name,
+		// TODO(wallyworld) - this should be configurable via lf.args.LogForwardConfig
+		MaxLookbackRecords: 100,
+	})
+	go lf.streamRecords(records, params.LogStreamConfig{
+		Sink:  auditSinkName(lf.args.Name),
+		Audit: true,
+	})
 
 	var sender SendCloser
 	defer func() {
@@ -233,7 +216,7 @@ func (lf *LogForwarder) loop() error {
 			return lf.catacomb.ErrDying()
 		case _, ok := <-configWatcher.Changes():
 			if !ok {
-				return errors.New("syslog configuration watcher closed")
+				return errors.New("log forward configuration watcher closed")
 			}
 			if sender, err = lf.processNewConfig(sender); err != nil {
 				return errors.Trace(err)
@@ -243,12 +226,48 @@ func (lf *LogForwarder) loop() error {
 				continue
 			}
 			if err := sender.Send(rec); err != nil {
+				select {
+				case <-lf.catacomb.Dying():
+					// The sink gave up sending because we are stopping.
+					return lf.catacomb.ErrDying()
+				default:
+				}
 				return errors.Trace(err)
 			}
 		}
 	}
 }
 
+// streamRecords reads records from the log stream described by cfg
+// once forwarding is enabled, and passes them on to be sent.
+func (lf *LogForwarder) streamRecords(records chan<- []logfwd.Record, cfg params.LogStreamConfig) {
+	var stream LogStream
+	for {
+		if err := lf.waitForEnabled(); err == tomb.ErrDying {
+			return
+		}
+		// Lazily create log streamer if needed.
+		if stream == nil {
+			var err error
+			stream, err = lf.args.OpenLogStream(lf.args.Caller, cfg, lf.args.ControllerUUID)
+			if err != nil {
+				lf.catacomb.Kill(errors.Annotate(err, "creating log stream"))
+				return
+			}
+		}
+		rec, err := stream.Next()
+		if err != nil {
+			lf.catacomb.Kill(errors.Annotate(err, "getting next log record"))
+			return
+		}
+		select {
+		case <-lf.catacomb.Dying():
+			return
+		case records <- rec: // Wait until the last one is sent.
+		}
+	}
+}
+
 // Kill implements Worker.Kill()
 func (lf *LogForwarder) Kill() {
 	lf.catacomb.Kill(nil)
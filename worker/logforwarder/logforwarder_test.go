@@ -11,11 +11,13 @@ import (
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
 	"gopkg.in/juju/worker.v1/workertest"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/logfwd"
 	"github.com/juju/juju/logfwd/syslog"
 	coretesting "github.com/juju/juju/testing"
@@ -26,9 +28,11 @@ import (
 type LogForwarderSuite struct {
 	testing.IsolationSuite
 
-	stream *stubStream
-	sender *stubSender
-	rec    logfwd.Record
+	stream      *stubStream
+	auditStream *stubStream
+	sender      *stubSender
+	caller      *mockCaller
+	rec         logfwd.Record
 }
 
 var _ = gc.Suite(&LogForwarderSuite{})
@@ -37,7 +41,9 @@ func (s *LogForwarderSuite) SetUpTest(c *gc.C) {
 	s.IsolationSuite.SetUpTest(c)
 
 	s.stream = newStubStream()
+	s.auditStream = newStubStream()
 	s.sender = newStubSender()
+	s.caller = &mockCaller{}
 	s.rec = logfwd.Record{
 		Origin: logfwd.Origin{
 			ControllerUUID: "feebdaed-2f18-4fd2-967d-db9663db7bea",
@@ -82,18 +88,28 @@ func (s *LogForwarderSuite) newLogForwarderArgsWithAPI(
 	sender *stubSender,
 ) logforwarder.OpenLogForwarderArgs {
 	return logforwarder.OpenLogForwarderArgs{
-		Caller:           &mockCaller{},
+		Name:             "syslog",
+		Caller:           s.caller,
 		LogForwardConfig: configAPI,
 		ControllerUUID:   "feebdaed-2f18-4fd2-967d-db9663db7bea",
-		OpenSink: func(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
-			sender.host = cfg.Host
+		SinkConfig: func(cfg *config.Config) (logforwarder.SinkConfig, bool) {
+			lfCfg, ok := cfg.LogFwdSyslog()
+			return lfCfg, ok && lfCfg.Enabled
+		},
+		OpenSink: func(cfg logforwarder.SinkConfig, _ <-chan struct{}) (*logforwarder.LogSink, error) {
+			sender.host = cfg.(*syslog.RawConfig).Host
 			sink := &logforwarder.LogSink{
 				sender,
 			}
 			return sink, nil
 		},
-		OpenLogStream: func(_ base.APICaller, _ params.LogStreamConfig, controllerUUID string) (logforwarder.LogStream, error) {
+		OpenLogStream: func(_ base.APICaller, cfg params.LogStreamConfig, controllerUUID string) (logforwarder.LogStream, error) {
 			c.Assert(controllerUUID, gc.Equals, "feebdaed-2f18-4fd2-967d-db9663db7bea")
+			if cfg.Audit {
+				c.Assert(cfg.Sink, gc.Equals, "syslog-audit")
+				return s.auditStream, nil
+			}
+			c.Assert(cfg.Sink, gc.Equals, "syslog")
 			return stream, nil
 		},
 		Logger: loggo.GetLogger("test"),
@@ -114,6 +130,43 @@ func (s *LogForwarderSuite) TestOne(c *gc.C) {
 	})
 }
 
+func (s *LogForwarderSuite) TestAuditRecords(c *gc.C) {
+	rec0 := s.rec
+	rec1 := s.rec
+	rec1.ID = 11
+	rec1.Origin = logfwd.OriginForAudit(
+		names.NewUserTag("bob"), rec0.Origin.ControllerUUID, rec0.Origin.ModelUUID, version.Current)
+	rec1.Location = logfwd.SourceLocation{Module: "juju.apiserver.auditlog", Line: -1}
+
+	lf, err := logforwarder.NewLogForwarder(s.newLogForwarderArgs(c, s.stream, s.sender))
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, lf)
+
+	s.stream.addRecords(c, rec0)
+	s.sender.waitForSend(c)
+	s.auditStream.addRecords(c, rec1)
+	s.sender.waitForSend(c)
+	workertest.CleanKill(c, lf)
+
+	rec0.Message = "send to 10.0.0.1"
+	rec1.Message = "send to 10.0.0.1"
+	s.sender.stub.CheckCalls(c, []testing.StubCall{
+		{"Send", []interface{}{[]logfwd.Record{rec0}}},
+		{"Send", []interface{}{[]logfwd.Record{rec1}}},
+		{"Close", nil},
+	})
+
+	// The positions in the agents' logs and the audit log are
+	// tracked separately.
+	var sinks []string
+	for _, call := range s.caller.Calls() {
+		args := call.Args[0].(params.LogForwardingSetLastSentParams)
+		c.Assert(args.Params, gc.HasLen, 1)
+		sinks = append(sinks, args.Params[0].Sink)
+	}
+	c.Check(sinks, jc.DeepEquals, []string{"syslog", "syslog-audit"})
+}
+
 func (s *LogForwarderSuite) TestConfigChange(c *gc.C) {
 	rec0 := s.rec
 	rec1 := s.rec
@@ -163,6 +216,7 @@ func (s *LogForwarderSuite) TestNotEnabled(c *gc.C) {
 	// There should be no stream or sender activity when log
 	// forwarding is disabled.
 	s.stream.stub.CheckCallNames(c)
+	s.auditStream.stub.CheckCallNames(c)
 	s.sender.stub.CheckCallNames(c)
 }
 
@@ -231,9 +285,13 @@ func (*mockWatcher) Wait() error {
 
 type mockCaller struct {
 	base.APICaller
+	testing.Stub
 }
 
-func (*mockCaller) APICall(objType string, version int, id, request string, params, response interface{}) error {
+func (c *mockCaller) APICall(objType string, version int, id, request string, params, response interface{}) error {
+	if request == "SetLastSent" {
+		c.MethodCall(c, request, params)
+	}
 	return nil
 }
 
@@ -249,14 +307,14 @@ func (c *mockLogForwardConfig) WatchForLogForwardConfigChanges() (watcher.Notify
 	}, nil
 }
 
-func (c *mockLogForwardConfig) LogForwardConfig() (*syslog.RawConfig, bool, error) {
-	return &syslog.RawConfig{
-		Enabled:    c.enabled,
-		Host:       c.host,
-		CACert:     coretesting.CACert,
-		ClientCert: coretesting.ServerCert,
-		ClientKey:  coretesting.ServerKey,
-	}, true, nil
+func (c *mockLogForwardConfig) ModelConfig() (*config.Config, error) {
+	return config.New(config.UseDefaults, coretesting.FakeConfig().Merge(coretesting.Attrs{
+		"logforward-enabled": c.enabled,
+		"syslog-host":        c.host,
+		"syslog-ca-cert":     coretesting.CACert,
+		"syslog-client-cert": coretesting.ServerCert,
+		"syslog-client-key":  coretesting.ServerKey,
+	}))
 }
 
 type stubStream struct {
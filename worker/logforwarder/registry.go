@@ -0,0 +1,53 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder
+
+import (
+	"fmt"
+	"sync"
+)
+
+var registry = struct {
+	mu    sync.Mutex
+	sinks []LogSinkSpec
+}{}
+
+// RegisterSink registers a kind of log sink, so that records are
+// forwarded to it by the log forwarding worker whenever it is enabled
+// in the model config. It panics if a sink with the same name has
+// already been registered. The returned function unregisters the sink.
+func RegisterSink(spec LogSinkSpec) (unregister func()) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	for _, existing := range registry.sinks {
+		if existing.Name == spec.Name {
+			panic(fmt.Errorf("juju: duplicate log sink name %q", spec.Name))
+		}
+	}
+	registry.sinks = append(registry.sinks, spec)
+	return func() {
+		unregisterSink(spec.Name)
+	}
+}
+
+func unregisterSink(name string) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	for i, spec := range registry.sinks {
+		if spec.Name == name {
+			registry.sinks = append(registry.sinks[:i:i], registry.sinks[i+1:]...)
+			return
+		}
+	}
+}
+
+// RegisteredSinks returns the log sinks that have been registered, in
+// the order they were registered.
+func RegisteredSinks() []LogSinkSpec {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	sinks := make([]LogSinkSpec, len(registry.sinks))
+	copy(sinks, registry.sinks)
+	return sinks
+}
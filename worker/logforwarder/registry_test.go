@@ -0,0 +1,46 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder_test
+
+import (
+	"github.com/juju/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+type RegistrySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&RegistrySuite{})
+
+func (s *RegistrySuite) sinkNames() []string {
+	var names []string
+	for _, spec := range logforwarder.RegisteredSinks() {
+		names = append(names, spec.Name)
+	}
+	return names
+}
+
+func (s *RegistrySuite) TestRegisterSink(c *gc.C) {
+	before := s.sinkNames()
+
+	unregister1 := logforwarder.RegisterSink(logforwarder.LogSinkSpec{Name: "test-sink-1"})
+	defer unregister1()
+	unregister2 := logforwarder.RegisterSink(logforwarder.LogSinkSpec{Name: "test-sink-2"})
+	c.Check(s.sinkNames(), gc.DeepEquals, append(before, "test-sink-1", "test-sink-2"))
+
+	unregister2()
+	c.Check(s.sinkNames(), gc.DeepEquals, append(before, "test-sink-1"))
+}
+
+func (s *RegistrySuite) TestRegisterSinkDuplicate(c *gc.C) {
+	unregister := logforwarder.RegisterSink(logforwarder.LogSinkSpec{Name: "test-sink"})
+	defer unregister()
+
+	c.Check(func() {
+		logforwarder.RegisterSink(logforwarder.LogSinkSpec{Name: "test-sink"})
+	}, gc.PanicMatches, `juju: duplicate log sink name "test-sink"`)
+}
@@ -5,7 +5,7 @@ package logforwarder
 
 import (
 	"github.com/juju/juju/core/watcher"
-	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/environs/config"
 )
 
 // LogForwardConfig provides access to the log forwarding config for a model.
@@ -14,20 +14,41 @@ type LogForwardConfig interface {
 	// log forward configuration to change.
 	WatchForLogForwardConfigChanges() (watcher.NotifyWatcher, error)
 
-	// LogForwardConfig returns the current log forward configuration.
-	LogForwardConfig() (*syslog.RawConfig, bool, error)
+	// ModelConfig returns the current model configuration, from which
+	// each log sink reads its configuration.
+	ModelConfig() (*config.Config, error)
 }
 
+// SinkConfig is the configuration of a single log sink.
+type SinkConfig interface {
+	// Validate returns an error if the config is not valid.
+	Validate() error
+}
+
+// SinkConfigFn is a function that extracts a log sink's configuration
+// from the model config. It also returns whether forwarding to the
+// sink is enabled.
+type SinkConfigFn func(*config.Config) (SinkConfig, bool)
+
+// LogSinkSpec describes a kind of log sink to which records may be
+// forwarded.
 type LogSinkSpec struct {
-	// Name is the name of the log sink.
+	// Name is the name of the log sink. It is also used to track
+	// the records that have been forwarded to the sink, so it must
+	// not change between releases.
 	Name string
 
+	// Config is a function that reads the log sink's configuration.
+	Config SinkConfigFn
+
 	// OpenFn is a function that opens a log sink.
 	OpenFn LogSinkFn
 }
 
-// LogSinkFn is a function that opens a log sink.
-type LogSinkFn func(cfg *syslog.RawConfig) (*LogSink, error)
+// LogSinkFn is a function that opens a log sink. The stop channel is
+// closed when the worker using the sink is stopping, so that the sink
+// can give up on any records it is waiting to send.
+type LogSinkFn func(cfg SinkConfig, stop <-chan struct{}) (*LogSink, error)
 
 // LogSink is a single log sink, to which log records may be sent.
 type LogSink struct {
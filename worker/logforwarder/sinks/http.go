@@ -0,0 +1,54 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/logfwd/httpjson"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// HTTPSinkName is the name of the HTTP log sink.
+const HTTPSinkName = "juju-log-forward-http"
+
+func init() {
+	logforwarder.RegisterSink(logforwarder.LogSinkSpec{
+		Name:   HTTPSinkName,
+		Config: HTTPConfig,
+		OpenFn: OpenHTTP,
+	})
+}
+
+// HTTPConfig returns the HTTP log forwarding config from the model
+// config, and whether forwarding to the HTTP endpoint is enabled.
+func HTTPConfig(modelCfg *config.Config) (logforwarder.SinkConfig, bool) {
+	cfg, ok := modelCfg.LogFwdHTTP()
+	if !ok {
+		return nil, false
+	}
+	return cfg, cfg.Enabled
+}
+
+// OpenHTTP returns a sink that posts the log records it receives to
+// an HTTP endpoint as JSON, giving up on retrying a failed post once
+// stop is closed.
+func OpenHTTP(sinkCfg logforwarder.SinkConfig, stop <-chan struct{}) (*logforwarder.LogSink, error) {
+	cfg, ok := sinkCfg.(*httpjson.RawConfig)
+	if !ok {
+		return nil, errors.Errorf("expected HTTP log forwarding config, got %T", sinkCfg)
+	}
+	if !cfg.Enabled {
+		return nil, errors.New("HTTP log forwarding not enabled")
+	}
+	client, err := httpjson.Open(*cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	client.Stop = stop
+	return &logforwarder.LogSink{
+		SendCloser: client,
+	}, nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/httpjson"
+	"github.com/juju/juju/logfwd/syslog"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/logforwarder"
+	"github.com/juju/juju/worker/logforwarder/sinks"
+)
+
+type SinksSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SinksSuite{})
+
+func (s *SinksSuite) TestRegistered(c *gc.C) {
+	var names []string
+	for _, spec := range logforwarder.RegisteredSinks() {
+		names = append(names, spec.Name)
+	}
+	c.Assert(names, jc.SameContents, []string{"juju-log-forward", "juju-log-forward-http"})
+}
+
+func (s *SinksSuite) TestSyslogConfig(c *gc.C) {
+	modelCfg := coretesting.CustomModelConfig(c, coretesting.Attrs{
+		"logforward-enabled": true,
+		"syslog-host":        "10.0.0.1:6514",
+	})
+	cfg, enabled := sinks.SyslogConfig(modelCfg)
+	c.Assert(enabled, jc.IsTrue)
+	c.Assert(cfg, jc.DeepEquals, &syslog.RawConfig{
+		Enabled: true,
+		Host:    "10.0.0.1:6514",
+	})
+}
+
+func (s *SinksSuite) TestSyslogConfigNotEnabled(c *gc.C) {
+	modelCfg := coretesting.CustomModelConfig(c, coretesting.Attrs{
+		"syslog-host": "10.0.0.1:6514",
+	})
+	_, enabled := sinks.SyslogConfig(modelCfg)
+	c.Assert(enabled, jc.IsFalse)
+
+	_, enabled = sinks.SyslogConfig(coretesting.ModelConfig(c))
+	c.Assert(enabled, jc.IsFalse)
+}
+
+func (s *SinksSuite) TestHTTPConfig(c *gc.C) {
+	modelCfg := coretesting.CustomModelConfig(c, coretesting.Attrs{
+		"logforward-http-enabled":    true,
+		"logforward-http-url":        "https://es.example.com:9200/juju/_bulk",
+		"logforward-http-username":   "bob",
+		"logforward-http-password":   "secret",
+		"logforward-http-format":     "elasticsearch",
+		"logforward-http-batch-size": 50,
+	})
+	cfg, enabled := sinks.HTTPConfig(modelCfg)
+	c.Assert(enabled, jc.IsTrue)
+	c.Assert(cfg, jc.DeepEquals, &httpjson.RawConfig{
+		Enabled:   true,
+		URL:       "https://es.example.com:9200/juju/_bulk",
+		Username:  "bob",
+		Password:  "secret",
+		Format:    "elasticsearch",
+		BatchSize: 50,
+	})
+}
+
+func (s *SinksSuite) TestHTTPConfigNotEnabled(c *gc.C) {
+	_, enabled := sinks.HTTPConfig(coretesting.ModelConfig(c))
+	c.Assert(enabled, jc.IsFalse)
+}
+
+func (s *SinksSuite) TestOpenHTTPNotEnabled(c *gc.C) {
+	_, err := sinks.OpenHTTP(&httpjson.RawConfig{URL: "http://localhost:3100"}, nil)
+	c.Assert(err, gc.ErrorMatches, "HTTP log forwarding not enabled")
+}
+
+func (s *SinksSuite) TestOpenHTTPWrongConfig(c *gc.C) {
+	_, err := sinks.OpenHTTP(&syslog.RawConfig{Enabled: true}, nil)
+	c.Assert(err, gc.ErrorMatches, `expected HTTP log forwarding config, got \*syslog.RawConfig`)
+}
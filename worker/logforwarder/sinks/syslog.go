@@ -6,13 +6,39 @@ package sinks
 import (
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/logfwd"
 	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/worker/logforwarder"
 )
 
+// SyslogSinkName is the name of the syslog log sink.
+const SyslogSinkName = "juju-log-forward"
+
+func init() {
+	logforwarder.RegisterSink(logforwarder.LogSinkSpec{
+		Name:   SyslogSinkName,
+		Config: SyslogConfig,
+		OpenFn: OpenSyslog,
+	})
+}
+
+// SyslogConfig returns the syslog forwarding config from the model
+// config, and whether syslog forwarding is enabled.
+func SyslogConfig(modelCfg *config.Config) (logforwarder.SinkConfig, bool) {
+	cfg, ok := modelCfg.LogFwdSyslog()
+	if !ok {
+		return nil, false
+	}
+	return cfg, cfg.Enabled
+}
+
 // OpenSyslog returns a sink used to receive log messages to be forwarded.
-func OpenSyslog(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
+func OpenSyslog(sinkCfg logforwarder.SinkConfig, _ <-chan struct{}) (*logforwarder.LogSink, error) {
+	cfg, ok := sinkCfg.(*syslog.RawConfig)
+	if !ok {
+		return nil, errors.Errorf("expected syslog config, got %T", sinkCfg)
+	}
 	if !cfg.Enabled {
 		return nil, errors.New("log forwarding not enabled")
 	}
@@ -10,13 +10,12 @@ import (
 	"github.com/juju/juju/api/base"
 	logfwdapi "github.com/juju/juju/api/logfwd"
 	"github.com/juju/juju/logfwd"
-	"github.com/juju/juju/logfwd/syslog"
 )
 
 // TrackingSinkArgs holds the args to OpenTrackingSender.
 type TrackingSinkArgs struct {
 	// Config is the logging config that will be used.
-	Config *syslog.RawConfig
+	Config SinkConfig
 
 	// Caller is the API caller that will be used.
 	Caller base.APICaller
@@ -27,12 +26,15 @@ type TrackingSinkArgs struct {
 	// OpenSink is the function that opens the underlying log sink that
 	// will be wrapped.
 	OpenSink LogSinkFn
+
+	// Stop is closed when the worker using the sink is stopping.
+	Stop <-chan struct{}
 }
 
 // OpenTrackingSink opens a log record sender to use with a worker.
 // The sender also tracks records that were successfully sent.
 func OpenTrackingSink(args TrackingSinkArgs) (*LogSink, error) {
-	sink, err := args.OpenSink(args.Config)
+	sink, err := args.OpenSink(args.Config, args.Stop)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -88,10 +90,16 @@ func (lst lastSentTracker) setLastSent(records []logfwd.Record) error {
 		return errors.Errorf("bad model UUID %q", model)
 	}
 	modelTag := names.NewModelTag(model)
+	// Each batch comes from a single log stream, and the position in
+	// the audit log is tracked apart from the agents' logs.
+	sink := lst.sink
+	if rec.Origin.Type == logfwd.OriginTypeAudit {
+		sink = auditSinkName(sink)
+	}
 	results, err := lst.client.SetLastSent([]logfwdapi.LastSentInfo{{
 		LastSentID: logfwdapi.LastSentID{
 			Model: modelTag,
-			Sink:  lst.sink,
+			Sink:  sink,
 		},
 		RecordID:        rec.ID,
 		RecordTimestamp: rec.Timestamp,
@@ -12,6 +12,8 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/deque"
+
+	"github.com/juju/juju/core/logfields"
 )
 
 // LogRecord represents a log message in an agent which is to be
@@ -23,6 +25,9 @@ type LogRecord struct {
 	Level    loggo.Level
 	Message  string
 
+	// Fields holds the structured fields attached to the message.
+	Fields logfields.Fields
+
 	// Number of messages dropped after this one due to buffer limit.
 	DroppedAfter int
 }
@@ -146,13 +151,17 @@ func (w *BufferedLogWriter) loop() {
 }
 
 // Write sends a new log message to the writer. This implements the loggo.Writer interface.
+// Any fields attached to the message by a logfields.Logger are split out
+// into the record's Fields.
 func (w *BufferedLogWriter) Write(entry loggo.Entry) {
+	message, fields := logfields.Parse(entry.Message)
 	w.in <- &LogRecord{
 		Time:     entry.Timestamp,
 		Module:   entry.Module,
 		Location: fmt.Sprintf("%s:%d", filepath.Base(entry.Filename), entry.Line),
 		Level:    entry.Level,
-		Message:  entry.Message,
+		Message:  message,
+		Fields:   fields,
 	}
 }
 
@@ -12,6 +12,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/core/logfields"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/logsender"
 	"github.com/juju/juju/worker/logsender/logsendertest"
@@ -77,6 +78,30 @@ func (s *bufferedLogWriterSuite) TestBuffering(c *gc.C) {
 	}
 }
 
+func (s *bufferedLogWriterSuite) TestFields(c *gc.C) {
+	now := time.Now()
+	s.writer.Write(loggo.Entry{
+		Level:     loggo.ERROR,
+		Module:    "juju.worker.uniter.operation",
+		Filename:  "runhook.go",
+		Line:      132,
+		Timestamp: now,
+		Message:   `hook "install" failed [fields: hook=install unit=mysql/0]`,
+	})
+
+	c.Assert(*s.receiveOne(c), jc.DeepEquals, logsender.LogRecord{
+		Time:     now,
+		Module:   "juju.worker.uniter.operation",
+		Location: "runhook.go:132",
+		Level:    loggo.ERROR,
+		Message:  `hook "install" failed`,
+		Fields: logfields.Fields{
+			"hook": "install",
+			"unit": "mysql/0",
+		},
+	})
+}
+
 func (s *bufferedLogWriterSuite) TestLimiting(c *gc.C) {
 	write := func(msgNum int) {
 		s.writer.Write(
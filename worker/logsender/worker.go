@@ -64,6 +64,7 @@ func New(logs LogRecordCh, logSenderAPI *logsender.API) worker.Worker {
 					Location: rec.Location,
 					Level:    rec.Level.String(),
 					Message:  rec.Message,
+					Fields:   rec.Fields,
 				})
 				if err != nil {
 					return errors.Trace(err)
@@ -611,6 +611,7 @@ func (w *Worker) transferLogs(targetInfo coremigration.TargetInfo, modelUUID str
 				Location: msg.Location,
 				Level:    msg.Severity,
 				Message:  msg.Message,
+				Fields:   msg.Fields,
 			})
 			if err != nil {
 				return errors.Trace(err)
@@ -513,6 +513,8 @@ func (c *cacheWorker) translateBranch(d multiwatcher.Delta) interface{} {
 		Id:            value.ID,
 		AssignedUnits: value.AssignedUnits,
 		Config:        coreItemChanges(value.Config),
+		CharmURLs:     value.CharmURLs,
+		Resources:     value.Resources,
 		Created:       value.Created,
 		CreatedBy:     value.CreatedBy,
 		Completed:     value.Completed,
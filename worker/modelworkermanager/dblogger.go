@@ -12,6 +12,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 
+	"github.com/juju/juju/core/logfields"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/logdb"
 )
@@ -51,13 +52,15 @@ type dbLogger struct {
 }
 
 func (l *dbLogger) Write(entry loggo.Entry) {
+	message, fields := logfields.Parse(entry.Message)
 	err := l.buffer.Log([]state.LogRecord{{
 		Time:     entry.Timestamp,
 		Entity:   l.name,
 		Module:   entry.Module,
 		Location: fmt.Sprintf("%s:%d", filepath.Base(entry.Filename), entry.Line),
 		Level:    entry.Level,
-		Message:  entry.Message,
+		Message:  message,
+		Fields:   fields,
 	}})
 
 	if err != nil {
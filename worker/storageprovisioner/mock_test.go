@@ -137,6 +137,8 @@ func (w *mockAttachmentPlansWatcher) Changes() watcher.MachineStorageIdsChannel
 
 type mockVolumeAccessor struct {
 	volumesWatcher         *mockStringsWatcher
+	volumeResizesWatcher   *mockStringsWatcher
+	snapshotsWatcher       *mockStringsWatcher
 	attachmentsWatcher     *mockAttachmentsWatcher
 	attachmentPlansWatcher *mockAttachmentPlansWatcher
 	blockDevicesWatcher    *mockNotifyWatcher
@@ -144,8 +146,14 @@ type mockVolumeAccessor struct {
 	provisionedVolumes     map[string]params.Volume
 	provisionedAttachments map[params.MachineStorageId]params.VolumeAttachment
 	blockDevices           map[params.MachineStorageId]storage.BlockDevice
+	requestedSizes         map[string]uint64
+	snapshots              map[string]params.VolumeSnapshotParams
 
 	setVolumeInfo               func([]params.Volume) ([]params.ErrorResult, error)
+	cancelVolumeResizes         func([]names.VolumeTag) ([]params.ErrorResult, error)
+	setVolumeSnapshotInfo       func([]params.VolumeSnapshot) ([]params.ErrorResult, error)
+	setVolumeSnapshotRestored   func([]params.VolumeSnapshotRestore) ([]params.ErrorResult, error)
+	removeVolumeSnapshots       func([]string) ([]params.ErrorResult, error)
 	setVolumeAttachmentInfo     func([]params.VolumeAttachment) ([]params.ErrorResult, error)
 	createVolumeAttachmentPlans func([]params.VolumeAttachmentPlan) ([]params.ErrorResult, error)
 }
@@ -165,6 +173,14 @@ func (w *mockVolumeAccessor) WatchVolumes(names.Tag) (watcher.StringsWatcher, er
 	return w.volumesWatcher, nil
 }
 
+func (w *mockVolumeAccessor) WatchVolumeResizes(names.Tag) (watcher.StringsWatcher, error) {
+	return w.volumeResizesWatcher, nil
+}
+
+func (w *mockVolumeAccessor) WatchVolumeSnapshots(names.Tag) (watcher.StringsWatcher, error) {
+	return w.snapshotsWatcher, nil
+}
+
 func (w *mockVolumeAccessor) WatchVolumeAttachments(names.Tag) (watcher.MachineStorageIdsWatcher, error) {
 	return w.attachmentsWatcher, nil
 }
@@ -267,6 +283,51 @@ func (v *mockVolumeAccessor) RemoveVolumeParams(volumes []names.VolumeTag) ([]pa
 	return result, nil
 }
 
+func (v *mockVolumeAccessor) CancelVolumeResizes(volumes []names.VolumeTag) ([]params.ErrorResult, error) {
+	if v.cancelVolumeResizes != nil {
+		return v.cancelVolumeResizes(volumes)
+	}
+	for _, tag := range volumes {
+		delete(v.requestedSizes, tag.String())
+	}
+	return make([]params.ErrorResult, len(volumes)), nil
+}
+
+func (v *mockVolumeAccessor) VolumeResizeParams(volumes []names.VolumeTag) ([]params.VolumeResizeParamsResult, error) {
+	var result []params.VolumeResizeParamsResult
+	for _, tag := range volumes {
+		size, ok := v.requestedSizes[tag.String()]
+		if !ok {
+			result = append(result, params.VolumeResizeParamsResult{
+				Error: &params.Error{Code: params.CodeNotFound},
+			})
+			continue
+		}
+		result = append(result, params.VolumeResizeParamsResult{Result: params.VolumeResizeParams{
+			VolumeTag: tag.String(),
+			VolumeId:  v.provisionedVolumes[tag.String()].Info.VolumeId,
+			Provider:  "dummy",
+			Size:      size,
+		}})
+	}
+	return result, nil
+}
+
+func (v *mockVolumeAccessor) VolumeSnapshotParams(snapshotNames []string) ([]params.VolumeSnapshotParamsResult, error) {
+	var result []params.VolumeSnapshotParamsResult
+	for _, name := range snapshotNames {
+		snapshot, ok := v.snapshots[name]
+		if !ok {
+			result = append(result, params.VolumeSnapshotParamsResult{
+				Error: &params.Error{Code: params.CodeNotFound},
+			})
+			continue
+		}
+		result = append(result, params.VolumeSnapshotParamsResult{Result: snapshot})
+	}
+	return result, nil
+}
+
 func (v *mockVolumeAccessor) VolumeAttachmentParams(ids []params.MachineStorageId) ([]params.VolumeAttachmentParamsResult, error) {
 	var result []params.VolumeAttachmentParamsResult
 	for _, id := range ids {
@@ -291,6 +352,27 @@ func (v *mockVolumeAccessor) SetVolumeInfo(volumes []params.Volume) ([]params.Er
 	return make([]params.ErrorResult, len(volumes)), nil
 }
 
+func (v *mockVolumeAccessor) SetVolumeSnapshotInfo(snapshots []params.VolumeSnapshot) ([]params.ErrorResult, error) {
+	if v.setVolumeSnapshotInfo != nil {
+		return v.setVolumeSnapshotInfo(snapshots)
+	}
+	return make([]params.ErrorResult, len(snapshots)), nil
+}
+
+func (v *mockVolumeAccessor) SetVolumeSnapshotRestored(restores []params.VolumeSnapshotRestore) ([]params.ErrorResult, error) {
+	if v.setVolumeSnapshotRestored != nil {
+		return v.setVolumeSnapshotRestored(restores)
+	}
+	return make([]params.ErrorResult, len(restores)), nil
+}
+
+func (v *mockVolumeAccessor) RemoveVolumeSnapshots(snapshotNames []string) ([]params.ErrorResult, error) {
+	if v.removeVolumeSnapshots != nil {
+		return v.removeVolumeSnapshots(snapshotNames)
+	}
+	return make([]params.ErrorResult, len(snapshotNames)), nil
+}
+
 func (v *mockVolumeAccessor) SetVolumeAttachmentInfo(volumeAttachments []params.VolumeAttachment) ([]params.ErrorResult, error) {
 	if v.setVolumeAttachmentInfo != nil {
 		return v.setVolumeAttachmentInfo(volumeAttachments)
@@ -320,6 +402,8 @@ func (v *mockVolumeAccessor) VolumeAttachmentPlans([]params.MachineStorageId) ([
 func newMockVolumeAccessor() *mockVolumeAccessor {
 	return &mockVolumeAccessor{
 		volumesWatcher:         newMockStringsWatcher(),
+		volumeResizesWatcher:   newMockStringsWatcher(),
+		snapshotsWatcher:       newMockStringsWatcher(),
 		attachmentsWatcher:     newMockAttachmentsWatcher(),
 		attachmentPlansWatcher: newMockAttachmentPlansWatcher(),
 		blockDevicesWatcher:    newMockNotifyWatcher(),
@@ -327,6 +411,8 @@ func newMockVolumeAccessor() *mockVolumeAccessor {
 		provisionedVolumes:     make(map[string]params.Volume),
 		provisionedAttachments: make(map[params.MachineStorageId]params.VolumeAttachment),
 		blockDevices:           make(map[params.MachineStorageId]storage.BlockDevice),
+		requestedSizes:         make(map[string]uint64),
+		snapshots:              make(map[string]params.VolumeSnapshotParams),
 	}
 }
 
@@ -553,6 +639,10 @@ type dummyProvider struct {
 	detachFilesystemsFunc        func([]storage.FilesystemAttachmentParams) ([]error, error)
 	destroyVolumesFunc           func([]string) ([]error, error)
 	releaseVolumesFunc           func([]string) ([]error, error)
+	resizeVolumesFunc            func([]storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error)
+	snapshotVolumesFunc          func([]storage.VolumeSnapshotParams) ([]storage.SnapshotVolumesResult, error)
+	restoreVolumesFunc           func([]storage.VolumeRestoreParams) ([]storage.RestoreVolumesResult, error)
+	destroySnapshotsFunc         func([]string) ([]error, error)
 	destroyFilesystemsFunc       func([]string) ([]error, error)
 	releaseFilesystemsFunc       func([]string) ([]error, error)
 	validateVolumeParamsFunc     func(storage.VolumeParams) error
@@ -630,6 +720,57 @@ func (s *dummyVolumeSource) DestroyVolumes(ctx context.ProviderCallContext, volu
 	return make([]error, len(volumeIds)), nil
 }
 
+// ResizeVolumes expands volumes.
+func (s *dummyVolumeSource) ResizeVolumes(ctx context.ProviderCallContext, params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	if s.provider.resizeVolumesFunc != nil {
+		return s.provider.resizeVolumesFunc(params)
+	}
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		results[i].Size = p.Size
+	}
+	return results, nil
+}
+
+// SnapshotVolumes takes snapshots of volumes.
+func (s *dummyVolumeSource) SnapshotVolumes(ctx context.ProviderCallContext, params []storage.VolumeSnapshotParams) ([]storage.SnapshotVolumesResult, error) {
+	if s.provider.snapshotVolumesFunc != nil {
+		return s.provider.snapshotVolumesFunc(params)
+	}
+	results := make([]storage.SnapshotVolumesResult, len(params))
+	for i, p := range params {
+		results[i].Snapshot = &storage.VolumeSnapshot{
+			SnapshotId: p.Name,
+			VolumeId:   p.VolumeId,
+			Ready:      true,
+		}
+	}
+	return results, nil
+}
+
+// RestoreVolumes creates volumes from snapshots.
+func (s *dummyVolumeSource) RestoreVolumes(ctx context.ProviderCallContext, params []storage.VolumeRestoreParams) ([]storage.RestoreVolumesResult, error) {
+	if s.provider.restoreVolumesFunc != nil {
+		return s.provider.restoreVolumesFunc(params)
+	}
+	results := make([]storage.RestoreVolumesResult, len(params))
+	for i, p := range params {
+		results[i].VolumeInfo = &storage.VolumeInfo{
+			VolumeId: "id-" + p.Storage.Id(),
+			Size:     p.Size,
+		}
+	}
+	return results, nil
+}
+
+// DestroySnapshots destroys volume snapshots.
+func (s *dummyVolumeSource) DestroySnapshots(ctx context.ProviderCallContext, snapshotIds []string) ([]error, error) {
+	if s.provider.destroySnapshotsFunc != nil {
+		return s.provider.destroySnapshotsFunc(snapshotIds)
+	}
+	return make([]error, len(snapshotIds)), nil
+}
+
 // ReleaseVolumes destroys volumes.
 func (s *dummyVolumeSource) ReleaseVolumes(ctx context.ProviderCallContext, volumeIds []string) ([]error, error) {
 	if s.provider.releaseVolumesFunc != nil {
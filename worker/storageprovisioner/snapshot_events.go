@@ -0,0 +1,106 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageprovisioner
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/storage"
+)
+
+// volumeSnapshotsChanged is called when the volume snapshots watcher
+// reports a change to one or more snapshots. Depending on the state of
+// each snapshot, an operation is scheduled to take it, to restore it,
+// or to destroy it; any operation previously scheduled for the snapshot
+// is superseded.
+func volumeSnapshotsChanged(ctx *context, changes []string) error {
+	paramsResults, err := ctx.config.Volumes.VolumeSnapshotParams(changes)
+	if err != nil {
+		return errors.Annotate(err, "getting volume snapshot params")
+	}
+	ops := make([]scheduleOp, 0, len(changes))
+	for i, result := range paramsResults {
+		name := changes[i]
+		ctx.schedule.Remove(volumeSnapshotKey{name})
+		if params.IsCodeNotFound(result.Error) {
+			// The snapshot has been removed; nothing to do.
+			continue
+		} else if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "getting parameters for volume snapshot %q", name,
+			)
+		}
+		op, err := volumeSnapshotOp(ctx, result.Result)
+		if err != nil {
+			return errors.Annotatef(err, "volume snapshot %q", name)
+		}
+		if op != nil {
+			ops = append(ops, op)
+		}
+	}
+	scheduleOperations(ctx, ops...)
+	return nil
+}
+
+// volumeSnapshotOp returns the operation to schedule for the volume
+// snapshot with the specified parameters, or nil if there is nothing
+// to be done.
+func volumeSnapshotOp(ctx *context, p params.VolumeSnapshotParams) (scheduleOp, error) {
+	volumeTag, err := names.ParseVolumeTag(p.VolumeTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	provider := storage.ProviderType(p.Provider)
+	switch {
+	case p.Life != life.Alive:
+		return &destroySnapshotOp{
+			provider:  provider,
+			name:      p.Name,
+			volumeTag: volumeTag,
+			info:      p.Info,
+		}, nil
+	case p.Info == nil || !p.Info.Ready:
+		if p.VolumeId == "" {
+			ctx.config.Logger.Warningf(
+				"cannot take snapshot %q: %s has been removed",
+				p.Name, names.ReadableString(volumeTag),
+			)
+			return nil, nil
+		}
+		// Taking a snapshot is idempotent, so the snapshot is
+		// taken again until the provider reports it is ready.
+		return &snapshotVolumeOp{
+			provider: provider,
+			name:     p.Name,
+			args: storage.VolumeSnapshotParams{
+				Tag:          volumeTag,
+				VolumeId:     p.VolumeId,
+				Name:         p.Name,
+				Attributes:   p.Attributes,
+				ResourceTags: p.Tags,
+			},
+		}, nil
+	case p.RestoreStorageTag != "":
+		storageTag, err := names.ParseStorageTag(p.RestoreStorageTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return &restoreSnapshotOp{
+			provider:  provider,
+			name:      p.Name,
+			volumeTag: volumeTag,
+			args: storage.VolumeRestoreParams{
+				Storage:      storageTag,
+				SnapshotId:   p.Info.SnapshotId,
+				Size:         p.Info.Size,
+				Attributes:   p.Attributes,
+				ResourceTags: p.Tags,
+			},
+		}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,315 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageprovisioner
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/storage"
+)
+
+// volumeSnapshotter returns the volume snapshotter for the specified
+// storage provider. If the provider does not support snapshots, the
+// returned snapshotter is nil.
+func volumeSnapshotter(ctx *context, providerType storage.ProviderType) (storage.VolumeSnapshotter, error) {
+	sourceName := string(providerType)
+	volumeSource, err := volumeSource(
+		ctx.config.StorageDir, sourceName, providerType, ctx.config.Registry,
+	)
+	if err != nil && errors.Cause(err) != errNonDynamic {
+		return nil, errors.Annotate(err, "getting volume source")
+	}
+	snapshotter, _ := volumeSource.(storage.VolumeSnapshotter)
+	return snapshotter, nil
+}
+
+// snapshotsNotSupportedStatus returns the status to set on a volume
+// whose storage provider does not support snapshots.
+func snapshotsNotSupportedStatus(tag names.VolumeTag, providerType storage.ProviderType) params.EntityStatusArgs {
+	return params.EntityStatusArgs{
+		Tag:    tag.String(),
+		Status: status.Error.String(),
+		Info:   fmt.Sprintf("storage provider %q does not support volume snapshots", providerType),
+	}
+}
+
+// snapshotVolumes takes snapshots of volumes with the specified
+// parameters, and records them in state. Snapshots that are not yet
+// ready are rescheduled, to learn when they are.
+func snapshotVolumes(ctx *context, ops map[string]*snapshotVolumeOp) error {
+	opsByProvider := make(map[storage.ProviderType][]*snapshotVolumeOp)
+	for _, op := range ops {
+		opsByProvider[op.provider] = append(opsByProvider[op.provider], op)
+	}
+	var reschedule []scheduleOp
+	var statuses []params.EntityStatusArgs
+	var snapshots []params.VolumeSnapshot
+	for providerType, ops := range opsByProvider {
+		snapshotter, err := volumeSnapshotter(ctx, providerType)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if snapshotter == nil {
+			// The request cannot be satisfied, so
+			// report it rather than retrying.
+			for _, op := range ops {
+				statuses = append(statuses, snapshotsNotSupportedStatus(op.args.Tag, providerType))
+			}
+			continue
+		}
+		snapshotParams := make([]storage.VolumeSnapshotParams, len(ops))
+		for i, op := range ops {
+			snapshotParams[i] = op.args
+		}
+		ctx.config.Logger.Debugf("taking volume snapshots: %v", snapshotParams)
+		results, err := snapshotter.SnapshotVolumes(ctx.config.CloudCallContext, snapshotParams)
+		if err != nil {
+			return errors.Annotatef(err, "taking volume snapshots from source %q", providerType)
+		}
+		for i, result := range results {
+			op := ops[i]
+			if result.Error != nil {
+				reschedule = append(reschedule, op)
+				statuses = append(statuses, params.EntityStatusArgs{
+					Tag:    op.args.Tag.String(),
+					Status: status.Error.String(),
+					Info:   errors.Annotatef(result.Error, "taking snapshot %q", op.name).Error(),
+				})
+				ctx.config.Logger.Debugf(
+					"failed to take snapshot %q of %s: %v",
+					op.name, names.ReadableString(op.args.Tag), result.Error,
+				)
+				continue
+			}
+			if !result.Snapshot.Ready {
+				reschedule = append(reschedule, op)
+			}
+			snapshots = append(snapshots, params.VolumeSnapshot{
+				Name: op.name,
+				Info: params.VolumeSnapshotInfo{
+					SnapshotId: result.Snapshot.SnapshotId,
+					Size:       result.Snapshot.Size,
+					Ready:      result.Snapshot.Ready,
+				},
+			})
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	setStatus(ctx, statuses)
+	if len(snapshots) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeSnapshotInfo(snapshots)
+	if err != nil {
+		return errors.Annotate(err, "publishing volume snapshots to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "publishing volume snapshot %q to state",
+				snapshots[i].Name,
+			)
+		}
+	}
+	return nil
+}
+
+// restoreSnapshots restores volume snapshots to new volumes, and
+// records the restored volumes in state. Restores of volumes that
+// the provider is yet to provision are rescheduled.
+func restoreSnapshots(ctx *context, ops map[string]*restoreSnapshotOp) error {
+	opsByProvider := make(map[storage.ProviderType][]*restoreSnapshotOp)
+	for _, op := range ops {
+		opsByProvider[op.provider] = append(opsByProvider[op.provider], op)
+	}
+	var reschedule []scheduleOp
+	var statuses []params.EntityStatusArgs
+	var restores []params.VolumeSnapshotRestore
+	for providerType, ops := range opsByProvider {
+		snapshotter, err := volumeSnapshotter(ctx, providerType)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if snapshotter == nil {
+			for _, op := range ops {
+				statuses = append(statuses, snapshotsNotSupportedStatus(op.volumeTag, providerType))
+			}
+			continue
+		}
+		restoreParams := make([]storage.VolumeRestoreParams, len(ops))
+		for i, op := range ops {
+			restoreParams[i] = op.args
+		}
+		ctx.config.Logger.Debugf("restoring volume snapshots: %v", restoreParams)
+		results, err := snapshotter.RestoreVolumes(ctx.config.CloudCallContext, restoreParams)
+		if err != nil {
+			return errors.Annotatef(err, "restoring volume snapshots from source %q", providerType)
+		}
+		for i, result := range results {
+			op := ops[i]
+			if errors.IsNotProvisioned(errors.Cause(result.Error)) {
+				// The volume is still being provisioned.
+				reschedule = append(reschedule, op)
+				continue
+			} else if result.Error != nil {
+				reschedule = append(reschedule, op)
+				statuses = append(statuses, params.EntityStatusArgs{
+					Tag:    op.volumeTag.String(),
+					Status: status.Error.String(),
+					Info:   errors.Annotatef(result.Error, "restoring snapshot %q", op.name).Error(),
+				})
+				ctx.config.Logger.Debugf(
+					"failed to restore snapshot %q to %s: %v",
+					op.name, names.ReadableString(op.args.Storage), result.Error,
+				)
+				continue
+			}
+			info := result.VolumeInfo
+			restores = append(restores, params.VolumeSnapshotRestore{
+				Name: op.name,
+				Info: params.VolumeInfo{
+					VolumeId:   info.VolumeId,
+					HardwareId: info.HardwareId,
+					WWN:        info.WWN,
+					Size:       info.Size,
+					Persistent: info.Persistent,
+				},
+			})
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	setStatus(ctx, statuses)
+	if len(restores) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeSnapshotRestored(restores)
+	if err != nil {
+		return errors.Annotate(err, "publishing restored volumes to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "publishing volume restored from snapshot %q to state",
+				restores[i].Name,
+			)
+		}
+	}
+	return nil
+}
+
+// destroySnapshots destroys dying volume snapshots, and removes them
+// from state.
+func destroySnapshots(ctx *context, ops map[string]*destroySnapshotOp) error {
+	opsByProvider := make(map[storage.ProviderType][]*destroySnapshotOp)
+	var remove []string
+	for _, op := range ops {
+		if op.info == nil {
+			// The snapshot was never taken,
+			// so there is nothing to destroy.
+			remove = append(remove, op.name)
+			continue
+		}
+		opsByProvider[op.provider] = append(opsByProvider[op.provider], op)
+	}
+	var reschedule []scheduleOp
+	var statuses []params.EntityStatusArgs
+	for providerType, ops := range opsByProvider {
+		snapshotter, err := volumeSnapshotter(ctx, providerType)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if snapshotter == nil {
+			for _, op := range ops {
+				statuses = append(statuses, snapshotsNotSupportedStatus(op.volumeTag, providerType))
+			}
+			continue
+		}
+		snapshotIds := make([]string, len(ops))
+		for i, op := range ops {
+			snapshotIds[i] = op.info.SnapshotId
+		}
+		ctx.config.Logger.Debugf("destroying volume snapshots: %v", snapshotIds)
+		errs, err := snapshotter.DestroySnapshots(ctx.config.CloudCallContext, snapshotIds)
+		if err != nil {
+			return errors.Annotatef(err, "destroying volume snapshots from source %q", providerType)
+		}
+		for i, err := range errs {
+			op := ops[i]
+			if err == nil {
+				remove = append(remove, op.name)
+				continue
+			}
+			reschedule = append(reschedule, op)
+			statuses = append(statuses, params.EntityStatusArgs{
+				Tag:    op.volumeTag.String(),
+				Status: status.Error.String(),
+				Info:   errors.Annotatef(err, "destroying snapshot %q", op.name).Error(),
+			})
+			ctx.config.Logger.Debugf("failed to destroy snapshot %q: %v", op.name, err)
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	setStatus(ctx, statuses)
+	if len(remove) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Volumes.RemoveVolumeSnapshots(remove)
+	if err != nil {
+		return errors.Annotate(err, "removing volume snapshots from state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "removing volume snapshot %q from state", remove[i],
+			)
+		}
+	}
+	return nil
+}
+
+// volumeSnapshotKey is the schedule key for the operations on a volume
+// snapshot. At most one operation is scheduled for each snapshot.
+type volumeSnapshotKey struct {
+	name string
+}
+
+type snapshotVolumeOp struct {
+	exponentialBackoff
+	provider storage.ProviderType
+	name     string
+	args     storage.VolumeSnapshotParams
+}
+
+func (op *snapshotVolumeOp) key() interface{} {
+	return volumeSnapshotKey{op.name}
+}
+
+type restoreSnapshotOp struct {
+	exponentialBackoff
+	provider  storage.ProviderType
+	name      string
+	volumeTag names.VolumeTag
+	args      storage.VolumeRestoreParams
+}
+
+func (op *restoreSnapshotOp) key() interface{} {
+	return volumeSnapshotKey{op.name}
+}
+
+type destroySnapshotOp struct {
+	exponentialBackoff
+	provider  storage.ProviderType
+	name      string
+	volumeTag names.VolumeTag
+	info      *params.VolumeSnapshotInfo
+}
+
+func (op *destroySnapshotOp) key() interface{} {
+	return volumeSnapshotKey{op.name}
+}
@@ -56,6 +56,16 @@ type VolumeAccessor interface {
 	// provisioner is responsible for.
 	WatchVolumes(scope names.Tag) (watcher.StringsWatcher, error)
 
+	// WatchVolumeResizes watches for changes to volumes that this
+	// storage provisioner is responsible for, including requests to
+	// expand them.
+	WatchVolumeResizes(scope names.Tag) (watcher.StringsWatcher, error)
+
+	// WatchVolumeSnapshots watches for changes to the volume snapshots
+	// that this storage provisioner is responsible for, including
+	// requests to restore them.
+	WatchVolumeSnapshots(scope names.Tag) (watcher.StringsWatcher, error)
+
 	// WatchVolumeAttachments watches for changes to volume attachments
 	// that this storage provisioner is responsible for.
 	WatchVolumeAttachments(scope names.Tag) (watcher.MachineStorageIdsWatcher, error)
@@ -86,6 +96,18 @@ type VolumeAccessor interface {
 	// releasing the volumes with the specified tags.
 	RemoveVolumeParams([]names.VolumeTag) ([]params.RemoveVolumeParamsResult, error)
 
+	// VolumeResizeParams returns the parameters for expanding the
+	// volumes with the specified tags.
+	VolumeResizeParams([]names.VolumeTag) ([]params.VolumeResizeParamsResult, error)
+
+	// CancelVolumeResizes cancels the requests to expand the volumes
+	// with the specified tags.
+	CancelVolumeResizes([]names.VolumeTag) ([]params.ErrorResult, error)
+
+	// VolumeSnapshotParams returns the parameters for taking, restoring
+	// or destroying the volume snapshots with the specified names.
+	VolumeSnapshotParams([]string) ([]params.VolumeSnapshotParamsResult, error)
+
 	// VolumeAttachmentParams returns the parameters for creating the
 	// volume attachments with the specified tags.
 	VolumeAttachmentParams([]params.MachineStorageId) ([]params.VolumeAttachmentParamsResult, error)
@@ -93,6 +115,18 @@ type VolumeAccessor interface {
 	// SetVolumeInfo records the details of newly provisioned volumes.
 	SetVolumeInfo([]params.Volume) ([]params.ErrorResult, error)
 
+	// SetVolumeSnapshotInfo records the details of volume snapshots
+	// taken by the storage provider.
+	SetVolumeSnapshotInfo([]params.VolumeSnapshot) ([]params.ErrorResult, error)
+
+	// SetVolumeSnapshotRestored records the volumes restored from
+	// volume snapshots.
+	SetVolumeSnapshotRestored([]params.VolumeSnapshotRestore) ([]params.ErrorResult, error)
+
+	// RemoveVolumeSnapshots removes the destroyed volume snapshots
+	// with the specified names from state.
+	RemoveVolumeSnapshots([]string) ([]params.ErrorResult, error)
+
 	// SetVolumeAttachmentInfo records the details of newly provisioned
 	// volume attachments.
 	SetVolumeAttachmentInfo([]params.VolumeAttachment) ([]params.ErrorResult, error)
@@ -218,6 +252,8 @@ func (w *storageProvisioner) Wait() error {
 func (w *storageProvisioner) loop() error {
 	var (
 		volumesChanges               watcher.StringsChannel
+		volumeResizesChanges         watcher.StringsChannel
+		volumeSnapshotsChanges       watcher.StringsChannel
 		filesystemsChanges           watcher.StringsChannel
 		volumeAttachmentsChanges     watcher.MachineStorageIdsChannel
 		volumeAttachmentPlansChanges watcher.MachineStorageIdsChannel
@@ -288,6 +324,27 @@ func (w *storageProvisioner) loop() error {
 		volumesChanges = volumesWatcher.Changes()
 	}
 
+	// Only model-scoped volumes may be expanded or snapshotted.
+	if _, ok := w.config.Scope.(names.ModelTag); ok {
+		volumeResizesWatcher, err := w.config.Volumes.WatchVolumeResizes(w.config.Scope)
+		if err != nil {
+			return errors.Annotate(err, "watching volume resizes")
+		}
+		if err := w.catacomb.Add(volumeResizesWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		volumeResizesChanges = volumeResizesWatcher.Changes()
+
+		volumeSnapshotsWatcher, err := w.config.Volumes.WatchVolumeSnapshots(w.config.Scope)
+		if err != nil {
+			return errors.Annotate(err, "watching volume snapshots")
+		}
+		if err := w.catacomb.Add(volumeSnapshotsWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		volumeSnapshotsChanges = volumeSnapshotsWatcher.Changes()
+	}
+
 	filesystemsWatcher, err := w.config.Filesystems.WatchFilesystems(w.config.Scope)
 	if err != nil {
 		return errors.Annotate(err, "watching filesystems")
@@ -332,6 +389,20 @@ func (w *storageProvisioner) loop() error {
 			if err := volumesChanged(&ctx, changes); err != nil {
 				return errors.Trace(err)
 			}
+		case changes, ok := <-volumeResizesChanges:
+			if !ok {
+				return errors.New("volume resizes watcher closed")
+			}
+			if err := volumeResizesChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
+		case changes, ok := <-volumeSnapshotsChanges:
+			if !ok {
+				return errors.New("volume snapshots watcher closed")
+			}
+			if err := volumeSnapshotsChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
 		case changes, ok := <-volumeAttachmentsChanges:
 			if !ok {
 				return errors.New("volume attachments watcher closed")
@@ -385,6 +456,10 @@ func processSchedule(ctx *context) error {
 	ready := ctx.schedule.Ready(ctx.config.Clock.Now())
 	createVolumeOps := make(map[names.VolumeTag]*createVolumeOp)
 	removeVolumeOps := make(map[names.VolumeTag]*removeVolumeOp)
+	resizeVolumeOps := make(map[names.VolumeTag]*resizeVolumeOp)
+	snapshotVolumeOps := make(map[string]*snapshotVolumeOp)
+	restoreSnapshotOps := make(map[string]*restoreSnapshotOp)
+	destroySnapshotOps := make(map[string]*destroySnapshotOp)
 	attachVolumeOps := make(map[params.MachineStorageId]*attachVolumeOp)
 	detachVolumeOps := make(map[params.MachineStorageId]*detachVolumeOp)
 	createFilesystemOps := make(map[names.FilesystemTag]*createFilesystemOp)
@@ -399,6 +474,14 @@ func processSchedule(ctx *context) error {
 			createVolumeOps[key.(names.VolumeTag)] = op
 		case *removeVolumeOp:
 			removeVolumeOps[key.(names.VolumeTag)] = op
+		case *resizeVolumeOp:
+			resizeVolumeOps[op.args.Tag] = op
+		case *snapshotVolumeOp:
+			snapshotVolumeOps[op.name] = op
+		case *restoreSnapshotOp:
+			restoreSnapshotOps[op.name] = op
+		case *destroySnapshotOp:
+			destroySnapshotOps[op.name] = op
 		case *attachVolumeOp:
 			attachVolumeOps[key.(params.MachineStorageId)] = op
 		case *detachVolumeOp:
@@ -423,6 +506,26 @@ func processSchedule(ctx *context) error {
 			return errors.Annotate(err, "creating volumes")
 		}
 	}
+	if len(resizeVolumeOps) > 0 {
+		if err := resizeVolumes(ctx, resizeVolumeOps); err != nil {
+			return errors.Annotate(err, "resizing volumes")
+		}
+	}
+	if len(destroySnapshotOps) > 0 {
+		if err := destroySnapshots(ctx, destroySnapshotOps); err != nil {
+			return errors.Annotate(err, "destroying volume snapshots")
+		}
+	}
+	if len(snapshotVolumeOps) > 0 {
+		if err := snapshotVolumes(ctx, snapshotVolumeOps); err != nil {
+			return errors.Annotate(err, "taking volume snapshots")
+		}
+	}
+	if len(restoreSnapshotOps) > 0 {
+		if err := restoreSnapshots(ctx, restoreSnapshotOps); err != nil {
+			return errors.Annotate(err, "restoring volume snapshots")
+		}
+	}
 	if len(detachVolumeOps) > 0 {
 		if err := detachVolumes(ctx, detachVolumeOps); err != nil {
 			return errors.Annotate(err, "detaching volumes")
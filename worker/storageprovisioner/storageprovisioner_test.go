@@ -1693,6 +1693,336 @@ func (s *storageProvisionerSuite) TestDestroyVolumesRetry(c *gc.C) {
 	})
 }
 
+func (s *storageProvisionerSuite) TestResizeVolumes(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(names.NewVolumeTag("1"))
+	volumeAccessor.provisionVolume(names.NewVolumeTag("2"))
+	volumeAccessor.requestedSizes["volume-1"] = 2048
+
+	resizedChan := make(chan interface{}, 1)
+	s.provider.resizeVolumesFunc = func(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+		resizedChan <- args
+		return []storage.ResizeVolumesResult{{Size: 2048}}, nil
+	}
+
+	volumeInfoSet := make(chan interface{}, 1)
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		volumeInfoSet <- volumes
+		return make([]params.ErrorResult, len(volumes)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// Only volumes with a pending request are expanded.
+	volumeAccessor.volumeResizesWatcher.changes <- []string{"1", "2"}
+	resized := waitChannel(c, resizedChan, "waiting for volume to be resized")
+	c.Assert(resized, jc.DeepEquals, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("1"),
+		VolumeId: "vol-1",
+		Size:     2048,
+	}})
+
+	volumes := waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+	c.Assert(volumes, jc.DeepEquals, []params.Volume{{
+		VolumeTag: "volume-1",
+		Info: params.VolumeInfo{
+			VolumeId: "vol-1",
+			Size:     2048,
+		},
+	}})
+	assertNoEvent(c, resizedChan, "volumes resized")
+}
+
+func (s *storageProvisionerSuite) TestResizeVolumesRetry(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(names.NewVolumeTag("1"))
+	volumeAccessor.requestedSizes["volume-1"] = 2048
+
+	clock := &mockClock{}
+	var resizeVolumeTimes []time.Time
+	s.provider.resizeVolumesFunc = func(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+		resizeVolumeTimes = append(resizeVolumeTimes, clock.Now())
+		if len(resizeVolumeTimes) < 3 {
+			return []storage.ResizeVolumesResult{{Error: errors.New("badness")}}, nil
+		}
+		return []storage.ResizeVolumesResult{{Size: 2048}}, nil
+	}
+
+	volumeInfoSet := make(chan interface{}, 1)
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		volumeInfoSet <- volumes
+		return make([]params.ErrorResult, len(volumes)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, clock: clock, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.volumeResizesWatcher.changes <- []string{"1"}
+	waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+	c.Assert(resizeVolumeTimes, gc.HasLen, 3)
+	c.Assert(resizeVolumeTimes[1].Sub(resizeVolumeTimes[0]), gc.Equals, 30*time.Second)
+	c.Assert(resizeVolumeTimes[2].Sub(resizeVolumeTimes[1]), gc.Equals, time.Minute)
+
+	c.Assert(args.statusSetter.args, jc.DeepEquals, []params.EntityStatusArgs{
+		{Tag: "volume-1", Status: "error", Info: "expanding volume: badness"},
+		{Tag: "volume-1", Status: "error", Info: "expanding volume: badness"},
+	})
+}
+
+func (s *storageProvisionerSuite) TestResizeVolumesPending(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(names.NewVolumeTag("1"))
+	volumeAccessor.requestedSizes["volume-1"] = 2048
+
+	// The provider reports the old size until the
+	// expansion completes, and may round the size up.
+	clock := &mockClock{}
+	var resizeVolumeTimes []time.Time
+	s.provider.resizeVolumesFunc = func(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+		resizeVolumeTimes = append(resizeVolumeTimes, clock.Now())
+		if len(resizeVolumeTimes) < 3 {
+			return []storage.ResizeVolumesResult{{Size: 1024}}, nil
+		}
+		return []storage.ResizeVolumesResult{{Size: 3072}}, nil
+	}
+
+	volumeInfoSet := make(chan interface{}, 1)
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		volumeInfoSet <- volumes
+		return make([]params.ErrorResult, len(volumes)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, clock: clock, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.volumeResizesWatcher.changes <- []string{"1"}
+	volumes := waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+	c.Assert(volumes, jc.DeepEquals, []params.Volume{{
+		VolumeTag: "volume-1",
+		Info: params.VolumeInfo{
+			VolumeId: "vol-1",
+			Size:     3072,
+		},
+	}})
+	c.Assert(resizeVolumeTimes, gc.HasLen, 3)
+	c.Assert(resizeVolumeTimes[1].Sub(resizeVolumeTimes[0]), gc.Equals, 30*time.Second)
+	c.Assert(resizeVolumeTimes[2].Sub(resizeVolumeTimes[1]), gc.Equals, time.Minute)
+	c.Assert(args.statusSetter.args, gc.HasLen, 0)
+}
+
+func (s *storageProvisionerSuite) TestResizeVolumesNotSupported(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(names.NewVolumeTag("1"))
+	volumeAccessor.provisionVolume(names.NewVolumeTag("2"))
+	volumeAccessor.requestedSizes["volume-1"] = 2048
+	volumeAccessor.requestedSizes["volume-2"] = 2048
+
+	clock := &mockClock{}
+	resizedChan := make(chan interface{}, 1)
+	s.provider.resizeVolumesFunc = func(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+		resizedChan <- args
+		results := make([]storage.ResizeVolumesResult, len(args))
+		for i, arg := range args {
+			if arg.Tag.Id() == "1" {
+				results[i].Error = errors.NotSupportedf("expanding volumes in storage class %q", "standard")
+			} else {
+				results[i].Error = errors.NotValidf("shrinking volume")
+			}
+		}
+		return results, nil
+	}
+
+	cancelled := make(chan interface{}, 1)
+	volumeAccessor.cancelVolumeResizes = func(tags []names.VolumeTag) ([]params.ErrorResult, error) {
+		cancelled <- tags
+		return make([]params.ErrorResult, len(tags)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, clock: clock, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.volumeResizesWatcher.changes <- []string{"1", "2"}
+	waitChannel(c, resizedChan, "waiting for volumes to be resized")
+	tags := waitChannel(c, cancelled, "waiting for resizes to be cancelled")
+	c.Assert(tags, jc.SameContents, []names.VolumeTag{
+		names.NewVolumeTag("1"), names.NewVolumeTag("2"),
+	})
+
+	// The requests are not retried.
+	assertNoEvent(c, resizedChan, "volumes resized")
+	c.Assert(args.statusSetter.args, jc.SameContents, []params.EntityStatusArgs{
+		{Tag: "volume-1", Status: "error", Info: `expanding volume: expanding volumes in storage class "standard" not supported`},
+		{Tag: "volume-2", Status: "error", Info: "expanding volume: shrinking volume not valid"},
+	})
+}
+
+func (s *storageProvisionerSuite) TestSnapshotVolumes(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.snapshots["nightly"] = params.VolumeSnapshotParams{
+		Name:       "nightly",
+		Life:       life.Alive,
+		VolumeTag:  "volume-1",
+		VolumeId:   "vol-1",
+		Provider:   "dummy",
+		Attributes: map[string]interface{}{"volume-snapshot-class": "csi"},
+	}
+
+	// The snapshot is taken again until it is ready.
+	var snapshotted [][]storage.VolumeSnapshotParams
+	s.provider.snapshotVolumesFunc = func(args []storage.VolumeSnapshotParams) ([]storage.SnapshotVolumesResult, error) {
+		snapshotted = append(snapshotted, args)
+		return []storage.SnapshotVolumesResult{{
+			Snapshot: &storage.VolumeSnapshot{
+				SnapshotId: "nightly",
+				VolumeId:   "vol-1",
+				Size:       1024,
+				Ready:      len(snapshotted) > 1,
+			},
+		}}, nil
+	}
+
+	snapshotInfoSet := make(chan interface{}, 2)
+	volumeAccessor.setVolumeSnapshotInfo = func(snapshots []params.VolumeSnapshot) ([]params.ErrorResult, error) {
+		snapshotInfoSet <- snapshots
+		return make([]params.ErrorResult, len(snapshots)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.snapshotsWatcher.changes <- []string{"nightly", "removed"}
+	snapshots := waitChannel(c, snapshotInfoSet, "waiting for snapshot info to be set")
+	c.Assert(snapshots, jc.DeepEquals, []params.VolumeSnapshot{{
+		Name: "nightly",
+		Info: params.VolumeSnapshotInfo{SnapshotId: "nightly", Size: 1024},
+	}})
+	snapshots = waitChannel(c, snapshotInfoSet, "waiting for snapshot info to be set")
+	c.Assert(snapshots, jc.DeepEquals, []params.VolumeSnapshot{{
+		Name: "nightly",
+		Info: params.VolumeSnapshotInfo{SnapshotId: "nightly", Size: 1024, Ready: true},
+	}})
+	c.Assert(snapshotted, gc.HasLen, 2)
+	c.Assert(snapshotted[0], jc.DeepEquals, []storage.VolumeSnapshotParams{{
+		Tag:        names.NewVolumeTag("1"),
+		VolumeId:   "vol-1",
+		Name:       "nightly",
+		Attributes: map[string]interface{}{"volume-snapshot-class": "csi"},
+	}})
+	assertNoEvent(c, snapshotInfoSet, "snapshot info set")
+}
+
+func (s *storageProvisionerSuite) TestRestoreSnapshot(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.snapshots["nightly"] = params.VolumeSnapshotParams{
+		Name:      "nightly",
+		Life:      life.Alive,
+		VolumeTag: "volume-1",
+		VolumeId:  "vol-1",
+		Provider:  "dummy",
+		Info: &params.VolumeSnapshotInfo{
+			SnapshotId: "nightly", Size: 1024, Ready: true,
+		},
+		RestoreStorageTag: "storage-pgdata-1",
+	}
+
+	// The restored volume is reported once it is provisioned.
+	clock := &mockClock{}
+	var restoreTimes []time.Time
+	s.provider.restoreVolumesFunc = func(args []storage.VolumeRestoreParams) ([]storage.RestoreVolumesResult, error) {
+		c.Assert(args, jc.DeepEquals, []storage.VolumeRestoreParams{{
+			Storage:    names.NewStorageTag("pgdata/1"),
+			SnapshotId: "nightly",
+			Size:       1024,
+		}})
+		restoreTimes = append(restoreTimes, clock.Now())
+		if len(restoreTimes) < 3 {
+			return []storage.RestoreVolumesResult{{
+				Error: errors.NotProvisionedf("volume claim %q", "storage-pgdata-1"),
+			}}, nil
+		}
+		return []storage.RestoreVolumesResult{{
+			VolumeInfo: &storage.VolumeInfo{VolumeId: "pvc-1", Size: 1024},
+		}}, nil
+	}
+
+	restored := make(chan interface{}, 1)
+	volumeAccessor.setVolumeSnapshotRestored = func(restores []params.VolumeSnapshotRestore) ([]params.ErrorResult, error) {
+		restored <- restores
+		return make([]params.ErrorResult, len(restores)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, clock: clock, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.snapshotsWatcher.changes <- []string{"nightly"}
+	restores := waitChannel(c, restored, "waiting for restored volume to be set")
+	c.Assert(restores, jc.DeepEquals, []params.VolumeSnapshotRestore{{
+		Name: "nightly",
+		Info: params.VolumeInfo{VolumeId: "pvc-1", Size: 1024},
+	}})
+	c.Assert(restoreTimes, gc.HasLen, 3)
+	c.Assert(restoreTimes[1].Sub(restoreTimes[0]), gc.Equals, 30*time.Second)
+	c.Assert(restoreTimes[2].Sub(restoreTimes[1]), gc.Equals, time.Minute)
+
+	// Waiting for the volume to be provisioned is not an error.
+	c.Assert(args.statusSetter.args, gc.HasLen, 0)
+}
+
+func (s *storageProvisionerSuite) TestDestroySnapshots(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.snapshots["nightly"] = params.VolumeSnapshotParams{
+		Name:      "nightly",
+		Life:      life.Dying,
+		VolumeTag: "volume-1",
+		Provider:  "dummy",
+		Info: &params.VolumeSnapshotInfo{
+			SnapshotId: "nightly", Size: 1024, Ready: true,
+		},
+	}
+	volumeAccessor.snapshots["never-taken"] = params.VolumeSnapshotParams{
+		Name:      "never-taken",
+		Life:      life.Dying,
+		VolumeTag: "volume-1",
+		Provider:  "dummy",
+	}
+
+	destroyed := make(chan interface{}, 1)
+	s.provider.destroySnapshotsFunc = func(snapshotIds []string) ([]error, error) {
+		destroyed <- snapshotIds
+		return make([]error, len(snapshotIds)), nil
+	}
+
+	removed := make(chan interface{}, 1)
+	volumeAccessor.removeVolumeSnapshots = func(snapshotNames []string) ([]params.ErrorResult, error) {
+		removed <- snapshotNames
+		return make([]params.ErrorResult, len(snapshotNames)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.snapshotsWatcher.changes <- []string{"nightly", "never-taken"}
+	snapshotIds := waitChannel(c, destroyed, "waiting for snapshots to be destroyed")
+	c.Assert(snapshotIds, jc.DeepEquals, []string{"nightly"})
+	snapshotNames := waitChannel(c, removed, "waiting for snapshots to be removed")
+	c.Assert(snapshotNames, jc.SameContents, []string{"nightly", "never-taken"})
+}
+
 func (s *storageProvisionerSuite) TestDestroyFilesystems(c *gc.C) {
 	unprovisionedFilesystem := names.NewFilesystemTag("0")
 	provisionedDestroyFilesystem := names.NewFilesystemTag("1")
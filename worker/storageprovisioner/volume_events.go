@@ -56,6 +56,47 @@ func volumesChanged(ctx *context, changes []string) error {
 	return nil
 }
 
+// volumeResizesChanged is called when the volume resizes watcher
+// reports a change to one or more volumes. Volumes with a pending
+// request to be expanded are scheduled for resizing; all others are
+// ignored.
+func volumeResizesChanged(ctx *context, changes []string) error {
+	tags := make([]names.VolumeTag, len(changes))
+	for i, change := range changes {
+		tags[i] = names.NewVolumeTag(change)
+	}
+	paramsResults, err := ctx.config.Volumes.VolumeResizeParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting volume resize params")
+	}
+	ops := make([]scheduleOp, 0, len(tags))
+	for i, result := range paramsResults {
+		if params.IsCodeNotFound(result.Error) {
+			// The volume has been removed, or has
+			// no expansion pending; nothing to do.
+			continue
+		} else if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "getting resize parameters for %s",
+				names.ReadableString(tags[i]),
+			)
+		}
+		op := &resizeVolumeOp{
+			provider: storage.ProviderType(result.Result.Provider),
+			args: storage.VolumeResizeParams{
+				Tag:      tags[i],
+				VolumeId: result.Result.VolumeId,
+				Size:     result.Result.Size,
+			},
+		}
+		// A later request supersedes any that is yet to complete.
+		ctx.schedule.Remove(op.key())
+		ops = append(ops, op)
+	}
+	scheduleOperations(ctx, ops...)
+	return nil
+}
+
 func sortVolumeAttachmentPlans(ctx *context, ids []params.MachineStorageId) (
 	alive, dying, dead []params.VolumeAttachmentPlanResult, err error) {
 	plans, err := ctx.config.Volumes.VolumeAttachmentPlans(ids)
@@ -4,6 +4,8 @@
 package storageprovisioner
 
 import (
+	"fmt"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v3"
 
@@ -325,6 +327,161 @@ func removeVolumes(ctx *context, ops map[names.VolumeTag]*removeVolumeOp) error
 	return nil
 }
 
+// resizeVolumes expands volumes with the specified parameters, and
+// records their new sizes in state once the storage provider reports
+// that the expansion has completed. Until then, the expansion is
+// rescheduled and the request remains pending.
+func resizeVolumes(ctx *context, ops map[names.VolumeTag]*resizeVolumeOp) error {
+	opsByProvider := make(map[storage.ProviderType][]*resizeVolumeOp)
+	for _, op := range ops {
+		opsByProvider[op.provider] = append(opsByProvider[op.provider], op)
+	}
+	var reschedule []scheduleOp
+	var statuses []params.EntityStatusArgs
+	resized := make(map[names.VolumeTag]uint64)
+	var cancelled []names.VolumeTag
+	for providerType, ops := range opsByProvider {
+		sourceName := string(providerType)
+		volumeSource, err := volumeSource(
+			ctx.config.StorageDir, sourceName, providerType, ctx.config.Registry,
+		)
+		if err != nil && errors.Cause(err) != errNonDynamic {
+			return errors.Annotate(err, "getting volume source")
+		}
+		resizer, ok := volumeSource.(storage.VolumeResizer)
+		if !ok {
+			// The request cannot be satisfied, so
+			// report and cancel it rather than retrying.
+			for _, op := range ops {
+				cancelled = append(cancelled, op.args.Tag)
+				statuses = append(statuses, params.EntityStatusArgs{
+					Tag:    op.args.Tag.String(),
+					Status: status.Error.String(),
+					Info:   fmt.Sprintf("storage provider %q does not support expanding volumes", providerType),
+				})
+			}
+			continue
+		}
+		resizeParams := make([]storage.VolumeResizeParams, len(ops))
+		for i, op := range ops {
+			resizeParams[i] = op.args
+		}
+		ctx.config.Logger.Debugf("resizing volumes: %v", resizeParams)
+		results, err := resizer.ResizeVolumes(ctx.config.CloudCallContext, resizeParams)
+		if err != nil {
+			return errors.Annotatef(err, "resizing volumes from source %q", sourceName)
+		}
+		for i, result := range results {
+			op := ops[i]
+			err := result.Error
+			if err == nil && result.Size < op.args.Size {
+				// The expansion is still in progress.
+				ctx.config.Logger.Debugf(
+					"%s is %dMiB, waiting for it to be expanded to %dMiB",
+					names.ReadableString(op.args.Tag), result.Size, op.args.Size,
+				)
+				reschedule = append(reschedule, op)
+				continue
+			} else if err == nil {
+				resized[op.args.Tag] = result.Size
+				continue
+			}
+			if cause := errors.Cause(err); errors.IsNotSupported(cause) || errors.IsNotValid(cause) {
+				// Retrying will not help, e.g. the storage
+				// class does not allow volume expansion.
+				cancelled = append(cancelled, op.args.Tag)
+			} else {
+				reschedule = append(reschedule, op)
+			}
+			statuses = append(statuses, params.EntityStatusArgs{
+				Tag:    op.args.Tag.String(),
+				Status: status.Error.String(),
+				Info:   errors.Annotate(err, "expanding volume").Error(),
+			})
+			ctx.config.Logger.Debugf(
+				"failed to resize %s: %v",
+				names.ReadableString(op.args.Tag), err,
+			)
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	setStatus(ctx, statuses)
+	if err := cancelVolumeResizes(ctx, cancelled); err != nil {
+		return errors.Annotate(err, "cancelling volume resizes")
+	}
+	if err := setResizedVolumeInfo(ctx, resized); err != nil {
+		return errors.Annotate(err, "publishing resized volumes to state")
+	}
+	return nil
+}
+
+// cancelVolumeResizes cancels the resize requests of the volumes with
+// the specified tags, which the storage provider cannot satisfy.
+func cancelVolumeResizes(ctx *context, tags []names.VolumeTag) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Volumes.CancelVolumeResizes(tags)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "cancelling resize of %s",
+				names.ReadableString(tags[i]),
+			)
+		}
+	}
+	return nil
+}
+
+// setResizedVolumeInfo records the new sizes of the resized volumes,
+// completing their resize requests.
+func setResizedVolumeInfo(ctx *context, sizes map[names.VolumeTag]uint64) error {
+	if len(sizes) == 0 {
+		return nil
+	}
+	tags := make([]names.VolumeTag, 0, len(sizes))
+	for tag := range sizes {
+		tags = append(tags, tag)
+	}
+	volumeResults, err := ctx.config.Volumes.Volumes(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting volume information")
+	}
+	volumes := make([]params.Volume, 0, len(tags))
+	for i, result := range volumeResults {
+		if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "getting information for %s",
+				names.ReadableString(tags[i]),
+			)
+		}
+		size := sizes[tags[i]]
+		volume := result.Result
+		volume.Info.Size = size
+		volumes = append(volumes, volume)
+		if v, ok := ctx.volumes[tags[i]]; ok {
+			v.Size = size
+			ctx.volumes[tags[i]] = v
+		}
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeInfo(volumes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "publishing %s to state",
+				names.ReadableString(tags[i]),
+			)
+		}
+	}
+	return nil
+}
+
 func partitionRemoveVolumeParams(removeTags []names.VolumeTag, removeParams []params.RemoveVolumeParams) (
 	destroyTags []names.VolumeTag, destroyIds []string,
 	releaseTags []names.VolumeTag, releaseIds []string,
@@ -553,6 +710,22 @@ func (op *removeVolumeOp) key() interface{} {
 	return op.tag
 }
 
+type resizeVolumeOp struct {
+	exponentialBackoff
+	provider storage.ProviderType
+	args     storage.VolumeResizeParams
+}
+
+// resizeVolumeKey is the schedule key for resizeVolumeOp, distinct
+// from the keys of other operations on the same volume.
+type resizeVolumeKey struct {
+	tag names.VolumeTag
+}
+
+func (op *resizeVolumeOp) key() interface{} {
+	return resizeVolumeKey{op.args.Tag}
+}
+
 type attachVolumeOp struct {
 	exponentialBackoff
 	args storage.VolumeAttachmentParams
@@ -0,0 +1,161 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/clock"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/juju/charm.v6/hooks"
+)
+
+const metricsNamespace = "juju_uniter"
+
+// durationBuckets are the histogram buckets, in seconds, used for the
+// times taken by hooks, actions and the resolver loop. Hooks can take
+// many minutes, so the default buckets are too small.
+var durationBuckets = []float64{0.1, 0.5, 1, 5, 10, 30, 60, 300, 600, 1800, 3600}
+
+// Collector is a prometheus.Collector that collects metrics about the
+// hooks and actions run by a uniter. Every metric is labelled with
+// the name of the unit, so that the collectors for the several units
+// run by a CAAS operator can be registered together.
+//
+// Collector implements operation.Metrics and resolver.LoopMetrics.
+type Collector struct {
+	clock         clock.Clock
+	relationCount func() int
+
+	hookRuns       *prometheus.CounterVec
+	hookDuration   *prometheus.HistogramVec
+	actionDuration *prometheus.HistogramVec
+	relations      prometheus.Gauge
+	loopDuration   prometheus.Histogram
+}
+
+// NewMetricsCollector returns a new Collector for the named unit. The
+// relationCount function is called to update the number of relations
+// whenever the resolver loop finishes acting on a change to the remote
+// state; it is only ever called from the resolver loop.
+func NewMetricsCollector(unitName string, clock clock.Clock, relationCount func() int) *Collector {
+	labels := prometheus.Labels{"unit": unitName}
+	return &Collector{
+		clock:         clock,
+		relationCount: relationCount,
+		hookRuns: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace:   metricsNamespace,
+				Name:        "hook_runs_total",
+				Help:        "The number of hooks run, by hook kind and outcome.",
+				ConstLabels: labels,
+			},
+			[]string{"hook", "outcome"},
+		),
+		hookDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace:   metricsNamespace,
+				Name:        "hook_duration_seconds",
+				Help:        "The time taken to run hooks, by hook kind and outcome.",
+				ConstLabels: labels,
+				Buckets:     durationBuckets,
+			},
+			[]string{"hook", "outcome"},
+		),
+		actionDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace:   metricsNamespace,
+				Name:        "action_duration_seconds",
+				Help:        "The time taken to run actions, by outcome.",
+				ConstLabels: labels,
+				Buckets:     durationBuckets,
+			},
+			[]string{"outcome"},
+		),
+		relations: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace:   metricsNamespace,
+				Name:        "relations",
+				Help:        "The number of relations the unit is participating in.",
+				ConstLabels: labels,
+			},
+		),
+		loopDuration: prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Namespace:   metricsNamespace,
+				Name:        "resolver_loop_duration_seconds",
+				Help:        "The time taken by the resolver loop to act on changes to the remote state.",
+				ConstLabels: labels,
+				Buckets:     durationBuckets,
+			},
+		),
+	}
+}
+
+// RegisterMetricsCollector registers c with registerer, and returns
+// the collector to record metrics with along with a function that
+// unregisters it. If a collector for the same unit is already
+// registered, that collector is used instead of c, with c's relation
+// count. Any other failure to register is logged, and c is still used.
+func RegisterMetricsCollector(registerer prometheus.Registerer, c *Collector) (*Collector, func()) {
+	err := registerer.Register(c)
+	if already, ok := err.(prometheus.AlreadyRegisteredError); ok {
+		if existing, ok := already.ExistingCollector.(*Collector); ok {
+			existing.relationCount = c.relationCount
+			c = existing
+			err = nil
+		}
+	}
+	if err != nil {
+		logger.Warningf("cannot register uniter metrics collector: %v", err)
+		return c, func() {}
+	}
+	return c, func() { registerer.Unregister(c) }
+}
+
+// HookStarted is part of the operation.Metrics interface.
+func (c *Collector) HookStarted(kind hooks.Kind) func(outcome string) {
+	start := c.clock.Now()
+	return func(outcome string) {
+		seconds := c.clock.Now().Sub(start).Seconds()
+		c.hookRuns.WithLabelValues(string(kind), outcome).Inc()
+		c.hookDuration.WithLabelValues(string(kind), outcome).Observe(seconds)
+	}
+}
+
+// ActionStarted is part of the operation.Metrics interface.
+func (c *Collector) ActionStarted() func(outcome string) {
+	start := c.clock.Now()
+	return func(outcome string) {
+		seconds := c.clock.Now().Sub(start).Seconds()
+		c.actionDuration.WithLabelValues(outcome).Observe(seconds)
+	}
+}
+
+// IterationStarted is part of the resolver.LoopMetrics interface.
+func (c *Collector) IterationStarted() func() {
+	start := c.clock.Now()
+	return func() {
+		c.loopDuration.Observe(c.clock.Now().Sub(start).Seconds())
+		if c.relationCount != nil {
+			c.relations.Set(float64(c.relationCount()))
+		}
+	}
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.hookRuns.Describe(ch)
+	c.hookDuration.Describe(ch)
+	c.actionDuration.Describe(ch)
+	c.relations.Describe(ch)
+	c.loopDuration.Describe(ch)
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.hookRuns.Collect(ch)
+	c.hookDuration.Collect(ch)
+	c.actionDuration.Collect(ch)
+	c.relations.Collect(ch)
+	c.loopDuration.Collect(ch)
+}
@@ -0,0 +1,173 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6/hooks"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter"
+	"github.com/juju/juju/worker/uniter/operation"
+)
+
+type MetricsSuite struct {
+	testing.BaseSuite
+
+	clock     *testclock.Clock
+	relations int
+	collector *uniter.Collector
+}
+
+var _ = gc.Suite(&MetricsSuite{})
+
+func (s *MetricsSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Now())
+	s.relations = 0
+	s.collector = uniter.NewMetricsCollector("mysql/0", s.clock, func() int {
+		return s.relations
+	})
+}
+
+// gather returns the metrics collected, keyed by name.
+func (s *MetricsSuite) gather(c *gc.C) map[string]*dto.MetricFamily {
+	registry := prometheus.NewPedanticRegistry()
+	err := registry.Register(s.collector)
+	c.Assert(err, jc.ErrorIsNil)
+	families, err := registry.Gather()
+	c.Assert(err, jc.ErrorIsNil)
+	result := make(map[string]*dto.MetricFamily)
+	for _, family := range families {
+		result[family.GetName()] = family
+	}
+	return result
+}
+
+func labels(m *dto.Metric) map[string]string {
+	result := make(map[string]string)
+	for _, pair := range m.GetLabel() {
+		result[pair.GetName()] = pair.GetValue()
+	}
+	return result
+}
+
+func (s *MetricsSuite) TestHooks(c *gc.C) {
+	finished := s.collector.HookStarted(hooks.ConfigChanged)
+	s.clock.Advance(2 * time.Second)
+	finished(operation.OutcomeCompleted)
+
+	finished = s.collector.HookStarted(hooks.ConfigChanged)
+	s.clock.Advance(time.Second)
+	finished(operation.OutcomeFailed)
+
+	finished = s.collector.HookStarted(hooks.ConfigChanged)
+	finished(operation.OutcomeFailed)
+
+	families := s.gather(c)
+	runs := families["juju_uniter_hook_runs_total"]
+	c.Assert(runs, gc.NotNil)
+	c.Assert(runs.GetMetric(), gc.HasLen, 2)
+	counts := make(map[string]float64)
+	for _, m := range runs.GetMetric() {
+		l := labels(m)
+		c.Check(l["unit"], gc.Equals, "mysql/0")
+		c.Check(l["hook"], gc.Equals, "config-changed")
+		counts[l["outcome"]] = m.GetCounter().GetValue()
+	}
+	c.Check(counts, jc.DeepEquals, map[string]float64{
+		"completed": 1,
+		"failed":    2,
+	})
+
+	durations := families["juju_uniter_hook_duration_seconds"]
+	c.Assert(durations, gc.NotNil)
+	sums := make(map[string]float64)
+	for _, m := range durations.GetMetric() {
+		sums[labels(m)["outcome"]] = m.GetHistogram().GetSampleSum()
+	}
+	c.Check(sums, jc.DeepEquals, map[string]float64{
+		"completed": 2,
+		"failed":    1,
+	})
+}
+
+func (s *MetricsSuite) TestActions(c *gc.C) {
+	finished := s.collector.ActionStarted()
+	s.clock.Advance(3 * time.Second)
+	finished(operation.OutcomeCompleted)
+
+	families := s.gather(c)
+	durations := families["juju_uniter_action_duration_seconds"]
+	c.Assert(durations, gc.NotNil)
+	c.Assert(durations.GetMetric(), gc.HasLen, 1)
+	m := durations.GetMetric()[0]
+	c.Check(labels(m), jc.DeepEquals, map[string]string{
+		"unit":    "mysql/0",
+		"outcome": "completed",
+	})
+	c.Check(m.GetHistogram().GetSampleCount(), gc.Equals, uint64(1))
+	c.Check(m.GetHistogram().GetSampleSum(), gc.Equals, float64(3))
+}
+
+func (s *MetricsSuite) TestResolverLoop(c *gc.C) {
+	s.relations = 2
+	finished := s.collector.IterationStarted()
+	s.clock.Advance(500 * time.Millisecond)
+	finished()
+
+	families := s.gather(c)
+	relations := families["juju_uniter_relations"]
+	c.Assert(relations, gc.NotNil)
+	c.Check(relations.GetMetric()[0].GetGauge().GetValue(), gc.Equals, float64(2))
+
+	loop := families["juju_uniter_resolver_loop_duration_seconds"]
+	c.Assert(loop, gc.NotNil)
+	c.Check(loop.GetMetric()[0].GetHistogram().GetSampleCount(), gc.Equals, uint64(1))
+	c.Check(loop.GetMetric()[0].GetHistogram().GetSampleSum(), gc.Equals, 0.5)
+}
+
+func (s *MetricsSuite) TestRegisterSeveralUnits(c *gc.C) {
+	registry := prometheus.NewPedanticRegistry()
+	err := registry.Register(s.collector)
+	c.Assert(err, jc.ErrorIsNil)
+	other := uniter.NewMetricsCollector("mysql/1", s.clock, nil)
+	err = registry.Register(other)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *MetricsSuite) TestRegisterMetricsCollector(c *gc.C) {
+	registry := prometheus.NewPedanticRegistry()
+	collector, unregister := uniter.RegisterMetricsCollector(registry, s.collector)
+	c.Assert(collector, gc.Equals, s.collector)
+
+	unregister()
+	c.Check(registry.Unregister(s.collector), jc.IsFalse)
+}
+
+func (s *MetricsSuite) TestRegisterMetricsCollectorAlreadyRegistered(c *gc.C) {
+	registry := prometheus.NewPedanticRegistry()
+	err := registry.Register(s.collector)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// A second collector for the same unit is replaced by the one
+	// already registered, which then counts the new relations.
+	other := uniter.NewMetricsCollector("mysql/0", s.clock, func() int {
+		return 3
+	})
+	collector, unregister := uniter.RegisterMetricsCollector(registry, other)
+	c.Assert(collector, gc.Equals, s.collector)
+	collector.IterationStarted()()
+	families := s.gather(c)
+	c.Check(families["juju_uniter_relations"].GetMetric()[0].GetGauge().GetValue(), gc.Equals, float64(3))
+
+	unregister()
+	c.Check(registry.Unregister(s.collector), jc.IsFalse)
+}
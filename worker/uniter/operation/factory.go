@@ -20,11 +20,17 @@ type FactoryParams struct {
 	Callbacks      Callbacks
 	Abort          <-chan struct{}
 	MetricSpoolDir string
+
+	// Metrics, if set, records the hooks and actions that are run.
+	Metrics Metrics
 }
 
 // NewFactory returns a Factory that creates Operations backed by the supplied
 // parameters.
 func NewFactory(params FactoryParams) Factory {
+	if params.Metrics == nil {
+		params.Metrics = noopMetrics{}
+	}
 	return &factory{
 		config: params,
 	}
@@ -93,6 +99,7 @@ func (f *factory) NewRunHook(hookInfo hook.Info) (Operation, error) {
 		info:          hookInfo,
 		callbacks:     f.config.Callbacks,
 		runnerFactory: f.config.RunnerFactory,
+		metrics:       f.config.Metrics,
 	}, nil
 }
 
@@ -114,6 +121,7 @@ func (f *factory) NewAction(actionId string) (Operation, error) {
 		actionId:      actionId,
 		callbacks:     f.config.Callbacks,
 		runnerFactory: f.config.RunnerFactory,
+		metrics:       f.config.Metrics,
 	}, nil
 }
 
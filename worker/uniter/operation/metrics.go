@@ -0,0 +1,53 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operation
+
+import (
+	"gopkg.in/juju/charm.v6/hooks"
+)
+
+// The outcomes recorded for hooks and actions run by the uniter.
+const (
+	// OutcomeCompleted is recorded for hooks and actions that ran
+	// to completion.
+	OutcomeCompleted = "completed"
+
+	// OutcomeFailed is recorded for hooks that failed, and for
+	// actions that failed or could not be run.
+	OutcomeFailed = "failed"
+
+	// OutcomeMissing is recorded for hooks that the charm does not
+	// implement.
+	OutcomeMissing = "missing"
+
+	// OutcomeReboot is recorded for hooks that requested a reboot.
+	OutcomeReboot = "reboot"
+)
+
+// Metrics records the hooks and actions run by operations.
+type Metrics interface {
+	// HookStarted is called when a hook of the given kind starts
+	// running. The returned function is called with the outcome once
+	// the hook has finished.
+	HookStarted(kind hooks.Kind) func(outcome string)
+
+	// ActionStarted is called when an action starts running. The
+	// returned function is called with the outcome once the action
+	// has finished.
+	ActionStarted() func(outcome string)
+}
+
+// noopMetrics is the Metrics used when none are supplied to the
+// factory.
+type noopMetrics struct{}
+
+// HookStarted is part of the Metrics interface.
+func (noopMetrics) HookStarted(hooks.Kind) func(string) {
+	return func(string) {}
+}
+
+// ActionStarted is part of the Metrics interface.
+func (noopMetrics) ActionStarted() func(string) {
+	return func(string) {}
+}
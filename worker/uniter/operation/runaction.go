@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/core/logfields"
 	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/runner"
 )
@@ -17,6 +18,7 @@ type runAction struct {
 
 	callbacks     Callbacks
 	runnerFactory runner.Factory
+	metrics       Metrics
 
 	name   string
 	runner runner.Runner
@@ -72,12 +74,20 @@ func (ra *runAction) Execute(state State) (*State, error) {
 	if err := ra.callbacks.SetExecutingStatus(message); err != nil {
 		return nil, err
 	}
+	finished := ra.metrics.ActionStarted()
 	err := ra.runner.RunAction(ra.name)
 	if err != nil {
+		finished(OutcomeFailed)
 		// This indicates an actual error -- an action merely failing should
 		// be handled inside the Runner, and returned as nil.
 		return nil, errors.Annotatef(err, "running action %q", ra.name)
 	}
+	outcome := OutcomeCompleted
+	if actionData, err := ra.runner.Context().ActionData(); err == nil && actionData.Failed {
+		outcome = OutcomeFailed
+	}
+	finished(outcome)
+	ra.logger().Infof("ran %q action", ra.name)
 	return stateChange{
 		Kind:     RunAction,
 		Step:     Done,
@@ -86,6 +96,15 @@ func (ra *runAction) Execute(state State) (*State, error) {
 	}.apply(state), nil
 }
 
+// logger returns a logger that attaches fields identifying the unit
+// and the action being run to its messages.
+func (ra *runAction) logger() logfields.Logger {
+	return logfields.NewLogger(logger, logfields.Fields{
+		logfields.Unit:     ra.runner.Context().UnitName(),
+		logfields.ActionID: ra.actionId,
+	})
+}
+
 // Commit preserves the recorded hook, and returns a neutral state.
 // Commit is part of the Operation interface.
 func (ra *runAction) Commit(state State) (*State, error) {
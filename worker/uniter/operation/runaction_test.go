@@ -227,6 +227,39 @@ func (s *RunActionSuite) TestExecuteSuccess(c *gc.C) {
 	}
 }
 
+func (s *RunActionSuite) TestExecuteRecordsMetrics(c *gc.C) {
+	for i, test := range []struct {
+		runErr  error
+		failed  bool
+		outcome string
+	}{{
+		outcome: operation.OutcomeCompleted,
+	}, {
+		failed:  true,
+		outcome: operation.OutcomeFailed,
+	}, {
+		runErr:  errors.New("blam"),
+		outcome: operation.OutcomeFailed,
+	}} {
+		c.Logf("test %d", i)
+		runnerFactory := NewRunActionRunnerFactory(test.runErr)
+		runnerFactory.MockNewActionRunner.runner.context.(*MockContext).actionData.Failed = test.failed
+		metrics := &MockMetrics{}
+		factory := operation.NewFactory(operation.FactoryParams{
+			RunnerFactory: runnerFactory,
+			Callbacks:     &RunActionCallbacks{},
+			Metrics:       metrics,
+		})
+		op, err := factory.NewAction(someActionId)
+		c.Assert(err, jc.ErrorIsNil)
+		midState, err := op.Prepare(operation.State{})
+		c.Assert(err, jc.ErrorIsNil)
+
+		_, _ = op.Execute(*midState)
+		c.Check(metrics.actions, jc.DeepEquals, []string{test.outcome})
+	}
+}
+
 func (s *RunActionSuite) TestCommit(c *gc.C) {
 	var stateChangeTests = []struct {
 		description string
@@ -5,11 +5,13 @@ package operation
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/juju/errors"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 	"gopkg.in/juju/charm.v6/hooks"
 
+	"github.com/juju/juju/core/logfields"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/relation"
 	"github.com/juju/juju/core/status"
@@ -24,6 +26,7 @@ type runHook struct {
 
 	callbacks     Callbacks
 	runnerFactory runner.Factory
+	metrics       Metrics
 
 	name   string
 	runner runner.Runner
@@ -116,29 +119,35 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	rh.hookFound = true
 	step := Done
 
+	finished := rh.metrics.HookStarted(rh.info.Kind)
 	err := rh.runner.RunHook(rh.name)
 	cause := errors.Cause(err)
+	outcome := OutcomeCompleted
 	switch {
 	case charmrunner.IsMissingHookError(cause):
 		rh.hookFound = false
 		err = nil
+		outcome = OutcomeMissing
 	case cause == context.ErrRequeueAndReboot:
 		step = Queued
 		fallthrough
 	case cause == context.ErrReboot:
 		err = ErrNeedsReboot
+		outcome = OutcomeReboot
 	case err == nil:
 	default:
-		logger.Errorf("hook %q failed: %v", rh.name, err)
+		finished(OutcomeFailed)
+		rh.logger().Errorf("hook %q failed: %v", rh.name, err)
 		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
 		return nil, ErrHookFailed
 	}
+	finished(outcome)
 
 	if rh.hookFound {
-		logger.Infof("ran %q hook", rh.name)
+		rh.logger().Infof("ran %q hook", rh.name)
 		rh.callbacks.NotifyHookCompleted(rh.name, rh.runner.Context())
 	} else {
-		logger.Infof("skipped %q hook (missing)", rh.name)
+		rh.logger().Infof("skipped %q hook (missing)", rh.name)
 	}
 
 	var hasRunStatusSet bool
@@ -154,6 +163,22 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	}.apply(state), err
 }
 
+// logger returns a logger that attaches fields identifying the unit
+// and the hook being run to its messages.
+func (rh *runHook) logger() logfields.Logger {
+	fields := logfields.Fields{
+		logfields.Unit: rh.runner.Context().UnitName(),
+		logfields.Hook: rh.name,
+	}
+	if rh.info.Kind.IsRelation() {
+		fields[logfields.RelationID] = strconv.Itoa(rh.info.RelationId)
+		if rh.info.RemoteUnit != "" {
+			fields[logfields.RemoteUnit] = rh.info.RemoteUnit
+		}
+	}
+	return logfields.NewLogger(logger, fields)
+}
+
 func (rh *runHook) beforeHook(state State) error {
 	var err error
 	switch rh.info.Kind {
@@ -178,7 +203,7 @@ func (rh *runHook) beforeHook(state State) error {
 	}
 
 	if err != nil {
-		logger.Errorf("error updating workload status before %v hook: %v", rh.info.Kind, err)
+		rh.logger().Errorf("error updating workload status before %v hook: %v", rh.info.Kind, err)
 		return err
 	}
 	return nil
@@ -190,7 +215,7 @@ func (rh *runHook) beforeHook(state State) error {
 func (rh *runHook) afterHook(state State) (_ bool, err error) {
 	defer func() {
 		if err != nil {
-			logger.Errorf("error updating workload status after %v hook: %v", rh.info.Kind, err)
+			rh.logger().Errorf("error updating workload status after %v hook: %v", rh.info.Kind, err)
 		}
 	}()
 
@@ -299,6 +299,43 @@ func (s *RunHookSuite) TestExecuteOtherError(c *gc.C) {
 	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
 }
 
+func (s *RunHookSuite) TestExecuteRecordsMetrics(c *gc.C) {
+	for i, test := range []struct {
+		runErr  error
+		outcome string
+	}{{
+		outcome: operation.OutcomeCompleted,
+	}, {
+		runErr:  charmrunner.NewMissingHookError("blah-blah"),
+		outcome: operation.OutcomeMissing,
+	}, {
+		runErr:  context.ErrReboot,
+		outcome: operation.OutcomeReboot,
+	}, {
+		runErr:  errors.New("graaargh"),
+		outcome: operation.OutcomeFailed,
+	}} {
+		c.Logf("test %d: %v", i, test.runErr)
+		metrics := &MockMetrics{}
+		factory := operation.NewFactory(operation.FactoryParams{
+			RunnerFactory: NewRunHookRunnerFactory(test.runErr),
+			Callbacks: &ExecuteHookCallbacks{
+				PrepareHookCallbacks:    NewPrepareHookCallbacks(),
+				MockNotifyHookCompleted: &MockNotify{},
+				MockNotifyHookFailed:    &MockNotify{},
+			},
+			Metrics: metrics,
+		})
+		op, err := factory.NewRunHook(hook.Info{Kind: hooks.ConfigChanged})
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = op.Prepare(operation.State{})
+		c.Assert(err, jc.ErrorIsNil)
+
+		_, _ = op.Execute(operation.State{})
+		c.Check(metrics.hooks, jc.DeepEquals, []string{"config-changed " + test.outcome})
+	}
+}
+
 func (s *RunHookSuite) TestInstallHookPreservesStatus(c *gc.C) {
 	op, callbacks, f := s.getExecuteRunnerTest(c, operation.Factory.NewRunHook, hooks.Install, nil)
 	err := f.MockNewHookRunner.runner.Context().SetUnitStatus(jujuc.StatusInfo{Status: "blocked", Info: "no database"})
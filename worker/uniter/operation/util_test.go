@@ -453,6 +453,23 @@ func NewRunHookRunnerFactory(runErr error, contextOps ...func(*MockContext)) *Mo
 	}
 }
 
+type MockMetrics struct {
+	hooks   []string
+	actions []string
+}
+
+func (mock *MockMetrics) HookStarted(kind hooks.Kind) func(string) {
+	return func(outcome string) {
+		mock.hooks = append(mock.hooks, string(kind)+" "+outcome)
+	}
+}
+
+func (mock *MockMetrics) ActionStarted() func(string) {
+	return func(outcome string) {
+		mock.actions = append(mock.actions, outcome)
+	}
+}
+
 type MockSendResponse struct {
 	gotResponse **utilexec.ExecResponse
 	gotErr      *error
@@ -453,6 +453,14 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			if len(hashes) != 1 {
 				return errors.New("expected one hash in config change")
 			}
+			// The config hash changes when the charm is upgraded in a
+			// branch that the unit is tracking, which is not reflected
+			// by changes to the application.
+			if w.modelType == model.IAAS {
+				if err := w.applicationChanged(); err != nil {
+					return errors.Trace(err)
+				}
+			}
 			w.configHashChanged(hashes[0])
 			observedEvent(&seenConfigChange)
 
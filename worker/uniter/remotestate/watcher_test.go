@@ -326,6 +326,35 @@ func (s *WatcherSuite) TestRemoteStateChanged(c *gc.C) {
 	assertOneChange()
 }
 
+func (s *WatcherSuite) TestConfigChangeRefreshesCharmURL(c *gc.C) {
+	if s.modelType != model.IAAS {
+		c.Skip("CAAS charm upgrades are driven by the operator")
+	}
+	s.signalAll()
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	// A charm upgrade in a tracked branch is signalled by a config change.
+	s.st.unit.application.curl = charm.MustParseURL("cs:trusty/mysql-2")
+	s.st.unit.configSettingsWatcher.changes <- []string{"confighash2"}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().CharmURL, jc.DeepEquals, charm.MustParseURL("cs:trusty/mysql-2"))
+}
+
+func (s *WatcherSuite) TestConfigChangeRefreshesCharmModifiedVersion(c *gc.C) {
+	if s.modelType != model.IAAS {
+		c.Skip("CAAS charm upgrades are driven by the operator")
+	}
+	s.signalAll()
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	// Resources staged in a tracked branch without a change of charm
+	// are signalled by a config change and a new charm modified version.
+	s.st.unit.application.charmModifiedVersion = 6
+	s.st.unit.configSettingsWatcher.changes <- []string{"confighash2"}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().CharmModifiedVersion, gc.Equals, 6)
+}
+
 func (s *WatcherSuite) TestActionsReceived(c *gc.C) {
 	s.signalAll()
 	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
@@ -30,6 +30,19 @@ type LoopConfig struct {
 	Abort         <-chan struct{}
 	OnIdle        func() error
 	CharmDirGuard fortress.Guard
+
+	// Metrics, if set, records how long the loop takes to act on
+	// changes to the remote state.
+	Metrics LoopMetrics
+}
+
+// LoopMetrics records the time taken by each pass of the loop.
+type LoopMetrics interface {
+	// IterationStarted is called when the loop starts acting on the
+	// remote state. The returned function is called once the loop has
+	// run out of work, and is about to wait for the remote state to
+	// change again.
+	IterationStarted() func()
 }
 
 // Loop repeatedly waits for remote state changes, feeding the local and
@@ -61,6 +74,10 @@ func Loop(cfg LoopConfig, localState *LocalState) error {
 	}
 
 	for {
+		var finished func()
+		if cfg.Metrics != nil {
+			finished = cfg.Metrics.IterationStarted()
+		}
 		rf.RemoteState = cfg.Watcher.Snapshot()
 		rf.LocalState.State = cfg.Executor.State()
 
@@ -97,6 +114,9 @@ func Loop(cfg LoopConfig, localState *LocalState) error {
 		default:
 			return err
 		}
+		if finished != nil {
+			finished()
+		}
 
 		select {
 		case <-cfg.Abort:
@@ -28,6 +28,7 @@ type LoopSuite struct {
 	charmURL  *charm.URL
 	abort     chan struct{}
 	onIdle    func() error
+	metrics   resolver.LoopMetrics
 }
 
 var _ = gc.Suite(&LoopSuite{})
@@ -58,6 +59,7 @@ func (s *LoopSuite) loop() (resolver.LocalState, error) {
 		Abort:         s.abort,
 		OnIdle:        s.onIdle,
 		CharmDirGuard: &mockCharmDirGuard{},
+		Metrics:       s.metrics,
 	}, &localState)
 	return localState, err
 }
@@ -105,6 +107,49 @@ func (s *LoopSuite) TestOnIdleError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "onIdle failed")
 }
 
+func (s *LoopSuite) TestMetrics(c *gc.C) {
+	var calls []string
+	s.metrics = loopMetricsFunc(func() func() {
+		calls = append(calls, "started")
+		return func() {
+			calls = append(calls, "finished")
+		}
+	})
+	s.onIdle = func() error {
+		calls = append(calls, "idle")
+		return nil
+	}
+	close(s.abort)
+	_, err := s.loop()
+	c.Assert(err, gc.Equals, resolver.ErrLoopAborted)
+	c.Assert(calls, jc.DeepEquals, []string{"started", "idle", "finished"})
+}
+
+func (s *LoopSuite) TestMetricsNotFinishedOnError(c *gc.C) {
+	var finished bool
+	s.metrics = loopMetricsFunc(func() func() {
+		return func() {
+			finished = true
+		}
+	})
+	s.resolver = resolver.ResolverFunc(func(
+		_ resolver.LocalState,
+		_ remotestate.Snapshot,
+		_ operation.Factory,
+	) (operation.Operation, error) {
+		return nil, errors.New("boom")
+	})
+	_, err := s.loop()
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(finished, jc.IsFalse)
+}
+
+type loopMetricsFunc func() func()
+
+func (f loopMetricsFunc) IterationStarted() func() {
+	return f()
+}
+
 func (s *LoopSuite) TestErrWaitingNoOnIdle(c *gc.C) {
 	var onIdleCalled bool
 	s.onIdle = func() error {
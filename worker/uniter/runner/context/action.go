@@ -4,6 +4,8 @@
 package context
 
 import (
+	"time"
+
 	"gopkg.in/juju/names.v3"
 )
 
@@ -15,6 +17,10 @@ type ActionData struct {
 	Failed         bool
 	ResultsMessage string
 	ResultsMap     map[string]interface{}
+
+	// Timeout, if non-zero, is how long the action may run for
+	// before it is killed and failed.
+	Timeout time.Duration
 }
 
 // NewActionData builds a suitable ActionData struct with no nil members.
@@ -206,8 +206,9 @@ type HookContext struct {
 
 	// machinePorts contains cached information about all opened port
 	// ranges on the unit's assigned machine, mapped to the unit that
-	// opened each range and the relevant relation.
-	machinePorts map[network.PortRange]params.RelationUnit
+	// opened each range, the relevant relation and the endpoint the
+	// range was opened for.
+	machinePorts map[network.PortRange]params.MachinePortRange
 
 	// assignedMachineTag contains the tag of the unit's assigned
 	// machine.
@@ -529,11 +530,13 @@ func (ctx *HookContext) AddUnitStorage(cons map[string]params.StorageConstraints
 }
 
 // OpenPorts marks the supplied port range for opening when the
-// executing unit's application is exposed.
+// executing unit's application is exposed. If an endpoint is given,
+// the range is only accessible from the sources that endpoint is
+// exposed to.
 // Implements jujuc.HookContext.ContextNetworking, part of runner.Context.
-func (ctx *HookContext) OpenPorts(protocol string, fromPort, toPort int) error {
+func (ctx *HookContext) OpenPorts(endpoint, protocol string, fromPort, toPort int) error {
 	return tryOpenPorts(
-		protocol, fromPort, toPort,
+		endpoint, protocol, fromPort, toPort,
 		ctx.unit.Tag(),
 		ctx.machinePorts, ctx.pendingPorts,
 	)
@@ -557,8 +560,8 @@ func (ctx *HookContext) ClosePorts(protocol string, fromPort, toPort int) error
 // Implements jujuc.HookContext.ContextNetworking, part of runner.Context.
 func (ctx *HookContext) OpenedPorts() []network.PortRange {
 	var unitRanges []network.PortRange
-	for portRange, relUnit := range ctx.machinePorts {
-		if relUnit.Unit == ctx.unit.Tag().String() {
+	for portRange, opened := range ctx.machinePorts {
+		if opened.UnitTag == ctx.unit.Tag().String() {
 			unitRanges = append(unitRanges, portRange)
 		}
 	}
@@ -651,6 +654,15 @@ func (ctx *HookContext) LogActionMessage(message string) error {
 	return ctx.unit.LogActionMessage(ctx.actionData.Tag, message)
 }
 
+// LogActionOutput sends lines written by the running Action to the given
+// output stream to the controller, so they can be watched as they happen.
+func (ctx *HookContext) LogActionOutput(stream string, lines []string) error {
+	if ctx.actionData == nil {
+		return errors.New("not running an action")
+	}
+	return ctx.unit.LogActionOutput(ctx.actionData.Tag, stream, lines)
+}
+
 // SetActionMessage sets a message for the Action, usually an error message.
 // Implements jujuc.ActionHookContext.actionHookContext, part of runner.Context.
 func (ctx *HookContext) SetActionMessage(message string) error {
@@ -912,7 +924,8 @@ func (ctx *HookContext) Flush(process string, ctxErr error) (err error) {
 			var e error
 			var op string
 			if rangeInfo.ShouldOpen {
-				e = ctx.unit.OpenPorts(
+				e = ctx.unit.OpenPortsForEndpoint(
+					rangeInfo.Endpoint,
 					rangeKey.Ports.Protocol,
 					rangeKey.Ports.FromPort,
 					rangeKey.Ports.ToPort,
@@ -1015,9 +1028,16 @@ func (ctx *HookContext) finalizeAction(err, unhandledErr error) error {
 			message = fmt.Sprintf("action not implemented on unit %q", ctx.unitName)
 		}
 		actionStatus = params.ActionFailed
+		ctx.actionData.Failed = true
 	}
 
 	callErr := ctx.state.ActionFinish(tag, actionStatus, results, message)
+	if params.IsCodeActionNotAvailable(callErr) {
+		// The action was expired by the controller before it finished,
+		// so its outcome has already been recorded.
+		logger.Infof("action %q was expired before it finished; discarding its results", tag.Id())
+		callErr = nil
+	}
 	if callErr != nil {
 		unhandledErr = errors.Wrap(unhandledErr, callErr)
 	}
@@ -403,6 +403,49 @@ func (s *InterfaceSuite) TestLogActionMessage(c *gc.C) {
 	c.Assert(messages[0].Message(), gc.Equals, "hello world")
 }
 
+// TestLogActionOutput ensures LogActionOutput works properly.
+func (s *InterfaceSuite) TestLogActionOutput(c *gc.C) {
+	s.toSupportNewActionID(c)
+	action, err := s.unit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	hctx := s.getHookContext(c, s.State.ModelUUID(), -1, "")
+	context.WithActionContext(hctx, nil)
+	err = hctx.LogActionOutput("stdout", []string{"hello world"})
+	c.Assert(err, jc.ErrorIsNil)
+	a, err := s.Model.Action(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := a.Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Assert(messages[0].Message(), gc.Equals, "hello world")
+	c.Assert(messages[0].Stream(), gc.Equals, "stdout")
+}
+
+func (s *InterfaceSuite) TestFlushExpiredAction(c *gc.C) {
+	s.toSupportNewActionID(c)
+	action, err := s.unit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	action, err = action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	// Simulate the controller expiring the action while it runs.
+	_, err = action.Finish(state.ActionResults{Status: state.ActionFailed, Message: "expired"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	hctx := s.getHookContext(c, s.State.ModelUUID(), -1, "")
+	context.WithActionContext(hctx, map[string]interface{}{"foo": "bar"})
+	err = hctx.Flush("action", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	a, err := s.Model.Action(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(a.Status(), gc.Equals, state.ActionFailed)
+	results, message := a.Results()
+	c.Check(results, gc.HasLen, 0)
+	c.Check(message, gc.Equals, "expired")
+}
+
 func (s *InterfaceSuite) TestRequestRebootAfterHook(c *gc.C) {
 	var killed bool
 	p := &mockProcess{func() error {
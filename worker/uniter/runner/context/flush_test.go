@@ -122,19 +122,19 @@ func (s *FlushContextSuite) TestRunHookOpensAndClosesPendingPorts(c *gc.C) {
 	ctx := s.context(c)
 
 	// Try opening some ports via the context.
-	err = ctx.OpenPorts("tcp", 100, 200)
+	err = ctx.OpenPorts("", "tcp", 100, 200)
 	c.Assert(err, jc.ErrorIsNil) // duplicates are ignored
-	err = ctx.OpenPorts("udp", 200, 300)
+	err = ctx.OpenPorts("", "udp", 200, 300)
 	c.Assert(err, gc.ErrorMatches, `cannot open 200-300/udp \(unit "u/0"\): conflicts with existing 200-300/udp \(unit "u/1"\)`)
-	err = ctx.OpenPorts("udp", 100, 200)
+	err = ctx.OpenPorts("", "udp", 100, 200)
 	c.Assert(err, gc.ErrorMatches, `cannot open 100-200/udp \(unit "u/0"\): conflicts with existing 200-300/udp \(unit "u/1"\)`)
-	err = ctx.OpenPorts("udp", 10, 20)
+	err = ctx.OpenPorts("", "udp", 10, 20)
 	c.Assert(err, jc.ErrorIsNil)
-	err = ctx.OpenPorts("tcp", 50, 100)
+	err = ctx.OpenPorts("", "tcp", 50, 100)
 	c.Assert(err, gc.ErrorMatches, `cannot open 50-100/tcp \(unit "u/0"\): conflicts with existing 100-200/tcp \(unit "u/0"\)`)
-	err = ctx.OpenPorts("tcp", 50, 80)
+	err = ctx.OpenPorts("", "tcp", 50, 80)
 	c.Assert(err, jc.ErrorIsNil)
-	err = ctx.OpenPorts("tcp", 40, 90)
+	err = ctx.OpenPorts("", "tcp", 40, 90)
 	c.Assert(err, gc.ErrorMatches, `cannot open 40-90/tcp \(unit "u/0"\): conflicts with 50-80/tcp requested earlier`)
 
 	// Now try closing some ports as well.
@@ -169,6 +169,27 @@ func (s *FlushContextSuite) TestRunHookOpensAndClosesPendingPorts(c *gc.C) {
 	c.Assert(unitRanges, jc.DeepEquals, expectUnitRanges)
 }
 
+func (s *FlushContextSuite) TestRunHookOpensPortsForEndpoint(c *gc.C) {
+	err := s.unit.OpenPorts("tcp", 100, 200)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := s.context(c)
+	err = ctx.OpenPorts("url", "tcp", 100, 200)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.OpenPorts("monitoring-port", "udp", 10, 20)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = ctx.Flush("some badge", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	endpoints, err := s.unit.OpenedPortEndpoints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(endpoints, jc.DeepEquals, map[network.PortRange]string{
+		{FromPort: 100, ToPort: 200, Protocol: "tcp"}: "url",
+		{FromPort: 10, ToPort: 20, Protocol: "udp"}:   "monitoring-port",
+	})
+}
+
 func (s *FlushContextSuite) TestRunHookAddStorageOnFailure(c *gc.C) {
 	ctx := s.context(c)
 	c.Assert(ctx.UnitName(), gc.Equals, "u/0")
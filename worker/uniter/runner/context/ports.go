@@ -14,11 +14,13 @@ import (
 )
 
 // PortRangeInfo contains information about a pending open- or
-// close-port operation for a port range. This is only exported for
+// close-port operation for a port range, and the endpoint a range
+// pending to be opened is opened for. This is only exported for
 // testing.
 type PortRangeInfo struct {
 	ShouldOpen  bool
 	RelationTag names.RelationTag
+	Endpoint    string
 }
 
 // PortRange contains a port range and a relation id. Used as key to
@@ -42,10 +44,10 @@ func validatePortRange(protocol string, fromPort, toPort int) (network.PortRange
 }
 
 func tryOpenPorts(
-	protocol string,
+	endpoint, protocol string,
 	fromPort, toPort int,
 	unitTag names.UnitTag,
-	machinePorts map[network.PortRange]params.RelationUnit,
+	machinePorts map[network.PortRange]params.MachinePortRange,
 	pendingPorts map[PortRange]PortRangeInfo,
 ) error {
 	// TODO(dimitern) Once port ranges are linked to relations in
@@ -65,19 +67,19 @@ func tryOpenPorts(
 
 	rangeInfo, isKnown := pendingPorts[rangeKey]
 	if isKnown {
-		if !rangeInfo.ShouldOpen {
-			// If the same range is already pending to be closed, just
-			// mark is pending to be opened.
-			rangeInfo.ShouldOpen = true
-			pendingPorts[rangeKey] = rangeInfo
-		}
+		// If the same range is already pending to be closed, just
+		// mark is pending to be opened. The last endpoint the range
+		// is opened for wins.
+		rangeInfo.ShouldOpen = true
+		rangeInfo.Endpoint = endpoint
+		pendingPorts[rangeKey] = rangeInfo
 		return nil
 	}
 
 	// Ensure there are no conflicts with existing ports on the
 	// machine.
-	for portRange, relUnit := range machinePorts {
-		relUnitTag, err := names.ParseUnitTag(relUnit.Unit)
+	for portRange, opened := range machinePorts {
+		relUnitTag, err := names.ParseUnitTag(opened.UnitTag)
 		if err != nil {
 			return errors.Annotatef(
 				err,
@@ -87,9 +89,14 @@ func tryOpenPorts(
 		}
 		if newRange.ConflictsWith(portRange) {
 			if portRange == newRange && relUnitTag == unitTag {
-				// The same unit trying to open the same range is just
-				// ignored.
-				return nil
+				if opened.Endpoint == endpoint {
+					// The same unit trying to open the same range
+					// is just ignored.
+					return nil
+				}
+				// Opening the range for another endpoint replaces
+				// the endpoint it was opened for.
+				continue
 			}
 			return errors.Errorf(
 				"cannot open %v (unit %q): conflicts with existing %v (unit %q)",
@@ -109,6 +116,7 @@ func tryOpenPorts(
 
 	rangeInfo = pendingPorts[rangeKey]
 	rangeInfo.ShouldOpen = true
+	rangeInfo.Endpoint = endpoint
 	pendingPorts[rangeKey] = rangeInfo
 	return nil
 }
@@ -117,7 +125,7 @@ func tryClosePorts(
 	protocol string,
 	fromPort, toPort int,
 	unitTag names.UnitTag,
-	machinePorts map[network.PortRange]params.RelationUnit,
+	machinePorts map[network.PortRange]params.MachinePortRange,
 	pendingPorts map[PortRange]PortRangeInfo,
 ) error {
 	// TODO(dimitern) Once port ranges are linked to relations in
@@ -147,12 +155,12 @@ func tryClosePorts(
 
 	// Ensure the range we're trying to close is opened on the
 	// machine.
-	relUnit, found := machinePorts[newRange]
+	opened, found := machinePorts[newRange]
 	if !found {
 		// Trying to close a range which is not open is ignored.
 		return nil
-	} else if relUnit.Unit != unitTag.String() {
-		relUnitTag, err := names.ParseUnitTag(relUnit.Unit)
+	} else if opened.UnitTag != unitTag.String() {
+		relUnitTag, err := names.ParseUnitTag(opened.UnitTag)
 		if err != nil {
 			return errors.Annotatef(
 				err,
@@ -95,8 +95,8 @@ func (s *PortsSuite) TestValidatePortRange(c *gc.C) {
 
 func makeMachinePorts(
 	unitName, proto string, fromPort, toPort int,
-) map[network.PortRange]params.RelationUnit {
-	result := make(map[network.PortRange]params.RelationUnit)
+) map[network.PortRange]params.MachinePortRange {
+	result := make(map[network.PortRange]params.MachinePortRange)
 	portRange := network.PortRange{
 		FromPort: fromPort,
 		ToPort:   toPort,
@@ -108,8 +108,9 @@ func makeMachinePorts(
 	} else {
 		unitTag = unitName
 	}
-	result[portRange] = params.RelationUnit{
-		Unit: unitTag,
+	result[portRange] = params.MachinePortRange{
+		UnitTag:   unitTag,
+		PortRange: params.FromNetworkPortRange(portRange),
 	}
 	return result
 }
@@ -133,11 +134,34 @@ func makePendingPorts(
 	return result
 }
 
+func makeEndpointMachinePorts(
+	unitName, endpoint, proto string, fromPort, toPort int,
+) map[network.PortRange]params.MachinePortRange {
+	result := makeMachinePorts(unitName, proto, fromPort, toPort)
+	for portRange, opened := range result {
+		opened.Endpoint = endpoint
+		result[portRange] = opened
+	}
+	return result
+}
+
+func makeEndpointPendingPorts(
+	endpoint, proto string, fromPort, toPort int,
+) map[context.PortRange]context.PortRangeInfo {
+	result := makePendingPorts(proto, fromPort, toPort, true)
+	for key, info := range result {
+		info.Endpoint = endpoint
+		result[key] = info
+	}
+	return result
+}
+
 type portsTest struct {
 	about         string
 	proto         string
 	ports         []int
-	machinePorts  map[network.PortRange]params.RelationUnit
+	endpoint      string
+	machinePorts  map[network.PortRange]params.MachinePortRange
 	pendingPorts  map[context.PortRange]context.PortRangeInfo
 	expectErr     string
 	expectPending map[context.PortRange]context.PortRangeInfo
@@ -192,6 +216,30 @@ func (s *PortsSuite) TestTryOpenPorts(c *gc.C) {
 		about:         "open a range conflicting with the same unit (ignored)",
 		machinePorts:  makeMachinePorts("u/0", "tcp", 10, 20),
 		expectPending: map[context.PortRange]context.PortRangeInfo{},
+	}, {
+		about:         "open a range for an endpoint",
+		endpoint:      "url",
+		expectPending: makeEndpointPendingPorts("url", "tcp", 10, 20),
+	}, {
+		about:         "open an existing range for another endpoint",
+		endpoint:      "url",
+		machinePorts:  makeMachinePorts("u/0", "tcp", 10, 20),
+		expectPending: makeEndpointPendingPorts("url", "tcp", 10, 20),
+	}, {
+		about:         "open an existing range for the same endpoint (ignored)",
+		endpoint:      "url",
+		machinePorts:  makeEndpointMachinePorts("u/0", "url", "tcp", 10, 20),
+		expectPending: map[context.PortRange]context.PortRangeInfo{},
+	}, {
+		about:         "open a range pending to be opened for another endpoint",
+		endpoint:      "url",
+		pendingPorts:  makePendingPorts("tcp", 10, 20, true),
+		expectPending: makeEndpointPendingPorts("url", "tcp", 10, 20),
+	}, {
+		about:        "try opening a range for an endpoint conflicting with another unit",
+		endpoint:     "url",
+		machinePorts: makeEndpointMachinePorts("u/1", "url", "tcp", 10, 20),
+		expectErr:    `cannot open 10-20/tcp \(unit "u/0"\): conflicts with existing 10-20/tcp \(unit "u/1"\)`,
 	}, {
 		about:        "try opening a range conflicting with another pending range",
 		pendingPorts: makePendingPorts("tcp", 5, 25, true),
@@ -202,6 +250,7 @@ func (s *PortsSuite) TestTryOpenPorts(c *gc.C) {
 
 		test = test.withDefaults("tcp", 10, 20)
 		err := context.TryOpenPorts(
+			test.endpoint,
 			test.proto,
 			test.ports[0],
 			test.ports[1],
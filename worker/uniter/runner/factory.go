@@ -126,6 +126,7 @@ func (f *factory) NewActionRunner(actionId string) (Runner, error) {
 	}
 
 	actionData := context.NewActionData(name, &tag, params)
+	actionData.Timeout = action.ExecutionTimeout()
 	ctx, err := f.contextFactory.ActionContext(actionData)
 	if err != nil {
 		return nil, charmrunner.NewBadActionError(name, err.Error())
@@ -157,8 +157,10 @@ type ContextNetworking interface {
 	PrivateAddress() (string, error)
 
 	// OpenPorts marks the supplied port range for opening when the
-	// executing unit's application is exposed.
-	OpenPorts(protocol string, fromPort, toPort int) error
+	// executing unit's application is exposed. If an endpoint is given,
+	// the range is only accessible from the sources that endpoint is
+	// exposed to.
+	OpenPorts(endpoint, protocol string, fromPort, toPort int) error
 
 	// ClosePorts ensures the supplied port range is closed even when
 	// the executing unit's application is exposed (unless it is opened
@@ -5,6 +5,7 @@ package jujuc
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/juju/cmd"
@@ -13,6 +14,7 @@ import (
 	"github.com/juju/loggo"
 
 	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/core/logfields"
 )
 
 // JujuLogCommandLogger provides a Logger interface for the juju-log command.
@@ -79,7 +81,12 @@ func (c *JujuLogCommand) Run(ctx *cmd.Context) error {
 	if c.formatFlag != "" {
 		fmt.Fprintf(ctx.Stderr, "--format flag deprecated for command %q", c.Info().Name)
 	}
-	logger := c.loggerFactory.GetLogger(fmt.Sprintf("unit.%s.juju-log", c.ctx.UnitName()))
+	unitName := c.ctx.UnitName()
+	logger := c.loggerFactory.GetLogger(fmt.Sprintf("unit.%s.juju-log", unitName))
+	fields := make(logfields.Fields)
+	if unitName != "" {
+		fields[logfields.Unit] = unitName
+	}
 
 	logLevel := loggo.INFO
 	if c.Debug {
@@ -96,6 +103,7 @@ func (c *JujuLogCommand) Run(ctx *cmd.Context) error {
 	prefix := ""
 	if r, err := c.ctx.HookRelation(); err == nil {
 		prefix = r.FakeId() + ": "
+		fields[logfields.RelationID] = strconv.Itoa(r.Id())
 	} else if errors.IsNotImplemented(err) {
 		// if the hook relation is not implemented, then we want to continue
 		// without a FakeId
@@ -103,7 +111,7 @@ func (c *JujuLogCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
-	logger.Logf(logLevel, "%s%s", prefix, c.Message)
+	logger.Logf(logLevel, "%s%s", prefix, logfields.Format(c.Message, fields))
 	return nil
 }
 
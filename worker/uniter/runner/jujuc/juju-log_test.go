@@ -82,10 +82,11 @@ func (s *JujuLogSuite) TestRunWithNoErrorsLogsOnRun(c *gc.C) {
 	messages := []string{"foo", "msg"}
 
 	cmd, context, logger := s.newJujuLogCommandWithMocks(ctrl, "")
-	logger.EXPECT().Logf(loggo.INFO, "%s%s", ": ", strings.Join(messages, " "))
+	logger.EXPECT().Logf(loggo.INFO, "%s%s", ": ", strings.Join(messages, " ")+" [fields: relation-id=3]")
 
 	relation := jujuc.NewMockContextRelation(ctrl)
 	relation.EXPECT().FakeId().Return("")
+	relation.EXPECT().Id().Return(3)
 
 	context.EXPECT().HookRelation().Return(relation, nil)
 	context.EXPECT().UnitName().Return("")
@@ -132,6 +133,24 @@ func (s *JujuLogSuite) TestRunWithErrorIsNotFoundLogsOnRun(c *gc.C) {
 	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
 }
 
+func (s *JujuLogSuite) TestRunLogsUnitField(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	cmd, context, logger := s.newJujuLogCommandWithMocks(ctrl, "mysql/0")
+	logger.EXPECT().Logf(loggo.WARNING, "%s%s", "db:1: ", "foo msg [fields: relation-id=1 unit=mysql/0]")
+
+	relation := jujuc.NewMockContextRelation(ctrl)
+	relation.EXPECT().FakeId().Return("db:1")
+	relation.EXPECT().Id().Return(1)
+
+	context.EXPECT().HookRelation().Return(relation, nil)
+	context.EXPECT().UnitName().Return("mysql/0")
+
+	_, err := cmdtesting.RunCommand(c, cmd, "-l", "WARNING", "foo", "msg")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *JujuLogSuite) TestRunWithErrorDoesNotLogOnRun(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
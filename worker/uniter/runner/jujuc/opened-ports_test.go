@@ -85,10 +85,10 @@ Each list entry has format <port>/<protocol> (e.g. "80/tcp") or
 
 func (s *OpenedPortsSuite) getContextAndOpenPorts(c *gc.C) *Context {
 	hctx := s.GetHookContext(c, -1, "")
-	hctx.OpenPorts("tcp", 80, 80)
-	hctx.OpenPorts("tcp", 10, 20)
-	hctx.OpenPorts("udp", 63, 63)
-	hctx.OpenPorts("udp", 53, 55)
+	hctx.OpenPorts("", "tcp", 80, 80)
+	hctx.OpenPorts("", "tcp", 10, 20)
+	hctx.OpenPorts("", "udp", 63, 63)
+	hctx.OpenPorts("", "udp", 53, 55)
 	return hctx
 }
 
@@ -126,12 +126,14 @@ func parseArguments(args []string) (portRange, error) {
 // portCommand implements the open-port and close-port commands.
 type portCommand struct {
 	cmd.CommandBase
-	info       *cmd.Info
-	action     func(*portCommand) error
-	Protocol   string
-	FromPort   int
-	ToPort     int
-	formatFlag string // deprecated
+	info         *cmd.Info
+	action       func(*portCommand) error
+	withEndpoint bool
+	Protocol     string
+	FromPort     int
+	ToPort       int
+	Endpoint     string
+	formatFlag   string // deprecated
 }
 
 func (c *portCommand) Info() *cmd.Info {
@@ -140,6 +142,9 @@ func (c *portCommand) Info() *cmd.Info {
 
 func (c *portCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.formatFlag, "format", "", "deprecated format flag")
+	if c.withEndpoint {
+		f.StringVar(&c.Endpoint, "endpoint", "", "the endpoint to open the port or range for")
+	}
 }
 
 func (c *portCommand) Init(args []string) error {
@@ -169,14 +174,19 @@ var openPortInfo = &cmd.Info{
 	Name:    "open-port",
 	Args:    portFormat,
 	Purpose: "register a port or range to open",
-	Doc:     "The port range will only be open while the application is exposed.",
+	Doc: `
+The port range will only be open while the application is exposed.
+With --endpoint, the range is only accessible from the spaces and CIDRs
+that the given application endpoint is exposed to. Opening a range that
+is already open for another endpoint replaces that endpoint.`[1:],
 }
 
 func NewOpenPortCommand(ctx Context) (cmd.Command, error) {
 	return &portCommand{
-		info: openPortInfo,
+		info:         openPortInfo,
+		withEndpoint: true,
 		action: func(c *portCommand) error {
-			return ctx.OpenPorts(c.Protocol, c.FromPort, c.ToPort)
+			return ctx.OpenPorts(c.Endpoint, c.Protocol, c.FromPort, c.ToPort)
 		},
 	}, nil
 }
@@ -91,6 +91,26 @@ func (s *PortsSuite) TestOpenClose(c *gc.C) {
 	}
 }
 
+func (s *PortsSuite) TestOpenForEndpoint(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("open-port"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{"--endpoint", "url", "80"})
+	c.Check(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+	s.Stub.CheckCall(c, 0, "OpenPorts", "url", "tcp", 80, 80)
+	hctx.info.CheckPorts(c, makeRanges("80/tcp"))
+}
+
+func (s *PortsSuite) TestCloseForEndpointNotSupported(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("close-port"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = cmdtesting.InitCommand(jujuc.NewJujucCommandWrappedForTest(com), []string{"--endpoint", "url", "80"})
+	c.Assert(err, gc.ErrorMatches, "option provided but not defined: --endpoint")
+}
+
 var badPortsTests = []struct {
 	args []string
 	err  string
@@ -136,6 +156,9 @@ register a port or range to open
 
 Details:
 The port range will only be open while the application is exposed.
+With --endpoint, the range is only accessible from the spaces and CIDRs
+that the given application endpoint is exposed to. Opening a range that
+is already open for another endpoint replaces that endpoint.
 `[1:])
 
 	close, err := jujuc.NewCommand(hctx, cmdString("close-port"))
@@ -78,7 +78,7 @@ func (*RestrictedContext) PublicAddress() (string, error) { return "", ErrRestri
 func (*RestrictedContext) PrivateAddress() (string, error) { return "", ErrRestrictedContext }
 
 // OpenPorts implements hooks.Context.
-func (*RestrictedContext) OpenPorts(protocol string, fromPort, toPort int) error {
+func (*RestrictedContext) OpenPorts(endpoint, protocol string, fromPort, toPort int) error {
 	return ErrRestrictedContext
 }
 
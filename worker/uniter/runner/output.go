@@ -0,0 +1,170 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+const (
+	// outputFlushInterval is how often buffered action output is sent
+	// to the controller.
+	outputFlushInterval = time.Second
+
+	// outputFlushLines is the number of buffered lines of action output
+	// which causes them to be sent to the controller straight away.
+	outputFlushLines = 100
+
+	// outputMaxLineBytes is the length at which a line of action
+	// output is cut short before it is sent to the controller.
+	outputMaxLineBytes = 4096
+
+	// outputMaxBatchBytes is the most action output sent to the
+	// controller in a single call.
+	outputMaxBatchBytes = 64 * 1024
+)
+
+// actionOutputLogger is implemented by contexts which can record
+// the output of a running action with the controller.
+type actionOutputLogger interface {
+	LogActionOutput(stream string, lines []string) error
+}
+
+// actionOutputStreamer implements MessageReceiver and sends lines
+// written by a running action to one of its output streams to the
+// controller as they happen, so they can be watched by the user.
+// Lines are buffered and sent in batches so that the hook logger
+// feeding them is never blocked on the controller.
+type actionOutputStreamer struct {
+	stream string
+	logger actionOutputLogger
+	clock  clock.Clock
+
+	mu      sync.Mutex
+	lines   []string
+	partial string
+
+	// failed is only accessed by the loop goroutine.
+	failed bool
+
+	flush   chan struct{}
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+func newActionOutputStreamer(stream string, logger actionOutputLogger, clock clock.Clock) *actionOutputStreamer {
+	s := &actionOutputStreamer{
+		stream:  stream,
+		logger:  logger,
+		clock:   clock,
+		flush:   make(chan struct{}, 1),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go s.loop()
+	return s
+}
+
+// Messagef implements MessageReceiver.
+func (s *actionOutputStreamer) Messagef(isPrefix bool, message string, args ...interface{}) {
+	formattedMessage := message
+	if len(args) > 0 {
+		formattedMessage = fmt.Sprintf(message, args...)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if isPrefix {
+		s.partial = truncateOutputLine(s.partial + formattedMessage)
+		return
+	}
+	s.lines = append(s.lines, truncateOutputLine(s.partial+formattedMessage))
+	s.partial = ""
+	if len(s.lines) >= outputFlushLines {
+		select {
+		case s.flush <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Stop sends any remaining output to the controller and stops
+// the streamer.
+func (s *actionOutputStreamer) Stop() {
+	select {
+	case <-s.done:
+	default:
+		close(s.done)
+	}
+	<-s.stopped
+}
+
+func (s *actionOutputStreamer) loop() {
+	defer close(s.stopped)
+	for {
+		select {
+		case <-s.done:
+			s.send(true)
+			return
+		case <-s.flush:
+		case <-s.clock.After(outputFlushInterval):
+		}
+		s.send(false)
+	}
+}
+
+// send sends the buffered lines to the controller, in batches of at
+// most outputMaxBatchBytes, including any incomplete line if final is
+// true. Once sending fails, including when the controller refuses
+// more output for the action, further output is discarded; the
+// action's complete output is still recorded in its results when it
+// finishes.
+func (s *actionOutputStreamer) send(final bool) {
+	s.mu.Lock()
+	lines := s.lines
+	if final && s.partial != "" {
+		lines = append(lines, s.partial)
+		s.partial = ""
+	}
+	s.lines = nil
+	s.mu.Unlock()
+
+	for len(lines) > 0 && !s.failed {
+		n, size := 0, 0
+		for ; n < len(lines); n++ {
+			size += len(lines[n])
+			if n > 0 && size > outputMaxBatchBytes {
+				break
+			}
+		}
+		batch := lines[:n]
+		lines = lines[n:]
+		if err := s.logger.LogActionOutput(s.stream, batch); err != nil {
+			s.failed = true
+			switch {
+			case errors.IsNotImplemented(err):
+				logger.Debugf("controller cannot record action %s output: %v", s.stream, err)
+			case params.IsCodeForbidden(err):
+				logger.Debugf("controller refused further action %s output: %v", s.stream, err)
+			default:
+				logger.Warningf("cannot send action %s output to controller: %v", s.stream, err)
+			}
+		}
+	}
+}
+
+// truncateOutputLine cuts line short at outputMaxLineBytes.
+func truncateOutputLine(line string) string {
+	if len(line) > outputMaxLineBytes {
+		return line[:outputMaxLineBytes]
+	}
+	return line
+}
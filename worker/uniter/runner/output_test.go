@@ -0,0 +1,144 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner_test
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner"
+)
+
+type ActionOutputStreamerSuite struct{}
+
+var _ = gc.Suite(&ActionOutputStreamerSuite{})
+
+type outputLogger struct {
+	mu     sync.Mutex
+	calls  [][]string
+	err    error
+	logged chan struct{}
+}
+
+func newOutputLogger() *outputLogger {
+	return &outputLogger{logged: make(chan struct{}, 10)}
+}
+
+func (l *outputLogger) LogActionOutput(stream string, lines []string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.calls = append(l.calls, append([]string{stream}, lines...))
+	l.logged <- struct{}{}
+	return l.err
+}
+
+func (l *outputLogger) Calls() [][]string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.calls
+}
+
+func (s *ActionOutputStreamerSuite) TestFlushesPeriodically(c *gc.C) {
+	clock := testclock.NewClock(time.Time{})
+	logger := newOutputLogger()
+	streamer := runner.NewActionOutputStreamer("stdout", logger, clock)
+	defer streamer.Stop()
+
+	streamer.Messagef(false, "%s", "hello")
+	streamer.Messagef(true, "%s", "wor")
+	streamer.Messagef(false, "%s", "ld")
+	c.Assert(clock.WaitAdvance(time.Second, testing.LongWait, 1), jc.ErrorIsNil)
+	select {
+	case <-logger.logged:
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for output to be logged")
+	}
+	c.Assert(logger.Calls(), jc.DeepEquals, [][]string{{"stdout", "hello", "world"}})
+}
+
+func (s *ActionOutputStreamerSuite) TestStopFlushesRemainingOutput(c *gc.C) {
+	clock := testclock.NewClock(time.Time{})
+	logger := newOutputLogger()
+	streamer := runner.NewActionOutputStreamer("stderr", logger, clock)
+
+	streamer.Messagef(false, "%s", "hello")
+	streamer.Messagef(true, "%s", "partial")
+	streamer.Stop()
+	c.Assert(logger.Calls(), jc.DeepEquals, [][]string{{"stderr", "hello", "partial"}})
+}
+
+func (s *ActionOutputStreamerSuite) TestStopsSendingAfterError(c *gc.C) {
+	clock := testclock.NewClock(time.Time{})
+	logger := newOutputLogger()
+	logger.err = errors.NotImplementedf("LogActionOutput")
+	streamer := runner.NewActionOutputStreamer("stdout", logger, clock)
+
+	streamer.Messagef(false, "%s", "hello")
+	c.Assert(clock.WaitAdvance(time.Second, testing.LongWait, 1), jc.ErrorIsNil)
+	select {
+	case <-logger.logged:
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for output to be logged")
+	}
+	streamer.Messagef(false, "%s", "world")
+	streamer.Stop()
+	c.Assert(logger.Calls(), jc.DeepEquals, [][]string{{"stdout", "hello"}})
+}
+
+func (s *ActionOutputStreamerSuite) TestStopsSendingWhenRefused(c *gc.C) {
+	clock := testclock.NewClock(time.Time{})
+	logger := newOutputLogger()
+	logger.err = &params.Error{Code: params.CodeForbidden, Message: "more than 10000 lines of output"}
+	streamer := runner.NewActionOutputStreamer("stdout", logger, clock)
+
+	streamer.Messagef(false, "%s", "hello")
+	c.Assert(clock.WaitAdvance(time.Second, testing.LongWait, 1), jc.ErrorIsNil)
+	select {
+	case <-logger.logged:
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for output to be logged")
+	}
+	streamer.Messagef(false, "%s", "world")
+	streamer.Stop()
+	c.Assert(logger.Calls(), jc.DeepEquals, [][]string{{"stdout", "hello"}})
+}
+
+func (s *ActionOutputStreamerSuite) TestTruncatesLongLines(c *gc.C) {
+	clock := testclock.NewClock(time.Time{})
+	logger := newOutputLogger()
+	streamer := runner.NewActionOutputStreamer("stdout", logger, clock)
+
+	long := strings.Repeat("x", 5000)
+	streamer.Messagef(false, "%s", long)
+	streamer.Messagef(true, "%s", long)
+	streamer.Messagef(true, "%s", long)
+	streamer.Stop()
+	c.Assert(logger.Calls(), jc.DeepEquals, [][]string{{"stdout", long[:4096], long[:4096]}})
+}
+
+func (s *ActionOutputStreamerSuite) TestSendsInBatches(c *gc.C) {
+	clock := testclock.NewClock(time.Time{})
+	logger := newOutputLogger()
+	streamer := runner.NewActionOutputStreamer("stdout", logger, clock)
+
+	// 20 lines of 4096 bytes are sent as a batch of 16 lines,
+	// which fills 64KiB, and a batch of the remaining 4.
+	line := strings.Repeat("x", 4096)
+	for i := 0; i < 20; i++ {
+		streamer.Messagef(false, "%s", line)
+	}
+	streamer.Stop()
+	calls := logger.Calls()
+	c.Assert(calls, gc.HasLen, 2)
+	c.Assert(calls[0], gc.HasLen, 17)
+	c.Assert(calls[1], gc.HasLen, 5)
+}
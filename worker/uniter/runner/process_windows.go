@@ -0,0 +1,21 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build windows
+
+package runner
+
+import (
+	"os"
+	"os/exec"
+)
+
+// setProcessGroup does nothing on Windows, which has no process groups
+// that can be killed as one.
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessGroup kills the given process. Any processes it started
+// are left running.
+func killProcessGroup(p *os.Process) error {
+	return p.Kill()
+}
@@ -11,6 +11,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"syscall"
@@ -26,6 +27,7 @@ import (
 	utilexec "github.com/juju/utils/exec"
 
 	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/core/logfields"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/runner/context"
@@ -225,7 +227,12 @@ func (runner *runner) runJujuRunAction() (err error) {
 			rMode = runOnRemote
 		}
 	}
-	results, err := runner.runCommandsWithTimeout(command, time.Duration(timeout), clock.WallClock, rMode)
+	// The action's own execution timeout also applies to the command.
+	commandTimeout := time.Duration(timeout)
+	if actionTimeout := runner.executionTimeout(); actionTimeout > 0 && (commandTimeout == 0 || actionTimeout < commandTimeout) {
+		commandTimeout = actionTimeout
+	}
+	results, err := runner.runCommandsWithTimeout(command, commandTimeout, clock.WallClock, rMode)
 	if results != nil {
 		if err := runner.updateActionResults(results); err != nil {
 			return runner.context.Flush("juju-run", err)
@@ -353,7 +360,7 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string, r
 // loggerAdaptor implements MessageReceiver and
 // sends messages to a logger.
 type loggerAdaptor struct {
-	loggo.Logger
+	logfields.Logger
 }
 
 func (l *loggerAdaptor) Messagef(isPrefix bool, message string, args ...interface{}) {
@@ -396,6 +403,12 @@ func (runner *runner) runCharmHookOnRemote(hookName string, env []string, charmL
 	hook := filepath.Join(charmDir, filepath.Join(charmLocation, hookName))
 
 	var cancel chan struct{}
+	timeout := runner.executionTimeout()
+	if timeout != 0 {
+		cancel = make(chan struct{})
+		timer := clock.WallClock.AfterFunc(timeout, func() { close(cancel) })
+		defer timer.Stop()
+	}
 	outReader, outWriter, err := os.Pipe()
 	if err != nil {
 		return errors.Errorf("cannot make stdout logging pipe: %v", err)
@@ -403,10 +416,15 @@ func (runner *runner) runCharmHookOnRemote(hookName string, env []string, charmL
 	defer outWriter.Close()
 
 	actionOut := &bufferAdaptor{ReadWriter: outWriter}
-	hookOutLogger := charmrunner.NewHookLogger(outReader,
+	outReceivers := []charmrunner.MessageReceiver{
 		&loggerAdaptor{runner.getLogger(hookName)},
 		actionOut,
-	)
+	}
+	if streamer := runner.newActionOutputStreamer(actions.StdoutStream); streamer != nil {
+		defer streamer.Stop()
+		outReceivers = append(outReceivers, streamer)
+	}
+	hookOutLogger := charmrunner.NewHookLogger(outReader, outReceivers...)
 	defer hookOutLogger.Stop()
 	go hookOutLogger.Run()
 
@@ -424,10 +442,15 @@ func (runner *runner) runCharmHookOnRemote(hookName string, env []string, charmL
 		defer errWriter.Close()
 
 		actionErr = &bufferAdaptor{ReadWriter: errWriter}
-		hookErrLogger = charmrunner.NewHookLogger(errReader,
+		errReceivers := []charmrunner.MessageReceiver{
 			&loggerAdaptor{runner.getLogger(hookName)},
 			actionErr,
-		)
+		}
+		if streamer := runner.newActionOutputStreamer(actions.StderrStream); streamer != nil {
+			defer streamer.Stop()
+			errReceivers = append(errReceivers, streamer)
+		}
+		hookErrLogger = charmrunner.NewHookLogger(errReader, errReceivers...)
 		defer hookErrLogger.Stop()
 		go hookErrLogger.Run()
 	}
@@ -455,6 +478,13 @@ func (runner *runner) runCharmHookOnRemote(hookName string, env []string, charmL
 			return errors.Trace(err)
 		}
 	}
+	if err != nil && cancel != nil {
+		select {
+		case <-cancel:
+			return errors.Errorf("action timed out after %v", timeout)
+		default:
+		}
+	}
 	return errors.Trace(err)
 }
 
@@ -477,10 +507,15 @@ func (runner *runner) runCharmHookOnLocal(hookName string, env []string, charmLo
 	ps.Stdout = outWriter
 	ps.Stderr = outWriter
 	actionOut := &bufferAdaptor{ReadWriter: outWriter}
-	hookOutLogger := charmrunner.NewHookLogger(outReader,
+	outReceivers := []charmrunner.MessageReceiver{
 		&loggerAdaptor{runner.getLogger(hookName)},
 		actionOut,
-	)
+	}
+	if streamer := runner.newActionOutputStreamer(actions.StdoutStream); streamer != nil {
+		defer streamer.Stop()
+		outReceivers = append(outReceivers, streamer)
+	}
+	hookOutLogger := charmrunner.NewHookLogger(outReader, outReceivers...)
 	go hookOutLogger.Run()
 	defer hookOutLogger.Stop()
 
@@ -500,21 +535,32 @@ func (runner *runner) runCharmHookOnLocal(hookName string, env []string, charmLo
 		ps.Stderr = errWriter
 		errBuf := &bufferAdaptor{ReadWriter: errWriter}
 		actionErr = errBuf
-		hookErrLogger = charmrunner.NewHookLogger(errReader,
+		errReceivers := []charmrunner.MessageReceiver{
 			&loggerAdaptor{runner.getLogger(hookName)},
 			errBuf,
-		)
+		}
+		if streamer := runner.newActionOutputStreamer(actions.StderrStream); streamer != nil {
+			defer streamer.Stop()
+			errReceivers = append(errReceivers, streamer)
+		}
+		hookErrLogger = charmrunner.NewHookLogger(errReader, errReceivers...)
 		defer hookErrLogger.Stop()
 		go hookErrLogger.Run()
 	}
 
+	timeout := runner.executionTimeout()
+	if timeout != 0 {
+		// Run the action in its own process group so that anything
+		// it starts is killed along with it if it times out.
+		setProcessGroup(ps)
+	}
 	err = ps.Start()
 	var exitErr error
 	if err == nil {
 		// Record the *os.Process of the hook
 		runner.context.SetProcess(hookProcess{ps.Process})
-		// Block until execution finishes
-		exitErr = ps.Wait()
+		// Block until execution finishes, or the action times out.
+		exitErr = waitWithTimeout(ps, timeout, clock.WallClock)
 	} else {
 		exitErr = err
 	}
@@ -555,6 +601,56 @@ func (runner *runner) runCharmHookOnLocal(hookName string, env []string, charmLo
 	return errors.Trace(exitErr)
 }
 
+// newActionOutputStreamer returns a streamer sending the output written
+// to the given stream by the action being run to the controller, or nil
+// if a hook is being run or the context cannot record action output.
+func (runner *runner) newActionOutputStreamer(stream string) *actionOutputStreamer {
+	if _, err := runner.context.ActionData(); err != nil {
+		return nil
+	}
+	outputLogger, ok := runner.context.(actionOutputLogger)
+	if !ok {
+		return nil
+	}
+	return newActionOutputStreamer(stream, outputLogger, clock.WallClock)
+}
+
+// executionTimeout returns how long the action being run may run for,
+// or zero if there is no limit or a hook is being run.
+func (runner *runner) executionTimeout() time.Duration {
+	actionData, err := runner.context.ActionData()
+	if err != nil {
+		return 0
+	}
+	return actionData.Timeout
+}
+
+// waitWithTimeout waits for the started command to finish, killing its
+// process group if it is still running once the timeout has passed.
+// A zero timeout means wait indefinitely.
+func waitWithTimeout(ps *exec.Cmd, timeout time.Duration, clock clock.Clock) error {
+	if timeout == 0 {
+		return ps.Wait()
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- ps.Wait()
+	}()
+	timer := clock.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		return err
+	case <-timer.Chan():
+		logger.Infof("killing process %v after action timeout of %v", ps.Process.Pid, timeout)
+		if err := killProcessGroup(ps.Process); err != nil {
+			logger.Warningf("cannot kill process %v: %v", ps.Process.Pid, err)
+		}
+		<-done
+		return errors.Errorf("action timed out after %v", timeout)
+	}
+}
+
 func (runner *runner) startJujucServer(token string, rMode runMode) (*jujuc.Server, error) {
 	// Prepare server.
 	getCmd := func(ctxId, cmdName string) (cmd.Command, error) {
@@ -574,8 +670,24 @@ func (runner *runner) startJujucServer(token string, rMode runMode) (*jujuc.Serv
 	return srv, nil
 }
 
-func (runner *runner) getLogger(hookName string) loggo.Logger {
-	return loggo.GetLogger(fmt.Sprintf("unit.%s.%s", runner.context.UnitName(), hookName))
+// getLogger returns a logger for the output of the named hook or
+// action, which attaches fields identifying what is being run.
+func (runner *runner) getLogger(hookName string) logfields.Logger {
+	unitName := runner.context.UnitName()
+	fields := logfields.Fields{logfields.Unit: unitName}
+	if data, err := runner.context.ActionData(); err == nil {
+		fields[logfields.ActionID] = data.Tag.Id()
+	} else {
+		fields[logfields.Hook] = hookName
+		if r, err := runner.context.HookRelation(); err == nil {
+			fields[logfields.RelationID] = strconv.Itoa(r.Id())
+		}
+		if remoteUnit, err := runner.context.RemoteUnitName(); err == nil && remoteUnit != "" {
+			fields[logfields.RemoteUnit] = remoteUnit
+		}
+	}
+	logger := loggo.GetLogger(fmt.Sprintf("unit.%s.%s", unitName, hookName))
+	return logfields.NewLogger(logger, fields)
 }
 
 type hookProcess struct {
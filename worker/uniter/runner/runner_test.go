@@ -366,6 +366,26 @@ func (s *RunMockContextSuite) TestRunActionFlushFailure(c *gc.C) {
 	s.assertRecordedPid(c, ctx.expectPid)
 }
 
+func (s *RunMockContextSuite) TestRunActionExecutionTimeout(c *gc.C) {
+	expectErr := errors.New("pew pew pew")
+	ctx := &MockContext{
+		flushResult:   expectErr,
+		actionData:    &context.ActionData{Timeout: 100 * time.Millisecond},
+		actionResults: map[string]interface{}{},
+	}
+	makeCharm(c, hookSpec{
+		dir:   "actions",
+		name:  hookName,
+		perm:  0700,
+		sleep: 10,
+	}, s.paths.GetCharmDir())
+	actualErr := runner.NewRunner(ctx, s.paths, nil).RunAction("something-happened")
+	c.Assert(actualErr, gc.Equals, expectErr)
+	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "action timed out after 100ms")
+	c.Assert(ctx.actionResults["Code"], gc.Equals, "-1")
+}
+
 func (s *RunMockContextSuite) TestRunActionParamsFailure(c *gc.C) {
 	expectErr := errors.New("stork")
 	ctx := &MockContext{
@@ -0,0 +1,51 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !windows
+
+package runner_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner"
+	"github.com/juju/juju/worker/uniter/runner/context"
+)
+
+func (s *RunMockContextSuite) TestRunActionExecutionTimeoutKillsChildren(c *gc.C) {
+	ctx := &MockContext{
+		actionData:    &context.ActionData{Timeout: 100 * time.Millisecond},
+		actionResults: map[string]interface{}{},
+	}
+	dir := filepath.Join(s.paths.GetCharmDir(), "actions")
+	err := os.Mkdir(dir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	script := "#!/bin/bash\nsleep 10 &\necho $! > child-pid\nsleep 10\n"
+	err = ioutil.WriteFile(filepath.Join(dir, hookName), []byte(script), 0700)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = runner.NewRunner(ctx, s.paths, nil).RunAction("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "action timed out after 100ms")
+
+	content, err := ioutil.ReadFile(filepath.Join(s.paths.GetCharmDir(), "child-pid"))
+	c.Assert(err, jc.ErrorIsNil)
+	pid, err := strconv.Atoi(strings.TrimSpace(string(content)))
+	c.Assert(err, jc.ErrorIsNil)
+	for a := testing.LongAttempt.Start(); a.Next(); {
+		if syscall.Kill(pid, 0) == syscall.ESRCH {
+			return
+		}
+	}
+	c.Fatalf("child process %d still running", pid)
+}
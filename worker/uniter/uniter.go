@@ -13,6 +13,7 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/utils"
 	"github.com/juju/utils/exec"
+	"github.com/prometheus/client_golang/prometheus"
 	corecharm "gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/charm.v6/hooks"
 	"gopkg.in/juju/names.v3"
@@ -126,6 +127,12 @@ type Uniter struct {
 	// downloader is the downloader that should be used to get the charm
 	// archive.
 	downloader charm.Downloader
+
+	// metrics, if set, records the hooks and actions run by the
+	// uniter, and is registered with prometheusRegisterer while the
+	// uniter is running.
+	metrics              *Collector
+	prometheusRegisterer prometheus.Registerer
 }
 
 // UniterParams hold all the necessary parameters for a new Uniter.
@@ -149,6 +156,9 @@ type UniterParams struct {
 	RunningStatusChannel    watcher.NotifyChannel
 	RunningStatusFunc       remotestate.RunningStatusFunc
 	SocketConfig            *SocketConfig
+	// PrometheusRegisterer, if set, is used to register the uniter's
+	// metrics collector.
+	PrometheusRegisterer prometheus.Registerer
 	// TODO (mattyw, wallyworld, fwereade) Having the observer here make this approach a bit more legitimate, but it isn't.
 	// the observer is only a stop gap to be used in tests. A better approach would be to have the uniter tests start hooks
 	// that write to files, and have the tests watch the output to know that hooks have finished.
@@ -209,6 +219,12 @@ func newUniter(uniterParams *UniterParams) func() (worker.Worker, error) {
 		runningStatusChannel:    uniterParams.RunningStatusChannel,
 		runningStatusFunc:       uniterParams.RunningStatusFunc,
 		runListener:             uniterParams.RunListener,
+		prometheusRegisterer:    uniterParams.PrometheusRegisterer,
+	}
+	if u.prometheusRegisterer != nil {
+		u.metrics = NewMetricsCollector(uniterParams.UnitTag.Id(), u.clock, func() int {
+			return len(u.relations.GetInfo())
+		})
 	}
 	startFunc := func() (worker.Worker, error) {
 		plan := catacomb.Plan{
@@ -231,6 +247,16 @@ func newUniter(uniterParams *UniterParams) func() (worker.Worker, error) {
 }
 
 func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
+	var loopMetrics resolver.LoopMetrics
+	if u.metrics != nil {
+		// The collector is registered before init, so that the
+		// operation factory records with the collector in use.
+		var unregister func()
+		u.metrics, unregister = RegisterMetricsCollector(u.prometheusRegisterer, u.metrics)
+		defer unregister()
+		loopMetrics = u.metrics
+	}
+
 	if err := u.init(unitTag); err != nil {
 		switch cause := errors.Cause(err); cause {
 		case resolver.ErrLoopAborted:
@@ -406,6 +432,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 				Abort:         u.catacomb.Dying(),
 				OnIdle:        onIdle,
 				CharmDirGuard: u.charmDirGuard,
+				Metrics:       loopMetrics,
 			}, &localState)
 
 			err = u.translateResolverErr(err)
@@ -606,13 +633,17 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	u.operationFactory = operation.NewFactory(operation.FactoryParams{
+	factoryParams := operation.FactoryParams{
 		Deployer:       deployer,
 		RunnerFactory:  runnerFactory,
 		Callbacks:      &operationCallbacks{u},
 		Abort:          u.catacomb.Dying(),
 		MetricSpoolDir: u.paths.GetMetricsSpoolDir(),
-	})
+	}
+	if u.metrics != nil {
+		factoryParams.Metrics = u.metrics
+	}
+	u.operationFactory = operation.NewFactory(factoryParams)
 
 	charmURL, err := u.getApplicationCharmURL()
 	if err != nil {